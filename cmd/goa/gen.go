@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"go/build"
@@ -29,6 +30,26 @@ type Generator struct {
 	// Output is the absolute path to the output directory.
 	Output string
 
+	// APIVersion is the API version used to filter the generated methods
+	// if any.
+	APIVersion string
+
+	// OpenAPIPerService indicates whether the OpenAPI specification of each
+	// HTTP service is generated in addition to the API specification.
+	OpenAPIPerService bool
+
+	// OpenAPIHiddenFeatures lists the feature flags whose gated methods
+	// are omitted from the OpenAPI specifications.
+	OpenAPIHiddenFeatures []string
+
+	// EndpointsCheck indicates whether the file asserting the signatures
+	// of the service methods and endpoints is generated.
+	EndpointsCheck bool
+
+	// Mocks indicates whether the service mocks and test clients are
+	// generated.
+	Mocks bool
+
 	// DesignVersion is the major component of the Goa version used by the design DSL.
 	// DesignVersion is either 2 or 3.
 	DesignVersion int
@@ -135,6 +156,7 @@ func (g *Generator) Write(debug bool) error {
 			codegen.SimpleImport("goa.design/goa/" + ver + "codegen"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "codegen/generator"),
 			codegen.SimpleImport("goa.design/goa/" + ver + "eval"),
+			codegen.SimpleImport("goa.design/goa/" + ver + "expr"),
 			codegen.NewImport("goa", "goa.design/goa/"+ver+"pkg"),
 			codegen.NewImport("_", g.DesignPath),
 		}
@@ -211,11 +233,30 @@ func (g *Generator) Run() ([]string, error) {
 	}
 
 	args := []string{"--version=" + strconv.Itoa(g.DesignVersion), "--output=" + g.Output, "--cmd=" + cmdl}
+	if g.APIVersion != "" {
+		args = append(args, "--api-version="+g.APIVersion)
+	}
+	if g.OpenAPIPerService {
+		args = append(args, "--openapi-per-service")
+	}
+	if g.EndpointsCheck {
+		args = append(args, "--endpoints-check")
+	}
+	if g.Mocks {
+		args = append(args, "--mocks")
+	}
+	if len(g.OpenAPIHiddenFeatures) > 0 {
+		args = append(args, "--openapi-hide-features="+strings.Join(g.OpenAPIHiddenFeatures, ","))
+	}
 	cmd := exec.Command(filepath.Join(g.tmpDir, g.bin), args...)
-	out, err := cmd.CombinedOutput()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("%s\n%s", err, string(out))
+		return nil, fmt.Errorf("%s\n%s%s", err, stderr.String(), string(out))
 	}
+	// Forward the warnings written by the generator.
+	os.Stderr.Write(stderr.Bytes())
 	res := strings.Split(string(out), "\n")
 	for (len(res) > 0) && (res[len(res)-1] == "") {
 		res = res[:len(res)-1]
@@ -255,8 +296,12 @@ func (g *Generator) runGoCmd(args ...string) error {
 }
 
 // cleanupDirs returns the paths of the subdirectories under gendir to delete
-// before generating code.
+// before generating code. The snapshot command deletes the previous snapshot
+// file instead.
 func cleanupDirs(cmd, output string) []string {
+	if cmd == "snapshot" {
+		return []string{filepath.Join(output, "design.json")}
+	}
 	if cmd == "gen" {
 		gendirPath := filepath.Join(output, codegen.Gendir)
 		gendir, err := os.Open(gendirPath)
@@ -285,6 +330,11 @@ const mainT = `func main() {
 		out     = flag.String("output", "", "")
 		version = flag.String("version", "", "")
 		cmdl    = flag.String("cmd", "", "")
+		apiver  = flag.String("api-version", "", "")
+		perSvc  = flag.Bool("openapi-per-service", false, "")
+		check   = flag.Bool("endpoints-check", false, "")
+		mocks   = flag.Bool("mocks", false, "")
+		hidden  = flag.String("openapi-hide-features", "", "")
 		ver int
 	)
 	{
@@ -321,8 +371,23 @@ const mainT = `func main() {
 {{- end }}
 {{- if gt .DesignVersion 2 }}
 	codegen.DesignVersion = ver
-{{- end }}
+	if *apiver != "" {
+		if err := expr.Root.FilterVersion(*apiver); err != nil {
+			fail(err.Error())
+		}
+	}
+	opts := generator.Options{
+		OpenAPIPerService: *perSvc,
+		EndpointsCheck:    *check,
+		Mocks:             *mocks,
+	}
+	if *hidden != "" {
+		opts.OpenAPIHiddenFeatures = strings.Split(*hidden, ",")
+	}
+	outputs, err := generator.GenerateWithOptions(*out, {{ printf "%q" .Command }}, opts)
+{{- else }}
 	outputs, err := generator.Generate(*out, {{ printf "%q" .Command }})
+{{- end }}
 	if err != nil {
 		fail(err.Error())
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/build"
 	"os"
@@ -8,9 +9,33 @@ import (
 
 	"flag"
 
+	"goa.design/goa/v3/diff"
+	"goa.design/goa/v3/expr"
 	goa "goa.design/goa/v3/pkg"
 )
 
+// genOptions contains the command line options of the gen, example and
+// snapshot commands.
+type genOptions struct {
+	// Output is the output directory.
+	Output string
+	// APIVersion is the API version of the generated methods.
+	APIVersion string
+	// OpenAPIPerService generates the OpenAPI specification of each
+	// service.
+	OpenAPIPerService bool
+	// OpenAPIHiddenFeatures lists the features whose gated methods are
+	// hidden from the OpenAPI specifications.
+	OpenAPIHiddenFeatures []string
+	// EndpointsCheck generates compile-time checks of the endpoint
+	// signatures.
+	EndpointsCheck bool
+	// Mocks generates the service mocks and test clients.
+	Mocks bool
+	// Debug keeps the generator source and prints debug information.
+	Debug bool
+}
+
 func main() {
 	var (
 		cmd    string
@@ -26,7 +51,14 @@ func main() {
 		case "version":
 			fmt.Println("Goa version " + goa.Version())
 			os.Exit(0)
-		case "gen", "example":
+		case "diff":
+			if len(os.Args) != 4 {
+				usage()
+				return
+			}
+			compare(os.Args[2], os.Args[3])
+			return
+		case "gen", "example", "snapshot":
 			if len(os.Args) == 2 {
 				usage()
 			}
@@ -38,37 +70,43 @@ func main() {
 		}
 	}
 
-	var (
-		output = "."
-		debug  bool
-	)
+	opts := genOptions{Output: "."}
 	if len(os.Args) > offset+1 {
 		var (
-			fset = flag.NewFlagSet("default", flag.ExitOnError)
-			o    = fset.String("o", "", "output `directory`")
-			out  = fset.String("output", output, "output `directory`")
+			fset   = flag.NewFlagSet("default", flag.ExitOnError)
+			o      = fset.String("o", "", "output `directory`")
+			out    = fset.String("output", opts.Output, "output `directory`")
+			hidden = fset.String("openapi-hide-features", "", "Comma separated `features` whose gated methods are hidden from the OpenAPI specifications")
 		)
-		fset.BoolVar(&debug, "debug", false, "Print debug information")
+		fset.StringVar(&opts.APIVersion, "api-version", "", "API `version` of the generated methods")
+		fset.BoolVar(&opts.OpenAPIPerService, "openapi-per-service", false, "Generate the OpenAPI specification of each service")
+		fset.BoolVar(&opts.EndpointsCheck, "endpoints-check", false, "Generate compile-time checks of the endpoint signatures")
+		fset.BoolVar(&opts.Mocks, "mocks", false, "Generate the service mocks and test clients")
+		fset.BoolVar(&opts.Debug, "debug", false, "Print debug information")
 
 		fset.Usage = usage
 		fset.Parse(os.Args[offset+1:])
 
-		output = *o
-		if output == "" {
-			output = *out
+		opts.Output = *o
+		if opts.Output == "" {
+			opts.Output = *out
+		}
+		if *hidden != "" {
+			opts.OpenAPIHiddenFeatures = strings.Split(*hidden, ",")
 		}
 	}
 
-	gen(cmd, path, output, debug)
+	gen(cmd, path, opts)
 }
 
 // help with tests
 var (
-	usage = help
-	gen   = generate
+	usage   = help
+	gen     = generate
+	compare = compareSnapshots
 )
 
-func generate(cmd, path, output string, debug bool) {
+func generate(cmd, path string, opts genOptions) {
 	var (
 		files []string
 		err   error
@@ -79,12 +117,23 @@ func generate(cmd, path, output string, debug bool) {
 		goto fail
 	}
 
-	tmp = NewGenerator(cmd, path, output)
-	if !debug {
+	if opts.APIVersion != "" {
+		if err = expr.ValidateVersion(opts.APIVersion); err != nil {
+			goto fail
+		}
+	}
+
+	tmp = NewGenerator(cmd, path, opts.Output)
+	tmp.APIVersion = opts.APIVersion
+	tmp.OpenAPIPerService = opts.OpenAPIPerService
+	tmp.OpenAPIHiddenFeatures = opts.OpenAPIHiddenFeatures
+	tmp.EndpointsCheck = opts.EndpointsCheck
+	tmp.Mocks = opts.Mocks
+	if !opts.Debug {
 		defer tmp.Remove()
 	}
 
-	if err = tmp.Write(debug); err != nil {
+	if err = tmp.Write(opts.Debug); err != nil {
 		goto fail
 	}
 
@@ -100,19 +149,66 @@ func generate(cmd, path, output string, debug bool) {
 	return
 fail:
 	fmt.Fprintln(os.Stderr, err.Error())
-	if !debug && tmp != nil {
+	if !opts.Debug && tmp != nil {
 		tmp.Remove()
 	}
 	os.Exit(1)
 }
 
+// compareSnapshots prints the JSON report of the changes between the design
+// snapshots stored in the files at the given paths. It exits with status 1 if
+// any of the changes is breaking.
+func compareSnapshots(oldPath, newPath string) {
+	var (
+		old, cur *diff.Snapshot
+		report   *diff.Report
+		b        []byte
+		err      error
+	)
+
+	if old, err = readSnapshot(oldPath); err != nil {
+		goto fail
+	}
+	if cur, err = readSnapshot(newPath); err != nil {
+		goto fail
+	}
+
+	report = diff.Compare(old, cur)
+	if b, err = json.MarshalIndent(report, "", "  "); err != nil {
+		goto fail
+	}
+	fmt.Println(string(b))
+	if report.Breaking {
+		os.Exit(1)
+	}
+	return
+fail:
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(2)
+}
+
+// readSnapshot reads the design snapshot stored in the file at the given path.
+func readSnapshot(path string) (*diff.Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s diff.Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return &s, nil
+}
+
 func help() {
 	fmt.Fprint(os.Stderr, `goa is the code generation tool for the Goa framework.
 Learn more at https://goa.design.
 
 Usage:
-  goa gen PACKAGE [--output DIRECTORY] [--debug]
+  goa gen PACKAGE [--output DIRECTORY] [--api-version VERSION] [--openapi-per-service] [--openapi-hide-features FEATURES] [--endpoints-check] [--mocks] [--debug]
   goa example PACKAGE [--output DIRECTORY] [--debug]
+  goa snapshot PACKAGE [--output DIRECTORY] [--debug]
+  goa diff OLD NEW
   goa version
 
 Commands:
@@ -120,6 +216,11 @@ Commands:
         Generate service interfaces, endpoints, transport code and OpenAPI spec.
   example
         Generate example server and client tool.
+  snapshot
+        Generate the design.json snapshot of the design used by diff.
+  diff
+        Print the JSON report of the changes between the OLD and NEW design
+        snapshots, exit with status 1 if any of the changes is breaking.
   version
         Print version information.
 
@@ -127,10 +228,35 @@ Args:
   PACKAGE
         Go import path to design package
 
+  OLD, NEW
+        Paths to the design.json files generated by the snapshot command
+
 Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
 
+  -api-version VERSION
+        only generate the methods available in the given API version as
+        defined by the Since and Until DSL functions
+
+  -openapi-per-service
+        also generate the OpenAPI specification of each HTTP service in the
+        service directory (e.g. gen/http/<service>/openapi.json)
+
+  -openapi-hide-features FEATURES
+        comma separated list of features whose methods gated with the
+        FeatureGate DSL are omitted from the OpenAPI specifications
+
+  -endpoints-check
+        also generate a endpoints_check.go file in each service package that
+        asserts the signatures of the service methods and endpoints at
+        compile time
+
+  -mocks
+        also generate a mock implementation of each service interface
+        (e.g. gen/<service>/mocks) and an in-process test client in each
+        service package
+
   -debug
         Print debug information (mainly intended for Goa developers)
 
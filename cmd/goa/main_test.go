@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -12,37 +13,50 @@ func TestCmdLine(t *testing.T) {
 		testOutput = "testOutput"
 	)
 	var (
-		usageCalled  bool
-		cmd          string
-		path, output string
-		debug        bool
+		usageCalled bool
+		cmd         string
+		path        string
+		opts        genOptions
 	)
 
 	usage = func() { usageCalled = true }
-	gen = func(c string, p, o string, d bool) { cmd, path, output, debug = c, p, o, d }
+	gen = func(c, p string, o genOptions) {
+		cmd, path, opts = c, p, o
+	}
 	defer func() {
 		usage = help
 		gen = generate
 	}()
 
+	defaults := genOptions{Output: "."}
 	cases := map[string]struct {
 		CmdLine         string
 		ExpectedUsage   bool
 		ExpectedCommand string
 		ExpectedPath    string
-		ExpectedOutput  string
-		ExpectedDebug   bool
+		ExpectedOptions genOptions
 	}{
-		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"gen":      {"gen " + testPkg, false, "gen", testPkg, defaults},
+		"snapshot": {"snapshot " + testPkg, false, "snapshot", testPkg, defaults},
+
+		"invalid":     {"invalid " + testPkg, true, "", "", defaults},
+		"empty":       {"", true, "", "", defaults},
+		"invalid gen": {"invalid gen" + testPkg, true, "", "", defaults},
+
+		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, genOptions{Output: testOutput}},
+		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, genOptions{Output: testOutput}},
+
+		"api version": {"gen " + testPkg + " -api-version v2", false, "gen", testPkg, genOptions{Output: ".", APIVersion: "v2"}},
 
-		"invalid":     {"invalid " + testPkg, true, "", "", ".", false},
-		"empty":       {"", true, "", "", ".", false},
-		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false},
+		"openapi per service": {"gen " + testPkg + " -openapi-per-service", false, "gen", testPkg, genOptions{Output: ".", OpenAPIPerService: true}},
 
-		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false},
-		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false},
+		"endpoints check": {"gen " + testPkg + " -endpoints-check", false, "gen", testPkg, genOptions{Output: ".", EndpointsCheck: true}},
 
-		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true},
+		"mocks": {"gen " + testPkg + " -mocks", false, "gen", testPkg, genOptions{Output: ".", Mocks: true}},
+
+		"openapi hide features": {"gen " + testPkg + " -openapi-hide-features beta,new_checkout", false, "gen", testPkg, genOptions{Output: ".", OpenAPIHiddenFeatures: []string{"beta", "new_checkout"}}},
+
+		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, genOptions{Output: ".", Debug: true}},
 	}
 
 	for k, c := range cases {
@@ -52,8 +66,7 @@ func TestCmdLine(t *testing.T) {
 			usageCalled = false
 			cmd = ""
 			path = ""
-			output = ""
-			debug = false
+			opts = defaults
 		}
 
 		main()
@@ -67,11 +80,54 @@ func TestCmdLine(t *testing.T) {
 		if path != c.ExpectedPath {
 			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
 		}
-		if output != c.ExpectedOutput {
-			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
+		if !reflect.DeepEqual(opts, c.ExpectedOptions) {
+			t.Errorf("%s: Expected options to be %+v but got %+v", k, c.ExpectedOptions, opts)
+		}
+	}
+}
+
+func TestCmdLineDiff(t *testing.T) {
+	var (
+		usageCalled bool
+		oldSnapshot string
+		newSnapshot string
+	)
+
+	usage = func() { usageCalled = true }
+	compare = func(o, n string) { oldSnapshot, newSnapshot = o, n }
+	defer func() {
+		usage = help
+		compare = compareSnapshots
+	}()
+
+	cases := map[string]struct {
+		CmdLine       string
+		ExpectedUsage bool
+		ExpectedOld   string
+		ExpectedNew   string
+	}{
+		"diff":        {"diff old.json new.json", false, "old.json", "new.json"},
+		"missing new": {"diff old.json", true, "", ""},
+		"too many":    {"diff old.json new.json other.json", true, "", ""},
+	}
+
+	for k, c := range cases {
+		os.Args = append([]string{"goa"}, strings.Split(c.CmdLine, " ")...)
+		usageCalled, oldSnapshot, newSnapshot = false, "", ""
+
+		main()
+
+		if usageCalled != c.ExpectedUsage {
+			t.Errorf("%s: Expected usage to be %v but got %v", k, c.ExpectedUsage, usageCalled)
+		}
+		if c.ExpectedUsage {
+			continue
+		}
+		if oldSnapshot != c.ExpectedOld {
+			t.Errorf("%s: Expected old snapshot to be %s but got %s", k, c.ExpectedOld, oldSnapshot)
 		}
-		if debug != c.ExpectedDebug {
-			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
+		if newSnapshot != c.ExpectedNew {
+			t.Errorf("%s: Expected new snapshot to be %s but got %s", k, c.ExpectedNew, newSnapshot)
 		}
 	}
 }
@@ -76,6 +76,12 @@ type (
 		Example string
 		// Default returns the default value if any.
 		Default interface{}
+		// ElemType is the type of the array elements if the flag holds an
+		// array of primitive values and may be repeated, e.g. STRING.
+		ElemType string
+		// Env is the name of the environment variable that holds the
+		// flag value if the flag holds a secret, e.g. "STORAGE_TOKEN".
+		Env string
 	}
 
 	// BuildFunctionData contains the data needed to generate a constructor
@@ -208,6 +214,11 @@ func BuildSubcommandData(svcName string, m *service.MethodData, buildFunction *B
 			}
 		}
 	}
+	for _, f := range flags {
+		if isSecret(m, f) {
+			f.Env = strings.ToUpper(codegen.SnakeCase(svcName + "_" + f.VarName))
+		}
+	}
 	sub := &SubcommandData{
 		Name:          name,
 		FullName:      fullName,
@@ -261,10 +272,13 @@ func UsageExamples(data []*CommandData) *codegen.SectionTemplate {
 // occurs during the generation of flag parsing code.
 func FlagsCode(data []*CommandData) string {
 	section := codegen.SectionTemplate{
-		Name:    "parse-endpoint-flags",
-		Source:  parseFlagsT,
-		Data:    data,
-		FuncMap: map[string]interface{}{"printDescription": printDescription},
+		Name:   "parse-endpoint-flags",
+		Source: parseFlagsT,
+		Data:   data,
+		FuncMap: map[string]interface{}{
+			"printDescription": printDescription,
+			"hasSecretFlags":   hasSecretFlags,
+		},
 	}
 	var flagsCode bytes.Buffer
 	err := section.Write(&flagsCode)
@@ -320,6 +334,7 @@ func NewFlagData(svcn, en, name, typeName, description string, required bool, ex
 		Required:    required,
 		Example:     ex,
 		Default:     def,
+		ElemType:    flagElemType(typeName),
 	}
 }
 
@@ -409,6 +424,52 @@ func flagType(tname string) string {
 	}
 }
 
+// flagElemType returns the type of the elements of the array of primitive
+// values with the given type name, the empty string if the type is not such
+// an array.
+func flagElemType(tname string) string {
+	if !strings.HasPrefix(tname, "[]") {
+		return ""
+	}
+	elem := tname[2:]
+	if elem == bytesN {
+		return ""
+	}
+	if ft := flagType(elem); ft != "JSON" {
+		return ft
+	}
+	return ""
+}
+
+// isSecret returns true if the flag initializes the payload field holding the
+// password, the API key or the token of one of the method security schemes.
+func isSecret(m *service.MethodData, f *FlagData) bool {
+	field := codegen.Goify(f.VarName, true)
+	for _, r := range m.Requirements {
+		for _, s := range r.Schemes {
+			if s.PasswordField == field || s.CredField == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSecretFlags returns true if any of the sub-commands defines a flag
+// holding a secret.
+func hasSecretFlags(data []*CommandData) bool {
+	for _, cmd := range data {
+		for _, sub := range cmd.Subcommands {
+			for _, f := range sub.Flags {
+				if f.Env != "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // jsonExample generates a json example
 func jsonExample(v interface{}) string {
 	// In JSON, keys must be a string. But goa allows map keys to be anything.
@@ -612,8 +673,16 @@ const parseFlagsT = `var (
 		{{ .FullName }}Flags = flag.NewFlagSet("{{ .Name }}", flag.ExitOnError)
 		{{- $sub := . }}
 		{{- range .Flags }}
+		{{- if .Env }}
+		{{ .FullName }}Flag = goa.SecretFlag({{ $sub.FullName }}Flags, "{{ .Name }}", "{{ if .Default }}{{ .Default }}{{ end }}", {{ printf "%q" .Description }}, {{ printf "%q" .Env }}, {{ .Required }})
+		{{- else if .ElemType }}
+		{{ .FullName }}Flag = goa.ArrayFlag({{ $sub.FullName }}Flags, "{{ .Name }}", "{{ if .Default }}{{ .Default }}{{ else if .Required }}REQUIRED{{ end }}", {{ printf "%q" .Description }}, {{ eq .ElemType "STRING" }})
+		{{- else if eq .Type "JSON" }}
+		{{ .FullName }}Flag = goa.JSONFlag({{ $sub.FullName }}Flags, "{{ .Name }}", "{{ if .Default }}{{ .Default }}{{ else if .Required }}REQUIRED{{ end }}", {{ printf "%q" .Description }})
+		{{- else }}
 		{{ .FullName }}Flag = {{ $sub.FullName }}Flags.String("{{ .Name }}", "{{ if .Default }}{{ .Default }}{{ else if .Required }}REQUIRED{{ end }}", {{ printf "%q" .Description }})
 		{{- end }}
+		{{- end }}
 		{{ end }}
 		{{- end }}
 	)
@@ -679,6 +748,14 @@ const parseFlagsT = `var (
 			return nil, nil, err
 		}
 	}
+	{{- if hasSecretFlags . }}
+
+	// Read the secret flags that are not set from the environment or prompt
+	// for them
+	if err := goa.ReadSecretFlags(epf); err != nil {
+		return nil, nil, err
+	}
+	{{- end }}
 `
 
 // input: commandData
@@ -704,7 +781,7 @@ func {{ .FullName }}Usage() {
 
 {{ printDescription .Description}}
 	{{- range .Flags }}
-    -{{ .Name }} {{ .Type }}: {{ .Description }}
+    -{{ .Name }} {{ .Type }}: {{ .Description }}{{ if .Env }} (or set {{ .Env }}){{ end }}
 	{{- end }}
 
 Example:
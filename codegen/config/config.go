@@ -0,0 +1,342 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// ConfigData contains the data needed to render the API configuration.
+	ConfigData struct {
+		// APIName is the name of the API.
+		APIName string
+		// Fields lists the configuration fields.
+		Fields []*FieldData
+		// ValidateCode is the code that validates the configuration if
+		// any.
+		ValidateCode string
+		// HasBool is true if at least one field is a boolean.
+		HasBool bool
+		// HasRequired is true if at least one field is required and
+		// has no default value.
+		HasRequired bool
+	}
+
+	// FieldData describes a configuration field.
+	FieldData struct {
+		// Name is the name of the configuration attribute.
+		Name string
+		// FieldName is the name of the Config struct field.
+		FieldName string
+		// TypeName is the name of the field Go type.
+		TypeName string
+		// Description is the field description.
+		Description string
+		// Pointer is true if the struct field is a pointer.
+		Pointer bool
+		// Required is true if the field is required and has no default
+		// value.
+		Required bool
+		// DefaultValue is the Go literal of the field default value if
+		// any.
+		DefaultValue string
+		// EnvVar is the name of the environment variable that sets the
+		// field value.
+		EnvVar string
+		// Flag is the name of the command line flag that sets the field
+		// value.
+		Flag string
+		// Usage is the usage message of the command line flag.
+		Usage string
+		// SetterName is the name of the Config method that parses and
+		// sets the field value.
+		SetterName string
+		// ParseCode is the code that parses the variable "v" and sets the
+		// field value.
+		ParseCode string
+		// IsBool is true if the field is a boolean.
+		IsBool bool
+	}
+)
+
+// Files returns the file containing the Config struct generated from the API
+// Config DSL, nil if the API does not define a configuration.
+func Files(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if root.API == nil || root.API.Config == nil {
+		return nil
+	}
+	data := buildConfigData(root.API)
+	if len(data.Fields) == 0 {
+		return nil
+	}
+	pkg := pathName(root)
+	fpath := filepath.Join(codegen.Gendir, pkg, "config.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "flag"},
+		{Path: "fmt"},
+		{Path: "os"},
+	}
+	for _, f := range data.Fields {
+		if f.TypeName != "string" {
+			imports = append(imports, &codegen.ImportSpec{Path: "strconv"})
+			break
+		}
+	}
+	if data.ValidateCode != "" {
+		imports = append(imports, codegen.GoaImport(""))
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(fmt.Sprintf("%s configuration", root.API.Name), pkg, imports),
+		{Name: "config-struct", Source: configStructT, Data: data},
+		{Name: "config-load", Source: configLoadT, Data: data},
+		{Name: "config-validate", Source: configValidateT, Data: data},
+		{Name: "config-setters", Source: configSettersT, Data: data},
+		{Name: "config-flag-value", Source: configFlagValueT, Data: data},
+	}
+	return []*codegen.File{{Path: fpath, SectionTemplates: sections}}
+}
+
+// pathName returns the name of the directory containing the generated config
+// package, "config" unless a service already uses it.
+func pathName(root *expr.RootExpr) string {
+	for _, svc := range root.Services {
+		if service.Services.Get(svc.Name).PathName == "config" {
+			return "goaconfig"
+		}
+	}
+	return "config"
+}
+
+// buildConfigData builds the data needed to render the configuration of the
+// given API.
+func buildConfigData(api *expr.APIExpr) *ConfigData {
+	var (
+		c     = api.Config
+		att   = c.Attribute()
+		scope = codegen.NewNameScope()
+		data  = &ConfigData{APIName: api.Name}
+	)
+	for _, nat := range *expr.AsObject(att.Type) {
+		var (
+			fieldName = codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+			pointer   = att.IsPrimitivePointer(nat.Name, true)
+			env       = c.EnvVar(nat.Name)
+			usage     = nat.Attribute.Description
+			def       string
+		)
+		if usage == "" {
+			usage = nat.Name
+		}
+		usage += " (env " + env
+		if nat.Attribute.DefaultValue != nil {
+			def = fmt.Sprintf("%#v", nat.Attribute.DefaultValue)
+			usage += ", default " + fmt.Sprint(nat.Attribute.DefaultValue)
+		}
+		usage += ")"
+		f := &FieldData{
+			Name:         nat.Name,
+			FieldName:    fieldName,
+			TypeName:     codegen.GoNativeTypeName(nat.Attribute.Type),
+			Description:  nat.Attribute.Description,
+			Pointer:      pointer,
+			Required:     att.IsRequiredNoDefault(nat.Name),
+			DefaultValue: def,
+			EnvVar:       env,
+			Flag:         c.FlagName(nat.Name),
+			Usage:        usage,
+			SetterName:   "set" + fieldName,
+			IsBool:       nat.Attribute.Type.Kind() == expr.BooleanKind,
+		}
+		f.ParseCode = parseCode(nat.Attribute.Type, fieldName, pointer)
+		if f.IsBool {
+			data.HasBool = true
+		}
+		if f.Required {
+			data.HasRequired = true
+		}
+		data.Fields = append(data.Fields, f)
+	}
+	ctx := codegen.NewAttributeContext(false, false, true, "", scope)
+	data.ValidateCode = codegen.ValidationCodeWithContext(att, nil, ctx, true, false, "c", "config")
+	return data
+}
+
+// parseCode returns the code that parses the string variable "v" into a value
+// of type dt and sets the field with the given name.
+func parseCode(dt expr.DataType, field string, pointer bool) string {
+	var (
+		fn   string
+		conv = codegen.GoNativeTypeName(dt)
+	)
+	switch dt.Kind() {
+	case expr.StringKind:
+		if pointer {
+			return fmt.Sprintf("c.%s = &v\nreturn nil", field)
+		}
+		return fmt.Sprintf("c.%s = v\nreturn nil", field)
+	case expr.BooleanKind:
+		fn, conv = "strconv.ParseBool(v)", ""
+	case expr.IntKind:
+		fn = "strconv.ParseInt(v, 10, 0)"
+	case expr.Int32Kind:
+		fn = "strconv.ParseInt(v, 10, 32)"
+	case expr.Int64Kind:
+		fn, conv = "strconv.ParseInt(v, 10, 64)", ""
+	case expr.UIntKind:
+		fn = "strconv.ParseUint(v, 10, 0)"
+	case expr.UInt32Kind:
+		fn = "strconv.ParseUint(v, 10, 32)"
+	case expr.UInt64Kind:
+		fn, conv = "strconv.ParseUint(v, 10, 64)", ""
+	case expr.Float32Kind:
+		fn = "strconv.ParseFloat(v, 32)"
+	case expr.Float64Kind:
+		fn, conv = "strconv.ParseFloat(v, 64)", ""
+	default:
+		panic(fmt.Sprintf("unsupported configuration type %s", dt.Name())) // bug
+	}
+	val := "val"
+	if conv != "" {
+		val = conv + "(val)"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "val, err := %s\nif err != nil {\n\treturn err\n}\n", fn)
+	switch {
+	case pointer && conv == "":
+		fmt.Fprintf(&b, "c.%s = &val\n", field)
+	case pointer:
+		fmt.Fprintf(&b, "tmp := %s\nc.%s = &tmp\n", val, field)
+	default:
+		fmt.Fprintf(&b, "c.%s = %s\n", field, val)
+	}
+	b.WriteString("return nil")
+	return b.String()
+}
+
+const (
+	// input: ConfigData
+	configStructT = `{{ printf "Config contains the configuration of the %s API." .APIName | comment }}
+type Config struct {
+{{- range .Fields }}
+	{{- if .Description }}
+	{{ comment .Description }}
+	{{- end }}
+	{{ .FieldName }} {{ if .Pointer }}*{{ end }}{{ .TypeName }}
+{{- end }}
+}
+
+// Default returns the configuration initialized with the default values.
+func Default() *Config {
+	return &Config{
+	{{- range .Fields }}
+		{{- if .DefaultValue }}
+		{{ .FieldName }}: {{ .DefaultValue }},
+		{{- end }}
+	{{- end }}
+	}
+}
+`
+
+	// input: ConfigData
+	configLoadT = `// Load returns the configuration read from the environment variables and from
+// the command line flags registered on fs and parsed from args. The flag values
+// take precedence over the environment variables which take precedence over
+// the default values. Load returns an error if a required field is not set or
+// if the configuration is invalid.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	c := Default()
+{{- if .HasRequired }}
+	// set records the required fields set by an environment variable or a
+	// flag.
+	set := make(map[string]bool)
+{{- end }}
+{{- range .Fields }}
+	if v, ok := os.LookupEnv({{ printf "%q" .EnvVar }}); ok {
+		if err := c.{{ .SetterName }}(v); err != nil {
+			return nil, fmt.Errorf({{ printf "invalid value %%q for environment variable %s: %%w" .EnvVar | printf "%q" }}, v, err)
+		}
+	{{- if .Required }}
+		set[{{ printf "%q" .Name }}] = true
+	{{- end }}
+	}
+	{{- if .Required }}
+	fs.Var({{ if .IsBool }}boolFlagFunc{{ else }}flagFunc{{ end }}(func(v string) error {
+		set[{{ printf "%q" .Name }}] = true
+		return c.{{ .SetterName }}(v)
+	}), {{ printf "%q" .Flag }}, {{ printf "%q" .Usage }})
+	{{- else }}
+	fs.Var({{ if .IsBool }}boolFlagFunc{{ else }}flagFunc{{ end }}(c.{{ .SetterName }}), {{ printf "%q" .Flag }}, {{ printf "%q" .Usage }})
+	{{- end }}
+{{- end }}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+{{- range .Fields }}
+	{{- if .Required }}
+	if !set[{{ printf "%q" .Name }}] {
+		return nil, fmt.Errorf({{ printf "missing required configuration %q, set the environment variable %s or the flag -%s" .Name .EnvVar .Flag | printf "%q" }})
+	}
+	{{- end }}
+{{- end }}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+`
+
+	// input: ConfigData
+	configValidateT = `// Validate runs the validations defined in the design on the configuration.
+{{- if .ValidateCode }}
+func (c *Config) Validate() (err error) {
+	{{ .ValidateCode }}
+	return
+}
+{{- else }}
+func (c *Config) Validate() error {
+	return nil
+}
+{{- end }}
+`
+
+	// input: ConfigData
+	configSettersT = `{{ range .Fields }}
+{{ printf "%s parses v and sets the %s field." .SetterName .FieldName | comment }}
+func (c *Config) {{ .SetterName }}(v string) error {
+	{{ .ParseCode }}
+}
+{{ end }}`
+
+	// input: ConfigData
+	configFlagValueT = `// flagFunc is a flag.Value that calls the function with the flag value.
+type flagFunc func(string) error
+
+// Set calls f with v.
+func (f flagFunc) Set(v string) error { return f(v) }
+
+// String returns the empty string, the flag default values are listed in the
+// flag usage.
+func (f flagFunc) String() string { return "" }
+{{- if .HasBool }}
+
+// boolFlagFunc is a flagFunc for boolean flags which may be given without
+// value.
+type boolFlagFunc func(string) error
+
+// Set calls f with v.
+func (f boolFlagFunc) Set(v string) error { return f(v) }
+
+// String returns the empty string, the flag default values are listed in the
+// flag usage.
+func (f boolFlagFunc) String() string { return "" }
+
+// IsBoolFlag returns true so that the flag may be given without value.
+func (f boolFlagFunc) IsBoolFlag() bool { return true }
+{{- end }}
+`
+)
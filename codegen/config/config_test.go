@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/config/testdata"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+func TestConfig(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"config", testdata.ConfigDSL, testdata.ConfigCode},
+		{"no-validation", testdata.ConfigNoValidationDSL, testdata.ConfigNoValidationCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			service.Services = make(service.ServicesData)
+			codegen.RunDSL(t, c.DSL)
+			fs := Files("gen", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			code := codegen.SectionsCode(t, fs[0].SectionTemplates[1:])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestConfigNone(t *testing.T) {
+	codegen.RunDSL(t, func() {})
+	if fs := Files("gen", expr.Root); fs != nil {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
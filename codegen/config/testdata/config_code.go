@@ -0,0 +1,193 @@
+package testdata
+
+const ConfigCode = `// Config contains the configuration of the ConfigAPI API.
+type Config struct {
+	// Listen port
+	Port int
+	// Database URL
+	DbURL string
+	Debug *bool
+	Ratio *float64
+}
+
+// Default returns the configuration initialized with the default values.
+func Default() *Config {
+	return &Config{
+		Port: 8080,
+	}
+}
+
+// Load returns the configuration read from the environment variables and from
+// the command line flags registered on fs and parsed from args. The flag values
+// take precedence over the environment variables which take precedence over
+// the default values. Load returns an error if a required field is not set or
+// if the configuration is invalid.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	c := Default()
+	// set records the required fields set by an environment variable or a
+	// flag.
+	set := make(map[string]bool)
+	if v, ok := os.LookupEnv("PORT"); ok {
+		if err := c.setPort(v); err != nil {
+			return nil, fmt.Errorf("invalid value %q for environment variable PORT: %w", v, err)
+		}
+	}
+	fs.Var(flagFunc(c.setPort), "port", "Listen port (env PORT, default 8080)")
+	if v, ok := os.LookupEnv("DATABASE_URL"); ok {
+		if err := c.setDbURL(v); err != nil {
+			return nil, fmt.Errorf("invalid value %q for environment variable DATABASE_URL: %w", v, err)
+		}
+		set["db_url"] = true
+	}
+	fs.Var(flagFunc(func(v string) error {
+		set["db_url"] = true
+		return c.setDbURL(v)
+	}), "db-url", "Database URL (env DATABASE_URL)")
+	if v, ok := os.LookupEnv("DEBUG"); ok {
+		if err := c.setDebug(v); err != nil {
+			return nil, fmt.Errorf("invalid value %q for environment variable DEBUG: %w", v, err)
+		}
+	}
+	fs.Var(boolFlagFunc(c.setDebug), "debug", "debug (env DEBUG)")
+	if v, ok := os.LookupEnv("RATIO"); ok {
+		if err := c.setRatio(v); err != nil {
+			return nil, fmt.Errorf("invalid value %q for environment variable RATIO: %w", v, err)
+		}
+	}
+	fs.Var(flagFunc(c.setRatio), "sample-ratio", "ratio (env RATIO)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if !set["db_url"] {
+		return nil, fmt.Errorf("missing required configuration \"db_url\", set the environment variable DATABASE_URL or the flag -db-url")
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate runs the validations defined in the design on the configuration.
+func (c *Config) Validate() (err error) {
+	if c.Port < 1 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError("config.port", c.Port, 1, true))
+	}
+	return
+}
+
+// setPort parses v and sets the Port field.
+func (c *Config) setPort(v string) error {
+	val, err := strconv.ParseInt(v, 10, 0)
+	if err != nil {
+		return err
+	}
+	c.Port = int(val)
+	return nil
+}
+
+// setDbURL parses v and sets the DbURL field.
+func (c *Config) setDbURL(v string) error {
+	c.DbURL = v
+	return nil
+}
+
+// setDebug parses v and sets the Debug field.
+func (c *Config) setDebug(v string) error {
+	val, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	c.Debug = &val
+	return nil
+}
+
+// setRatio parses v and sets the Ratio field.
+func (c *Config) setRatio(v string) error {
+	val, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return err
+	}
+	c.Ratio = &val
+	return nil
+}
+
+// flagFunc is a flag.Value that calls the function with the flag value.
+type flagFunc func(string) error
+
+// Set calls f with v.
+func (f flagFunc) Set(v string) error { return f(v) }
+
+// String returns the empty string, the flag default values are listed in the
+// flag usage.
+func (f flagFunc) String() string { return "" }
+
+// boolFlagFunc is a flagFunc for boolean flags which may be given without
+// value.
+type boolFlagFunc func(string) error
+
+// Set calls f with v.
+func (f boolFlagFunc) Set(v string) error { return f(v) }
+
+// String returns the empty string, the flag default values are listed in the
+// flag usage.
+func (f boolFlagFunc) String() string { return "" }
+
+// IsBoolFlag returns true so that the flag may be given without value.
+func (f boolFlagFunc) IsBoolFlag() bool { return true }
+`
+
+const ConfigNoValidationCode = `// Config contains the configuration of the ConfigAPI API.
+type Config struct {
+	Name string
+}
+
+// Default returns the configuration initialized with the default values.
+func Default() *Config {
+	return &Config{
+		Name: "api",
+	}
+}
+
+// Load returns the configuration read from the environment variables and from
+// the command line flags registered on fs and parsed from args. The flag values
+// take precedence over the environment variables which take precedence over
+// the default values. Load returns an error if a required field is not set or
+// if the configuration is invalid.
+func Load(fs *flag.FlagSet, args []string) (*Config, error) {
+	c := Default()
+	if v, ok := os.LookupEnv("NAME"); ok {
+		if err := c.setName(v); err != nil {
+			return nil, fmt.Errorf("invalid value %q for environment variable NAME: %w", v, err)
+		}
+	}
+	fs.Var(flagFunc(c.setName), "name", "name (env NAME, default api)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Validate runs the validations defined in the design on the configuration.
+func (c *Config) Validate() error {
+	return nil
+}
+
+// setName parses v and sets the Name field.
+func (c *Config) setName(v string) error {
+	c.Name = v
+	return nil
+}
+
+// flagFunc is a flag.Value that calls the function with the flag value.
+type flagFunc func(string) error
+
+// Set calls f with v.
+func (f flagFunc) Set(v string) error { return f(v) }
+
+// String returns the empty string, the flag default values are listed in the
+// flag usage.
+func (f flagFunc) String() string { return "" }
+`
@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ConfigDSL = func() {
+	API("ConfigAPI", func() {
+		Config(func() {
+			Attribute("port", Int, "Listen port", func() {
+				Default(8080)
+				Minimum(1)
+			})
+			Attribute("db_url", String, "Database URL", func() {
+				Meta("config:env", "DATABASE_URL")
+			})
+			Attribute("debug", Boolean)
+			Attribute("ratio", Float64, func() {
+				Meta("config:flag", "sample-ratio")
+			})
+			Required("db_url")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {})
+	})
+}
+
+var ConfigNoValidationDSL = func() {
+	API("ConfigAPI", func() {
+		Config(func() {
+			Attribute("name", String, func() {
+				Default("api")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {})
+	})
+}
@@ -105,6 +105,7 @@ func exampleSvrMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 			Name:   "server-main-endpoints",
 			Source: mainEndpointsT,
 			Data: map[string]interface{}{
+				"APIPkg":   apiPkg,
 				"Services": svcData,
 			},
 			FuncMap: map[string]interface{}{
@@ -198,7 +199,7 @@ func main() {
 {{- end }}
 `
 
-	// input: map[string]interface{"Services": []*service.Data}
+	// input: map[string]interface{"APIPkg": string, "Services": []*service.Data}
 	mainEndpointsT = `
 {{- if mustInitServices .Services }}
 	{{ comment "Wrap the services in endpoints that can be invoked from other services potentially running in different processes." }}
@@ -211,7 +212,15 @@ func main() {
 	)
 	{
 	{{- range .Services }}
-		{{- if .Methods }}
+		{{- if .CustomValidations }}
+		{
+			var err error
+			{{ .VarName }}Endpoints, err = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc, {{ $.APIPkg }}.New{{ .StructName }}CustomValidations())
+			if err != nil {
+				logger.Fatal(err)
+			}
+		}
+		{{- else if .Methods }}
 			{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc)
 		{{- end }}
 	{{- end }}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 	"unicode"
 
 	"goa.design/goa/v3/expr"
@@ -98,10 +99,14 @@ var toLower = map[string]string{"OAuth": "oauth"}
 // If acronym is true and a part of the string is a common acronym
 // then it keeps the part capitalized (firstUpper = true)
 // (e.g. APIVersion) or lowercase (firstUpper = false) (e.g. apiVersion).
+// The acronyms listed in the API "naming:acronyms" meta extend the common
+// acronyms.
 func CamelCase(name string, firstUpper bool, acronym bool) string {
 	if name == "" {
 		return ""
 	}
+	acronyms := apiAcronyms()
+	custom := len(acronyms) > 0
 
 	runes := []rune(name)
 	// remove trailing invalid identifiers (makes code below simpler)
@@ -136,6 +141,10 @@ func CamelCase(name string, firstUpper bool, acronym bool) string {
 		} else if isLower(runes[i]) && !isLower(runes[i+1]) {
 			// lower->non-lower
 			eow = true
+		} else if custom && i+2 < len(runes) && unicode.IsUpper(runes[i]) && unicode.IsUpper(runes[i+1]) && unicode.IsLower(runes[i+2]) {
+			// upper->upper followed by lower, e.g. the "D" in "IDUser",
+			// only when the API lists its acronyms
+			eow = true
 		}
 		i++
 		if !eow {
@@ -145,7 +154,7 @@ func CamelCase(name string, firstUpper bool, acronym bool) string {
 		// [w,i] is a word.
 		word := string(runes[w:i])
 		// is it one of our initialisms?
-		if u := strings.ToUpper(word); commonInitialisms[u] {
+		if u := strings.ToUpper(word); commonInitialisms[u] || acronyms[u] {
 			switch {
 			case firstUpper && acronym:
 				// u is already in upper case. Nothing to do here.
@@ -260,6 +269,27 @@ func WrapText(text string, maxChars int) string {
 	return res[:len(res)-1]
 }
 
+// DurationToGo returns the Go expression for the given duration using the
+// largest time unit that divides it, e.g. "90 * time.Second".
+func DurationToGo(d time.Duration) string {
+	units := []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%d * %s", d/u.d, u.name)
+		}
+	}
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
+
 // InitStructFields produces Go code to initialize a struct and its fields from
 // the given init arguments.
 func InitStructFields(args []*InitArgData, targetVar, sourcePkg, targetPkg string) (string, []*TransformFunctionData, error) {
@@ -274,6 +304,10 @@ func InitStructFields(args []*InitArgData, targetVar, sourcePkg, targetPkg strin
 		switch {
 		case arg.FieldName == "" && arg.FieldType == nil:
 		// do nothing
+		case arg.Type == arg.FieldType && expr.IsObject(arg.Type):
+			// arg already uses the struct field type, e.g. a query
+			// parameter that uses the "deepObject" style.
+			code += fmt.Sprintf("%s.%s = %s\n", targetVar, arg.FieldName, arg.Name)
 		case expr.Equal(unalias(arg.Type), arg.FieldType):
 			// arg type and struct field type are the same. No need to call transform
 			// to initialize the field
@@ -356,6 +390,15 @@ func isLower(r rune) bool {
 	return unicode.IsDigit(r) || unicode.IsLower(r)
 }
 
+// apiAcronyms returns the upper case acronyms listed in the API
+// "naming:acronyms" meta if any.
+func apiAcronyms() map[string]bool {
+	if expr.Root == nil || expr.Root.API == nil {
+		return nil
+	}
+	return expr.Root.API.Acronyms
+}
+
 // validIdentifier returns true if the rune is a letter or number
 func validIdentifier(r rune) bool {
 	return unicode.IsLetter(r) || unicode.IsDigit(r)
@@ -2,6 +2,8 @@ package codegen
 
 import (
 	"testing"
+
+	"goa.design/goa/v3/expr"
 )
 
 func TestSnakeCase(t *testing.T) {
@@ -55,6 +57,9 @@ func TestCamelCase(t *testing.T) {
 		"lower camel case":              {"aa_id", false, false, "aaId"},
 		"upper camel case":              {"aaID", false, true, "aaID"},
 		"lower camel case with acronym": {"aaId", false, true, "aaID"},
+		"leading acronym snake case":    {"id_user", true, true, "IDUser"},
+		"leading acronyms unchanged":    {"XMLHttpRequest", true, true, "XMLHttpRequest"},
+		"leading acronym lower first":   {"HTTPServer", false, true, "hTTPServer"},
 
 		"disable acronym":                    {"aa_id", false, false, "aaId"},
 		"disable acronym first upper":        {"aaID", true, false, "AaId"},
@@ -72,6 +77,54 @@ func TestCamelCase(t *testing.T) {
 	}
 }
 
+func TestCamelCaseAcronyms(t *testing.T) {
+	root := expr.Root
+	defer func() { expr.Root = root }()
+	expr.Root = &expr.RootExpr{API: &expr.APIExpr{Meta: expr.MetaExpr{"naming:acronyms": {"sku"}}}}
+	expr.Root.API.Finalize()
+	cases := map[string]struct {
+		str        string
+		firstUpper bool
+		expected   string
+	}{
+		"custom acronym":             {"item_sku", false, "itemSKU"},
+		"custom acronym first upper": {"item_sku", true, "ItemSKU"},
+		"leading custom acronym":     {"sku_code", false, "skuCode"},
+		"leading upper acronym":      {"SKUCode", false, "skuCode"},
+		"listed acronym":             {"user_id", false, "userID"},
+		"leading listed acronym":     {"IDUser", false, "idUser"},
+		"leading listed first upper": {"IDUser", true, "IDUser"},
+		"common acronym":             {"api_key", true, "APIKey"},
+		"unlisted word":              {"item_ean", true, "ItemEan"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			actual := CamelCase(tc.str, tc.firstUpper, true)
+			if actual != tc.expected {
+				t.Errorf("got %q, expected %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCamelCaseAcronymsNewDesign(t *testing.T) {
+	root := expr.Root
+	defer func() { expr.Root = root }()
+	expr.Root = &expr.RootExpr{API: &expr.APIExpr{Meta: expr.MetaExpr{"naming:acronyms": {"sku"}}}}
+	expr.Root.API.Finalize()
+	if actual := CamelCase("item_sku", true, true); actual != "ItemSKU" {
+		t.Errorf("got %q, expected %q", actual, "ItemSKU")
+	}
+	expr.Root = &expr.RootExpr{API: &expr.APIExpr{Meta: expr.MetaExpr{"naming:acronyms": {"ean"}}}}
+	expr.Root.API.Finalize()
+	if actual := CamelCase("item_sku", true, true); actual != "ItemSku" {
+		t.Errorf("got %q with the acronyms of the previous design, expected %q", actual, "ItemSku")
+	}
+	if actual := CamelCase("item_ean", true, true); actual != "ItemEAN" {
+		t.Errorf("got %q, expected %q", actual, "ItemEAN")
+	}
+}
+
 func TestKebabCase(t *testing.T) {
 	cases := map[string]struct {
 		str      string
@@ -17,5 +17,27 @@ OpenAPI
 
 The OpenAPI generator generates a OpenAPI v2 specification for the service
 REST endpoints. This generator requires the design to define the HTTP transport.
+The generator also generates the specification of each HTTP service in the
+service directory when OpenAPIPerService is true (e.g. when the "goa gen"
+command is run with the --openapi-per-service flag). The methods gated by the
+feature flags listed in OpenAPIHiddenFeatures are omitted from the
+specifications (e.g. when the "goa gen" command is run with the
+--openapi-hide-features flag).
+
+Endpoints Check
+
+The service generator also generates a endpoints_check.go file in each service
+package when EndpointsCheck is true (e.g. when the "goa gen" command is run with
+the --endpoints-check flag). The file asserts the signatures of the service
+methods, endpoint constructors and endpoint wrapping function so that code
+written against them fails to compile with a clear error when the design
+changes.
+
+Mocks
+
+The service generator also generates a mock implementation of the service
+interface in the mocks package of each service and an in-process test client in
+each service package when Mocks is true (e.g. when the "goa gen" command is run
+with the --mocks flag).
 */
 package generator
@@ -43,6 +43,9 @@ func Example(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			if fs := httpcodegen.ExampleCLIFiles(genpkg, r); len(fs) != 0 {
 				files = append(files, fs...)
 			}
+			if fs := httpcodegen.ExampleIntegrationFiles(genpkg, r); len(fs) != 0 {
+				files = append(files, fs...)
+			}
 		}
 
 		// GRPC
@@ -1,17 +1,51 @@
 package generator
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
 	"golang.org/x/tools/go/packages"
 )
 
+// Options contains the code generation options set on the goa command line.
+type Options struct {
+	// OpenAPIPerService indicates whether the OpenAPI specifications of
+	// each HTTP service are generated in addition to the specifications of
+	// the API.
+	OpenAPIPerService bool
+	// OpenAPIHiddenFeatures lists the feature flags whose gated methods
+	// are omitted from the OpenAPI specifications.
+	OpenAPIHiddenFeatures []string
+	// EndpointsCheck indicates whether the files asserting the signatures
+	// of the service methods and endpoints at compile time are generated.
+	EndpointsCheck bool
+	// Mocks indicates whether the mock implementation and the in-process
+	// test client of each service are generated.
+	Mocks bool
+}
+
 // Generate runs the code generation algorithms.
-func Generate(dir, cmd string) (outputs []string, err1 error) {
+func Generate(dir, cmd string) ([]string, error) {
+	return generate(dir, cmd, Generators)
+}
+
+// GenerateWithOptions runs the code generation algorithms using the given
+// options. The generator functions are given by the Generators method of opts.
+func GenerateWithOptions(dir, cmd string, opts Options) ([]string, error) {
+	return generate(dir, cmd, opts.Generators)
+}
+
+// generate runs the code generation algorithms using the generator functions
+// returned by gens for the given command.
+func generate(dir, cmd string, gens func(string) ([]Genfunc, error)) (outputs []string, err1 error) {
 	// 1. Compute design roots.
 	var roots []eval.Root
 	{
@@ -52,17 +86,20 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 			return nil, err
 		}
 
-		pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName}, path)
+		pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedModule}, path)
 		if err != nil {
 			return nil, err
 		}
 		genpkg = pkgs[0].PkgPath
+		if mod := designModule(roots); mod != "" {
+			genpkg = overrideModule(genpkg, pkgs[0].Module, mod, os.Stderr)
+		}
 	}
 
 	// 3. Retrieve goa generators for given command.
 	var genfuncs []Genfunc
 	{
-		gs, err := Generators(cmd)
+		gs, err := gens(cmd)
 		if err != nil {
 			return nil, err
 		}
@@ -124,3 +161,31 @@ func Generate(dir, cmd string) (outputs []string, err1 error) {
 
 	return outputs, nil
 }
+
+// designModule returns the module path set in the design with the "gen:module"
+// API meta, an empty string if none.
+func designModule(roots []eval.Root) string {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok && r.API != nil {
+			if mod, ok := r.API.Meta.Last("gen:module"); ok {
+				return mod
+			}
+		}
+	}
+	return ""
+}
+
+// overrideModule returns the import path of the "gen" package genpkg where
+// the path of module m is replaced with mod. It assumes that the "gen"
+// package is at the root of the module if the module is unknown. It writes a
+// warning to w if mod differs from the path of m.
+func overrideModule(genpkg string, m *packages.Module, mod string, w io.Writer) string {
+	if m == nil || m.Path == "" {
+		return path.Join(mod, codegen.Gendir)
+	}
+	if m.Path == mod {
+		return genpkg
+	}
+	fmt.Fprintf(w, "warning: module %q set with the gen:module meta overrides module %q defined in %s\n", mod, m.Path, m.GoMod)
+	return mod + strings.TrimPrefix(genpkg, m.Path)
+}
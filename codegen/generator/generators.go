@@ -19,11 +19,20 @@ var Generators = generators
 // generators returns the generator functions exposed by the generator package
 // for the given command.
 func generators(cmd string) ([]Genfunc, error) {
+	return Options{}.Generators(cmd)
+}
+
+// Generators returns the generator functions exposed by the generator package
+// for the given command configured with the options, an error if the command
+// is not supported.
+func (o Options) Generators(cmd string) ([]Genfunc, error) {
 	switch cmd {
 	case "gen":
-		return []Genfunc{Service, Transport, OpenAPI}, nil
+		return []Genfunc{o.Service, Transport, o.OpenAPI}, nil
 	case "example":
 		return []Genfunc{Example}, nil
+	case "snapshot":
+		return []Genfunc{Snapshot}, nil
 	default:
 		return nil, fmt.Errorf("unknown command %q", cmd)
 	}
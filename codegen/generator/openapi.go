@@ -8,12 +8,30 @@ import (
 )
 
 // OpenAPI iterates through the roots and returns the files needed to render
-// the service OpenAPI spec. It produces OpenAPI specifications only if the
-// roots define a HTTP service.
-func OpenAPI(_ string, roots []eval.Root) ([]*codegen.File, error) {
+// the service OpenAPI spec using the default options. It produces OpenAPI
+// specifications only if the roots define a HTTP service.
+func OpenAPI(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	return Options{}.OpenAPI(genpkg, roots)
+}
+
+// OpenAPI iterates through the roots and returns the files needed to render
+// the service OpenAPI spec. It also produces the specifications of each HTTP
+// service if OpenAPIPerService is set and omits the methods gated by the
+// features listed in OpenAPIHiddenFeatures.
+func (o Options) OpenAPI(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 	for _, root := range roots {
 		if r, ok := root.(*expr.RootExpr); ok {
-			return httpcodegen.OpenAPIFiles(r)
+			httpcodegen.AddOpenAPICodeSamples(genpkg, r)
+			httpcodegen.HideOpenAPIFeatures(r, o.OpenAPIHiddenFeatures)
+			files, err := httpcodegen.OpenAPIFiles(r)
+			if err != nil || !o.OpenAPIPerService {
+				return files, err
+			}
+			sfiles, err := httpcodegen.OpenAPIServiceFiles(r)
+			if err != nil {
+				return nil, err
+			}
+			return append(files, sfiles...), nil
 		}
 	}
 	return nil, nil
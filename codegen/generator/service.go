@@ -2,15 +2,23 @@ package generator
 
 import (
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/config"
 	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
 
 // Service iterates through the roots and returns the files needed to render
-// the service code. It returns an error if the roots slice does not include
-// a goa design.
+// the service code using the default options. It returns an error if the roots
+// slice does not include a goa design.
 func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	return Options{}.Service(genpkg, roots)
+}
+
+// Service iterates through the roots and returns the files needed to render
+// the service code. It also produces the endpoint signature assertions if
+// EndpointsCheck is set and the service mocks and test clients if Mocks is set.
+func (o Options) Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 	var files []*codegen.File
 	var userTypePkgs = make(map[string][]string)
 	for _, root := range roots {
@@ -21,7 +29,16 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 				// properly initialized.
 				files = append(files, service.Files(genpkg, s, userTypePkgs)...)
 				files = append(files, service.EndpointFile(genpkg, s))
+				if o.EndpointsCheck {
+					files = append(files, service.EndpointCheckFile(genpkg, s))
+				}
 				files = append(files, service.ClientFile(genpkg, s))
+				if o.Mocks {
+					files = append(files, service.MockFile(genpkg, s))
+					if f := service.TestClientFile(genpkg, s); f != nil {
+						files = append(files, f)
+					}
+				}
 				if f := service.ViewsFile(genpkg, s); f != nil {
 					files = append(files, f)
 				}
@@ -38,6 +55,7 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 					files = append(files, f)
 				}
 			}
+			files = append(files, config.Files(genpkg, r)...)
 		}
 	}
 	return files, nil
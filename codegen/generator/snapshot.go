@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"encoding/json"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/diff"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Snapshot iterates through the roots and returns the file containing the JSON
+// snapshot of the design used to compare it with other versions of the design
+// (see package diff).
+func Snapshot(_ string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			section := &codegen.SectionTemplate{
+				Name:    "snapshot",
+				FuncMap: template.FuncMap{"toJSON": snapshotJSON},
+				Source:  "{{ toJSON . }}\n",
+				Data:    diff.NewSnapshot(r),
+			}
+			return []*codegen.File{{
+				Path:             "design.json",
+				SectionTemplates: []*codegen.SectionTemplate{section},
+			}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// snapshotJSON returns the indented JSON representation of the given snapshot
+// so that changes to the snapshot file are easy to review.
+func snapshotJSON(s *diff.Snapshot) string {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		panic("snapshot: " + err.Error()) // bug
+	}
+	return string(b)
+}
@@ -2,6 +2,7 @@ package generator
 
 import (
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/publisher"
 	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
@@ -35,6 +36,10 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 		files = append(files, grpccodegen.ServerTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, grpccodegen.ClientCLIFiles(genpkg, r)...)
+		files = append(files, grpccodegen.HealthFiles(genpkg, r)...)
+
+		// Event publishers
+		files = append(files, publisher.Files(genpkg, r)...)
 
 		for _, f := range files {
 			if len(f.SectionTemplates) > 0 {
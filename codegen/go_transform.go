@@ -410,11 +410,10 @@ func transformUnionToObject(source, target *expr.AttributeExpr, sourceVar, targe
 	}
 	srcUnion := expr.AsUnion(source.Type)
 	sourceTypeRefs := make([]string, len(srcUnion.Values))
-	sourceTypeNames := make([]string, len(srcUnion.Values))
 	for i, st := range srcUnion.Values {
 		sourceTypeRefs[i] = ta.SourceCtx.Scope.Ref(st.Attribute, ta.SourceCtx.Pkg(st.Attribute))
-		sourceTypeNames[i] = st.Name
 	}
+	sourceTypeNames := expr.UnionTypeNames(srcUnion)
 	data := map[string]interface{}{
 		"NewVar":          newVar,
 		"TargetVar":       targetVar,
@@ -445,10 +444,9 @@ func transformObjectToUnion(source, target *expr.AttributeExpr, sourceVar, targe
 		sourceVarDeref = "*" + sourceVar
 	}
 	tgtUnion := expr.AsUnion(target.Type)
-	unionTypes := make([]string, len(tgtUnion.Values))
+	unionTypes := expr.UnionTypeNames(tgtUnion)
 	targetTypeRefs := make([]string, len(tgtUnion.Values))
 	for i, tt := range tgtUnion.Values {
-		unionTypes[i] = tt.Name
 		targetTypeRefs[i] = ta.TargetCtx.Scope.Ref(tt.Attribute, ta.TargetCtx.Pkg(tt.Attribute))
 	}
 	data := map[string]interface{}{
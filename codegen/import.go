@@ -100,11 +100,23 @@ func (loc *Location) PackageName() string {
 }
 
 // GetMetaType retrieves the type and package defined by the struct:field:type
-// metadata if any.
+// metadata if any. The type of nullable primitive attributes is the
+// corresponding goa.Nullable type, the type of attributes defined with
+// DurationFormat is goa.Duration and the type of Any attributes with the
+// struct:field:any metadata set to "json.RawMessage" is json.RawMessage.
 func GetMetaType(att *expr.AttributeExpr) (typeName string, importS *ImportSpec) {
 	if att == nil {
 		return
 	}
+	if p, ok := att.Type.(expr.Primitive); ok && att.IsNullable() {
+		return fmt.Sprintf("goa.Nullable[%s]", GoNativeTypeName(p)), GoaImport("")
+	}
+	if att.IsDuration() && att.Type == expr.String {
+		return "goa.Duration", GoaImport("")
+	}
+	if v, ok := att.Meta.Last("struct:field:any"); ok && v == "json.RawMessage" && att.Type == expr.Any {
+		return "json.RawMessage", &ImportSpec{Path: "encoding/json"}
+	}
 	if args, ok := att.Meta["struct:field:type"]; ok {
 		if len(args) > 0 {
 			typeName = args[0]
@@ -35,6 +35,21 @@ func TestGetMetaTypeImports(t *testing.T) {
 				"package/int",
 			},
 		},
+		{
+			name: "payload-raw-any",
+			dsl: func() {
+				dsl.Method("m", func() {
+					dsl.Payload(func() {
+						dsl.Attribute("a", dsl.Any, func() {
+							dsl.Meta("struct:field:any", "json.RawMessage")
+						})
+					})
+				})
+			},
+			want: []string{
+				"encoding/json",
+			},
+		},
 		{
 			name: "payload-map",
 			dsl: func() {
@@ -168,3 +183,33 @@ func TestGetMetaTypeImports(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMetaType(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Attribute  *expr.AttributeExpr
+		TypeName   string
+		ImportPath string
+	}{
+		{"none", &expr.AttributeExpr{Type: expr.Any}, "", ""},
+		{"struct:field:type", &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:field:type": {"json.Number", "encoding/json"}}}, "json.Number", "encoding/json"},
+		{"raw any", &expr.AttributeExpr{Type: expr.Any, Meta: expr.MetaExpr{"struct:field:any": {"json.RawMessage"}}}, "json.RawMessage", "encoding/json"},
+		{"raw any not any", &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:field:any": {"json.RawMessage"}}}, "", ""},
+		{"duration", &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:field:duration": nil}}, "goa.Duration", "goa.design/goa/v3/pkg"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			typeName, imp := GetMetaType(c.Attribute)
+			if typeName != c.TypeName {
+				t.Errorf("got type %q, expected %q", typeName, c.TypeName)
+			}
+			var path string
+			if imp != nil {
+				path = imp.Path
+			}
+			if path != c.ImportPath {
+				t.Errorf("got import path %q, expected %q", path, c.ImportPath)
+			}
+		})
+	}
+}
@@ -13,6 +13,10 @@ type (
 
 	// PrepareFunc makes it possible to modify the design roots before
 	// the files being generated by the goa code generators or other plugins.
+	// PrepareFunc runs once the design has been validated and finalized,
+	// plugins that alter the design expressions (e.g. to add attributes to
+	// the method payloads) should use eval.RegisterTransform instead so that
+	// the changes get validated and finalized.
 	PrepareFunc func(genpkg string, roots []eval.Root) error
 
 	// plugin is a plugin that has been registered with a given command.
@@ -0,0 +1,263 @@
+package publisher
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
+)
+
+type (
+	// PublisherData contains the data needed to render the publisher of
+	// a message broker.
+	PublisherData struct {
+		// Broker is the name of the message broker.
+		Broker string
+		// Interface is the name of the publisher interface.
+		Interface string
+		// VarName is the name of the struct implementing the publisher
+		// interface.
+		VarName string
+		// Init is the name of the publisher constructor.
+		Init string
+		// ServiceName is the name of the service.
+		ServiceName string
+		// Methods lists the published methods.
+		Methods []*MethodData
+	}
+
+	// MethodData contains the data needed to render the function that
+	// publishes the results streamed by a method.
+	MethodData struct {
+		// Name is the name of the method.
+		Name string
+		// VarName is the name of the publisher function.
+		VarName string
+		// Topic is the name of the topic the events are published to.
+		Topic string
+		// ResultRef is the reference to the service result type.
+		ResultRef string
+		// Key describes the result field used as message key if any.
+		Key *KeyData
+		// Proto is true if the events are serialized with protocol
+		// buffer.
+		Proto bool
+		// ProtoInit is the name of the function that converts the
+		// service result type to the gRPC message if the events are
+		// serialized with protocol buffer.
+		ProtoInit string
+		// ViewedResultInit is the name of the function that projects
+		// the result if the gRPC message is built from a viewed result.
+		ViewedResultInit string
+		// ViewName is the name of the view used to project the result.
+		ViewName string
+	}
+
+	// KeyData describes the result field used as message key.
+	KeyData struct {
+		// FieldName is the name of the result struct field.
+		FieldName string
+		// Pointer is true if the field is a pointer.
+		Pointer bool
+		// Kind is the kind of the field type.
+		Kind expr.Kind
+	}
+)
+
+// Files returns the files containing the publishers of the events streamed by
+// the methods that use the Publish DSL. There is one file per service.
+func Files(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.Services {
+		if f := file(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// file returns the file containing the publishers of the given service, nil
+// if no method of the service publishes its results.
+func file(genpkg string, svc *expr.ServiceExpr) *codegen.File {
+	pubs, hasJSON, hasProto, hasFmt := analyze(svc)
+	if len(pubs) == 0 {
+		return nil
+	}
+	data := service.Services.Get(svc.Name)
+	fpath := filepath.Join(codegen.Gendir, "events", data.PathName, "publisher", "publisher.go")
+	imports := []*codegen.ImportSpec{{Path: "context"}}
+	if hasJSON {
+		imports = append(imports, &codegen.ImportSpec{Path: "encoding/json"})
+	}
+	if hasFmt {
+		imports = append(imports, &codegen.ImportSpec{Path: "fmt"})
+	}
+	imports = append(imports, &codegen.ImportSpec{Path: path.Join(genpkg, data.PathName), Name: data.PkgName})
+	if hasProto {
+		imports = append(imports,
+			&codegen.ImportSpec{Path: path.Join(genpkg, "grpc", data.PathName, "server"), Name: data.PkgName + "svr"},
+			&codegen.ImportSpec{Path: "google.golang.org/protobuf/proto"})
+	}
+	imports = append(imports, data.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(fmt.Sprintf("%s event publishers", svc.Name), "publisher", imports),
+		{Name: "publisher-send-func", Source: sendFuncT},
+	}
+	for _, p := range pubs {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "publisher",
+			Source: publisherT,
+			Data:   p,
+			FuncMap: map[string]interface{}{
+				"keyExpr": keyExpr,
+			},
+		})
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
+// analyze returns the data needed to render the publishers of the given
+// service grouped by broker in the order of the methods. It also returns
+// whether the events are serialized with JSON and protocol buffer and whether
+// a message key must be formatted with the fmt package.
+func analyze(svc *expr.ServiceExpr) (pubs []*PublisherData, hasJSON, hasProto, hasFmt bool) {
+	var (
+		data     = service.Services.Get(svc.Name)
+		scope    = codegen.NewNameScope()
+		byBroker = make(map[string]*PublisherData)
+	)
+	for _, m := range svc.Methods {
+		p := m.Publish
+		if p == nil {
+			continue
+		}
+		pub, ok := byBroker[p.Broker]
+		if !ok {
+			name := codegen.Goify(p.Broker, true) + "Publisher"
+			pub = &PublisherData{
+				Broker:      p.Broker,
+				Interface:   scope.Unique(name),
+				VarName:     scope.Unique(codegen.Goify(p.Broker, false) + "Publisher"),
+				Init:        scope.Unique("New" + name),
+				ServiceName: svc.Name,
+			}
+			byBroker[p.Broker] = pub
+			pubs = append(pubs, pub)
+		}
+		md := data.Method(m.Name)
+		pkg := data.PkgName
+		if md.ResultLoc != nil {
+			pkg = md.ResultLoc.PackageName()
+		}
+		pmd := &MethodData{
+			Name:      m.Name,
+			VarName:   md.VarName,
+			Topic:     p.Topic,
+			ResultRef: data.Scope.GoFullTypeRef(m.Result, pkg),
+			Proto:     p.Format == expr.PublishFormatProto,
+		}
+		if p.Key != "" {
+			att := expr.AsObject(m.Result.Type).Attribute(p.Key)
+			pmd.Key = &KeyData{
+				FieldName: codegen.GoifyAtt(att, p.Key, true),
+				Pointer:   m.Result.IsPrimitivePointer(p.Key, true),
+				Kind:      att.Type.Kind(),
+			}
+			if pmd.Key.Kind != expr.StringKind && pmd.Key.Kind != expr.BytesKind {
+				hasFmt = true
+			}
+		}
+		if pmd.Proto {
+			hasProto = true
+			ed := grpccodegen.GRPCServices.Get(svc.Name).Endpoint(m.Name)
+			pmd.ProtoInit = data.PkgName + "svr." + ed.ServerStream.SendConvert.Init.Name
+			if vr := md.ViewedResult; vr != nil {
+				pmd.ViewedResultInit = data.PkgName + "." + vr.Init.Name
+				pmd.ViewName = vr.ViewName
+				if pmd.ViewName == "" {
+					pmd.ViewName = expr.DefaultView
+				}
+			}
+		} else {
+			hasJSON = true
+		}
+		pub.Methods = append(pub.Methods, pmd)
+	}
+	return
+}
+
+// keyExpr returns the Go expression that converts the value of the message
+// key field v to a byte slice.
+func keyExpr(k *KeyData, v string) string {
+	switch k.Kind {
+	case expr.StringKind:
+		return "[]byte(" + v + ")"
+	case expr.BytesKind:
+		return v
+	default:
+		return "[]byte(fmt.Sprint(" + v + "))"
+	}
+}
+
+// input: none
+const sendFuncT = `// SendFunc sends a message with the given key and value to the topic of a
+// message broker. The key is nil if the message has no key.
+type SendFunc func(ctx context.Context, topic string, key, value []byte) error
+`
+
+// input: PublisherData
+const publisherT = `{{ printf "%s publishes the results streamed by the %q service methods to the %q message broker." .Interface .ServiceName .Broker | comment }}
+type {{ .Interface }} interface {
+{{- range .Methods }}
+	{{ printf "%s publishes the results streamed by the %q method to the %q topic." .VarName .Name .Topic | comment }}
+	{{ .VarName }}(ctx context.Context, ev {{ .ResultRef }}) error
+{{- end }}
+}
+
+{{ printf "%s implements %s." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+	send SendFunc
+}
+
+{{ printf "%s returns a %s that serializes the events and sends them to the message broker with send." .Init .Interface | comment }}
+func {{ .Init }}(send SendFunc) {{ .Interface }} {
+	return &{{ .VarName }}{send: send}
+}
+{{- $pub := . }}
+{{- range .Methods }}
+
+{{ printf "%s publishes the results streamed by the %q method to the %q topic." .VarName .Name .Topic | comment }}
+func (p *{{ $pub.VarName }}) {{ .VarName }}(ctx context.Context, ev {{ .ResultRef }}) error {
+{{- if .Proto }}
+	{{- if .ViewedResultInit }}
+	vres := {{ .ViewedResultInit }}(ev, {{ printf "%q" .ViewName }})
+	value, err := proto.Marshal({{ .ProtoInit }}(vres.Projected))
+	{{- else }}
+	value, err := proto.Marshal({{ .ProtoInit }}(ev))
+	{{- end }}
+{{- else }}
+	value, err := json.Marshal(ev)
+{{- end }}
+	if err != nil {
+		return err
+	}
+{{- if .Key }}
+	{{- if .Key.Pointer }}
+	var key []byte
+	if ev.{{ .Key.FieldName }} != nil {
+		key = {{ keyExpr .Key (printf "*ev.%s" .Key.FieldName) }}
+	}
+	{{- else }}
+	key := {{ keyExpr .Key (printf "ev.%s" .Key.FieldName) }}
+	{{- end }}
+	return p.send(ctx, {{ printf "%q" .Topic }}, key, value)
+{{- else }}
+	return p.send(ctx, {{ printf "%q" .Topic }}, nil, value)
+{{- end }}
+}
+{{- end }}
+`
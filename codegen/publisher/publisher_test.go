@@ -0,0 +1,38 @@
+package publisher
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/publisher/testdata"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+	grpccodegen "goa.design/goa/v3/grpc/codegen"
+)
+
+func TestPublisher(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"json", testdata.PublishJSONDSL, testdata.PublishJSONCode},
+		{"numeric-key", testdata.PublishNumericKeyDSL, testdata.PublishNumericKeyCode},
+		{"proto", testdata.PublishProtoDSL, testdata.PublishProtoCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			service.Services = make(service.ServicesData)
+			grpccodegen.GRPCServices = make(grpccodegen.ServicesData)
+			codegen.RunDSL(t, c.DSL)
+			fs := Files("gen", expr.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			code := codegen.SectionsCode(t, fs[0].SectionTemplates[1:])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
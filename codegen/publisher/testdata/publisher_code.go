@@ -0,0 +1,122 @@
+package testdata
+
+const PublishJSONCode = `// SendFunc sends a message with the given key and value to the topic of a
+// message broker. The key is nil if the message has no key.
+type SendFunc func(ctx context.Context, topic string, key, value []byte) error
+
+// KafkaPublisher publishes the results streamed by the "PublishJSON" service
+// methods to the "kafka" message broker.
+type KafkaPublisher interface {
+	// Created publishes the results streamed by the "Created" method to the
+	// "users.created" topic.
+	Created(ctx context.Context, ev *publishjson.User) error
+	// Renamed publishes the results streamed by the "Renamed" method to the
+	// "users.renamed" topic.
+	Renamed(ctx context.Context, ev *publishjson.User) error
+}
+
+// kafkaPublisher implements KafkaPublisher.
+type kafkaPublisher struct {
+	send SendFunc
+}
+
+// NewKafkaPublisher returns a KafkaPublisher that serializes the events and
+// sends them to the message broker with send.
+func NewKafkaPublisher(send SendFunc) KafkaPublisher {
+	return &kafkaPublisher{send: send}
+}
+
+// Created publishes the results streamed by the "Created" method to the
+// "users.created" topic.
+func (p *kafkaPublisher) Created(ctx context.Context, ev *publishjson.User) error {
+	value, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	var key []byte
+	if ev.ID != nil {
+		key = []byte(*ev.ID)
+	}
+	return p.send(ctx, "users.created", key, value)
+}
+
+// Renamed publishes the results streamed by the "Renamed" method to the
+// "users.renamed" topic.
+func (p *kafkaPublisher) Renamed(ctx context.Context, ev *publishjson.User) error {
+	value, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return p.send(ctx, "users.renamed", nil, value)
+}
+`
+
+const PublishNumericKeyCode = `// SendFunc sends a message with the given key and value to the topic of a
+// message broker. The key is nil if the message has no key.
+type SendFunc func(ctx context.Context, topic string, key, value []byte) error
+
+// NatsPublisher publishes the results streamed by the "PublishNumericKey"
+// service methods to the "nats" message broker.
+type NatsPublisher interface {
+	// Ticks publishes the results streamed by the "Ticks" method to the "ticks"
+	// topic.
+	Ticks(ctx context.Context, ev *publishnumerickey.TicksResult) error
+}
+
+// natsPublisher implements NatsPublisher.
+type natsPublisher struct {
+	send SendFunc
+}
+
+// NewNatsPublisher returns a NatsPublisher that serializes the events and
+// sends them to the message broker with send.
+func NewNatsPublisher(send SendFunc) NatsPublisher {
+	return &natsPublisher{send: send}
+}
+
+// Ticks publishes the results streamed by the "Ticks" method to the "ticks"
+// topic.
+func (p *natsPublisher) Ticks(ctx context.Context, ev *publishnumerickey.TicksResult) error {
+	value, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	key := []byte(fmt.Sprint(ev.N))
+	return p.send(ctx, "ticks", key, value)
+}
+`
+
+const PublishProtoCode = `// SendFunc sends a message with the given key and value to the topic of a
+// message broker. The key is nil if the message has no key.
+type SendFunc func(ctx context.Context, topic string, key, value []byte) error
+
+// KafkaPublisher publishes the results streamed by the "PublishProto" service
+// methods to the "kafka" message broker.
+type KafkaPublisher interface {
+	// Feed publishes the results streamed by the "Feed" method to the "items"
+	// topic.
+	Feed(ctx context.Context, ev *publishproto.Item) error
+}
+
+// kafkaPublisher implements KafkaPublisher.
+type kafkaPublisher struct {
+	send SendFunc
+}
+
+// NewKafkaPublisher returns a KafkaPublisher that serializes the events and
+// sends them to the message broker with send.
+func NewKafkaPublisher(send SendFunc) KafkaPublisher {
+	return &kafkaPublisher{send: send}
+}
+
+// Feed publishes the results streamed by the "Feed" method to the "items"
+// topic.
+func (p *kafkaPublisher) Feed(ctx context.Context, ev *publishproto.Item) error {
+	vres := publishproto.NewViewedItem(ev, "default")
+	value, err := proto.Marshal(publishprotosvr.NewProtoItemViewFeedResponse(vres.Projected))
+	if err != nil {
+		return err
+	}
+	return p.send(ctx, "items", nil, value)
+}
+`
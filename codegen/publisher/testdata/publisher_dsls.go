@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var PublishJSONDSL = func() {
+	var User = Type("User", func() {
+		Attribute("id", String)
+		Attribute("name", String)
+	})
+	Service("PublishJSON", func() {
+		Method("Created", func() {
+			StreamingResult(User)
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+				MessageKey("id")
+			})
+		})
+		Method("Renamed", func() {
+			StreamingResult(User)
+			Publish(func() {
+				Topic("users.renamed")
+				Broker("kafka")
+			})
+		})
+	})
+}
+
+var PublishNumericKeyDSL = func() {
+	Service("PublishNumericKey", func() {
+		Method("Ticks", func() {
+			StreamingResult(func() {
+				Attribute("n", Int64)
+				Required("n")
+			})
+			Publish(func() {
+				Topic("ticks")
+				Broker("nats")
+				MessageKey("n")
+			})
+		})
+	})
+}
+
+var PublishProtoDSL = func() {
+	var Item = ResultType("application/vnd.item", func() {
+		Attributes(func() {
+			Field(1, "id", String)
+			Field(2, "name", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("PublishProto", func() {
+		Method("Feed", func() {
+			StreamingResult(Item)
+			Publish(func() {
+				Topic("items")
+				Broker("kafka")
+				Serialization("proto")
+			})
+			GRPC(func() {})
+		})
+	})
+}
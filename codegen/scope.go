@@ -147,13 +147,13 @@ func (s *NameScope) goTypeDef(att *expr.AttributeExpr, ptr, useDefault bool, pkg
 				tdef = s.goTypeDef(at, ptr, useDefault, pkg)
 				if expr.IsObject(at.Type) ||
 					att.IsPrimitivePointer(name, useDefault) ||
-					(ptr && expr.IsPrimitive(at.Type) && at.Type.Kind() != expr.AnyKind && at.Type.Kind() != expr.BytesKind) {
+					(ptr && expr.IsPrimitive(at.Type) && at.Type.Kind() != expr.AnyKind && at.Type.Kind() != expr.BytesKind && !at.IsNullable()) {
 					tdef = "*" + tdef
 				}
 				if at.Description != "" {
 					desc = Comment(at.Description) + "\n\t"
 				}
-				tags = AttributeTags(att, at)
+				tags = fieldTags(att, at, name)
 			}
 			ss = append(ss, fmt.Sprintf("\t%s%s %s%s", desc, fn, tdef, tags))
 		}
@@ -24,8 +24,11 @@ func ClientFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		imports := []*codegen.ImportSpec{
 			{Path: "context"},
 			{Path: "io"},
-			codegen.GoaImport(""),
 		}
+		if hasLongRunning(data) {
+			imports = append(imports, &codegen.ImportSpec{Path: "time"})
+		}
+		imports = append(imports, codegen.GoaImport(""))
 		imports = append(imports, svc.UserTypeImports...)
 		header := codegen.Header(service.Name+" client", svc.PkgName, imports)
 		def := &codegen.SectionTemplate{
@@ -46,11 +49,38 @@ func ClientFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				Data:   m,
 			})
 		}
+		if hasLongRunning(data) {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-poll-options",
+				Source: serviceClientPollOptionsT,
+			})
+			for _, m := range data.Methods {
+				if m.LongRunning == nil {
+					continue
+				}
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-poll",
+					Source: serviceClientPollT,
+					Data:   m,
+				})
+			}
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
+// hasLongRunning returns true if at least one method of the service is
+// long-running.
+func hasLongRunning(data *endpointsData) bool {
+	for _, m := range data.Methods {
+		if m.LongRunning != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // input: endpointsData
 const serviceClientT = `// {{ .ClientVarName }} is the {{ printf "%q" .Name }} service client.
 type {{ .ClientVarName }} struct {
@@ -105,3 +135,88 @@ func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .Payloa
 	{{- end }}
 }
 `
+
+// input: none
+const serviceClientPollOptionsT = `// PollOption configures the polling of the jobs returned by the long-running
+// methods.
+type PollOption func(*pollConfig)
+
+// pollConfig holds the polling configuration.
+type pollConfig struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	backoff     float64
+}
+
+// WithPollInterval sets the interval between the first two polls, defaults to
+// one second.
+func WithPollInterval(d time.Duration) PollOption {
+	return func(c *pollConfig) { c.interval = d }
+}
+
+// WithPollMaxInterval sets the maximum interval between two polls, defaults to
+// one minute.
+func WithPollMaxInterval(d time.Duration) PollOption {
+	return func(c *pollConfig) { c.maxInterval = d }
+}
+
+// WithPollBackoff sets the factor the interval between two polls is multiplied
+// by after each poll, defaults to 2. A factor of 1 polls at a constant
+// interval.
+func WithPollBackoff(f float64) PollOption {
+	return func(c *pollConfig) { c.backoff = f }
+}
+
+// newPollConfig returns the polling configuration set by the given options.
+func newPollConfig(opts []PollOption) *pollConfig {
+	c := &pollConfig{interval: time.Second, maxInterval: time.Minute, backoff: 2}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// next returns the interval to wait before the poll following an interval of
+// d.
+func (c *pollConfig) next(d time.Duration) time.Duration {
+	d = time.Duration(float64(d) * c.backoff)
+	if d > c.maxInterval {
+		d = c.maxInterval
+	}
+	return d
+}
+`
+
+// input: endpointMethodData
+const serviceClientPollT = `
+{{ printf "%s calls the %q endpoint until done returns true for the job returned by the %q endpoint. It returns the last polled job or the error returned by the %q endpoint. It returns the context error if ctx is canceled before the job is done." .LongRunning.PollFunc .LongRunning.PollMethod .Name .LongRunning.PollMethod | comment }}
+func (c *{{ .ClientVarName }}) {{ .LongRunning.PollFunc }}(ctx context.Context, job {{ .LongRunning.JobRef }}, done func({{ .LongRunning.JobRef }}) bool, opts ...PollOption) ({{ .LongRunning.JobRef }}, error) {
+	cfg := newPollConfig(opts)
+	interval := cfg.interval
+	for !done(job) {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	{{- if .LongRunning.PayloadName }}
+		p := &{{ .LongRunning.PayloadName }}{
+		{{- range .LongRunning.Fields }}
+			{{ .FieldName }}: {{ if .Pointer }}&{{ end }}job.{{ .FieldName }},
+		{{- end }}
+		}
+		res, err := c.{{ .LongRunning.PollVarName }}(ctx, p)
+	{{- else }}
+		res, err := c.{{ .LongRunning.PollVarName }}(ctx)
+	{{- end }}
+		if err != nil {
+			return nil, err
+		}
+		job = res
+		interval = cfg.next(interval)
+	}
+	return job, nil
+}
+`
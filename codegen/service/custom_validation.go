@@ -0,0 +1,245 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// CustomValidationData describes a custom validation function defined
+	// with the CustomValidation DSL.
+	CustomValidationData struct {
+		// Name is the name given to the CustomValidation DSL.
+		Name string
+		// FieldName is the name of the CustomValidations struct field
+		// that holds the function.
+		FieldName string
+		// TypeRef is the Go type of the values validated by the
+		// function.
+		TypeRef string
+	}
+
+	// CustomValidateMethodData describes a method of the CustomValidations
+	// struct that runs the custom validations of a payload or user type.
+	CustomValidateMethodData struct {
+		// Name is the name of the method.
+		Name string
+		// ArgRef is a reference to the type of the validated value.
+		ArgRef string
+		// Validate is the validation code.
+		Validate string
+	}
+
+	// customValidator generates the code that runs the custom validations
+	// of the service payloads.
+	customValidator struct {
+		scope   *codegen.NameScope
+		funcs   map[string]*CustomValidationData
+		methods []*CustomValidateMethodData
+		// types records the names of the methods that validate the
+		// user types indexed by user type ID.
+		types map[string]string
+	}
+)
+
+var customCallT = template.Must(template.New("customCall").Parse(customCallTmpl))
+
+// collectCustomValidations returns the custom validation functions used by the
+// payloads of the service methods sorted by name and the methods that run
+// them. It sets the CustomValidate field of the methods whose payload defines
+// custom validations.
+func collectCustomValidations(service *expr.ServiceExpr, methods []*MethodData, scope *codegen.NameScope) ([]*CustomValidationData, []*CustomValidateMethodData) {
+	cv := &customValidator{
+		scope: scope,
+		funcs: make(map[string]*CustomValidationData),
+		types: make(map[string]string),
+	}
+	for i, m := range service.Methods {
+		if m.Payload == nil || m.Payload.Type == expr.Empty || !hasCustomValidations(m.Payload) {
+			continue
+		}
+		if ut, ok := m.Payload.Type.(expr.UserType); ok && expr.IsObject(ut) {
+			methods[i].CustomValidate = cv.userTypeMethod(ut)
+			continue
+		}
+		name := "validate" + methods[i].VarName + "Payload"
+		cv.methods = append(cv.methods, &CustomValidateMethodData{
+			Name:     name,
+			ArgRef:   methods[i].PayloadRef,
+			Validate: cv.code(m.Payload, "v", "payload", false),
+		})
+		methods[i].CustomValidate = name
+	}
+	if len(cv.funcs) == 0 {
+		return nil, nil
+	}
+	funcs := make([]*CustomValidationData, 0, len(cv.funcs))
+	for _, f := range cv.funcs {
+		funcs = append(funcs, f)
+	}
+	sort.Slice(funcs, func(i, j int) bool { return funcs[i].Name < funcs[j].Name })
+	return funcs, cv.methods
+}
+
+// hasCustomValidations returns true if the attribute or any of its children
+// defines custom validations.
+func hasCustomValidations(att *expr.AttributeExpr) bool {
+	done := errors.New("done")
+	return codegen.Walk(att, func(a *expr.AttributeExpr) error {
+		if a.Validation != nil && len(a.Validation.Custom) > 0 {
+			return done
+		}
+		return nil
+	}) == done
+}
+
+// code returns the code that runs the custom validations defined on att
+// against the value held by target. context is the path of the value used in
+// the validation errors. ptr is true if target is a pointer to a primitive
+// value. The generated code assumes that there is a pre-existing "err"
+// variable of type error and a "cv" variable holding the CustomValidations.
+func (cv *customValidator) code(att *expr.AttributeExpr, target, context string, ptr bool) string {
+	if !hasCustomValidations(att) {
+		return ""
+	}
+	if expr.IsPrimitive(att.Type) {
+		return cv.primitiveCode(att, target, context, ptr)
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		name := cv.userTypeMethod(dt)
+		call := fmt.Sprintf("if err2 := cv.%s(%s); err2 != nil {\nerr = goa.MergeErrors(err, goa.NestErrors(err2, %q))\n}", name, target, context)
+		if expr.IsObject(dt) {
+			return fmt.Sprintf("if %s != nil {\n%s\n}", target, call)
+		}
+		return call
+	case *expr.Object:
+		var buf bytes.Buffer
+		for _, nat := range *dt {
+			tgt := fmt.Sprintf("%s.%s", target, codegen.GoifyAtt(nat.Attribute, nat.Name, true))
+			ctx := fmt.Sprintf("%s.%s", context, nat.Name)
+			if code := cv.code(nat.Attribute, tgt, ctx, att.IsPrimitivePointer(nat.Name, true)); code != "" {
+				if buf.Len() > 0 {
+					buf.WriteByte('\n')
+				}
+				buf.WriteString(code)
+			}
+		}
+		return buf.String()
+	case *expr.Array:
+		code := cv.code(dt.ElemType, "e", context+"[*]", false)
+		if code == "" {
+			return ""
+		}
+		return fmt.Sprintf("for i, e := range %s {\nerr = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {\n%s\nreturn\n}(), %q, i))\n}", target, code, context)
+	case *expr.Map:
+		key := cv.code(dt.KeyType, "k", context+".key", false)
+		val := cv.code(dt.ElemType, "v", context+"[key]", false)
+		if key == "" && val == "" {
+			return ""
+		}
+		k, v := "k", "v"
+		if val == "" {
+			v = "_"
+		} else {
+			val = fmt.Sprintf("\nerr = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {\n%s\nreturn\n}(), %q, k))", val, context)
+		}
+		if key != "" {
+			key = "\n" + key
+		}
+		return fmt.Sprintf("for %s, %s := range %s {%s%s\n}", k, v, target, key, val)
+	}
+	// The values of unions are validated by the transports only.
+	return ""
+}
+
+// primitiveCode returns the code that calls the custom validation functions
+// defined on the primitive attribute att.
+func (cv *customValidator) primitiveCode(att *expr.AttributeExpr, target, context string, ptr bool) string {
+	var names []string
+	if att.Validation != nil {
+		names = append(names, att.Validation.Custom...)
+	}
+	base := att.Type
+	if ut, ok := att.Type.(expr.UserType); ok {
+		if v := ut.Attribute().Validation; v != nil {
+			names = append(names, v.Custom...)
+		}
+		base = ut.Attribute().Type
+	}
+	typeRef := codegen.GoNativeTypeName(base)
+	value := target
+	switch {
+	case att.IsNullable():
+		value = target + ".Value"
+	case ptr:
+		value = "*" + target
+	}
+	arg := value
+	if base != att.Type {
+		arg = fmt.Sprintf("%s(%s)", typeRef, value)
+	}
+	key, _ := att.Meta.Last("i18n:key")
+	var buf bytes.Buffer
+	seen := make(map[string]struct{})
+	for _, n := range names {
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		f, ok := cv.funcs[n]
+		if !ok {
+			f = &CustomValidationData{Name: n, FieldName: codegen.Goify(n, true), TypeRef: typeRef}
+			cv.funcs[n] = f
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		data := map[string]interface{}{
+			"FieldName": f.FieldName,
+			"Name":      n,
+			"Arg":       arg,
+			"Value":     value,
+			"Context":   context,
+			"Key":       key,
+		}
+		if err := customCallT.Execute(&buf, data); err != nil {
+			panic(err) // bug
+		}
+	}
+	code := buf.String()
+	switch {
+	case att.IsNullable():
+		return fmt.Sprintf("if %s.HasValue() {\n%s\n}", target, code)
+	case ptr:
+		return fmt.Sprintf("if %s != nil {\n%s\n}", target, code)
+	}
+	return code
+}
+
+// userTypeMethod returns the name of the method that runs the custom
+// validations of the given user type, it generates the method the first time
+// the type is used.
+func (cv *customValidator) userTypeMethod(ut expr.UserType) string {
+	if name, ok := cv.types[ut.ID()]; ok {
+		return name
+	}
+	att := &expr.AttributeExpr{Type: ut}
+	name := "validate" + cv.scope.GoTypeName(att) + "Type"
+	cv.types[ut.ID()] = name
+	m := &CustomValidateMethodData{Name: name, ArgRef: cv.scope.GoTypeRef(att)}
+	cv.methods = append(cv.methods, m)
+	m.Validate = cv.code(ut.Attribute(), "v", ut.Name(), false)
+	return name
+}
+
+// input: map[string]interface{}{"FieldName": string, "Name": string, "Arg": string, "Value": string, "Context": string, "Key": string}
+const customCallTmpl = `if err2 := cv.{{ .FieldName }}({{ .Arg }}); err2 != nil {
+	err = goa.MergeErrors(err, {{ if .Key }}goa.WithI18nKey({{ end }}goa.InvalidCustomError({{ printf "%q" .Context }}, {{ printf "%q" .Name }}, {{ .Value }}, err2){{ if .Key }}, {{ printf "%q" .Key }}){{ end }})
+}`
@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"goa.design/goa/v3/codegen"
@@ -30,6 +31,18 @@ type (
 		// Schemes contains the security schemes types used by the
 		// all the endpoints.
 		Schemes SchemesData
+		// CustomValidations lists the custom validation functions run by
+		// the endpoints.
+		CustomValidations []*CustomValidationData
+		// CustomValidateMethods lists the methods of the
+		// CustomValidations struct that run the custom validations.
+		CustomValidateMethods []*CustomValidateMethodData
+		// Validator is true if the endpoints validate the payloads with
+		// the service Validator interface.
+		Validator bool
+		// Features lists the feature flags that gate the service
+		// methods sorted by name.
+		Features []*FeatureGateData
 	}
 
 	// endpointMethodData describes a single endpoint method.
@@ -44,6 +57,12 @@ type (
 		ServiceName string
 		// ServiceVarName is the name of the owner service Go interface.
 		ServiceVarName string
+		// Validate is true if the endpoint validates the payload with the
+		// service Validator interface.
+		Validate bool
+		// DefaultValidation is the body of the DefaultValidator method
+		// that validates the payload.
+		DefaultValidation string
 	}
 )
 
@@ -74,6 +93,30 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			codegen.GoaImport("security"),
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: svc.ViewsPkg},
 		}
+		for _, m := range data.Methods {
+			if m.Concurrency != nil && m.Concurrency.QueueTimeout != "" {
+				imports = append(imports, &codegen.ImportSpec{Path: "time"})
+				break
+			}
+		}
+		if data.Validator {
+			imports = append(imports, &codegen.ImportSpec{Path: "unicode/utf8"})
+		}
+		seen := make(map[string]struct{}, len(imports))
+		for _, imp := range imports {
+			seen[imp.Path] = struct{}{}
+		}
+		for _, m := range service.Methods {
+			for _, d := range m.ServerDefaults {
+				for _, path := range d.Imports {
+					if _, ok := seen[path]; ok {
+						continue
+					}
+					seen[path] = struct{}{}
+					imports = append(imports, &codegen.ImportSpec{Path: path})
+				}
+			}
+		}
 		imports = append(imports, svc.UserTypeImports...)
 		header := codegen.Header(service.Name+" endpoints", svc.PkgName, imports)
 		def := &codegen.SectionTemplate{
@@ -105,6 +148,20 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 				})
 			}
 		}
+		if data.Validator {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoints-validator",
+				Source: serviceEndpointsValidatorT,
+				Data:   data,
+			})
+		}
+		if len(data.CustomValidations) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoints-custom-validations",
+				Source: serviceEndpointsCustomValidationsT,
+				Data:   data,
+			})
+		}
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "endpoints-init",
 			Source: serviceEndpointsInitT,
@@ -115,14 +172,43 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 			Source: serviceEndpointsUseT,
 			Data:   data,
 		})
+		if len(data.Features) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoints-use-feature-checker",
+				Source: serviceEndpointsUseFeatureCheckerT,
+				Data:   data,
+			})
+		}
 		for _, m := range data.Methods {
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:    "endpoint-method",
-				Source:  serviceEndpointMethodT,
-				Data:    m,
-				FuncMap: map[string]interface{}{"payloadVar": payloadVar},
+				Name:   "endpoint-method",
+				Source: serviceEndpointMethodT,
+				Data:   m,
+				FuncMap: map[string]interface{}{
+					"payloadVar":         payloadVar,
+					"forbiddenError":     forbiddenError,
+					"forbiddenErrorName": func() string { return expr.ForbiddenErrorName },
+				},
 			})
 		}
+		for _, m := range data.Methods {
+			if m.Concurrency != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:    "endpoint-concurrency-limiter",
+					Source:  serviceEndpointConcurrencyLimiterT,
+					Data:    m,
+					FuncMap: map[string]interface{}{"unavailableErrorName": func() string { return expr.UnavailableErrorName }},
+				})
+			}
+			if m.FeatureGate != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:    "endpoint-feature-gate",
+					Source:  serviceEndpointFeatureGateT,
+					Data:    m,
+					FuncMap: map[string]interface{}{"featureDisabledErrorName": func() string { return expr.FeatureDisabledErrorName }},
+				})
+			}
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -130,6 +216,7 @@ func EndpointFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 
 func endpointData(service *expr.ServiceExpr) *endpointsData {
 	svc := Services.Get(service.Name)
+	validator := hasValidator(service)
 	methods := make([]*endpointMethodData, len(svc.Methods))
 	names := make([]string, len(svc.Methods))
 	for i, m := range svc.Methods {
@@ -140,6 +227,10 @@ func endpointData(service *expr.ServiceExpr) *endpointsData {
 			ServiceVarName: serviceInterfaceName,
 			ClientVarName:  clientStructName,
 		}
+		if validator && m.PayloadRef != "" {
+			methods[i].Validate = true
+			methods[i].DefaultValidation = defaultValidation(service.Method(m.Name), svc.Scope)
+		}
 		names[i] = codegen.Goify(m.VarName, false)
 	}
 	desc := fmt.Sprintf("%s wraps the %q service endpoints.", endpointsStructName, service.Name)
@@ -152,7 +243,70 @@ func endpointData(service *expr.ServiceExpr) *endpointsData {
 		ClientInitArgs: strings.Join(names, ", "),
 		Methods:        methods,
 		Schemes:        svc.Schemes,
+
+		CustomValidations:     svc.CustomValidations,
+		CustomValidateMethods: svc.customValidateMethods,
+		Validator:             validator,
+		Features:              features(svc.Methods),
+	}
+}
+
+// features returns the data of the feature flags that gate the given methods
+// sorted by name. Methods gated by the same flag share its data.
+func features(methods []*MethodData) []*FeatureGateData {
+	seen := make(map[string]struct{})
+	var fs []*FeatureGateData
+	for _, m := range methods {
+		if m.FeatureGate == nil {
+			continue
+		}
+		if _, ok := seen[m.FeatureGate.Feature]; ok {
+			continue
+		}
+		seen[m.FeatureGate.Feature] = struct{}{}
+		fs = append(fs, m.FeatureGate)
 	}
+	sort.Slice(fs, func(i, j int) bool { return fs[i].Feature < fs[j].Feature })
+	return fs
+}
+
+// hasValidator returns true if the service or the API enables the generation
+// of the Validator interface with the "validate:interface" meta.
+func hasValidator(service *expr.ServiceExpr) bool {
+	if v, ok := service.Meta.Last("validate:interface"); ok {
+		return v == "true"
+	}
+	v, _ := expr.Root.API.Meta.Last("validate:interface")
+	return v == "true"
+}
+
+// defaultValidation returns the body of the DefaultValidator method that runs
+// the validations defined on the payload of the given method.
+func defaultValidation(m *expr.MethodExpr, scope *codegen.NameScope) string {
+	ctx := validateContext(scope)
+	if ut, ok := m.Payload.Type.(expr.UserType); ok {
+		if codegen.HasValidateMethod(ctx, ut) {
+			return "return p.Validate()"
+		}
+		return "return nil"
+	}
+	code := codegen.ValidationCodeWithContext(m.Payload, nil, ctx, true, false, "p", "payload")
+	if code == "" {
+		return "return nil"
+	}
+	return "var err error\n" + code + "\nreturn err"
+}
+
+// forbiddenError returns the name of the function that builds the method
+// error returned when the request is not granted the required scopes, empty
+// if the method does not define one.
+func forbiddenError(e *endpointMethodData) string {
+	for _, er := range e.Errors {
+		if er.ErrName == expr.ForbiddenErrorName {
+			return er.Name
+		}
+	}
+	return ""
 }
 
 func payloadVar(e *endpointMethodData) string {
@@ -172,17 +326,90 @@ type {{ .VarName }} struct {
 `
 
 // input: endpointsData
-const serviceEndpointsInitT = `{{ printf "New%s wraps the methods of the %q service with endpoints." .VarName .Name | comment }}
-func New{{ .VarName }}(s {{ .ServiceVarName }}) *{{ .VarName }} {
+const serviceEndpointsValidatorT = `{{ printf "Validator validates the payloads of the %q service methods before the endpoints call the service. The endpoints created with New%s use DefaultValidator, use New%sWithValidator to provide a different implementation." .Name .VarName .VarName | comment }}
+type Validator interface {
+{{- range .Methods }}
+	{{- if .Validate }}
+	{{ printf "Validate%s validates the payload of the %q method." .VarName .Name | comment }}
+	Validate{{ .VarName }}(context.Context, {{ .PayloadRef }}) error
+	{{- end }}
+{{- end }}
+}
+
+// DefaultValidator implements Validator by running the validations defined in
+// the design.
+type DefaultValidator struct{}
+{{ range .Methods }}
+	{{- if .Validate }}
+
+{{ printf "Validate%s runs the validations defined on the payload of the %q method." .VarName .Name | comment }}
+func (DefaultValidator) Validate{{ .VarName }}(ctx context.Context, p {{ .PayloadRef }}) error {
+	{{ .DefaultValidation }}
+}
+	{{- end }}
+{{- end }}
+`
+
+// input: endpointsData
+const serviceEndpointsCustomValidationsT = `{{ printf "CustomValidations holds the custom validation functions run by the %q service endpoints once the payloads pass the validations defined in the design." .Name | comment }}
+type CustomValidations struct {
+{{- range .CustomValidations }}
+	{{ printf "%s implements the %q custom validation." .FieldName .Name | comment }}
+	{{ .FieldName }} func(v {{ .TypeRef }}) error
+{{- end }}
+}
+
+// check returns an error if cv does not hold all the custom validation
+// functions.
+func (cv *CustomValidations) check() error {
+	if cv == nil {
+		return fmt.Errorf("missing custom validations")
+	}
+	var missing []string
+{{- range .CustomValidations }}
+	if cv.{{ .FieldName }} == nil {
+		missing = append(missing, {{ printf "%q" .Name }})
+	}
+{{- end }}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing custom validations %q", missing)
+	}
+	return nil
+}
+{{- range .CustomValidateMethods }}
+
+{{ printf "%s runs the custom validations of v." .Name | comment }}
+func (cv *CustomValidations) {{ .Name }}(v {{ .ArgRef }}) (err error) {
+	{{ .Validate }}
+	return
+}
+{{- end }}
+`
+
+// input: endpointsData
+const serviceEndpointsInitT = `{{ if .CustomValidations }}{{ printf "New%s wraps the methods of the %q service with endpoints. It returns an error if cv does not hold all the custom validation functions." .VarName .Name | comment }}{{ else }}{{ printf "New%s wraps the methods of the %q service with endpoints." .VarName .Name | comment }}{{ end }}
+func New{{ .VarName }}(s {{ .ServiceVarName }}{{ if .CustomValidations }}, cv *CustomValidations{{ end }}) {{ if .CustomValidations }}(*{{ .VarName }}, error){{ else }}*{{ .VarName }}{{ end }} {
+{{- if .Validator }}
+	return New{{ .VarName }}WithValidator(s, DefaultValidator{}{{ if .CustomValidations }}, cv{{ end }})
+}
+
+{{ if .CustomValidations }}{{ printf "New%sWithValidator wraps the methods of the %q service with endpoints that validate the payloads with v. It returns an error if cv does not hold all the custom validation functions." .VarName .Name | comment }}{{ else }}{{ printf "New%sWithValidator wraps the methods of the %q service with endpoints that validate the payloads with v." .VarName .Name | comment }}{{ end }}
+func New{{ .VarName }}WithValidator(s {{ .ServiceVarName }}, v Validator{{ if .CustomValidations }}, cv *CustomValidations{{ end }}) {{ if .CustomValidations }}(*{{ .VarName }}, error){{ else }}*{{ .VarName }}{{ end }} {
+{{- end }}
+{{- if .CustomValidations }}
+	if err := cv.check(); err != nil {
+		return nil, err
+	}
+{{- end }}
 {{- if .Schemes }}
 	// Casting service to Auther interface
 	a := s.(Auther)
 {{- end }}
 	return &{{ .VarName }}{
 {{- range .Methods }}
-		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}),
+		{{ .VarName }}: {{ if .Concurrency }}{{ .Concurrency.LimiterName }}()({{ end }}New{{ .VarName }}Endpoint(s{{ if .Validate }}, v{{ end }}{{ if .CustomValidate }}, cv{{ end }}{{ range .Schemes }}, a.{{ .Type }}Auth{{ end }}){{ if .Concurrency }}){{ end }},
 {{- end }}
-	}
+	}{{ if .CustomValidations }}, nil{{ end }}
 }
 `
 
@@ -224,7 +451,7 @@ type {{ .ResponseStruct }} struct {
 
 // input: endpointMethodData
 const serviceEndpointMethodT = `{{ printf "New%sEndpoint returns an endpoint function that calls the method %q of service %q." .VarName .Name .ServiceName | comment }}
-func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}) goa.Endpoint {
+func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ if .Validate }}, v Validator{{ end }}{{ if .CustomValidate }}, cv *CustomValidations{{ end }}{{ range .Schemes }}, auth{{ .Type }}Fn security.Auth{{ .Type }}Func{{ end }}) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 {{- if or .ServerStream }}
 		ep := req.(*{{ .ServerStream.EndpointStruct }})
@@ -234,6 +461,40 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		p := req.({{ .PayloadRef }})
 {{- end }}
 {{- $payload := payloadVar . }}
+{{- range .PayloadDefaults }}
+	{{- if .Source }}
+		if {{ $payload }}.{{ .FieldName }} == nil{{ if and .Pointer .SourcePointer }} && {{ $payload }}.{{ .Source }} != nil{{ end }} {
+		{{- if and .Pointer .SourcePointer }}
+			tmp := *{{ $payload }}.{{ .Source }}
+			{{ $payload }}.{{ .FieldName }} = &tmp
+		{{- else if .Pointer }}
+			tmp := {{ $payload }}.{{ .Source }}
+			{{ $payload }}.{{ .FieldName }} = &tmp
+		{{- else }}
+			{{ $payload }}.{{ .FieldName }} = {{ $payload }}.{{ .Source }}
+		{{- end }}
+		}
+	{{- else }}
+		if {{ $payload }}.{{ .FieldName }} == nil {
+	{{- if .Pointer }}
+			var tmp {{ .TypeRef }} = {{ .Value }}
+			{{ $payload }}.{{ .FieldName }} = &tmp
+	{{- else }}
+			{{ $payload }}.{{ .FieldName }} = {{ .Value }}
+	{{- end }}
+		}
+	{{- end }}
+{{- end }}
+{{- if .Validate }}
+		if err := v.Validate{{ .VarName }}(ctx, {{ $payload }}); err != nil {
+			return nil, err
+		}
+{{- end }}
+{{- if .CustomValidate }}
+		if err := cv.{{ .CustomValidate }}({{ $payload }}); err != nil {
+			return nil, err
+		}
+{{- end }}
 {{- if .Requirements }}
 		var err error
 	{{- range $ridx, $r := .Requirements }}
@@ -330,6 +591,17 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				}
 			{{- end }}
 		{{- end }}
+		{{- if $r.RequiredScopes }}
+		if err == nil {
+			if serr := security.CheckScopes(ctx, []string{ {{- range $r.RequiredScopes }}{{ printf "%q" . }}, {{ end }} }); serr != nil {
+			{{- if forbiddenError $ }}
+				err = {{ forbiddenError $ }}(serr)
+			{{- else }}
+				err = goa.NewServiceError(serr, {{ printf "%q" forbiddenErrorName }}, false, false, false)
+			{{- end }}
+			}
+		}
+		{{- end }}
 		{{- if ne $ridx 0 }}
 		}
 		{{- end }}
@@ -377,6 +649,86 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 }
 `
 
+// input: endpointMethodData
+const serviceEndpointConcurrencyLimiterT = `{{- $doc := printf "%s returns an endpoint middleware that limits the number of requests handled concurrently by the method %q of service %q to %d." .Concurrency.LimiterName .Name .ServiceName .Concurrency.Limit }}
+{{- if .Concurrency.QueueTimeout }}
+	{{- $doc = printf "%s Requests beyond the limit wait for a slot until the queue timeout expires or their context is canceled." $doc }}
+{{- end }}
+{{- comment $doc }}
+func {{ .Concurrency.LimiterName }}() func(goa.Endpoint) goa.Endpoint {
+	sem := make(chan struct{}, {{ .Concurrency.Limit }})
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			select {
+			case sem <- struct{}{}:
+			default:
+{{- if .Concurrency.QueueTimeout }}
+				timer := time.NewTimer({{ .Concurrency.QueueTimeout }})
+				defer timer.Stop()
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-timer.C:
+					return nil, {{ template "unavailable_error" . }}
+				}
+{{- else }}
+				return nil, {{ template "unavailable_error" . }}
+{{- end }}
+			}
+			{{ comment "Release the slot even if the endpoint panics." }}
+			defer func() { <-sem }()
+			return e(ctx, req)
+		}
+	}
+}
+
+{{- define "unavailable_error" }}
+	{{- $err := printf "fmt.Errorf(%q)" (printf "too many concurrent requests, the maximum is %d" .Concurrency.Limit) }}
+	{{- if .Concurrency.ErrorInit }}{{ .Concurrency.ErrorInit }}({{ $err }})
+	{{- else }}goa.NewServiceError({{ $err }}, {{ printf "%q" unavailableErrorName }}, false, true, false)
+	{{- end }}
+{{- end }}
+`
+
+// input: endpointMethodData
+const serviceEndpointFeatureGateT = `{{ printf "%s returns an endpoint middleware that rejects the requests made to the method %q of service %q when fc reports the feature %q as disabled." .FeatureGate.GateName .Name .ServiceName .FeatureGate.Feature | comment }}
+func {{ .FeatureGate.GateName }}(fc goa.FeatureChecker) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if !fc.FeatureEnabled(ctx, {{ .FeatureGate.ConstName }}) {
+				err := fmt.Errorf("feature %q is disabled", {{ .FeatureGate.ConstName }})
+{{- if .FeatureGate.ErrorInit }}
+				return nil, {{ .FeatureGate.ErrorInit }}(err)
+{{- else }}
+				return nil, goa.NewServiceError(err, {{ printf "%q" featureDisabledErrorName }}, false, true, false)
+{{- end }}
+			}
+			return e(ctx, req)
+		}
+	}
+}
+`
+
+// input: endpointsData
+const serviceEndpointsUseFeatureCheckerT = `{{ printf "Names of the feature flags that gate the %q service methods." .Name | comment }}
+const (
+{{- range .Features }}
+	{{ printf "%s is the name of the %q feature flag." .ConstName .Feature | comment }}
+	{{ .ConstName }} = {{ printf "%q" .Feature }}
+{{- end }}
+)
+
+{{ printf "UseFeatureChecker wraps the endpoints of the %q service methods gated by a feature flag with middleware that use fc to reject the requests made while the flag is disabled. The methods are enabled when UseFeatureChecker is not called." .Name | comment }}
+func (e *{{ .VarName }}) UseFeatureChecker(fc goa.FeatureChecker) {
+{{- range .Methods }}
+	{{- if .FeatureGate }}
+	e.{{ .VarName }} = {{ .FeatureGate.GateName }}(fc)(e.{{ .VarName }})
+	{{- end }}
+{{- end }}
+}
+`
+
 // input: endpointMethodData
 const serviceEndpointsUseT = `{{ printf "Use applies the given middleware to all the %q service endpoints." .Name | comment }}
 func (e *{{ .VarName }}) Use(m func(goa.Endpoint) goa.Endpoint) {
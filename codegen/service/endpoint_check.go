@@ -0,0 +1,59 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// EndpointCheckFile returns the file that asserts the signatures of the
+// service interface methods, of the endpoint constructors and of the
+// function used to wrap the endpoints at compile time. Code written against
+// the generated packages, for example endpoint wrappers applied with Use,
+// fails to compile with an error pointing to the mismatched signature if the
+// design changes.
+func EndpointCheckFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	path := filepath.Join(codegen.Gendir, svc.PathName, "endpoints_check.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "io"},
+		codegen.GoaImport(""),
+		codegen.GoaImport("security"),
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" endpoints signature checks", svc.PkgName, imports),
+		{
+			Name:   "endpoints-check",
+			Source: serviceEndpointsCheckT,
+			Data:   endpointData(service),
+		},
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: endpointsData
+const serviceEndpointsCheckT = `// The assignments below fail to compile if the generated service interface,
+// endpoint constructors or endpoint wrapping function do not have the expected
+// signatures.
+var (
+	_ func({{ .ServiceVarName }}) *{{ .VarName }} = New{{ .VarName }}
+{{- if .Validator }}
+	_ func({{ .ServiceVarName }}, Validator) *{{ .VarName }} = New{{ .VarName }}WithValidator
+{{- end }}
+	_ func(*{{ .VarName }}, func(goa.Endpoint) goa.Endpoint) = (*{{ .VarName }}).Use
+{{- range .Methods }}
+	_ func({{ .ServiceVarName }}{{ if .Validate }}, Validator{{ end }}{{ range .Schemes }}, security.Auth{{ .Type }}Func{{ end }}) goa.Endpoint = New{{ .VarName }}Endpoint
+	{{- if .ServerStream }}
+	_ func({{ .ServiceVarName }}, context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}, {{ .ServerStream.Interface }}) error = {{ .ServiceVarName }}.{{ .VarName }}
+	{{- else }}
+	_ func({{ .ServiceVarName }}, context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}{{ if .SkipRequestBodyEncodeDecode }}, io.ReadCloser{{ end }}) ({{ if .Result }}{{ .ResultRef }}, {{ end }}{{ if .SkipResponseBodyEncodeDecode }}io.ReadCloser, {{ end }}{{ if .Result }}{{ if .ViewedResult }}{{ if not .ViewedResult.ViewName }}string, {{ end }}{{ end }}{{ end }}error) = {{ .ServiceVarName }}.{{ .VarName }}
+	{{- end }}
+{{- end }}
+{{- range .Schemes }}
+	_ func(Auther, context.Context, {{ if eq .Type "Basic" }}string, string{{ else }}string{{ end }}, *security.{{ .Type }}Scheme) (context.Context, error) = Auther.{{ .Type }}Auth
+{{- end }}
+)
+`
@@ -31,6 +31,13 @@ func TestEndpoint(t *testing.T) {
 		{"streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodEndpoint},
 		{"bidirectional-streaming", testdata.BidirectionalStreamingEndpointDSL, testdata.BidirectionalStreamingMethodEndpoint},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodEndpoint},
+		{"custom-validations", testdata.CustomValidationsEndpointDSL, testdata.CustomValidationsEndpoint},
+		{"max-concurrent", testdata.MaxConcurrentEndpointDSL, testdata.MaxConcurrentEndpoint},
+		{"feature-gate", testdata.FeatureGateEndpointDSL, testdata.FeatureGateEndpoint},
+		{"method-defaults", testdata.MethodDefaultsEndpointDSL, testdata.MethodDefaultsEndpoint},
+		{"server-defaults", testdata.ServerDefaultsEndpointDSL, testdata.ServerDefaultsEndpoint},
+		{"defaults-from", testdata.DefaultsFromEndpointDSL, testdata.DefaultsFromEndpoint},
+		{"validator", testdata.ValidatorEndpointDSL, testdata.ValidatorEndpoint},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -61,3 +68,34 @@ func TestEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointCheck(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"multiple", testdata.MultipleEndpointsDSL, testdata.MultipleEndpointsCheck},
+		{"with-result-multiple-views", testdata.WithResultMultipleViewsEndpointDSL, testdata.WithResultMultipleViewsEndpointCheck},
+		{"streaming-result", testdata.StreamingResultEndpointDSL, testdata.StreamingResultMethodEndpointCheck},
+		{"security-skip-request-body", testdata.EndpointCheckDSL, testdata.SecuritySkipRequestBodyEndpointCheck},
+		{"validator", testdata.ValidatorEndpointDSL, testdata.ValidatorEndpointCheck},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			expr.Root.GeneratedTypes = &expr.GeneratedRoot{}
+			if len(expr.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(expr.Root.Services))
+			}
+			f := EndpointCheckFile("goa.design/goa/example", expr.Root.Services[0])
+			if len(f.SectionTemplates) != 2 {
+				t.Fatalf("got %d sections, expected 2", len(f.SectionTemplates))
+			}
+			code := codegen.SectionCode(t, f.SectionTemplates[1])
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
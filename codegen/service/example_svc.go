@@ -30,6 +30,9 @@ type (
 		// StreamInterface is the stream interface in the service package used
 		// by the endpoint implementation.
 		StreamInterface string
+		// BatchFunc is the fully qualified name of the function that
+		// implements the method if the method is a batch method.
+		BatchFunc string
 	}
 )
 
@@ -86,6 +89,13 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 			Data:   data,
 		})
 	}
+	if len(data.CustomValidations) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "custom-validations",
+			Source: dummyCustomValidationsT,
+			Data:   data,
+		})
+	}
 	for _, m := range svc.Methods {
 		sections = append(sections, basicEndpointSection(m, data))
 	}
@@ -123,6 +133,9 @@ func basicEndpointSection(m *expr.MethodExpr, svcData *Data) *codegen.SectionTem
 	if md.ServerStream != nil {
 		ed.StreamInterface = svcData.PkgName + "." + md.ServerStream.Interface
 	}
+	if md.Batch != nil {
+		ed.BatchFunc = svcData.PkgName + "." + md.Batch.FuncName
+	}
 	return &codegen.SectionTemplate{
 		Name:   "basic-endpoint",
 		Source: endpointT,
@@ -143,6 +156,22 @@ type {{ .VarName }}srvc struct {
 func New{{ .StructName }}(logger *log.Logger) {{ .PkgName }}.Service {
 	return &{{ .VarName }}srvc{logger}
 }
+`
+
+	// input: service.Data
+	dummyCustomValidationsT = `{{ printf "New%sCustomValidations returns the custom validation functions run by the %s service endpoints." .StructName .Name | comment }}
+func New{{ .StructName }}CustomValidations() *{{ .PkgName }}.CustomValidations {
+	return &{{ .PkgName }}.CustomValidations{
+	{{- range .CustomValidations }}
+		{{ .FieldName }}: func(v {{ .TypeRef }}) error {
+			//
+			// TBD: add {{ .Name }} validation logic.
+			//
+			return nil
+		},
+	{{- end }}
+	}
+}
 `
 
 	// input: basicEndpointData
@@ -173,7 +202,11 @@ func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .Pay
 	{{- end }}
 {{- end }}
 	s.logger.Print("{{ .ServiceVarName }}.{{ .Name }}")
+{{- if .BatchFunc }}
+	return {{ .BatchFunc }}(ctx, s, p), nil
+{{- else }}
 	return
+{{- end }}
 }
 `
 )
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// mockData contains the data needed to render the mock implementation
+	// of a service.
+	mockData struct {
+		// Name is the service name.
+		Name string
+		// PkgName is the name of the service package.
+		PkgName string
+		// Methods lists the mocked service methods.
+		Methods []*mockMethodData
+		// Schemes lists the security schemes whose authorization
+		// functions are mocked.
+		Schemes SchemesData
+		// Streams lists the mocked stream interfaces.
+		Streams []*mockStreamData
+	}
+
+	// mockMethodData contains the data needed to render a mocked method.
+	mockMethodData struct {
+		// VarName is the name of the method.
+		VarName string
+		// Name is the name of the method as defined in the design.
+		Name string
+		// FuncName is the name of the mock struct field holding the
+		// method implementation.
+		FuncName string
+		// Params is the method parameter list.
+		Params string
+		// Args is the list of arguments used to call the implementation.
+		Args string
+		// Results is the method result list.
+		Results string
+	}
+
+	// mockStreamData contains the data needed to render a mocked stream.
+	mockStreamData struct {
+		// VarName is the name of the mock struct.
+		VarName string
+		// Interface is the qualified name of the mocked interface.
+		Interface string
+		// Methods lists the mocked stream methods.
+		Methods []*mockMethodData
+	}
+)
+
+// MockFile returns the file defining a mock implementation of the service
+// interface and of its stream interfaces. The mock records the number of calls
+// made to each method.
+func MockFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	data := buildMockData(service, svc)
+	path := filepath.Join(codegen.Gendir, svc.PathName, "mocks", "service.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "io"},
+		{Path: "sync"},
+		codegen.GoaImport("security"),
+		{Path: genpkg + "/" + svc.PathName, Name: svc.PkgName},
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" service mocks", "mocks", imports),
+		{Name: "mock-service", Source: mockServiceT, Data: data},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "mock-service-method",
+			Source: mockMethodT,
+			Data:   map[string]interface{}{"Struct": "Service", "Method": m},
+		})
+	}
+	for _, s := range data.Streams {
+		sections = append(sections, &codegen.SectionTemplate{Name: "mock-stream", Source: mockStreamT, Data: s})
+		for _, m := range s.Methods {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "mock-stream-method",
+				Source: mockMethodT,
+				Data:   map[string]interface{}{"Struct": s.VarName, "Method": m},
+			})
+		}
+	}
+	sections = append(sections, &codegen.SectionTemplate{Name: "mock-call-counter", Source: mockCallCounterT})
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// buildMockData builds the data needed to render the mock implementation of
+// the given service.
+func buildMockData(service *expr.ServiceExpr, svc *Data) *mockData {
+	data := &mockData{Name: service.Name, PkgName: svc.PkgName, Schemes: svc.Schemes}
+	qualify := func(att *expr.AttributeExpr) string {
+		loc := codegen.UserTypeLocation(att.Type)
+		pkg := svc.PkgName
+		if loc != nil {
+			pkg = loc.PackageName()
+		}
+		return svc.Scope.GoFullTypeRef(att, pkg)
+	}
+	for _, m := range service.Methods {
+		md := svc.Method(m.Name)
+		params := []string{"ctx context.Context"}
+		args := []string{"ctx"}
+		if m.Payload.Type != expr.Empty {
+			params = append(params, "p "+qualify(m.Payload))
+			args = append(args, "p")
+		}
+		var results []string
+		if md.ServerStream != nil {
+			params = append(params, "stream "+svc.PkgName+"."+md.ServerStream.Interface)
+			args = append(args, "stream")
+		} else {
+			if md.SkipRequestBodyEncodeDecode {
+				params = append(params, "body io.ReadCloser")
+				args = append(args, "body")
+			}
+			if m.Result.Type != expr.Empty {
+				results = append(results, qualify(m.Result))
+			}
+			if md.SkipResponseBodyEncodeDecode {
+				results = append(results, "io.ReadCloser")
+			}
+			if m.Result.Type != expr.Empty && md.ViewedResult != nil && md.ViewedResult.ViewName == "" {
+				results = append(results, "string")
+			}
+		}
+		results = append(results, "error")
+		data.Methods = append(data.Methods, &mockMethodData{
+			VarName:  md.VarName,
+			Name:     md.Name,
+			FuncName: md.VarName + "Func",
+			Params:   strings.Join(params, ", "),
+			Args:     strings.Join(args, ", "),
+			Results:  mockResults(results),
+		})
+		if md.ServerStream == nil {
+			continue
+		}
+		var spayloadRef, resultRef string
+		if m.StreamingPayload.Type != expr.Empty {
+			spayloadRef = qualify(m.StreamingPayload)
+		}
+		if m.Result.Type != expr.Empty {
+			resultRef = qualify(m.Result)
+		}
+		viewed := md.ViewedResult != nil && md.ViewedResult.ViewName == ""
+		svr := buildMockStreamData(svc.PkgName, md.ServerStream, resultRef, spayloadRef, viewed)
+		if md.Trailers != nil {
+			svr.Methods = append(svr.Methods, &mockMethodData{
+				VarName:  "SetTrailers",
+				Name:     "SetTrailers",
+				FuncName: "SetTrailersFunc",
+				Params:   "t *" + svc.PkgName + "." + md.Trailers.VarName,
+				Args:     "t",
+			})
+		}
+		data.Streams = append(data.Streams,
+			svr,
+			buildMockStreamData(svc.PkgName, md.ClientStream, spayloadRef, resultRef, false),
+		)
+	}
+	return data
+}
+
+// buildMockStreamData builds the data needed to render the mock implementation
+// of the given stream interface. sendRef and recvRef are the qualified
+// references to the types sent and received through the stream.
+func buildMockStreamData(pkg string, s *StreamData, sendRef, recvRef string, viewed bool) *mockStreamData {
+	data := &mockStreamData{VarName: s.Interface, Interface: pkg + "." + s.Interface}
+	if s.SendTypeRef != "" {
+		data.Methods = append(data.Methods, &mockMethodData{
+			VarName:  s.SendName,
+			Name:     s.SendName,
+			FuncName: s.SendName + "Func",
+			Params:   "v " + sendRef,
+			Args:     "v",
+			Results:  "error",
+		})
+	}
+	if s.RecvTypeRef != "" {
+		data.Methods = append(data.Methods, &mockMethodData{
+			VarName:  s.RecvName,
+			Name:     s.RecvName,
+			FuncName: s.RecvName + "Func",
+			Results:  mockResults([]string{recvRef, "error"}),
+		})
+	}
+	if s.CloseAndRecv {
+		data.Methods = append(data.Methods, &mockMethodData{
+			VarName:  "CloseAndRecv",
+			Name:     "CloseAndRecv",
+			FuncName: "CloseAndRecvFunc",
+			Results:  mockResults([]string{recvRef, "error"}),
+		})
+	}
+	if s.MustClose {
+		data.Methods = append(data.Methods, &mockMethodData{
+			VarName:  "Close",
+			Name:     "Close",
+			FuncName: "CloseFunc",
+			Results:  "error",
+		})
+	}
+	if viewed {
+		data.Methods = append(data.Methods, &mockMethodData{
+			VarName:  "SetView",
+			Name:     "SetView",
+			FuncName: "SetViewFunc",
+			Params:   "view string",
+			Args:     "view",
+		})
+	}
+	return data
+}
+
+// mockResults returns the Go code for the given list of function results.
+func mockResults(results []string) string {
+	if len(results) == 1 {
+		return results[0]
+	}
+	return fmt.Sprintf("(%s)", strings.Join(results, ", "))
+}
+
+// input: mockData
+const mockServiceT = `{{ printf "Service is a mock implementation of the %s.Service interface. Each method calls the function stored in the corresponding field and panics if the field is nil. Service records the number of calls made to each method, see Calls." .PkgName | comment }}
+type Service struct {
+{{- range .Methods }}
+	{{ printf "%s implements the %q method." .FuncName .Name | comment }}
+	{{ .FuncName }} func({{ .Params }}) {{ .Results }}
+{{- end }}
+{{- range .Schemes }}
+	{{ printf "%sAuthFunc implements the authorization logic for the %s security scheme." .Type .Type | comment }}
+	{{ .Type }}AuthFunc func(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ .Type }}Scheme) (context.Context, error)
+{{- end }}
+
+	callCounter
+}
+
+var _ {{ .PkgName }}.Service = (*Service)(nil)
+{{- if .Schemes }}
+
+var _ {{ .PkgName }}.Auther = (*Service)(nil)
+{{- range .Schemes }}
+
+{{ printf "%sAuth calls %sAuthFunc." .Type .Type | comment }}
+func (m *Service) {{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, schema *security.{{ .Type }}Scheme) (context.Context, error) {
+	m.record("{{ .Type }}Auth")
+	if m.{{ .Type }}AuthFunc == nil {
+		panic("mocks: unexpected call to Service.{{ .Type }}Auth")
+	}
+	return m.{{ .Type }}AuthFunc(ctx, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }}, schema)
+}
+{{- end }}
+{{- end }}
+`
+
+// input: map[string]interface{}{"Struct": string, "Method": *mockMethodData}
+const mockMethodT = `{{ printf "%s calls %s." .Method.VarName .Method.FuncName | comment }}
+func (m *{{ .Struct }}) {{ .Method.VarName }}({{ .Method.Params }}) {{ .Method.Results }} {
+	m.record({{ printf "%q" .Method.VarName }})
+	if m.{{ .Method.FuncName }} == nil {
+		panic({{ printf "mocks: unexpected call to %s.%s" .Struct .Method.VarName | printf "%q" }})
+	}
+	{{ if .Method.Results }}return {{ end }}m.{{ .Method.FuncName }}({{ .Method.Args }})
+}
+`
+
+// input: mockStreamData
+const mockStreamT = `{{ printf "%s is a mock implementation of the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+{{- range .Methods }}
+	{{ printf "%s implements the %s method." .FuncName .Name | comment }}
+	{{ .FuncName }} func({{ .Params }}) {{ .Results }}
+{{- end }}
+
+	callCounter
+}
+
+var _ {{ .Interface }} = (*{{ .VarName }})(nil)
+`
+
+const mockCallCounterT = `// callCounter records the number of calls made to the mock methods.
+type callCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of times the method with the given name was called.
+func (c *callCounter) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+// record increments the number of calls made to the given method.
+func (c *callCounter) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+`
@@ -48,6 +48,8 @@ func TestSecureEndpoint(t *testing.T) {
 		Code string
 	}{
 		{"with-required-scopes", testdata.EndpointWithRequiredScopesDSL, testdata.EndpointWithRequiredScopesCode},
+		{"with-enforced-scopes", testdata.EndpointWithEnforcedScopesDSL, testdata.EndpointWithEnforcedScopesCode},
+		{"with-enforced-scopes-no-error", testdata.EndpointWithEnforcedScopesNoErrorDSL, testdata.EndpointWithEnforcedScopesNoErrorCode},
 		{"with-optional-required-scopes", testdata.EndpointWithOptionalRequiredScopesDSL, testdata.EndpointWithOptionalRequiredScopesCode},
 		{"with-api-key-override", testdata.EndpointWithAPIKeyOverrideDSL, testdata.EndpointWithAPIKeyOverrideCode},
 		{"with-oauth2", testdata.EndpointWithOAuth2DSL, testdata.EndpointWithOAuth2Code},
@@ -53,6 +53,13 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 				})
 			}
 		}
+		if m.Trailers != nil {
+			addTypeDefSection(svcPath, m.Trailers.VarName, &codegen.SectionTemplate{
+				Name:   "service-trailers",
+				Source: trailersT,
+				Data:   m.Trailers,
+			})
+		}
 		if m.ResultDef != "" {
 			if _, ok := seen[m.Result]; !ok {
 				addTypeDefSection(resultPath, m.Result, &codegen.SectionTemplate{
@@ -100,6 +107,30 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		})
 	}
 
+	for _, m := range svc.redactMethods {
+		addTypeDefSection(pathWithDefault(m.Loc, svcPath), "~"+m.TypeRef+".String", &codegen.SectionTemplate{
+			Name:   "service-redact-method",
+			Source: redactMethodT,
+			Data:   m,
+		})
+	}
+
+	for _, m := range svc.validateMethods {
+		addTypeDefSection(pathWithDefault(m.Loc, svcPath), "~"+m.TypeRef+".Validate", &codegen.SectionTemplate{
+			Name:   "service-validate-method",
+			Source: validateMethodT,
+			Data:   m,
+		})
+	}
+
+	for _, c := range svc.constructors {
+		addTypeDefSection(pathWithDefault(c.Loc, svcPath), "~"+c.TypeRef+"."+c.Name, &codegen.SectionTemplate{
+			Name:   "service-constructor",
+			Source: constructorT,
+			Data:   c,
+		})
+	}
+
 	for _, et := range errorTypes {
 		// Don't override the section created for the error type
 		// declaration, make sure the key does not clash with existing
@@ -119,6 +150,23 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 			Data:   er,
 		})
 	}
+	if len(svc.errorCatalog) > 0 {
+		svcSections = append(svcSections, &codegen.SectionTemplate{
+			Name:   "error-catalog",
+			Source: errorCatalogT,
+			Data:   map[string]interface{}{"Name": svc.Name, "Errors": svc.errorCatalog},
+		})
+	}
+
+	for _, m := range svc.Methods {
+		if m.Batch != nil {
+			svcSections = append(svcSections, &codegen.SectionTemplate{
+				Name:   "service-batch-func",
+				Source: batchFuncT,
+				Data:   m.Batch,
+			})
+		}
+	}
 
 	// transform result type functions
 	for _, t := range svc.viewedResultTypes {
@@ -163,7 +211,13 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 
 	imports := []*codegen.ImportSpec{
 		codegen.SimpleImport("context"),
+		codegen.SimpleImport("errors"),
+		codegen.SimpleImport("fmt"),
 		codegen.SimpleImport("io"),
+		codegen.SimpleImport("strconv"),
+		codegen.SimpleImport("strings"),
+		codegen.SimpleImport("time"),
+		codegen.SimpleImport("unicode/utf8"),
 		codegen.GoaImport(""),
 		codegen.GoaImport("security"),
 		codegen.NewImport(svc.ViewsPkg, genpkg+"/"+svcName+"/views"),
@@ -174,7 +228,7 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		Name:    "service",
 		Source:  serviceT,
 		Data:    svc,
-		FuncMap: map[string]interface{}{"streamInterfaceFor": streamInterfaceFor},
+		FuncMap: map[string]interface{}{"streamInterfaceFor": streamInterfaceFor, "hasSLO": hasSLO},
 	}
 
 	// service.go
@@ -228,7 +282,13 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		}
 		fullRelPath := filepath.Join(codegen.Gendir, p)
 		dir, _ := filepath.Split(fullRelPath)
-		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), nil)
+		h := codegen.Header("User types", codegen.Goify(filepath.Base(dir), false), []*codegen.ImportSpec{
+			codegen.SimpleImport("fmt"),
+			codegen.SimpleImport("strconv"),
+			codegen.SimpleImport("strings"),
+			codegen.SimpleImport("unicode/utf8"),
+			codegen.GoaImport(""),
+		})
 		sections := append([]*codegen.SectionTemplate{h}, secs...)
 		files = append(files, &codegen.File{Path: fullRelPath, SectionTemplates: sections})
 	}
@@ -272,6 +332,17 @@ func errorName(et *UserTypeData) string {
 	return fmt.Sprintf("%q", et.Name)
 }
 
+// hasSLO returns true if any of the given methods defines service level
+// objectives.
+func hasSLO(methods []*MethodData) bool {
+	for _, m := range methods {
+		if m.SLO != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // streamInterfaceFor builds the data to generate the client and server stream
 // interfaces for the given endpoint.
 func streamInterfaceFor(typ string, m *MethodData, stream *StreamData) map[string]interface{} {
@@ -283,6 +354,7 @@ func streamInterfaceFor(typ string, m *MethodData, stream *StreamData) map[strin
 		// expression, we can use that view to render the result type instead
 		// of iterating through the list of views defined in the result type.
 		"IsViewedResult": m.ViewedResult != nil && m.ViewedResult.ViewName == "",
+		"Trailers":       m.Trailers,
 	}
 }
 
@@ -333,11 +405,30 @@ type Auther interface {
 // same value that is set in the endpoint request contexts under the ServiceKey
 // key.
 const ServiceName = {{ printf "%q" .Name }}
+{{- if .APIVersion }}
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = {{ printf "%q" .APIVersion }}
+{{- end }}
 
 // MethodNames lists the service method names as defined in the design. These
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
 var MethodNames = [{{ len .Methods }}]string{ {{ range .Methods }}{{ printf "%q" .Name }}, {{ end }} }
+{{- if hasSLO .Methods }}
+
+// MethodSLOs lists the service level objectives of the service methods as
+// defined in the design indexed by method name. Metrics middlewares may use
+// them as labels or alerting targets, they do not affect how the requests are
+// handled.
+var MethodSLOs = map[string]goa.SLO{
+	{{- range .Methods }}
+		{{- if .SLO }}
+	{{ printf "%q" .Name }}: { {{- if .SLO.LatencyP99 }}LatencyP99: {{ .SLO.LatencyP99 }}{{ if .SLO.SuccessRate }}, {{ end }}{{ end }}{{ if .SLO.SuccessRate }}SuccessRate: {{ .SLO.SuccessRate }}{{ end -}} },
+		{{- end }}
+	{{- end }}
+}
+{{- end }}
 {{- range .Methods }}
 	{{- if .ServerStream }}
 		{{ template "stream_interface" (streamInterfaceFor "server" . .ServerStream) }}
@@ -356,6 +447,10 @@ type {{ .Stream.Interface }} interface {
 		{{ comment .Stream.RecvDesc }}
 		{{ .Stream.RecvName }}() ({{ .Stream.RecvTypeRef }}, error)
 	{{- end }}
+	{{- if .Stream.CloseAndRecv }}
+		{{ comment (printf "CloseAndRecv stops sending messages to the stream and reads the next instance of %q from the stream. Use Recv to read the remaining instances until it returns io.EOF." .Stream.RecvTypeName) }}
+		CloseAndRecv() ({{ .Stream.RecvTypeRef }}, error)
+	{{- end }}
 	{{- if .Stream.MustClose }}
 		{{ comment "Close closes the stream." }}
 		Close() error
@@ -364,6 +459,10 @@ type {{ .Stream.Interface }} interface {
 		{{ comment "SetView sets the view used to render the result before streaming." }}
 		SetView(view string)
 	{{- end }}
+	{{- if and .Trailers (eq .Type "server") }}
+		{{ comment "SetTrailers sets the trailers sent once the results have been streamed." }}
+		SetTrailers(*{{ .Trailers.VarName }})
+	{{- end }}
 }
 {{- end }}
 `
@@ -380,6 +479,17 @@ const resultT = `{{ comment .ResultDesc }}
 type {{ .Result }} {{ .ResultDef }}
 `
 
+const trailersT = `{{ printf "%s contains the trailers sent by the %q method once the results have been streamed. Trailers whose field is nil are not sent." .VarName .Method | comment }}
+type {{ .VarName }} struct {
+{{- range .Fields }}
+	{{- if .Description }}
+	{{ comment .Description }}
+	{{- end }}
+	{{ .FieldName }} *{{ .TypeRef }}
+{{- end }}
+}
+`
+
 const userTypeT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
 `
@@ -405,6 +515,35 @@ func (e {{ .Ref }}) GoaErrorName() string {
 const unionValueMethodT = `func ({{ .TypeRef }}) {{ .Name }}() {}
 `
 
+// input: RedactMethodData
+const redactMethodT = `// String returns the representation of the {{ .Name }} value with the
+// sensitive fields redacted.
+func (v {{ .TypeRef }}) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields []string
+{{- range .Fields }}
+	{{- if .Redacted }}
+	fields = append(fields, {{ printf "%q" (print .Name ":[REDACTED]") }})
+	{{- else if .Pointer }}
+	if v.{{ .FieldName }} != nil {
+		fields = append(fields, fmt.Sprintf({{ printf "%q" (print .Name ":%v") }}, *v.{{ .FieldName }}))
+	}
+	{{- else }}
+	fields = append(fields, fmt.Sprintf({{ printf "%q" (print .Name ":%v") }}, v.{{ .FieldName }}))
+	{{- end }}
+{{- end }}
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
+// Format implements fmt.Formatter. It prints the value returned by String for
+// all the verbs so that the sensitive fields are never printed.
+func (v {{ .TypeRef }}) Format(f fmt.State, _ rune) {
+	fmt.Fprint(f, v.String())
+}
+`
+
 // input: map[string]{"Type": TypeData, "Error": ErrorData}
 const errorInitT = `{{ printf "%s builds a %s from an error." .Name .TypeName |  comment }}
 func {{ .Name }}(err error) {{ .TypeRef }} {
@@ -412,9 +551,62 @@ func {{ .Name }}(err error) {{ .TypeRef }} {
 }
 `
 
+// input: map[string]interface{}{"Name": string, "Errors": []*ErrorInitData}
+const errorCatalogT = `{{ printf "Errors lists the errors that the methods of the %q service may return indexed by name. Use Errors.Retryable to determine whether a request that failed with an error may be retried." .Name | comment }}
+var Errors = goa.ErrorCatalog{
+{{- range .Errors }}
+	{{ printf "%q" .ErrName }}: { Name: {{ printf "%q" .ErrName }}{{ if .Timeout }}, Timeout: true{{ end }}{{ if .Temporary }}, Temporary: true{{ end }}{{ if .Fault }}, Fault: true{{ end }} },
+{{- end }}
+}
+`
+
+// input: BatchData
+const batchFuncT = `{{ printf "%s implements the %q method by calling the %q method of s for each payload of the batch. The errors returned by the %q method are reported in the items of the failed payloads and do not interrupt the processing of the batch." .FuncName .Name .Method .Method | comment }}
+func {{ .FuncName }}(ctx context.Context, s Service, p {{ .PayloadRef }}) {{ .ResultRef }} {
+	res := make({{ .ResultRef }}, len(p))
+	for i, item := range p {
+		{{ if .HasResult }}r, {{ end }}err := s.{{ .VarName }}(ctx, item)
+		if err != nil {
+			name := "fault"
+			var namer goa.GoaErrorNamer
+			if errors.As(err, &namer) {
+				name = namer.GoaErrorName()
+			}
+			res[i] = &{{ .ItemName }}{Error: &{{ .ErrorName }}{Name: name, Message: err.Error()}}
+			continue
+		}
+		res[i] = &{{ .ItemName }}{ {{- if .HasResult }}Result: {{ if .ResultPointer }}&{{ end }}r{{ end }}}
+	}
+	return res
+}
+`
+
 // input: InitData
 const typeInitT = `{{ comment .Description }}
 func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .Ref }}, {{ end }}) {{ .ReturnTypeRef }} {
 	{{ .Code }}
 }
 `
+
+// input: ValidateMethodData
+const validateMethodT = `// Validate runs the validations defined on {{ .Name }}. It returns all the
+// validation errors merged into a single error.
+func (v {{ .TypeRef }}) Validate() (err error) {
+	{{ .Validate }}
+	return
+}
+`
+
+// input: ConstructorData
+const constructorT = `{{ printf "%s returns a new %s initialized with the given required fields. The optional fields with a default value are set to that value." .Name .TypeName | comment }}
+func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ .TypeRef }} {
+	return &{{ .TypeName }}{
+	{{- range .Args }}
+		{{ .FieldName }}: {{ .Name }},
+	{{- end }}
+	{{- range .Defaults }}
+		{{ .FieldName }}: {{ .Value }},
+	{{- end }}
+	}
+}
+`
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -67,6 +68,11 @@ type (
 		// ProtoImports lists the import specifications for the custom
 		// proto types used by the service.
 		ProtoImports []*codegen.ImportSpec
+		// APIVersion is the version of the API as defined in the design.
+		APIVersion string
+		// CustomValidations lists the custom validation functions run by
+		// the service endpoints sorted by name.
+		CustomValidations []*CustomValidationData
 
 		// userTypes lists the type definitions that the service depends on.
 		userTypes []*UserTypeData
@@ -75,6 +81,9 @@ type (
 		// errorInits list the information required to generate error init
 		// functions.
 		errorInits []*ErrorInitData
+		// errorCatalog lists the errors that the service methods may
+		// return.
+		errorCatalog []*ErrorInitData
 		// projectedTypes lists the types which uses pointers for all fields to
 		// define view specific validation logic.
 		projectedTypes []*ProjectedTypeData
@@ -84,6 +93,18 @@ type (
 		viewedResultTypes []*ViewedResultTypeData
 		// unionValueMethods lists the methods used to define union types.
 		unionValueMethods []*UnionValueMethodData
+		// redactMethods lists the String and Format methods generated for
+		// the types with sensitive attributes.
+		redactMethods []*RedactMethodData
+		// validateMethods lists the Validate methods generated for the
+		// types with validations.
+		validateMethods []*ValidateMethodData
+		// constructors lists the constructors generated for the types
+		// that define the "struct:constructor" meta.
+		constructors []*ConstructorData
+		// customValidateMethods lists the methods of the
+		// CustomValidations struct that run the custom validations.
+		customValidateMethods []*CustomValidateMethodData
 	}
 
 	// UnionValueMethodData describes a method used on a union value type.
@@ -97,6 +118,87 @@ type (
 		Loc *codegen.Location
 	}
 
+	// RedactMethodData describes the String and Format methods generated
+	// for a type that has sensitive attributes.
+	RedactMethodData struct {
+		// Name is the name of the type.
+		Name string
+		// TypeRef is a reference to the type.
+		TypeRef string
+		// Fields lists the type fields in the order they are printed.
+		Fields []*RedactFieldData
+		// Loc defines the file and Go package of the method if
+		// overridden in the type via Meta.
+		Loc *codegen.Location
+	}
+
+	// ValidateMethodData describes the Validate method generated for a type
+	// that defines validations.
+	ValidateMethodData struct {
+		// Name is the name of the type.
+		Name string
+		// TypeRef is a reference to the type.
+		TypeRef string
+		// Validate is the validation code.
+		Validate string
+		// Loc defines the file and Go package of the method if
+		// overridden in the type via Meta.
+		Loc *codegen.Location
+	}
+
+	// ConstructorData describes the constructor generated for a type that
+	// defines the "struct:constructor" meta.
+	ConstructorData struct {
+		// Name is the name of the constructor.
+		Name string
+		// TypeName is the name of the type.
+		TypeName string
+		// TypeRef is a reference to the type.
+		TypeRef string
+		// Args lists the constructor arguments, one per required
+		// attribute.
+		Args []*ConstructorArgData
+		// Defaults lists the fields initialized with their default
+		// values.
+		Defaults []*ConstructorDefaultData
+		// Loc defines the file and Go package of the constructor if
+		// overridden in the type via Meta.
+		Loc *codegen.Location
+	}
+
+	// ConstructorArgData describes a constructor argument.
+	ConstructorArgData struct {
+		// Name is the name of the argument.
+		Name string
+		// FieldName is the name of the struct field set with the
+		// argument.
+		FieldName string
+		// TypeRef is a reference to the argument type.
+		TypeRef string
+	}
+
+	// ConstructorDefaultData describes a field initialized with its
+	// default value by a constructor.
+	ConstructorDefaultData struct {
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Value is the Go literal of the default value.
+		Value string
+	}
+
+	// RedactFieldData describes a field printed by a String method.
+	RedactFieldData struct {
+		// Name is the name of the attribute printed with the value.
+		Name string
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Redacted is true if the field value is sensitive and must be
+		// masked.
+		Redacted bool
+		// Pointer is true if the field is a pointer to a primitive value.
+		Pointer bool
+	}
+
 	// ErrorInitData describes an error returned by a service method of type
 	// ErrorResult.
 	ErrorInitData struct {
@@ -141,6 +243,10 @@ type (
 		PayloadEx interface{}
 		// PayloadDefault is the default value of the payload if any.
 		PayloadDefault interface{}
+		// PayloadDefaults lists the payload fields initialized by the
+		// endpoint with default values scoped to the method, server
+		// defaults or the values of other fields if any.
+		PayloadDefaults []*PayloadDefaultData
 		// StreamingPayload is the name of the streaming payload type if any.
 		StreamingPayload string
 		// StreamingPayloadDef is the streaming payload type definition if any.
@@ -201,6 +307,182 @@ type (
 		// result and response body reader when SkipResponseBodyEncodeDecode is
 		// used.
 		ResponseStruct string
+		// LongRunning contains the data needed to render the client
+		// function that polls the job returned by the method if the
+		// method is long-running.
+		LongRunning *LongRunningData
+		// Batch contains the data needed to render the function that
+		// implements the method if the method was generated with the
+		// Batch DSL.
+		Batch *BatchData
+		// Concurrency contains the data needed to render the endpoint
+		// middleware that limits the number of concurrent requests if
+		// the method sets a limit.
+		Concurrency *ConcurrencyData
+		// Trailers contains the data needed to render the struct
+		// holding the trailers sent after the streamed results if the
+		// method defines any.
+		Trailers *TrailersData
+		// FeatureGate contains the data needed to render the endpoint
+		// middleware that rejects the requests when the feature flag
+		// that gates the method is disabled.
+		FeatureGate *FeatureGateData
+		// SLO contains the data needed to list the service level
+		// objectives of the method if the method defines any.
+		SLO *SLOData
+		// CustomValidate is the name of the CustomValidations method
+		// that runs the custom validations of the payload if any.
+		CustomValidate string
+	}
+
+	// SLOData contains the data needed to render the service level
+	// objectives of a method.
+	SLOData struct {
+		// LatencyP99 is the Go expression for the target 99th
+		// percentile latency, empty if not set.
+		LatencyP99 string
+		// SuccessRate is the target percentage of successful requests
+		// formatted as a Go literal, empty if not set.
+		SuccessRate string
+	}
+
+	// FeatureGateData contains the data needed to render the endpoint
+	// middleware that checks the feature flag gating a method.
+	FeatureGateData struct {
+		// GateName is the name of the function that creates the
+		// endpoint middleware.
+		GateName string
+		// Feature is the name of the feature flag.
+		Feature string
+		// ConstName is the name of the constant holding the feature
+		// flag name.
+		ConstName string
+		// ErrorInit is the name of the function that builds the method
+		// "feature_disabled" error, empty if the method does not define
+		// one.
+		ErrorInit string
+	}
+
+	// PayloadDefaultData describes a payload field initialized by the
+	// endpoint with a default value scoped to the method when it is nil.
+	PayloadDefaultData struct {
+		// FieldName is the name of the payload field.
+		FieldName string
+		// TypeRef is the reference to the field value type.
+		TypeRef string
+		// Value is the Go code of the default value.
+		Value string
+		// Pointer is true if the field holds a pointer to the value.
+		Pointer bool
+		// Source is the name of the payload field whose value is
+		// copied if the default is set with DefaultFrom, empty
+		// otherwise.
+		Source string
+		// SourcePointer is true if the source field holds a pointer to
+		// the value.
+		SourcePointer bool
+	}
+
+	// TrailersData contains the data needed to render the struct holding
+	// the trailers sent by a streaming method after the results.
+	TrailersData struct {
+		// VarName is the name of the struct.
+		VarName string
+		// Method is the name of the method.
+		Method string
+		// Fields lists the trailers.
+		Fields []*TrailerData
+	}
+
+	// TrailerData describes a trailer sent by a streaming method.
+	TrailerData struct {
+		// Name is the trailer name as defined in the design.
+		Name string
+		// FieldName is the name of the struct field holding the
+		// trailer value.
+		FieldName string
+		// TypeRef is the reference to the trailer value type.
+		TypeRef string
+		// Description is the trailer description if any.
+		Description string
+		// IsString is true if the trailer value is a string.
+		IsString bool
+	}
+
+	// ConcurrencyData contains the data needed to render the endpoint
+	// middleware that limits the number of requests handled concurrently by
+	// a method.
+	ConcurrencyData struct {
+		// LimiterName is the name of the function that creates the
+		// endpoint middleware.
+		LimiterName string
+		// Limit is the maximum number of concurrent requests.
+		Limit int
+		// QueueTimeout is the Go expression for the maximum duration
+		// requests wait for a slot, empty if requests beyond the limit
+		// are rejected right away.
+		QueueTimeout string
+		// ErrorInit is the name of the function that builds the method
+		// "unavailable" error, empty if the method does not define one.
+		ErrorInit string
+	}
+
+	// BatchData contains the data needed to render the function that
+	// implements a batch method by calling the batched method for each
+	// payload of the batch.
+	BatchData struct {
+		// FuncName is the name of the function.
+		FuncName string
+		// Name is the name of the batch method.
+		Name string
+		// Method is the name of the batched method.
+		Method string
+		// VarName is the name of the batched method in the service
+		// interface.
+		VarName string
+		// PayloadRef is the reference to the batch payload type.
+		PayloadRef string
+		// ResultRef is the reference to the batch result type.
+		ResultRef string
+		// ItemName is the name of the batch item type.
+		ItemName string
+		// ErrorName is the name of the batch item error type.
+		ErrorName string
+		// HasResult is true if the batched method has a result.
+		HasResult bool
+		// ResultPointer is true if the item result field is a pointer
+		// to the batched method result.
+		ResultPointer bool
+	}
+
+	// LongRunningData contains the data needed to render the client function
+	// that polls the job returned by a long-running method.
+	LongRunningData struct {
+		// PollFunc is the name of the client function that polls the
+		// job.
+		PollFunc string
+		// PollMethod is the name of the poll method.
+		PollMethod string
+		// PollVarName is the name of the client method that calls the
+		// poll method endpoint.
+		PollVarName string
+		// JobRef is the reference to the job type.
+		JobRef string
+		// PayloadName is the name of the poll method payload type, empty
+		// if the poll method has no payload.
+		PayloadName string
+		// Fields lists the poll method payload fields initialized with
+		// the job fields.
+		Fields []*PollFieldData
+	}
+
+	// PollFieldData describes a poll method payload field initialized with
+	// the job field of the same name.
+	PollFieldData struct {
+		// FieldName is the name of the payload and job field.
+		FieldName string
+		// Pointer is true if the payload field is a pointer.
+		Pointer bool
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -231,6 +513,11 @@ type (
 		// MustClose indicates whether the stream should implement the Close()
 		// function.
 		MustClose bool
+		// CloseAndRecv indicates whether the stream should implement the
+		// CloseAndRecv() function that stops sending messages and reads
+		// the next result. It is set for the client side of bidirectional
+		// streams.
+		CloseAndRecv bool
 		// EndpointStruct is the name of the endpoint struct that holds a payload
 		// reference (if any) and the endpoint server stream. It is set only if the
 		// client sends a normal payload and server streams a result.
@@ -246,6 +533,9 @@ type (
 		Schemes []*SchemeData
 		// Scopes list the required scopes.
 		Scopes []string
+		// RequiredScopes list the scopes checked by the generated code
+		// once the request is authenticated.
+		RequiredScopes []string
 	}
 
 	// UserTypeData contains the data describing a user-defined type.
@@ -553,6 +843,7 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		types            []*UserTypeData
 		errTypes         []*UserTypeData
 		errorInits       []*ErrorInitData
+		errorCatalog     []*ErrorInitData
 		projTypes        []*ProjectedTypeData
 		viewedUnionMeths []*UnionValueMethodData
 		viewedRTs        []*ViewedResultTypeData
@@ -565,7 +856,11 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		scope = codegen.NewNameScope()
 		scope.Unique("Use") // Reserve "Use" for Endpoints struct Use method.
 		viewScope = codegen.NewNameScope()
-		pkgName = scope.HashedUnique(service, strings.ToLower(codegen.Goify(service.Name, false)), "svc")
+		pkgName = strings.ToLower(codegen.Goify(service.Name, false))
+		if n, ok := service.Meta.Last("struct:pkg:name"); ok {
+			pkgName = n
+		}
+		pkgName = scope.HashedUnique(service, pkgName, "svc")
 		viewspkg = pkgName + "views"
 		seen = make(map[string]struct{})
 		seenErrors = make(map[string]struct{})
@@ -573,8 +868,13 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		seenViewed = make(map[string]*ViewedResultTypeData)
 
 		// A function to collect user types from an error expression
+		seenCatalog := make(map[string]struct{})
 		recordError := func(er *expr.ErrorExpr) {
 			errTypes = append(errTypes, collectTypes(er.AttributeExpr, scope, seen)...)
+			if _, ok := seenCatalog[er.Name]; !ok {
+				seenCatalog[er.Name] = struct{}{}
+				errorCatalog = append(errorCatalog, buildErrorInitData(er, scope))
+			}
 			if er.Type == expr.ErrorResult {
 				if _, ok := seenErrors[er.Name]; ok {
 					return
@@ -697,6 +997,14 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 			m.ViewedResult = vrt
 			seenViewed[vrt.Name+"::"+view] = vrt
 		}
+		for i, e := range service.Methods {
+			if e.LongRunning != nil {
+				methods[i].LongRunning = buildLongRunningData(e, methods, scope)
+			}
+			if e.Batch != nil {
+				methods[i].Batch = buildBatchData(e, methods[i], scope)
+			}
+		}
 	}
 
 	var (
@@ -723,6 +1031,62 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		})
 	}
 
+	var (
+		rms []*RedactMethodData
+	)
+	{
+		seen := make(map[string]struct{})
+		for _, t := range types {
+			rms = append(rms, collectRedactMethods(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, t := range errTypes {
+			rms = append(rms, collectRedactMethods(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, m := range service.Methods {
+			rms = append(rms, collectRedactMethods(m.Payload, scope, seen)...)
+			rms = append(rms, collectRedactMethods(m.StreamingPayload, scope, seen)...)
+			rms = append(rms, collectRedactMethods(m.Result, scope, seen)...)
+		}
+	}
+
+	var (
+		vms []*ValidateMethodData
+	)
+	{
+		seen := make(map[string]struct{})
+		for _, t := range types {
+			vms = append(vms, collectValidateMethods(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, t := range errTypes {
+			vms = append(vms, collectValidateMethods(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, m := range service.Methods {
+			vms = append(vms, collectValidateMethods(m.Payload, scope, seen)...)
+			vms = append(vms, collectValidateMethods(m.StreamingPayload, scope, seen)...)
+			vms = append(vms, collectValidateMethods(m.Result, scope, seen)...)
+		}
+	}
+
+	var (
+		ctors []*ConstructorData
+	)
+	{
+		seen := make(map[string]struct{})
+		for _, t := range types {
+			ctors = append(ctors, collectConstructors(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, t := range errTypes {
+			ctors = append(ctors, collectConstructors(&expr.AttributeExpr{Type: t.Type}, scope, seen)...)
+		}
+		for _, m := range service.Methods {
+			ctors = append(ctors, collectConstructors(m.Payload, scope, seen)...)
+			ctors = append(ctors, collectConstructors(m.StreamingPayload, scope, seen)...)
+			ctors = append(ctors, collectConstructors(m.Result, scope, seen)...)
+		}
+	}
+
+	cvs, cvms := collectCustomValidations(service, methods, scope)
+
 	var (
 		desc string
 	)
@@ -746,13 +1110,21 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		Schemes:            schemes,
 		Scope:              scope,
 		ViewScope:          viewScope,
+		APIVersion:         expr.Root.API.Version,
+		CustomValidations:  cvs,
 		errorTypes:         errTypes,
 		errorInits:         errorInits,
+		errorCatalog:       errorCatalog,
 		userTypes:          types,
 		projectedTypes:     projTypes,
 		viewedUnionMethods: viewedUnionMeths,
 		viewedResultTypes:  viewedRTs,
 		unionValueMethods:  ms,
+		redactMethods:      rms,
+		validateMethods:    vms,
+		constructors:       ctors,
+
+		customValidateMethods: cvms,
 	}
 	d[service.Name] = data
 
@@ -851,6 +1223,230 @@ func collectUnionMethods(att *expr.AttributeExpr, scope *codegen.NameScope, loc
 	return
 }
 
+// collectRedactMethods traverses the attribute to gather the String methods
+// of the user types that have sensitive attributes either directly or through
+// the types of their attributes.
+func collectRedactMethods(att *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*RedactMethodData) {
+	if att == nil || att.Type == expr.Empty {
+		return
+	}
+	collect := func(at *expr.AttributeExpr) []*RedactMethodData {
+		return collectRedactMethods(at, scope, seen)
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if _, ok := seen[dt.ID()]; ok {
+			return nil
+		}
+		seen[dt.ID()] = struct{}{}
+		if obj := expr.AsObject(dt); obj != nil && hasSensitive(dt, make(map[string]struct{})) {
+			uatt := dt.Attribute()
+			rm := &RedactMethodData{
+				Name:    dt.Name(),
+				TypeRef: scope.GoTypeRef(&expr.AttributeExpr{Type: dt}),
+				Loc:     codegen.UserTypeLocation(dt),
+			}
+			for _, nat := range *obj {
+				f := &RedactFieldData{
+					Name:      nat.Name,
+					FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+				}
+				switch {
+				case nat.Attribute.IsSensitive():
+					f.Redacted = true
+				case uatt.IsPrimitivePointer(nat.Name, true):
+					f.Pointer = true
+				}
+				rm.Fields = append(rm.Fields, f)
+			}
+			data = append(data, rm)
+		}
+		data = append(data, collect(dt.Attribute())...)
+	case *expr.Object:
+		for _, nat := range *dt {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	case *expr.Array:
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Map:
+		data = append(data, collect(dt.KeyType)...)
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Union:
+		for _, nat := range dt.Values {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	}
+	return
+}
+
+// collectValidateMethods traverses the attribute to gather the Validate methods
+// of the user types that define validations either directly or through the
+// types of their attributes.
+func collectValidateMethods(att *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*ValidateMethodData) {
+	if att == nil || att.Type == expr.Empty {
+		return
+	}
+	collect := func(at *expr.AttributeExpr) []*ValidateMethodData {
+		return collectValidateMethods(at, scope, seen)
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if _, ok := seen[dt.ID()]; ok {
+			return nil
+		}
+		seen[dt.ID()] = struct{}{}
+		ctx := validateContext(scope)
+		if codegen.HasValidateMethod(ctx, dt) {
+			data = append(data, &ValidateMethodData{
+				Name:     dt.Name(),
+				TypeRef:  scope.GoTypeRef(&expr.AttributeExpr{Type: dt}),
+				Validate: codegen.ValidationCodeWithContext(dt.Attribute(), dt, ctx, true, false, "v", dt.Name()),
+				Loc:      codegen.UserTypeLocation(dt),
+			})
+		}
+		data = append(data, collect(dt.Attribute())...)
+	case *expr.Object:
+		for _, nat := range *dt {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	case *expr.Array:
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Map:
+		data = append(data, collect(dt.KeyType)...)
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Union:
+		for _, nat := range dt.Values {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	}
+	return
+}
+
+// collectConstructors traverses the attribute to gather the constructors of the
+// user types that define the "struct:constructor" meta.
+func collectConstructors(att *expr.AttributeExpr, scope *codegen.NameScope, seen map[string]struct{}) (data []*ConstructorData) {
+	if att == nil || att.Type == expr.Empty {
+		return
+	}
+	collect := func(at *expr.AttributeExpr) []*ConstructorData {
+		return collectConstructors(at, scope, seen)
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if _, ok := seen[dt.ID()]; ok {
+			return nil
+		}
+		seen[dt.ID()] = struct{}{}
+		if c := buildConstructorData(dt, scope); c != nil {
+			data = append(data, c)
+		}
+		data = append(data, collect(dt.Attribute())...)
+	case *expr.Object:
+		for _, nat := range *dt {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	case *expr.Array:
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Map:
+		data = append(data, collect(dt.KeyType)...)
+		data = append(data, collect(dt.ElemType)...)
+	case *expr.Union:
+		for _, nat := range dt.Values {
+			data = append(data, collect(nat.Attribute)...)
+		}
+	}
+	return
+}
+
+// buildConstructorData returns the data needed to generate the constructor of
+// the given user type, nil if the type does not define the "struct:constructor"
+// meta or is not an object.
+func buildConstructorData(ut expr.UserType, scope *codegen.NameScope) *ConstructorData {
+	if v, ok := ut.Attribute().Meta.Last("struct:constructor"); !ok || v != "true" {
+		return nil
+	}
+	obj := expr.AsObject(ut)
+	if obj == nil {
+		return nil
+	}
+	var (
+		args []*ConstructorArgData
+		defs []*ConstructorDefaultData
+
+		att = ut.Attribute()
+	)
+	for _, nat := range *obj {
+		fn := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		if att.IsRequired(nat.Name) {
+			ref := scope.GoTypeRef(nat.Attribute)
+			if att.IsPrimitivePointer(nat.Name, true) {
+				ref = "*" + ref
+			}
+			args = append(args, &ConstructorArgData{
+				Name:      codegen.GoifyAtt(nat.Attribute, nat.Name, false),
+				FieldName: fn,
+				TypeRef:   ref,
+			})
+			continue
+		}
+		// Only the primitive attributes are stored in non-pointer fields
+		// initialized with their default values.
+		if def := nat.Attribute.DefaultValue; def != nil && expr.IsPrimitive(nat.Attribute.Type) && !att.IsPrimitivePointer(nat.Name, true) {
+			defs = append(defs, &ConstructorDefaultData{
+				FieldName: fn,
+				Value:     fmt.Sprintf("%#v", def),
+			})
+		}
+	}
+	name := scope.GoTypeName(&expr.AttributeExpr{Type: ut})
+	return &ConstructorData{
+		Name:     "New" + name,
+		TypeName: name,
+		TypeRef:  scope.GoTypeRef(&expr.AttributeExpr{Type: ut}),
+		Args:     args,
+		Defaults: defs,
+		Loc:      codegen.UserTypeLocation(ut),
+	}
+}
+
+// validateContext returns the attribute context used to generate the Validate
+// methods of the service types.
+func validateContext(scope *codegen.NameScope) *codegen.AttributeContext {
+	ctx := typeContext("", scope)
+	ctx.ValidateMethod = true
+	return ctx
+}
+
+// hasSensitive returns true if the given user type is an object with sensitive
+// attributes or with attributes whose types have sensitive attributes. seen
+// records the types being visited to handle recursive types.
+func hasSensitive(ut expr.UserType, seen map[string]struct{}) bool {
+	if _, ok := seen[ut.ID()]; ok {
+		return false
+	}
+	seen[ut.ID()] = struct{}{}
+	obj := expr.AsObject(ut)
+	if obj == nil {
+		return false
+	}
+	for _, nat := range *obj {
+		if nat.Attribute.IsSensitive() {
+			return true
+		}
+		att := nat.Attribute
+		switch {
+		case expr.IsArray(att.Type):
+			att = expr.AsArray(att.Type).ElemType
+		case expr.IsMap(att.Type):
+			att = expr.AsMap(att.Type).ElemType
+		}
+		if nut, ok := att.Type.(expr.UserType); ok && hasSensitive(nut, seen) {
+			return true
+		}
+	}
+	return false
+}
+
 // buildErrorInitData creates the data needed to generate code around endpoint error return values.
 func buildErrorInitData(er *expr.ErrorExpr, scope *codegen.NameScope) *ErrorInitData {
 	_, temporary := er.AttributeExpr.Meta["goa:error:temporary"]
@@ -943,7 +1539,7 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 			rs = rs.Append(sch)
 			schemes = schemes.Append(sch)
 		}
-		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes})
+		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes, RequiredScopes: req.RequiredScopes})
 	}
 	var httpMet *expr.HTTPEndpointExpr
 	if httpSvc := expr.Root.HTTPService(m.Service.Name); httpSvc != nil {
@@ -976,9 +1572,225 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 		RequestStruct:                vname + "RequestData",
 		ResponseStruct:               vname + "ResponseData",
 	}
+	if m.Concurrency != nil {
+		data.Concurrency = buildConcurrencyData(m.Concurrency, vname, errors)
+	}
+	if m.FeatureGate != nil {
+		data.FeatureGate = buildFeatureGateData(m.FeatureGate, vname, errors)
+	}
+	if m.SLO != nil {
+		data.SLO = buildSLOData(m.SLO)
+	}
 	if m.IsStreaming() {
 		initStreamData(data, m, vname, rname, resultRef, scope)
 	}
+	if obj := expr.AsObject(m.Payload.Type); obj != nil {
+		data.PayloadDefaults = buildPayloadDefaults(m, obj, scope)
+	}
+	if m.StreamTrailers != nil && m.IsResultStreaming() {
+		data.Trailers = buildTrailersData(m, vname, scope)
+	}
+	return data
+}
+
+// buildPayloadDefaults builds the data needed to initialize the payload fields
+// of the given method that use default values scoped to the method, server
+// defaults or the values of other fields. The copies of the other fields come
+// last, in dependency order.
+func buildPayloadDefaults(m *expr.MethodExpr, obj *expr.Object, scope *codegen.NameScope) []*PayloadDefaultData {
+	var defs []*PayloadDefaultData
+	for _, nat := range *obj {
+		def := nat.Attribute.MethodDefault(m.Name)
+		if def == nil || !m.Payload.IsPrimitivePointer(nat.Name, true) {
+			continue
+		}
+		defs = append(defs, &PayloadDefaultData{
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			TypeRef:   scope.GoTypeRef(nat.Attribute),
+			Value:     fmt.Sprintf("%#v", def),
+			Pointer:   true,
+		})
+	}
+	for _, d := range m.ServerDefaults {
+		att := obj.Attribute(d.Field)
+		if att == nil {
+			continue
+		}
+		defs = append(defs, &PayloadDefaultData{
+			FieldName: codegen.GoifyAtt(att, d.Field, true),
+			TypeRef:   scope.GoTypeRef(att),
+			Value:     d.Code,
+			Pointer:   m.Payload.IsPrimitivePointer(d.Field, true),
+		})
+	}
+	for _, d := range sortDefaultsFrom(m.DefaultsFrom) {
+		att, src := obj.Attribute(d.Field), obj.Attribute(d.Source)
+		if att == nil || src == nil {
+			continue
+		}
+		defs = append(defs, &PayloadDefaultData{
+			FieldName:     codegen.GoifyAtt(att, d.Field, true),
+			TypeRef:       scope.GoTypeRef(att),
+			Pointer:       m.Payload.IsPrimitivePointer(d.Field, true),
+			Source:        codegen.GoifyAtt(src, d.Source, true),
+			SourcePointer: m.Payload.IsPrimitivePointer(d.Source, true),
+		})
+	}
+	return defs
+}
+
+// sortDefaultsFrom returns the given defaults ordered so that the default of
+// a field comes after the default of its source field if any.
+func sortDefaultsFrom(defaults []*expr.DefaultFromExpr) []*expr.DefaultFromExpr {
+	sorted := make([]*expr.DefaultFromExpr, 0, len(defaults))
+	pending := defaults
+	for len(pending) > 0 {
+		fields := make(map[string]struct{}, len(pending))
+		for _, d := range pending {
+			fields[d.Field] = struct{}{}
+		}
+		var next []*expr.DefaultFromExpr
+		for _, d := range pending {
+			if _, ok := fields[d.Source]; ok {
+				next = append(next, d)
+				continue
+			}
+			sorted = append(sorted, d)
+		}
+		if len(next) == len(pending) {
+			// cycle, reported by the design validation
+			return append(sorted, next...)
+		}
+		pending = next
+	}
+	return sorted
+}
+
+// buildTrailersData builds the data needed to render the struct holding the
+// trailers sent by the given method after the streamed results.
+func buildTrailersData(m *expr.MethodExpr, vname string, scope *codegen.NameScope) *TrailersData {
+	data := &TrailersData{
+		VarName: scope.Unique(vname + "Trailers"),
+		Method:  m.Name,
+	}
+	for _, nat := range *expr.AsObject(m.StreamTrailers.Type) {
+		data.Fields = append(data.Fields, &TrailerData{
+			Name:        nat.Name,
+			FieldName:   codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			TypeRef:     scope.GoTypeRef(nat.Attribute),
+			Description: nat.Attribute.Description,
+			IsString:    nat.Attribute.Type == expr.String,
+		})
+	}
+	return data
+}
+
+// buildConcurrencyData builds the data needed to render the endpoint
+// middleware that limits the number of concurrent requests of a method.
+func buildConcurrencyData(c *expr.ConcurrencyExpr, vname string, errors []*ErrorInitData) *ConcurrencyData {
+	data := &ConcurrencyData{
+		LimiterName: "New" + vname + "ConcurrencyLimiter",
+		Limit:       c.Limit,
+	}
+	if c.QueueTimeout > 0 {
+		data.QueueTimeout = codegen.DurationToGo(c.QueueTimeout)
+	}
+	for _, er := range errors {
+		if er.ErrName == expr.UnavailableErrorName {
+			data.ErrorInit = er.Name
+			break
+		}
+	}
+	return data
+}
+
+// buildBatchData builds the data needed to render the function that
+// implements the batch method m.
+func buildBatchData(m *expr.MethodExpr, md *MethodData, scope *codegen.NameScope) *BatchData {
+	var (
+		batched = m.Batch.Batched()
+		item    = expr.AsArray(m.Result.Type).ElemType
+		obj     = expr.AsObject(item.Type)
+		ut      = item.Type.(expr.UserType)
+	)
+	return &BatchData{
+		FuncName:      "Run" + md.VarName,
+		Name:          m.Name,
+		Method:        batched.Name,
+		VarName:       codegen.Goify(batched.Name, true),
+		PayloadRef:    md.PayloadRef,
+		ResultRef:     md.ResultRef,
+		ItemName:      scope.GoTypeName(item),
+		ErrorName:     scope.GoTypeName(obj.Attribute("error")),
+		HasResult:     obj.Attribute("result") != nil,
+		ResultPointer: ut.Attribute().IsPrimitivePointer("result", true),
+	}
+}
+
+// buildFeatureGateData builds the data needed to render the endpoint
+// middleware that checks the feature flag gating a method.
+func buildFeatureGateData(f *expr.FeatureGateExpr, vname string, errors []*ErrorInitData) *FeatureGateData {
+	data := &FeatureGateData{
+		GateName:  "New" + vname + "FeatureGate",
+		Feature:   f.Name,
+		ConstName: "Feature" + codegen.Goify(f.Name, true),
+	}
+	for _, er := range errors {
+		if er.ErrName == expr.FeatureDisabledErrorName {
+			data.ErrorInit = er.Name
+			break
+		}
+	}
+	return data
+}
+
+// buildSLOData builds the data needed to render the service level objectives
+// of a method.
+func buildSLOData(s *expr.SLOExpr) *SLOData {
+	data := &SLOData{}
+	if s.LatencyP99 > 0 {
+		data.LatencyP99 = codegen.DurationToGo(s.LatencyP99)
+	}
+	if s.SuccessRate > 0 {
+		data.SuccessRate = strconv.FormatFloat(s.SuccessRate, 'f', -1, 64)
+	}
+	return data
+}
+
+// buildLongRunningData builds the data needed to render the client function
+// that polls the job returned by the long-running method m. methods lists the
+// data of all the service methods.
+func buildLongRunningData(m *expr.MethodExpr, methods []*MethodData, scope *codegen.NameScope) *LongRunningData {
+	var (
+		lr   = m.LongRunning
+		poll = lr.Poll()
+		md   *MethodData
+		pmd  *MethodData
+	)
+	for i, sm := range m.Service.Methods {
+		switch sm {
+		case m:
+			md = methods[i]
+		case poll:
+			pmd = methods[i]
+		}
+	}
+	data := &LongRunningData{
+		PollFunc:    "Poll" + md.VarName,
+		PollMethod:  poll.Name,
+		PollVarName: pmd.VarName,
+		JobRef:      md.ResultRef,
+	}
+	if poll.Payload.Type == expr.Empty {
+		return data
+	}
+	data.PayloadName = scope.GoFullTypeName(poll.Payload, pmd.PayloadLoc.PackageName())
+	for _, nat := range *expr.AsObject(poll.Payload.Type) {
+		data.Fields = append(data.Fields, &PollFieldData{
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			Pointer:   poll.Payload.IsPrimitivePointer(nat.Name, true),
+		})
+	}
 	return data
 }
 
@@ -1038,6 +1850,7 @@ func initStreamData(data *MethodData, m *expr.MethodExpr, vname, rname, resultRe
 			}
 		case expr.BidirectionalStreamKind:
 			cliStream.MustClose = true
+			cliStream.CloseAndRecv = true
 		}
 		svrStream.RecvName = "Recv"
 		svrStream.RecvDesc = fmt.Sprintf("Recv reads instances of %q from the stream.", spayloadName)
@@ -3,7 +3,12 @@ package service
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"path/filepath"
 	"testing"
 
@@ -24,6 +29,8 @@ func TestService(t *testing.T) {
 		{"service-union", testdata.UnionMethodDSL, testdata.UnionMethod},
 		{"service-multi-union", testdata.MultiUnionMethodDSL, testdata.MultiUnionMethod},
 		{"service-no-payload-no-result", testdata.EmptyMethodDSL, testdata.EmptyMethod},
+		{"service-api-version", testdata.APIVersionDSL, testdata.APIVersion},
+		{"service-slo", testdata.SLOMethodsDSL, testdata.SLOMethods},
 		{"service-payload-no-result", testdata.EmptyResultMethodDSL, testdata.EmptyResultMethod},
 		{"service-no-payload-result", testdata.EmptyPayloadMethodDSL, testdata.EmptyPayloadMethod},
 		{"service-payload-result-with-default", testdata.WithDefaultDSL, testdata.WithDefault},
@@ -36,14 +43,20 @@ func TestService(t *testing.T) {
 		{"service-result-with-one-of-type", testdata.ResultWithOneOfTypeMethodDSL, testdata.ResultWithOneOfTypeMethod},
 		{"service-result-with-inline-validation", testdata.ResultWithInlineValidationDSL, testdata.ResultWithInlineValidation},
 		{"service-service-level-error", testdata.ServiceErrorDSL, testdata.ServiceError},
+		{"service-error-catalog", testdata.ErrorCatalogDSL, testdata.ErrorCatalog},
 		{"service-custom-errors", testdata.CustomErrorsDSL, testdata.CustomErrors},
 		{"service-custom-errors-custom-field", testdata.CustomErrorsCustomFieldDSL, testdata.CustomErrorsCustomField},
 		{"service-force-generate-type", testdata.ForceGenerateTypeDSL, testdata.ForceGenerateType},
+		{"service-number-validation", testdata.NumberValidationDSL, testdata.NumberValidation},
+		{"service-sensitive-attributes", testdata.SensitiveAttributesDSL, testdata.SensitiveAttributes},
+		{"service-constructor", testdata.ConstructorDSL, testdata.Constructor},
 		{"service-force-generate-type-explicit", testdata.ForceGenerateTypeExplicitDSL, testdata.ForceGenerateTypeExplicit},
 		{"service-streaming-result", testdata.StreamingResultMethodDSL, testdata.StreamingResultMethod},
 		{"service-streaming-result-with-views", testdata.StreamingResultWithViewsMethodDSL, testdata.StreamingResultWithViewsMethod},
 		{"service-streaming-result-with-explicit-view", testdata.StreamingResultWithExplicitViewMethodDSL, testdata.StreamingResultWithExplicitViewMethod},
 		{"service-streaming-result-no-payload", testdata.StreamingResultNoPayloadMethodDSL, testdata.StreamingResultNoPayloadMethod},
+		{"service-streaming-result-with-trailers", testdata.StreamingResultWithTrailersMethodDSL, testdata.StreamingResultWithTrailersMethod},
+		{"service-batch", testdata.BatchMethodDSL, testdata.BatchMethod},
 		{"service-streaming-payload", testdata.StreamingPayloadMethodDSL, testdata.StreamingPayloadMethod},
 		{"service-streaming-payload-no-payload", testdata.StreamingPayloadNoPayloadMethodDSL, testdata.StreamingPayloadNoPayloadMethod},
 		{"service-streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethod},
@@ -69,6 +82,26 @@ func TestService(t *testing.T) {
 	}
 }
 
+func TestServiceNumberValidationCompiles(t *testing.T) {
+	codegen.RunDSL(t, testdata.NumberValidationDSL)
+	svc := expr.Root.Services[0]
+	f := Files("goa.design/goa/example", svc, make(map[string][]string))[0]
+	AddServiceDataMetaTypeImports(f.SectionTemplates[0], svc)
+	path, err := f.Render(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("numbervalidation", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("generated code does not compile: %s", err)
+	}
+}
+
 func TestStructPkgPath(t *testing.T) {
 	fooPath := filepath.Join("gen", "foo", "foo.go")
 	recursiveFooPath := filepath.Join("gen", "foo", "recursive_foo.go")
@@ -136,3 +169,18 @@ func validateFile(t *testing.T, f *codegen.File, path, code string) {
 		t.Errorf("got\n%s\ngot vs. expected:\n%s", actual, codegen.Diff(t, actual, code))
 	}
 }
+
+func TestServicePkgName(t *testing.T) {
+	codegen.RunDSL(t, testdata.PkgNameDSL)
+	svc := expr.Root.Services[0]
+	files := append(Files("goa.design/goa/example", svc, make(map[string][]string)), EndpointFile("goa.design/goa/example", svc))
+	for _, f := range files {
+		if dir := filepath.Dir(f.Path); dir != filepath.Join("gen", "pkg_name") {
+			t.Errorf("%s: got directory %q, expected %q", f.Path, dir, filepath.Join("gen", "pkg_name"))
+		}
+		data := f.SectionTemplates[0].Data.(map[string]interface{})
+		if pkg := data["Pkg"]; pkg != "svcv2" {
+			t.Errorf("%s: got package %q, expected %q", f.Path, pkg, "svcv2")
+		}
+	}
+}
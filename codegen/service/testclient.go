@@ -0,0 +1,250 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// testClientData contains the data needed to render the in-process test
+	// client of a service.
+	testClientData struct {
+		// Name is the service name.
+		Name string
+		// PkgName is the name of the service package.
+		PkgName string
+		// CustomValidations is true if the service endpoints run custom
+		// validations.
+		CustomValidations bool
+		// Methods lists the methods of the test client.
+		Methods []*testClientMethodData
+	}
+
+	// testClientMethodData contains the data needed to render a test client
+	// method.
+	testClientMethodData struct {
+		// VarName is the name of the method.
+		VarName string
+		// Name is the name of the method as defined in the design.
+		Name string
+		// PayloadRef is the qualified reference to the payload type if
+		// any.
+		PayloadRef string
+		// ResultRef is the qualified reference to the result type if
+		// any.
+		ResultRef string
+		// Defaults lists the payload fields initialized with their
+		// default value when they hold the zero value.
+		Defaults []*testClientDefaultData
+		// ValidateMethod is true if the payload type defines a Validate
+		// method.
+		ValidateMethod bool
+		// Validate is the code validating the payload if it does not
+		// define a Validate method.
+		Validate string
+		// ViewedResultRef is the qualified reference to the viewed
+		// result type returned by the endpoint if any.
+		ViewedResultRef string
+		// ResultInit is the qualified name of the function that builds
+		// the result from the viewed result if any.
+		ResultInit string
+	}
+
+	// testClientDefaultData describes a payload field initialized with its
+	// default value.
+	testClientDefaultData struct {
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Zero is the Go literal of the zero value of the field.
+		Zero string
+		// Value is the Go literal of the default value.
+		Value string
+	}
+)
+
+// TestClientFile returns the file defining an in-process client of the service
+// for use in tests. The client calls the service endpoints directly, it does
+// not encode nor decode the requests and responses. It returns nil if the
+// service has no method that the client can call in-process.
+func TestClientFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	data := buildTestClientData(service, svc)
+	if len(data.Methods) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, svc.PathName, "testclient", "client.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "unicode/utf8"},
+		codegen.GoaImport(""),
+		{Path: genpkg + "/" + svc.PathName, Name: svc.PkgName},
+		{Path: genpkg + "/" + svc.PathName + "/" + "views", Name: svc.ViewsPkg},
+	}
+	imports = append(imports, svc.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" in-process test client", "testclient", imports),
+		{Name: "testclient-struct", Source: testClientT, Data: data},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "testclient-method",
+			Source: testClientMethodT,
+			Data:   m,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// buildTestClientData builds the data needed to render the in-process test
+// client of the given service. The streaming methods and the methods that
+// read or write the raw HTTP bodies are skipped.
+func buildTestClientData(service *expr.ServiceExpr, svc *Data) *testClientData {
+	data := &testClientData{Name: service.Name, PkgName: svc.PkgName, CustomValidations: len(svc.CustomValidations) > 0}
+	qualify := func(att *expr.AttributeExpr) string {
+		loc := codegen.UserTypeLocation(att.Type)
+		pkg := svc.PkgName
+		if loc != nil {
+			pkg = loc.PackageName()
+		}
+		return svc.Scope.GoFullTypeRef(att, pkg)
+	}
+	for _, m := range service.Methods {
+		md := svc.Method(m.Name)
+		if md.ServerStream != nil || md.SkipRequestBodyEncodeDecode || md.SkipResponseBodyEncodeDecode {
+			continue
+		}
+		cm := &testClientMethodData{VarName: md.VarName, Name: md.Name}
+		if m.Payload.Type != expr.Empty {
+			cm.PayloadRef = qualify(m.Payload)
+			ctx := validateContext(svc.Scope)
+			if ut, ok := m.Payload.Type.(expr.UserType); ok && expr.IsObject(ut) {
+				cm.Defaults = buildTestClientDefaults(ut)
+				cm.ValidateMethod = codegen.HasValidateMethod(ctx, ut)
+			} else {
+				cm.Validate = codegen.ValidationCode(m.Payload, nil, ctx, true, expr.IsAlias(m.Payload.Type), "p")
+			}
+		}
+		if m.Result.Type != expr.Empty {
+			cm.ResultRef = qualify(m.Result)
+			if vr := md.ViewedResult; vr != nil {
+				cm.ViewedResultRef = vr.FullRef
+				cm.ResultInit = svc.PkgName + "." + vr.ResultInit.Name
+			}
+		}
+		data.Methods = append(data.Methods, cm)
+	}
+	return data
+}
+
+// buildTestClientDefaults returns the fields of the given payload type that
+// the test client initializes with their default value. Only the primitive
+// attributes are stored in non-pointer fields initialized with their default
+// values, see buildConstructorData.
+func buildTestClientDefaults(ut expr.UserType) []*testClientDefaultData {
+	var (
+		defs []*testClientDefaultData
+
+		att = ut.Attribute()
+	)
+	for _, nat := range *expr.AsObject(ut) {
+		def := nat.Attribute.DefaultValue
+		if def == nil || att.IsRequired(nat.Name) || !expr.IsPrimitive(nat.Attribute.Type) || att.IsPrimitivePointer(nat.Name, true) {
+			continue
+		}
+		var zero string
+		switch nat.Attribute.Type.Kind() {
+		case expr.BooleanKind:
+			zero = "false"
+		case expr.StringKind:
+			zero = `""`
+		case expr.BytesKind, expr.AnyKind:
+			zero = "nil"
+		default:
+			zero = "0"
+		}
+		defs = append(defs, &testClientDefaultData{
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+			Zero:      zero,
+			Value:     fmt.Sprintf("%#v", def),
+		})
+	}
+	return defs
+}
+
+// input: testClientData
+const testClientT = `{{ printf "Client is an in-process client of the %q service for use in tests. It calls the service endpoints directly without encoding the requests and responses. The payloads are initialized with their default values and validated before the endpoints are called like the transports do." .Name | comment }}
+type Client struct {
+{{- range .Methods }}
+	{{ .VarName }}Endpoint goa.Endpoint
+{{- end }}
+}
+{{ if .CustomValidations }}
+{{ printf "New returns an in-process client of the %q service implemented by s. It returns an error if cv does not hold all the custom validation functions." .Name | comment }}
+func New(s {{ .PkgName }}.Service, cv *{{ .PkgName }}.CustomValidations) (*Client, error) {
+	e, err := {{ .PkgName }}.NewEndpoints(s, cv)
+	if err != nil {
+		return nil, err
+	}
+{{- else }}
+{{ printf "New returns an in-process client of the %q service implemented by s." .Name | comment }}
+func New(s {{ .PkgName }}.Service) *Client {
+	e := {{ .PkgName }}.NewEndpoints(s)
+{{- end }}
+	return &Client{
+{{- range .Methods }}
+		{{ .VarName }}Endpoint: e.{{ .VarName }},
+{{- end }}
+	}{{ if .CustomValidations }}, nil{{ end }}
+}
+`
+
+// input: testClientMethodData
+const testClientMethodT = `
+{{ printf "%s calls the %q endpoint in-process." .VarName .Name | comment }}
+{{- if .Defaults }}
+{{ comment "The payload fields that hold the zero value and have a default value are set to the default value." }}
+{{- end }}
+func (c *Client) {{ .VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}) ({{ if .ResultRef }}res {{ .ResultRef }}, {{ end }}err error) {
+{{- if .Defaults }}
+	if p != nil {
+		v := *p
+	{{- range .Defaults }}
+		if v.{{ .FieldName }} == {{ .Zero }} {
+			v.{{ .FieldName }} = {{ .Value }}
+		}
+	{{- end }}
+		p = &v
+	}
+{{- end }}
+{{- if .ValidateMethod }}
+	if p != nil {
+		if err = p.Validate(); err != nil {
+			return
+		}
+	}
+{{- else if .Validate }}
+	{{ .Validate }}
+	if err != nil {
+		return
+	}
+{{- end }}
+{{- if .ResultRef }}
+	var ires interface{}
+	ires, err = c.{{ .VarName }}Endpoint(ctx, {{ if .PayloadRef }}p{{ else }}nil{{ end }})
+	if err != nil {
+		return
+	}
+	{{- if .ViewedResultRef }}
+	return {{ .ResultInit }}(ires.({{ .ViewedResultRef }})), nil
+	{{- else }}
+	return ires.({{ .ResultRef }}), nil
+	{{- end }}
+{{- else }}
+	_, err = c.{{ .VarName }}Endpoint(ctx, {{ if .PayloadRef }}p{{ else }}nil{{ end }})
+	return
+{{- end }}
+}
+`
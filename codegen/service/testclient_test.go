@@ -0,0 +1,73 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestTestClientFile(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"single", testdata.SingleMethodDSL, testdata.SingleMethodTestClient},
+		{"with-default", testdata.WithDefaultDSL, testdata.WithDefaultTestClient},
+		{"validation", testdata.ValidatedPayloadsDSL, testdata.ValidatedPayloadsTestClient},
+		{"multiple-views", testdata.MultipleMethodsResultMultipleViewsDSL, testdata.MultipleMethodsResultMultipleViewsTestClient},
+		{"custom-validations", testdata.CustomValidationsEndpointDSL, testdata.CustomValidationsTestClient},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			Services = make(ServicesData)
+			if len(expr.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(expr.Root.Services))
+			}
+			f := TestClientFile("test/gen", expr.Root.Services[0])
+			if f == nil {
+				t.Fatalf("got nil file, expected not nil")
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bs, err := format.Source(buf.Bytes())
+			if err != nil {
+				fmt.Println(buf.String())
+				t.Fatal(err)
+			}
+			code := string(bs)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs expected\n:%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestTestClientFileNoMethod(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"bidirectional-streaming", testdata.BidirectionalStreamingMethodDSL},
+		{"skip-request-body-encode-decode", testdata.EndpointWithBasicAuthAndSkipRequestBodyEncodeDecodeDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			Services = make(ServicesData)
+			if f := TestClientFile("test/gen", expr.Root.Services[0]); f != nil {
+				t.Errorf("got file %s, expected nil", f.Path)
+			}
+		})
+	}
+}
@@ -283,3 +283,117 @@ func (c *Client) BidirectionalStreamingNoPayloadMethod(ctx context.Context) (res
 	return ires.(BidirectionalStreamingNoPayloadMethodClientStream), nil
 }
 `
+
+const LongRunningMethodClient = `// Client is the "LongRunningEndpoint" service client.
+type Client struct {
+	StartEndpoint goa.Endpoint
+	PollEndpoint  goa.Endpoint
+}
+
+// NewClient initializes a "LongRunningEndpoint" service client given the
+// endpoints.
+func NewClient(start, poll goa.Endpoint) *Client {
+	return &Client{
+		StartEndpoint: start,
+		PollEndpoint:  poll,
+	}
+}
+
+// Start calls the "Start" endpoint of the "LongRunningEndpoint" service.
+func (c *Client) Start(ctx context.Context) (res *Job, err error) {
+	var ires interface{}
+	ires, err = c.StartEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return ires.(*Job), nil
+}
+
+// Poll calls the "Poll" endpoint of the "LongRunningEndpoint" service.
+func (c *Client) Poll(ctx context.Context, p *PollPayload) (res *Job, err error) {
+	var ires interface{}
+	ires, err = c.PollEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Job), nil
+}
+
+// PollOption configures the polling of the jobs returned by the long-running
+// methods.
+type PollOption func(*pollConfig)
+
+// pollConfig holds the polling configuration.
+type pollConfig struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	backoff     float64
+}
+
+// WithPollInterval sets the interval between the first two polls, defaults to
+// one second.
+func WithPollInterval(d time.Duration) PollOption {
+	return func(c *pollConfig) { c.interval = d }
+}
+
+// WithPollMaxInterval sets the maximum interval between two polls, defaults to
+// one minute.
+func WithPollMaxInterval(d time.Duration) PollOption {
+	return func(c *pollConfig) { c.maxInterval = d }
+}
+
+// WithPollBackoff sets the factor the interval between two polls is multiplied
+// by after each poll, defaults to 2. A factor of 1 polls at a constant
+// interval.
+func WithPollBackoff(f float64) PollOption {
+	return func(c *pollConfig) { c.backoff = f }
+}
+
+// newPollConfig returns the polling configuration set by the given options.
+func newPollConfig(opts []PollOption) *pollConfig {
+	c := &pollConfig{interval: time.Second, maxInterval: time.Minute, backoff: 2}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// next returns the interval to wait before the poll following an interval of
+// d.
+func (c *pollConfig) next(d time.Duration) time.Duration {
+	d = time.Duration(float64(d) * c.backoff)
+	if d > c.maxInterval {
+		d = c.maxInterval
+	}
+	return d
+}
+
+// PollStart calls the "Poll" endpoint until done returns true for the job
+// returned by the "Start" endpoint. It returns the last polled job or the
+// error returned by the "Poll" endpoint. It returns the context error if ctx
+// is canceled before the job is done.
+func (c *Client) PollStart(ctx context.Context, job *Job, done func(*Job) bool, opts ...PollOption) (*Job, error) {
+	cfg := newPollConfig(opts)
+	interval := cfg.interval
+	for !done(job) {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		p := &PollPayload{
+			ID:     job.ID,
+			Tenant: &job.Tenant,
+		}
+		res, err := c.Poll(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		job = res
+		interval = cfg.next(interval)
+	}
+	return job, nil
+}
+`
@@ -513,3 +513,661 @@ func NewBidirectionalStreamingNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const CustomValidationsEndpoint = `// Endpoints wraps the "CustomValidations" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+	C goa.Endpoint
+}
+
+// CustomValidations holds the custom validation functions run by the
+// "CustomValidations" service endpoints once the payloads pass the validations
+// defined in the design.
+type CustomValidations struct {
+	// ValidateCard implements the "validateCard" custom validation.
+	ValidateCard func(v string) error
+	// ValidateCode implements the "validateCode" custom validation.
+	ValidateCode func(v int) error
+	// ValidateExpiry implements the "validateExpiry" custom validation.
+	ValidateExpiry func(v string) error
+}
+
+// check returns an error if cv does not hold all the custom validation
+// functions.
+func (cv *CustomValidations) check() error {
+	if cv == nil {
+		return fmt.Errorf("missing custom validations")
+	}
+	var missing []string
+	if cv.ValidateCard == nil {
+		missing = append(missing, "validateCard")
+	}
+	if cv.ValidateCode == nil {
+		missing = append(missing, "validateCode")
+	}
+	if cv.ValidateExpiry == nil {
+		missing = append(missing, "validateExpiry")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing custom validations %q", missing)
+	}
+	return nil
+}
+
+// validateCardType runs the custom validations of v.
+func (cv *CustomValidations) validateCardType(v *Card) (err error) {
+	if v.Number != nil {
+		if err2 := cv.ValidateCard(*v.Number); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidCustomError("Card.number", "validateCard", *v.Number, err2))
+		}
+	}
+	if v.Expiry != nil {
+		if err2 := cv.ValidateExpiry(*v.Expiry); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidCustomError("Card.expiry", "validateExpiry", *v.Expiry, err2))
+		}
+	}
+	return
+}
+
+// validateBPayloadType runs the custom validations of v.
+func (cv *CustomValidations) validateBPayloadType(v *BPayload) (err error) {
+	if v.Card != nil {
+		if err2 := cv.validateCardType(v.Card); err2 != nil {
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "BPayload.card"))
+		}
+	}
+	for i, e := range v.Backups {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if e != nil {
+				if err2 := cv.validateCardType(e); err2 != nil {
+					err = goa.MergeErrors(err, goa.NestErrors(err2, "BPayload.backups[*]"))
+				}
+			}
+			return
+		}(), "BPayload.backups", i))
+	}
+	for i, e := range v.Codes {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if err2 := cv.ValidateCode(e); err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidCustomError("BPayload.codes[*]", "validateCode", e, err2))
+			}
+			return
+		}(), "BPayload.codes", i))
+	}
+	if err2 := cv.ValidateCode(v.Pin); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidCustomError("BPayload.pin", "validateCode", v.Pin, err2))
+	}
+	return
+}
+
+// validateCPayload runs the custom validations of v.
+func (cv *CustomValidations) validateCPayload(v string) (err error) {
+	if err2 := cv.ValidateCard(v); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidCustomError("payload", "validateCard", v, err2))
+	}
+	return
+}
+
+// NewEndpoints wraps the methods of the "CustomValidations" service with
+// endpoints. It returns an error if cv does not hold all the custom validation
+// functions.
+func NewEndpoints(s Service, cv *CustomValidations) (*Endpoints, error) {
+	if err := cv.check(); err != nil {
+		return nil, err
+	}
+	return &Endpoints{
+		A: NewAEndpoint(s, cv),
+		B: NewBEndpoint(s, cv),
+		C: NewCEndpoint(s, cv),
+	}, nil
+}
+
+// Use applies the given middleware to all the "CustomValidations" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+	e.C = m(e.C)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "CustomValidations".
+func NewAEndpoint(s Service, cv *CustomValidations) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*Card)
+		if err := cv.validateCardType(p); err != nil {
+			return nil, err
+		}
+		return s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "CustomValidations".
+func NewBEndpoint(s Service, cv *CustomValidations) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*BPayload)
+		if err := cv.validateBPayloadType(p); err != nil {
+			return nil, err
+		}
+		return nil, s.B(ctx, p)
+	}
+}
+
+// NewCEndpoint returns an endpoint function that calls the method "C" of
+// service "CustomValidations".
+func NewCEndpoint(s Service, cv *CustomValidations) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		if err := cv.validateCPayload(p); err != nil {
+			return nil, err
+		}
+		return nil, s.C(ctx, p)
+	}
+}
+`
+
+const MethodDefaultsEndpoint = `// Endpoints wraps the "MethodDefaults" service endpoints.
+type Endpoints struct {
+	Create goa.Endpoint
+	Import goa.Endpoint
+	Update goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "MethodDefaults" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Create: NewCreateEndpoint(s),
+		Import: NewImportEndpoint(s),
+		Update: NewUpdateEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "MethodDefaults" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Create = m(e.Create)
+	e.Import = m(e.Import)
+	e.Update = m(e.Update)
+}
+
+// NewCreateEndpoint returns an endpoint function that calls the method
+// "Create" of service "MethodDefaults".
+func NewCreateEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*UserPayload)
+		if p.Status == nil {
+			var tmp string = "active"
+			p.Status = &tmp
+		}
+		if p.Quota == nil {
+			var tmp int = 10
+			p.Quota = &tmp
+		}
+		return nil, s.Create(ctx, p)
+	}
+}
+
+// NewImportEndpoint returns an endpoint function that calls the method
+// "Import" of service "MethodDefaults".
+func NewImportEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*UserPayload)
+		if p.Status == nil {
+			var tmp string = "pending"
+			p.Status = &tmp
+		}
+		return nil, s.Import(ctx, p)
+	}
+}
+
+// NewUpdateEndpoint returns an endpoint function that calls the method
+// "Update" of service "MethodDefaults".
+func NewUpdateEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*UserPayload)
+		return nil, s.Update(ctx, p)
+	}
+}
+`
+
+const ServerDefaultsEndpoint = `// Endpoints wraps the "ServerDefaults" service endpoints.
+type Endpoints struct {
+	Create goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ServerDefaults" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Create: NewCreateEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "ServerDefaults" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Create = m(e.Create)
+}
+
+// NewCreateEndpoint returns an endpoint function that calls the method
+// "Create" of service "ServerDefaults".
+func NewCreateEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*CreatePayload)
+		if p.CreatedAt == nil {
+			var tmp string = time.Now().UTC().Format(time.RFC3339)
+			p.CreatedAt = &tmp
+		}
+		if p.Tags == nil {
+			p.Tags = []*Tag{}
+		}
+		return nil, s.Create(ctx, p)
+	}
+}
+`
+
+const ValidatorEndpoint = `// Endpoints wraps the "Validator" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+	C goa.Endpoint
+}
+
+// Validator validates the payloads of the "Validator" service methods before
+// the endpoints call the service. The endpoints created with NewEndpoints use
+// DefaultValidator, use NewEndpointsWithValidator to provide a different
+// implementation.
+type Validator interface {
+	// ValidateA validates the payload of the "A" method.
+	ValidateA(context.Context, *APayload) error
+	// ValidateB validates the payload of the "B" method.
+	ValidateB(context.Context, int) error
+}
+
+// DefaultValidator implements Validator by running the validations defined in
+// the design.
+type DefaultValidator struct{}
+
+// ValidateA runs the validations defined on the payload of the "A" method.
+func (DefaultValidator) ValidateA(ctx context.Context, p *APayload) error {
+	return p.Validate()
+}
+
+// ValidateB runs the validations defined on the payload of the "B" method.
+func (DefaultValidator) ValidateB(ctx context.Context, p int) error {
+	var err error
+	if p < 1 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError("payload", p, 1, true))
+	}
+	return err
+}
+
+// NewEndpoints wraps the methods of the "Validator" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return NewEndpointsWithValidator(s, DefaultValidator{})
+}
+
+// NewEndpointsWithValidator wraps the methods of the "Validator" service with
+// endpoints that validate the payloads with v.
+func NewEndpointsWithValidator(s Service, v Validator) *Endpoints {
+	return &Endpoints{
+		A: NewAEndpoint(s, v),
+		B: NewBEndpoint(s, v),
+		C: NewCEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "Validator" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+	e.C = m(e.C)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "Validator".
+func NewAEndpoint(s Service, v Validator) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*APayload)
+		if err := v.ValidateA(ctx, p); err != nil {
+			return nil, err
+		}
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "Validator".
+func NewBEndpoint(s Service, v Validator) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(int)
+		if err := v.ValidateB(ctx, p); err != nil {
+			return nil, err
+		}
+		return nil, s.B(ctx, p)
+	}
+}
+
+// NewCEndpoint returns an endpoint function that calls the method "C" of
+// service "Validator".
+func NewCEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.C(ctx)
+	}
+}
+`
+
+const FeatureGateEndpoint = `// Endpoints wraps the "FeatureGate" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+	C goa.Endpoint
+	D goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "FeatureGate" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: NewAEndpoint(s),
+		B: NewBEndpoint(s),
+		C: NewCEndpoint(s),
+		D: NewDEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "FeatureGate" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+	e.C = m(e.C)
+	e.D = m(e.D)
+}
+
+// Names of the feature flags that gate the "FeatureGate" service methods.
+const (
+	// FeatureBeta is the name of the "beta" feature flag.
+	FeatureBeta = "beta"
+	// FeatureNewCheckout is the name of the "new_checkout" feature flag.
+	FeatureNewCheckout = "new_checkout"
+)
+
+// UseFeatureChecker wraps the endpoints of the "FeatureGate" service methods
+// gated by a feature flag with middleware that use fc to reject the requests
+// made while the flag is disabled. The methods are enabled when
+// UseFeatureChecker is not called.
+func (e *Endpoints) UseFeatureChecker(fc goa.FeatureChecker) {
+	e.A = NewAFeatureGate(fc)(e.A)
+	e.B = NewBFeatureGate(fc)(e.B)
+	e.C = NewCFeatureGate(fc)(e.C)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "FeatureGate".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "FeatureGate".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.B(ctx)
+	}
+}
+
+// NewCEndpoint returns an endpoint function that calls the method "C" of
+// service "FeatureGate".
+func NewCEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.C(ctx)
+	}
+}
+
+// NewDEndpoint returns an endpoint function that calls the method "D" of
+// service "FeatureGate".
+func NewDEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.D(ctx)
+	}
+}
+
+// NewAFeatureGate returns an endpoint middleware that rejects the requests
+// made to the method "A" of service "FeatureGate" when fc reports the feature
+// "new_checkout" as disabled.
+func NewAFeatureGate(fc goa.FeatureChecker) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if !fc.FeatureEnabled(ctx, FeatureNewCheckout) {
+				err := fmt.Errorf("feature %q is disabled", FeatureNewCheckout)
+				return nil, goa.NewServiceError(err, "feature_disabled", false, true, false)
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// NewBFeatureGate returns an endpoint middleware that rejects the requests
+// made to the method "B" of service "FeatureGate" when fc reports the feature
+// "new_checkout" as disabled.
+func NewBFeatureGate(fc goa.FeatureChecker) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if !fc.FeatureEnabled(ctx, FeatureNewCheckout) {
+				err := fmt.Errorf("feature %q is disabled", FeatureNewCheckout)
+				return nil, MakeFeatureDisabled(err)
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// NewCFeatureGate returns an endpoint middleware that rejects the requests
+// made to the method "C" of service "FeatureGate" when fc reports the feature
+// "beta" as disabled.
+func NewCFeatureGate(fc goa.FeatureChecker) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if !fc.FeatureEnabled(ctx, FeatureBeta) {
+				err := fmt.Errorf("feature %q is disabled", FeatureBeta)
+				return nil, goa.NewServiceError(err, "feature_disabled", false, true, false)
+			}
+			return e(ctx, req)
+		}
+	}
+}
+`
+
+const MaxConcurrentEndpoint = `// Endpoints wraps the "MaxConcurrent" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "MaxConcurrent" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		A: NewAConcurrencyLimiter()(NewAEndpoint(s)),
+		B: NewBConcurrencyLimiter()(NewBEndpoint(s)),
+	}
+}
+
+// Use applies the given middleware to all the "MaxConcurrent" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "MaxConcurrent".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "MaxConcurrent".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.B(ctx)
+	}
+}
+
+// NewAConcurrencyLimiter returns an endpoint middleware that limits the number
+// of requests handled concurrently by the method "A" of service
+// "MaxConcurrent" to 10.
+func NewAConcurrencyLimiter() func(goa.Endpoint) goa.Endpoint {
+	sem := make(chan struct{}, 10)
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			select {
+			case sem <- struct{}{}:
+			default:
+				return nil, goa.NewServiceError(fmt.Errorf("too many concurrent requests, the maximum is 10"), "unavailable", false, true, false)
+			}
+			// Release the slot even if the endpoint panics.
+			defer func() { <-sem }()
+			return e(ctx, req)
+		}
+	}
+}
+
+// NewBConcurrencyLimiter returns an endpoint middleware that limits the number
+// of requests handled concurrently by the method "B" of service
+// "MaxConcurrent" to 5. Requests beyond the limit wait for a slot until the
+// queue timeout expires or their context is canceled.
+func NewBConcurrencyLimiter() func(goa.Endpoint) goa.Endpoint {
+	sem := make(chan struct{}, 5)
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			select {
+			case sem <- struct{}{}:
+			default:
+				timer := time.NewTimer(2 * time.Second)
+				defer timer.Stop()
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-timer.C:
+					return nil, MakeUnavailable(fmt.Errorf("too many concurrent requests, the maximum is 5"))
+				}
+			}
+			// Release the slot even if the endpoint panics.
+			defer func() { <-sem }()
+			return e(ctx, req)
+		}
+	}
+}
+`
+
+const MultipleEndpointsCheck = `// The assignments below fail to compile if the generated service interface,
+// endpoint constructors or endpoint wrapping function do not have the expected
+// signatures.
+var (
+	_ func(Service) *Endpoints                          = NewEndpoints
+	_ func(*Endpoints, func(goa.Endpoint) goa.Endpoint) = (*Endpoints).Use
+	_ func(Service) goa.Endpoint                        = NewBEndpoint
+	_ func(Service, context.Context, *BType) error      = Service.B
+	_ func(Service) goa.Endpoint                        = NewCEndpoint
+	_ func(Service, context.Context, *CType) error      = Service.C
+)
+`
+
+const WithResultMultipleViewsEndpointCheck = `// The assignments below fail to compile if the generated service interface,
+// endpoint constructors or endpoint wrapping function do not have the expected
+// signatures.
+var (
+	_ func(Service) *Endpoints                          = NewEndpoints
+	_ func(*Endpoints, func(goa.Endpoint) goa.Endpoint) = (*Endpoints).Use
+	_ func(Service) goa.Endpoint                        = NewAEndpoint
+	_ func(Service, context.Context) (*Viewtype, error) = Service.A
+	_ func(Service) goa.Endpoint                        = NewBEndpoint
+	_ func(Service, context.Context) (*Viewtype, error) = Service.B
+)
+`
+
+const StreamingResultMethodEndpointCheck = `// The assignments below fail to compile if the generated service interface,
+// endpoint constructors or endpoint wrapping function do not have the expected
+// signatures.
+var (
+	_ func(Service) *Endpoints                                                        = NewEndpoints
+	_ func(*Endpoints, func(goa.Endpoint) goa.Endpoint)                               = (*Endpoints).Use
+	_ func(Service) goa.Endpoint                                                      = NewStreamingResultMethodEndpoint
+	_ func(Service, context.Context, *AType, StreamingResultMethodServerStream) error = Service.StreamingResultMethod
+)
+`
+
+const SecuritySkipRequestBodyEndpointCheck = `// The assignments below fail to compile if the generated service interface,
+// endpoint constructors or endpoint wrapping function do not have the expected
+// signatures.
+var (
+	_ func(Service) *Endpoints                                                            = NewEndpoints
+	_ func(*Endpoints, func(goa.Endpoint) goa.Endpoint)                                   = (*Endpoints).Use
+	_ func(Service, security.AuthJWTFunc) goa.Endpoint                                    = NewAEndpoint
+	_ func(Service, context.Context, *APayload, io.ReadCloser) (string, error)            = Service.A
+	_ func(Auther, context.Context, string, *security.JWTScheme) (context.Context, error) = Auther.JWTAuth
+)
+`
+
+const ValidatorEndpointCheck = `// The assignments below fail to compile if the generated service interface,
+// endpoint constructors or endpoint wrapping function do not have the expected
+// signatures.
+var (
+	_ func(Service) *Endpoints                          = NewEndpoints
+	_ func(Service, Validator) *Endpoints               = NewEndpointsWithValidator
+	_ func(*Endpoints, func(goa.Endpoint) goa.Endpoint) = (*Endpoints).Use
+	_ func(Service, Validator) goa.Endpoint             = NewAEndpoint
+	_ func(Service, context.Context, *APayload) error   = Service.A
+	_ func(Service, Validator) goa.Endpoint             = NewBEndpoint
+	_ func(Service, context.Context, int) error         = Service.B
+	_ func(Service) goa.Endpoint                        = NewCEndpoint
+	_ func(Service, context.Context) error              = Service.C
+)
+`
+
+const DefaultsFromEndpoint = `// Endpoints wraps the "DefaultsFrom" service endpoints.
+type Endpoints struct {
+	Signup goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "DefaultsFrom" service with endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Signup: NewSignupEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "DefaultsFrom" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Signup = m(e.Signup)
+}
+
+// NewSignupEndpoint returns an endpoint function that calls the method
+// "Signup" of service "DefaultsFrom".
+func NewSignupEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*SignupPayload)
+		if p.Nickname == nil {
+			tmp := p.Username
+			p.Nickname = &tmp
+		}
+		if p.Labels == nil {
+			p.Labels = p.Tags
+		}
+		if p.DisplayName == nil && p.Nickname != nil {
+			tmp := *p.Nickname
+			p.DisplayName = &tmp
+		}
+		return nil, s.Signup(ctx, p)
+	}
+}
+`
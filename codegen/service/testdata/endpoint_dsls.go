@@ -181,3 +181,200 @@ var BidirectionalStreamingEndpointDSL = func() {
 		})
 	})
 }
+
+var CustomValidationsEndpointDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("number", String, func() {
+			CustomValidation("validateCard")
+		})
+		Attribute("expiry", String, func() {
+			CustomValidation("validateExpiry")
+		})
+	})
+	Service("CustomValidations", func() {
+		Method("A", func() {
+			Payload(Card)
+			Result(String, func() {
+				CustomValidation("validateCard")
+			})
+		})
+		Method("B", func() {
+			Payload(func() {
+				Attribute("card", Card)
+				Attribute("backups", ArrayOf(Card))
+				Attribute("codes", ArrayOf(Int, func() {
+					CustomValidation("validateCode")
+				}))
+				Attribute("pin", Int, func() {
+					CustomValidation("validateCode")
+				})
+				Required("pin")
+			})
+		})
+		Method("C", func() {
+			Payload(String, func() {
+				CustomValidation("validateCard")
+			})
+		})
+	})
+}
+
+var MaxConcurrentEndpointDSL = func() {
+	Service("MaxConcurrent", func() {
+		Method("A", func() {
+			Payload(String)
+			MaxConcurrent(10)
+		})
+		Method("B", func() {
+			Error("unavailable")
+			MaxConcurrent(5, func() {
+				QueueTimeout("2s")
+			})
+		})
+	})
+}
+
+var FeatureGateEndpointDSL = func() {
+	Service("FeatureGate", func() {
+		Method("A", func() {
+			Payload(String)
+			FeatureGate("new_checkout")
+		})
+		Method("B", func() {
+			Error("feature_disabled")
+			FeatureGate("new_checkout")
+		})
+		Method("C", func() {
+			FeatureGate("beta")
+		})
+		Method("D", func() {})
+	})
+}
+
+var MethodDefaultsEndpointDSL = func() {
+	var UserPayload = Type("UserPayload", func() {
+		Attribute("name", String)
+		Attribute("status", String, func() {
+			Default("active", func() {
+				Only("Create")
+			})
+			Default("pending", func() {
+				Only("Import")
+			})
+		})
+		Attribute("quota", Int, func() {
+			Default(10, func() {
+				Only("Create")
+			})
+		})
+	})
+	Service("MethodDefaults", func() {
+		Method("Create", func() {
+			Payload(UserPayload)
+		})
+		Method("Import", func() {
+			Payload(UserPayload)
+		})
+		Method("Update", func() {
+			Payload(UserPayload)
+		})
+	})
+}
+
+var ServerDefaultsEndpointDSL = func() {
+	var Tag = Type("Tag", func() {
+		Attribute("name", String)
+	})
+	Service("ServerDefaults", func() {
+		Method("Create", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("created_at", String)
+				Attribute("tags", ArrayOf(Tag))
+				Required("name")
+			})
+			ServerDefault("created_at", "time.Now().UTC().Format(time.RFC3339)", "time")
+			ServerDefault("tags", "[]*Tag{}")
+		})
+	})
+}
+
+var DefaultsFromEndpointDSL = func() {
+	Service("DefaultsFrom", func() {
+		Method("Signup", func() {
+			Payload(func() {
+				Attribute("username", String)
+				Attribute("nickname", String)
+				Attribute("display_name", String)
+				Attribute("tags", ArrayOf(String))
+				Attribute("labels", ArrayOf(String))
+				Required("username")
+			})
+			DefaultFrom("display_name", "nickname")
+			DefaultFrom("nickname", "username")
+			DefaultFrom("labels", "tags")
+		})
+	})
+}
+
+var ValidatorEndpointDSL = func() {
+	Service("Validator", func() {
+		Meta("validate:interface", "true")
+		Method("A", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					MinLength(2)
+				})
+				Required("name")
+			})
+		})
+		Method("B", func() {
+			Payload(Int, func() {
+				Minimum(1)
+			})
+		})
+		Method("C", func() {})
+	})
+}
+
+var EndpointCheckDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	Service("EndpointCheck", func() {
+		Method("A", func() {
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+			})
+			Result(String)
+			HTTP(func() {
+				SkipRequestBodyEncodeDecode()
+				GET("/")
+			})
+		})
+	})
+}
+
+var LongRunningEndpointDSL = func() {
+	var Job = Type("Job", func() {
+		Attribute("id", String)
+		Attribute("tenant", String)
+		Attribute("status", String)
+		Required("id", "tenant", "status")
+	})
+	Service("LongRunningEndpoint", func() {
+		Method("Start", func() {
+			LongRunning(func() {
+				JobType(Job)
+				PollMethod("Poll")
+			})
+		})
+		Method("Poll", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("tenant", String)
+				Required("id")
+			})
+			Result(Job)
+		})
+	})
+}
@@ -0,0 +1,421 @@
+package testdata
+
+const SingleMethodMock = `// Service is a mock implementation of the singlemethod.Service interface. Each
+// method calls the function stored in the corresponding field and panics if
+// the field is nil. Service records the number of calls made to each method,
+// see Calls.
+type Service struct {
+	// AFunc implements the "A" method.
+	AFunc func(ctx context.Context, p *singlemethod.APayload) (*singlemethod.AResult, error)
+
+	callCounter
+}
+
+var _ singlemethod.Service = (*Service)(nil)
+
+// A calls AFunc.
+func (m *Service) A(ctx context.Context, p *singlemethod.APayload) (*singlemethod.AResult, error) {
+	m.record("A")
+	if m.AFunc == nil {
+		panic("mocks: unexpected call to Service.A")
+	}
+	return m.AFunc(ctx, p)
+}
+
+// callCounter records the number of calls made to the mock methods.
+type callCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of times the method with the given name was called.
+func (c *callCounter) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+// record increments the number of calls made to the given method.
+func (c *callCounter) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+`
+
+const MultipleMethodsResultMultipleViewsMock = `// Service is a mock implementation of the
+// multiplemethodsresultmultipleviews.Service interface. Each method calls the
+// function stored in the corresponding field and panics if the field is nil.
+// Service records the number of calls made to each method, see Calls.
+type Service struct {
+	// AFunc implements the "A" method.
+	AFunc func(ctx context.Context, p *multiplemethodsresultmultipleviews.APayload) (*multiplemethodsresultmultipleviews.MultipleViews, string, error)
+	// BFunc implements the "B" method.
+	BFunc func(ctx context.Context) (*multiplemethodsresultmultipleviews.SingleView, error)
+
+	callCounter
+}
+
+var _ multiplemethodsresultmultipleviews.Service = (*Service)(nil)
+
+// A calls AFunc.
+func (m *Service) A(ctx context.Context, p *multiplemethodsresultmultipleviews.APayload) (*multiplemethodsresultmultipleviews.MultipleViews, string, error) {
+	m.record("A")
+	if m.AFunc == nil {
+		panic("mocks: unexpected call to Service.A")
+	}
+	return m.AFunc(ctx, p)
+}
+
+// B calls BFunc.
+func (m *Service) B(ctx context.Context) (*multiplemethodsresultmultipleviews.SingleView, error) {
+	m.record("B")
+	if m.BFunc == nil {
+		panic("mocks: unexpected call to Service.B")
+	}
+	return m.BFunc(ctx)
+}
+
+// callCounter records the number of calls made to the mock methods.
+type callCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of times the method with the given name was called.
+func (c *callCounter) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+// record increments the number of calls made to the given method.
+func (c *callCounter) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+`
+
+const StreamingResultWithViewsMethodMock = `// Service is a mock implementation of the
+// streamingresultwithviewsservice.Service interface. Each method calls the
+// function stored in the corresponding field and panics if the field is nil.
+// Service records the number of calls made to each method, see Calls.
+type Service struct {
+	// StreamingResultWithViewsMethodFunc implements the
+	// "StreamingResultWithViewsMethod" method.
+	StreamingResultWithViewsMethodFunc func(ctx context.Context, p string, stream streamingresultwithviewsservice.StreamingResultWithViewsMethodServerStream) error
+
+	callCounter
+}
+
+var _ streamingresultwithviewsservice.Service = (*Service)(nil)
+
+// StreamingResultWithViewsMethod calls StreamingResultWithViewsMethodFunc.
+func (m *Service) StreamingResultWithViewsMethod(ctx context.Context, p string, stream streamingresultwithviewsservice.StreamingResultWithViewsMethodServerStream) error {
+	m.record("StreamingResultWithViewsMethod")
+	if m.StreamingResultWithViewsMethodFunc == nil {
+		panic("mocks: unexpected call to Service.StreamingResultWithViewsMethod")
+	}
+	return m.StreamingResultWithViewsMethodFunc(ctx, p, stream)
+}
+
+// StreamingResultWithViewsMethodServerStream is a mock implementation of the
+// streamingresultwithviewsservice.StreamingResultWithViewsMethodServerStream
+// interface.
+type StreamingResultWithViewsMethodServerStream struct {
+	// SendFunc implements the Send method.
+	SendFunc func(v *streamingresultwithviewsservice.MultipleViews) error
+	// CloseFunc implements the Close method.
+	CloseFunc func() error
+	// SetViewFunc implements the SetView method.
+	SetViewFunc func(view string)
+
+	callCounter
+}
+
+var _ streamingresultwithviewsservice.StreamingResultWithViewsMethodServerStream = (*StreamingResultWithViewsMethodServerStream)(nil)
+
+// Send calls SendFunc.
+func (m *StreamingResultWithViewsMethodServerStream) Send(v *streamingresultwithviewsservice.MultipleViews) error {
+	m.record("Send")
+	if m.SendFunc == nil {
+		panic("mocks: unexpected call to StreamingResultWithViewsMethodServerStream.Send")
+	}
+	return m.SendFunc(v)
+}
+
+// Close calls CloseFunc.
+func (m *StreamingResultWithViewsMethodServerStream) Close() error {
+	m.record("Close")
+	if m.CloseFunc == nil {
+		panic("mocks: unexpected call to StreamingResultWithViewsMethodServerStream.Close")
+	}
+	return m.CloseFunc()
+}
+
+// SetView calls SetViewFunc.
+func (m *StreamingResultWithViewsMethodServerStream) SetView(view string) {
+	m.record("SetView")
+	if m.SetViewFunc == nil {
+		panic("mocks: unexpected call to StreamingResultWithViewsMethodServerStream.SetView")
+	}
+	m.SetViewFunc(view)
+}
+
+// StreamingResultWithViewsMethodClientStream is a mock implementation of the
+// streamingresultwithviewsservice.StreamingResultWithViewsMethodClientStream
+// interface.
+type StreamingResultWithViewsMethodClientStream struct {
+	// RecvFunc implements the Recv method.
+	RecvFunc func() (*streamingresultwithviewsservice.MultipleViews, error)
+
+	callCounter
+}
+
+var _ streamingresultwithviewsservice.StreamingResultWithViewsMethodClientStream = (*StreamingResultWithViewsMethodClientStream)(nil)
+
+// Recv calls RecvFunc.
+func (m *StreamingResultWithViewsMethodClientStream) Recv() (*streamingresultwithviewsservice.MultipleViews, error) {
+	m.record("Recv")
+	if m.RecvFunc == nil {
+		panic("mocks: unexpected call to StreamingResultWithViewsMethodClientStream.Recv")
+	}
+	return m.RecvFunc()
+}
+
+// callCounter records the number of calls made to the mock methods.
+type callCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of times the method with the given name was called.
+func (c *callCounter) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+// record increments the number of calls made to the given method.
+func (c *callCounter) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+`
+
+const BidirectionalStreamingMethodMock = `// Service is a mock implementation of the
+// bidirectionalstreamingservice.Service interface. Each method calls the
+// function stored in the corresponding field and panics if the field is nil.
+// Service records the number of calls made to each method, see Calls.
+type Service struct {
+	// BidirectionalStreamingMethodFunc implements the
+	// "BidirectionalStreamingMethod" method.
+	BidirectionalStreamingMethodFunc func(ctx context.Context, p *bidirectionalstreamingservice.BPayload, stream bidirectionalstreamingservice.BidirectionalStreamingMethodServerStream) error
+
+	callCounter
+}
+
+var _ bidirectionalstreamingservice.Service = (*Service)(nil)
+
+// BidirectionalStreamingMethod calls BidirectionalStreamingMethodFunc.
+func (m *Service) BidirectionalStreamingMethod(ctx context.Context, p *bidirectionalstreamingservice.BPayload, stream bidirectionalstreamingservice.BidirectionalStreamingMethodServerStream) error {
+	m.record("BidirectionalStreamingMethod")
+	if m.BidirectionalStreamingMethodFunc == nil {
+		panic("mocks: unexpected call to Service.BidirectionalStreamingMethod")
+	}
+	return m.BidirectionalStreamingMethodFunc(ctx, p, stream)
+}
+
+// BidirectionalStreamingMethodServerStream is a mock implementation of the
+// bidirectionalstreamingservice.BidirectionalStreamingMethodServerStream
+// interface.
+type BidirectionalStreamingMethodServerStream struct {
+	// SendFunc implements the Send method.
+	SendFunc func(v *bidirectionalstreamingservice.AResult) error
+	// RecvFunc implements the Recv method.
+	RecvFunc func() (*bidirectionalstreamingservice.APayload, error)
+	// CloseFunc implements the Close method.
+	CloseFunc func() error
+
+	callCounter
+}
+
+var _ bidirectionalstreamingservice.BidirectionalStreamingMethodServerStream = (*BidirectionalStreamingMethodServerStream)(nil)
+
+// Send calls SendFunc.
+func (m *BidirectionalStreamingMethodServerStream) Send(v *bidirectionalstreamingservice.AResult) error {
+	m.record("Send")
+	if m.SendFunc == nil {
+		panic("mocks: unexpected call to BidirectionalStreamingMethodServerStream.Send")
+	}
+	return m.SendFunc(v)
+}
+
+// Recv calls RecvFunc.
+func (m *BidirectionalStreamingMethodServerStream) Recv() (*bidirectionalstreamingservice.APayload, error) {
+	m.record("Recv")
+	if m.RecvFunc == nil {
+		panic("mocks: unexpected call to BidirectionalStreamingMethodServerStream.Recv")
+	}
+	return m.RecvFunc()
+}
+
+// Close calls CloseFunc.
+func (m *BidirectionalStreamingMethodServerStream) Close() error {
+	m.record("Close")
+	if m.CloseFunc == nil {
+		panic("mocks: unexpected call to BidirectionalStreamingMethodServerStream.Close")
+	}
+	return m.CloseFunc()
+}
+
+// BidirectionalStreamingMethodClientStream is a mock implementation of the
+// bidirectionalstreamingservice.BidirectionalStreamingMethodClientStream
+// interface.
+type BidirectionalStreamingMethodClientStream struct {
+	// SendFunc implements the Send method.
+	SendFunc func(v *bidirectionalstreamingservice.APayload) error
+	// RecvFunc implements the Recv method.
+	RecvFunc func() (*bidirectionalstreamingservice.AResult, error)
+	// CloseAndRecvFunc implements the CloseAndRecv method.
+	CloseAndRecvFunc func() (*bidirectionalstreamingservice.AResult, error)
+	// CloseFunc implements the Close method.
+	CloseFunc func() error
+
+	callCounter
+}
+
+var _ bidirectionalstreamingservice.BidirectionalStreamingMethodClientStream = (*BidirectionalStreamingMethodClientStream)(nil)
+
+// Send calls SendFunc.
+func (m *BidirectionalStreamingMethodClientStream) Send(v *bidirectionalstreamingservice.APayload) error {
+	m.record("Send")
+	if m.SendFunc == nil {
+		panic("mocks: unexpected call to BidirectionalStreamingMethodClientStream.Send")
+	}
+	return m.SendFunc(v)
+}
+
+// Recv calls RecvFunc.
+func (m *BidirectionalStreamingMethodClientStream) Recv() (*bidirectionalstreamingservice.AResult, error) {
+	m.record("Recv")
+	if m.RecvFunc == nil {
+		panic("mocks: unexpected call to BidirectionalStreamingMethodClientStream.Recv")
+	}
+	return m.RecvFunc()
+}
+
+// CloseAndRecv calls CloseAndRecvFunc.
+func (m *BidirectionalStreamingMethodClientStream) CloseAndRecv() (*bidirectionalstreamingservice.AResult, error) {
+	m.record("CloseAndRecv")
+	if m.CloseAndRecvFunc == nil {
+		panic("mocks: unexpected call to BidirectionalStreamingMethodClientStream.CloseAndRecv")
+	}
+	return m.CloseAndRecvFunc()
+}
+
+// Close calls CloseFunc.
+func (m *BidirectionalStreamingMethodClientStream) Close() error {
+	m.record("Close")
+	if m.CloseFunc == nil {
+		panic("mocks: unexpected call to BidirectionalStreamingMethodClientStream.Close")
+	}
+	return m.CloseFunc()
+}
+
+// callCounter records the number of calls made to the mock methods.
+type callCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of times the method with the given name was called.
+func (c *callCounter) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+// record increments the number of calls made to the given method.
+func (c *callCounter) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+`
+
+const BasicAuthAndSkipRequestBodyEncodeDecodeMock = `// Service is a mock implementation of the
+// endpointwithskiprequestbodyencodedecode.Service interface. Each method calls
+// the function stored in the corresponding field and panics if the field is
+// nil. Service records the number of calls made to each method, see Calls.
+type Service struct {
+	// EndpointWithSkipRequestBodyEncodeDecodeFunc implements the
+	// "EndpointWithSkipRequestBodyEncodeDecode" method.
+	EndpointWithSkipRequestBodyEncodeDecodeFunc func(ctx context.Context, p *endpointwithskiprequestbodyencodedecode.EndpointWithSkipRequestBodyEncodeDecodePayload, body io.ReadCloser) error
+	// BasicAuthFunc implements the authorization logic for the Basic security
+	// scheme.
+	BasicAuthFunc func(ctx context.Context, user, pass string, schema *security.BasicScheme) (context.Context, error)
+
+	callCounter
+}
+
+var _ endpointwithskiprequestbodyencodedecode.Service = (*Service)(nil)
+
+var _ endpointwithskiprequestbodyencodedecode.Auther = (*Service)(nil)
+
+// BasicAuth calls BasicAuthFunc.
+func (m *Service) BasicAuth(ctx context.Context, user, pass string, schema *security.BasicScheme) (context.Context, error) {
+	m.record("BasicAuth")
+	if m.BasicAuthFunc == nil {
+		panic("mocks: unexpected call to Service.BasicAuth")
+	}
+	return m.BasicAuthFunc(ctx, user, pass, schema)
+}
+
+// EndpointWithSkipRequestBodyEncodeDecode calls
+// EndpointWithSkipRequestBodyEncodeDecodeFunc.
+func (m *Service) EndpointWithSkipRequestBodyEncodeDecode(ctx context.Context, p *endpointwithskiprequestbodyencodedecode.EndpointWithSkipRequestBodyEncodeDecodePayload, body io.ReadCloser) error {
+	m.record("EndpointWithSkipRequestBodyEncodeDecode")
+	if m.EndpointWithSkipRequestBodyEncodeDecodeFunc == nil {
+		panic("mocks: unexpected call to Service.EndpointWithSkipRequestBodyEncodeDecode")
+	}
+	return m.EndpointWithSkipRequestBodyEncodeDecodeFunc(ctx, p, body)
+}
+
+// callCounter records the number of calls made to the mock methods.
+type callCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// Calls returns the number of times the method with the given name was called.
+func (c *callCounter) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[method]
+}
+
+// record increments the number of calls made to the given method.
+func (c *callCounter) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[method]++
+}
+`
@@ -117,6 +117,42 @@ var EndpointWithRequiredScopesDSL = func() {
 	})
 }
 
+var EndpointWithEnforcedScopesDSL = func() {
+	Service("EndpointWithEnforcedScopes", func() {
+		Method("SecureWithEnforcedScopes", func() {
+			Security(JWTAuth, func() {
+				RequiredScopes("api:read", "api:write")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+			Error("forbidden")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var EndpointWithEnforcedScopesNoErrorDSL = func() {
+	Service("EndpointWithEnforcedScopesNoError", func() {
+		Method("SecureWithEnforcedScopesNoError", func() {
+			Security(BasicAuth)
+			Security(JWTAuth, func() {
+				RequiredScopes("api:read")
+			})
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var EndpointWithOptionalRequiredScopesDSL = func() {
 	Service("EndpointWithOptionalRequiredScopes", func() {
 		Method("SecureWithOptionalRequiredScopes", func() {
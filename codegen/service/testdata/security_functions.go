@@ -67,6 +67,82 @@ func NewSecureWithRequiredScopesEndpoint(s Service, authJWTFn security.AuthJWTFu
 }
 `
 
+var EndpointWithEnforcedScopesCode = `// NewSecureWithEnforcedScopesEndpoint returns an endpoint function that calls
+// the method "SecureWithEnforcedScopes" of service
+// "EndpointWithEnforcedScopes".
+func NewSecureWithEnforcedScopesEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*SecureWithEnforcedScopesPayload)
+		var err error
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read", "api:write", "api:admin"},
+			RequiredScopes: []string{"api:read", "api:write"},
+		}
+		var token string
+		if p.Token != nil {
+			token = *p.Token
+		}
+		ctx, err = authJWTFn(ctx, token, &sc)
+		if err == nil {
+			if serr := security.CheckScopes(ctx, []string{"api:read", "api:write"}); serr != nil {
+				err = MakeForbidden(serr)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.SecureWithEnforcedScopes(ctx, p)
+	}
+}
+`
+
+var EndpointWithEnforcedScopesNoErrorCode = `// NewSecureWithEnforcedScopesNoErrorEndpoint returns an endpoint function that
+// calls the method "SecureWithEnforcedScopesNoError" of service
+// "EndpointWithEnforcedScopesNoError".
+func NewSecureWithEnforcedScopesNoErrorEndpoint(s Service, authBasicFn security.AuthBasicFunc, authJWTFn security.AuthJWTFunc) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*SecureWithEnforcedScopesNoErrorPayload)
+		var err error
+		sc := security.BasicScheme{
+			Name:           "basic",
+			Scopes:         []string{"api:read", "api:write", "api:admin"},
+			RequiredScopes: []string{},
+		}
+		var user string
+		if p.User != nil {
+			user = *p.User
+		}
+		var pass string
+		if p.Pass != nil {
+			pass = *p.Pass
+		}
+		ctx, err = authBasicFn(ctx, user, pass, &sc)
+		if err != nil {
+			sc := security.JWTScheme{
+				Name:           "jwt",
+				Scopes:         []string{"api:read", "api:write", "api:admin"},
+				RequiredScopes: []string{"api:read"},
+			}
+			var token string
+			if p.Token != nil {
+				token = *p.Token
+			}
+			ctx, err = authJWTFn(ctx, token, &sc)
+			if err == nil {
+				if serr := security.CheckScopes(ctx, []string{"api:read"}); serr != nil {
+					err = goa.NewServiceError(serr, "forbidden", false, false, false)
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.SecureWithEnforcedScopesNoError(ctx, p)
+	}
+}
+`
+
 var EndpointWithOptionalRequiredScopesCode = `// NewSecureWithOptionalRequiredScopesEndpoint returns an endpoint function
 // that calls the method "SecureWithOptionalRequiredScopes" of service
 // "EndpointWithOptionalRequiredScopes".
@@ -95,6 +95,24 @@ type AResult struct {
 	BytesField    []byte
 	OptionalField *string
 }
+
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
+// Validate runs the validations defined on AResult. It returns all the
+// validation errors merged into a single error.
+func (v *AResult) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "AResult"))
+	}
+	return
+}
 `
 
 const MultipleMethods = `
@@ -163,6 +181,24 @@ type Child struct {
 type Parent struct {
 	C *Child
 }
+
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
+// Validate runs the validations defined on AResult. It returns all the
+// validation errors merged into a single error.
+func (v *AResult) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "AResult"))
+	}
+	return
+}
 `
 
 const UnionMethod = `
@@ -291,6 +327,58 @@ const ServiceName = "Empty"
 var MethodNames = [1]string{"Empty"}
 `
 
+const APIVersion = `
+// Service is the Versioned service interface.
+type Service interface {
+	// Versioned implements Versioned.
+	Versioned(context.Context) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Versioned"
+
+// APIVersion is the version of the API as defined in the design.
+const APIVersion = "2.3.1"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"Versioned"}
+`
+
+const SLOMethods = `
+// Service is the SLO service interface.
+type Service interface {
+	// Search implements Search.
+	Search(context.Context) (err error)
+	// Report implements Report.
+	Report(context.Context) (err error)
+	// Ping implements Ping.
+	Ping(context.Context) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SLO"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [3]string{"Search", "Report", "Ping"}
+
+// MethodSLOs lists the service level objectives of the service methods as
+// defined in the design indexed by method name. Metrics middlewares may use
+// them as labels or alerting targets, they do not affect how the requests are
+// handled.
+var MethodSLOs = map[string]goa.SLO{
+	"Search": {LatencyP99: 200 * time.Millisecond, SuccessRate: 99.9},
+	"Report": {LatencyP99: 2 * time.Second},
+}
+`
+
 const EmptyResultMethod = `
 // Service is the EmptyResult service interface.
 type Service interface {
@@ -316,6 +404,15 @@ type APayload struct {
 	BytesField    []byte
 	OptionalField *string
 }
+
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
 `
 
 const EmptyPayloadMethod = `
@@ -343,6 +440,15 @@ type AResult struct {
 	BytesField    []byte
 	OptionalField *string
 }
+
+// Validate runs the validations defined on AResult. It returns all the
+// validation errors merged into a single error.
+func (v *AResult) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "AResult"))
+	}
+	return
+}
 `
 
 const ServiceError = `
@@ -366,6 +472,77 @@ var MethodNames = [1]string{"A"}
 func MakeError(err error) *goa.ServiceError {
 	return goa.NewServiceError(err, "error", false, false, false)
 }
+
+// Errors lists the errors that the methods of the "ServiceError" service may
+// return indexed by name. Use Errors.Retryable to determine whether a request
+// that failed with an error may be retried.
+var Errors = goa.ErrorCatalog{
+	"error": {Name: "error"},
+}
+`
+
+const ErrorCatalog = `
+// Service is the ErrorCatalog service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context) (err error)
+	// B implements B.
+	B(context.Context) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "ErrorCatalog"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [2]string{"A", "B"}
+
+type NotFound string
+
+// Error returns an error description.
+func (e NotFound) Error() string {
+	return ""
+}
+
+// ErrorName returns "not_found".
+//
+// Deprecated: Use GoaErrorName - https://github.com/goadesign/goa/issues/3105
+func (e NotFound) ErrorName() string {
+	return e.GoaErrorName()
+}
+
+// GoaErrorName returns "not_found".
+func (e NotFound) GoaErrorName() string {
+	return "not_found"
+}
+
+// MakeUnavailable builds a goa.ServiceError from an error.
+func MakeUnavailable(err error) *goa.ServiceError {
+	return goa.NewServiceError(err, "unavailable", false, true, false)
+}
+
+// MakeTimeout builds a goa.ServiceError from an error.
+func MakeTimeout(err error) *goa.ServiceError {
+	return goa.NewServiceError(err, "timeout", true, true, false)
+}
+
+// MakeInternal builds a goa.ServiceError from an error.
+func MakeInternal(err error) *goa.ServiceError {
+	return goa.NewServiceError(err, "internal", false, false, true)
+}
+
+// Errors lists the errors that the methods of the "ErrorCatalog" service may
+// return indexed by name. Use Errors.Retryable to determine whether a request
+// that failed with an error may be retried.
+var Errors = goa.ErrorCatalog{
+	"unavailable": {Name: "unavailable", Temporary: true},
+	"timeout":     {Name: "timeout", Timeout: true, Temporary: true},
+	"internal":    {Name: "internal", Fault: true},
+	"not_found":   {Name: "not_found"},
+}
 `
 
 const CustomErrors = `
@@ -451,6 +628,24 @@ func (e Primitive) ErrorName() string {
 func (e Primitive) GoaErrorName() string {
 	return "primitive"
 }
+
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
+// Errors lists the errors that the methods of the "CustomErrors" service may
+// return indexed by name. Use Errors.Retryable to determine whether a request
+// that failed with an error may be retried.
+var Errors = goa.ErrorCatalog{
+	"primitive":         {Name: "primitive"},
+	"user_type":         {Name: "user_type"},
+	"struct_error_name": {Name: "struct_error_name"},
+}
 `
 
 const CustomErrorsCustomField = `
@@ -490,6 +685,13 @@ func (e *GoaError) ErrorName() string {
 func (e *GoaError) GoaErrorName() string {
 	return e.ErrorCode
 }
+
+// Errors lists the errors that the methods of the "CustomErrorsCustomFields"
+// service may return indexed by name. Use Errors.Retryable to determine
+// whether a request that failed with an error may be retried.
+var Errors = goa.ErrorCatalog{
+	"struct_error_name": {Name: "struct_error_name"},
+}
 `
 
 const MultipleMethodsResultMultipleViews = `
@@ -538,6 +740,15 @@ type SingleView struct {
 	B *string
 }
 
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
 // NewMultipleViews initializes result type MultipleViews from viewed result
 // type MultipleViews.
 func NewMultipleViews(vres *multiplemethodsresultmultipleviewsviews.MultipleViews) *MultipleViews {
@@ -911,6 +1122,15 @@ type MultipleViews2 struct {
 	B *string
 }
 
+// Validate runs the validations defined on MultipleViews. It returns all the
+// validation errors merged into a single error.
+func (v *MultipleViews) Validate() (err error) {
+	if v.B == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("b", "MultipleViews"))
+	}
+	return
+}
+
 // NewMultipleViews initializes result type MultipleViews from viewed result
 // type MultipleViews.
 func NewMultipleViews(vres *resultwithotherresultviews.MultipleViews) *MultipleViews {
@@ -1610,6 +1830,145 @@ type ForcedType struct {
 }
 `
 
+const Constructor = `
+// Service is the Constructor service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *User) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Constructor"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+type Address struct {
+	City *string
+}
+
+// User is the payload type of the Constructor service A method.
+type User struct {
+	Name     string
+	Type     string
+	Address  *Address
+	Tags     []string
+	Role     string
+	Rank     int
+	Nickname *string
+}
+
+// NewUser returns a new User initialized with the given required fields. The
+// optional fields with a default value are set to that value.
+func NewUser(name string, type_ string, address *Address, tags []string) *User {
+	return &User{
+		Name:    name,
+		Type:    type_,
+		Address: address,
+		Tags:    tags,
+		Role:    "member",
+		Rank:    1,
+	}
+}
+
+// Validate runs the validations defined on User. It returns all the
+// validation errors merged into a single error.
+func (v *User) Validate() (err error) {
+	if v.Address == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("address", "User"))
+	}
+	if v.Tags == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("tags", "User"))
+	}
+	return
+}
+`
+
+const SensitiveAttributes = `
+// Service is the SensitiveAttributes service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *User) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SensitiveAttributes"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+type Address struct {
+	City   *string
+	Street *string
+}
+
+type Token string
+
+// User is the payload type of the SensitiveAttributes service A method.
+type User struct {
+	Name     *string
+	Ssn      *string
+	Token    *Token
+	Address  *Address
+	Previous []*Address
+	Labels   map[string]string
+	Age      int
+}
+
+// String returns the representation of the Address value with the
+// sensitive fields redacted.
+func (v *Address) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if v.City != nil {
+		fields = append(fields, fmt.Sprintf("city:%v", *v.City))
+	}
+	fields = append(fields, "street:[REDACTED]")
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
+// Format implements fmt.Formatter. It prints the value returned by String for
+// all the verbs so that the sensitive fields are never printed.
+func (v *Address) Format(f fmt.State, _ rune) {
+	fmt.Fprint(f, v.String())
+}
+
+// String returns the representation of the User value with the
+// sensitive fields redacted.
+func (v *User) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if v.Name != nil {
+		fields = append(fields, fmt.Sprintf("name:%v", *v.Name))
+	}
+	fields = append(fields, "ssn:[REDACTED]")
+	fields = append(fields, "token:[REDACTED]")
+	fields = append(fields, fmt.Sprintf("address:%v", v.Address))
+	fields = append(fields, fmt.Sprintf("previous:%v", v.Previous))
+	fields = append(fields, fmt.Sprintf("labels:%v", v.Labels))
+	fields = append(fields, fmt.Sprintf("age:%v", v.Age))
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
+// Format implements fmt.Formatter. It prints the value returned by String for
+// all the verbs so that the sensitive fields are never printed.
+func (v *User) Format(f fmt.State, _ rune) {
+	fmt.Fprint(f, v.String())
+}
+`
+
 const ForceGenerateTypeExplicit = `
 // Service is the ForceGenerateTypeExplicit service interface.
 type Service interface {
@@ -1684,6 +2043,24 @@ type AResult struct {
 	BytesField    []byte
 	OptionalField *string
 }
+
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
+// Validate runs the validations defined on AResult. It returns all the
+// validation errors merged into a single error.
+func (v *AResult) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "AResult"))
+	}
+	return
+}
 `
 
 const StreamingResultWithViewsMethod = `
@@ -1907,6 +2284,147 @@ func newMultipleViewsViewTiny(res *MultipleViews) *streamingresultwithexplicitvi
 }
 `
 
+const BatchMethod = `
+// Service is the BatchService service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (res int, err error)
+	// B implements B.
+	B(context.Context, string) (err error)
+	// Applies the A method to each payload of the batch.
+	BatchA(context.Context, []*APayload) (res []*ABatchItem, err error)
+	// Applies the B method to each payload of the batch.
+	BatchB(context.Context, []string) (res []*BBatchItem, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "BatchService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [4]string{"A", "B", "batch_A", "batch_B"}
+
+// Outcome of the A method for one item of a batch.
+type ABatchItem struct {
+	Result *int
+	// Error produced by the item if it failed.
+	Error *BatchItemError
+}
+
+// APayload is the payload type of the BatchService service A method.
+type APayload struct {
+	IntField    *int
+	StringField string
+}
+
+// Outcome of the B method for one item of a batch.
+type BBatchItem struct {
+	// Error produced by the item if it failed.
+	Error *BatchItemError
+}
+
+// Error produced by a batch item.
+type BatchItemError struct {
+	// Name of the error.
+	Name string
+	// Error message.
+	Message string
+}
+
+// RunBatchA implements the "batch_A" method by calling the "A" method of s for
+// each payload of the batch. The errors returned by the "A" method are
+// reported in the items of the failed payloads and do not interrupt the
+// processing of the batch.
+func RunBatchA(ctx context.Context, s Service, p []*APayload) []*ABatchItem {
+	res := make([]*ABatchItem, len(p))
+	for i, item := range p {
+		r, err := s.A(ctx, item)
+		if err != nil {
+			name := "fault"
+			var namer goa.GoaErrorNamer
+			if errors.As(err, &namer) {
+				name = namer.GoaErrorName()
+			}
+			res[i] = &ABatchItem{Error: &BatchItemError{Name: name, Message: err.Error()}}
+			continue
+		}
+		res[i] = &ABatchItem{Result: &r}
+	}
+	return res
+}
+
+// RunBatchB implements the "batch_B" method by calling the "B" method of s for
+// each payload of the batch. The errors returned by the "B" method are
+// reported in the items of the failed payloads and do not interrupt the
+// processing of the batch.
+func RunBatchB(ctx context.Context, s Service, p []string) []*BBatchItem {
+	res := make([]*BBatchItem, len(p))
+	for i, item := range p {
+		err := s.B(ctx, item)
+		if err != nil {
+			name := "fault"
+			var namer goa.GoaErrorNamer
+			if errors.As(err, &namer) {
+				name = namer.GoaErrorName()
+			}
+			res[i] = &BBatchItem{Error: &BatchItemError{Name: name, Message: err.Error()}}
+			continue
+		}
+		res[i] = &BBatchItem{}
+	}
+	return res
+}
+`
+
+const StreamingResultWithTrailersMethod = `
+// Service is the StreamingResultWithTrailersService service interface.
+type Service interface {
+	// StreamingResultWithTrailersMethod implements
+	// StreamingResultWithTrailersMethod.
+	StreamingResultWithTrailersMethod(context.Context, StreamingResultWithTrailersMethodServerStream) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "StreamingResultWithTrailersService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"StreamingResultWithTrailersMethod"}
+
+// StreamingResultWithTrailersMethodServerStream is the interface a
+// "StreamingResultWithTrailersMethod" endpoint server stream must satisfy.
+type StreamingResultWithTrailersMethodServerStream interface {
+	// Send streams instances of "string".
+	Send(string) error
+	// Close closes the stream.
+	Close() error
+	// SetTrailers sets the trailers sent once the results have been streamed.
+	SetTrailers(*StreamingResultWithTrailersMethodTrailers)
+}
+
+// StreamingResultWithTrailersMethodClientStream is the interface a
+// "StreamingResultWithTrailersMethod" endpoint client stream must satisfy.
+type StreamingResultWithTrailersMethodClientStream interface {
+	// Recv reads instances of "string" from the stream.
+	Recv() (string, error)
+}
+
+// StreamingResultWithTrailersMethodTrailers contains the trailers sent by the
+// "StreamingResultWithTrailersMethod" method once the results have been
+// streamed. Trailers whose field is nil are not sent.
+type StreamingResultWithTrailersMethodTrailers struct {
+	// Number of streamed results
+	XTotalCount *int
+	XNext       *string
+}
+`
+
 const StreamingResultNoPayloadMethod = `
 // Service is the StreamingResultNoPayloadService service interface.
 type Service interface {
@@ -1949,6 +2467,15 @@ type AResult struct {
 	BytesField    []byte
 	OptionalField *string
 }
+
+// Validate runs the validations defined on AResult. It returns all the
+// validation errors merged into a single error.
+func (v *AResult) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "AResult"))
+	}
+	return
+}
 `
 
 const StreamingPayloadMethod = `
@@ -2026,6 +2553,24 @@ type Child struct {
 type Parent struct {
 	C *Child
 }
+
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
+// Validate runs the validations defined on AResult. It returns all the
+// validation errors merged into a single error.
+func (v *AResult) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "AResult"))
+	}
+	return
+}
 `
 
 const StreamingPayloadNoPayloadMethod = `
@@ -2162,6 +2707,15 @@ type MultipleViews struct {
 	B *string
 }
 
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
 // NewMultipleViews initializes result type MultipleViews from viewed result
 // type MultipleViews.
 func NewMultipleViews(vres *streamingpayloadresultwithviewsserviceviews.MultipleViews) *MultipleViews {
@@ -2379,6 +2933,10 @@ type BidirectionalStreamingMethodClientStream interface {
 	Send(*APayload) error
 	// Recv reads instances of "AResult" from the stream.
 	Recv() (*AResult, error)
+	// CloseAndRecv stops sending messages to the stream and reads the next
+	// instance of "AResult" from the stream. Use Recv to read the remaining
+	// instances until it returns io.EOF.
+	CloseAndRecv() (*AResult, error)
 	// Close closes the stream.
 	Close() error
 }
@@ -2422,6 +2980,24 @@ type Child struct {
 type Parent struct {
 	C *Child
 }
+
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
+// Validate runs the validations defined on AResult. It returns all the
+// validation errors merged into a single error.
+func (v *AResult) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "AResult"))
+	}
+	return
+}
 `
 
 const BidirectionalStreamingNoPayloadMethod = `
@@ -2460,6 +3036,10 @@ type BidirectionalStreamingNoPayloadMethodClientStream interface {
 	Send(string) error
 	// Recv reads instances of "int" from the stream.
 	Recv() (int, error)
+	// CloseAndRecv stops sending messages to the stream and reads the next
+	// instance of "int" from the stream. Use Recv to read the remaining instances
+	// until it returns io.EOF.
+	CloseAndRecv() (int, error)
 	// Close closes the stream.
 	Close() error
 }
@@ -2509,6 +3089,10 @@ type BidirectionalStreamingResultWithViewsMethodClientStream interface {
 	Send(*APayload) error
 	// Recv reads instances of "MultipleViews" from the stream.
 	Recv() (*MultipleViews, error)
+	// CloseAndRecv stops sending messages to the stream and reads the next
+	// instance of "MultipleViews" from the stream. Use Recv to read the remaining
+	// instances until it returns io.EOF.
+	CloseAndRecv() (*MultipleViews, error)
 	// Close closes the stream.
 	Close() error
 }
@@ -2532,6 +3116,15 @@ type MultipleViews struct {
 	B *string
 }
 
+// Validate runs the validations defined on APayload. It returns all the
+// validation errors merged into a single error.
+func (v *APayload) Validate() (err error) {
+	if v.BytesField == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("BytesField", "APayload"))
+	}
+	return
+}
+
 // NewMultipleViews initializes result type MultipleViews from viewed result
 // type MultipleViews.
 func NewMultipleViews(vres *bidirectionalstreamingresultwithviewsserviceviews.MultipleViews) *MultipleViews {
@@ -2638,6 +3231,10 @@ type BidirectionalStreamingResultWithExplicitViewMethodClientStream interface {
 	Send([][]byte) error
 	// Recv reads instances of "MultipleViews" from the stream.
 	Recv() (*MultipleViews, error)
+	// CloseAndRecv stops sending messages to the stream and reads the next
+	// instance of "MultipleViews" from the stream. Use Recv to read the remaining
+	// instances until it returns io.EOF.
+	CloseAndRecv() (*MultipleViews, error)
 	// Close closes the stream.
 	Close() error
 }
@@ -2939,3 +3536,49 @@ type Foo struct {
 	IntField *int
 }
 `
+
+const NumberValidation = `
+// Service is the NumberValidation service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *Amount) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "NumberValidation"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// Amount is the payload type of the NumberValidation service A method.
+type Amount struct {
+	Value json.Number
+	Limit *json.Number
+}
+
+// Validate runs the validations defined on Amount. It returns all the
+// validation errors merged into a single error.
+func (v *Amount) Validate() (err error) {
+	if nv, nerr := v.Value.Float64(); nerr != nil {
+		err = goa.MergeErrors(err, goa.InvalidFieldTypeError("Amount.Value", v.Value.String(), "number"))
+	} else {
+		if nv < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("Amount.Value", nv, 0, true))
+		}
+	}
+	if v.Limit != nil {
+		if nv, nerr := v.Limit.Float64(); nerr != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError("Amount.Limit", v.Limit.String(), "number"))
+		} else {
+			if nv >= 100 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("Amount.Limit", nv, 100, false))
+			}
+		}
+	}
+	return
+}
+`
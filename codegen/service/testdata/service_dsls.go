@@ -149,6 +149,52 @@ var WithDefaultDSL = func() {
 	})
 }
 
+var ValidatedPayloadsDSL = func() {
+	Service("ValidatedPayloads", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("IntField", Int, func() {
+					Minimum(1)
+					Default(2)
+				})
+				Attribute("EnumField", String, func() {
+					Enum("a", "b")
+					Default("a")
+				})
+				Attribute("RequiredField", String, func() {
+					MinLength(2)
+				})
+				Required("RequiredField")
+			})
+		})
+		Method("B", func() {
+			Payload(String, func() {
+				MaxLength(10)
+			})
+			Result(String)
+		})
+	})
+}
+
+var NumberValidationDSL = func() {
+	var Amount = Type("Amount", func() {
+		Attribute("Value", Float64, func() {
+			Meta("struct:field:type", "json.Number", "encoding/json")
+			Minimum(0)
+		})
+		Attribute("Limit", Float64, func() {
+			Meta("struct:field:type", "json.Number", "encoding/json")
+			ExclusiveMaximum(100)
+		})
+		Required("Value")
+	})
+	Service("NumberValidation", func() {
+		Method("A", func() {
+			Payload(Amount)
+		})
+	})
+}
+
 var EmptyMethodDSL = func() {
 	Service("Empty", func() {
 		Method("Empty", func() {
@@ -156,6 +202,34 @@ var EmptyMethodDSL = func() {
 	})
 }
 
+var APIVersionDSL = func() {
+	API("APIVersion", func() {
+		Version("2.3.1")
+	})
+	Service("Versioned", func() {
+		Method("Versioned", func() {
+		})
+	})
+}
+
+var SLOMethodsDSL = func() {
+	Service("SLO", func() {
+		Method("Search", func() {
+			SLO(func() {
+				LatencyP99("200ms")
+				SuccessRate(99.9)
+			})
+		})
+		Method("Report", func() {
+			SLO(func() {
+				LatencyP99("2s")
+			})
+		})
+		Method("Ping", func() {
+		})
+	})
+}
+
 var EmptyPayloadMethodDSL = func() {
 	var AResult = Type("AResult", func() {
 		Attribute("IntField", Int)
@@ -196,6 +270,30 @@ var ServiceErrorDSL = func() {
 	})
 }
 
+var ErrorCatalogDSL = func() {
+	Service("ErrorCatalog", func() {
+		Error("unavailable", func() {
+			Temporary()
+		})
+		Method("A", func() {
+			Error("timeout", func() {
+				Timeout()
+				Temporary()
+			})
+			Error("internal", func() {
+				Fault()
+			})
+		})
+		Method("B", func() {
+			Error("timeout", func() {
+				Timeout()
+				Temporary()
+			})
+			Error("not_found", String)
+		})
+	})
+}
+
 var CustomErrorsDSL = func() {
 	var APayload = Type("APayload", func() {
 		Attribute("IntField", Int)
@@ -493,6 +591,61 @@ var ForceGenerateTypeExplicitDSL = func() {
 	})
 }
 
+var ConstructorDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("city", String)
+	})
+	var User = Type("User", func() {
+		Meta("struct:constructor", "true")
+		Attribute("name", String)
+		Attribute("type", String)
+		Attribute("address", Address)
+		Attribute("tags", ArrayOf(String))
+		Attribute("role", String, func() {
+			Default("member")
+		})
+		Attribute("rank", Int, func() {
+			Default(1)
+		})
+		Attribute("nickname", String)
+		Required("name", "type", "address", "tags")
+	})
+	Service("Constructor", func() {
+		Method("A", func() {
+			Payload(User)
+		})
+	})
+}
+
+var SensitiveAttributesDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("city", String)
+		Attribute("street", String, func() {
+			Meta("sensitive", "pii")
+		})
+	})
+	var Token = Type("Token", String, func() {
+		Meta("sensitive", "secret")
+	})
+	var User = Type("User", func() {
+		Attribute("name", String)
+		Attribute("ssn", String, func() {
+			Meta("sensitive", "pii")
+		})
+		Attribute("token", Token)
+		Attribute("address", Address)
+		Attribute("previous", ArrayOf(Address))
+		Attribute("labels", MapOf(String, String))
+		Attribute("age", Int)
+		Required("age")
+	})
+	Service("SensitiveAttributes", func() {
+		Method("A", func() {
+			Payload(User)
+		})
+	})
+}
+
 var StreamingResultMethodDSL = func() {
 	var APayload = Type("APayload", func() {
 		Attribute("IntField", Int)
@@ -583,6 +736,41 @@ var StreamingResultNoPayloadMethodDSL = func() {
 	})
 }
 
+var StreamingResultWithTrailersMethodDSL = func() {
+	Service("StreamingResultWithTrailersService", func() {
+		Method("StreamingResultWithTrailersMethod", func() {
+			StreamingResult(String)
+			GRPC(func() {
+				Response(func() {
+					Trailer("X-Total-Count", Int, "Number of streamed results")
+					Trailer("X-Next", String)
+				})
+			})
+		})
+	})
+}
+
+var BatchMethodDSL = func() {
+	var APayload = Type("APayload", func() {
+		Attribute("IntField", Int)
+		Attribute("StringField", String)
+		Required("StringField")
+	})
+	Service("BatchService", func() {
+		Batch("A", func() {
+			MaxItems(10)
+		})
+		Batch("B")
+		Method("A", func() {
+			Payload(APayload)
+			Result(Int)
+		})
+		Method("B", func() {
+			Payload(String)
+		})
+	})
+}
+
 var StreamingPayloadMethodDSL = func() {
 	var _ = Type("Child", func() {
 		Attribute("p", "Parent")
@@ -967,3 +1155,12 @@ var PkgPathPayloadAttributeDSL = func() {
 		})
 	})
 }
+
+var PkgNameDSL = func() {
+	Service("PkgName", func() {
+		Meta("struct:pkg:name", "svcv2")
+		Method("A", func() {
+			Payload(String)
+		})
+	})
+}
@@ -0,0 +1,227 @@
+package testdata
+
+const SingleMethodTestClient = `// Client is an in-process client of the "SingleMethod" service for use in
+// tests. It calls the service endpoints directly without encoding the requests
+// and responses. The payloads are initialized with their default values and
+// validated before the endpoints are called like the transports do.
+type Client struct {
+	AEndpoint goa.Endpoint
+}
+
+// New returns an in-process client of the "SingleMethod" service implemented
+// by s.
+func New(s singlemethod.Service) *Client {
+	e := singlemethod.NewEndpoints(s)
+	return &Client{
+		AEndpoint: e.A,
+	}
+}
+
+// A calls the "A" endpoint in-process.
+func (c *Client) A(ctx context.Context, p *singlemethod.APayload) (res *singlemethod.AResult, err error) {
+	if p != nil {
+		if err = p.Validate(); err != nil {
+			return
+		}
+	}
+	var ires interface{}
+	ires, err = c.AEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*singlemethod.AResult), nil
+}
+`
+
+const WithDefaultTestClient = `// Client is an in-process client of the "WithDefault" service for use in
+// tests. It calls the service endpoints directly without encoding the requests
+// and responses. The payloads are initialized with their default values and
+// validated before the endpoints are called like the transports do.
+type Client struct {
+	AEndpoint goa.Endpoint
+}
+
+// New returns an in-process client of the "WithDefault" service implemented by
+// s.
+func New(s withdefault.Service) *Client {
+	e := withdefault.NewEndpoints(s)
+	return &Client{
+		AEndpoint: e.A,
+	}
+}
+
+// A calls the "A" endpoint in-process.
+// The payload fields that hold the zero value and have a default value are set
+// to the default value.
+func (c *Client) A(ctx context.Context, p *withdefault.APayload) (res *withdefault.AResult, err error) {
+	if p != nil {
+		v := *p
+		if v.IntField == 0 {
+			v.IntField = 1
+		}
+		if v.StringField == "" {
+			v.StringField = "foo"
+		}
+		p = &v
+	}
+	var ires interface{}
+	ires, err = c.AEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*withdefault.AResult), nil
+}
+`
+
+const ValidatedPayloadsTestClient = `// Client is an in-process client of the "ValidatedPayloads" service for use in
+// tests. It calls the service endpoints directly without encoding the requests
+// and responses. The payloads are initialized with their default values and
+// validated before the endpoints are called like the transports do.
+type Client struct {
+	AEndpoint goa.Endpoint
+	BEndpoint goa.Endpoint
+}
+
+// New returns an in-process client of the "ValidatedPayloads" service
+// implemented by s.
+func New(s validatedpayloads.Service) *Client {
+	e := validatedpayloads.NewEndpoints(s)
+	return &Client{
+		AEndpoint: e.A,
+		BEndpoint: e.B,
+	}
+}
+
+// A calls the "A" endpoint in-process.
+// The payload fields that hold the zero value and have a default value are set
+// to the default value.
+func (c *Client) A(ctx context.Context, p *validatedpayloads.APayload) (err error) {
+	if p != nil {
+		v := *p
+		if v.IntField == 0 {
+			v.IntField = 2
+		}
+		if v.EnumField == "" {
+			v.EnumField = "a"
+		}
+		p = &v
+	}
+	if p != nil {
+		if err = p.Validate(); err != nil {
+			return
+		}
+	}
+	_, err = c.AEndpoint(ctx, p)
+	return
+}
+
+// B calls the "B" endpoint in-process.
+func (c *Client) B(ctx context.Context, p string) (res string, err error) {
+	if utf8.RuneCountInString(p) > 10 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("p", p, utf8.RuneCountInString(p), 10, false))
+	}
+	if err != nil {
+		return
+	}
+	var ires interface{}
+	ires, err = c.BEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(string), nil
+}
+`
+
+const MultipleMethodsResultMultipleViewsTestClient = `// Client is an in-process client of the "MultipleMethodsResultMultipleViews"
+// service for use in tests. It calls the service endpoints directly without
+// encoding the requests and responses. The payloads are initialized with their
+// default values and validated before the endpoints are called like the
+// transports do.
+type Client struct {
+	AEndpoint goa.Endpoint
+	BEndpoint goa.Endpoint
+}
+
+// New returns an in-process client of the "MultipleMethodsResultMultipleViews"
+// service implemented by s.
+func New(s multiplemethodsresultmultipleviews.Service) *Client {
+	e := multiplemethodsresultmultipleviews.NewEndpoints(s)
+	return &Client{
+		AEndpoint: e.A,
+		BEndpoint: e.B,
+	}
+}
+
+// A calls the "A" endpoint in-process.
+func (c *Client) A(ctx context.Context, p *multiplemethodsresultmultipleviews.APayload) (res *multiplemethodsresultmultipleviews.MultipleViews, err error) {
+	if p != nil {
+		if err = p.Validate(); err != nil {
+			return
+		}
+	}
+	var ires interface{}
+	ires, err = c.AEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return multiplemethodsresultmultipleviews.NewMultipleViews(ires.(*multiplemethodsresultmultipleviewsviews.MultipleViews)), nil
+}
+
+// B calls the "B" endpoint in-process.
+func (c *Client) B(ctx context.Context) (res *multiplemethodsresultmultipleviews.SingleView, err error) {
+	var ires interface{}
+	ires, err = c.BEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	return multiplemethodsresultmultipleviews.NewSingleView(ires.(*multiplemethodsresultmultipleviewsviews.SingleView)), nil
+}
+`
+
+const CustomValidationsTestClient = `// Client is an in-process client of the "CustomValidations" service for use in
+// tests. It calls the service endpoints directly without encoding the requests
+// and responses. The payloads are initialized with their default values and
+// validated before the endpoints are called like the transports do.
+type Client struct {
+	AEndpoint goa.Endpoint
+	BEndpoint goa.Endpoint
+	CEndpoint goa.Endpoint
+}
+
+// New returns an in-process client of the "CustomValidations" service
+// implemented by s. It returns an error if cv does not hold all the custom
+// validation functions.
+func New(s customvalidations.Service, cv *customvalidations.CustomValidations) (*Client, error) {
+	e, err := customvalidations.NewEndpoints(s, cv)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		AEndpoint: e.A,
+		BEndpoint: e.B,
+		CEndpoint: e.C,
+	}, nil
+}
+
+// A calls the "A" endpoint in-process.
+func (c *Client) A(ctx context.Context, p *customvalidations.Card) (res string, err error) {
+	var ires interface{}
+	ires, err = c.AEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(string), nil
+}
+
+// B calls the "B" endpoint in-process.
+func (c *Client) B(ctx context.Context, p *customvalidations.BPayload) (err error) {
+	_, err = c.BEndpoint(ctx, p)
+	return
+}
+
+// C calls the "C" endpoint in-process.
+func (c *Client) C(ctx context.Context, p string) (err error) {
+	_, err = c.CEndpoint(ctx, p)
+	return
+}
+`
@@ -792,10 +792,13 @@ func ValidateResult(result *Result) (err error) {
 // ValidateResultView runs the validations defined on ResultView using the
 // "default" view.
 func ValidateResultView(result *ResultView) (err error) {
-	for _, e := range result.T {
-		if !(string(e) == "a" || string(e) == "b") {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("result.t[*]", string(e), []interface{}{"a", "b"}))
-		}
+	for i, e := range result.T {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if !(string(e) == "a" || string(e) == "b") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("result.t[*]", string(e), []interface{}{"a", "b"}))
+			}
+			return
+		}(), "result.t", i))
 	}
 	return
 }
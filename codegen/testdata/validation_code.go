@@ -167,6 +167,57 @@ const (
 		}
 	}
 }
+`
+
+	NumberRequiredValidationCode = `func Validate() (err error) {
+	if nv, nerr := target.RequiredNumber.Float64(); nerr != nil {
+		err = goa.MergeErrors(err, goa.InvalidFieldTypeError("target.required_number", target.RequiredNumber.String(), "number"))
+	} else {
+		if nv < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.required_number", nv, 1, true))
+		}
+	}
+	if target.Number != nil {
+		if nv, nerr := target.Number.Float64(); nerr != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError("target.number", target.Number.String(), "number"))
+		} else {
+			if !(nv == 1.5 || nv == 10) {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.number", nv, []interface{}{1.5, 10}))
+			}
+			if nv >= 100 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("target.number", nv, 100, false))
+			}
+		}
+	}
+}
+`
+
+	NumberPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredNumber == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_number", "target"))
+	}
+	if target.RequiredNumber != nil {
+		if nv, nerr := target.RequiredNumber.Float64(); nerr != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError("target.required_number", target.RequiredNumber.String(), "number"))
+		} else {
+			if nv < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("target.required_number", nv, 1, true))
+			}
+		}
+	}
+	if target.Number != nil {
+		if nv, nerr := target.Number.Float64(); nerr != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError("target.number", target.Number.String(), "number"))
+		} else {
+			if !(nv == 1.5 || nv == 10) {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.number", nv, []interface{}{1.5, 10}))
+			}
+			if nv >= 100 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("target.number", nv, 100, false))
+			}
+		}
+	}
+}
 `
 
 	StringRequiredValidationCode = `func Validate() (err error) {
@@ -231,6 +282,167 @@ const (
 		err = goa.MergeErrors(err, goa.ValidateFormat("target.string", *target.String, goa.FormatDateTime))
 	}
 }
+`
+
+	CustomRequiredValidationCode = `func Validate() (err error) {
+	if utf8.RuneCountInString(target.RequiredCard) < 12 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError("target.required_card", target.RequiredCard, utf8.RuneCountInString(target.RequiredCard), 12, true))
+	}
+	if target.Code != nil {
+		if *target.Code < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.code", *target.Code, 1, true))
+		}
+	}
+}
+`
+
+	CustomPointerValidationCode = `func Validate() (err error) {
+	if target.RequiredCard == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("required_card", "target"))
+	}
+	if target.RequiredCard != nil {
+		if utf8.RuneCountInString(*target.RequiredCard) < 12 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.required_card", *target.RequiredCard, utf8.RuneCountInString(*target.RequiredCard), 12, true))
+		}
+	}
+	if target.Code != nil {
+		if *target.Code < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.code", *target.Code, 1, true))
+		}
+	}
+}
+`
+
+	CustomFormatPointerValidationCode = `func Validate() (err error) {
+	if target.Currency != nil {
+		err = goa.MergeErrors(err, goa.ValidatePattern("target.currency", *target.Currency, "^[A-Z]{3}$"))
+	}
+}
+`
+
+	I18nKeysPointerValidationCode = `func Validate() (err error) {
+	if target.Name == nil {
+		err = goa.MergeErrors(err, goa.WithI18nKey(goa.MissingFieldError("name", "target"), "user.name"))
+	}
+	if target.Name != nil {
+		if utf8.RuneCountInString(*target.Name) < 2 {
+			err = goa.MergeErrors(err, goa.WithI18nKey(goa.InvalidLengthError("target.name", *target.Name, utf8.RuneCountInString(*target.Name), 2, true), "user.name"))
+		}
+	}
+	if target.Email != nil {
+		err = goa.MergeErrors(err, goa.WithI18nKey(goa.ValidateFormat("target.email", *target.Email, goa.FormatEmail), "user.email"))
+	}
+	if target.Age != nil {
+		if *target.Age < 18 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.age", *target.Age, 18, true))
+		}
+	}
+}
+`
+
+	NullablePointerValidationCode = `func Validate() (err error) {
+	if target.Nickname.HasValue() {
+		if utf8.RuneCountInString(target.Nickname.Value) < 2 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("target.nickname", target.Nickname.Value, utf8.RuneCountInString(target.Nickname.Value), 2, true))
+		}
+	}
+	if target.Age.HasValue() {
+		if target.Age.Value < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.age", target.Age.Value, 0, true))
+		}
+	}
+}
+`
+
+	EqualFieldsRequiredValidationCode = `func Validate() (err error) {
+	if target.PasswordConfirm == nil || target.Password != *target.PasswordConfirm {
+		err = goa.MergeErrors(err, goa.InvalidEqualFieldsError("target.password_confirm", "target.password"))
+	}
+	if (target.Pin == nil) != (target.PinConfirm == nil) || target.Pin != nil && *target.Pin != *target.PinConfirm {
+		err = goa.MergeErrors(err, goa.InvalidEqualFieldsError("target.pin_confirm", "target.pin"))
+	}
+}
+`
+
+	EqualFieldsPointerValidationCode = `func Validate() (err error) {
+	if target.Password == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("password", "target"))
+	}
+	if (target.Password == nil) != (target.PasswordConfirm == nil) || target.Password != nil && *target.Password != *target.PasswordConfirm {
+		err = goa.MergeErrors(err, goa.InvalidEqualFieldsError("target.password_confirm", "target.password"))
+	}
+	if (target.Pin == nil) != (target.PinConfirm == nil) || target.Pin != nil && *target.Pin != *target.PinConfirm {
+		err = goa.MergeErrors(err, goa.InvalidEqualFieldsError("target.pin_confirm", "target.pin"))
+	}
+}
+`
+
+	FieldGroupsRequiredValidationCode = `func Validate() (err error) {
+	{
+		var set []string
+		if target.ID != nil {
+			set = append(set, "target.id")
+		}
+		if target.Email != nil {
+			set = append(set, "target.email")
+		}
+		if target.Name != nil {
+			set = append(set, "target.name")
+		}
+		if len(set) > 1 {
+			err = goa.MergeErrors(err, goa.InvalidMutuallyExclusiveError([]string{"target.id", "target.email", "target.name"}, set))
+		}
+	}
+	{
+		var set []string
+		if target.Name != nil {
+			set = append(set, "target.name")
+		}
+		if target.Tags != nil {
+			set = append(set, "target.tags")
+		}
+		if len(set) > 1 {
+			err = goa.MergeErrors(err, goa.InvalidMutuallyExclusiveError([]string{"target.name", "target.tags"}, set))
+		}
+	}
+	if target.ID == nil && target.Email == nil && target.Name == nil {
+		err = goa.MergeErrors(err, goa.MissingRequiredOneOfError([]string{"target.id", "target.email", "target.name"}))
+	}
+}
+`
+
+	FieldGroupsPointerValidationCode = `func Validate() (err error) {
+	{
+		var set []string
+		if target.ID != nil {
+			set = append(set, "target.id")
+		}
+		if target.Email != nil {
+			set = append(set, "target.email")
+		}
+		if target.Name != nil {
+			set = append(set, "target.name")
+		}
+		if len(set) > 1 {
+			err = goa.MergeErrors(err, goa.InvalidMutuallyExclusiveError([]string{"target.id", "target.email", "target.name"}, set))
+		}
+	}
+	{
+		var set []string
+		if target.Name != nil {
+			set = append(set, "target.name")
+		}
+		if target.Tags != nil {
+			set = append(set, "target.tags")
+		}
+		if len(set) > 1 {
+			err = goa.MergeErrors(err, goa.InvalidMutuallyExclusiveError([]string{"target.name", "target.tags"}, set))
+		}
+	}
+	if target.ID == nil && target.Email == nil && target.Name == nil {
+		err = goa.MergeErrors(err, goa.MissingRequiredOneOfError([]string{"target.id", "target.email", "target.name"}))
+	}
+}
 `
 
 	AliasTypeValidationCode = `func Validate() (err error) {
@@ -263,17 +475,17 @@ const (
 	}
 	if target.RequiredInteger != nil {
 		if err2 := ValidateInteger(target.RequiredInteger); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.required_integer"))
 		}
 	}
 	if target.DefaultString != nil {
 		if err2 := ValidateString(target.DefaultString); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.default_string"))
 		}
 	}
 	if target.Float != nil {
 		if err2 := ValidateFloat(target.Float); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.float"))
 		}
 	}
 }
@@ -285,17 +497,17 @@ const (
 	}
 	if target.RequiredInteger != nil {
 		if err2 := ValidateInteger(target.RequiredInteger); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.required_integer"))
 		}
 	}
 	if target.DefaultString != nil {
 		if err2 := ValidateString(target.DefaultString); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.default_string"))
 		}
 	}
 	if target.Float != nil {
 		if err2 := ValidateFloat(target.Float); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.float"))
 		}
 	}
 }
@@ -306,29 +518,32 @@ const (
 	}
 	if target.RequiredInteger != nil {
 		if err2 := ValidateInteger(target.RequiredInteger); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.required_integer"))
 		}
 	}
 	if target.DefaultString != nil {
 		if err2 := ValidateString(target.DefaultString); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.default_string"))
 		}
 	}
 	if target.Float != nil {
 		if err2 := ValidateFloat(target.Float); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.float"))
 		}
 	}
 }
 `
 
 	UserTypeArrayValidationCode = `func Validate() (err error) {
-	for _, e := range target.Array {
-		if e != nil {
-			if err2 := ValidateFloat(e); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+	for i, e := range target.Array {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if e != nil {
+				if err2 := ValidateFloat(e); err2 != nil {
+					err = goa.MergeErrors(err, goa.NestErrors(err2, "target.array[*]"))
+				}
 			}
-		}
+			return
+		}(), "target.array", i))
 	}
 }
 `
@@ -343,10 +558,13 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
-		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
-		}
+	for i, e := range target.Array {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			}
+			return
+		}(), "target.array", i))
 	}
 }
 `
@@ -361,10 +579,13 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
-		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
-		}
+	for i, e := range target.Array {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			}
+			return
+		}(), "target.array", i))
 	}
 }
 `
@@ -379,10 +600,13 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
-	for _, e := range target.Array {
-		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
-		}
+	for i, e := range target.Array {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
+			}
+			return
+		}(), "target.array", i))
 	}
 }
 `
@@ -399,9 +623,12 @@ const (
 	}
 	for k, v := range target.Map {
 		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
-		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
-		}
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if v > 5 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			}
+			return
+		}(), "target.map", k))
 	}
 }
 `
@@ -418,9 +645,12 @@ const (
 	}
 	for k, v := range target.Map {
 		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
-		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
-		}
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if v > 5 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			}
+			return
+		}(), "target.map", k))
 	}
 }
 `
@@ -437,9 +667,12 @@ const (
 	}
 	for k, v := range target.Map {
 		err = goa.MergeErrors(err, goa.ValidatePattern("target.map.key", k, "^[A-Z]"))
-		if v > 5 {
-			err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
-		}
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if v > 5 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("target.map[key]", v, 5, false))
+			}
+			return
+		}(), "target.map", k))
 	}
 }
 `
@@ -451,21 +684,21 @@ const (
 	case *Union_Int:
 		if v.Int != nil {
 			if err2 := ValidateInteger(v.Int); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestErrors(err2, "target.required_union.value"))
 			}
 		}
 
 	case *Union_Float:
 		if v.Float != nil {
 			if err2 := ValidateFloat(v.Float); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestErrors(err2, "target.required_union.value"))
 			}
 		}
 
 	case *Union_String:
 		if v.String != nil {
 			if err2 := ValidateString(v.String); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestErrors(err2, "target.required_union.value"))
 			}
 		}
 	}
@@ -473,21 +706,21 @@ const (
 	case *Union_Int:
 		if v.Int != nil {
 			if err2 := ValidateInteger(v.Int); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestErrors(err2, "target.union.value"))
 			}
 		}
 
 	case *Union_Float:
 		if v.Float != nil {
 			if err2 := ValidateFloat(v.Float); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestErrors(err2, "target.union.value"))
 			}
 		}
 
 	case *Union_String:
 		if v.String != nil {
 			if err2 := ValidateString(v.String); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestErrors(err2, "target.union.value"))
 			}
 		}
 	}
@@ -504,12 +737,15 @@ const (
 `
 
 	ResultCollectionPointerValidationCode = `func Validate() (err error) {
-	for _, e := range target {
-		if e != nil {
-			if err2 := ValidateResult(e); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+	for i, e := range target {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if e != nil {
+				if err2 := ValidateResult(e); err2 != nil {
+					err = goa.MergeErrors(err, goa.NestErrors(err2, "target[*]"))
+				}
 			}
-		}
+			return
+		}(), "target", i))
 	}
 }
 `
@@ -517,7 +753,7 @@ const (
 	TypeWithCollectionPointerValidationCode = `func Validate() (err error) {
 	if target.Collection != nil {
 		if err2 := ValidateResultCollection(target.Collection); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "target.collection"))
 		}
 	}
 }
@@ -527,7 +763,7 @@ const (
 	if target.Deep != nil {
 		if target.Deep.Integer != nil {
 			if err2 := ValidateInteger(target.Deep.Integer); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+				err = goa.MergeErrors(err, goa.NestErrors(err2, "target.deep.integer"))
 			}
 		}
 	}
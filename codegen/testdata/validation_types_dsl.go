@@ -40,6 +40,19 @@ var ValidationTypesDSL = func() {
 			Required("required_float")
 		})
 
+		_ = Type("Number", func() {
+			Attribute("required_number", Float64, func() {
+				Meta("struct:field:type", "json.Number", "encoding/json")
+				Minimum(1)
+			})
+			Attribute("number", Float64, func() {
+				Meta("struct:field:type", "json.Number", "encoding/json")
+				Enum(1.5, 10)
+				ExclusiveMaximum(100)
+			})
+			Required("required_number")
+		})
+
 		StringT = Type("String", func() {
 			Attribute("required_string", String, func() {
 				MinLength(1)
@@ -56,6 +69,75 @@ var ValidationTypesDSL = func() {
 			Required("required_string")
 		})
 
+		_ = Type("Custom", func() {
+			Attribute("required_card", String, func() {
+				MinLength(12)
+				CustomValidation("validateCard")
+			})
+			Attribute("code", Int, func() {
+				Minimum(1)
+				CustomValidation("validateCode")
+				CustomValidation("validateChecksum")
+			})
+			Required("required_card")
+		})
+
+		_ = Type("EqualFields", func() {
+			Attribute("password", String)
+			Attribute("password_confirm", String)
+			Attribute("pin", Int)
+			Attribute("pin_confirm", Int)
+			EqualFields("password", "password_confirm")
+			EqualFields("pin", "pin_confirm")
+			Required("password")
+		})
+
+		_ = Type("FieldGroups", func() {
+			Attribute("id", String)
+			Attribute("email", String)
+			Attribute("name", String)
+			Attribute("tags", ArrayOf(String))
+			MutuallyExclusive("id", "email", "name")
+			MutuallyExclusive("name", "tags")
+			RequiredOneOf("id", "email", "name")
+		})
+
+		_ = Type("CustomFormat", func() {
+			Attribute("currency", String, func() {
+				CustomFormat("iso-4217-currency")
+				Pattern("^[A-Z]{3}$")
+			})
+			Attribute("country", String, func() {
+				CustomFormat("iso-3166-country")
+			})
+		})
+
+		_ = Type("I18nKeys", func() {
+			Attribute("name", String, func() {
+				MinLength(2)
+				Meta("i18n:key", "user.name")
+			})
+			Attribute("email", String, func() {
+				Format(FormatEmail)
+				Meta("i18n:key", "user.email")
+			})
+			Attribute("age", Int, func() {
+				Minimum(18)
+			})
+			Required("name")
+		})
+
+		_ = Type("Nullable", func() {
+			Attribute("nickname", String, func() {
+				Nullable()
+				MinLength(2)
+			})
+			Attribute("age", Int, func() {
+				Nullable()
+				Minimum(0)
+			})
+		})
+
 		Alias = Type("Alias", String, func() {
 			MinLength(1)
 			MaxLength(10)
@@ -46,6 +46,10 @@ type (
 		// IsInterface is true if the attribute is an interface (union type).
 		// In this case assigning child attributes requires a type assertion.
 		IsInterface bool
+		// ValidateMethod if true indicates that the user types define a
+		// Validate method used to validate their values instead of a
+		// Validate<TypeName> function.
+		ValidateMethod bool
 	}
 
 	// AttributeScope contains the scope of an attribute. It implements the
@@ -85,6 +89,9 @@ type (
 		ParamTypeRef  string
 		ResultTypeRef string
 		Code          string
+		// ReturnsError is true if the function returns an error in
+		// addition to the result.
+		ReturnsError bool
 	}
 )
 
@@ -205,7 +212,7 @@ func mapDepth(dt expr.DataType, depth int, seen ...map[string]struct{}) int {
 // IsPrimitivePointer returns true if the attribute with the given name is a
 // primitive pointer in the given parent attribute.
 func (a *AttributeContext) IsPrimitivePointer(name string, att *expr.AttributeExpr) bool {
-	if at := att.Find(name); at != nil && (at.Type == expr.Any || at.Type == expr.Bytes) {
+	if at := att.Find(name); at != nil && (at.Type == expr.Any || at.Type == expr.Bytes || at.IsNullable()) {
 		return false
 	}
 	if a.Pointer {
@@ -230,6 +237,7 @@ func (a *AttributeContext) Dup() *AttributeContext {
 		UseDefault:     a.UseDefault,
 		Scope:          a.Scope,
 		DefaultPkg:     a.DefaultPkg,
+		ValidateMethod: a.ValidateMethod,
 	}
 }
 
@@ -43,6 +43,33 @@ func GoNativeTypeName(t expr.DataType) string {
 
 // AttributeTags computes the struct field tags from its metadata if any.
 func AttributeTags(parent, att *expr.AttributeExpr) string {
+	return formatTags(attributeTagElems(att))
+}
+
+// fieldTags computes the tags of the struct field generated for the attribute
+// with the given name of parent. It adds a db tag to the tags computed by
+// AttributeTags if parent defines the "struct:tag:db:policy" meta and a json
+// tag if the API defines the "struct:tag:json:omitempty" meta.
+func fieldTags(parent, att *expr.AttributeExpr, name string) string {
+	elems := attributeTagElems(att)
+	var added bool
+	if tag := dbTag(parent, att, name); tag != "" {
+		elems = append(elems, tag)
+		added = true
+	}
+	if tag := jsonTag(parent, att, name); tag != "" {
+		elems = append(elems, tag)
+		added = true
+	}
+	if added {
+		sort.Strings(elems)
+	}
+	return formatTags(elems)
+}
+
+// attributeTagElems returns the struct field tags defined in the attribute
+// metadata sorted by name.
+func attributeTagElems(att *expr.AttributeExpr) []string {
 	var elems []string
 	keys := make([]string, len(att.Meta))
 	i := 0
@@ -53,12 +80,65 @@ func AttributeTags(parent, att *expr.AttributeExpr) string {
 	sort.Strings(keys)
 	for _, key := range keys {
 		val := att.Meta[key]
-		if strings.HasPrefix(key, "struct:tag:") {
+		if strings.HasPrefix(key, "struct:tag:") && key != "struct:tag:db:policy" {
 			name := key[11:]
 			value := strings.Join(val, ",")
 			elems = append(elems, fmt.Sprintf("%s:\"%s\"", name, value))
 		}
 	}
+	return elems
+}
+
+// dbTag returns the db tag computed using the "struct:tag:db:policy" meta of
+// parent for the attribute with the given name. It returns an empty string if
+// parent does not define the meta or if the attribute defines a db tag
+// explicitly. Attributes that define the "db:skip" meta are ignored by the
+// database mapping.
+func dbTag(parent, att *expr.AttributeExpr, name string) string {
+	policy, ok := parent.Meta.Last("struct:tag:db:policy")
+	if !ok {
+		return ""
+	}
+	if _, ok := att.Meta["struct:tag:db"]; ok {
+		return ""
+	}
+	if _, ok := att.Meta["db:skip"]; ok {
+		return `db:"-"`
+	}
+	switch policy {
+	case "snake":
+		return fmt.Sprintf("db:%q", SnakeCase(name))
+	default:
+		return ""
+	}
+}
+
+// jsonTag returns the json tag with the omitempty option computed using the
+// "struct:tag:json:omitempty" meta of the API for the attribute with the given
+// name. It returns an empty string if the API does not define the meta, if the
+// attribute is required or has a default value (and thus is never nil), if the
+// attribute defines a json tag explicitly or if the attribute is nullable.
+// The goa.Nullable fields of nullable attributes are structs which
+// encoding/json omits only with the omitzero option, that option requires Go
+// 1.24 and is thus not used.
+func jsonTag(parent, att *expr.AttributeExpr, name string) string {
+	if expr.Root == nil || expr.Root.API == nil {
+		return ""
+	}
+	if policy, ok := expr.Root.API.Meta.Last("struct:tag:json:omitempty"); !ok || policy != "optional" {
+		return ""
+	}
+	if _, ok := att.Meta["struct:tag:json"]; ok {
+		return ""
+	}
+	if parent.IsRequired(name) || parent.HasDefaultValue(name) || att.IsNullable() {
+		return ""
+	}
+	return fmt.Sprintf("json:\"%s,omitempty\"", name)
+}
+
+// formatTags returns the struct field tags declaration for the given tags.
+func formatTags(elems []string) string {
 	if len(elems) > 0 {
 		return " `" + strings.Join(elems, " ") + "`"
 	}
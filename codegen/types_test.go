@@ -47,6 +47,15 @@ func TestGoTypeDef(t *testing.T) {
 				{"StructPkgPath", &expr.AttributeExpr{Type: utPkgPathMeta}},
 			},
 			Validation: &expr.ValidationExpr{Required: []string{"IntField", "ArrayField", "MapField", "UserTypeField", "MetaTypeField", "QualifiedMetaTypeField"}}}
+		dbPolicyObj = &expr.AttributeExpr{
+			Type: &expr.Object{
+				{Name: "firstName", Attribute: &expr.AttributeExpr{Type: expr.String}},
+				{Name: "last_name", Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:tag:json": []string{"last"}}}},
+				{Name: "id", Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:tag:db": []string{"user_id"}}}},
+				{Name: "password", Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"db:skip": nil}}},
+			},
+			Validation: &expr.ValidationExpr{Required: []string{"firstName", "last_name", "id", "password"}},
+			Meta:       expr.MetaExpr{"struct:tag:db:policy": []string{"snake"}}}
 	)
 	cases := map[string]struct {
 		att        *expr.AttributeExpr
@@ -78,6 +87,8 @@ func TestGoTypeDef(t *testing.T) {
 		"ObjMixed":        {mixedObj, false, true, "struct {\n\tIntField int\n\tArrayField []bool\n\tMapField map[int]string\n\tUserTypeField UserType\n\tMetaTypeField json.RawMessage\n\tQualifiedMetaTypeField jason.RawMessage\n\tStructPkgPath *types.UserType\n}"},
 		"ObjMixedPointer": {mixedObj, true, true, "struct {\n\tIntField *int\n\tArrayField []bool\n\tMapField map[int]string\n\tUserTypeField *UserType\n\tMetaTypeField *json.RawMessage\n\tQualifiedMetaTypeField *jason.RawMessage\n\tStructPkgPath *types.UserType\n}"},
 
+		"ObjDBPolicy": {dbPolicyObj, false, true, "struct {\n\tFirstName string `db:\"first_name\"`\n\tLastName string `db:\"last_name\" json:\"last\"`\n\tID string `db:\"user_id\"`\n\tPassword string `db:\"-\"`\n}"},
+
 		"MetaTypeSameAsDesign":                      {&expr.AttributeExpr{Type: expr.String, Meta: stringMetaType}, false, true, "string"},
 		"MetaTypeOverrideDesign":                    {&expr.AttributeExpr{Type: expr.String, Meta: jsonWithImportMetaType}, false, true, "json.RawMessage"},
 		"MetaTypeOverrideDesignWithQualifiedImport": {&expr.AttributeExpr{Type: expr.String, Meta: jsonWithRenameMetaType}, false, true, "jason.RawMessage"},
@@ -92,6 +103,31 @@ func TestGoTypeDef(t *testing.T) {
 	}
 }
 
+func TestGoTypeDefJSONOmitEmpty(t *testing.T) {
+	var (
+		obj = &expr.AttributeExpr{
+			Type: &expr.Object{
+				{Name: "required", Attribute: &expr.AttributeExpr{Type: expr.String}},
+				{Name: "optional", Attribute: &expr.AttributeExpr{Type: expr.String}},
+				{Name: "default", Attribute: &expr.AttributeExpr{Type: expr.Int, DefaultValue: 1}},
+				{Name: "override", Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"struct:tag:json": []string{"o"}}}},
+				{Name: "list", Attribute: &expr.AttributeExpr{Type: &expr.Array{ElemType: &expr.AttributeExpr{Type: expr.String}}}},
+				{Name: "nullable", Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"nullable": nil}}},
+			},
+			Validation: &expr.ValidationExpr{Required: []string{"required"}},
+		}
+		expected = "struct {\n\tRequired string\n\tOptional *string `json:\"optional,omitempty\"`\n\tDefault int\n\tOverride *string `json:\"o\"`\n\tList []string `json:\"list,omitempty\"`\n\tNullable goa.Nullable[string]\n}"
+	)
+	api := expr.Root.API
+	defer func() { expr.Root.API = api }()
+	expr.Root.API = &expr.APIExpr{Name: "test", Meta: expr.MetaExpr{"struct:tag:json:omitempty": []string{"optional"}}}
+
+	actual := NewNameScope().GoTypeDef(obj, false, true)
+	if actual != expected {
+		t.Errorf("got %#v, expected %#v", actual, expected)
+	}
+}
+
 func TestGoNativeTypeName(t *testing.T) {
 	cases := map[string]struct {
 		dataType expr.DataType
@@ -22,6 +22,10 @@ var (
 	mapValT        *template.Template
 	unionValT      *template.Template
 	userValT       *template.Template
+	numberValT     *template.Template
+	equalValT      *template.Template
+	exclusiveValT  *template.Template
+	oneOfValT      *template.Template
 )
 
 func init() {
@@ -30,6 +34,7 @@ func init() {
 		"oneof":    oneof,
 		"constant": constant,
 		"add":      func(a, b int) int { return a + b },
+		"i18nKey":  i18nKey,
 	}
 	enumValT = template.Must(template.New("enum").Funcs(fm).Parse(enumValTmpl))
 	formatValT = template.Must(template.New("format").Funcs(fm).Parse(formatValTmpl))
@@ -42,6 +47,10 @@ func init() {
 	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
 	unionValT = template.Must(template.New("union").Funcs(fm).Parse(unionValTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
+	numberValT = template.Must(template.New("number").Funcs(fm).Parse(numberValTmpl))
+	equalValT = template.Must(template.New("equal").Funcs(fm).Parse(equalValTmpl))
+	exclusiveValT = template.Must(template.New("exclusive").Funcs(fm).Parse(exclusiveValTmpl))
+	oneOfValT = template.Must(template.New("oneOf").Funcs(fm).Parse(oneOfValTmpl))
 }
 
 // ValidationCode produces Go code that runs the validations defined in the
@@ -65,6 +74,14 @@ func ValidationCode(att *expr.AttributeExpr, put expr.UserType, attCtx *Attribut
 	return recurseValidationCode(att, put, attCtx, req, alias, target, target, seen).String()
 }
 
+// ValidationCodeWithContext is like ValidationCode but uses context instead of
+// the target variable name to describe the validated value in the validation
+// error messages.
+func ValidationCodeWithContext(att *expr.AttributeExpr, put expr.UserType, attCtx *AttributeContext, req, alias bool, target, context string) string {
+	seen := make(map[string]*bytes.Buffer)
+	return recurseValidationCode(att, put, attCtx, req, alias, target, context, seen).String()
+}
+
 func recurseValidationCode(att *expr.AttributeExpr, put expr.UserType, attCtx *AttributeContext, req, alias bool, target, context string, seen map[string]*bytes.Buffer) *bytes.Buffer {
 	var (
 		buf      = new(bytes.Buffer)
@@ -123,7 +140,7 @@ func recurseValidationCode(att *expr.AttributeExpr, put expr.UserType, attCtx *A
 		val := validateAttribute(ctx, elem, put, "e", context+"[*]", true)
 		if val != "" {
 			newline()
-			data := map[string]interface{}{"target": target, "validation": val}
+			data := map[string]interface{}{"target": target, "context": context, "validation": val}
 			if err := arrayValT.Execute(buf, data); err != nil {
 				panic(err) // bug
 			}
@@ -142,7 +159,7 @@ func recurseValidationCode(att *expr.AttributeExpr, put expr.UserType, attCtx *A
 		}
 		if keyVal != "" || valueVal != "" {
 			newline()
-			data := map[string]interface{}{"target": target, "keyValidation": keyVal, "valueValidation": valueVal}
+			data := map[string]interface{}{"target": target, "context": context, "keyValidation": keyVal, "valueValidation": valueVal}
 			if err := mapValT.Execute(buf, data); err != nil {
 				panic(err) // bug
 			}
@@ -194,6 +211,9 @@ func validateAttribute(ctx *AttributeContext, att *expr.AttributeExpr, put expr.
 		if expr.IsArray(att.Type) || expr.IsMap(att.Type) || expr.IsUnion(att.Type) {
 			return code
 		}
+		if att.IsNullable() {
+			return fmt.Sprintf("if %s.HasValue() {\n%s\n}", target, code)
+		}
 		if !ctx.Pointer && (req || (att.DefaultValue != nil && ctx.UseDefault)) {
 			return code
 		}
@@ -206,12 +226,12 @@ func validateAttribute(ctx *AttributeContext, att *expr.AttributeExpr, put expr.
 	if expr.IsAlias(ut) {
 		return recurseValidationCode(ut.Attribute(), put, ctx, req, true, target, context, nil).String()
 	}
-	if !hasValidations(ctx, ut) {
+	if ctx.ValidateMethod && !HasValidateMethod(ctx, ut) || !hasValidations(ctx, ut) {
 		return ""
 	}
 	var buf bytes.Buffer
 	name := ctx.Scope.Name(att, "", ctx.Pointer, ctx.UseDefault)
-	data := map[string]interface{}{"name": Goify(name, true), "target": target}
+	data := map[string]interface{}{"name": Goify(name, true), "target": target, "context": context, "method": ctx.ValidateMethod}
 	if err := userValT.Execute(&buf, data); err != nil {
 		panic(err) // bug
 	}
@@ -255,7 +275,11 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 		isPointer       = attCtx.Pointer || (!req && (att.DefaultValue == nil || !attCtx.UseDefault))
 		tval            = target
 	)
-	if isPointer && expr.IsPrimitive(att.Type) && !isNativePointer {
+	if att.IsNullable() {
+		// Nullable attributes are validated only when they hold a value.
+		isPointer = false
+		tval += ".Value"
+	} else if isPointer && expr.IsPrimitive(att.Type) && !isNativePointer {
 		tval = "*" + tval
 	}
 	if alias {
@@ -280,13 +304,32 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 		return buf.String()
 	}
 	var res []string
+	if typeName, _ := GetMetaType(att); typeName == "json.Number" {
+		// Numbers decoded as json.Number are stored as strings, parse them
+		// so that the enum and range validations can still apply.
+		if val := numberValidationCode(validation, data, runTemplate); val != "" {
+			res = append(res, val)
+		}
+		validation = validation.Dup()
+		validation.Values = nil
+		validation.ExclusiveMinimum = nil
+		validation.Minimum = nil
+		validation.ExclusiveMaximum = nil
+		validation.Maximum = nil
+	} else if typeName == "goa.Duration" {
+		// Durations are parsed when decoded, there is no string left to
+		// validate.
+		validation = validation.Dup()
+		validation.Format = ""
+	}
 	if values := validation.Values; values != nil {
 		data["values"] = values
 		if val := runTemplate(enumValT, data); val != "" {
 			res = append(res, val)
 		}
 	}
-	if format := validation.Format; format != "" {
+	if format := validation.Format; format.IsSupported() {
+		// Custom formats are only used for documentation.
 		data["format"] = string(format)
 		if val := runTemplate(formatValT, data); val != "" {
 			res = append(res, val)
@@ -350,9 +393,157 @@ func validationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 		data["reqAtt"] = reqAtt
 		res = append(res, runTemplate(requiredValT, data))
 	}
+	for _, f := range validation.EqualFields {
+		if obj == nil {
+			continue
+		}
+		fatt, oatt := obj.Attribute(f[0]), obj.Attribute(f[1])
+		if fatt == nil || oatt == nil {
+			continue
+		}
+		isFieldPointer := func(n string, a *expr.AttributeExpr) bool {
+			return attCtx.Pointer || (!att.IsRequired(n) && (a.DefaultValue == nil || !attCtx.UseDefault))
+		}
+		data["field"] = f[0]
+		data["fieldRef"] = target + "." + attCtx.Scope.Field(fatt, f[0], true)
+		data["fieldPointer"] = isFieldPointer(f[0], fatt)
+		data["other"] = f[1]
+		data["otherRef"] = target + "." + attCtx.Scope.Field(oatt, f[1], true)
+		data["otherPointer"] = isFieldPointer(f[1], oatt)
+		res = append(res, runTemplate(equalValT, data))
+	}
+	for _, g := range validation.MutuallyExclusive {
+		if fields := groupFields(obj, g, attCtx, target, context); fields != nil {
+			data["fields"] = fields
+			res = append(res, runTemplate(exclusiveValT, data))
+		}
+	}
+	for _, g := range validation.RequiredOneOf {
+		if fields := groupFields(obj, g, attCtx, target, context); fields != nil {
+			data["fields"] = fields
+			res = append(res, runTemplate(oneOfValT, data))
+		}
+	}
 	return strings.Join(res, "\n")
 }
 
+// groupFields returns the references and names of the fields of a
+// MutuallyExclusive or RequiredOneOf group. It returns nil if the generated
+// code cannot tell whether one of the fields is set: protocol buffer scalar
+// fields do not track presence.
+func groupFields(obj *expr.Object, group []string, attCtx *AttributeContext, target, context string) []map[string]string {
+	if obj == nil {
+		return nil
+	}
+	fields := make([]map[string]string, len(group))
+	for i, n := range group {
+		att := obj.Attribute(n)
+		if att == nil {
+			return nil
+		}
+		if attCtx.IgnoreRequired && expr.IsPrimitive(att.Type) &&
+			att.Type.Kind() != expr.BytesKind && att.Type.Kind() != expr.AnyKind {
+			return nil
+		}
+		fields[i] = map[string]string{
+			"ref":  target + "." + attCtx.Scope.Field(att, n, true),
+			"name": context + "." + n,
+		}
+	}
+	return fields
+}
+
+// numberValidationCode produces the enum and range validation code for
+// attributes whose Go type is overridden to json.Number via the
+// "struct:field:type" meta. The generated code parses the number and runs the
+// validations against the resulting float64 value.
+func numberValidationCode(validation *expr.ValidationExpr, data map[string]interface{}, runTemplate func(*template.Template, interface{}) string) string {
+	ndata := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		ndata[k] = v
+	}
+	ndata["isPointer"] = false
+	// Use a name that the enclosing code cannot use (e.g. the receiver "v"
+	// of the generated Validate methods) for the parsed value.
+	ndata["targetVal"] = "nv"
+	var res []string
+	if values := validation.Values; values != nil {
+		vals := make([]interface{}, len(values))
+		for i, v := range values {
+			vals[i] = toFloat64(v)
+		}
+		ndata["values"] = vals
+		res = append(res, runTemplate(enumValT, ndata))
+	}
+	if exclMin := validation.ExclusiveMinimum; exclMin != nil {
+		ndata["exclMin"] = *exclMin
+		ndata["isExclMin"] = true
+		res = append(res, runTemplate(exclMinMaxValT, ndata))
+	}
+	if min := validation.Minimum; min != nil {
+		ndata["min"] = *min
+		ndata["isMin"] = true
+		res = append(res, runTemplate(minMaxValT, ndata))
+	}
+	if exclMax := validation.ExclusiveMaximum; exclMax != nil {
+		ndata["exclMax"] = *exclMax
+		ndata["isExclMin"] = false
+		ndata["isExclMax"] = true
+		res = append(res, runTemplate(exclMinMaxValT, ndata))
+	}
+	if max := validation.Maximum; max != nil {
+		ndata["max"] = *max
+		ndata["isMin"] = false
+		res = append(res, runTemplate(minMaxValT, ndata))
+	}
+	if len(res) == 0 {
+		return ""
+	}
+	data["validation"] = strings.Join(res, "\n")
+	return runTemplate(numberValT, data)
+}
+
+// toFloat64 converts numerical enum values to float64 so that they can be
+// compared with parsed json.Number values.
+func toFloat64(v interface{}) interface{} {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint:
+		return float64(n)
+	case uint32:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	case float32:
+		return float64(n)
+	}
+	return v
+}
+
+// HasValidateMethod returns true if the Go type generated for the given user
+// type defines a Validate method when the user types are validated with
+// methods (see AttributeContext.ValidateMethod). Only objects, arrays and maps
+// that define validations and no field named Validate define the method.
+func HasValidateMethod(attCtx *AttributeContext, ut expr.UserType) bool {
+	if expr.IsAlias(ut) || expr.IsUnion(ut) || !hasValidations(attCtx, ut) {
+		return false
+	}
+	if obj := expr.AsObject(ut); obj != nil {
+		for _, nat := range *obj {
+			if attCtx.Scope.Field(nat.Attribute, nat.Name, true) == "Validate" {
+				return false
+			}
+		}
+		return true
+	}
+	return expr.IsArray(ut) || expr.IsMap(ut)
+}
+
 // hasValidations returns true if a UserType contains validations.
 func hasValidations(attCtx *AttributeContext, ut expr.UserType) bool {
 	// We need to check empirically whether there are validations to be
@@ -361,7 +552,8 @@ func hasValidations(attCtx *AttributeContext, ut expr.UserType) bool {
 	res := false
 	done := errors.New("done")
 	Walk(ut.Attribute(), func(a *expr.AttributeExpr) error {
-		if a.Validation == nil {
+		if a.Validation == nil || a.IsDuration() {
+			// The format of durations is validated when parsed.
 			return nil
 		}
 		if attCtx.Pointer || !a.Validation.HasRequiredOnly() {
@@ -440,6 +632,16 @@ func flattenValidations(att *expr.AttributeExpr, seen map[string]struct{}) {
 	}
 }
 
+// i18nKey returns the localization key set on att with the "i18n:key" meta,
+// the generated code attaches the key to the validation errors of att.
+func i18nKey(att *expr.AttributeExpr) string {
+	if att == nil {
+		return ""
+	}
+	key, _ := att.Meta.Last("i18n:key")
+	return key
+}
+
 // toSlice returns Go code that represents the given slice.
 func toSlice(val []interface{}) string {
 	elems := make([]string, len(val))
@@ -490,18 +692,28 @@ func constant(formatName string) string {
 		return "goa.FormatJSON"
 	case "rfc1123":
 		return "goa.FormatRFC1123"
+	case "duration":
+		return "goa.FormatDuration"
 	}
 	panic("unknown format") // bug
 }
 
 const (
-	arrayValTmpl = `for _, e := range {{ .target }} {
+	arrayValTmpl = `for i, e := range {{ .target }} {
+        err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
 {{ .validation }}
+        return
+        }(), {{ printf "%q" .context }}, i))
 }`
 
-	mapValTmpl = `for {{if .keyValidation }}k{{ else }}_{{ end }}, {{ if .valueValidation }}v{{ else }}_{{ end }} := range {{ .target }} {
+	mapValTmpl = `for {{if or .keyValidation .valueValidation }}k{{ else }}_{{ end }}, {{ if .valueValidation }}v{{ else }}_{{ end }} := range {{ .target }} {
 {{- .keyValidation }}
+{{- if .valueValidation }}
+        err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
 {{- .valueValidation }}
+        return
+        }(), {{ printf "%q" .context }}, k))
+{{- end }}
 }`
 
 	unionValTmpl = `switch v := {{ .target }}.(type) {
@@ -511,62 +723,101 @@ const (
 {{ end -}}
 }`
 
-	userValTmpl = `if err2 := Validate{{ .name }}({{ .target }}); err2 != nil {
-        err = goa.MergeErrors(err, err2)
+	userValTmpl = `if err2 := {{ if .method }}{{ .target }}.Validate(){{ else }}Validate{{ .name }}({{ .target }}){{ end }}; err2 != nil {
+        err = goa.MergeErrors(err, goa.NestErrors(err2, {{ printf "%q" .context }}))
 }`
 
-	enumValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
+	enumValTmpl = `{{ $key := i18nKey .attribute }}{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
 if !({{ oneof .targetVal .values }}) {
-        err = goa.MergeErrors(err, goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ .targetVal }}, {{ slice .values }}))
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.InvalidEnumValueError({{ printf "%q" .context }}, {{ .targetVal }}, {{ slice .values }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
 {{ if .isPointer -}}
 }
 {{ end -}}
 }`
 
-	patternValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
+	patternValTmpl = `{{ $key := i18nKey .attribute }}{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}))
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.ValidatePattern({{ printf "%q" .context }}, {{ .targetVal }}, {{ printf "%q" .pattern }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
 {{- if .isPointer }}
 }
 {{- end }}`
 
-	formatValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
+	formatValTmpl = `{{ $key := i18nKey .attribute }}{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
-        err = goa.MergeErrors(err, goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}))
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.ValidateFormat({{ printf "%q" .context }}, {{ .targetVal}}, {{ constant .format }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
 {{- if .isPointer }}
 }
 {{- end }}`
 
-	exclMinMaxValTmpl = `{{ if .isPointer }}if {{ .target }} != nil {
+	equalValTmpl = `{{ $key := i18nKey .attribute }}{{ if and .fieldPointer .otherPointer -}}
+if ({{ .fieldRef }} == nil) != ({{ .otherRef }} == nil) || {{ .fieldRef }} != nil && *{{ .fieldRef }} != *{{ .otherRef }} {
+{{- else if .fieldPointer -}}
+if {{ .fieldRef }} == nil || *{{ .fieldRef }} != {{ .otherRef }} {
+{{- else if .otherPointer -}}
+if {{ .otherRef }} == nil || {{ .fieldRef }} != *{{ .otherRef }} {
+{{- else -}}
+if {{ .fieldRef }} != {{ .otherRef }} {
+{{- end }}
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.InvalidEqualFieldsError({{ printf "%q" (printf "%s.%s" .context .other) }}, {{ printf "%q" (printf "%s.%s" .context .field) }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
+}`
+
+	exclusiveValTmpl = `{{ $key := i18nKey .attribute }}{
+        var set []string
+{{- range .fields }}
+        if {{ .ref }} != nil {
+                set = append(set, {{ printf "%q" .name }})
+        }
+{{- end }}
+        if len(set) > 1 {
+                err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.InvalidMutuallyExclusiveError([]string{ {{- range $i, $f := .fields }}{{ if $i }}, {{ end }}{{ printf "%q" $f.name }}{{ end -}} }, set){{ if $key }}, {{ printf "%q" $key }}){{ end }})
+        }
+}`
+
+	oneOfValTmpl = `{{ $key := i18nKey .attribute }}if {{ range $i, $f := .fields }}{{ if $i }} && {{ end }}{{ $f.ref }} == nil{{ end }} {
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.MissingRequiredOneOfError([]string{ {{- range $i, $f := .fields }}{{ if $i }}, {{ end }}{{ printf "%q" $f.name }}{{ end -}} }){{ if $key }}, {{ printf "%q" $key }}){{ end }})
+}`
+
+	exclMinMaxValTmpl = `{{ $key := i18nKey .attribute }}{{ if .isPointer }}if {{ .target }} != nil {
 {{ end -}}
         if {{ .targetVal }} {{ if .isExclMin }}<={{ else }}>={{ end }} {{ if .isExclMin }}{{ .exclMin }}{{ else }}{{ .exclMax }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isExclMin }}{{ .exclMin }}, true{{ else }}{{ .exclMax }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isExclMin }}{{ .exclMin }}, true{{ else }}{{ .exclMax }}, false{{ end }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
 {{ if .isPointer -}}
 }
 {{ end -}}
 }`
 
-	minMaxValTmpl = `{{ if .isPointer -}}if {{ .target }} != nil {
+	minMaxValTmpl = `{{ $key := i18nKey .attribute }}{{ if .isPointer -}}if {{ .target }} != nil {
 {{ end -}}
         if {{ .targetVal }} {{ if .isMin }}<{{ else }}>{{ end }} {{ if .isMin }}{{ .min }}{{ else }}{{ .max }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.InvalidRangeError({{ printf "%q" .context }}, {{ .targetVal }}, {{ if .isMin }}{{ .min }}, true{{ else }}{{ .max }}, false{{ end }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
 {{ if .isPointer -}}
 }
 {{ end -}}
 }`
 
-	lengthValTmpl = `{{ $target := or (and (or (or .array .map) .nonzero) .target) .targetVal -}}
+	lengthValTmpl = `{{ $key := i18nKey .attribute }}{{ $target := or (and (or (or .array .map) .nonzero) .target) .targetVal -}}
 {{ if and .isPointer .string -}}
 if {{ .target }} != nil {
 {{ end -}}
 if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }} {{ if .isMinLength }}<{{ else }}>{{ end }} {{ if .isMinLength }}{{ .minLength }}{{ else }}{{ .maxLength }}{{ end }} {
-        err = goa.MergeErrors(err, goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}))
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.InvalidLengthError({{ printf "%q" .context }}, {{ $target }}, {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target }}){{ end }}, {{ if .isMinLength }}{{ .minLength }}, true{{ else }}{{ .maxLength }}, false{{ end }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
 }{{- if and .isPointer .string }}
 }
 {{- end }}`
 
-	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
-        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
+	numberValTmpl = `{{ $key := i18nKey .attribute }}{{ if .isPointer }}if {{ .target }} != nil {
+{{ end -}}
+if nv, nerr := {{ .target }}.Float64(); nerr != nil {
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.InvalidFieldTypeError({{ printf "%q" .context }}, {{ .target }}.String(), "number"){{ if $key }}, {{ printf "%q" $key }}){{ end }})
+} else {
+{{ .validation }}
+}
+{{- if .isPointer }}
+}
+{{- end }}`
+
+	requiredValTmpl = `{{ $key := i18nKey .reqAtt }}if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
+        err = goa.MergeErrors(err, {{ if $key }}goa.WithI18nKey({{ end }}goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}){{ if $key }}, {{ printf "%q" $key }}){{ end }})
 }`
 )
@@ -15,6 +15,13 @@ func TestRecursiveValidationCode(t *testing.T) {
 		integerT = root.UserType("Integer")
 		stringT  = root.UserType("String")
 		floatT   = root.UserType("Float")
+		numberT  = root.UserType("Number")
+		customT  = root.UserType("Custom")
+		cformatT = root.UserType("CustomFormat")
+		equalT   = root.UserType("EqualFields")
+		groupsT  = root.UserType("FieldGroups")
+		i18nT    = root.UserType("I18nKeys")
+		nullT    = root.UserType("Nullable")
 		aliasT   = root.UserType("AliasType")
 		userT    = root.UserType("UserType")
 		arrayUT  = root.UserType("ArrayUserType")
@@ -40,9 +47,20 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"float-required", floatT, true, false, false, testdata.FloatRequiredValidationCode},
 		{"float-pointer", floatT, false, true, false, testdata.FloatPointerValidationCode},
 		{"float-use-default", floatT, false, false, true, testdata.FloatUseDefaultValidationCode},
+		{"number-required", numberT, true, false, false, testdata.NumberRequiredValidationCode},
+		{"number-pointer", numberT, false, true, false, testdata.NumberPointerValidationCode},
 		{"string-required", stringT, true, false, false, testdata.StringRequiredValidationCode},
 		{"string-pointer", stringT, false, true, false, testdata.StringPointerValidationCode},
 		{"string-use-default", stringT, false, false, true, testdata.StringUseDefaultValidationCode},
+		{"custom-required", customT, true, false, false, testdata.CustomRequiredValidationCode},
+		{"custom-pointer", customT, false, true, false, testdata.CustomPointerValidationCode},
+		{"equal-fields-required", equalT, true, false, false, testdata.EqualFieldsRequiredValidationCode},
+		{"equal-fields-pointer", equalT, false, true, false, testdata.EqualFieldsPointerValidationCode},
+		{"field-groups-required", groupsT, true, false, false, testdata.FieldGroupsRequiredValidationCode},
+		{"field-groups-pointer", groupsT, false, true, false, testdata.FieldGroupsPointerValidationCode},
+		{"custom-format-pointer", cformatT, false, true, false, testdata.CustomFormatPointerValidationCode},
+		{"i18n-keys-pointer", i18nT, false, true, false, testdata.I18nKeysPointerValidationCode},
+		{"nullable-pointer", nullT, false, true, false, testdata.NullablePointerValidationCode},
 		{"alias-type", aliasT, true, false, false, testdata.AliasTypeValidationCode},
 		{"user-type-required", userT, true, false, false, testdata.UserTypeRequiredValidationCode},
 		{"user-type-pointer", userT, false, true, false, testdata.UserTypePointerValidationCode},
@@ -0,0 +1,302 @@
+/*
+Package diff compares two versions of a design and reports the changes that
+break the compatibility of the API with its existing clients.
+
+The designs are compared using snapshots that describe their services, methods,
+HTTP routes and the types of the method payloads and results. Snapshots are
+created with NewSnapshot from evaluated designs and are serialized to JSON so
+that they may be stored (e.g. with "goa snapshot") and compared later (e.g. with
+"goa diff") to gate changes in continuous integration pipelines.
+
+Changes are classified as breaking or non-breaking depending on whether they
+affect the data sent by the clients (payloads) or received by the clients
+(results): for example making a payload field required is breaking while
+making a result field required is not.
+*/
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+type (
+	// Report lists the changes between two snapshots.
+	Report struct {
+		// Breaking is true if at least one of the changes is breaking.
+		Breaking bool `json:"breaking"`
+		// Changes lists the changes.
+		Changes []*Change `json:"changes"`
+	}
+
+	// Change describes a single change between two snapshots.
+	Change struct {
+		// Kind is the kind of change.
+		Kind ChangeKind `json:"kind"`
+		// Path identifies the changed element, e.g.
+		// "users.create.payload.name".
+		Path string `json:"path"`
+		// Message describes the change.
+		Message string `json:"message"`
+	}
+
+	// ChangeKind is the kind of change, breaking or non-breaking.
+	ChangeKind string
+
+	// direction indicates whether an attribute is sent by the clients
+	// (payloads) or received by the clients (results).
+	direction int
+
+	// comparator accumulates the changes found while comparing snapshots.
+	comparator struct {
+		changes []*Change
+	}
+)
+
+const (
+	// Breaking indicates a change that breaks existing clients.
+	Breaking ChangeKind = "breaking"
+	// NonBreaking indicates a change compatible with existing clients.
+	NonBreaking ChangeKind = "non-breaking"
+)
+
+const (
+	input direction = iota
+	output
+)
+
+// Compare returns the changes made to the design described by old to produce
+// the design described by cur.
+func Compare(old, cur *Snapshot) *Report {
+	c := &comparator{}
+	c.compareServices(old.Services, cur.Services)
+	r := &Report{Changes: c.changes}
+	if r.Changes == nil {
+		r.Changes = []*Change{}
+	}
+	for _, ch := range r.Changes {
+		if ch.Kind == Breaking {
+			r.Breaking = true
+			break
+		}
+	}
+	return r
+}
+
+// compareServices records the changes made to the services and their methods.
+func (c *comparator) compareServices(old, cur []*Service) {
+	newSvcs := make(map[string]*Service, len(cur))
+	for _, s := range cur {
+		newSvcs[s.Name] = s
+	}
+	oldSvcs := make(map[string]*Service, len(old))
+	for _, s := range old {
+		oldSvcs[s.Name] = s
+		ns, ok := newSvcs[s.Name]
+		if !ok {
+			c.add(Breaking, s.Name, "service removed")
+			continue
+		}
+		c.compareMethods(s, ns)
+	}
+	for _, s := range cur {
+		if _, ok := oldSvcs[s.Name]; !ok {
+			c.add(NonBreaking, s.Name, "service added")
+		}
+	}
+}
+
+// compareMethods records the changes made to the methods of a service.
+func (c *comparator) compareMethods(old, cur *Service) {
+	newMeths := make(map[string]*Method, len(cur.Methods))
+	for _, m := range cur.Methods {
+		newMeths[m.Name] = m
+	}
+	oldMeths := make(map[string]*Method, len(old.Methods))
+	for _, m := range old.Methods {
+		oldMeths[m.Name] = m
+		path := old.Name + "." + m.Name
+		nm, ok := newMeths[m.Name]
+		if !ok {
+			c.add(Breaking, path, "method removed")
+			continue
+		}
+		if m.Stream != nm.Stream {
+			c.add(Breaking, path, fmt.Sprintf("stream kind changed from %q to %q", m.Stream, nm.Stream))
+		}
+		c.compareAttributes(path+".payload", m.Payload, nm.Payload, input)
+		c.compareAttributes(path+".result", m.Result, nm.Result, output)
+		c.compareLists(path+".errors", "error", quote(m.Errors), quote(nm.Errors), NonBreaking, NonBreaking)
+		c.compareLists(path+".routes", "HTTP route", quote(m.Routes), quote(nm.Routes), Breaking, NonBreaking)
+	}
+	for _, m := range cur.Methods {
+		if _, ok := oldMeths[m.Name]; !ok {
+			c.add(NonBreaking, cur.Name+"."+m.Name, "method added")
+		}
+	}
+}
+
+// compareLists records the values removed from old with the kind removed and
+// the values added to cur with the kind added. The values must be quoted.
+func (c *comparator) compareLists(path, name string, old, cur []string, removed, added ChangeKind) {
+	oldVals := make(map[string]struct{}, len(old))
+	for _, v := range old {
+		oldVals[v] = struct{}{}
+	}
+	newVals := make(map[string]struct{}, len(cur))
+	for _, v := range cur {
+		newVals[v] = struct{}{}
+		if _, ok := oldVals[v]; !ok {
+			c.add(added, path, fmt.Sprintf("%s %s added", name, v))
+		}
+	}
+	for _, v := range old {
+		if _, ok := newVals[v]; !ok {
+			c.add(removed, path, fmt.Sprintf("%s %s removed", name, v))
+		}
+	}
+}
+
+// compareAttributes records the changes made to an attribute, dir indicates
+// whether the attribute is sent or received by the clients.
+func (c *comparator) compareAttributes(path string, old, cur *Attribute, dir direction) {
+	switch {
+	case old == nil && cur == nil:
+		return
+	case old == nil:
+		kind := NonBreaking
+		if dir == input {
+			kind = Breaking
+		}
+		c.add(kind, path, "attribute added")
+		return
+	case cur == nil:
+		kind := Breaking
+		if dir == input {
+			kind = NonBreaking
+		}
+		c.add(kind, path, "attribute removed")
+		return
+	}
+	if old.Type != cur.Type {
+		c.add(Breaking, path, fmt.Sprintf("type changed from %s to %s", old.Type, cur.Type))
+		return
+	}
+	c.compareEnums(path, old.Enum, cur.Enum, dir)
+	if old.Ref != "" || cur.Ref != "" {
+		return
+	}
+	c.compareFields(path, old.Fields, cur.Fields, dir)
+	c.compareAttributes(path+"[key]", old.Key, cur.Key, dir)
+	c.compareAttributes(path+"[elem]", old.Elem, cur.Elem, dir)
+}
+
+// compareFields records the changes made to the fields of an object.
+func (c *comparator) compareFields(path string, old, cur []*Field, dir direction) {
+	newFields := make(map[string]*Field, len(cur))
+	for _, f := range cur {
+		newFields[f.Name] = f
+	}
+	oldFields := make(map[string]*Field, len(old))
+	for _, f := range old {
+		oldFields[f.Name] = f
+		fpath := path + "." + f.Name
+		nf, ok := newFields[f.Name]
+		if !ok {
+			c.add(Breaking, fpath, "field removed")
+			continue
+		}
+		switch {
+		case !f.Required && nf.Required:
+			kind := NonBreaking
+			if dir == input {
+				kind = Breaking
+			}
+			c.add(kind, fpath, "field made required")
+		case f.Required && !nf.Required:
+			kind := Breaking
+			if dir == input {
+				kind = NonBreaking
+			}
+			c.add(kind, fpath, "field made optional")
+		}
+		c.compareAttributes(fpath, f.Attribute, nf.Attribute, dir)
+	}
+	for _, f := range cur {
+		if _, ok := oldFields[f.Name]; ok {
+			continue
+		}
+		kind := NonBreaking
+		msg := "optional field added"
+		if f.Required {
+			msg = "required field added"
+			if dir == input {
+				kind = Breaking
+			}
+		}
+		c.add(kind, path+"."+f.Name, msg)
+	}
+}
+
+// compareEnums records the changes made to the values of an enum. Narrowing
+// the values of a payload attribute and widening the values of a result
+// attribute are breaking.
+func (c *comparator) compareEnums(path string, old, cur []interface{}, dir direction) {
+	if len(old) == 0 && len(cur) == 0 {
+		return
+	}
+	if len(cur) == 0 {
+		kind := NonBreaking
+		if dir == output {
+			kind = Breaking
+		}
+		c.add(kind, path, "enum validation removed")
+		return
+	}
+	if len(old) == 0 {
+		kind := NonBreaking
+		if dir == input {
+			kind = Breaking
+		}
+		c.add(kind, path, "enum validation added")
+		return
+	}
+	removed, added := NonBreaking, NonBreaking
+	if dir == input {
+		removed = Breaking
+	} else {
+		added = Breaking
+	}
+	c.compareLists(path, "enum value", enumKeys(old), enumKeys(cur), removed, added)
+}
+
+// enumKeys returns the JSON representations of the given enum values so that
+// values read from snapshots and values read from designs compare equal.
+func enumKeys(vals []interface{}) []string {
+	keys := make([]string, len(vals))
+	for i, v := range vals {
+		b, err := json.Marshal(v)
+		if err != nil {
+			b = []byte(fmt.Sprint(v))
+		}
+		keys[i] = string(b)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quote returns the given values quoted.
+func quote(vals []string) []string {
+	res := make([]string, len(vals))
+	for i, v := range vals {
+		res[i] = strconv.Quote(v)
+	}
+	return res
+}
+
+// add records a change.
+func (c *comparator) add(kind ChangeKind, path, msg string) {
+	c.changes = append(c.changes, &Change{Kind: kind, Path: path, Message: msg})
+}
@@ -0,0 +1,178 @@
+package diff_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/diff"
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Old, New func()
+		Breaking bool
+		Expected []diff.Change
+	}{
+		{"identical", baseDSL(nil), baseDSL(nil), false, nil},
+		{"method removed", baseDSL(nil), func() {
+			Service("users", func() {})
+		}, true, []diff.Change{
+			{Kind: diff.Breaking, Path: "users.create", Message: "method removed"},
+		}},
+		{"method added", baseDSL(nil), baseDSL(func() {
+			Method("delete", func() {})
+		}), false, []diff.Change{
+			{Kind: diff.NonBreaking, Path: "users.delete", Message: "method added"},
+		}},
+		{"payload field made required", baseDSL(nil), payloadDSL(func() {
+			Attribute("name", String)
+			Attribute("role", String, func() { Enum("admin", "member") })
+			Required("name", "role")
+		}), true, []diff.Change{
+			{Kind: diff.Breaking, Path: "users.create.payload.role", Message: "field made required"},
+		}},
+		{"payload enum narrowed", baseDSL(nil), payloadDSL(func() {
+			Attribute("name", String)
+			Attribute("role", String, func() { Enum("admin") })
+			Required("name")
+		}), true, []diff.Change{
+			{Kind: diff.Breaking, Path: "users.create.payload.role", Message: `enum value "member" removed`},
+		}},
+		{"payload enum widened", baseDSL(nil), payloadDSL(func() {
+			Attribute("name", String)
+			Attribute("role", String, func() { Enum("admin", "member", "guest") })
+			Required("name")
+		}), false, []diff.Change{
+			{Kind: diff.NonBreaking, Path: "users.create.payload.role", Message: `enum value "guest" added`},
+		}},
+		{"payload field type changed", baseDSL(nil), payloadDSL(func() {
+			Attribute("name", Int)
+			Attribute("role", String, func() { Enum("admin", "member") })
+			Required("name")
+		}), true, []diff.Change{
+			{Kind: diff.Breaking, Path: "users.create.payload.name", Message: "type changed from string to int"},
+		}},
+		{"optional payload field added", baseDSL(nil), payloadDSL(func() {
+			Attribute("name", String)
+			Attribute("role", String, func() { Enum("admin", "member") })
+			Attribute("email", String)
+			Required("name")
+		}), false, []diff.Change{
+			{Kind: diff.NonBreaking, Path: "users.create.payload.email", Message: "optional field added"},
+		}},
+		{"result field made optional", baseDSL(nil), resultDSL(func() {
+			Attribute("id", Int)
+		}), true, []diff.Change{
+			{Kind: diff.Breaking, Path: "users.create.result.id", Message: "field made optional"},
+		}},
+		{"route changed", baseDSL(nil), baseDSL(nil, "/members"), true, []diff.Change{
+			{Kind: diff.NonBreaking, Path: "users.create.routes", Message: `HTTP route "POST /members" added`},
+			{Kind: diff.Breaking, Path: "users.create.routes", Message: `HTTP route "POST /users" removed`},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			old := diff.NewSnapshot(expr.RunDSL(t, c.Old))
+			cur := diff.NewSnapshot(expr.RunDSL(t, c.New))
+
+			r := diff.Compare(old, cur)
+
+			if r.Breaking != c.Breaking {
+				t.Errorf("got breaking %v, expected %v", r.Breaking, c.Breaking)
+			}
+			if len(r.Changes) != len(c.Expected) {
+				t.Fatalf("got %d changes, expected %d: %s", len(r.Changes), len(c.Expected), toJSON(t, r))
+			}
+			for i, ch := range r.Changes {
+				if *ch != c.Expected[i] {
+					t.Errorf("got change %+v, expected %+v", *ch, c.Expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshotJSON(t *testing.T) {
+	snap := diff.NewSnapshot(expr.RunDSL(t, payloadDSL(func() {
+		Attribute("count", Int, func() { Enum(1, 2) })
+		Attribute("friends", ArrayOf(String))
+	})))
+	var decoded diff.Snapshot
+	if err := json.Unmarshal([]byte(toJSON(t, snap)), &decoded); err != nil {
+		t.Fatalf("failed to decode snapshot: %s", err)
+	}
+
+	r := diff.Compare(snap, &decoded)
+
+	if len(r.Changes) != 0 {
+		t.Errorf("got changes %s, expected none", toJSON(t, r))
+	}
+	if !reflect.DeepEqual(decoded.Services[0].Methods[0].Routes, []string{"POST /users"}) {
+		t.Errorf("got routes %v, expected [POST /users]", decoded.Services[0].Methods[0].Routes)
+	}
+}
+
+// baseDSL returns the DSL of the design used as reference by the tests. fn is
+// executed in the service DSL if not nil. path overrides the HTTP path of the
+// method.
+func baseDSL(fn func(), path ...string) func() {
+	p := "/users"
+	if len(path) > 0 {
+		p = path[0]
+	}
+	return designDSL(fn, func() {
+		Attribute("name", String)
+		Attribute("role", String, func() { Enum("admin", "member") })
+		Required("name")
+	}, func() {
+		Attribute("id", Int)
+		Required("id")
+	}, p)
+}
+
+// payloadDSL returns the DSL of the reference design using the given payload.
+func payloadDSL(payload func()) func() {
+	return designDSL(nil, payload, func() {
+		Attribute("id", Int)
+		Required("id")
+	}, "/users")
+}
+
+// resultDSL returns the DSL of the reference design using the given result.
+func resultDSL(result func()) func() {
+	return designDSL(nil, func() {
+		Attribute("name", String)
+		Attribute("role", String, func() { Enum("admin", "member") })
+		Required("name")
+	}, result, "/users")
+}
+
+func designDSL(fn, payload, result func(), path string) func() {
+	return func() {
+		Service("users", func() {
+			Method("create", func() {
+				Payload(payload)
+				Result(result)
+				HTTP(func() {
+					POST(path)
+				})
+			})
+			if fn != nil {
+				fn()
+			}
+		})
+	}
+}
+
+func toJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
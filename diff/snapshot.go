@@ -0,0 +1,187 @@
+package diff
+
+import (
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// Snapshot describes the parts of a design that affect the
+	// compatibility of the API with its clients. Snapshots are serialized
+	// to JSON so that the designs of different versions of an API may be
+	// compared without having to evaluate them together.
+	Snapshot struct {
+		// API is the name of the API.
+		API string `json:"api"`
+		// Version is the version of the API if any.
+		Version string `json:"version,omitempty"`
+		// Services lists the API services.
+		Services []*Service `json:"services,omitempty"`
+	}
+
+	// Service describes a service.
+	Service struct {
+		// Name is the name of the service.
+		Name string `json:"name"`
+		// Methods lists the service methods.
+		Methods []*Method `json:"methods,omitempty"`
+	}
+
+	// Method describes a service method.
+	Method struct {
+		// Name is the name of the method.
+		Name string `json:"name"`
+		// Stream is the kind of stream used by the method if any, one of
+		// "client", "server" or "bidirectional".
+		Stream string `json:"stream,omitempty"`
+		// Payload describes the method payload if any.
+		Payload *Attribute `json:"payload,omitempty"`
+		// Result describes the method result if any.
+		Result *Attribute `json:"result,omitempty"`
+		// Errors lists the names of the method errors.
+		Errors []string `json:"errors,omitempty"`
+		// Routes lists the HTTP routes of the method formatted as
+		// "VERB /path".
+		Routes []string `json:"routes,omitempty"`
+	}
+
+	// Attribute describes the type of a payload, a result or one of their
+	// fields.
+	Attribute struct {
+		// Type is the name of the type kind, e.g. "string", "object" or
+		// "array".
+		Type string `json:"type"`
+		// Enum lists the values allowed by the attribute if any.
+		Enum []interface{} `json:"enum,omitempty"`
+		// Fields lists the fields of objects and the values of unions.
+		Fields []*Field `json:"fields,omitempty"`
+		// Key describes the keys of maps.
+		Key *Attribute `json:"key,omitempty"`
+		// Elem describes the elements of arrays and the values of maps.
+		Elem *Attribute `json:"elem,omitempty"`
+		// Ref is the name of the recursive user type described by one
+		// of the parents of the attribute if any. Ref attributes do not
+		// describe the type fields.
+		Ref string `json:"ref,omitempty"`
+	}
+
+	// Field describes an object field.
+	Field struct {
+		// Name is the name of the field.
+		Name string `json:"name"`
+		// Required is true if the field is required.
+		Required bool `json:"required,omitempty"`
+		// Attribute describes the field type.
+		Attribute *Attribute `json:"attribute"`
+	}
+)
+
+// NewSnapshot returns the snapshot of the given evaluated design.
+func NewSnapshot(root *expr.RootExpr) *Snapshot {
+	s := &Snapshot{API: root.API.Name, Version: root.API.Version}
+	for _, svc := range root.Services {
+		sd := &Service{Name: svc.Name}
+		for _, m := range svc.Methods {
+			md := &Method{
+				Name:    m.Name,
+				Payload: newAttribute(m.Payload, make(map[string]struct{})),
+				Result:  newAttribute(m.Result, make(map[string]struct{})),
+			}
+			switch m.Stream {
+			case expr.ClientStreamKind:
+				md.Stream = "client"
+			case expr.ServerStreamKind:
+				md.Stream = "server"
+			case expr.BidirectionalStreamKind:
+				md.Stream = "bidirectional"
+			}
+			for _, e := range m.Errors {
+				md.Errors = append(md.Errors, e.Name)
+			}
+			if root.API.HTTP != nil {
+				if hs := root.API.HTTP.Service(svc.Name); hs != nil {
+					if e := hs.Endpoint(m.Name); e != nil {
+						for _, r := range e.Routes {
+							for _, p := range r.FullPaths() {
+								md.Routes = append(md.Routes, r.Method+" "+p)
+							}
+						}
+					}
+				}
+			}
+			sd.Methods = append(sd.Methods, md)
+		}
+		s.Services = append(s.Services, sd)
+	}
+	return s
+}
+
+// newAttribute returns the description of the given attribute, nil if the
+// attribute is empty. seen records the user types being described to handle
+// recursive types.
+func newAttribute(att *expr.AttributeExpr, seen map[string]struct{}) *Attribute {
+	if att == nil || att.Type == nil || att.Type == expr.Empty {
+		return nil
+	}
+	a := &Attribute{}
+	if att.Validation != nil {
+		a.Enum = att.Validation.Values
+	}
+	dt := att.Type
+	for {
+		ut, ok := dt.(expr.UserType)
+		if !ok {
+			break
+		}
+		if _, ok := seen[ut.ID()]; ok {
+			a.Type = kindName(ut)
+			a.Ref = ut.Name()
+			return a
+		}
+		seen[ut.ID()] = struct{}{}
+		defer delete(seen, ut.ID())
+		if a.Enum == nil && ut.Attribute().Validation != nil {
+			a.Enum = ut.Attribute().Validation.Values
+		}
+		dt = ut.Attribute().Type
+	}
+	a.Type = kindName(dt)
+	switch dt := dt.(type) {
+	case *expr.Object:
+		for _, nat := range *dt {
+			a.Fields = append(a.Fields, &Field{
+				Name:      nat.Name,
+				Required:  att.IsRequired(nat.Name),
+				Attribute: newAttribute(nat.Attribute, seen),
+			})
+		}
+	case *expr.Array:
+		a.Elem = newAttribute(dt.ElemType, seen)
+	case *expr.Map:
+		a.Key = newAttribute(dt.KeyType, seen)
+		a.Elem = newAttribute(dt.ElemType, seen)
+	case *expr.Union:
+		for _, nat := range dt.Values {
+			a.Fields = append(a.Fields, &Field{Name: nat.Name, Attribute: newAttribute(nat.Attribute, seen)})
+		}
+	}
+	return a
+}
+
+// kindName returns the name of the kind of the given type, e.g. "string" or
+// "object".
+func kindName(dt expr.DataType) string {
+	if ut, ok := dt.(expr.UserType); ok {
+		return kindName(ut.Attribute().Type)
+	}
+	switch dt.(type) {
+	case *expr.Object:
+		return "object"
+	case *expr.Array:
+		return "array"
+	case *expr.Map:
+		return "map"
+	case *expr.Union:
+		return "union"
+	}
+	return dt.Name()
+}
@@ -79,12 +79,17 @@ func Title(val string) {
 //
 // Version must appear in a API expression.
 //
-// Version accepts a single string argument.
+// Version accepts a single string argument which must be a semantic version
+// (see https://semver.org), the leading "v" as well as the minor and patch
+// versions are optional. The version is used in the generated OpenAPI
+// specifications and in the APIVersion constant generated in each service
+// package. The "http:version:path" meta can be used to serve the version over
+// HTTP.
 //
 // Example:
 //
 //    var _ = API("divider", func() {
-//        Version("1.0")
+//        Version("2.3.1")
 //    })
 //
 func Version(ver string) {
@@ -158,7 +163,10 @@ func License(fn func()) {
 // expr.Randomizer.
 //
 // The default randomizer uses the API name as the seed, to get consistent
-// random examples.
+// random examples. The examples of user types are generated using the API name
+// and the type name as seed so that changes to a type do not change the
+// examples of the other types. Examples defined in the design always take
+// precedence over generated values.
 //
 // Example:
 //
@@ -239,6 +247,37 @@ func TermsOfService(terms string) {
 	eval.IncompatibleDSL()
 }
 
+// RequestID enables the propagation of request IDs using the header with the
+// given name. The generated HTTP servers initialize the request context with
+// the request ID read from the header, a new UUID is generated if the request
+// does not define the header. The generated HTTP clients set the header to the
+// request ID stored in the request context so that the request IDs are
+// propagated to the downstream services. The request ID is stored in the
+// context under the middleware.RequestIDKey key.
+//
+// RequestID must appear in a API expression.
+//
+// RequestID takes a single argument which is the name of the header.
+//
+// Example:
+//
+//    var _ = API("divider", func() {
+//        RequestID("X-Request-Id")
+//    })
+//
+func RequestID(header string) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if header == "" {
+		eval.ReportError("RequestID header name cannot be empty")
+		return
+	}
+	a.RequestIDHeader = header
+}
+
 // Name sets the contact or license name.
 //
 // Name must appear in a Contact or License expression.
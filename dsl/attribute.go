@@ -245,21 +245,221 @@ func OneOf(name string, args ...interface{}) {
 //
 // Default must appear in an Attribute DSL.
 //
-// Default takes one parameter: the default value.
-func Default(def interface{}) {
+// Default takes one or two parameters: the default value and optionally a
+// function that uses Only to list the methods whose payloads use the default
+// value. This makes it possible to reuse a type as the payload of methods that
+// need different defaults, for example a create method that defaults the
+// status of new resources and an update method that leaves it unchanged when
+// omitted. Default values scoped to methods only apply to the top level
+// attributes of the payloads, the attributes must be of type Boolean, String
+// or numeric and must not be required. The fields generated for such
+// attributes are pointers and the generated endpoints of the listed methods
+// set them to the default value when they are nil. An attribute may define
+// multiple default values scoped to different methods but cannot define both
+// a default value and default values scoped to methods.
+//
+// Example:
+//
+//	var UserPayload = Type("UserPayload", func() {
+//	    Attribute("name", String)
+//	    Attribute("status", String, func() {
+//	        Default("active", func() {
+//	            Only("create")
+//	        })
+//	    })
+//	})
+func Default(def interface{}, fn ...func()) {
 	a, ok := eval.Current().(*expr.AttributeExpr)
 	if !ok {
 		eval.IncompatibleDSL()
 		return
 	}
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Default")
+		return
+	}
 	if a.Type != nil && !a.Type.IsCompatible(def) {
 		eval.ReportError("default value %#v is incompatible with attribute of type %s",
 			def, expr.QualifiedTypeName(a.Type))
 		return
 	}
+	if len(fn) == 1 {
+		d := &expr.MethodDefaultExpr{Value: def}
+		if !eval.Execute(fn[0], d) {
+			return
+		}
+		a.MethodDefaults = append(a.MethodDefaults, d)
+		return
+	}
 	a.SetDefault(def)
 }
 
+// Only lists the methods whose payloads use a default value.
+//
+// Only must appear in the function given to Default.
+//
+// Only takes one or more method names.
+//
+// Example:
+//
+//	Attribute("status", String, func() {
+//	    Default("active", func() {
+//	        Only("create", "import")
+//	    })
+//	})
+func Only(methods ...string) {
+	d, ok := eval.Current().(*expr.MethodDefaultExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(methods) == 0 {
+		eval.ReportError("Only requires at least one method name")
+		return
+	}
+	d.Methods = append(d.Methods, methods...)
+}
+
+// Alias lists additional names accepted for the attribute when decoding JSON
+// request bodies, for example to keep accepting a name that was renamed. The
+// generated HTTP server request body types implement json.Unmarshaler so that
+// the aliases are mapped to the attribute. The attribute name takes precedence
+// if a request uses both the name and an alias. The HTTP responses, the
+// generated clients and the OpenAPI specifications always use the attribute
+// name.
+//
+// Alias must appear in the DSL of an attribute of an object.
+//
+// Alias takes one or more names.
+//
+// Example:
+//
+//	Attribute("user_name", String, func() {
+//	    Alias("username", "userName")
+//	})
+func Alias(names ...string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(names) == 0 {
+		eval.ReportError("Alias requires at least one name")
+		return
+	}
+	for _, n := range names {
+		if n == "" {
+			eval.ReportError("alias cannot be empty")
+			return
+		}
+	}
+	a.Aliases = append(a.Aliases, names...)
+}
+
+// Nullable makes it possible to distinguish an attribute explicitly set to null
+// from an absent attribute. The Go struct fields generated for nullable
+// attributes use the goa.Nullable type which records whether the attribute is
+// present and whether it is null. This makes it possible to implement JSON
+// Merge Patch semantics where absent attributes leave the resource unchanged
+// and null attributes clear the corresponding resource fields. The OpenAPI
+// specifications mark the attributes as nullable.
+//
+// Nullable must appear in an Attribute DSL. The attribute must be of type
+// Boolean, String or numeric, must not be required and must not have a
+// default value. Nullable attributes are only supported in HTTP request and
+// response bodies encoded with JSON, they cannot be mapped to headers,
+// parameters or cookies and cannot be used with gRPC.
+//
+// Nullable takes no argument.
+//
+// Example:
+//
+//    var PatchUser = Type("PatchUser", func() {
+//        Attribute("nickname", String, func() {
+//            Nullable()
+//        })
+//    })
+//
+func Nullable() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("nullable")
+}
+
+// Generated flags an attribute whose value is assigned by the server, for
+// example an auto-incremented identifier or a creation timestamp. The
+// attribute is removed from the method payloads that use the enclosing type so
+// that clients cannot set it, it remains present in the results. The OpenAPI
+// specifications mark the attribute as read-only.
+//
+// Generated must appear in an Attribute DSL. Only the top level attributes of
+// the payloads are removed, including the attributes inherited with Extend.
+// Methods that need the attribute in their payload, for example to identify
+// the resource being updated, must declare it explicitly in an inline payload
+// (possibly using Reference).
+//
+// Generated takes no argument.
+//
+// Example:
+//
+//    var User = Type("User", func() {
+//        Attribute("id", Int64, func() {
+//            Generated()
+//        })
+//        Attribute("name", String)
+//        Required("id", "name")
+//    })
+//
+//    var _ = Service("users", func() {
+//        Method("create", func() {
+//            Payload(User) // Payload only contains "name"
+//            Result(User)
+//        })
+//    })
+//
+func Generated() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("generated")
+}
+
+// Unit documents the unit of the values of a numeric attribute, for example
+// "celsius" or "ms". The unit does not affect the generated code, it is
+// documented in the OpenAPI specifications with the "x-unit" extension and
+// in the attribute description. Combine Unit with Minimum and Maximum to
+// define the valid range of values.
+//
+// Unit must appear in an Attribute DSL of a numeric attribute.
+//
+// Unit accepts one argument: the name of the unit.
+//
+// Example:
+//
+//    var Reading = Type("Reading", func() {
+//        Attribute("temperature", Float64, func() {
+//            Unit("celsius")
+//            Minimum(-273.15)
+//        })
+//    })
+//
+func Unit(name string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta != nil {
+		delete(a.Meta, "unit")
+	}
+	a.AddMeta("unit", name)
+}
+
 // Example provides an example value for a type, a parameter, a header or any
 // attribute. Example supports two syntaxes: one syntax accepts two arguments
 // where the first argument is a summary describing the example and the second a
@@ -0,0 +1,82 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Batch generates a method that applies another method of the same service to
+// many payloads at once. The generated method is named after the batched method
+// prefixed with "batch_", its payload is an array of the batched method
+// payloads and its result is an array of items that hold either the result or
+// the error produced by the batched method for the payload at the same index
+// so that partial failures are reported for each item.
+//
+// The generated service package includes a function that implements the batch
+// method by calling the batched method for each payload, see the generated code
+// for details.
+//
+// If the batched method has an HTTP endpoint then the batch method HTTP
+// endpoint accepts POST requests on the path of the batched method route
+// suffixed with "/batch" and responds with status 207 (Multi-Status). The route
+// of the batched method cannot define path parameters. Streaming and secured
+// methods cannot be batched.
+//
+// Batch must appear in a Service expression.
+//
+// Batch accepts two arguments: the name of the batched method and an optional
+// DSL which may use MaxItems.
+//
+// Example:
+//
+//	Service("users", func() {
+//	    Batch("create", func() {
+//	        MaxItems(100)
+//	    })
+//	    Method("create", func() {
+//	        Payload(User)
+//	        Result(String)
+//	        HTTP(func() {
+//	            POST("/users") // batch: POST /users/batch
+//	        })
+//	    })
+//	})
+func Batch(name string, fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to Batch")
+		return
+	}
+	s, ok := eval.Current().(*expr.ServiceExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	b := &expr.BatchExpr{Name: name, Service: s}
+	if len(fn) > 0 {
+		if !eval.Execute(fn[0], b) {
+			return
+		}
+	}
+	s.Batches = append(s.Batches, b)
+}
+
+// MaxItems sets the maximum number of payloads in a batch. Requests with more
+// payloads are rejected with a validation error.
+//
+// MaxItems must appear in a Batch expression.
+//
+// MaxItems accepts a single argument which is the maximum number of payloads.
+//
+// Example:
+//
+//	Batch("create", func() {
+//	    MaxItems(100)
+//	})
+func MaxItems(n int) {
+	b, ok := eval.Current().(*expr.BatchExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	b.MaxItems = n
+}
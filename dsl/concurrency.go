@@ -0,0 +1,77 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// MaxConcurrent sets the maximum number of requests handled concurrently by
+// the method. The generated endpoints wrap the method endpoint with a
+// middleware that rejects the requests beyond the limit with the method
+// "unavailable" error if the method defines one, or with a temporary service
+// error named "unavailable" otherwise (HTTP status 503 Service Unavailable). The
+// slot held by a request is released when the endpoint returns, including when
+// it panics.
+//
+// MaxConcurrent must appear in a Method expression.
+//
+// MaxConcurrent accepts the maximum number of concurrent requests as first
+// argument and an optional function that may use QueueTimeout to make requests
+// beyond the limit wait for a slot instead of being rejected right away.
+//
+// Example:
+//
+//	Method("report", func() {
+//	    Payload(ReportRequest)
+//	    Result(Report)
+//	    Error("unavailable")
+//	    MaxConcurrent(10, func() {
+//	        QueueTimeout("2s")
+//	    })
+//	})
+func MaxConcurrent(limit int, fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to MaxConcurrent")
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c := &expr.ConcurrencyExpr{Limit: limit, Method: m}
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], c) {
+			return
+		}
+	}
+	m.Concurrency = c
+}
+
+// QueueTimeout sets the maximum duration requests beyond the limit set with
+// MaxConcurrent wait for a slot before being rejected. Requests whose context
+// is canceled while waiting fail with the context error. The duration uses the
+// format accepted by time.ParseDuration.
+//
+// QueueTimeout must appear in a MaxConcurrent expression.
+//
+// Example:
+//
+//	MaxConcurrent(10, func() {
+//	    QueueTimeout("500ms")
+//	})
+func QueueTimeout(d string) {
+	c, ok := eval.Current().(*expr.ConcurrencyExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	timeout, err := time.ParseDuration(d)
+	if err != nil {
+		eval.InvalidArgError("duration", d)
+		return
+	}
+	c.QueueTimeout = timeout
+}
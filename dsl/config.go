@@ -0,0 +1,54 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Config defines the configuration of the API. The code generator produces a
+// "config" package containing a Config struct with one field per configuration
+// attribute and a Load function that initializes the fields with their default
+// values, then with the values of the corresponding environment variables and
+// finally with the values of the corresponding command line flags. Load returns
+// an error if a required field is not set or if a value does not satisfy the
+// attribute validations.
+//
+// The environment variable of a field is named after the field using upper
+// case letters, e.g. "LOG_LEVEL" for "log_level", and its command line flag
+// uses dashes as separators, e.g. "-log-level". The "config:env" and
+// "config:flag" meta override the names.
+//
+// Config must appear in an API expression.
+//
+// Config accepts a single argument which is the defining DSL. The DSL lists the
+// configuration fields with Attribute. The fields must be strings, booleans or
+// numbers and may define default values, validations and required fields.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    Config(func() {
+//	        Attribute("port", Int, "Listen port", func() {
+//	            Default(8080)
+//	            Minimum(1)
+//	            Maximum(65535)
+//	        })
+//	        Attribute("db_url", String, "Database URL", func() {
+//	            Meta("config:env", "DATABASE_URL")
+//	        })
+//	        Attribute("debug", Boolean, "Log debug messages")
+//	        Required("db_url")
+//	    })
+//	})
+func Config(fn func()) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c := &expr.ConfigExpr{}
+	if !eval.Execute(fn, c) {
+		return
+	}
+	a.Config = c
+}
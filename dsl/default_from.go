@@ -0,0 +1,43 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// DefaultFrom initializes a payload field with the value of another payload
+// field when the request does not provide it. The generated endpoint copies
+// the value after the request is decoded and right before the service method
+// is called, once the defaults set with Default and ServerDefault have been
+// applied. Defaults that depend on each other are applied in dependency order,
+// e.g. "a" defaulting to "b" defaulting to "c" first copies "c" to "b".
+//
+// DefaultFrom must appear in a Method expression.
+//
+// DefaultFrom accepts the name of the payload attribute to initialize and the
+// name of the payload attribute whose value is copied. Both attributes must
+// have the same type, the first one cannot be required nor define a default
+// value. Values provided by the request are never overridden.
+//
+// Example:
+//
+//	Method("signup", func() {
+//	    Payload(func() {
+//	        Attribute("username", String)
+//	        Attribute("display_name", String)
+//	        Required("username")
+//	    })
+//	    DefaultFrom("display_name", "username")
+//	})
+func DefaultFrom(name, source string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.DefaultsFrom = append(m.DefaultsFrom, &expr.DefaultFromExpr{
+		Field:  name,
+		Source: source,
+		Method: m,
+	})
+}
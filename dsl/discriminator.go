@@ -0,0 +1,97 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Discriminator defines the attribute of a base type whose value identifies
+// the concrete type of an object among the types that extend the base type
+// with Extend. The OpenAPI v3 specification describes the base type schema with
+// a discriminator object listing the mapped types and describes the schemas of
+// the types that extend the base type using "allOf" with a reference to the
+// base type schema.
+//
+// The types of the values of a union (see OneOf) that are all mapped by the
+// same discriminator are identified by their discriminator values instead of
+// the union attribute names in HTTP bodies so that the generated code decodes
+// the union values into the Go type mapped to the discriminator value.
+//
+// Discriminator must appear in a Type or ResultType expression.
+//
+// Discriminator accepts two arguments: the name of the discriminator attribute
+// which must be a String attribute of the base type and a function that must
+// use Map to map the discriminator values to the types that extend the base
+// type.
+//
+// Example:
+//
+//	var Pet = Type("Pet", func() {
+//	    Attribute("kind", String)
+//	    Attribute("name", String)
+//	    Required("kind", "name")
+//	    Discriminator("kind", func() {
+//	        Map("dog", "Dog") // Dog refers to Pet, use the type name
+//	        Map("cat", "Cat")
+//	    })
+//	})
+//
+//	var Dog = Type("Dog", func() {
+//	    Extend(Pet)
+//	    Attribute("barks", Boolean)
+//	})
+//
+//	var Cat = Type("Cat", func() {
+//	    Extend(Pet)
+//	    Attribute("lives", Int)
+//	})
+func Discriminator(name string, fn func()) {
+	var att *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.ResultTypeExpr:
+		att = def.AttributeExpr
+	case *expr.AttributeExpr:
+		att = def
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	d := &expr.DiscriminatorExpr{PropertyName: name}
+	if !eval.Execute(fn, d) {
+		return
+	}
+	att.Discriminator = d
+}
+
+// Map maps a discriminator value to the type identified by the value.
+//
+// Map must appear in a Discriminator expression.
+//
+// Map accepts two arguments: the discriminator value and the type or the name of
+// the type which must be an object user type that defines the discriminator
+// attribute, usually by extending the base type. Use the type name when the
+// type extends the base type to avoid Go initialization cycles.
+//
+// Example:
+//
+//	Discriminator("kind", func() {
+//	    Map("dog", "Dog")
+//	})
+func Map(value string, t interface{}) {
+	d, ok := eval.Current().(*expr.DiscriminatorExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	dt, ok := t.(expr.DataType)
+	if !ok {
+		if name, ok := t.(string); ok {
+			dt = expr.Root.UserType(name)
+		}
+	}
+	if dt == nil {
+		eval.ReportError("invalid Map argument: not a type and not a known user type name")
+		return
+	}
+	d.Mappings = append(d.Mappings, &expr.DiscriminatorMappingExpr{Value: value, Type: dt})
+}
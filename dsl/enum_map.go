@@ -0,0 +1,69 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// EnumMap defines a string enum whose values are also identified by numbers.
+// The HTTP transport uses the value names while the gRPC transport uses the
+// value numbers: the generated protocol buffer field is an int32 and the
+// generated code converts between the names and the numbers. The attribute
+// validations only accept the value names.
+//
+// By default the gRPC transport rejects unknown numbers. Use Fallback to
+// decode unknown numbers to a given value instead, similarly to how proto3
+// preserves unknown enum values.
+//
+// EnumMap must appear in an attribute or type expression of type String.
+//
+// EnumMap takes a function that must use Value to define the name and number
+// of each enum value and may use Fallback.
+//
+// Example:
+//
+//	Attribute("status", String, func() {
+//	    EnumMap(func() {
+//	        Value("UNKNOWN", 0)
+//	        Value("ACTIVE", 1)
+//	        Value("SUSPENDED", 2)
+//	        Fallback("UNKNOWN")
+//	    })
+//	})
+func EnumMap(fn func()) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	em := &expr.EnumMapExpr{}
+	if !eval.Execute(fn, em) {
+		return
+	}
+	a.EnumMap = em
+	if a.Validation == nil {
+		a.Validation = &expr.ValidationExpr{}
+	}
+	a.Validation.Values = em.Names()
+}
+
+// Fallback sets the name of the enum value used by the gRPC transport to
+// decode numbers that do not correspond to any value.
+//
+// Fallback must appear in an EnumMap expression.
+//
+// Example:
+//
+//	EnumMap(func() {
+//	    Value("UNKNOWN", 0)
+//	    Value("ACTIVE", 1)
+//	    Fallback("UNKNOWN")
+//	})
+func Fallback(name string) {
+	em, ok := eval.Current().(*expr.EnumMapExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	em.Fallback = name
+}
@@ -50,6 +50,17 @@ const (
 	InvalidRange = pkg.InvalidRange
 	// InvalidLength is the error name for invalid length errors.
 	InvalidLength = pkg.InvalidLength
+	// InvalidCustom is the error name for failed custom validations.
+	InvalidCustom = pkg.InvalidCustom
+	// InvalidEqualFields is the error name for fields that must be equal
+	// but are not.
+	InvalidEqualFields = pkg.InvalidEqualFields
+	// InvalidMutuallyExclusive is the error name for mutually exclusive
+	// fields that are set together.
+	InvalidMutuallyExclusive = pkg.InvalidMutuallyExclusive
+	// MissingRequiredOneOf is the error name for groups of fields of which
+	// none is set but at least one is required.
+	MissingRequiredOneOf = pkg.MissingRequiredOneOf
 )
 
 // Error describes a method error return value. The description includes a
@@ -61,6 +72,8 @@ const (
 // Error must appear in the Service (to define error responses that apply to all
 // the service methods) or Method expressions. Error may also appear under the API
 // expression to create reusable error definitions.
+// Errors defined in the API expression with Inherited apply to all the methods
+// of all the services.
 //
 // See Attribute for details on the Error arguments.
 //
@@ -112,6 +125,35 @@ func Error(name string, args ...interface{}) {
 	}
 }
 
+// Inherited makes an error defined in the API expression apply to all the
+// methods of all the services. The HTTP and gRPC responses defined for the
+// error in the API expression apply to all the methods as well. Methods and
+// services may override the error by defining an error with the same name.
+//
+// Inherited must appear in a Error expression.
+//
+// Inherited takes no argument.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Error("internal_error", ErrorResult, func() {
+//            Inherited()
+//            Fault()
+//        })
+//        HTTP(func() {
+//            Response("internal_error", StatusInternalServerError)
+//        })
+//    })
+func Inherited() {
+	attr, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	attr.AddMeta("goa:error:inherited")
+}
+
 // ErrorName identifies the attribute of a custom error type used to select the
 // returned error response when multiple errors of that type are defined on the
 // same method. The value of the field identifies the error name as defined in
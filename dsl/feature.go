@@ -0,0 +1,46 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// FeatureGate makes the method available only when the feature flag with the
+// given name is enabled at runtime. The generated endpoints define a
+// UseFeatureChecker method that wraps the endpoints of the gated methods with a
+// middleware that checks the flag with the given goa.FeatureChecker before
+// calling the service. Requests made to a disabled method fail with the method
+// "feature_disabled" error if the method defines one, or with a temporary
+// service error named "feature_disabled" otherwise (HTTP status 503 Service
+// Unavailable). Define the error and map it to StatusNotFound to make disabled
+// methods look like they do not exist. The methods are enabled when no checker
+// is set.
+//
+// The gated methods can be hidden from the generated OpenAPI specifications by
+// running "goa gen" with the --openapi-hide-features flag listing the disabled
+// features.
+//
+// FeatureGate must appear in a Method expression.
+//
+// FeatureGate accepts a single argument which is the name of the feature flag.
+//
+// Example:
+//
+//	Method("checkout", func() {
+//	    Payload(Cart)
+//	    Result(Order)
+//	    Error("feature_disabled")
+//	    FeatureGate("new_checkout")
+//	    HTTP(func() {
+//	        POST("/checkout")
+//	        Response("feature_disabled", StatusNotFound)
+//	    })
+//	})
+func FeatureGate(name string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.FeatureGate = &expr.FeatureGateExpr{Name: name, Method: m}
+}
@@ -0,0 +1,39 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// FieldOrder sets the order of the fields of the generated struct and thus the
+// order of the fields in the encoded JSON objects. The listed fields come first
+// in the given order, the other fields follow in the order they are defined.
+// This is useful for legacy consumers that depend on the order of the JSON
+// fields.
+//
+// FieldOrder must appear in a Type or ResultType expression.
+//
+// FieldOrder accepts the names of the fields in order. It is an error to list
+// a field that the type does not define, including through Extend.
+//
+// Example:
+//
+//	var Account = Type("Account", func() {
+//	    Attribute("name", String)
+//	    Attribute("id", String)
+//	    Attribute("created_at", String)
+//	    FieldOrder("id", "name") // id, name, created_at
+//	})
+func FieldOrder(names ...string) {
+	var att *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.ResultTypeExpr:
+		att = def.AttributeExpr
+	case *expr.AttributeExpr:
+		att = def
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	att.FieldOrder = &expr.FieldOrderExpr{Fields: names}
+}
@@ -21,6 +21,10 @@ import (
 //
 // Headers accepts one argument which is a function listing the headers.
 //
+// When used in a CORS expression Headers adds the given header name to the
+// list of request headers allowed by the policy. Headers may be called
+// multiple times in a CORS expression to allow more than one header.
+//
 // Example:
 //
 //     // HTTP headers
@@ -59,6 +63,15 @@ import (
 //     })
 //
 func Headers(args interface{}) {
+	if cors, ok := eval.Current().(*expr.HTTPCORSExpr); ok {
+		name, ok := args.(string)
+		if !ok {
+			eval.InvalidArgError("string", args)
+			return
+		}
+		cors.Headers = append(cors.Headers, name)
+		return
+	}
 	fn, ok := args.(func())
 	if !ok {
 		eval.InvalidArgError("function", args)
@@ -230,7 +230,9 @@ func Produces(args ...string) {
 // As a special case, if you want to generate a path with a trailing slash, you can use
 // GET("/./") to generate a path such as '/foo/'.
 //
-// Path must appear in a API HTTP expression or a Service HTTP expression.
+// Path must appear in a API HTTP expression or a Service HTTP expression. Path
+// may also appear in a WebSocket expression to define the path used to upgrade
+// the connection.
 //
 // Path accepts one argument: the HTTP path prefix.
 func Path(val string) {
@@ -254,6 +256,8 @@ func Path(val string) {
 			}
 		}
 		def.Paths = append(def.Paths, val)
+	case *expr.HTTPWebSocketExpr:
+		def.Path = val
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -269,6 +273,12 @@ func Path(val string) {
 // A wildcard that starts with '{*' matches the rest of the path. Such wildcards
 // must terminate the path.
 //
+// A wildcard may define a regular expression after its name separated with a
+// colon, e.g. '{id:[0-9]+}'. The generated server responds with 404 Not Found
+// to requests whose path segment does not match the entire regular expression.
+// The regular expression is also added to the validations of the
+// corresponding string path parameter.
+//
 // GET must appear in a method HTTP function.
 //
 // GET accepts one argument which is the request path.
@@ -282,6 +292,7 @@ func Path(val string) {
 //             HTTP(func() {
 //                 GET("/{accountID}/details")
 //                 GET("/{*accountPath}")
+//                 GET("/by-number/{number:[0-9]+}")
 //             })
 //         })
 //     })
@@ -331,6 +342,11 @@ func PATCH(path string) *expr.RouteExpr {
 
 func route(method, path string) *expr.RouteExpr {
 	r := &expr.RouteExpr{Method: method, Path: path}
+	if p, patterns, err := expr.ParseHTTPPathPatterns(path); err != nil {
+		eval.ReportError(err.Error())
+	} else {
+		r.Path, r.Patterns = p, patterns
+	}
 	a, ok := eval.Current().(*expr.HTTPEndpointExpr)
 	if !ok {
 		eval.IncompatibleDSL()
@@ -351,8 +367,11 @@ func route(method, path string) *expr.RouteExpr {
 // expression (to define request headers) or a Response expression (to define
 // the response headers). Header may also appear in a method GRPC expression (to
 // define headers sent in message metadata), or in a Response expression (to
-// define headers sent in result metadata). Finally Header may also appear in a
-// Headers expression.
+// define headers sent in result metadata). Header may also appear in a Headers
+// expression. Finally Header may appear in an Idempotent expression to set the
+// name of the header carrying the idempotency key or in a WebhookSignature
+// expression to set the name of the header carrying the signature, in which
+// case it accepts only the header name.
 //
 // Header accepts the same arguments as the Attribute function. The header name
 // may define a mapping between the attribute name and the HTTP header name when
@@ -377,6 +396,22 @@ func route(method, path string) *expr.RouteExpr {
 //    })
 //
 func Header(name string, args ...interface{}) {
+	if idem, ok := eval.Current().(*expr.HTTPIdempotencyExpr); ok {
+		if len(args) > 0 {
+			eval.ReportError("too many arguments given to Header in Idempotent")
+			return
+		}
+		idem.Header = name
+		return
+	}
+	if sig, ok := eval.Current().(*expr.HTTPWebhookSignatureExpr); ok {
+		if len(args) > 0 {
+			eval.ReportError("too many arguments given to Header in WebhookSignature")
+			return
+		}
+		sig.Header = name
+		return
+	}
 	h := headers(eval.Current())
 	if h == nil {
 		eval.IncompatibleDSL()
@@ -684,6 +719,177 @@ func Param(name string, args ...interface{}) {
 	p.Remap()
 }
 
+// Style sets the serialization style of a HTTP path or query string parameter
+// as defined by the OpenAPI specification. The styles supported by path
+// parameters are "simple" (the default, e.g. "/users/5"), "matrix" (e.g.
+// "/users/;id=5") and "label" (e.g. "/users/.5"). The elements of array path
+// parameters are separated with commas unless the parameter uses Explode.
+//
+// The only style supported by query string parameters is "deepObject" which
+// serializes the properties of an object parameter as separate query string
+// values, e.g. "?filter[name]=x&filter[age]=5". The properties must be
+// primitives or arrays of primitives, nested objects are not supported.
+//
+// Style must appear in a Param expression.
+//
+// Example:
+//
+//	Method("show", func() {
+//	    Payload(func() {
+//	        Attribute("id", Int)
+//	    })
+//	    HTTP(func() {
+//	        GET("/users/{id}")
+//	        Param("id", func() {
+//	            Style("matrix") // GET /users/;id=5
+//	        })
+//	    })
+//	})
+//
+//	Method("list", func() {
+//	    Payload(func() {
+//	        Attribute("filter", Filter)
+//	    })
+//	    HTTP(func() {
+//	        GET("/users")
+//	        Param("filter", func() {
+//	            Style("deepObject") // GET /users?filter[name]=x
+//	        })
+//	    })
+//	})
+func Style(style string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("http:param:style", style)
+}
+
+// Explode indicates that the elements of an array path parameter that uses the
+// "matrix" or "label" style are serialized separately, e.g. ";id=3;id=4" or
+// ".3.4" rather than ";id=3,4" or ".3,4".
+//
+// The elements of array query string parameters are serialized separately by
+// default, e.g. "?ids=3&ids=4". Explode(false) serializes them in a single
+// comma separated value instead, e.g. "?ids=3,4". See Delimiter to use a
+// different separator.
+//
+// Explode must appear in a Param expression of an array parameter. Explode
+// accepts an optional boolean argument which defaults to true.
+//
+// Example:
+//
+//	Param("ids", ArrayOf(Int), func() {
+//	    Style("matrix")
+//	    Explode() // GET /users/;ids=3;ids=4
+//	})
+//
+//	Param("tags", ArrayOf(String), func() {
+//	    Explode(false) // GET /users?tags=a,b
+//	})
+func Explode(explode ...bool) {
+	if len(explode) > 1 {
+		eval.ReportError("too many arguments given to Explode")
+		return
+	}
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta != nil {
+		delete(a.Meta, "http:param:explode")
+	}
+	if len(explode) == 1 && !explode[0] {
+		a.AddMeta("http:param:explode", "false")
+		return
+	}
+	a.AddMeta("http:param:explode")
+}
+
+// Delimiter sets the separator of the elements of an array query string
+// parameter serialized in a single value, e.g. "?ids=3|4" rather than
+// "?ids=3&ids=4". The generated client joins the elements with the separator
+// and the generated server splits the parameter value. The OpenAPI
+// specifications use the "pipeDelimited" and "spaceDelimited" styles for the
+// "|" and " " separators, the "form" style otherwise with the "x-delimiter"
+// extension for separators other than a comma.
+//
+// Delimiter must appear in a Param expression of an array query string
+// parameter whose elements are primitives. Delimiter implies Explode(false).
+//
+// Delimiter accepts one argument: the separator.
+//
+// Example:
+//
+//	Param("ids", ArrayOf(Int), func() {
+//	    Explode(false)
+//	    Delimiter("|") // GET /users?ids=3|4
+//	})
+func Delimiter(sep string) {
+	a, ok := paramAttribute()
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if sep == "" {
+		eval.ReportError("delimiter cannot be empty")
+		return
+	}
+	if strings.ContainsAny(sep, "&=#") {
+		eval.ReportError("invalid delimiter %q, delimiter cannot contain '&', '=' or '#'", sep)
+		return
+	}
+	if a.Meta != nil {
+		delete(a.Meta, "http:param:delimiter")
+	}
+	a.AddMeta("http:param:delimiter", sep)
+}
+
+// AllowEmptyValue indicates that the query string parameter may be given with
+// an empty value, e.g. "?q=". The generated server code accepts empty values
+// for required string parameters that allow them and the generated OpenAPI
+// specifications set the parameter "allowEmptyValue" flag.
+//
+// AllowEmptyValue must appear in a Param expression of a query string
+// parameter.
+//
+// Example:
+//
+//	Param("q", String, func() {
+//	    AllowEmptyValue() // GET /search?q=
+//	})
+func AllowEmptyValue() {
+	a, ok := paramAttribute()
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("http:param:allow-empty-value")
+}
+
+// AllowReserved indicates that the value of the query string parameter may
+// contain the characters reserved by RFC 3986 (e.g. "/" or "?") without
+// percent-encoding. The generated OpenAPI specifications set the parameter
+// "allowReserved" flag.
+//
+// AllowReserved must appear in a Param expression of a query string parameter.
+//
+// Example:
+//
+//	Param("path", String, func() {
+//	    AllowReserved() // GET /files?path=/a/b
+//	})
+func AllowReserved() {
+	a, ok := paramAttribute()
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("http:param:allow-reserved")
+}
+
 // MapParams describes the query string parameters in a HTTP request.
 //
 // MapParams must appear in a Method HTTP expression to map the query string
@@ -851,6 +1057,9 @@ func SkipResponseBodyEncodeDecode() {
 //    properties (description, type, validations etc.) of the request or
 //    response type attributes with identical names.
 //
+// The function may also use ContentType to set the media type of the body. The
+// body consists of the default attributes if the function does not list any.
+//
 // Assuming the type:
 //
 //     var CreatePayload = Type("CreatePayload", func() {
@@ -977,6 +1186,11 @@ func Body(args ...interface{}) {
 	if fn != nil {
 		eval.Execute(fn, attr)
 	}
+	if attr.Type == nil {
+		// The DSL does not list any attribute (e.g. it only sets the
+		// body content type), use the default body.
+		return
+	}
 	attr.AddMeta("http:body")
 	setter(attr)
 }
@@ -1058,15 +1272,50 @@ func Tag(name, value string) {
 	res.Tag = [2]string{name, value}
 }
 
-// ContentType sets the value of the Content-Type response header.
+// ContentType sets the value of the Content-Type response header or the media
+// type of the request body.
 //
-// ContentType must appear in a Response expression.
+// ContentType must appear in a Response expression or in the Body expression
+// of a request or response.
 // ContentType accepts one argument: the mime type as defined by RFC 6838.
 //
+// ContentType may be called multiple times in the same Response expression to
+// list all the media types the response may be encoded with. In this case the
+// generated encoder selects the media type using the request Accept header and
+// responds with 406 Not Acceptable if none of the media types is acceptable.
+// The first media type is used when the request does not specify an Accept
+// header. The method errors are encoded with the media type selected the same
+// way among all the media types listed by the method responses, the first one
+// is used if none is acceptable. Error responses that set their own
+// ContentType are not affected.
+//
 //    var _ = Method("add", func() {
 //	      HTTP(func() {
 //            Response(StatusOK, func() {
 //                ContentType("application/json")
+//                ContentType("application/xml")
+//            })
+//        })
+//    })
+//
+// When used in a request Body expression ContentType sets the Content-Type
+// header of the requests sent by the generated client. The generated server
+// decodes the request bodies according to their Content-Type header. Bodies
+// with the "application/x-www-form-urlencoded" media type are encoded as HTML
+// forms: the body must be an object whose attributes are primitives or arrays
+// of primitives, array elements are encoded using repeated keys. Nested objects
+// and maps cannot be encoded in forms and are rejected.
+//
+//    var _ = Method("login", func() {
+//        Payload(func() {
+//            Attribute("username", String)
+//            Attribute("password", String)
+//            Attribute("scopes", ArrayOf(String))
+//        })
+//        HTTP(func() {
+//            POST("/login")
+//            Body(func() {
+//                ContentType("application/x-www-form-urlencoded")
 //            })
 //        })
 //    })
@@ -1076,12 +1325,35 @@ func ContentType(typ string) {
 	case *expr.ResultTypeExpr:
 		actual.ContentType = typ // deprecated
 	case *expr.HTTPResponseExpr:
-		actual.ContentType = typ
+		setResponseContentType(actual, typ)
+	case *expr.AttributeExpr:
+		// ContentType is used in a Body expression, the parent
+		// expression is the endpoint or the response.
+		var parent eval.Expression
+		if s := eval.Context.Stack; len(s) > 1 {
+			parent = s[len(s)-2]
+		}
+		switch p := parent.(type) {
+		case *expr.HTTPEndpointExpr:
+			p.RequestContentType = typ
+		case *expr.HTTPResponseExpr:
+			setResponseContentType(p, typ)
+		default:
+			eval.IncompatibleDSL()
+		}
 	default:
 		eval.IncompatibleDSL()
 	}
 }
 
+// setResponseContentType adds typ to the media types of the response.
+func setResponseContentType(r *expr.HTTPResponseExpr, typ string) {
+	if r.ContentType == "" {
+		r.ContentType = typ
+	}
+	r.ContentTypes = append(r.ContentTypes, typ)
+}
+
 // headers returns the mapped attribute containing the headers for the given
 // expression if it's either the root, a service or an endpoint - nil otherwise.
 func headers(exp eval.Expression) *expr.MappedAttributeExpr {
@@ -1144,6 +1416,37 @@ func cookies(exp eval.Expression) *expr.MappedAttributeExpr {
 	}
 }
 
+// paramAttribute returns the attribute of the parameter whose Param DSL is
+// being executed and true, nil and false if the current expression is not a
+// parameter.
+func paramAttribute() (*expr.AttributeExpr, bool) {
+	s := eval.Context.Stack
+	if len(s) < 3 {
+		return nil, false
+	}
+	a, ok := s[len(s)-1].(*expr.AttributeExpr)
+	if !ok {
+		return nil, false
+	}
+	// Param executes the DSL of the parameter in the context of the
+	// attribute of the params of the parent expression.
+	var p *expr.MappedAttributeExpr
+	switch e := s[len(s)-3].(type) {
+	case *expr.RootExpr:
+		p = e.API.HTTP.Params
+	case *expr.HTTPServiceExpr:
+		p = e.Params
+	case *expr.HTTPEndpointExpr:
+		p = e.Params
+	case *expr.MappedAttributeExpr:
+		p = e
+	}
+	if p == nil || s[len(s)-2] != p.AttributeExpr {
+		return nil, false
+	}
+	return a, true
+}
+
 // params returns the mapped attribute containing the path and query params for
 // the given expression if it's either the root, a API server, a service or an
 // endpoint - nil otherwise.
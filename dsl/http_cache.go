@@ -0,0 +1,185 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Cache defines the caching directives of the method HTTP responses. The
+// generated HTTP server sets the Cache-Control response header of successful
+// responses accordingly and the Vary header if the responses vary by request
+// headers. The responses of methods that require authentication are private
+// unless Public is used explicitly.
+//
+// Cache must appear in a Method expression or in a method HTTP expression.
+//
+// Cache accepts a function that may use MaxAge, Public, Private, NoCache,
+// NoStore, MustRevalidate and Vary to define the directives.
+//
+// Example:
+//
+//	Method("show", func() {
+//	    Payload(String)
+//	    Result(Document)
+//	    Cache(func() {
+//	        MaxAge("300s")
+//	        Public()
+//	        Vary("Accept-Language")
+//	    })
+//	    HTTP(func() {
+//	        GET("/documents/{id}")
+//	    })
+//	})
+func Cache(fn func()) {
+	var e *expr.HTTPEndpointExpr
+	switch actual := eval.Current().(type) {
+	case *expr.MethodExpr:
+		e = expr.Root.API.HTTP.ServiceFor(actual.Service).EndpointFor(actual.Name, actual)
+	case *expr.HTTPEndpointExpr:
+		e = actual
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	c := &expr.HTTPCacheExpr{Endpoint: e}
+	if !eval.Execute(fn, c) {
+		return
+	}
+	e.Cache = c
+}
+
+// Public indicates that the responses may be stored by shared caches such as
+// proxies, including the responses of methods that require authentication.
+// Public cannot be combined with Private or NoStore.
+//
+// Public must appear in a Cache expression.
+//
+// Example:
+//
+//	Cache(func() {
+//	    MaxAge("1h")
+//	    Public()
+//	})
+func Public() {
+	c, ok := eval.Current().(*expr.HTTPCacheExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.Public = true
+}
+
+// Private indicates that the responses may only be stored by the client cache.
+//
+// Private must appear in a Cache expression.
+//
+// Example:
+//
+//	Cache(func() {
+//	    MaxAge("60s")
+//	    Private()
+//	})
+func Private() {
+	c, ok := eval.Current().(*expr.HTTPCacheExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.Private = true
+}
+
+// NoCache indicates that caches must revalidate the responses with the server
+// before using them.
+//
+// NoCache must appear in a Cache expression.
+//
+// Example:
+//
+//	Cache(func() {
+//	    NoCache()
+//	})
+func NoCache() {
+	c, ok := eval.Current().(*expr.HTTPCacheExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.NoCache = true
+}
+
+// NoStore indicates that the responses must not be stored by any cache.
+// NoStore cannot be combined with Public or MaxAge.
+//
+// NoStore must appear in a Cache expression.
+//
+// Example:
+//
+//	Cache(func() {
+//	    NoStore()
+//	})
+func NoStore() {
+	c, ok := eval.Current().(*expr.HTTPCacheExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.NoStore = true
+}
+
+// MustRevalidate indicates that caches must not use stale responses without
+// revalidating them with the server first.
+//
+// MustRevalidate must appear in a Cache expression.
+//
+// Example:
+//
+//	Cache(func() {
+//	    MaxAge("5m")
+//	    MustRevalidate()
+//	})
+func MustRevalidate() {
+	c, ok := eval.Current().(*expr.HTTPCacheExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.MustRevalidate = true
+}
+
+// Vary lists the request headers that the responses vary by, for example the
+// Authorization header for responses that depend on the authenticated user.
+//
+// Vary must appear in a Cache expression.
+//
+// Example:
+//
+//	Cache(func() {
+//	    Private()
+//	    Vary("Authorization", "Accept-Language")
+//	})
+func Vary(headers ...string) {
+	c, ok := eval.Current().(*expr.HTTPCacheExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c.Vary = append(c.Vary, headers...)
+}
+
+// cacheMaxAge sets the max-age directive of the cache expression. val must be
+// a duration string as accepted by time.ParseDuration, for example "300s".
+func cacheMaxAge(c *expr.HTTPCacheExpr, val interface{}) {
+	s, ok := val.(string)
+	if !ok {
+		eval.InvalidArgError("duration string (e.g. \"300s\")", val)
+		return
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		eval.InvalidArgError("duration string (e.g. \"300s\")", val)
+		return
+	}
+	c.MaxAge = &d
+}
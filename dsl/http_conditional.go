@@ -0,0 +1,96 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ConditionalRequests enables the handling of conditional requests by the HTTP
+// endpoint. The generated HTTP server computes an entity tag from the value of
+// a result attribute and sets the ETag response header. GET and HEAD requests
+// whose If-None-Match header matches the entity tag get a 304 Not Modified
+// response and GET and HEAD requests whose If-Match header does not match get a
+// 412 Precondition Failed response. The conditions of other requests are not
+// evaluated by the generated code as the entity tag is only known once the
+// endpoint ran: services that modify resources may call goahttp.CheckIfMatch
+// with the current value of the attribute to reject concurrent updates with a
+// 412 Precondition Failed response.
+//
+// ConditionalRequests must appear in a Method expression or in a method HTTP
+// expression.
+//
+// ConditionalRequests accepts a function that must use ETagFrom to define the
+// result attribute used to compute the entity tags and may use WeakETag to
+// generate weak validators.
+//
+// Example:
+//
+//	Method("show", func() {
+//	    Payload(String)
+//	    Result(Document)
+//	    ConditionalRequests(func() {
+//	        ETagFrom("version")
+//	    })
+//	    HTTP(func() {
+//	        GET("/documents/{id}")
+//	    })
+//	})
+func ConditionalRequests(fn func()) {
+	var e *expr.HTTPEndpointExpr
+	switch actual := eval.Current().(type) {
+	case *expr.MethodExpr:
+		e = expr.Root.API.HTTP.ServiceFor(actual.Service).EndpointFor(actual.Name, actual)
+	case *expr.HTTPEndpointExpr:
+		e = actual
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	cond := &expr.HTTPConditionalExpr{Endpoint: e}
+	if !eval.Execute(fn, cond) {
+		return
+	}
+	e.Conditional = cond
+}
+
+// ETagFrom sets the name of the result attribute whose value is used to
+// compute the entity tags of the responses. The attribute is typically a
+// version number or a last modification timestamp.
+//
+// ETagFrom must appear in a ConditionalRequests expression.
+//
+// Example:
+//
+//	ConditionalRequests(func() {
+//	    ETagFrom("version")
+//	})
+func ETagFrom(name string) {
+	cond, ok := eval.Current().(*expr.HTTPConditionalExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cond.Attribute = name
+}
+
+// WeakETag makes the generated entity tags weak validators ("W/" prefix).
+// Weak entity tags indicate that responses with the same tag are semantically
+// equivalent but not necessarily byte for byte identical. Weak entity tags
+// never match the If-Match request header.
+//
+// WeakETag must appear in a ConditionalRequests expression.
+//
+// Example:
+//
+//	ConditionalRequests(func() {
+//	    ETagFrom("updated_at")
+//	    WeakETag()
+//	})
+func WeakETag() {
+	cond, ok := eval.Current().(*expr.HTTPConditionalExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cond.Weak = true
+}
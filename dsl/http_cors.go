@@ -0,0 +1,209 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// CORS defines the Cross-Origin Resource Sharing policy of the HTTP endpoints.
+// The generated HTTP server applies the policy response headers to the
+// endpoint responses and handles the preflight OPTIONS requests.
+//
+// CORS must appear in an API or Service expression or in their HTTP
+// expressions. A policy defined on a service overrides the API policy for all
+// the service endpoints.
+//
+// CORS accepts one argument: a function listing the policy properties using
+// Origin, Methods, Headers, Expose, MaxAge and Credentials.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    CORS(func() {
+//	        Origin("https://app.example.com")
+//	        Methods("GET", "POST")
+//	        Headers("Authorization")
+//	        MaxAge(600)
+//	    })
+//	})
+//
+//	var _ = Service("admin", func() {
+//	    CORS(func() {
+//	        Origin("https://*.admin.example.com")
+//	        Credentials()
+//	    })
+//	})
+func CORS(fn func()) {
+	cors := &expr.HTTPCORSExpr{}
+	switch actual := eval.Current().(type) {
+	case *expr.APIExpr:
+		cors.Parent = actual
+		if eval.Execute(fn, cors) {
+			expr.Root.API.HTTP.CORS = cors
+		}
+	case *expr.RootExpr:
+		cors.Parent = actual.API
+		if eval.Execute(fn, cors) {
+			expr.Root.API.HTTP.CORS = cors
+		}
+	case *expr.ServiceExpr:
+		cors.Parent = actual
+		if eval.Execute(fn, cors) {
+			expr.Root.API.HTTP.ServiceFor(actual).CORS = cors
+		}
+	case *expr.HTTPServiceExpr:
+		cors.Parent = actual.ServiceExpr
+		if eval.Execute(fn, cors) {
+			actual.CORS = cors
+		}
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Origin adds origins to the list of origins allowed by a CORS policy. An
+// origin may be "*" to allow any origin or may use a wildcard in place of a
+// sub-domain, for example "https://*.example.com".
+//
+// Origin must appear in a CORS expression.
+//
+// Origin accepts one or more strings corresponding to the allowed origins.
+//
+// Example:
+//
+//	CORS(func() {
+//	    Origin("https://app.example.com", "https://*.example.com")
+//	})
+func Origin(origins ...string) {
+	cors, ok := eval.Current().(*expr.HTTPCORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Origins = append(cors.Origins, origins...)
+}
+
+// Methods adds HTTP methods to the list of methods allowed by a CORS policy.
+//
+// Methods must appear in a CORS expression.
+//
+// Methods accepts one or more strings corresponding to the HTTP methods.
+//
+// Example:
+//
+//	CORS(func() {
+//	    Origin("https://app.example.com")
+//	    Methods("GET", "POST")
+//	})
+func Methods(methods ...string) {
+	cors, ok := eval.Current().(*expr.HTTPCORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	for _, m := range methods {
+		cors.Methods = append(cors.Methods, strings.ToUpper(m))
+	}
+}
+
+// Expose adds HTTP response headers to the list of headers exposed to clients
+// by a CORS policy.
+//
+// Expose must appear in a CORS expression.
+//
+// Expose accepts one or more strings corresponding to the header names.
+//
+// Example:
+//
+//	CORS(func() {
+//	    Origin("https://app.example.com")
+//	    Expose("X-Request-Id", "X-Time")
+//	})
+func Expose(headers ...string) {
+	cors, ok := eval.Current().(*expr.HTTPCORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Exposed = append(cors.Exposed, headers...)
+}
+
+// MaxAge sets the duration during which responses may be cached.
+//
+// MaxAge must appear in a CORS or a Cache expression.
+//
+// In a CORS expression MaxAge sets the number of seconds clients may cache the
+// response to preflight requests.
+//
+// In a Cache expression MaxAge sets the max-age directive of the responses and
+// accepts a duration string as accepted by time.ParseDuration, for example
+// "300s" or "1h".
+//
+// Example:
+//
+//	CORS(func() {
+//	    Origin("https://app.example.com")
+//	    MaxAge(600)
+//	})
+//
+//	Cache(func() {
+//	    MaxAge("300s")
+//	})
+func MaxAge(val interface{}) {
+	switch actual := eval.Current().(type) {
+	case *expr.HTTPCORSExpr:
+		seconds, ok := toUint(val)
+		if !ok {
+			eval.InvalidArgError("number of seconds", val)
+			return
+		}
+		actual.MaxAge = seconds
+	case *expr.HTTPCacheExpr:
+		cacheMaxAge(actual, val)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// toUint converts the given integer value to a uint. It returns false if val
+// is not an integer or is negative.
+func toUint(val interface{}) (uint, bool) {
+	switch v := val.(type) {
+	case int:
+		return uint(v), v >= 0
+	case int32:
+		return uint(v), v >= 0
+	case int64:
+		return uint(v), v >= 0
+	case uint:
+		return v, true
+	case uint32:
+		return uint(v), true
+	case uint64:
+		return uint(v), true
+	}
+	return 0, false
+}
+
+// Credentials indicates that the CORS policy allows credentials (cookies,
+// authorization headers or TLS client certificates) to be sent with
+// cross-origin requests. Credentials cannot be combined with the "*" origin.
+//
+// Credentials must appear in a CORS expression.
+//
+// Example:
+//
+//	CORS(func() {
+//	    Origin("https://app.example.com")
+//	    Credentials()
+//	})
+func Credentials() {
+	cors, ok := eval.Current().(*expr.HTTPCORSExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	cors.Credentials = true
+}
@@ -0,0 +1,135 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Idempotent declares whether the method is idempotent or makes the HTTP
+// endpoint safe to retry with idempotency keys.
+//
+// Idempotent(true) and Idempotent(false) declare explicitly whether sending the
+// same request multiple times has the same effect as sending it once. By
+// default the idempotency of a HTTP endpoint is inferred from its HTTP method:
+// GET, HEAD, OPTIONS, TRACE, PUT and DELETE endpoints are idempotent. The
+// generated HTTP client records explicit declarations in the request context so
+// that retry middlewares may use goahttp.IsIdempotent to decide whether a
+// request may be retried. The OpenAPI specifications document explicit
+// declarations with the "x-idempotent" operation extension.
+//
+// Idempotent() and Idempotent(func() {...}) make the HTTP endpoint handle
+// idempotency keys: requests that carry the same idempotency key header replay
+// the response (status, headers and body) recorded for the first request
+// instead of calling the endpoint again. The generated HTTP server requires an
+// implementation of the goahttp.IdempotencyStore interface to record the
+// responses. Only the 2xx and 4xx responses are recorded. Concurrent requests
+// using a key that is still being processed are rejected with a 409 Conflict
+// response and requests reusing a key with a different payload are rejected
+// with a 422 Unprocessable Entity response. Endpoints that handle idempotency
+// keys are idempotent.
+//
+// Idempotent must appear in a Method expression or in a method HTTP
+// expression. Methods that handle idempotency keys must define an HTTP
+// endpoint.
+//
+// Idempotent accepts an optional boolean or an optional function. The function
+// may use Header to override the name of the header carrying the key
+// ("Idempotency-Key" by default) and TTL to override the duration during which
+// responses are replayed (24 hours by default).
+//
+// Example:
+//
+//	Method("pay", func() {
+//	    Payload(Payment)
+//	    Result(Receipt)
+//	    Idempotent(func() {
+//	        Header("Idempotency-Key")
+//	        TTL("24h")
+//	    })
+//	    HTTP(func() {
+//	        POST("/payments")
+//	    })
+//	})
+//
+//	Method("search", func() {
+//	    Payload(Query)
+//	    Result(CollectionOf(Item))
+//	    Idempotent(true)
+//	    HTTP(func() {
+//	        POST("/search")
+//	    })
+//	})
+func Idempotent(args ...interface{}) {
+	if len(args) > 1 {
+		eval.ReportError("too many arguments given to Idempotent")
+		return
+	}
+	var fn func()
+	if len(args) == 1 {
+		switch a := args[0].(type) {
+		case bool:
+			var m *expr.MethodExpr
+			switch actual := eval.Current().(type) {
+			case *expr.MethodExpr:
+				m = actual
+			case *expr.HTTPEndpointExpr:
+				m = actual.MethodExpr
+			default:
+				eval.IncompatibleDSL()
+				return
+			}
+			m.Idempotent = &a
+			return
+		case func():
+			fn = a
+		default:
+			eval.InvalidArgError("boolean or function", a)
+			return
+		}
+	}
+	idem := &expr.HTTPIdempotencyExpr{
+		Header: expr.DefaultIdempotencyHeader,
+		TTL:    expr.DefaultIdempotencyTTL,
+	}
+	switch actual := eval.Current().(type) {
+	case *expr.MethodExpr:
+		if fn != nil && !eval.Execute(fn, idem) {
+			return
+		}
+		actual.Idempotency = idem
+	case *expr.HTTPEndpointExpr:
+		idem.Endpoint = actual
+		if fn != nil && !eval.Execute(fn, idem) {
+			return
+		}
+		actual.Idempotency = idem
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// TTL sets the duration during which the response to an idempotent request is
+// replayed. The duration uses the format accepted by time.ParseDuration.
+//
+// TTL must appear in an Idempotent expression.
+//
+// Example:
+//
+//	Idempotent(func() {
+//	    TTL("1h30m")
+//	})
+func TTL(d string) {
+	idem, ok := eval.Current().(*expr.HTTPIdempotencyExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ttl, err := time.ParseDuration(d)
+	if err != nil {
+		eval.InvalidArgError("duration", d)
+		return
+	}
+	idem.TTL = ttl
+}
@@ -0,0 +1,55 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// MethodOverride enables the overriding of the method of HTTP POST requests
+// with the X-HTTP-Method-Override header. Clients behind proxies that do not
+// support methods such as PATCH may send a POST request with the header set to
+// PATCH to call the PATCH endpoints. The generated HTTP server packages define
+// a MethodOverrideMiddleware function returning the middleware that overrides
+// the method. The middleware must wrap the muxer so that the request method is
+// overridden before the request is routed, the generated example server does
+// so.
+//
+// MethodOverride must appear in an API expression or in its HTTP expression.
+//
+// MethodOverride accepts the list of methods that may override POST. The only
+// allowed methods are PUT, PATCH and DELETE which are also the default.
+//
+// Example:
+//
+//	var _ = API("calc", func() {
+//	    HTTP(func() {
+//	        MethodOverride("PATCH")
+//	    })
+//	})
+func MethodOverride(methods ...string) {
+	switch eval.Current().(type) {
+	case *expr.APIExpr, *expr.RootExpr:
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(methods) == 0 {
+		methods = []string{"PUT", "PATCH", "DELETE"}
+	}
+	h := expr.Root.API.HTTP
+	for _, m := range methods {
+		m = strings.ToUpper(m)
+		found := false
+		for _, o := range h.MethodOverrides {
+			if o == m {
+				found = true
+				break
+			}
+		}
+		if !found {
+			h.MethodOverrides = append(h.MethodOverrides, m)
+		}
+	}
+}
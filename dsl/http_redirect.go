@@ -47,3 +47,66 @@ func Redirect(url string, code int) {
 		eval.IncompatibleDSL()
 	}
 }
+
+// RedirectFrom indicates that HTTP requests reply to the request with a
+// redirect to the URL returned by the method. The generated handler calls the
+// method and replies with a response that sets the Location header to the
+// value of the result attribute and has no body, for example to redirect to a
+// pre-signed storage URL instead of streaming a file. The response encoder
+// returns an error if the attribute is empty.
+//
+// RedirectFrom must appear in a HTTP endpoint expression.
+//
+// RedirectFrom accepts one argument: a function which uses LocationFrom to set
+// the name of the method result attribute holding the URL and optionally Code
+// to set the HTTP status code (302 Found by default).
+//
+// Example:
+//
+//    var _ = Service("storage", func() {
+//        Method("download", func() {
+//            Payload(String)
+//            Result(func() {
+//                Attribute("download_url", String)
+//                Required("download_url")
+//            })
+//            HTTP(func() {
+//                GET("/files/{name}")
+//                RedirectFrom(func() {
+//                    LocationFrom("download_url")
+//                    Code(StatusTemporaryRedirect)
+//                })
+//            })
+//        })
+//    })
+//
+func RedirectFrom(fn func()) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	redirect := &expr.HTTPRedirectExpr{
+		StatusCode: expr.StatusFound,
+		Parent:     e,
+	}
+	if !eval.Execute(fn, redirect) {
+		return
+	}
+	e.Redirect = redirect
+}
+
+// LocationFrom sets the name of the method result attribute holding the URL
+// the requests are redirected to. The attribute must be a string.
+//
+// LocationFrom must appear in a RedirectFrom expression.
+//
+// LocationFrom accepts one argument: the name of the result attribute.
+func LocationFrom(name string) {
+	r, ok := eval.Current().(*expr.HTTPRedirectExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.LocationFrom = name
+}
@@ -0,0 +1,116 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// SSE makes a streaming endpoint send its results as server-sent events
+// instead of using a WebSocket connection. The generated server writes each
+// result as an event whose "data" field holds the JSON encoding of the result
+// and the generated client reads the events back into the streaming result
+// type.
+//
+// SSE must appear in a method HTTP expression of a method that defines a
+// StreamingResult and no StreamingPayload.
+//
+// SSE accepts a function that uses Event to define the names of the events
+// and optionally EventID to set the attribute of the result used as the event
+// ID. If a single event is defined then all the results are sent using that
+// event name. If multiple events are defined then the streaming result must be
+// an object with one attribute per event: the server sends the first
+// attribute that is set using the attribute name as event name and the client
+// sets the attribute corresponding to the name of the events it receives.
+//
+// Clients that reconnect send the ID of the last event they received in the
+// Last-Event-ID header which may be mapped to a payload attribute to resume
+// the stream.
+//
+// Example:
+//
+//	Method("notifications", func() {
+//	    Payload(func() {
+//	        Attribute("last_event_id", String)
+//	    })
+//	    StreamingResult(Notification)
+//	    HTTP(func() {
+//	        GET("/notifications")
+//	        Header("last_event_id:Last-Event-ID")
+//	        SSE(func() {
+//	            Event("message", ChatMessage)
+//	            Event("error", Failure)
+//	            EventID("id")
+//	        })
+//	    })
+//	})
+func SSE(fn func()) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	sse := &expr.HTTPSSEExpr{Endpoint: e}
+	if !eval.Execute(fn, sse) {
+		return
+	}
+	e.SSE = sse
+}
+
+// Event defines a server-sent event. The type of the event data defaults to
+// the streaming result type if the SSE expression defines a single event and
+// to the type of the streaming result attribute with the same name otherwise.
+//
+// Event must appear in a SSE expression.
+//
+// Event accepts one or two arguments: the event name and optionally the type
+// of the event data.
+//
+// Example:
+//
+//	SSE(func() {
+//	    Event("message", ChatMessage)
+//	    Event("error", Failure)
+//	})
+func Event(name string, args ...interface{}) {
+	sse, ok := eval.Current().(*expr.HTTPSSEExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(args) > 1 {
+		eval.ReportError("too many arguments given to Event")
+		return
+	}
+	ev := &expr.SSEEventExpr{Name: name}
+	if len(args) == 1 {
+		dt, ok := args[0].(expr.DataType)
+		if !ok {
+			eval.InvalidArgError("type", args[0])
+			return
+		}
+		ev.Type = dt
+	}
+	sse.Events = append(sse.Events, ev)
+}
+
+// EventID sets the name of the streaming result attribute whose value is used
+// as the ID of the server-sent events. The attribute must be a string.
+// Browsers and other clients that reconnect use the ID of the last event they
+// received to set the Last-Event-ID request header.
+//
+// EventID must appear in a SSE expression.
+//
+// Example:
+//
+//	SSE(func() {
+//	    Event("message")
+//	    EventID("id")
+//	})
+func EventID(name string) {
+	sse, ok := eval.Current().(*expr.HTTPSSEExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	sse.IDField = name
+}
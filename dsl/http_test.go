@@ -0,0 +1,134 @@
+package dsl_test
+
+import (
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestParamDSL(t *testing.T) {
+	cases := map[string]struct {
+		DSL   func()
+		Error string
+	}{
+		"delimiter-in-param": {
+			DSL: func() {
+				Service("Service", func() {
+					Method("Method", func() {
+						Payload(func() {
+							Attribute("ids", ArrayOf(Int))
+							Attribute("tags", ArrayOf(String))
+						})
+						HTTP(func() {
+							GET("/")
+							Param("ids", func() {
+								Delimiter("|")
+							})
+							Params(func() {
+								Param("tags", func() {
+									Delimiter(" ")
+								})
+							})
+						})
+					})
+				})
+			},
+		},
+		"delimiter-in-attribute": {
+			DSL: func() {
+				Service("Service", func() {
+					Method("Method", func() {
+						Payload(func() {
+							Attribute("ids", ArrayOf(Int), func() {
+								Delimiter("|")
+							})
+						})
+					})
+				})
+			},
+			Error: "invalid use of Delimiter in attribute",
+		},
+		"delimiter-in-header": {
+			DSL: func() {
+				Service("Service", func() {
+					Method("Method", func() {
+						Payload(func() {
+							Attribute("ids", ArrayOf(Int))
+						})
+						HTTP(func() {
+							GET("/")
+							Header("ids", func() {
+								Delimiter("|")
+							})
+						})
+					})
+				})
+			},
+			Error: "invalid use of Delimiter in attribute",
+		},
+		"flags-in-param": {
+			DSL: func() {
+				Service("Service", func() {
+					Method("Method", func() {
+						Payload(func() {
+							Attribute("q", String)
+						})
+						HTTP(func() {
+							GET("/")
+							Param("q", func() {
+								AllowEmptyValue()
+								AllowReserved()
+							})
+						})
+					})
+				})
+			},
+		},
+		"allow-empty-value-in-attribute": {
+			DSL: func() {
+				Service("Service", func() {
+					Method("Method", func() {
+						Payload(func() {
+							Attribute("q", String, func() {
+								AllowEmptyValue()
+							})
+						})
+					})
+				})
+			},
+			Error: "invalid use of AllowEmptyValue in attribute",
+		},
+		"allow-reserved-in-header": {
+			DSL: func() {
+				Service("Service", func() {
+					Method("Method", func() {
+						Payload(func() {
+							Attribute("q", String)
+						})
+						HTTP(func() {
+							GET("/")
+							Header("q", func() {
+								AllowReserved()
+							})
+						})
+					})
+				})
+			},
+			Error: "invalid use of AllowReserved in attribute",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+				return
+			}
+			err := expr.RunInvalidDSL(t, c.DSL)
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+			}
+		})
+	}
+}
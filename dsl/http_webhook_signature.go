@@ -0,0 +1,83 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// WebhookSignature makes the HTTP endpoint verify the HMAC signature of the
+// requests it receives. The signature is computed over the raw request body
+// using a secret shared with the sender and is read from a request header
+// either as a hex encoded string or prefixed with the algorithm name (e.g.
+// "sha256=<hex>"). The generated HTTP server requires the secret and rejects
+// the requests whose signature is missing or invalid with a 401 Unauthorized
+// response before the request body is decoded. The request body is read only
+// once: the decoder reads the same buffer that was used to verify the
+// signature.
+//
+// WebhookSignature must appear in a Method expression or in a method HTTP
+// expression.
+//
+// WebhookSignature accepts an optional function. The function may use Header
+// to override the name of the header carrying the signature ("X-Signature" by
+// default) and Algorithm to override the hash function used to compute the
+// HMAC ("sha256" by default).
+//
+// Example:
+//
+//	Method("notify", func() {
+//	    Payload(Event)
+//	    WebhookSignature(func() {
+//	        Header("X-Hub-Signature-256")
+//	        Algorithm("sha256")
+//	    })
+//	    HTTP(func() {
+//	        POST("/webhooks")
+//	    })
+//	})
+func WebhookSignature(fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments given to WebhookSignature")
+		return
+	}
+	var e *expr.HTTPEndpointExpr
+	switch actual := eval.Current().(type) {
+	case *expr.MethodExpr:
+		e = expr.Root.API.HTTP.ServiceFor(actual.Service).EndpointFor(actual.Name, actual)
+	case *expr.HTTPEndpointExpr:
+		e = actual
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	sig := &expr.HTTPWebhookSignatureExpr{
+		Header:    expr.DefaultWebhookSignatureHeader,
+		Algorithm: expr.DefaultWebhookSignatureAlgorithm,
+		Endpoint:  e,
+	}
+	if len(fn) == 1 {
+		if !eval.Execute(fn[0], sig) {
+			return
+		}
+	}
+	e.WebhookSignature = sig
+}
+
+// Algorithm sets the name of the hash function used to compute the HMAC
+// webhook signatures. The supported values are "sha1", "sha256" and "sha512".
+//
+// Algorithm must appear in a WebhookSignature expression.
+//
+// Example:
+//
+//	WebhookSignature(func() {
+//	    Algorithm("sha512")
+//	})
+func Algorithm(name string) {
+	sig, ok := eval.Current().(*expr.HTTPWebhookSignatureExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	sig.Algorithm = name
+}
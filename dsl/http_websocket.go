@@ -0,0 +1,145 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// WebSocketText sends the stream messages as WebSocket text frames.
+	WebSocketText = expr.WebSocketFramingText
+
+	// WebSocketBinary sends the stream messages as WebSocket binary frames.
+	WebSocketBinary = expr.WebSocketFramingBinary
+)
+
+// WebSocket configures the WebSocket connection used by a streaming endpoint.
+// Streaming endpoints use WebSockets by default, WebSocket makes it possible
+// to control the upgrade path, the message framing and the keepalive
+// behavior. The generated server and client treat the normal and "going away"
+// close codes as the end of the stream.
+//
+// WebSocket must appear in a method HTTP expression of a method that defines
+// a StreamingPayload or a StreamingResult.
+//
+// WebSocket accepts an optional function that may use Path to set the path
+// used to upgrade the connection (added to the endpoint routes as a GET
+// route), Framing to set the frame type used to send messages (text frames by
+// default), PingInterval to make the server send ping messages periodically
+// and PongTimeout to close connections whose client does not answer the
+// pings.
+//
+// Example:
+//
+//	Method("watch", func() {
+//	    StreamingResult(Event)
+//	    HTTP(func() {
+//	        WebSocket(func() {
+//	            Path("/events/ws")
+//	            Framing(WebSocketBinary)
+//	            PingInterval("30s")
+//	            PongTimeout("1m")
+//	        })
+//	    })
+//	})
+func WebSocket(fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to WebSocket")
+		return
+	}
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws := &expr.HTTPWebSocketExpr{
+		Framing:  expr.WebSocketFramingText,
+		Endpoint: e,
+	}
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], ws) {
+			return
+		}
+	}
+	if ws.Path != "" {
+		e.Routes = append(e.Routes, &expr.RouteExpr{Method: "GET", Path: ws.Path, Endpoint: e})
+	}
+	e.WebSocket = ws
+}
+
+// Framing sets the WebSocket frame type used to send the stream messages. The
+// messages are JSON encoded in both cases.
+//
+// Framing must appear in a WebSocket expression.
+//
+// Framing accepts one argument: WebSocketText (default) or WebSocketBinary.
+//
+// Example:
+//
+//	WebSocket(func() {
+//	    Framing(WebSocketBinary)
+//	})
+func Framing(f string) {
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ws.Framing = f
+}
+
+// PingInterval sets the interval at which the server sends WebSocket ping
+// messages to keep the connection alive. The duration uses the format accepted
+// by time.ParseDuration.
+//
+// PingInterval must appear in a WebSocket expression.
+//
+// Example:
+//
+//	WebSocket(func() {
+//	    PingInterval("30s")
+//	})
+func PingInterval(d string) {
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	interval, err := time.ParseDuration(d)
+	if err != nil {
+		eval.InvalidArgError("duration", d)
+		return
+	}
+	ws.PingInterval = interval
+}
+
+// PongTimeout sets the duration after which the server closes the WebSocket
+// connection if the client did not answer the ping messages. The timeout must
+// be greater than the ping interval. The server only processes the pong
+// messages while reading from the connection so bidirectional streaming
+// services must keep receiving from the stream. The duration uses the format
+// accepted by time.ParseDuration.
+//
+// PongTimeout must appear in a WebSocket expression.
+//
+// Example:
+//
+//	WebSocket(func() {
+//	    PingInterval("30s")
+//	    PongTimeout("1m")
+//	})
+func PongTimeout(d string) {
+	ws, ok := eval.Current().(*expr.HTTPWebSocketExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	timeout, err := time.ParseDuration(d)
+	if err != nil {
+		eval.InvalidArgError("duration", d)
+		return
+	}
+	ws.PongTimeout = timeout
+}
@@ -0,0 +1,119 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// LongRunning declares that the method starts a long-running operation. The
+// method returns a job right away and clients poll the job with another method
+// of the same service until the operation completes.
+//
+// The HTTP endpoint of a long-running method responds with status 202
+// (Accepted) by default and the 202 responses include a Location header set to
+// the path of the poll method endpoint. The generated service client includes a
+// Poll function for each long-running method that calls the poll method until
+// a function provided by the caller reports the job as done, see the generated
+// code for details.
+//
+// LongRunning must appear in a Method expression.
+//
+// LongRunning accepts a single argument which is the defining DSL. The DSL must
+// use JobType and PollMethod.
+//
+// Example:
+//
+//	var Job = Type("Job", func() {
+//	    Attribute("id", String)
+//	    Attribute("status", String)
+//	    Required("id", "status")
+//	})
+//
+//	Method("export", func() {
+//	    LongRunning(func() {
+//	        JobType(Job)
+//	        PollMethod("get_job")
+//	    })
+//	    HTTP(func() {
+//	        POST("/exports")
+//	    })
+//	})
+//
+//	Method("get_job", func() {
+//	    Payload(func() {
+//	        Attribute("id", String)
+//	        Required("id")
+//	    })
+//	    Result(Job)
+//	    HTTP(func() {
+//	        GET("/jobs/{id}")
+//	    })
+//	})
+func LongRunning(fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	l := &expr.LongRunningExpr{Method: m}
+	if !eval.Execute(fn, l) {
+		return
+	}
+	m.LongRunning = l
+}
+
+// JobType sets the type of the job returned by a long-running method and by
+// its poll method. The type must be an object. JobType also sets the method
+// result if the method does not define one. The attributes of the poll method
+// payload must be required attributes of the job with the same types so that
+// the payload can be built from the job.
+//
+// JobType must appear in a LongRunning expression.
+//
+// JobType accepts a single argument which is the job user type.
+//
+// Example:
+//
+//	LongRunning(func() {
+//	    JobType(Job)
+//	    PollMethod("get_job")
+//	})
+func JobType(t interface{}) {
+	l, ok := eval.Current().(*expr.LongRunningExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ut, ok := t.(expr.UserType)
+	if !ok {
+		eval.InvalidArgError("user type", t)
+		return
+	}
+	l.JobType = ut
+	if l.Method.Result == nil {
+		l.Method.Result = &expr.AttributeExpr{Type: ut}
+	}
+}
+
+// PollMethod sets the name of the method used to poll the job returned by a
+// long-running method. The poll method must belong to the same service and
+// return the job type.
+//
+// PollMethod must appear in a LongRunning expression.
+//
+// PollMethod accepts a single argument which is the name of the poll method.
+//
+// Example:
+//
+//	LongRunning(func() {
+//	    JobType(Job)
+//	    PollMethod("get_job")
+//	})
+func PollMethod(name string) {
+	l, ok := eval.Current().(*expr.LongRunningExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	l.PollMethod = name
+}
@@ -50,6 +50,16 @@ import (
 //	    Meta("struct:pkg:path", "types")
 //	})
 //
+// - "struct:pkg:name" overrides the name of the Go package generated for the
+// enclosing service. The package is still generated in the directory named
+// after the service, the generated code that uses the package imports it with
+// the overridden name. The name must be a lowercase Go identifier distinct
+// from the names given to the other services. Applicable to services only.
+//
+//	var _ = Service("my service", func() {
+//	    Meta("struct:pkg:name", "svcv2") // package svcv2 in gen/my_service
+//	})
+//
 // - "struct:field:name" overrides the Go struct field name generated by default
 // by goa. Applicable to attributes only.
 //
@@ -59,6 +69,17 @@ import (
 //	    })
 //	})
 //
+// - "struct:field:name:strip-prefix" removes the given prefix from the names of
+// the type attributes when computing the Go struct field names. The attribute
+// names are still used on the wire. Attributes that define "struct:field:name"
+// are not affected. Applicable to types only.
+//
+//	var User = Type("User", func() {
+//	    Meta("struct:field:name:strip-prefix", "user_")
+//	    Attribute("user_first_name", String) // Go field FirstName
+//	    Attribute("user_last_name", String)  // Go field LastName
+//	})
+//
 // - "struct:field:type" overrides the Go struct field type specified in the
 // design, with one caveat; if the type would have been a pointer (such as its
 // not Required) the new type will also be a pointer.  Applicable to attributes
@@ -76,6 +97,32 @@ import (
 //	     })
 //	})
 //
+// Numeric attributes whose type is overridden with json.Number are decoded
+// without loss of precision: the generated HTTP request decoders call
+// UseNumber on the JSON decoder and range and enum validations are applied to
+// the parsed value.
+//
+//	var Transfer = Type("Transfer", func() {
+//	    Attribute("amount", Float64, func() {
+//	        Meta("struct:field:type", "json.Number", "encoding/json")
+//	        Minimum(0)
+//	    })
+//	})
+//
+// - "struct:field:any" sets the Go type of an Any attribute. The only supported
+// value is "json.RawMessage": the attribute is generated as a json.RawMessage
+// field in the service and transport types so that the JSON value is kept as
+// is, e.g. numbers are not decoded as float64, and is encoded back verbatim.
+// The service is responsible for decoding the value. Applicable to attributes
+// of type Any only and cannot be combined with "struct:field:type".
+//
+//	var Event = Type("Event", func() {
+//	    Attribute("kind", String)
+//	    Attribute("data", Any, func() {
+//	        Meta("struct:field:any", "json.RawMessage")
+//	    })
+//	})
+//
 // - "struct:field:proto" overrides the generated protobuf field type. If the
 // type is defined in a separate proto file, the last three elements define the
 // proto file import path, Go type name and Go import path respectively.
@@ -96,6 +143,17 @@ import (
 //	    })
 //	})
 //
+// - "grpc:wellknown" maps string attributes with the FormatDateTime and
+// FormatDuration formats to the google.protobuf.Timestamp and
+// google.protobuf.Duration well-known types in the generated protobuf
+// messages. The generated code converts the values to and from their string
+// representation, sending a message with an invalid value fails with an
+// error. Applicable to APIs and services.
+//
+//	var _ = API("calc", func() {
+//	    Meta("grpc:wellknown")
+//	})
+//
 // - "struct:tag:xxx" sets a generated Go struct field tag and overrides tags
 // that Goa would otherwise set. If the metadata value is a slice then the
 // strings are joined with the space character as separator. Applicable to
@@ -108,6 +166,125 @@ import (
 //	    })
 //	})
 //
+// - "struct:tag:db:policy" generates a db struct field tag for each field of
+// the Go struct generated for the type, as used by database libraries such as
+// sqlx. The only supported policy is "snake" which uses the attribute name
+// converted to snake_case as column name. Attributes that define
+// "struct:tag:db" keep their tag. Attributes that define "db:skip" get the tag
+// db:"-" so that they are not mapped to a column, for example computed or
+// write-only attributes. Applicable to types only. The tags are only added to
+// the service types, not the transport types.
+//
+//	var User = Type("User", func() {
+//	    Meta("struct:tag:db:policy", "snake")
+//	    Attribute("firstName", String) // db:"first_name"
+//	    Attribute("password", String, func() {
+//	        Meta("db:skip") // db:"-"
+//	    })
+//	})
+//
+// - "struct:tag:json:omitempty" adds a json struct field tag with the omitempty
+// option to the fields of the Go structs generated in the service packages for
+// the optional attributes that have no default value so that the fields are
+// omitted when nil. The only supported value is "optional". Required attributes
+// and attributes that define "struct:tag:json" are not affected. Nullable
+// attributes are not affected either as omitting their goa.Nullable fields
+// requires the omitzero option introduced in Go 1.24. Applicable to API
+// definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("struct:tag:json:omitempty", "optional")
+//	})
+//
+// - "struct:constructor" generates a constructor for the Go struct generated
+// for the type in the service package. The constructor is named after the type
+// (e.g. NewUser) and accepts the required attributes as arguments in the order
+// they are defined so that callers cannot omit them. The optional attributes
+// that define a default value are initialized with it. Applicable to object
+// types only.
+//
+//	var User = Type("User", func() {
+//	    Meta("struct:constructor", "true")
+//	    Attribute("name", String)
+//	    Attribute("role", String, func() {
+//	        Default("member")
+//	    })
+//	    Required("name")
+//	}) // func NewUser(name string) *User
+//
+// - "sensitive" flags an attribute holding sensitive data such as personally
+// identifiable information or secrets. The value describes the data
+// classification, for example "pii" or "secret". Goa generates String and
+// Format methods for the types that have sensitive attributes, either directly
+// or through nested types. The methods print the values of the sensitive
+// attributes as "[REDACTED]" whatever the fmt verb used. Applicable to
+// attributes and user types.
+//
+//	var User = Type("User", func() {
+//	    Attribute("name", String)
+//	    Attribute("ssn", String, "User SSN", func() {
+//	        Meta("sensitive", "pii")
+//	    })
+//	})
+//
+// - "crypto:field" flags a string attribute whose value is encrypted in the HTTP
+// requests and responses. The generated HTTP servers accept a goahttp.Cipher
+// used to decrypt the flagged payload fields after decoding the requests and to
+// encrypt the flagged result fields prior to encoding the responses. Requests
+// whose flagged fields cannot be decrypted are rejected with a bad request
+// error. Applicable to the top level attributes of method payloads and results
+// only.
+//
+//	Method("create", func() {
+//	    Payload(func() {
+//	        Attribute("name", String)
+//	        Attribute("ssn", String, func() {
+//	            Meta("crypto:field", "true")
+//	        })
+//	    })
+//	})
+//
+// - "naming:acronyms" lists the acronyms kept in upper case (or lower case at
+// the start of unexported names) when generating Go identifiers from the design
+// names, e.g. "user_id" and "IDUser" produce "UserID" and "idUser". The list
+// extends the default list of common acronyms such as "ID", "URL" or "API".
+// Applicable to API definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("naming:acronyms", "SKU", "EAN")
+//	})
+//
+// - "gen:module" sets the path of the Go module containing the generated code.
+// The generated import paths use this module path instead of the path of the
+// module detected from the go.mod file. The gen command prints a warning if
+// both differ. Applicable to API definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("gen:module", "github.com/me/app")
+//	})
+//
+// - "grpc:health" enables the generation of the standard gRPC health checking
+// service (grpc.health.v1.Health) when set to "true". The gen command generates
+// the gen/grpc/health package which implements the health service and the
+// example gRPC server registers it. The default health server reports all the
+// gRPC services as SERVING, the status of each service can be changed with
+// SetServingStatus. Applicable to API definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("grpc:health", "true")
+//	})
+//
+// - "grpc:client:pool" sets the number of connections of the gRPC client
+// connection pools. The generated gRPC client packages define a PoolSize
+// constant, a DialPool function that creates a goagrpc.ClientConnPool of
+// PoolSize connections and a NewPooledClient function that creates a client
+// distributing the requests across the pool connections in round-robin order.
+// The connections in failure are skipped. Applicable to API definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("grpc:client:pool", "4")
+//	})
+//
 // - "protoc:include" provides the list of import paths used to invoke protoc.
 // Applicable to API and service definitions only. If used on an API definition
 // the include paths are used for all services.
@@ -120,8 +297,156 @@ import (
 //	    Meta("protoc:include", "/usr/local/include/google/protobuf")
 //	})
 //
+// - "grpc:http:transcode" generates the google.api.http annotations used by
+// gRPC gateways to transcode HTTP requests into gRPC calls. The annotations
+// are derived from the HTTP routes, path parameters and body of the methods
+// that define both a HTTP and a gRPC endpoint. The proto files import
+// "google/api/annotations.proto" so protoc must be able to find the googleapis
+// proto files, see "protoc:include". Applicable to API definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("grpc:http:transcode", "true")
+//	})
+//
+// - "http:error:format" sets the format of the HTTP error responses. The only
+// supported value is "problem+json" which encodes the errors as RFC 7807
+// problem details with the "application/problem+json" content type. The
+// members of the error response bodies are encoded as problem extension
+// members. Applicable to API definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("http:error:format", "problem+json")
+//	})
+//
+// - "http:error:validation" sets the format of the HTTP responses that encode
+// the request validation errors. The only supported value is "fields" which
+// encodes the errors with the 422 status code as a list of the invalid fields
+// listing the JSON pointer to each field, the rule that failed and the error
+// message. The "problem+json" error format takes precedence. Applicable to API
+// definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("http:error:validation", "fields")
+//	})
+//
+// - "http:options:generate" generates HTTP server handlers that answer the
+// OPTIONS requests made to the endpoint paths with a 204 response listing the
+// methods accepted by the path in the Allow header. Paths that define an
+// explicit OPTIONS endpoint are left untouched. The CORS preflight handlers
+// also set the Allow header when CORS is enabled. Applicable to API
+// definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("http:options:generate", "true")
+//	})
+//
+// - "http:version:path" generates a HTTP server handler that serves the API
+// version as a JSON object of the form {"version":"2.3.1"} in response to GET
+// requests made to the given path. Requires the API to define a version.
+// Applicable to service definitions only.
+//
+//	var _ = Service("health", func() {
+//	    Meta("http:version:path", "/version")
+//	})
+//
+// - "http:compress" sets the content encoding used to compress the HTTP request
+// and response bodies. The only supported value is "gzip". The generated HTTP
+// servers decompress the request bodies whose Content-Encoding header is "gzip"
+// and compress the response bodies when the request Accept-Encoding header
+// accepts gzip. Response bodies smaller than goahttp.GzipMinSize bytes are not
+// compressed. Applicable to API and method definitions, the value "none"
+// disables compression for a method when the API enables it.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("http:compress", "gzip")
+//	})
+//
+// - "log:client" generates a UseLogger method on the HTTP clients that wraps
+// the endpoint doers so that the requests made by the clients are logged with
+// the given logger. The logs include the service and method names, the request
+// HTTP method and URL, the response status code and the request duration.
+// Setting "log:client:body" to "true" also logs the request and response bodies
+// with the values of the attributes flagged with the "sensitive" meta replaced
+// with "[REDACTED]". The bodies of streaming endpoints are never logged.
+// Applicable to API definitions only.
+//
+//	var _ = API("myapi", func() {
+//	    Meta("log:client", "true")
+//	    Meta("log:client:body", "true")
+//	})
+//
+// - "multipart:decode" generates a HTTP server decoder that maps the parts of
+// a multipart request body to the payload fields. The parts of nested
+// multipart bodies (e.g. "multipart/mixed" parts) are mapped to the fields of
+// user types, the parts sharing the same name to arrays. The payload fields
+// must be primitives, user types or arrays of these. "multipart:max-depth"
+// sets the maximum number of nested multipart bodies, it defaults to
+// goahttp.DefaultMultipartMaxDepth. "multipart:max-part-size" and
+// "multipart:max-size" set the maximum size in bytes of the content of a part
+// and of all the parts, they default to goahttp.DefaultMultipartMaxPartSize
+// and goahttp.DefaultMultipartMaxSize. Applicable to methods using
+// MultipartRequest only. "multipart:content-type" lists the content types
+// accepted for the part mapped to an attribute, wildcard subtypes such as
+// "image/*" are supported.
+//
+//	Method("upload", func() {
+//	    Payload(func() {
+//	        Attribute("title", String)
+//	        Attribute("logo", Bytes, func() {
+//	            Meta("multipart:content-type", "image/png", "image/jpeg")
+//	        })
+//	        Attribute("owner", Person)
+//	    })
+//	    Meta("multipart:decode", "true")
+//	    Meta("multipart:max-depth", "2")
+//	    Meta("multipart:max-size", "10485760")
+//	    HTTP(func() {
+//	        POST("/")
+//	        MultipartRequest()
+//	    })
+//	})
+//
+// - "validate:interface" generates a Validator interface in the service
+// package with one method per method payload and a DefaultValidator
+// implementation that runs the validations defined in the design. The
+// endpoints validate the payloads with the Validator before calling the
+// service. NewEndpoints uses DefaultValidator, NewEndpointsWithValidator
+// accepts a different implementation, for example to stub the validations in
+// tests. Applicable to API and service definitions, the service value takes
+// precedence.
+//
+//	var _ = Service("service1", func() {
+//	    Meta("validate:interface", "true")
+//	})
+//
+// - "i18n:key" sets the localization key of the validation errors of an
+// attribute. The generated validation code attaches the key to the errors
+// alongside the default English message so that a middleware can translate
+// them: the key is available in the I18nKey field of goa.ServiceError and
+// goa.FieldError and the validation rule that failed in their Name and Rule
+// fields. Applicable to attributes only.
+//
+//	var User = Type("User", func() {
+//	    Attribute("name", String, func() {
+//	        MinLength(2)
+//	        Meta("i18n:key", "user.name")
+//	    })
+//	    Required("name")
+//	})
+//
 // - "swagger:generate" DEPRECATED, use "openapi:generate" instead.
 //
+// - "openapi:versions" specifies whether the range of API versions defined with
+// Since and Until is appended to the OpenAPI operation description. Defaults to
+// true. Applicable to methods only.
+//
+//	var _ = Service("service1", func() {
+//	    Method("search", func() {
+//	        Since("v2")
+//	        Meta("openapi:versions", "false")
+//	    })
+//	})
+//
 // - "openapi:generate" specifies whether OpenAPI specification should be
 // generated. Defaults to true. Applicable to services, methods and file
 // servers.
@@ -180,10 +505,15 @@ import (
 //
 // - "openapi:tag:xxx" sets the OpenAPI object field tag xxx. Applicable to
 // HTTP services and methods. Tags are defined on services and used by methods.
+// The "order" suffix sets the position of the tag in the list of tags of the
+// specification, tags without order are listed after the ordered tags and
+// sorted by name. The "externalDocs:url" and "externalDocs:desc" suffixes are
+// aliases for "url" and "url:desc".
 //
 //	var _ = Service("MyService", func() {
 //	    HTTP(func() {
 //	    	Meta("openapi:tag:Backend:desc", "Description of Backend")
+//	    	Meta("openapi:tag:Backend:order", "1")
 //	    	Meta("openapi:tag:Backend:url", "http://example.com")
 //	    	Meta("openapi:tag:Backend:url:desc", "See more docs here")
 //	    	Meta("openapi:tag:Backend:extension:x-data", `{"foo":"bar"}`)
@@ -208,6 +538,17 @@ import (
 //	    Meta("openapi:extension:x-api", `{"foo":"bar"}`)
 //	})
 //
+// - "openapi:codesamples" adds the "x-codeSamples" extension to the OpenAPI
+// operations when set to "true". The extension contains a Go program that calls
+// the method using the generated HTTP client. The payload of the program sets
+// the required attributes using the attribute examples and the security
+// credentials using placeholder values. Applicable to API definitions. Methods
+// may set the value to "false" to omit their code sample.
+//
+//	var _ = API("MyAPI", func() {
+//	    Meta("openapi:codesamples", "true")
+//	})
+//
 // - "openapi:typename" overrides the name of the type generated in the OpenAPI specification.
 // Applicable to types (including embedded Payload and Result definitions).
 //
@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"time"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
@@ -36,3 +38,120 @@ func Method(name string, fn func()) {
 	ep := &expr.MethodExpr{Name: name, Service: s, DSLFunc: fn}
 	s.Methods = append(s.Methods, ep)
 }
+
+// Since sets the first API version in which the method is available. Versions
+// are semantic versions optionally prefixed with "v" whose minor and patch
+// components may be omitted, for example "v2", "1.3" or "2.0.0-beta.1".
+// Pre-releases are ordered as defined by https://semver.org. Running "goa gen" with the --api-version flag only generates
+// the methods available in the given version.
+//
+// Since must appear in a Method expression.
+//
+// Since takes a single argument: the API version.
+//
+// Example:
+//
+//    Method("search", func() {
+//        Since("v2")
+//        Payload(SearchQuery)
+//        Result(CollectionOf(Item))
+//    })
+//
+func Since(version string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.Since = version
+}
+
+// Until sets the last API version in which the method is available. See Since
+// for the format of versions.
+//
+// Until must appear in a Method expression.
+//
+// Until takes a single argument: the API version.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Since("v1")
+//        Until("v2") // Replaced by "search" in v3
+//        Result(CollectionOf(Item))
+//    })
+//
+func Until(version string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.Until = version
+}
+
+// Sunset deprecates the method and sets the date after which it may stop being
+// available. The date uses the YYYY-MM-DD format. The HTTP servers add the
+// "Deprecation" and "Sunset" headers (see RFC 8594) to the method responses
+// and the OpenAPI specifications mark the corresponding operations as
+// deprecated.
+//
+// Sunset must appear in a Method expression.
+//
+// Sunset takes a single argument: the sunset date.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Sunset("2025-12-31") // Use "search" instead
+//        Result(CollectionOf(Item))
+//    })
+//
+func Sunset(date string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		eval.InvalidArgError("date (YYYY-MM-DD)", date)
+		return
+	}
+	m.Sunset = t
+}
+
+// SparseFields lets the clients select the result fields included in the
+// responses. The HTTP endpoints of the method accept a "fields" query string
+// parameter listing the names of the selected fields separated with commas,
+// e.g. "?fields=id,name". The responses include all the fields when the
+// parameter is absent. Requests selecting unknown fields are rejected with a
+// 400 Bad Request response.
+//
+// Only the top level fields of the result may be selected, the fields of an
+// array result apply to its elements. Nested field selection such as
+// "address.zip" is rejected with a 400 Bad Request response.
+//
+// SparseFields must appear in a Method expression.
+//
+// Example:
+//
+//    Method("show", func() {
+//        SparseFields()
+//        Payload(func() {
+//            Attribute("id", Int)
+//        })
+//        Result(User)
+//        HTTP(func() {
+//            GET("/users/{id}") // GET /users/1?fields=id,name
+//        })
+//    })
+//
+func SparseFields() {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.SparseFields = true
+}
@@ -111,3 +111,37 @@ func TestMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestSunset(t *testing.T) {
+	cases := map[string]struct {
+		Date     string
+		Expected string
+		Error    bool
+	}{
+		"valid":        {"2025-12-31", "2025-12-31", false},
+		"invalid":      {"31/12/2025", "", true},
+		"invalid-date": {"2025-02-30", "", true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			eval.Context = &eval.DSLContext{}
+			methodExpr := &expr.MethodExpr{}
+			eval.Execute(func() { Sunset(tc.Date) }, methodExpr)
+			if tc.Error {
+				if eval.Context.Errors == nil {
+					t.Errorf("%s: expected an error", k)
+				}
+				if !methodExpr.Sunset.IsZero() {
+					t.Errorf("%s: expected no sunset date, got %s", k, methodExpr.Sunset)
+				}
+				return
+			}
+			if eval.Context.Errors != nil {
+				t.Fatalf("%s: Sunset DSL failed unexpectedly with %s", k, eval.Context.Errors)
+			}
+			if actual := methodExpr.Sunset.Format("2006-01-02"); actual != tc.Expected {
+				t.Errorf("%s: got sunset date %s, expected %s", k, actual, tc.Expected)
+			}
+		})
+	}
+}
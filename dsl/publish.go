@@ -0,0 +1,141 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Publish publishes the results streamed by the method to a message broker
+// topic. The generated code includes a publisher interface per broker listing
+// one function per published method and a default implementation that
+// serializes the events and sends them using a function provided by the
+// service so that the generated code does not depend on a specific broker
+// client. The publishers are generated in the "events/<service>/publisher"
+// package.
+//
+// Publish must appear in a Method expression whose result is streamed, see
+// StreamingResult.
+//
+// Publish accepts a single argument which is the defining DSL. The DSL must use
+// Topic and Broker and may use MessageKey and Serialization.
+//
+// Example:
+//
+//	Method("watch", func() {
+//	    StreamingResult(User)
+//	    Publish(func() {
+//	        Topic("users.created")
+//	        Broker("kafka")
+//	        MessageKey("id")
+//	        Serialization("json")
+//	    })
+//	})
+func Publish(fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := &expr.PublishExpr{Method: m}
+	if !eval.Execute(fn, p) {
+		return
+	}
+	m.Publish = p
+}
+
+// Topic sets the name of the topic the method events are published to.
+//
+// Topic must appear in a Publish expression.
+//
+// Topic accepts a single argument which is the topic name.
+//
+// Example:
+//
+//	Publish(func() {
+//	    Topic("users.created")
+//	    Broker("kafka")
+//	})
+func Topic(name string) {
+	p, ok := eval.Current().(*expr.PublishExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Topic = name
+}
+
+// Broker sets the name of the message broker the method events are published
+// to. The name is used to name the generated publisher, e.g. "kafka" produces
+// a KafkaPublisher interface. Methods published to the same broker share the
+// same publisher.
+//
+// Broker must appear in a Publish expression.
+//
+// Broker accepts a single argument which is the broker name.
+//
+// Example:
+//
+//	Publish(func() {
+//	    Topic("users.created")
+//	    Broker("kafka")
+//	})
+func Broker(name string) {
+	p, ok := eval.Current().(*expr.PublishExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Broker = name
+}
+
+// MessageKey sets the name of the result attribute whose value is used as the
+// key of the published messages. The attribute must be of a primitive type.
+// The messages have no key if MessageKey is not used or if the value of the
+// attribute is not set.
+//
+// MessageKey must appear in a Publish expression.
+//
+// MessageKey accepts a single argument which is the attribute name.
+//
+// Example:
+//
+//	Publish(func() {
+//	    Topic("users.created")
+//	    Broker("kafka")
+//	    MessageKey("id")
+//	})
+func MessageKey(name string) {
+	p, ok := eval.Current().(*expr.PublishExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Key = name
+}
+
+// Serialization sets the format used to serialize the published events. The
+// supported formats are "json" (default) which encodes the service result
+// types with encoding/json and "proto" which encodes the protocol buffer
+// messages generated for the method gRPC endpoint. The JSON object keys are the
+// Go struct field names unless the attributes define a "struct:tag:json" meta.
+// The "proto" format requires the method to define a gRPC endpoint.
+//
+// Serialization must appear in a Publish expression.
+//
+// Serialization accepts a single argument which is the format.
+//
+// Example:
+//
+//	Publish(func() {
+//	    Topic("users.created")
+//	    Broker("kafka")
+//	    Serialization("proto")
+//	})
+func Serialization(format string) {
+	p, ok := eval.Current().(*expr.PublishExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Format = format
+}
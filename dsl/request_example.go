@@ -0,0 +1,146 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// RequestExample defines a request sent to the method together with the
+// expected response. The "example" command generates an integration_test.go
+// file that starts the example HTTP server, sends the request examples of each
+// method with the generated HTTP client and checks the responses. The tests of
+// methods that require authorization send the credentials returned by the
+// tokenProvider function defined in the generated file, the default
+// implementation reads them from environment variables.
+//
+// Request examples of streaming methods, of methods that use multipart
+// requests and of methods that skip the request or response body encoding are
+// not tested.
+//
+// RequestExample must appear in a Method expression.
+//
+// RequestExample accepts two arguments: the name of the example and the
+// defining DSL. The DSL may use ExamplePayload and one of ExampleResult or
+// ExampleError.
+//
+// Example:
+//
+//	Method("divide", func() {
+//	    Payload(func() {
+//	        Attribute("a", Int)
+//	        Attribute("b", Int)
+//	        Required("a", "b")
+//	    })
+//	    Result(Int)
+//	    Error("div_by_zero")
+//	    RequestExample("four by two", func() {
+//	        ExamplePayload(Val{"a": 4, "b": 2})
+//	        ExampleResult(2)
+//	    })
+//	    RequestExample("division by zero", func() {
+//	        ExamplePayload(Val{"a": 4, "b": 0})
+//	        ExampleError("div_by_zero")
+//	    })
+//	})
+func RequestExample(name string, fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r := &expr.RequestExampleExpr{Name: name, Method: m}
+	if !eval.Execute(fn, r) {
+		return
+	}
+	m.RequestExamples = append(m.RequestExamples, r)
+}
+
+// ExamplePayload sets the payload sent by a request example.
+//
+// ExamplePayload must appear in a RequestExample expression.
+//
+// ExamplePayload accepts a single argument which is the payload value. Object
+// values are described with Val.
+//
+// Example:
+//
+//	RequestExample("four by two", func() {
+//	    ExamplePayload(Val{"a": 4, "b": 2})
+//	    ExampleResult(2)
+//	})
+func ExamplePayload(val interface{}) {
+	r, ok := eval.Current().(*expr.RequestExampleExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Payload = exampleValue(val)
+}
+
+// ExampleResult sets the result expected by a request example. The test fails
+// if the result returned by the method differs.
+//
+// ExampleResult must appear in a RequestExample expression.
+//
+// ExampleResult accepts a single argument which is the expected result value.
+// Object values are described with Val.
+//
+// Example:
+//
+//	RequestExample("four by two", func() {
+//	    ExamplePayload(Val{"a": 4, "b": 2})
+//	    ExampleResult(2)
+//	})
+func ExampleResult(val interface{}) {
+	r, ok := eval.Current().(*expr.RequestExampleExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Result = exampleValue(val)
+}
+
+// ExampleError sets the name of the method error expected by a request
+// example.
+//
+// ExampleError must appear in a RequestExample expression.
+//
+// ExampleError accepts a single argument which is the name of the error.
+//
+// Example:
+//
+//	RequestExample("division by zero", func() {
+//	    ExamplePayload(Val{"a": 4, "b": 0})
+//	    ExampleError("div_by_zero")
+//	})
+func ExampleError(name string) {
+	r, ok := eval.Current().(*expr.RequestExampleExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	r.Error = name
+}
+
+// exampleValue converts the Val values contained in val to maps recursively.
+func exampleValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case Val:
+		return exampleValue(map[string]interface{}(v))
+	case expr.Val:
+		return exampleValue(map[string]interface{}(v))
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = exampleValue(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, e := range v {
+			s[i] = exampleValue(e)
+		}
+		return s
+	}
+	return val
+}
@@ -0,0 +1,56 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Reserved reserves protocol buffer field numbers and names so that the fields
+// of the type cannot use them. The generated protocol buffer message declares
+// the field numbers and names as reserved. Reserving the field numbers and
+// names of removed fields makes sure that they are not reused later which
+// would break wire compatibility with clients and servers that still use them.
+//
+// Reserved must appear in a Type or ResultType expression. Reserved may appear
+// multiple times in which case the field numbers and names are appended.
+//
+// Reserved accepts any number of arguments, each argument must be a field
+// number (an integer) or a field name (a string). It is an error for a field
+// of the type to use a reserved field number or name.
+//
+// Example:
+//
+//	var Account = Type("Account", func() {
+//	    Reserved(3, 5, "old_name")
+//	    Field(1, "id", String)
+//	    Field(2, "name", String)
+//	})
+func Reserved(values ...interface{}) {
+	var att *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.ResultTypeExpr:
+		att = def.AttributeExpr
+	case *expr.AttributeExpr:
+		att = def
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if att.Reserved == nil {
+		att.Reserved = &expr.ReservedExpr{}
+	}
+	for _, v := range values {
+		switch val := v.(type) {
+		case int:
+			if val < 0 {
+				eval.ReportError("invalid field number %d, field numbers must be positive", val)
+				continue
+			}
+			att.Reserved.Numbers = append(att.Reserved.Numbers, uint64(val))
+		case string:
+			att.Reserved.Names = append(att.Reserved.Names, val)
+		default:
+			eval.InvalidArgError("field number (int) or field name (string)", val)
+		}
+	}
+}
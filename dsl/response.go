@@ -1,10 +1,18 @@
 package dsl
 
 import (
+	"net/http"
+	"regexp"
+	"strings"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
 
+// nonAlnumRegex matches the characters replaced with underscores in the names
+// derived from HTTP status texts.
+var nonAlnumRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
 // Response describes a HTTP or a gRPC response. Response describes both success
 // and error responses. When describing an error response the first argument is
 // the name of the error.
@@ -36,6 +44,10 @@ import (
 //
 // * Response(status, func)
 //
+// * Response(status, type)
+//
+// * Response(status, type, func)
+//
 // Error responses additionally accept the name of the error as first or second argument.
 //
 // * Response(error_name, status)
@@ -48,6 +60,15 @@ import (
 //
 // * Response(status, error_name, func)
 //
+// Success HTTP responses of a method that does not define a result may each
+// define their own type. In this case the method result is an object with one
+// attribute per response named after the status text (e.g. "ok" or "accepted")
+// and a required "status" attribute whose value selects the response. The
+// service sets the status and the corresponding attribute, the server encodes
+// the attribute in the response body and the client sets both from the
+// response. All the success responses of such a method must define a type,
+// use Empty for responses without a body.
+//
 // By default (i.e. if Response only defines a status code) then:
 //
 //    - success HTTP responses use code 200 (OK) and error HTTP responses use code 400 (BadRequest)
@@ -93,6 +114,15 @@ import (
 //        })
 //    })
 //
+//    Method("process", func() {
+//        Payload(Job)
+//        HTTP(func() {
+//            POST("/jobs")
+//            Response(StatusOK, JobResult)          // result.Status == "ok", result.Ok set
+//            Response(StatusAccepted, JobReference) // result.Status == "accepted", result.Accepted set
+//        })
+//    })
+//
 func Response(val interface{}, args ...interface{}) {
 	name, ok := val.(string)
 	if !ok && len(args) > 0 {
@@ -143,6 +173,12 @@ func Response(val interface{}, args ...interface{}) {
 			}
 			return
 		}
+		if len(args) > 0 {
+			if dt, ok := args[0].(expr.DataType); ok {
+				httpTypedResponse(t, val, dt, args[1:]...)
+				return
+			}
+		}
 		code, fn := parseResponseArgs(val, args...)
 		if code == 0 {
 			code = expr.StatusOK
@@ -185,15 +221,18 @@ func Response(val interface{}, args ...interface{}) {
 	}
 }
 
-// Code sets the Response status code.
+// Code sets the Response status code or the status code of the responses sent
+// by a RedirectFrom expression.
 //
-// Code must appear in a Response expression.
+// Code must appear in a Response or RedirectFrom expression.
 //
 // Code accepts one argument: the HTTP or gRPC status code.
 func Code(code int) {
 	switch t := eval.Current().(type) {
 	case *expr.HTTPResponseExpr:
 		t.StatusCode = code
+	case *expr.HTTPRedirectExpr:
+		t.StatusCode = code
 	case *expr.GRPCResponseExpr:
 		t.StatusCode = code
 	default:
@@ -259,6 +298,84 @@ func parseResponseArgs(val interface{}, args ...interface{}) (code int, fn func(
 	return
 }
 
+// httpTypedResponse defines a success response of e with its own type. It
+// initializes the method result with the response status attribute on first
+// use and adds an attribute holding the response body for each response.
+func httpTypedResponse(e *expr.HTTPEndpointExpr, val interface{}, dt expr.DataType, args ...interface{}) {
+	code, ok := val.(int)
+	if !ok {
+		eval.InvalidArgError("int (HTTP status code)", val)
+		return
+	}
+	var fn func()
+	if len(args) > 1 {
+		eval.ReportError("too many arguments given to Response (%d)", len(args)+2)
+		return
+	}
+	if len(args) == 1 {
+		if fn, ok = args[0].(func()); !ok {
+			eval.InvalidArgError("function", args[0])
+			return
+		}
+	}
+	name := responseStatusName(code)
+	if name == "" {
+		eval.ReportError("Response type requires a known HTTP status code, got %d", code)
+		return
+	}
+	m := e.MethodExpr
+	if e.ResponseTag == "" {
+		if m.Result != nil && m.Result.Type != expr.Empty {
+			eval.ReportError("Response cannot define a type when the method defines a Result")
+			return
+		}
+		m.Result = &expr.AttributeExpr{
+			Type: &expr.Object{{
+				Name: "status",
+				Attribute: &expr.AttributeExpr{
+					Type:        expr.String,
+					Description: "Status selects the HTTP response and the result attribute that holds its body.",
+					Validation:  &expr.ValidationExpr{},
+				},
+			}},
+			Validation: &expr.ValidationExpr{Required: []string{"status"}},
+		}
+		e.ResponseTag = "status"
+	}
+	if m.Result.Find(name) != nil {
+		eval.ReportError("Response with status code %d is already defined", code)
+		return
+	}
+	status := m.Result.Find("status")
+	status.Validation.Values = append(status.Validation.Values, name)
+	body := &expr.AttributeExpr{Type: dt}
+	if dt != expr.Empty {
+		obj := expr.AsObject(m.Result.Type)
+		*obj = append(*obj, &expr.NamedAttributeExpr{Name: name, Attribute: &expr.AttributeExpr{Type: dt}})
+		body.AddMeta("origin:attribute", name)
+		if rt, ok := dt.(*expr.ResultTypeExpr); ok {
+			*expr.Root.GeneratedTypes = append(*expr.Root.GeneratedTypes, rt)
+		}
+	}
+	body.AddMeta("http:body")
+	resp := &expr.HTTPResponseExpr{
+		StatusCode: code,
+		Body:       body,
+		Tag:        [2]string{e.ResponseTag, name},
+		Parent:     e,
+	}
+	if fn != nil {
+		eval.Execute(fn, resp)
+	}
+	e.Responses = append(e.Responses, resp)
+}
+
+// responseStatusName returns the snake case status text of code used to name
+// the result attribute and status value of a typed response, e.g. "accepted".
+func responseStatusName(code int) string {
+	return strings.Trim(nonAlnumRegex.ReplaceAllString(strings.ToLower(http.StatusText(code)), "_"), "_")
+}
+
 func httpError(n string, p eval.Expression, args ...interface{}) *expr.HTTPErrorExpr {
 	if len(args) == 0 {
 		eval.ReportError("not enough arguments, use Response(name, status), Response(name, status, func()) or Response(name, func())")
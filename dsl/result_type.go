@@ -430,8 +430,12 @@ func Reference(t expr.DataType) {
 // Extend adds the parameter type attributes to the type using Extend. The
 // parameter type must be an object.
 //
-// Extend may be used in Type or ResultType. Extend accepts a single argument:
-// the type or result type containing the attributes to be copied.
+// Extend may be used in Type, ResultType or in the function given to Payload
+// or Result. Extend accepts a single argument: the type or result type
+// containing the attributes to be copied. Extend may be used multiple times to
+// compose a type from several types, in this case the required attributes are
+// the union of the required attributes of the extended types. Extended types
+// may only define attributes with the same name if their types are equal.
 //
 // Example:
 //
@@ -448,6 +452,14 @@ func Reference(t expr.DataType) {
 //	    Attribute("id", String, "ID of bottle to update")
 //	    Extend(CreateBottlePayload) // Adds attributes "name" and "vintage"
 //	})
+//
+//	Method("create", func() {
+//	    Payload(func() {
+//	        Extend(AuditFields)
+//	        Extend(TenantFields)
+//	        Attribute("name", String)
+//	    })
+//	})
 func Extend(t expr.DataType) {
 	if !expr.IsObject(t) {
 		eval.ReportError("argument of Extend must be an object, got %s", t.Name())
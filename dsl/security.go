@@ -288,7 +288,33 @@ func Security(args ...interface{}) {
 
 // NoSecurity removes the need for an endpoint to perform authorization.
 //
-// NoSecurity must appear in Method.
+// NoSecurity must appear in Service or Method. NoSecurity opts the method or
+// all the methods of the service out of the security requirements defined at
+// the API or service level and cannot be used together with Security in the
+// same scope. Note that a method that uses Security overrides the requirements
+// defined at the service and API levels entirely, the requirements are not
+// merged.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        // All methods are secured with JWT by default.
+//        Security(JWTAuth)
+//    })
+//
+//    var _ = Service("auth", func() {
+//        Method("login", func() {
+//            // login does not require a token.
+//            NoSecurity()
+//        })
+//    })
+//
+//    var _ = Service("health", func() {
+//        // None of the health methods require a token.
+//        NoSecurity()
+//        Method("check", func() {})
+//    })
+//
 func NoSecurity() {
 	security := &expr.SecurityExpr{
 		Schemes: []*expr.SchemeExpr{{Kind: expr.NoKind}},
@@ -298,6 +324,8 @@ func NoSecurity() {
 	switch actual := current.(type) {
 	case *expr.MethodExpr:
 		actual.Requirements = append(actual.Requirements, security)
+	case *expr.ServiceExpr:
+		actual.Requirements = append(actual.Requirements, security)
 	default:
 		eval.IncompatibleDSL()
 		return
@@ -560,6 +588,57 @@ func Scope(name string, desc ...string) {
 	}
 }
 
+// RequiredScopes lists scopes that the generated code checks are granted to the
+// request once authenticated. Unlike the scopes listed with Scope which are
+// only given to the authorization functions, the generated endpoints return
+// the method "forbidden" error listing the missing scopes if the request is
+// not granted all the required scopes. The "forbidden" error must use the
+// default error type if defined, a service error named "forbidden" is returned
+// otherwise. The required scopes are also added to the requirement scopes.
+//
+// The authorization functions store the scopes granted to the request in the
+// context using security.WithScopes. The scopes may be given as a space
+// delimited string (e.g. the "scope" claim of a JWT) or as an array (e.g. the
+// "scp" claim).
+//
+// RequiredScopes must appear in Security.
+//
+// RequiredScopes accepts one or more scope names.
+//
+// Example:
+//
+//    Method("update", func() {
+//        Security(JWT, func() {
+//            RequiredScopes("read:users", "write:users")
+//        })
+//        Error("forbidden")
+//    })
+//
+func RequiredScopes(scopes ...string) {
+	current, ok := eval.Current().(*expr.SecurityExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(scopes) == 0 {
+		eval.ReportError("RequiredScopes requires at least one scope")
+		return
+	}
+	current.RequiredScopes = append(current.RequiredScopes, scopes...)
+	for _, scope := range scopes {
+		found := false
+		for _, s := range current.Scopes {
+			if s == scope {
+				found = true
+				break
+			}
+		}
+		if !found {
+			current.Scopes = append(current.Scopes, scope)
+		}
+	}
+}
+
 // AuthorizationCodeFlow defines an authorizationCode OAuth2 flow as described
 // in section 1.3.1 of RFC 6749.
 //
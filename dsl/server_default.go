@@ -0,0 +1,47 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// ServerDefault initializes a payload field with the value of a Go expression
+// evaluated server-side when the request does not provide it. The generated
+// endpoint sets the field after the request is decoded and right before the
+// service method is called. Unlike Default the value is computed for each
+// request, for example to default a timestamp to the current time, and is
+// never used by the clients nor documented in the OpenAPI specification.
+//
+// ServerDefault must appear in a Method expression.
+//
+// ServerDefault accepts the name of the payload attribute, the Go expression
+// computing the value and optionally the import paths of the packages used by
+// the expression. The expression must evaluate to the type of the generated
+// field. The attribute cannot be required nor define a default value. Values
+// provided by the request are never overridden.
+//
+// Example:
+//
+//	Method("create", func() {
+//	    Payload(func() {
+//	        Attribute("name", String)
+//	        Attribute("created_at", String, func() {
+//	            Format(FormatDateTime)
+//	        })
+//	        Required("name")
+//	    })
+//	    ServerDefault("created_at", "time.Now().UTC().Format(time.RFC3339)", "time")
+//	})
+func ServerDefault(name, code string, imports ...string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.ServerDefaults = append(m.ServerDefaults, &expr.ServerDefaultExpr{
+		Field:   name,
+		Code:    code,
+		Imports: imports,
+		Method:  m,
+	})
+}
@@ -0,0 +1,85 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// SLO declares the service level objectives of the method. The objectives are
+// annotations: the OpenAPI specifications describe them with the "x-slo"
+// operation extension and the generated service package lists them in the
+// MethodSLOs variable so that metrics middlewares may use them as labels or
+// alerting targets. They do not change how the requests are handled.
+//
+// SLO must appear in a Method expression.
+//
+// SLO accepts a function that uses LatencyP99 and SuccessRate to set the
+// objectives. At least one objective must be set.
+//
+// Example:
+//
+//	Method("search", func() {
+//	    Payload(SearchRequest)
+//	    Result(SearchResults)
+//	    SLO(func() {
+//	        LatencyP99("200ms")
+//	        SuccessRate(99.9)
+//	    })
+//	})
+func SLO(fn func()) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	s := &expr.SLOExpr{Method: m}
+	if !eval.Execute(fn, s) {
+		return
+	}
+	m.SLO = s
+}
+
+// LatencyP99 sets the target 99th percentile latency of the method requests.
+// The duration uses the format accepted by time.ParseDuration.
+//
+// LatencyP99 must appear in a SLO expression.
+//
+// Example:
+//
+//	SLO(func() {
+//	    LatencyP99("200ms")
+//	})
+func LatencyP99(d string) {
+	s, ok := eval.Current().(*expr.SLOExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	latency, err := time.ParseDuration(d)
+	if err != nil {
+		eval.InvalidArgError("duration", d)
+		return
+	}
+	s.LatencyP99 = latency
+}
+
+// SuccessRate sets the target percentage of successful method requests, e.g.
+// 99.9.
+//
+// SuccessRate must appear in a SLO expression.
+//
+// Example:
+//
+//	SLO(func() {
+//	    SuccessRate(99.9)
+//	})
+func SuccessRate(pct float64) {
+	s, ok := eval.Current().(*expr.SLOExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	s.SuccessRate = pct
+}
@@ -0,0 +1,54 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Trace enables the OpenTelemetry instrumentation of the method. The generated
+// HTTP server starts a span named "service.method" for each request handled by
+// the method endpoint using the global OpenTelemetry tracer provider. The span
+// records the HTTP request method, the path of the endpoint first route and
+// the response status code. Errors returned by the method are recorded on the
+// span. The generated code uses the go.opentelemetry.io/otel module which must
+// be added to the service module dependencies.
+//
+// Trace must appear in a Method expression.
+//
+// Trace accepts an optional function that may use Attribute to list the
+// payload attributes recorded as span attributes. The attributes must be of
+// type Boolean, String or numeric and cannot be flagged as sensitive (see the
+// "sensitive" meta).
+//
+// Example:
+//
+//	Method("show", func() {
+//	    Payload(func() {
+//	        Attribute("tenant_id", String)
+//	        Attribute("id", String)
+//	    })
+//	    Trace(func() {
+//	        Attribute("tenant_id")
+//	    })
+//	    HTTP(func() {
+//	        GET("/{tenant_id}/items/{id}")
+//	    })
+//	})
+func Trace(fns ...func()) {
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to Trace")
+		return
+	}
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	t := &expr.TraceExpr{Method: m}
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], t) {
+			return
+		}
+	}
+	m.Trace = t
+}
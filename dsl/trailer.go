@@ -0,0 +1,69 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Trailer defines a trailer sent by the server once it is done streaming the
+// results of a method, for example the total number of results. The generated
+// server stream interface includes a SetTrailers method that the service
+// implementation calls to set the trailer values. Trailers that are not set
+// are not sent.
+//
+// The HTTP endpoint announces the trailers in the "Trailer" response header and
+// sends them after the events, it must thus use SSE. The gRPC endpoint sends
+// the trailers in the trailer metadata using the lowercase trailer names as
+// keys. The trailers defined in the HTTP and gRPC responses of a method are
+// shared by both transports.
+//
+// Trailer must appear in the HTTP or gRPC Response expression of a method that
+// defines a StreamingResult.
+//
+// Trailer accepts the same arguments as the Attribute function. The trailer
+// type must be a string, a boolean or a number and defaults to String.
+//
+// Example:
+//
+//	Method("list", func() {
+//	    StreamingResult(Item)
+//	    HTTP(func() {
+//	        GET("/items")
+//	        SSE(func() {
+//	            Event("item")
+//	        })
+//	        Response(StatusOK, func() {
+//	            Trailer("X-Total-Count", Int, "Number of streamed items")
+//	        })
+//	    })
+//	    GRPC(func() {
+//	        Response(func() {
+//	            Trailer("X-Total-Count", Int, "Number of streamed items")
+//	        })
+//	    })
+//	})
+func Trailer(name string, args ...interface{}) {
+	var m *expr.MethodExpr
+	switch r := eval.Current().(type) {
+	case *expr.HTTPResponseExpr:
+		if e, ok := r.Parent.(*expr.HTTPEndpointExpr); ok {
+			m = e.MethodExpr
+		}
+	case *expr.GRPCResponseExpr:
+		if e, ok := r.Parent.(*expr.GRPCEndpointExpr); ok {
+			m = e.MethodExpr
+		}
+	}
+	if m == nil {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("trailer name cannot be empty")
+		return
+	}
+	if m.StreamTrailers == nil {
+		m.StreamTrailers = &expr.AttributeExpr{Type: &expr.Object{}}
+	}
+	eval.Execute(func() { Attribute(name, args...) }, m.StreamTrailers)
+}
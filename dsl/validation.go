@@ -1,9 +1,13 @@
 package dsl
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
@@ -51,6 +55,10 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = expr.FormatRFC1123
+
+	// FormatDuration describes duration values using the syntax accepted by
+	// the Go time.ParseDuration function.
+	FormatDuration = expr.FormatDuration
 )
 
 // Enum adds a "enum" validation to the attribute.
@@ -114,6 +122,75 @@ func Enum(vals ...interface{}) {
 	}
 }
 
+// EnumFromFile adds a "enum" validation to the attribute using the values
+// listed in the given file. The file is read when the design is evaluated, it
+// lists one value per line. Leading and trailing spaces are ignored as well as
+// empty lines and lines starting with "#". Relative paths are relative to the
+// directory of the design file calling EnumFromFile.
+//
+// The values of attributes of type String or Bytes are the lines as is. The
+// values of other attributes are parsed as integers, floating point numbers or
+// booleans and must be compatible with the attribute type.
+//
+// EnumFromFile must appear in an attribute expression.
+//
+// Example:
+//
+//    Attribute("country", String, func() {
+//        EnumFromFile("countries.txt")
+//    })
+//
+func EnumFromFile(path string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if !filepath.IsAbs(path) {
+		if _, file, _, ok := runtime.Caller(1); ok {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		eval.ReportError("failed to read enum values: %s", err)
+		return
+	}
+	str := a.Type == nil || a.Type.Kind() == expr.StringKind || a.Type.Kind() == expr.BytesKind
+	var vals []interface{}
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		vals = append(vals, enumValue(line, str))
+	}
+	if len(vals) == 0 {
+		eval.ReportError("enum values file %q does not list any value", path)
+		return
+	}
+	Enum(vals...)
+}
+
+// enumValue returns the enum value represented by s. It returns s if str is
+// true and the integer, floating point number or boolean represented by s
+// otherwise, falling back to s if it does not represent any of these.
+func enumValue(s string, str bool) interface{} {
+	if str {
+		return s
+	}
+	if i, err := strconv.Atoi(s); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return s
+}
+
 // Format adds a "format" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor104.
 // The formats supported by goa are:
@@ -142,6 +219,10 @@ func Enum(vals ...interface{}) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// FormatDuration: Go duration such as "1h30m"
+//
+// Use CustomFormat to document values using other formats.
+//
 // Example:
 //
 //    Attribute("created_at", String, func() {
@@ -150,7 +231,7 @@ func Enum(vals ...interface{}) {
 func Format(f expr.ValidationFormat) {
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
 		if !a.IsSupportedValidationFormat(f) {
-			eval.ReportError("invalid validation format %q", f)
+			eval.ReportError("invalid validation format %q, use CustomFormat for formats not supported by goa", f)
 		}
 		if a.Type != nil && a.Type.Kind() != expr.StringKind {
 			incompatibleAttributeType("format", a.Type.Name(), "a string")
@@ -163,6 +244,69 @@ func Format(f expr.ValidationFormat) {
 	}
 }
 
+// CustomFormat sets the format of the attribute to a format not supported by
+// goa such as "iso-4217-currency". The format is used to document the values
+// in the generated OpenAPI specifications but the generated code does not
+// validate it. Use Pattern to also validate the values.
+//
+// CustomFormat must appear in an attribute expression whose type is a string.
+//
+// Example:
+//
+//    Attribute("currency", String, func() {
+//        CustomFormat("iso-4217-currency")
+//        Pattern("^[A-Z]{3}$")
+//    })
+func CustomFormat(f string) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if f == "" {
+			eval.ReportError("custom format cannot be empty")
+		}
+		if a.Type != nil && a.Type.Kind() != expr.StringKind {
+			incompatibleAttributeType("format", a.Type.Name(), "a string")
+		} else {
+			if a.Validation == nil {
+				a.Validation = &expr.ValidationExpr{}
+			}
+			a.Validation.Format = expr.ValidationFormat(f)
+		}
+	}
+}
+
+// DurationFormat sets the format of the attribute to FormatDuration and makes
+// the generated struct fields use the goa.Duration type instead of string. The
+// goa.Duration type is a time.Duration that is decoded from and encoded to
+// strings such as "1h30m" so that the service code handles time.Duration
+// values directly. Decoding fails if the value is not a valid duration.
+//
+// DurationFormat must appear in an attribute expression whose type is a
+// string. The attribute cannot have a default value nor define other string
+// validations (enum, pattern or length). The attribute is only supported in
+// HTTP request and response bodies, it cannot be mapped to headers, parameters
+// or cookies and cannot be used with gRPC.
+//
+// Example:
+//
+//    Attribute("timeout", String, func() {
+//        DurationFormat()
+//    })
+func DurationFormat() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Type != nil && a.Type.Kind() != expr.StringKind {
+		incompatibleAttributeType("format", a.Type.Name(), "a string")
+		return
+	}
+	if a.Validation == nil {
+		a.Validation = &expr.ValidationExpr{}
+	}
+	a.Validation.Format = expr.FormatDuration
+	a.AddMeta("struct:field:duration")
+}
+
 // Pattern adds a "pattern" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor33.
 //
@@ -446,6 +590,8 @@ func Required(names ...string) {
 		at = def.AttributeExpr
 	case *expr.MappedAttributeExpr:
 		at = def.AttributeExpr
+	case *expr.ConfigExpr:
+		at = def.Attribute()
 	default:
 		eval.IncompatibleDSL()
 		return
@@ -467,6 +613,186 @@ func Required(names ...string) {
 	}
 }
 
+// EqualFields adds a validation to the object attribute that checks that the
+// values of the two given attributes are equal, for example a password and
+// its confirmation. The attributes must have the same Boolean, String or
+// numeric type. If neither attribute is required the validation passes when
+// both are unset and fails when only one of them is set. The validation
+// cannot be expressed in JSON schema and is documented in the description of
+// the OpenAPI schemas instead.
+//
+// Example:
+//
+//    var _ = Type("SignUp", func() {
+//        Attribute("password", String)
+//        Attribute("password_confirm", String)
+//        EqualFields("password", "password_confirm")
+//        Required("password")
+//    })
+//
+func EqualFields(field, other string) {
+	var at *expr.AttributeExpr
+
+	switch def := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		at = def
+	case *expr.ResultTypeExpr:
+		at = def.AttributeExpr
+	case *expr.MappedAttributeExpr:
+		at = def.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+
+	if at.Type != nil && !expr.IsObject(at.Type) {
+		incompatibleAttributeType("equal fields", at.Type.Name(), "an object")
+		return
+	}
+	if field == other {
+		eval.ReportError("EqualFields must be given two different attribute names, got %q twice", field)
+		return
+	}
+	if at.Validation == nil {
+		at.Validation = &expr.ValidationExpr{}
+	}
+	at.Validation.AddEqualFields(field, other)
+	if ut, ok := at.Type.(expr.UserType); ok {
+		if ut.Attribute().Validation == nil {
+			ut.Attribute().Validation = &expr.ValidationExpr{}
+		}
+		ut.Attribute().Validation.AddEqualFields(field, other)
+	}
+}
+
+// MutuallyExclusive adds a validation to the object attribute that checks that
+// at most one of the given attributes is set. The validation error lists the
+// attributes that are set. Use RequiredOneOf with the same attributes to
+// require exactly one of them. The attributes cannot be required, nullable or
+// have a default value. The OpenAPI 3 schemas express the validation with
+// "not" or "oneOf" when combined with RequiredOneOf, the OpenAPI 2 schemas
+// document it in their description.
+//
+// Example:
+//
+//    var _ = Type("Search", func() {
+//        Attribute("name", String)
+//        Attribute("email", String)
+//        Attribute("phone", String)
+//        MutuallyExclusive("name", "email", "phone")
+//    })
+//
+func MutuallyExclusive(names ...string) {
+	addFieldGroup("MutuallyExclusive", "mutually exclusive", names, (*expr.ValidationExpr).AddMutuallyExclusive)
+}
+
+// RequiredOneOf adds a validation to the object attribute that checks that at
+// least one of the given attributes is set. Use MutuallyExclusive with the
+// same attributes to require exactly one of them. The attributes cannot be
+// required, nullable or have a default value. The OpenAPI 3 schemas express
+// the validation with "anyOf" or "oneOf" when combined with
+// MutuallyExclusive, the OpenAPI 2 schemas document it in their description.
+//
+// Example:
+//
+//    var _ = Type("Lookup", func() {
+//        Attribute("id", String)
+//        Attribute("email", String)
+//        MutuallyExclusive("id", "email")
+//        RequiredOneOf("id", "email") // exactly one of id and email
+//    })
+//
+func RequiredOneOf(names ...string) {
+	addFieldGroup("RequiredOneOf", "required one of", names, (*expr.ValidationExpr).AddRequiredOneOf)
+}
+
+// addFieldGroup adds the validation of a group of attributes of the current
+// object attribute and of its user type if any.
+func addFieldGroup(fn, validation string, names []string, add func(*expr.ValidationExpr, ...string)) {
+	var at *expr.AttributeExpr
+
+	switch def := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		at = def
+	case *expr.ResultTypeExpr:
+		at = def.AttributeExpr
+	case *expr.MappedAttributeExpr:
+		at = def.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+
+	if at.Type != nil && !expr.IsObject(at.Type) {
+		incompatibleAttributeType(validation, at.Type.Name(), "an object")
+		return
+	}
+	if len(names) < 2 {
+		eval.ReportError("%s must be given at least two attribute names", fn)
+		return
+	}
+	seen := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		if _, ok := seen[n]; ok {
+			eval.ReportError("%s must be given different attribute names, got %q twice", fn, n)
+			return
+		}
+		seen[n] = struct{}{}
+	}
+	if at.Validation == nil {
+		at.Validation = &expr.ValidationExpr{}
+	}
+	add(at.Validation, names...)
+	if ut, ok := at.Type.(expr.UserType); ok {
+		if ut.Attribute().Validation == nil {
+			ut.Attribute().Validation = &expr.ValidationExpr{}
+		}
+		add(ut.Attribute().Validation, names...)
+	}
+}
+
+// CustomValidation adds a custom validation to the attribute. The generated
+// service package defines a CustomValidations struct with one field per custom
+// validation holding the function that implements it, the field name is the
+// validation name in CamelCase. The function accepts a value of the Go type of
+// the attribute and returns an error, for example func(v string) error for a
+// String attribute. The endpoints run the custom validations defined on the
+// method payloads after the built-in validations succeed. The generated
+// NewEndpoints function accepts the CustomValidations and returns an error if
+// any of the functions is missing. CustomValidation may be called multiple
+// times to add multiple custom validations.
+//
+// Example:
+//
+//    Attribute("card", String, func() {
+//        MinLength(12)
+//        CustomValidation("validateCard") // Luhn check
+//    })
+//
+// The validation functions are provided when the endpoints are created:
+//
+//    endpoints, err := payment.NewEndpoints(svc, &payment.CustomValidations{
+//        ValidateCard: func(v string) error {
+//            if !luhn(v) {
+//                return errors.New("invalid card number")
+//            }
+//            return nil
+//        },
+//    })
+//
+func CustomValidation(name string) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && !expr.IsPrimitive(a.Type) {
+			incompatibleAttributeType("custom", a.Type.Name(), "a primitive")
+		} else {
+			if a.Validation == nil {
+				a.Validation = &expr.ValidationExpr{}
+			}
+			a.Validation.AddCustom(name)
+		}
+	}
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
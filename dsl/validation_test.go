@@ -1,6 +1,8 @@
 package dsl_test
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	. "goa.design/goa/v3/dsl"
@@ -81,3 +83,212 @@ func TestRequired(t *testing.T) {
 		t.Errorf("Required invalid on %+v, expected foo, got %+v", uattr, uattr.Validation.Required)
 	}
 }
+
+func TestCustomValidation(t *testing.T) {
+	cases := map[string]struct {
+		Type     expr.DataType
+		Names    []string
+		Expected []string
+		Error    bool
+	}{
+		"single":        {String, []string{"validateCard"}, []string{"validateCard"}, false},
+		"multiple":      {Int, []string{"a", "b", "a"}, []string{"a", "b"}, false},
+		"non-primitive": {&expr.Array{ElemType: &expr.AttributeExpr{Type: String}}, []string{"a"}, nil, true},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(func() {
+			for _, n := range tc.Names {
+				CustomValidation(n)
+			}
+		}, att)
+		if tc.Error {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: CustomValidation did not fail", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: CustomValidation failed unexpectedly with %s", k, eval.Context.Errors)
+			continue
+		}
+		if att.Validation == nil {
+			t.Errorf("%s: CustomValidation not initialized Validation in %+v", k, att)
+			continue
+		}
+		if len(att.Validation.Custom) != len(tc.Expected) {
+			t.Errorf("%s: got custom validations %v, expected %v", k, att.Validation.Custom, tc.Expected)
+			continue
+		}
+		for i, n := range tc.Expected {
+			if att.Validation.Custom[i] != n {
+				t.Errorf("%s: got custom validations %v, expected %v", k, att.Validation.Custom, tc.Expected)
+			}
+		}
+	}
+}
+
+func TestCustomFormat(t *testing.T) {
+	cases := map[string]struct {
+		Format string
+		Type   expr.DataType
+		Error  bool
+	}{
+		"custom":     {"iso-4217-currency", expr.String, false},
+		"empty":      {"", expr.String, true},
+		"not-string": {"iso-4217-currency", expr.Int, true},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(func() { CustomFormat(tc.Format) }, att)
+		if tc.Error {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: CustomFormat did not fail", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: CustomFormat failed unexpectedly with %s", k, eval.Context.Errors)
+		}
+		if att.Validation == nil || att.Validation.Format != expr.ValidationFormat(tc.Format) {
+			t.Errorf("%s: CustomFormat not set on %+v, expected %s", k, att, tc.Format)
+		}
+	}
+}
+
+func TestEqualFields(t *testing.T) {
+	obj := func() expr.DataType {
+		return &expr.Object{
+			{Name: "password", Attribute: &expr.AttributeExpr{Type: String}},
+			{Name: "password_confirm", Attribute: &expr.AttributeExpr{Type: String}},
+		}
+	}
+	cases := map[string]struct {
+		Type     expr.DataType
+		Fields   [][2]string
+		Expected [][2]string
+		Error    bool
+	}{
+		"single":     {obj(), [][2]string{{"password", "password_confirm"}}, [][2]string{{"password", "password_confirm"}}, false},
+		"duplicate":  {obj(), [][2]string{{"password", "password_confirm"}, {"password_confirm", "password"}}, [][2]string{{"password", "password_confirm"}}, false},
+		"same field": {obj(), [][2]string{{"password", "password"}}, nil, true},
+		"non-object": {String, [][2]string{{"password", "password_confirm"}}, nil, true},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(func() {
+			for _, f := range tc.Fields {
+				EqualFields(f[0], f[1])
+			}
+		}, att)
+		if tc.Error {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: EqualFields did not fail", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: EqualFields failed unexpectedly with %s", k, eval.Context.Errors)
+			continue
+		}
+		if att.Validation == nil {
+			t.Errorf("%s: EqualFields not initialized Validation in %+v", k, att)
+			continue
+		}
+		if !reflect.DeepEqual(att.Validation.EqualFields, tc.Expected) {
+			t.Errorf("%s: got equal fields %v, expected %v", k, att.Validation.EqualFields, tc.Expected)
+		}
+	}
+}
+
+func TestMutuallyExclusive(t *testing.T) {
+	obj := func() expr.DataType {
+		return &expr.Object{
+			{Name: "id", Attribute: &expr.AttributeExpr{Type: String}},
+			{Name: "email", Attribute: &expr.AttributeExpr{Type: String}},
+			{Name: "name", Attribute: &expr.AttributeExpr{Type: String}},
+		}
+	}
+	cases := map[string]struct {
+		Type     expr.DataType
+		Groups   [][]string
+		Expected [][]string
+		Error    bool
+	}{
+		"single":     {obj(), [][]string{{"id", "email", "name"}}, [][]string{{"id", "email", "name"}}, false},
+		"multiple":   {obj(), [][]string{{"id", "email"}, {"email", "name"}}, [][]string{{"id", "email"}, {"email", "name"}}, false},
+		"duplicate":  {obj(), [][]string{{"id", "email"}, {"email", "id"}}, [][]string{{"id", "email"}}, false},
+		"one field":  {obj(), [][]string{{"id"}}, nil, true},
+		"same field": {obj(), [][]string{{"id", "id"}}, nil, true},
+		"non-object": {String, [][]string{{"id", "email"}}, nil, true},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(func() {
+			for _, g := range tc.Groups {
+				MutuallyExclusive(g...)
+				RequiredOneOf(g...)
+			}
+		}, att)
+		if tc.Error {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: MutuallyExclusive did not fail", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: MutuallyExclusive failed unexpectedly with %s", k, eval.Context.Errors)
+			continue
+		}
+		if att.Validation == nil {
+			t.Errorf("%s: MutuallyExclusive not initialized Validation in %+v", k, att)
+			continue
+		}
+		if !reflect.DeepEqual(att.Validation.MutuallyExclusive, tc.Expected) {
+			t.Errorf("%s: got mutually exclusive groups %v, expected %v", k, att.Validation.MutuallyExclusive, tc.Expected)
+		}
+		if !reflect.DeepEqual(att.Validation.RequiredOneOf, tc.Expected) {
+			t.Errorf("%s: got required one of groups %v, expected %v", k, att.Validation.RequiredOneOf, tc.Expected)
+		}
+	}
+}
+
+func TestEnumFromFile(t *testing.T) {
+	cases := map[string]struct {
+		Type     expr.DataType
+		Path     string
+		Expected []interface{}
+		Error    string
+	}{
+		"strings":      {expr.String, "testdata/countries.txt", []interface{}{"CZ", "FR", "US"}, ""},
+		"missing-file": {expr.String, "testdata/missing.txt", nil, "failed to read enum values"},
+		"incompatible": {expr.Int, "testdata/invalid_ints.txt", nil, `value "three" at index 2 is incompatible`},
+	}
+
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(func() { EnumFromFile(tc.Path) }, att)
+		if tc.Error != "" {
+			if eval.Context.Errors == nil || !strings.Contains(eval.Context.Errors.Error(), tc.Error) {
+				t.Errorf("%s: got error %v, expected to contain %q", k, eval.Context.Errors, tc.Error)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Errorf("%s: EnumFromFile failed unexpectedly with %s", k, eval.Context.Errors)
+			continue
+		}
+		if att.Validation == nil || !reflect.DeepEqual(att.Validation.Values, tc.Expected) {
+			t.Errorf("%s: got %+v, expected values %v", k, att.Validation, tc.Expected)
+		}
+	}
+}
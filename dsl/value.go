@@ -8,11 +8,13 @@ import (
 // Val is an alias for expr.Val.
 type Val expr.Val
 
-// Value sets the example value.
+// Value sets the example value or defines a value of an enum map.
 //
-// Value must appear in Example.
+// Value must appear in Example or in EnumMap.
 //
-// Value takes one argument: the example value.
+// Value takes one argument when used in Example: the example value. Value
+// takes two arguments when used in EnumMap: the name and the number of the
+// enum value.
 //
 // Example:
 //
@@ -21,13 +23,33 @@ type Val expr.Val
 //        Value(Val{"ID": 1})
 //    })
 //
-func Value(val interface{}) {
+//    EnumMap(func() {
+//        Value("UNKNOWN", 0)
+//        Value("ACTIVE", 1)
+//    })
+//
+func Value(val interface{}, number ...int) {
 	switch e := eval.Current().(type) {
 	case *expr.ExampleExpr:
+		if len(number) > 0 {
+			eval.ReportError("too many arguments given to Value")
+			return
+		}
 		if v, ok := val.(expr.Val); ok {
 			val = map[string]interface{}(v)
 		}
 		e.Value = val
+	case *expr.EnumMapExpr:
+		name, ok := val.(string)
+		if !ok {
+			eval.InvalidArgError("string", val)
+			return
+		}
+		if len(number) != 1 {
+			eval.ReportError("Value must be given the name and the number of the enum value")
+			return
+		}
+		e.Values = append(e.Values, &expr.EnumMapValueExpr{Name: name, Number: number[0]})
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -106,6 +106,11 @@ prepares all the preparer expressions. In the third phase RunDSL validates all
 the validator expressions and in the last phase it calls Finalize on all the
 finalizer expressions.
 
+The functions registered with RegisterTransform run between the first and the
+second phases. They may modify the expressions created by the DSLs, for example
+to add attributes to all the method payloads, and the resulting expressions are
+prepared, validated and finalized like any other.
+
 The eval package exposes functions that the implementation of the DSL can take
 advantage of to report errors, such as ReportError, InvalidArg, and
 IncompatibleDSL. The engine records the errors being reported but keeps running
@@ -10,10 +10,10 @@ import (
 
 // RunDSL iterates through the root expressions and calls WalkSets on each to
 // retrieve the expression sets. It iterates over the expression sets multiple
-// times to first execute the DSL, then validate the resulting expressions and
-// lastly to finalize them. The executed DSL may register additional roots
-// during initial execution via Register to have them be executed (last) in the
-// same run.
+// times to first execute the DSL, then run the registered transforms, prepare
+// and validate the resulting expressions and lastly to finalize them. The
+// executed DSL may register additional roots during initial execution via
+// Register to have them be executed (last) in the same run.
 func RunDSL() error {
 	roots, err := Context.Roots()
 	if err != nil {
@@ -39,6 +39,9 @@ func RunDSL() error {
 	if Context.Errors != nil {
 		return Context.Errors
 	}
+	if err := runTransforms(roots); err != nil {
+		return err
+	}
 	for _, root := range roots {
 		prepareSet(ExpressionSet{root})
 		root.WalkSets(prepareSet)
@@ -0,0 +1,49 @@
+package eval
+
+import (
+	"fmt"
+	"sort"
+)
+
+type (
+	// TransformFunc modifies the design roots. It is called once the DSL
+	// has been executed and before the expressions are prepared, validated
+	// and finalized so that the expressions created or modified by the
+	// function go through the same validations as the ones defined by the
+	// design.
+	TransformFunc func(roots []Root) error
+
+	// transform is a registered transform function.
+	transform struct {
+		// name is the transform name.
+		name string
+		// fn is the transform function.
+		fn TransformFunc
+	}
+)
+
+// transforms lists the registered transforms sorted by name.
+var transforms []*transform
+
+// RegisterTransform registers a function that modifies the design roots prior
+// to validation, for example to add attributes to all the method payloads.
+// Plugins typically call RegisterTransform in their package init function. The
+// transforms run in the order of their names, transforms registered with the
+// same name run in the order of registration.
+func RegisterTransform(name string, fn TransformFunc) {
+	t := &transform{name: name, fn: fn}
+	i := sort.Search(len(transforms), func(i int) bool { return transforms[i].name > name })
+	transforms = append(transforms, nil)
+	copy(transforms[i+1:], transforms[i:])
+	transforms[i] = t
+}
+
+// runTransforms runs the registered transforms on the given roots.
+func runTransforms(roots []Root) error {
+	for _, t := range transforms {
+		if err := t.fn(roots); err != nil {
+			return fmt.Errorf("transform %q: %w", t.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package eval
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type (
+	transformRoot struct {
+		attrs *transformAttrs
+	}
+
+	transformAttrs struct {
+		names []string
+	}
+)
+
+func (r *transformRoot) EvalName() string        { return "transform root" }
+func (r *transformRoot) WalkSets(walk SetWalker) { walk(ExpressionSet{r.attrs}) }
+func (r *transformRoot) DependsOn() []Root       { return nil }
+func (r *transformRoot) Packages() []string      { return nil }
+func (a *transformAttrs) EvalName() string       { return "attributes" }
+
+func (a *transformAttrs) Validate() error {
+	verr := new(ValidationErrors)
+	for _, n := range a.names {
+		if n == "" {
+			verr.Add(a, "attribute name cannot be empty")
+		}
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
+
+func TestRunTransforms(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Names    []string
+		Fail     bool
+		Expected []string
+		Error    string
+	}{
+		{"ordered", []string{"b", "a", "c", "a"}, false, []string{"a", "a", "b", "c"}, ""},
+		{"invalid", []string{""}, false, nil, "attributes: attribute name cannot be empty"},
+		{"error", []string{"a"}, true, nil, `transform "a": boom`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			defer func() { transforms = nil }()
+			Reset()
+			root := &transformRoot{attrs: &transformAttrs{}}
+			if err := Register(root); err != nil {
+				t.Fatal(err)
+			}
+			for _, n := range c.Names {
+				n := n
+				RegisterTransform(n, func(roots []Root) error {
+					if c.Fail {
+						return errors.New("boom")
+					}
+					attrs := roots[0].(*transformRoot).attrs
+					attrs.names = append(attrs.names, n)
+					return nil
+				})
+			}
+			err := RunDSL()
+			if c.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), c.Error) {
+					t.Fatalf("got error %v, expected %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(root.attrs.names, c.Expected) {
+				t.Errorf("got %v, expected %v", root.attrs.names, c.Expected)
+			}
+		})
+	}
+}
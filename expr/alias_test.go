@@ -0,0 +1,37 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestAliasDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.AliasValidDSL},
+		{Name: "attribute name", DSL: testdata.AliasAttributeNameDSL, Error: `alias "email" of attribute "user_name" is an attribute name`},
+		{Name: "duplicate", DSL: testdata.AliasDuplicateDSL, Error: `alias "name" of attribute "full_name" is already used by attribute "user_name"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				root := expr.RunDSL(t, c.DSL)
+				att := expr.AsObject(root.Services[0].Methods[0].Payload.Type).Attribute("user_name")
+				if len(att.Aliases) != 2 || att.Aliases[0] != "username" || att.Aliases[1] != "userName" {
+					t.Errorf("got aliases %v, expected [username userName]", att.Aliases)
+				}
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package expr
 
 import (
 	"sort"
+	"strings"
 
 	"goa.design/goa/v3/eval"
 )
@@ -36,10 +37,18 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// RequestIDHeader is the name of the header used to propagate
+		// the request IDs if any.
+		RequestIDHeader string
 		// HTTP contains the HTTP specific API level expressions.
 		HTTP *HTTPExpr
 		// GRPC contains the gRPC specific API level expressions.
 		GRPC *GRPCExpr
+		// Config describes the API configuration if any.
+		Config *ConfigExpr
+		// Acronyms is the set of upper case acronyms listed in the
+		// "naming:acronyms" meta, it is computed by Finalize.
+		Acronyms map[string]bool
 
 		// random generator used to build examples for the API types.
 		ExampleGenerator *ExampleGenerator
@@ -129,9 +138,38 @@ func (a *APIExpr) EvalName() string { return "API " + a.Name }
 // Hash returns a unique hash value for a.
 func (a *APIExpr) Hash() string { return "_api_+" + a.Name }
 
+// Validate makes sure the API version is a semantic version, that the
+// "gen:module" meta is a module path and that the configuration is valid.
+func (a *APIExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if a.Version != "" {
+		if err := ValidateVersion(a.Version); err != nil {
+			verr.AddError(a, err)
+		}
+	}
+	if mod, ok := a.Meta.Last("gen:module"); ok {
+		if mod == "" || strings.ContainsAny(mod, " \t\\") || strings.HasPrefix(mod, "/") || strings.HasSuffix(mod, "/") {
+			verr.Add(a, "invalid gen:module meta %q, value must be a Go module path such as \"github.com/me/app\"", mod)
+		}
+	}
+	if policy, ok := a.Meta.Last("struct:tag:json:omitempty"); ok && policy != "optional" {
+		verr.Add(a, `unsupported struct:tag:json:omitempty %q, the only supported value is "optional"`, policy)
+	}
+	if a.Config != nil {
+		if err := a.Config.Validate(); err != nil {
+			verr.AddError(a.Config, err)
+		}
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
+
 // Finalize makes sure that the API name is initialized and there is at least
 // one server definition (if none exists, it creates a default server). If API
 // name is empty, it sets the name of the first service definition as API name.
+// It also computes the acronyms listed in the "naming:acronyms" meta.
 func (a *APIExpr) Finalize() {
 	if a.Name == "" {
 		a.Name = "api"
@@ -142,6 +180,15 @@ func (a *APIExpr) Finalize() {
 	if len(a.Servers) == 0 {
 		a.Servers = []*ServerExpr{a.DefaultServer()}
 	}
+	if a.Config != nil {
+		a.Config.Finalize()
+	}
+	if list, ok := a.Meta["naming:acronyms"]; ok {
+		a.Acronyms = make(map[string]bool, len(list))
+		for _, acr := range list {
+			a.Acronyms[strings.ToUpper(acr)] = true
+		}
+	}
 }
 
 // EvalName is the qualified name of the expression.
@@ -174,3 +174,78 @@ func TestLicenseExprEvalName(t *testing.T) {
 		}
 	}
 }
+
+func TestAPIExprValidate(t *testing.T) {
+	cases := map[string]struct {
+		version string
+		valid   bool
+	}{
+		"empty":       {"", true},
+		"full":        {"2.3.1", true},
+		"short":       {"1.0", true},
+		"major":       {"1", true},
+		"prefixed":    {"v2.3.1", true},
+		"pre-release": {"2.3.1-beta.1", true},
+		"build":       {"2.3.1+20230101.sha", true},
+		"leading-0":   {"01.2.3", false},
+		"too-long":    {"1.2.3.4", false},
+		"text":        {"latest", false},
+		"empty-pre":   {"1.2.3-", false},
+		"space":       {" 1.2.3", false},
+	}
+	for k, tc := range cases {
+		api := &APIExpr{Name: "test", Version: tc.version}
+		err := api.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", k, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error for version %q", k, tc.version)
+		}
+	}
+}
+
+func TestAPIExprValidateModule(t *testing.T) {
+	cases := map[string]struct {
+		module string
+		valid  bool
+	}{
+		"host":           {"github.com/me/app", true},
+		"major":          {"github.com/me/app/v2", true},
+		"empty":          {"", false},
+		"space":          {"github.com/me/my app", false},
+		"leading-slash":  {"/github.com/me/app", false},
+		"trailing-slash": {"github.com/me/app/", false},
+	}
+	for k, tc := range cases {
+		api := &APIExpr{Name: "test", Meta: MetaExpr{"gen:module": {tc.module}}}
+		err := api.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", k, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error for module %q", k, tc.module)
+		}
+	}
+}
+
+func TestAPIExprValidateJSONOmitEmpty(t *testing.T) {
+	cases := map[string]struct {
+		policy string
+		valid  bool
+	}{
+		"optional": {"optional", true},
+		"all":      {"all", false},
+		"empty":    {"", false},
+	}
+	for k, tc := range cases {
+		api := &APIExpr{Name: "test", Meta: MetaExpr{"struct:tag:json:omitempty": {tc.policy}}}
+		err := api.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", k, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error for value %q", k, tc.policy)
+		}
+	}
+}
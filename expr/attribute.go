@@ -2,7 +2,9 @@ package expr
 
 import (
 	"fmt"
+	"go/token"
 	"strings"
+	"unicode"
 
 	"goa.design/goa/v3/eval"
 )
@@ -25,10 +27,27 @@ type (
 		Docs *DocsExpr
 		// Optional validations
 		Validation *ValidationExpr
+		// EnumMap defines the numbers of the enum values if any.
+		EnumMap *EnumMapExpr
+		// Discriminator describes the attribute that identifies the
+		// types extending the attribute type if any.
+		Discriminator *DiscriminatorExpr
+		// Reserved lists the protocol buffer field numbers and names
+		// that the fields of the attribute type cannot use if any.
+		Reserved *ReservedExpr
+		// FieldOrder defines the order of the fields of the attribute
+		// type if any.
+		FieldOrder *FieldOrderExpr
 		// Meta is a list of key/value pairs
 		Meta MetaExpr
 		// Optional member default value
 		DefaultValue interface{}
+		// MethodDefaults lists the default values that only apply to
+		// the payloads of specific methods if any.
+		MethodDefaults []*MethodDefaultExpr
+		// Aliases lists the additional names accepted for the attribute
+		// when decoding request bodies if any.
+		Aliases []string
 		// UserExample set in DSL or computed in Finalize
 		UserExamples []*ExampleExpr
 		// finalized is true if the attribute has been finalized - only
@@ -97,6 +116,18 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
 		Required []string
+		// Custom lists the names of the custom validation functions the
+		// endpoints run after the built-in validations.
+		Custom []string
+		// EqualFields lists the pairs of object attributes whose values
+		// must be equal, for example a password and its confirmation.
+		EqualFields [][2]string
+		// MutuallyExclusive lists the groups of object attributes of which
+		// at most one may be set.
+		MutuallyExclusive [][]string
+		// RequiredOneOf lists the groups of object attributes of which at
+		// least one must be set.
+		RequiredOneOf [][]string
 	}
 
 	// ValidationFormat is the type used to enumerate the possible string
@@ -146,6 +177,10 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatDuration describes duration values using the syntax accepted by
+	// the Go time.ParseDuration function.
+	FormatDuration = "duration"
 )
 
 // EvalName returns the name used by the DSL evaluation.
@@ -201,14 +236,92 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 	if v := a.Validation; v != nil {
 		verr.Merge(v.Validate(ctx, parent))
 	}
+	if a.EnumMap != nil {
+		if a.Type.Kind() != StringKind {
+			verr.Add(parent, "%sEnumMap can only be used on attributes of type String, got %s", ctx, a.Type.Name())
+		}
+		verr.Merge(a.EnumMap.Validate(ctx, parent))
+	}
+	if a.IsNullable() {
+		if p, ok := a.Type.(Primitive); !ok || p.Kind() == BytesKind || p.Kind() == AnyKind {
+			verr.Add(parent, "%sNullable can only be used on attributes of type Boolean, String or numeric, got %s", ctx, a.Type.Name())
+		}
+		if a.DefaultValue != nil {
+			verr.Add(parent, "%sNullable attributes cannot have a default value", ctx)
+		}
+	}
+	if a.IsDuration() {
+		if a.Type != String {
+			verr.Add(parent, "%sDurationFormat can only be used on attributes of type String, got %s", ctx, a.Type.Name())
+		}
+		if a.DefaultValue != nil || len(a.MethodDefaults) > 0 {
+			verr.Add(parent, "%sDurationFormat attributes cannot have a default value", ctx)
+		}
+		if v := a.Validation; v != nil && (v.Values != nil || v.Pattern != "" || v.MinLength != nil || v.MaxLength != nil || v.Format != FormatDuration) {
+			verr.Add(parent, "%sDurationFormat attributes cannot define enum, pattern, length or format validations", ctx)
+		}
+		if _, ok := a.Meta["struct:field:type"]; ok {
+			verr.Add(parent, "%sDurationFormat cannot be used together with struct:field:type", ctx)
+		}
+	}
+	if len(a.MethodDefaults) > 0 {
+		verr.Merge(a.validateMethodDefaults(ctx, parent))
+	}
+	if _, ok := a.Meta["unit"]; ok {
+		if a.Unit() == "" {
+			verr.Add(parent, "%sUnit cannot be empty", ctx)
+		} else if !isNumeric(a.Type) {
+			verr.Add(parent, "%sUnit can only be used on numeric attributes, got %s", ctx, a.Type.Name())
+		}
+	}
+	if v, ok := a.Meta.Last("struct:field:any"); ok {
+		if a.Type != Any {
+			verr.Add(parent, "%sstruct:field:any can only be used on attributes of type Any, got %s", ctx, a.Type.Name())
+		} else if v != "json.RawMessage" {
+			verr.Add(parent, `%sunsupported struct:field:any %q, the only supported value is "json.RawMessage"`, ctx, v)
+		}
+		if _, ok := a.Meta["struct:field:type"]; ok {
+			verr.Add(parent, "%sstruct:field:any cannot be used together with struct:field:type", ctx)
+		}
+	}
 	if o := AsObject(a.Type); o != nil {
+		verr.Merge(a.validateBases(ctx, parent))
+		if policy, ok := a.Meta.Last("struct:tag:db:policy"); ok && policy != "snake" {
+			verr.Add(parent, `%sunsupported struct:tag:db:policy %q, the only supported policy is "snake"`, ctx, policy)
+		}
 		for _, n := range a.AllRequired() {
 			if a.Find(n) == nil {
 				verr.Add(parent, `%srequired field %q does not exist in type %s`, ctx, n, a.Type.Name())
 			}
 		}
+		verr.Merge(a.validateEqualFields(ctx, parent))
+		verr.Merge(a.validateFieldGroups(ctx, parent))
+		if prefix := a.fieldNamePrefix(); prefix != "" {
+			fields := make(map[string]string, len(*o))
+			for _, nat := range *o {
+				name := nat.Name
+				if n, ok := nat.Attribute.Meta["struct:field:name"]; ok && len(n) > 0 {
+					name = n[0]
+				} else if n := strings.TrimPrefix(name, prefix); n != "" {
+					name = n
+				}
+				key := fieldNameKey(name)
+				if other, ok := fields[key]; ok {
+					verr.Add(parent, "%sfields %q and %q have the same Go field name after stripping prefix %q", ctx, other, nat.Name, prefix)
+					continue
+				}
+				fields[key] = nat.Name
+			}
+		}
+		verr.Merge(a.validateAliases(ctx, parent))
 		for _, nat := range *o {
 			ctx = fmt.Sprintf("field %s", nat.Name)
+			if nat.Attribute.IsNullable() && a.IsRequired(nat.Name) {
+				verr.Add(parent, "%s - Nullable attributes cannot be required", ctx)
+			}
+			if len(nat.Attribute.MethodDefaults) > 0 && a.IsRequired(nat.Name) {
+				verr.Add(parent, "%s - attributes with default values scoped to methods cannot be required", ctx)
+			}
 			verr.Merge(nat.Attribute.Validate(ctx, parent))
 		}
 	} else if ar := AsArray(a.Type); ar != nil {
@@ -279,7 +392,22 @@ func (a *AttributeExpr) Finalize() {
 				pkgPath = meta[0]
 			}
 		}
+		prefix := a.fieldNamePrefix()
 		for _, nat := range *AsObject(a.Type) {
+			if prefix != "" {
+				if _, ok := nat.Attribute.Meta["struct:field:name"]; !ok {
+					if name := strings.TrimPrefix(nat.Name, prefix); name != nat.Name && name != "" {
+						// The child attribute may be shared with the
+						// types extended via Extend so set the meta on
+						// a copy. The copy is shallow so that nested
+						// user types are preserved.
+						att := *nat.Attribute
+						att.Meta = att.Meta.Dup()
+						att.AddMeta("struct:field:name", name)
+						nat.Attribute = &att
+					}
+				}
+			}
 			if pkgPath != "" {
 				if u := AsUnion(nat.Attribute.Type); u != nil {
 					for _, nat := range u.Values {
@@ -415,12 +543,140 @@ func (a *AttributeExpr) IsPrimitivePointer(attName string, useDefault bool) bool
 		return false
 	}
 	if IsPrimitive(att.Type) {
-		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind &&
+		return att.Type.Kind() != BytesKind && att.Type.Kind() != AnyKind && !att.IsNullable() &&
 			!a.IsRequired(attName) && (!a.HasDefaultValue(attName) || !useDefault)
 	}
 	return false
 }
 
+// Unit returns the unit of the attribute values defined with the Unit DSL, for
+// example "celsius", or the empty string if the attribute does not define one.
+func (a *AttributeExpr) Unit() string {
+	if a == nil {
+		return ""
+	}
+	u, _ := a.Meta.Last("unit")
+	return u
+}
+
+// MethodDefault returns the default value of the attribute that applies to
+// the payload of the method with the given name, nil if there is none.
+func (a *AttributeExpr) MethodDefault(method string) interface{} {
+	for _, d := range a.MethodDefaults {
+		if d.AppliesTo(method) {
+			return d.Value
+		}
+	}
+	return nil
+}
+
+// validateAliases makes sure the aliases of the object attributes are unique
+// and do not clash with the attribute names.
+func (a *AttributeExpr) validateAliases(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	o := AsObject(a.Type)
+	names := make(map[string]string, len(*o))
+	for _, nat := range *o {
+		names[nat.Name] = nat.Name
+	}
+	for _, nat := range *o {
+		for _, alias := range nat.Attribute.Aliases {
+			if other, ok := names[alias]; ok {
+				if other == alias {
+					verr.Add(parent, "%salias %q of attribute %q is an attribute name", ctx, alias, nat.Name)
+				} else {
+					verr.Add(parent, "%salias %q of attribute %q is already used by attribute %q", ctx, alias, nat.Name, other)
+				}
+				continue
+			}
+			names[alias] = nat.Name
+		}
+	}
+	return verr
+}
+
+// validateMethodDefaults makes sure the default values scoped to methods are
+// set on primitive attributes that do not define a default value and that a
+// method uses at most one of them.
+func (a *AttributeExpr) validateMethodDefaults(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !IsPrimitive(a.Type) || a.Type.Kind() == BytesKind || a.Type.Kind() == AnyKind {
+		verr.Add(parent, "%sdefault values scoped to methods can only be used on attributes of type Boolean, String or numeric, got %s", ctx, a.Type.Name())
+	}
+	if a.DefaultValue != nil {
+		verr.Add(parent, "%sattribute cannot define both a default value and default values scoped to methods", ctx)
+	}
+	seen := make(map[string]struct{})
+	for _, d := range a.MethodDefaults {
+		verr.Merge(d.Validate(ctx, parent))
+		for _, m := range d.Methods {
+			if _, ok := seen[m]; ok {
+				verr.Add(parent, "%smore than one default value applies to method %q", ctx, m)
+			}
+			seen[m] = struct{}{}
+		}
+	}
+	return verr
+}
+
+// IsNullable returns true if the attribute was defined with the Nullable DSL.
+// The fields generated for nullable attributes use the goa.Nullable type which
+// distinguishes absent values from null values.
+func (a *AttributeExpr) IsNullable() bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Meta["nullable"]
+	return ok
+}
+
+// IsGenerated returns true if the attribute value is assigned by the server,
+// see the Generated DSL.
+func (a *AttributeExpr) IsGenerated() bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Meta["generated"]
+	return ok
+}
+
+// IsSensitive returns true if the attribute or its user type is flagged with
+// the "sensitive" meta. The values of sensitive attributes are redacted from
+// the logs and never recorded as span attributes.
+func (a *AttributeExpr) IsSensitive() bool {
+	if a == nil {
+		return false
+	}
+	if _, ok := a.Meta["sensitive"]; ok {
+		return true
+	}
+	if ut, ok := a.Type.(UserType); ok {
+		_, ok := ut.Attribute().Meta["sensitive"]
+		return ok
+	}
+	return false
+}
+
+// IsDuration returns true if the attribute was defined with the DurationFormat
+// DSL. The fields generated for such attributes use the goa.Duration type.
+func (a *AttributeExpr) IsDuration() bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Meta["struct:field:duration"]
+	return ok
+}
+
+// fieldNamePrefix returns the prefix stripped from the names of the object
+// attribute fields to compute the Go struct field names as defined by the
+// "struct:field:name:strip-prefix" meta, empty string if there is none.
+func (a *AttributeExpr) fieldNamePrefix() string {
+	if p, ok := a.Meta["struct:field:name:strip-prefix"]; ok && len(p) > 0 {
+		return p[0]
+	}
+	return ""
+}
+
 // HasTag returns true if the attribute is an object that has an attribute with
 // the given tag.
 func (a *AttributeExpr) HasTag(tag string) bool {
@@ -658,6 +914,102 @@ func (a *AttributeExpr) debug(prefix string, seen map[*AttributeExpr]int, indent
 	}
 }
 
+// validateEqualFields makes sure the attributes compared with EqualFields
+// exist and have the same comparable primitive type.
+func (a *AttributeExpr) validateEqualFields(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if a.Validation == nil {
+		return verr
+	}
+	for _, f := range a.Validation.EqualFields {
+		var atts [2]*AttributeExpr
+		for i, n := range f {
+			att := a.Find(n)
+			if att == nil {
+				verr.Add(parent, "%sequal field %q does not exist in type %s", ctx, n, a.Type.Name())
+				continue
+			}
+			if p, ok := att.Type.(Primitive); !ok || p.Kind() == BytesKind || p.Kind() == AnyKind {
+				verr.Add(parent, "%sequal field %q must be of type Boolean, String or numeric, got %s", ctx, n, att.Type.Name())
+				continue
+			}
+			if att.IsNullable() {
+				verr.Add(parent, "%sequal field %q cannot be nullable", ctx, n)
+				continue
+			}
+			atts[i] = att
+		}
+		if atts[0] != nil && atts[1] != nil && atts[0].Type.Kind() != atts[1].Type.Kind() {
+			verr.Add(parent, "%sequal fields %q and %q must have the same type, got %s and %s", ctx, f[0], f[1], atts[0].Type.Name(), atts[1].Type.Name())
+		}
+	}
+	return verr
+}
+
+// validateFieldGroups makes sure the attributes listed in the MutuallyExclusive
+// and RequiredOneOf groups exist and that the generated code can tell whether
+// they are set: the attributes cannot be required, nullable or have a default
+// value.
+func (a *AttributeExpr) validateFieldGroups(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if a.Validation == nil {
+		return verr
+	}
+	check := func(kind string, groups [][]string) {
+		for _, g := range groups {
+			for _, n := range g {
+				att := a.Find(n)
+				switch {
+				case att == nil:
+					verr.Add(parent, "%s%s field %q does not exist in type %s", ctx, kind, n, a.Type.Name())
+				case a.IsRequired(n):
+					verr.Add(parent, "%s%s field %q cannot be required", ctx, kind, n)
+				case att.IsNullable():
+					verr.Add(parent, "%s%s field %q cannot be nullable", ctx, kind, n)
+				case att.DefaultValue != nil:
+					verr.Add(parent, "%s%s field %q cannot have a default value", ctx, kind, n)
+				}
+			}
+		}
+	}
+	check("mutually exclusive", a.Validation.MutuallyExclusive)
+	check("required one of", a.Validation.RequiredOneOf)
+	return verr
+}
+
+// validateBases makes sure that the types extended by the attribute do not
+// define different attributes with the same name. Attributes with the same
+// name must be the same attribute or have equal types.
+func (a *AttributeExpr) validateBases(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	if len(a.Bases) < 2 {
+		return nil
+	}
+	type definition struct {
+		base string
+		att  *AttributeExpr
+	}
+	verr := new(eval.ValidationErrors)
+	defs := make(map[string]definition)
+	for _, base := range a.Bases {
+		obj := AsObject(base)
+		if obj == nil {
+			continue
+		}
+		for _, nat := range *obj {
+			def, ok := defs[nat.Name]
+			if !ok {
+				defs[nat.Name] = definition{base.Name(), nat.Attribute}
+				continue
+			}
+			if def.att == nat.Attribute || Equal(def.att.Type, nat.Attribute.Type) {
+				continue
+			}
+			verr.Add(parent, "%sattribute %q is defined with different types in extended types %s and %s", ctx, nat.Name, def.base, base.Name())
+		}
+	}
+	return verr
+}
+
 // validateEnumDefault makes sure that the attribute default value is one of the
 // enum values.
 func (a *AttributeExpr) validateEnumDefault(ctx string, parent eval.Expression) *eval.ValidationErrors {
@@ -701,6 +1053,12 @@ func (a *AttributeExpr) inheritRecursive(parent *AttributeExpr, seen map[*Attrib
 			if att.DefaultValue == nil {
 				att.DefaultValue = patt.DefaultValue
 			}
+			if att.MethodDefaults == nil {
+				att.MethodDefaults = patt.MethodDefaults
+			}
+			if att.Aliases == nil {
+				att.Aliases = patt.Aliases
+			}
 			if att.Type == nil {
 				att.Type = patt.Type
 			} else if att.shouldInherit(patt) {
@@ -767,6 +1125,11 @@ func (v *ValidationExpr) Validate(ctx string, parent eval.Expression) *eval.Vali
 	if v.MinLength != nil && v.MaxLength != nil && *v.MinLength > *v.MaxLength {
 		verr.Add(parent, "%smin length is greater than max length", ctx)
 	}
+	for _, c := range v.Custom {
+		if !token.IsIdentifier(c) {
+			verr.Add(parent, "%scustom validation name %q must be a valid identifier", ctx, c)
+		}
+	}
 	return verr
 }
 
@@ -800,6 +1163,16 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 		v.MaxLength = other.MaxLength
 	}
 	v.AddRequired(other.Required...)
+	v.AddCustom(other.Custom...)
+	for _, f := range other.EqualFields {
+		v.AddEqualFields(f[0], f[1])
+	}
+	for _, g := range other.MutuallyExclusive {
+		v.AddMutuallyExclusive(g...)
+	}
+	for _, g := range other.RequiredOneOf {
+		v.AddRequiredOneOf(g...)
+	}
 }
 
 // AddRequired merges the required fields into v.
@@ -818,6 +1191,99 @@ func (v *ValidationExpr) AddRequired(required ...string) {
 	}
 }
 
+// AddCustom merges the custom validation names into v.
+func (v *ValidationExpr) AddCustom(names ...string) {
+	for _, n := range names {
+		found := false
+		for _, c := range v.Custom {
+			if n == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Custom = append(v.Custom, n)
+		}
+	}
+}
+
+// AddEqualFields adds the validation that the given object attributes are
+// equal to v.
+func (v *ValidationExpr) AddEqualFields(field, other string) {
+	for _, f := range v.EqualFields {
+		if f[0] == field && f[1] == other || f[0] == other && f[1] == field {
+			return
+		}
+	}
+	v.EqualFields = append(v.EqualFields, [2]string{field, other})
+}
+
+// AddMutuallyExclusive adds the validation that at most one of the given object
+// attributes is set to v.
+func (v *ValidationExpr) AddMutuallyExclusive(names ...string) {
+	v.MutuallyExclusive = addFieldGroup(v.MutuallyExclusive, names)
+}
+
+// AddRequiredOneOf adds the validation that at least one of the given object
+// attributes is set to v.
+func (v *ValidationExpr) AddRequiredOneOf(names ...string) {
+	v.RequiredOneOf = addFieldGroup(v.RequiredOneOf, names)
+}
+
+// HasMutuallyExclusive returns true if v defines a MutuallyExclusive
+// validation for the given attributes regardless of their order.
+func (v *ValidationExpr) HasMutuallyExclusive(names ...string) bool {
+	for _, g := range v.MutuallyExclusive {
+		if sameFieldGroup(g, names) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRequiredOneOf returns true if v defines a RequiredOneOf validation for
+// the given attributes regardless of their order.
+func (v *ValidationExpr) HasRequiredOneOf(names ...string) bool {
+	for _, g := range v.RequiredOneOf {
+		if sameFieldGroup(g, names) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameFieldGroup returns true if the two groups list the same attributes
+// regardless of their order.
+func sameFieldGroup(g, other []string) bool {
+	if len(g) != len(other) {
+		return false
+	}
+	for _, n := range g {
+		found := false
+		for _, o := range other {
+			if n == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// addFieldGroup appends a copy of group to groups unless groups already
+// contains the same group.
+func addFieldGroup(groups [][]string, group []string) [][]string {
+	for _, g := range groups {
+		if sameFieldGroup(g, group) {
+			return groups
+		}
+	}
+	return append(groups, append([]string(nil), group...))
+}
+
 // RemoveRequired removes the given field from the list of required fields.
 func (v *ValidationExpr) RemoveRequired(required string) {
 	for i, r := range v.Required {
@@ -829,12 +1295,14 @@ func (v *ValidationExpr) RemoveRequired(required string) {
 }
 
 // HasRequiredOnly returns true if the validation only has the Required field
-// with a non-zero value.
+// with a non-zero value. Custom formats are ignored as they are not validated,
+// custom validations are ignored as the endpoints run them.
 func (v *ValidationExpr) HasRequiredOnly() bool {
 	if len(v.Values) > 0 {
 		return false
 	}
-	if v.Format != "" || v.Pattern != "" {
+	if v.Format.IsSupported() || v.Pattern != "" || len(v.EqualFields) > 0 ||
+		len(v.MutuallyExclusive) > 0 || len(v.RequiredOneOf) > 0 {
 		return false
 	}
 	if (v.ExclusiveMinimum != nil) ||
@@ -855,23 +1323,44 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		req = make([]string, len(v.Required))
 		copy(req, v.Required)
 	}
+	var custom []string
+	if len(v.Custom) > 0 {
+		custom = make([]string, len(v.Custom))
+		copy(custom, v.Custom)
+	}
+	var equal [][2]string
+	if len(v.EqualFields) > 0 {
+		equal = make([][2]string, len(v.EqualFields))
+		copy(equal, v.EqualFields)
+	}
+	var exclusive, oneOf [][]string
+	for _, g := range v.MutuallyExclusive {
+		exclusive = addFieldGroup(exclusive, g)
+	}
+	for _, g := range v.RequiredOneOf {
+		oneOf = addFieldGroup(oneOf, g)
+	}
 	return &ValidationExpr{
-		Values:           v.Values,
-		Format:           v.Format,
-		Pattern:          v.Pattern,
-		ExclusiveMinimum: v.ExclusiveMinimum,
-		Minimum:          v.Minimum,
-		ExclusiveMaximum: v.ExclusiveMaximum,
-		Maximum:          v.Maximum,
-		MinLength:        v.MinLength,
-		MaxLength:        v.MaxLength,
-		Required:         req,
+		Values:            v.Values,
+		Format:            v.Format,
+		Pattern:           v.Pattern,
+		ExclusiveMinimum:  v.ExclusiveMinimum,
+		Minimum:           v.Minimum,
+		ExclusiveMaximum:  v.ExclusiveMaximum,
+		Maximum:           v.Maximum,
+		MinLength:         v.MinLength,
+		MaxLength:         v.MaxLength,
+		Required:          req,
+		Custom:            custom,
+		EqualFields:       equal,
+		MutuallyExclusive: exclusive,
+		RequiredOneOf:     oneOf,
 	}
 }
 
 // Debug dumps the validation to STDOUT in a goa developer friendly way.
 func (v *ValidationExpr) Debug(title, prefix, indent string) {
-	if v.HasRequiredOnly() && len(v.Required) == 0 {
+	if v.HasRequiredOnly() && len(v.Required) == 0 && v.Format == "" && len(v.Custom) == 0 {
 		return
 	}
 	fmt.Printf("%s%svalidations\n", prefix, title)
@@ -905,10 +1394,29 @@ func (v *ValidationExpr) Debug(title, prefix, indent string) {
 	if len(v.Required) > 0 {
 		fmt.Printf("%s%s- required: %v\n", prefix, indent, v.Required)
 	}
+	if len(v.Custom) > 0 {
+		fmt.Printf("%s%s- custom: %v\n", prefix, indent, v.Custom)
+	}
+	if len(v.EqualFields) > 0 {
+		fmt.Printf("%s%s- equal fields: %v\n", prefix, indent, v.EqualFields)
+	}
+	if len(v.MutuallyExclusive) > 0 {
+		fmt.Printf("%s%s- mutually exclusive: %v\n", prefix, indent, v.MutuallyExclusive)
+	}
+	if len(v.RequiredOneOf) > 0 {
+		fmt.Printf("%s%s- required one of: %v\n", prefix, indent, v.RequiredOneOf)
+	}
 }
 
 // IsSupportedValidationFormat checks if the validation format is supported by goa.
 func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
+	return vf.IsSupported()
+}
+
+// IsSupported returns true if the format is one of the formats validated by
+// goa. The other formats are custom formats (see CustomFormat) only used to
+// document the values.
+func (vf ValidationFormat) IsSupported() bool {
 	switch vf {
 	case FormatDate:
 		return true
@@ -938,6 +1446,8 @@ func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 		return true
 	case FormatRFC1123:
 		return true
+	case FormatDuration:
+		return true
 	}
 	return false
 }
@@ -974,3 +1484,27 @@ func walkAttribute(att *AttributeExpr, it func(name string, a *AttributeExpr) er
 	}
 	return nil
 }
+
+// fieldNameKey returns a key that is identical for attribute names that
+// produce the same Go field name, it ignores the case and the characters
+// removed when generating Go identifiers.
+func fieldNameKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// isNumeric returns true if dt is a numeric primitive type or an alias of one.
+func isNumeric(dt DataType) bool {
+	if ut, ok := dt.(UserType); ok {
+		return isNumeric(ut.Attribute().Type)
+	}
+	switch dt.Kind() {
+	case IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind, Float32Kind, Float64Kind:
+		return true
+	}
+	return false
+}
@@ -124,6 +124,22 @@ func TestAttributeExprValidate(t *testing.T) {
 		errRequiredFieldNotExist = fmt.Errorf(`%srequired field %q does not exist in type %s`, normalizedCtx, "foo", fieldNotExistType.Name())
 		errViewButNotAResultType = fmt.Errorf("%s uses view %q but %q is not a result type", normalizedCtx, metadata["view"][0], notAResultType.Name())
 		errTypeNotDefineView     = fmt.Errorf("%s: type %q does not define view %q", normalizedCtx, viewNotDefinedTypeName, "foo")
+		errNullableNotPrimitive  = fmt.Errorf("%sNullable can only be used on attributes of type Boolean, String or numeric, got array", normalizedCtx)
+		errNullableRequired      = fmt.Errorf("field foo - Nullable attributes cannot be required")
+		errStripPrefixCollision  = fmt.Errorf("%sfields %q and %q have the same Go field name after stripping prefix %q", normalizedCtx, "name", "user_name", "user_")
+		errUnsupportedDBPolicy   = fmt.Errorf(`%sunsupported struct:tag:db:policy %q, the only supported policy is "snake"`, normalizedCtx, "camel")
+		errEqualFieldNotExist    = fmt.Errorf("%sequal field %q does not exist in type %s", normalizedCtx, "confirm", "object")
+		errEqualFieldsMismatch   = fmt.Errorf("%sequal fields %q and %q must have the same type, got %s and %s", normalizedCtx, "pin", "confirm", "int", "string")
+		errUnitNotNumeric        = fmt.Errorf("%sUnit can only be used on numeric attributes, got string", normalizedCtx)
+		errEqualFieldNotPrim     = fmt.Errorf("%sequal field %q must be of type Boolean, String or numeric, got %s", normalizedCtx, "pin", "bytes")
+		errExclusiveNotExist     = fmt.Errorf("%smutually exclusive field %q does not exist in type %s", normalizedCtx, "email", "object")
+		errExclusiveRequired     = fmt.Errorf("%smutually exclusive field %q cannot be required", normalizedCtx, "id")
+		errOneOfDefault          = fmt.Errorf("%srequired one of field %q cannot have a default value", normalizedCtx, "email")
+		errAnyNotAny             = fmt.Errorf("%sstruct:field:any can only be used on attributes of type Any, got string", normalizedCtx)
+		errAnyUnsupported        = fmt.Errorf(`%sunsupported struct:field:any %q, the only supported value is "json.RawMessage"`, normalizedCtx, "map")
+		errAnyWithType           = fmt.Errorf("%sstruct:field:any cannot be used together with struct:field:type", normalizedCtx)
+		errDurationNotString     = fmt.Errorf("%sDurationFormat can only be used on attributes of type String, got int", normalizedCtx)
+		errDurationValidation    = fmt.Errorf("%sDurationFormat attributes cannot define enum, pattern, length or format validations", normalizedCtx)
 	)
 	cases := map[string]struct {
 		typ        DataType
@@ -150,6 +166,74 @@ func TestAttributeExprValidate(t *testing.T) {
 			},
 			expected: &eval.ValidationErrors{Errors: []error{errAttributeTypeNil}},
 		},
+		"nullable primitive": {
+			typ:      String,
+			metadata: MetaExpr{"nullable": nil},
+			expected: &eval.ValidationErrors{},
+		},
+		"nullable array": {
+			typ:      &Array{ElemType: &AttributeExpr{Type: String}},
+			metadata: MetaExpr{"nullable": nil},
+			expected: &eval.ValidationErrors{Errors: []error{errNullableNotPrimitive}},
+		},
+		"unit numeric": {
+			typ:      Float64,
+			metadata: MetaExpr{"unit": []string{"celsius"}},
+			expected: &eval.ValidationErrors{},
+		},
+		"unit not numeric": {
+			typ:      String,
+			metadata: MetaExpr{"unit": []string{"celsius"}},
+			expected: &eval.ValidationErrors{Errors: []error{errUnitNotNumeric}},
+		},
+		"raw any": {
+			typ:      Any,
+			metadata: MetaExpr{"struct:field:any": []string{"json.RawMessage"}},
+			expected: &eval.ValidationErrors{},
+		},
+		"raw any not any": {
+			typ:      String,
+			metadata: MetaExpr{"struct:field:any": []string{"json.RawMessage"}},
+			expected: &eval.ValidationErrors{Errors: []error{errAnyNotAny}},
+		},
+		"raw any unsupported": {
+			typ:      Any,
+			metadata: MetaExpr{"struct:field:any": []string{"map"}},
+			expected: &eval.ValidationErrors{Errors: []error{errAnyUnsupported}},
+		},
+		"raw any with type": {
+			typ:      Any,
+			metadata: MetaExpr{"struct:field:any": []string{"json.RawMessage"}, "struct:field:type": []string{"json.RawMessage", "encoding/json"}},
+			expected: &eval.ValidationErrors{Errors: []error{errAnyWithType}},
+		},
+		"duration": {
+			typ:        String,
+			validation: &ValidationExpr{Format: FormatDuration},
+			metadata:   MetaExpr{"struct:field:duration": nil},
+			expected:   &eval.ValidationErrors{},
+		},
+		"duration not string": {
+			typ:        Int,
+			validation: &ValidationExpr{Format: FormatDuration},
+			metadata:   MetaExpr{"struct:field:duration": nil},
+			expected:   &eval.ValidationErrors{Errors: []error{errDurationNotString}},
+		},
+		"duration with pattern": {
+			typ:        String,
+			validation: &ValidationExpr{Format: FormatDuration, Pattern: "^1"},
+			metadata:   MetaExpr{"struct:field:duration": nil},
+			expected:   &eval.ValidationErrors{Errors: []error{errDurationValidation}},
+		},
+		"nullable required": {
+			typ: &Object{
+				&NamedAttributeExpr{
+					Name:      "foo",
+					Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"nullable": nil}},
+				},
+			},
+			validation: validation,
+			expected:   &eval.ValidationErrors{Errors: []error{errNullableRequired}},
+		},
 		"attribute type is nil in the array": {
 			typ: &Array{
 				ElemType: &AttributeExpr{
@@ -264,6 +348,94 @@ func TestAttributeExprValidate(t *testing.T) {
 			validation: validation,
 			expected:   &eval.ValidationErrors{Errors: []error{}},
 		},
+		"strip prefix collision": {
+			typ: &Object{
+				{Name: "name", Attribute: &AttributeExpr{Type: String}},
+				{Name: "user_name", Attribute: &AttributeExpr{Type: String}},
+				{Name: "user_id", Attribute: &AttributeExpr{Type: String}},
+			},
+			metadata: MetaExpr{"struct:field:name:strip-prefix": []string{"user_"}},
+			expected: &eval.ValidationErrors{Errors: []error{errStripPrefixCollision}},
+		},
+		"strip prefix overridden": {
+			typ: &Object{
+				{Name: "name", Attribute: &AttributeExpr{Type: String}},
+				{Name: "user_name", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"struct:field:name": []string{"UserName"}}}},
+			},
+			metadata: MetaExpr{"struct:field:name:strip-prefix": []string{"user_"}},
+			expected: &eval.ValidationErrors{Errors: []error{}},
+		},
+		"db tag policy": {
+			typ:      &Object{{Name: "name", Attribute: &AttributeExpr{Type: String}}},
+			metadata: MetaExpr{"struct:tag:db:policy": []string{"snake"}},
+			expected: &eval.ValidationErrors{Errors: []error{}},
+		},
+		"unsupported db tag policy": {
+			typ:      &Object{{Name: "name", Attribute: &AttributeExpr{Type: String}}},
+			metadata: MetaExpr{"struct:tag:db:policy": []string{"camel"}},
+			expected: &eval.ValidationErrors{Errors: []error{errUnsupportedDBPolicy}},
+		},
+		"equal fields": {
+			typ: &Object{
+				{Name: "pin", Attribute: &AttributeExpr{Type: Int}},
+				{Name: "confirm", Attribute: &AttributeExpr{Type: Int}},
+			},
+			validation: &ValidationExpr{EqualFields: [][2]string{{"pin", "confirm"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{}},
+		},
+		"equal field does not exist": {
+			typ:        &Object{{Name: "pin", Attribute: &AttributeExpr{Type: Int}}},
+			validation: &ValidationExpr{EqualFields: [][2]string{{"pin", "confirm"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errEqualFieldNotExist}},
+		},
+		"equal fields type mismatch": {
+			typ: &Object{
+				{Name: "pin", Attribute: &AttributeExpr{Type: Int}},
+				{Name: "confirm", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{EqualFields: [][2]string{{"pin", "confirm"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errEqualFieldsMismatch}},
+		},
+		"equal fields not comparable": {
+			typ: &Object{
+				{Name: "pin", Attribute: &AttributeExpr{Type: Bytes}},
+				{Name: "confirm", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{EqualFields: [][2]string{{"pin", "confirm"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errEqualFieldNotPrim}},
+		},
+		"field groups": {
+			typ: &Object{
+				{Name: "id", Attribute: &AttributeExpr{Type: String}},
+				{Name: "email", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{
+				MutuallyExclusive: [][]string{{"id", "email"}},
+				RequiredOneOf:     [][]string{{"id", "email"}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{}},
+		},
+		"mutually exclusive field does not exist": {
+			typ:        &Object{{Name: "id", Attribute: &AttributeExpr{Type: String}}},
+			validation: &ValidationExpr{MutuallyExclusive: [][]string{{"id", "email"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errExclusiveNotExist}},
+		},
+		"mutually exclusive field required": {
+			typ: &Object{
+				{Name: "id", Attribute: &AttributeExpr{Type: String}},
+				{Name: "email", Attribute: &AttributeExpr{Type: String}},
+			},
+			validation: &ValidationExpr{MutuallyExclusive: [][]string{{"id", "email"}}, Required: []string{"id"}},
+			expected:   &eval.ValidationErrors{Errors: []error{errExclusiveRequired}},
+		},
+		"required one of field with default": {
+			typ: &Object{
+				{Name: "id", Attribute: &AttributeExpr{Type: String}},
+				{Name: "email", Attribute: &AttributeExpr{Type: String, DefaultValue: "a@b.c"}},
+			},
+			validation: &ValidationExpr{RequiredOneOf: [][]string{{"id", "email"}}},
+			expected:   &eval.ValidationErrors{Errors: []error{errOneOfDefault}},
+		},
 		"defines a view but is not a result type": {
 			typ:      Boolean,
 			metadata: metadata,
@@ -307,6 +479,31 @@ func TestAttributeExprValidate(t *testing.T) {
 	}
 }
 
+func TestAttributeExprFinalizeStripPrefix(t *testing.T) {
+	att := &AttributeExpr{
+		Type: &Object{
+			{Name: "user_first_name", Attribute: &AttributeExpr{Type: String}},
+			{Name: "user_id", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"struct:field:name": []string{"UID"}}}},
+			{Name: "user_", Attribute: &AttributeExpr{Type: String}},
+			{Name: "age", Attribute: &AttributeExpr{Type: Int}},
+		},
+		Meta: MetaExpr{"struct:field:name:strip-prefix": []string{"user_"}},
+	}
+	att.Finalize()
+	expected := map[string][]string{
+		"user_first_name": {"first_name"},
+		"user_id":         {"UID"},
+		"user_":           nil,
+		"age":             nil,
+	}
+	for _, nat := range *AsObject(att.Type) {
+		got := nat.Attribute.Meta["struct:field:name"]
+		if len(got) != len(expected[nat.Name]) || (len(got) > 0 && got[0] != expected[nat.Name][0]) {
+			t.Errorf("%s: got struct field name %v, expected %v", nat.Name, got, expected[nat.Name])
+		}
+	}
+}
+
 func TestAttributeExprAllRequired(t *testing.T) {
 	cases := map[string]struct {
 		typ      DataType
@@ -0,0 +1,242 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// BatchExpr describes a method generated from another method of the
+	// same service that processes many payloads at once. The payload of the
+	// batch method is an array of the batched method payloads and its
+	// result is an array of items that hold either the result or the error
+	// produced by the batched method for the payload at the same index.
+	BatchExpr struct {
+		// Name is the name of the batched method.
+		Name string
+		// MaxItems is the maximum number of payloads in a batch, zero
+		// if there is no maximum.
+		MaxItems int
+		// Service is the service that defines the batch.
+		Service *ServiceExpr
+		// Method is the generated batch method, nil if the batched
+		// method cannot be batched.
+		Method *MethodExpr
+	}
+)
+
+// BatchRouteSuffix is appended to the path of the batched method HTTP route to
+// compute the path of the batch method HTTP route.
+const BatchRouteSuffix = "/batch"
+
+// EvalName returns the generic definition name used in error messages.
+func (b *BatchExpr) EvalName() string {
+	return fmt.Sprintf("batch of method %q of service %q", b.Name, b.Service.Name)
+}
+
+// MethodName returns the name of the generated batch method.
+func (b *BatchExpr) MethodName() string {
+	return "batch_" + b.Name
+}
+
+// Batched returns the batched method, nil if there is none.
+func (b *BatchExpr) Batched() *MethodExpr {
+	return b.Service.Method(b.Name)
+}
+
+// Validate makes sure the batched method exists and can be batched.
+func (b *BatchExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if b.MaxItems < 0 {
+		verr.Add(b, "maximum number of items cannot be negative, got %d", b.MaxItems)
+	}
+	m := b.Batched()
+	if m == nil {
+		verr.Add(b, "method %q not found in service %q", b.Name, b.Service.Name)
+		return verr
+	}
+	verr.Merge(b.check(m))
+	return verr
+}
+
+// check returns the reasons why m cannot be batched. The payloads of the
+// batched method must be sent in the request body so the method cannot be
+// streaming or secured (the security attributes would be sent with each item
+// and could not be checked for the batch as a whole) and its HTTP route cannot
+// define path parameters.
+func (b *BatchExpr) check(m *MethodExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if bm := b.Service.Method(b.MethodName()); bm != nil && bm != b.Method {
+		verr.Add(b, "service %q already defines a method named %q", b.Service.Name, b.MethodName())
+	}
+	if m.IsStreaming() {
+		verr.Add(b, "streaming methods cannot be batched")
+	}
+	if m.Payload == nil || m.Payload.Type == Empty {
+		verr.Add(b, "method %q must define a payload to be batched", b.Name)
+	}
+	if isSecured(m) {
+		verr.Add(b, "secured methods cannot be batched")
+	}
+	if m.Result != nil {
+		if rt, ok := m.Result.Type.(*ResultTypeExpr); ok && len(rt.Views) > 1 {
+			if _, ok := m.Result.Meta["view"]; !ok {
+				verr.Add(b, "method %q result type %q defines multiple views, use View to set the view used to render the results", b.Name, rt.Name())
+			}
+		}
+	}
+	if e := batchedEndpoint(m); e != nil {
+		for _, r := range e.Routes {
+			if len(ExtractHTTPWildcards(r.Path)) > 0 {
+				verr.Add(b, "HTTP route %q of method %q cannot define path parameters", r.Path, b.Name)
+			}
+		}
+	}
+	return verr
+}
+
+// addBatchMethods is the transform that adds the methods described with the
+// Batch DSL to the services of the design together with their HTTP endpoints.
+// It runs before the expressions are prepared so that the batch methods are
+// prepared, validated and finalized like the methods defined by the design.
+func addBatchMethods(roots []eval.Root) error {
+	for _, root := range roots {
+		r, ok := root.(*RootExpr)
+		if !ok {
+			continue
+		}
+		var errType UserType
+		for _, svc := range r.Services {
+			for _, b := range svc.Batches {
+				m := b.Batched()
+				if m == nil || len(b.check(m).Errors) > 0 {
+					continue
+				}
+				if errType == nil {
+					errType = newBatchErrorType()
+					r.Types = append(r.Types, errType)
+				}
+				b.Method = newBatchMethod(r, b, m, errType)
+				svc.Methods = append(svc.Methods, b.Method)
+				if e := batchedEndpoint(m); e != nil {
+					be := e.Service.EndpointFor(b.Method.Name, b.Method)
+					be.Routes = []*RouteExpr{{
+						Method:   "POST",
+						Path:     e.Routes[0].Path + BatchRouteSuffix,
+						Endpoint: be,
+					}}
+					be.Responses = []*HTTPResponseExpr{{StatusCode: StatusMultiStatus, Parent: be}}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// newBatchMethod returns the batch method of m. The batch payload and result
+// item types share the payload and result attributes of m so that the batch
+// method uses the types generated for m.
+func newBatchMethod(r *RootExpr, b *BatchExpr, m *MethodExpr, errType UserType) *MethodExpr {
+	item := &Object{{
+		Name: "error",
+		Attribute: &AttributeExpr{
+			Type:        errType,
+			Description: "Error produced by the item if it failed.",
+		},
+	}}
+	if m.Result != nil && m.Result.Type != Empty {
+		named(m.Result, b.Service, m.Name+"Result")
+		res := &NamedAttributeExpr{
+			Name:      "result",
+			Attribute: m.Result,
+		}
+		*item = append(Object{res}, *item...)
+	}
+	itemType := &UserTypeExpr{
+		TypeName: m.Name + "BatchItem",
+		UID:      b.Service.Name + "#" + m.Name + "BatchItem",
+		AttributeExpr: &AttributeExpr{
+			Type:        item,
+			Description: fmt.Sprintf("Outcome of the %s method for one item of a batch.", m.Name),
+		},
+	}
+	r.Types = append(r.Types, itemType)
+	named(m.Payload, b.Service, m.Name+"Payload")
+	payload := &AttributeExpr{Type: &Array{ElemType: m.Payload}}
+	if b.MaxItems > 0 {
+		max := b.MaxItems
+		payload.Validation = &ValidationExpr{MaxLength: &max}
+	}
+	return &MethodExpr{
+		Name:         b.MethodName(),
+		Description:  fmt.Sprintf("Applies the %s method to each payload of the batch.", m.Name),
+		Payload:      payload,
+		Result:       &AttributeExpr{Type: &Array{ElemType: &AttributeExpr{Type: itemType}}},
+		Requirements: m.Requirements,
+		Service:      b.Service,
+		Since:        m.Since,
+		Until:        m.Until,
+		Batch:        b,
+	}
+}
+
+// newBatchErrorType returns the type of the errors of the batch items.
+func newBatchErrorType() UserType {
+	return &UserTypeExpr{
+		TypeName: "BatchItemError",
+		UID:      "#BatchItemError",
+		AttributeExpr: &AttributeExpr{
+			Type: &Object{
+				{Name: "name", Attribute: &AttributeExpr{Type: String, Description: "Name of the error."}},
+				{Name: "message", Attribute: &AttributeExpr{Type: String, Description: "Error message."}},
+			},
+			Description: "Error produced by a batch item.",
+			Validation:  &ValidationExpr{Required: []string{"name", "message"}},
+		},
+	}
+}
+
+// named turns the inline object described by att into a user type with the
+// given name, the codegen does the same for the payloads and results of all
+// methods but the batch methods must refer to the same type.
+func named(att *AttributeExpr, svc *ServiceExpr, name string) {
+	if _, ok := att.Type.(*Object); !ok {
+		return
+	}
+	att.Type = &UserTypeExpr{
+		TypeName:      name,
+		UID:           svc.Name + "#" + name,
+		AttributeExpr: DupAtt(att),
+	}
+}
+
+// batchedEndpoint returns the HTTP endpoint of m, nil if m has no HTTP
+// endpoint with a route.
+func batchedEndpoint(m *MethodExpr) *HTTPEndpointExpr {
+	if Root.API == nil || Root.API.HTTP == nil {
+		return nil
+	}
+	hs := Root.API.HTTP.Service(m.Service.Name)
+	if hs == nil {
+		return nil
+	}
+	e := hs.Endpoint(m.Name)
+	if e == nil || len(e.Routes) == 0 {
+		return nil
+	}
+	return e
+}
+
+// isSecured returns true if m has security requirements including the
+// requirements inherited from its service and from the API.
+func isSecured(m *MethodExpr) bool {
+	reqs := m.Requirements
+	if len(reqs) == 0 {
+		reqs = m.Service.Requirements
+	}
+	if len(reqs) == 0 && Root.API != nil {
+		reqs = Root.API.Requirements
+	}
+	return len(reqs) > 0 && !isNoSecurity(reqs)
+}
@@ -0,0 +1,78 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestBatchDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.BatchValidDSL},
+		{Name: "unknown method", DSL: testdata.BatchUnknownMethodDSL, Error: `method "create" not found in service "batch-unknown-method"`},
+		{Name: "no payload", DSL: testdata.BatchNoPayloadDSL, Error: `method "list" must define a payload to be batched`},
+		{Name: "streaming", DSL: testdata.BatchStreamingDSL, Error: "streaming methods cannot be batched"},
+		{Name: "secured", DSL: testdata.BatchSecuredDSL, Error: "secured methods cannot be batched"},
+		{Name: "path param", DSL: testdata.BatchPathParamDSL, Error: `HTTP route "/users/{id}" of method "update" cannot define path parameters`},
+		{Name: "name conflict", DSL: testdata.BatchNameConflictDSL, Error: `service "batch-name-conflict" already defines a method named "batch_create"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchMethod(t *testing.T) {
+	root := expr.RunDSL(t, testdata.BatchValidDSL)
+	svc := root.Service("batch-valid")
+	m := svc.Method("batch_create")
+	if m == nil {
+		t.Fatal("batch method not found")
+	}
+	if m.Batch != svc.Batches[0] || svc.Batches[0].Method != m {
+		t.Error("batch method not linked to batch")
+	}
+	create := svc.Method("create")
+	payload := expr.AsArray(m.Payload.Type)
+	if payload == nil || payload.ElemType != create.Payload {
+		t.Errorf("got payload type %q, expected an array of the create payload", m.Payload.Type.Name())
+	}
+	if max := m.Payload.Validation.MaxLength; max == nil || *max != 10 {
+		t.Errorf("got max length %v, expected 10", max)
+	}
+	result := expr.AsArray(m.Result.Type)
+	if result == nil {
+		t.Fatalf("got result type %q, expected an array", m.Result.Type.Name())
+	}
+	item := expr.AsObject(result.ElemType.Type)
+	if att := item.Attribute("result"); att != create.Result {
+		t.Error("item result is not the create result")
+	}
+	if item.Attribute("error") == nil {
+		t.Error("item error not found")
+	}
+	e := root.API.HTTP.Service("batch-valid").Endpoint("batch_create")
+	if e == nil {
+		t.Fatal("batch endpoint not found")
+	}
+	if r := e.Routes[0]; r.Method != "POST" || r.Path != "/users/batch" {
+		t.Errorf("got route %s %s, expected POST /users/batch", r.Method, r.Path)
+	}
+	if s := e.Responses[0].StatusCode; s != expr.StatusMultiStatus {
+		t.Errorf("got status %d, expected %d", s, expr.StatusMultiStatus)
+	}
+}
@@ -0,0 +1,54 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+// UnavailableErrorName is the name of the method error returned by the
+// generated code when a request exceeds the maximum number of concurrent
+// requests set with MaxConcurrent.
+const UnavailableErrorName = "unavailable"
+
+type (
+	// ConcurrencyExpr describes the maximum number of requests handled
+	// concurrently by a method.
+	ConcurrencyExpr struct {
+		// Limit is the maximum number of concurrent requests.
+		Limit int
+		// QueueTimeout is the maximum duration requests wait for a
+		// slot when the limit is reached. The zero value indicates
+		// that such requests are rejected right away.
+		QueueTimeout time.Duration
+		// Method is the method whose requests are limited.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *ConcurrencyExpr) EvalName() string {
+	var prefix string
+	if c.Method != nil {
+		prefix = c.Method.EvalName() + " "
+	}
+	return prefix + "concurrency limit"
+}
+
+// Validate makes sure the limit is positive and that the "unavailable" method
+// error, if any, uses the default error type.
+func (c *ConcurrencyExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if c.Limit <= 0 {
+		verr.Add(c, "maximum number of concurrent requests must be greater than 0, got %d", c.Limit)
+	}
+	if c.QueueTimeout < 0 {
+		verr.Add(c, "queue timeout cannot be negative, got %s", c.QueueTimeout)
+	}
+	if c.Method != nil {
+		if e := c.Method.Error(UnavailableErrorName); e != nil && e.Type != ErrorResult {
+			verr.Add(c, "error %q must use the default error type as the method limits concurrent requests", UnavailableErrorName)
+		}
+	}
+	return verr
+}
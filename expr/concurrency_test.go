@@ -0,0 +1,48 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestConcurrencyDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.ConcurrencyValidDSL},
+		{Name: "invalid limit", DSL: testdata.ConcurrencyInvalidLimitDSL, Error: "maximum number of concurrent requests must be greater than 0, got 0"},
+		{Name: "invalid error type", DSL: testdata.ConcurrencyInvalidErrorTypeDSL, Error: `error "unavailable" must use the default error type`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestConcurrencyQueueTimeout(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ConcurrencyValidDSL)
+	c := root.Service("concurrency-valid").Method("method").Concurrency
+	if c == nil {
+		t.Fatal("got nil concurrency limit")
+	}
+	if c.Limit != 10 {
+		t.Errorf("got limit %d, expected 10", c.Limit)
+	}
+	if c.QueueTimeout != 500*time.Millisecond {
+		t.Errorf("got queue timeout %s, expected 500ms", c.QueueTimeout)
+	}
+}
@@ -0,0 +1,105 @@
+package expr
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// ConfigExpr describes the configuration of the API. The generated
+	// config package defines a Config struct with one field per
+	// configuration attribute and a Load function that reads the field
+	// values from environment variables and command line flags.
+	ConfigExpr struct {
+		// Fields is the object attribute listing the configuration
+		// fields.
+		Fields *AttributeExpr
+	}
+)
+
+// Attribute returns the object attribute listing the configuration fields. It
+// makes it possible to use Attribute in the Config DSL.
+func (c *ConfigExpr) Attribute() *AttributeExpr {
+	if c.Fields == nil {
+		c.Fields = &AttributeExpr{Type: &Object{}}
+	}
+	return c.Fields
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (c *ConfigExpr) EvalName() string {
+	return "API configuration"
+}
+
+// EnvVar returns the name of the environment variable that sets the value of
+// the configuration field with the given name. The name is the value of the
+// "config:env" meta of the field if any, the upper case field name otherwise.
+func (c *ConfigExpr) EnvVar(name string) string {
+	if att := AsObject(c.Attribute().Type).Attribute(name); att != nil {
+		if v, ok := att.Meta.Last("config:env"); ok {
+			return v
+		}
+	}
+	return strings.ToUpper(strings.Map(configNameMapper('_'), name))
+}
+
+// FlagName returns the name of the command line flag that sets the value of
+// the configuration field with the given name. The name is the value of the
+// "config:flag" meta of the field if any, the field name using dashes as
+// separators otherwise.
+func (c *ConfigExpr) FlagName(name string) string {
+	if att := AsObject(c.Attribute().Type).Attribute(name); att != nil {
+		if v, ok := att.Meta.Last("config:flag"); ok {
+			return v
+		}
+	}
+	return strings.Map(configNameMapper('-'), name)
+}
+
+// Validate makes sure the configuration fields are strings, booleans or
+// numbers and that the environment variable and flag names are unique.
+func (c *ConfigExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	obj := AsObject(c.Attribute().Type)
+	if obj == nil {
+		verr.Add(c, "configuration must be an object")
+		return verr
+	}
+	verr.Merge(c.Fields.Validate("configuration", c))
+	envs := make(map[string]string)
+	flags := make(map[string]string)
+	for _, nat := range *obj {
+		dt := nat.Attribute.Type
+		if _, ok := dt.(Primitive); !ok || dt.Kind() == BytesKind || dt.Kind() == AnyKind {
+			verr.Add(c, "configuration field %q must be a string, a boolean or a number", nat.Name)
+		}
+		env := c.EnvVar(nat.Name)
+		if other, ok := envs[env]; ok {
+			verr.Add(c, "configuration fields %q and %q use the same environment variable %q", other, nat.Name, env)
+		}
+		envs[env] = nat.Name
+		flag := c.FlagName(nat.Name)
+		if other, ok := flags[flag]; ok {
+			verr.Add(c, "configuration fields %q and %q use the same flag %q", other, nat.Name, flag)
+		}
+		flags[flag] = nat.Name
+	}
+	return verr
+}
+
+// Finalize finalizes the configuration fields.
+func (c *ConfigExpr) Finalize() {
+	c.Attribute().Finalize()
+}
+
+// configNameMapper returns a function that replaces the characters that are
+// not letters or digits with sep.
+func configNameMapper(sep rune) func(rune) rune {
+	return func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return sep
+	}
+}
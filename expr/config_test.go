@@ -0,0 +1,51 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestConfigDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.ConfigValidDSL},
+		{Name: "non primitive", DSL: testdata.ConfigNonPrimitiveDSL, Error: `configuration field "hosts" must be a string, a boolean or a number`},
+		{Name: "duplicate env", DSL: testdata.ConfigDuplicateEnvDSL, Error: `configuration fields "db_url" and "database" use the same environment variable "DB_URL"`},
+		{Name: "duplicate flag", DSL: testdata.ConfigDuplicateFlagDSL, Error: `configuration fields "db_url" and "database" use the same flag "db-url"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigExprNames(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ConfigValidDSL)
+	c := root.API.Config
+	if c == nil {
+		t.Fatal("got nil config")
+	}
+	if env := c.EnvVar("log_level"); env != "LOG_LEVEL" {
+		t.Errorf("got environment variable %q, expected %q", env, "LOG_LEVEL")
+	}
+	if flag := c.FlagName("log_level"); flag != "log-level" {
+		t.Errorf("got flag %q, expected %q", flag, "log-level")
+	}
+	if !c.Fields.IsRequired("log_level") {
+		t.Error("got optional log_level field, expected required")
+	}
+}
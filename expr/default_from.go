@@ -0,0 +1,70 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// DefaultFromExpr describes a payload field initialized with the value
+	// of another payload field when the request does not provide it.
+	DefaultFromExpr struct {
+		// Field is the name of the payload attribute initialized when
+		// absent.
+		Field string
+		// Source is the name of the payload attribute whose value is
+		// copied.
+		Source string
+		// Method is the method whose payload field is initialized.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (d *DefaultFromExpr) EvalName() string {
+	var prefix string
+	if d.Method != nil {
+		prefix = d.Method.EvalName() + " "
+	}
+	return fmt.Sprintf("%sdefault of %q from %q", prefix, d.Field, d.Source)
+}
+
+// Validate makes sure both attributes are defined by the payload, that they
+// have the same type and that the field is an optional attribute that does not
+// define a default value.
+func (d *DefaultFromExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	obj := AsObject(d.Method.Payload.Type)
+	if obj == nil {
+		verr.Add(d, "DefaultFrom requires a payload that is an object")
+		return verr
+	}
+	att := obj.Attribute(d.Field)
+	if att == nil {
+		verr.Add(d, "payload does not define attribute %q", d.Field)
+	}
+	src := obj.Attribute(d.Source)
+	if src == nil {
+		verr.Add(d, "payload does not define source attribute %q", d.Source)
+	}
+	if att == nil || src == nil {
+		return verr
+	}
+	if d.Field == d.Source {
+		verr.Add(d, "attribute %q cannot default to itself", d.Field)
+	}
+	if att.Type.Hash() != src.Type.Hash() {
+		verr.Add(d, "attribute %q of type %s cannot default to attribute %q of type %s", d.Field, att.Type.Name(), d.Source, src.Type.Name())
+	}
+	if d.Method.Payload.IsRequired(d.Field) {
+		verr.Add(d, "attribute %q cannot be required, defaults from other attributes only apply to absent values", d.Field)
+	}
+	if att.DefaultValue != nil || att.MethodDefault(d.Method.Name) != nil {
+		verr.Add(d, "attribute %q cannot define both a default value and a default from another attribute", d.Field)
+	}
+	if att.IsNullable() || src.IsNullable() {
+		verr.Add(d, "attributes %q and %q cannot be nullable", d.Field, d.Source)
+	}
+	return verr
+}
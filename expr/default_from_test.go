@@ -0,0 +1,39 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestDefaultFromDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.DefaultFromValidDSL},
+		{Name: "unknown source", DSL: testdata.DefaultFromUnknownSourceDSL, Error: `payload does not define source attribute "username"`},
+		{Name: "type mismatch", DSL: testdata.DefaultFromTypeMismatchDSL, Error: `attribute "display_name" of type string cannot default to attribute "id" of type int`},
+		{Name: "required", DSL: testdata.DefaultFromRequiredDSL, Error: `attribute "display_name" cannot be required`},
+		{Name: "cycle", DSL: testdata.DefaultFromCycleDSL, Error: `defaults from other attributes of "display_name" form a cycle`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				root := expr.RunDSL(t, c.DSL)
+				ds := root.Services[0].Methods[0].DefaultsFrom
+				if len(ds) != 2 || ds[0].Field != "display_name" || ds[0].Source != "nickname" {
+					t.Errorf("got defaults %v, expected display_name from nickname and nickname from username", ds)
+				}
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
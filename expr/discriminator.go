@@ -0,0 +1,157 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// DiscriminatorExpr describes the attribute of a base type whose value
+	// identifies the concrete type of an object among the types that extend
+	// the base type.
+	DiscriminatorExpr struct {
+		// PropertyName is the name of the discriminator attribute.
+		PropertyName string
+		// Mappings lists the discriminator values and the corresponding
+		// types.
+		Mappings []*DiscriminatorMappingExpr
+		// Base is the base type that defines the discriminator.
+		Base UserType
+	}
+
+	// DiscriminatorMappingExpr maps a discriminator value to a type.
+	DiscriminatorMappingExpr struct {
+		// Value is the discriminator value.
+		Value string
+		// Type is the type identified by the value.
+		Type DataType
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (d *DiscriminatorExpr) EvalName() string {
+	if d.Base == nil {
+		return fmt.Sprintf("discriminator %q", d.PropertyName)
+	}
+	return fmt.Sprintf("discriminator %q of type %q", d.PropertyName, d.Base.Name())
+}
+
+// ValueOf returns the discriminator value mapped to the given type and true if
+// there is one, the empty string and false otherwise.
+func (d *DiscriminatorExpr) ValueOf(dt DataType) (string, bool) {
+	ut, ok := dt.(UserType)
+	if !ok {
+		return "", false
+	}
+	for _, m := range d.Mappings {
+		if mt, ok := m.Type.(UserType); ok && mt.ID() == ut.ID() {
+			return m.Value, true
+		}
+	}
+	return "", false
+}
+
+// Validate makes sure the discriminator attribute is a string attribute of the
+// base type and that the mapped types are distinct object user types that
+// define the discriminator attribute.
+func (d *DiscriminatorExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	att := d.Base.Attribute()
+	if !IsObject(att.Type) {
+		verr.Add(d, "Discriminator can only be used on object types, got %s", att.Type.Name())
+		return verr
+	}
+	if p := att.Find(d.PropertyName); p == nil {
+		verr.Add(d, "attribute %q does not exist", d.PropertyName)
+	} else if p.Type != String {
+		verr.Add(d, "attribute %q must be of type String, got %s", d.PropertyName, p.Type.Name())
+	}
+	if len(d.Mappings) == 0 {
+		verr.Add(d, "Discriminator must map at least one value")
+	}
+	var (
+		values = make(map[string]struct{}, len(d.Mappings))
+		types  = make(map[string]string, len(d.Mappings))
+	)
+	for _, m := range d.Mappings {
+		if m.Value == "" {
+			verr.Add(d, "value cannot be empty")
+		}
+		if _, ok := values[m.Value]; ok {
+			verr.Add(d, "value %q is mapped more than once", m.Value)
+		}
+		values[m.Value] = struct{}{}
+		ut, ok := m.Type.(UserType)
+		if !ok || !IsObject(ut) {
+			verr.Add(d, "value %q must be mapped to an object user type, got %s", m.Value, m.Type.Name())
+			continue
+		}
+		if other, ok := types[ut.ID()]; ok {
+			verr.Add(d, "values %q and %q are mapped to the same type %s", other, m.Value, ut.Name())
+		}
+		types[ut.ID()] = m.Value
+		if ut.Attribute().Find(d.PropertyName) == nil {
+			verr.Add(d, "type %s mapped to value %q does not define attribute %q", ut.Name(), m.Value, d.PropertyName)
+		}
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// DiscriminatedBase returns the base type extended by att that defines a
+// discriminator if any, nil otherwise.
+func DiscriminatedBase(att *AttributeExpr) UserType {
+	for _, b := range att.Bases {
+		if ut, ok := b.(UserType); ok && ut.Attribute().Discriminator != nil {
+			return ut
+		}
+	}
+	return nil
+}
+
+// UnionDiscriminator returns the discriminator of the base type extended by
+// all the types of the values of u if the discriminator maps all of them, nil
+// otherwise. The values of such unions are identified by their discriminator
+// values when serialized.
+func UnionDiscriminator(u *Union) *DiscriminatorExpr {
+	if len(u.Values) == 0 {
+		return nil
+	}
+	var base UserType
+	for _, nat := range u.Values {
+		ut, ok := nat.Attribute.Type.(UserType)
+		if !ok {
+			return nil
+		}
+		b := DiscriminatedBase(ut.Attribute())
+		if b == nil || base != nil && b.ID() != base.ID() {
+			return nil
+		}
+		base = b
+	}
+	d := base.Attribute().Discriminator
+	for _, nat := range u.Values {
+		if _, ok := d.ValueOf(nat.Attribute.Type); !ok {
+			return nil
+		}
+	}
+	return d
+}
+
+// UnionTypeNames returns the names that identify the types of the values of u
+// when serialized: the discriminator values if u is discriminated (see
+// UnionDiscriminator) and the names of the union attributes otherwise.
+func UnionTypeNames(u *Union) []string {
+	d := UnionDiscriminator(u)
+	names := make([]string, len(u.Values))
+	for i, nat := range u.Values {
+		names[i] = nat.Name
+		if d != nil {
+			names[i], _ = d.ValueOf(nat.Attribute.Type)
+		}
+	}
+	return names
+}
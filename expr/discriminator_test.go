@@ -0,0 +1,57 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestDiscriminatorDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.DiscriminatorValidDSL},
+		{Name: "missing attribute", DSL: testdata.DiscriminatorMissingAttributeDSL, Error: `attribute "kind" does not exist`},
+		{Name: "not string", DSL: testdata.DiscriminatorNotStringDSL, Error: `attribute "kind" must be of type String, got int`},
+		{Name: "empty", DSL: testdata.DiscriminatorEmptyDSL, Error: "Discriminator must map at least one value"},
+		{Name: "duplicate value", DSL: testdata.DiscriminatorDuplicateValueDSL, Error: `value "dog" is mapped more than once`},
+		{Name: "duplicate type", DSL: testdata.DiscriminatorDuplicateTypeDSL, Error: `values "dog" and "hound" are mapped to the same type Dog`},
+		{Name: "not object", DSL: testdata.DiscriminatorNotObjectDSL, Error: `value "dog" must be mapped to an object user type, got Name`},
+		{Name: "mapped type missing attribute", DSL: testdata.DiscriminatorMappedTypeMissingAttributeDSL, Error: `type Dog mapped to value "dog" does not define attribute "kind"`},
+		{Name: "unknown type", DSL: testdata.DiscriminatorUnknownTypeDSL, Error: "invalid Map argument: not a type and not a known user type name"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestUnionDiscriminator(t *testing.T) {
+	root := expr.RunDSL(t, testdata.DiscriminatorValidDSL)
+	pet := expr.AsObject(root.Service("discriminator-valid").Method("method").Payload.Type).Attribute("pet")
+	d := expr.UnionDiscriminator(expr.AsUnion(pet.Type))
+	if d == nil {
+		t.Fatal("got nil discriminator")
+	}
+	if d.Base.Name() != "Pet" {
+		t.Errorf("got base %q, expected %q", d.Base.Name(), "Pet")
+	}
+	if v, ok := d.ValueOf(root.UserType("Cat")); !ok || v != "cat" {
+		t.Errorf("got value %q (%v) for Cat, expected %q", v, ok, "cat")
+	}
+	if base := expr.DiscriminatedBase(root.UserType("Dog").Attribute()); base == nil || base.Name() != "Pet" {
+		t.Errorf("got base %v for Dog, expected Pet", base)
+	}
+}
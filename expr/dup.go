@@ -47,16 +47,21 @@ func (d *dupper) DupAttribute(att *AttributeExpr) *AttributeExpr {
 		metaDup = att.Meta.Dup()
 	}
 	dup := AttributeExpr{
-		Type:         d.DupType(att.Type),
-		Description:  att.Description,
-		References:   att.References,
-		Bases:        att.Bases,
-		Validation:   valDup,
-		Meta:         metaDup,
-		DefaultValue: att.DefaultValue,
-		DSLFunc:      att.DSLFunc,
-		UserExamples: att.UserExamples,
-		finalized:    att.finalized,
+		Type:           d.DupType(att.Type),
+		Description:    att.Description,
+		References:     att.References,
+		Bases:          att.Bases,
+		Validation:     valDup,
+		EnumMap:        att.EnumMap,
+		Discriminator:  att.Discriminator,
+		Reserved:       att.Reserved,
+		Meta:           metaDup,
+		DefaultValue:   att.DefaultValue,
+		MethodDefaults: att.MethodDefaults,
+		Aliases:        att.Aliases,
+		DSLFunc:        att.DSLFunc,
+		UserExamples:   att.UserExamples,
+		finalized:      att.finalized,
 	}
 	d.ats[&dup] = struct{}{}
 	return &dup
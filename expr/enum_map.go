@@ -0,0 +1,94 @@
+package expr
+
+import (
+	"math"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// EnumMapExpr describes a string enum whose values are also identified
+	// by numbers. The names are used by the HTTP transport while the gRPC
+	// transport uses the numbers.
+	EnumMapExpr struct {
+		// Values lists the enum values.
+		Values []*EnumMapValueExpr
+		// Fallback is the name of the value used when decoding unknown
+		// numbers, unknown numbers are rejected if empty.
+		Fallback string
+	}
+
+	// EnumMapValueExpr is a value of an enum map.
+	EnumMapValueExpr struct {
+		// Name is the value name.
+		Name string
+		// Number is the value number.
+		Number int
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (e *EnumMapExpr) EvalName() string {
+	return "enum map"
+}
+
+// Names returns the names of the enum values.
+func (e *EnumMapExpr) Names() []interface{} {
+	names := make([]interface{}, len(e.Values))
+	for i, v := range e.Values {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// Numbers returns the numbers of the enum values.
+func (e *EnumMapExpr) Numbers() []interface{} {
+	numbers := make([]interface{}, len(e.Values))
+	for i, v := range e.Values {
+		numbers[i] = v.Number
+	}
+	return numbers
+}
+
+// Number returns the number of the value with the given name and true if
+// there is one, 0 and false otherwise.
+func (e *EnumMapExpr) Number(name string) (int, bool) {
+	for _, v := range e.Values {
+		if v.Name == name {
+			return v.Number, true
+		}
+	}
+	return 0, false
+}
+
+// Validate makes sure the enum map defines values with unique names and
+// numbers.
+func (e *EnumMapExpr) Validate(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if len(e.Values) == 0 {
+		verr.Add(parent, "%sEnumMap must define at least one value", ctx)
+	}
+	var (
+		names   = make(map[string]struct{}, len(e.Values))
+		numbers = make(map[int]string, len(e.Values))
+	)
+	for _, v := range e.Values {
+		if _, ok := names[v.Name]; ok {
+			verr.Add(parent, "%sEnumMap value %q is defined more than once", ctx, v.Name)
+		}
+		names[v.Name] = struct{}{}
+		if other, ok := numbers[v.Number]; ok {
+			verr.Add(parent, "%sEnumMap values %q and %q have the same number %d", ctx, other, v.Name, v.Number)
+		}
+		numbers[v.Number] = v.Name
+		if v.Number < math.MinInt32 || v.Number > math.MaxInt32 {
+			verr.Add(parent, "%sEnumMap value %q number %d does not fit in 32 bits", ctx, v.Name, v.Number)
+		}
+	}
+	if e.Fallback != "" {
+		if _, ok := names[e.Fallback]; !ok {
+			verr.Add(parent, "%sEnumMap fallback %q is not an enum value", ctx, e.Fallback)
+		}
+	}
+	return verr
+}
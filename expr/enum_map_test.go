@@ -0,0 +1,53 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestEnumMapDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.EnumMapValidDSL},
+		{Name: "not string", DSL: testdata.EnumMapNotStringDSL, Error: "EnumMap can only be used on attributes of type String, got int"},
+		{Name: "empty", DSL: testdata.EnumMapEmptyDSL, Error: "EnumMap must define at least one value"},
+		{Name: "duplicate name", DSL: testdata.EnumMapDuplicateNameDSL, Error: `EnumMap value "ACTIVE" is defined more than once`},
+		{Name: "duplicate number", DSL: testdata.EnumMapDuplicateNumberDSL, Error: `EnumMap values "UNKNOWN" and "ACTIVE" have the same number 1`},
+		{Name: "invalid fallback", DSL: testdata.EnumMapInvalidFallbackDSL, Error: `EnumMap fallback "UNKNOWN" is not an enum value`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestEnumMapDSLValues(t *testing.T) {
+	root := expr.RunDSL(t, testdata.EnumMapValidDSL)
+	att := expr.AsObject(root.Service("enum-map-valid").Method("method").Payload.Type).Attribute("status")
+	if att.EnumMap == nil {
+		t.Fatal("got nil enum map")
+	}
+	if att.EnumMap.Fallback != "UNKNOWN" {
+		t.Errorf("got fallback %q, expected %q", att.EnumMap.Fallback, "UNKNOWN")
+	}
+	if n, ok := att.EnumMap.Number("ACTIVE"); !ok || n != 1 {
+		t.Errorf("got number %d (%v) for ACTIVE, expected 1", n, ok)
+	}
+	if att.Validation == nil || len(att.Validation.Values) != 2 || att.Validation.Values[0] != "UNKNOWN" || att.Validation.Values[1] != "ACTIVE" {
+		t.Errorf("got enum validation %v, expected the value names", att.Validation)
+	}
+}
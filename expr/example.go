@@ -72,11 +72,35 @@ func (a *AttributeExpr) Example(r *ExampleGenerator) interface{} {
 			}
 		}
 		if example == nil {
-			example = a.Type.Example(r)
+			example = removeExclusiveFields(a, a.Type.Example(r))
 		}
 		return example
 	}
-	return a.Type.Example(r)
+	return removeExclusiveFields(a, a.Type.Example(r))
+}
+
+// removeExclusiveFields removes the fields of the object example ex that are
+// mutually exclusive with a field set before them so that the example passes
+// the MutuallyExclusive validations of a.
+func removeExclusiveFields(a *AttributeExpr, ex interface{}) interface{} {
+	m, ok := ex.(map[string]interface{})
+	if !ok || a.Validation == nil {
+		return ex
+	}
+	for _, g := range a.Validation.MutuallyExclusive {
+		set := false
+		for _, n := range g {
+			if _, ok := m[n]; !ok {
+				continue
+			}
+			if set {
+				delete(m, n)
+			} else {
+				set = true
+			}
+		}
+	}
+	return ex
 }
 
 // NewLength returns an int that validates the generator attribute length
@@ -126,7 +150,7 @@ func hasEnumValidation(a *AttributeExpr) bool {
 }
 
 func hasFormatValidation(a *AttributeExpr) bool {
-	return a.Validation != nil && a.Validation.Format != ""
+	return a.Validation != nil && a.Validation.Format.IsSupported()
 }
 
 func hasPatternValidation(a *AttributeExpr) bool {
@@ -213,7 +237,8 @@ func byFormat(a *AttributeExpr, r *ExampleGenerator) interface{} {
 			}
 			return uuid.String()
 		}(),
-		FormatJSON: `{"name":"example","email":"mail@example.com"}`,
+		FormatJSON:     `{"name":"example","email":"mail@example.com"}`,
+		FormatDuration: "1h30m",
 	}[format]; ok {
 		return res
 	}
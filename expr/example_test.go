@@ -88,3 +88,27 @@ func TestExample(t *testing.T) {
 		})
 	}
 }
+
+func TestUserTypeExampleSeed(t *testing.T) {
+	newType := func(name string) *expr.UserTypeExpr {
+		return &expr.UserTypeExpr{
+			TypeName: name,
+			AttributeExpr: &expr.AttributeExpr{Type: &expr.Object{
+				{Name: "name", Attribute: &expr.AttributeExpr{Type: expr.String}},
+				{Name: "count", Attribute: &expr.AttributeExpr{Type: expr.Int}},
+			}},
+		}
+	}
+	ut := newType("Type")
+	expected := ut.Example(expr.NewRandom("test"))
+
+	r := expr.NewRandom("test")
+	newType("Other").Example(r)
+	r.Int()
+	if example := ut.Example(r); !reflect.DeepEqual(example, expected) {
+		t.Errorf("invalid example: got %v, expected %v", example, expected)
+	}
+	if example := newType("Other").Example(expr.NewRandom("test")); reflect.DeepEqual(example, expected) {
+		t.Errorf("got the same example %v for types with different names", example)
+	}
+}
@@ -0,0 +1,62 @@
+package expr_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestExtendPayload(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ExtendPayloadDSL)
+	payload := root.Service("extend-payload").Method("method").Payload
+	obj := expr.AsObject(payload.Type)
+	if obj == nil {
+		t.Fatalf("got payload type %s, expected an object", payload.Type.Name())
+	}
+	var names []string
+	for _, nat := range *obj {
+		names = append(names, nat.Name)
+	}
+	sort.Strings(names)
+	if got, expected := strings.Join(names, ","), "created_by,name,tenant_id,updated_by"; got != expected {
+		t.Errorf("got attributes %s, expected %s", got, expected)
+	}
+	required := payload.AllRequired()
+	sort.Strings(required)
+	if got, expected := strings.Join(required, ","), "created_by,name,tenant_id"; got != expected {
+		t.Errorf("got required attributes %s, expected %s", got, expected)
+	}
+}
+
+func TestExtendPayloadConflict(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.ExtendPayloadConflictDSL)
+	expected := `attribute "tenant_id" is defined with different types in extended types AuditFields and TenantFields`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("got error %q, expected to contain %q", err.Error(), expected)
+	}
+}
+
+func TestExtendStripPrefix(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ExtendStripPrefixDSL)
+	fieldNames := func(typ string) string {
+		var names []string
+		for _, nat := range *expr.AsObject(root.UserType(typ).Attribute().Type) {
+			name := "-"
+			if n, ok := nat.Attribute.Meta["struct:field:name"]; ok {
+				name = n[0]
+			}
+			names = append(names, nat.Name+":"+name)
+		}
+		sort.Strings(names)
+		return strings.Join(names, ",")
+	}
+	if got, expected := fieldNames("Account"), "user_email:email,user_id:id,user_name:name"; got != expected {
+		t.Errorf("got Account field names %s, expected %s", got, expected)
+	}
+	if got, expected := fieldNames("User"), "user_email:-,user_name:-"; got != expected {
+		t.Errorf("got User field names %s, expected %s", got, expected)
+	}
+}
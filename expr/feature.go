@@ -0,0 +1,44 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+// FeatureDisabledErrorName is the name of the method error returned by the
+// generated code when the feature flag that gates the method is disabled.
+const FeatureDisabledErrorName = "feature_disabled"
+
+type (
+	// FeatureGateExpr describes the feature flag that enables a method at
+	// runtime.
+	FeatureGateExpr struct {
+		// Name is the name of the feature flag.
+		Name string
+		// Method is the gated method.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (f *FeatureGateExpr) EvalName() string {
+	var prefix string
+	if f.Method != nil {
+		prefix = f.Method.EvalName() + " "
+	}
+	return prefix + "feature gate"
+}
+
+// Validate makes sure the feature flag has a name and that the
+// "feature_disabled" method error, if any, uses the default error type.
+func (f *FeatureGateExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if f.Name == "" {
+		verr.Add(f, "feature name cannot be empty")
+	}
+	if f.Method != nil {
+		if e := f.Method.Error(FeatureDisabledErrorName); e != nil && e.Type != ErrorResult {
+			verr.Add(f, "error %q must use the default error type as the method is gated by a feature flag", FeatureDisabledErrorName)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,40 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestFeatureGateDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.FeatureGateValidDSL},
+		{Name: "empty name", DSL: testdata.FeatureGateEmptyNameDSL, Error: "feature name cannot be empty"},
+		{Name: "invalid error type", DSL: testdata.FeatureGateInvalidErrorTypeDSL, Error: `error "feature_disabled" must use the default error type`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				root := expr.RunDSL(t, c.DSL)
+				f := root.Services[0].Methods[0].FeatureGate
+				if f == nil {
+					t.Fatal("got nil feature gate")
+				}
+				if f.Name != "new_checkout" {
+					t.Errorf("got feature %q, expected %q", f.Name, "new_checkout")
+				}
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+// FieldOrderExpr describes the order of the fields of an object type. The
+// listed fields come first in the given order followed by the other fields in
+// the order they are defined.
+type FieldOrderExpr struct {
+	// Fields lists the names of the fields in order.
+	Fields []string
+	// Type is the type whose fields are ordered.
+	Type UserType
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (f *FieldOrderExpr) EvalName() string {
+	if f.Type == nil {
+		return "field order"
+	}
+	return fmt.Sprintf("field order of type %q", f.Type.Name())
+}
+
+// Validate makes sure the type is an object and that the listed fields are
+// distinct fields of the type.
+func (f *FieldOrderExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	att := f.Type.Attribute()
+	if !IsObject(att.Type) {
+		verr.Add(f, "FieldOrder can only be used on object types, got %s", att.Type.Name())
+		return verr
+	}
+	seen := make(map[string]struct{}, len(f.Fields))
+	for _, n := range f.Fields {
+		if _, ok := seen[n]; ok {
+			verr.Add(f, "field %q is listed more than once", n)
+		}
+		seen[n] = struct{}{}
+		if att.Find(n) == nil {
+			verr.Add(f, "field %q does not exist", n)
+		}
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// Finalize reorders the fields of the type. It must run after the type is
+// finalized so that the fields inherited from the extended types are ordered
+// as well.
+func (f *FieldOrderExpr) Finalize() {
+	obj := AsObject(f.Type)
+	if obj == nil {
+		return
+	}
+	ordered := make(Object, 0, len(*obj))
+	listed := make(map[string]struct{}, len(f.Fields))
+	for _, n := range f.Fields {
+		for _, nat := range *obj {
+			if nat.Name == n {
+				ordered = append(ordered, nat)
+				listed[n] = struct{}{}
+				break
+			}
+		}
+	}
+	for _, nat := range *obj {
+		if _, ok := listed[nat.Name]; !ok {
+			ordered = append(ordered, nat)
+		}
+	}
+	*obj = ordered
+}
@@ -0,0 +1,44 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestFieldOrderDSL(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected []string
+		Error    string
+	}{
+		{Name: "valid", DSL: testdata.FieldOrderValidDSL, Expected: []string{"id", "owner", "name", "created_at"}},
+		{Name: "extend", DSL: testdata.FieldOrderExtendDSL, Expected: []string{"created_at", "id", "name"}},
+		{Name: "result type", DSL: testdata.FieldOrderResultTypeDSL, Expected: []string{"id", "name"}},
+		{Name: "unknown", DSL: testdata.FieldOrderUnknownDSL, Error: `field "unknown" does not exist`},
+		{Name: "duplicate", DSL: testdata.FieldOrderDuplicateDSL, Error: `field "id" is listed more than once`},
+		{Name: "not object", DSL: testdata.FieldOrderNotObjectDSL, Error: "FieldOrder can only be used on object types, got string"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error != "" {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, c.DSL)
+			var names []string
+			for _, nat := range *expr.AsObject(root.UserType("Account")) {
+				names = append(names, nat.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(c.Expected, ",") {
+				t.Errorf("got fields %v, expected %v", names, c.Expected)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package expr
 
+import (
+	"strconv"
+
+	"goa.design/goa/v3/eval"
+)
+
 type (
 	// GRPCExpr contains the API level gRPC specific expressions.
 	GRPCExpr struct {
@@ -37,3 +43,22 @@ func (g *GRPCExpr) ServiceFor(s *ServiceExpr) *GRPCServiceExpr {
 func (g *GRPCExpr) EvalName() string {
 	return "API GRPC"
 }
+
+// Validate makes sure the size of the client connection pools set with the
+// "grpc:client:pool" meta is a positive integer.
+func (g *GRPCExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if v, ok := Root.API.Meta.Last("grpc:client:pool"); ok {
+		if n, err := strconv.Atoi(v); err != nil || n <= 0 {
+			verr.Add(Root.API, "invalid gRPC client connection pool size %q, must be an integer greater than 0", v)
+		}
+	}
+	return verr
+}
+
+// Prepare initializes the error responses defined globally.
+func (g *GRPCExpr) Prepare() {
+	for _, er := range g.Errors {
+		er.Response.Prepare()
+	}
+}
@@ -334,6 +334,9 @@ func (e *GRPCEndpointExpr) Finalize() {
 				nat.Attribute.Meta.Merge(patt.Meta)
 			}
 		}
+		if e.Request.Reserved == nil {
+			e.Request.Reserved = reservedFields(e.MethodExpr.Payload)
+		}
 	} else {
 		// method payload is not an object type.
 		if e.MethodExpr.StreamingPayload.Type != Empty {
@@ -519,7 +522,7 @@ func getSecurityAttributes(m *MethodExpr) []string {
 }
 
 // hasAnyType recurses through the given attribute and returns validation error
-// if any attribute is of Any type.
+// if any attribute is of Any type, is nullable or uses DurationFormat.
 func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[string]struct{}) *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
 	if a.Type == Any {
@@ -568,6 +571,12 @@ func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[
 				if nat.Attribute.Type == Any {
 					verr.Add(e, "Attribute %q is Any type which is not supported in gRPC", nat.Name)
 				}
+				if nat.Attribute.IsNullable() {
+					verr.Add(e, "Attribute %q is nullable which is not supported in gRPC", nat.Name)
+				}
+				if nat.Attribute.IsDuration() {
+					verr.Add(e, "Attribute %q uses DurationFormat which is not supported in gRPC", nat.Name)
+				}
 				continue
 			}
 			verr.Merge(e.hasAnyType(nat.Attribute, typ, seen...))
@@ -186,6 +186,9 @@ func (r *GRPCResponseExpr) Finalize(a *GRPCEndpointExpr, svcAtt *AttributeExpr)
 				nat.Attribute.Meta.Merge(svcAtt.Meta)
 			}
 		}
+		if r.Message.Reserved == nil {
+			r.Message.Reserved = reservedFields(svcAtt)
+		}
 	} else {
 		// method result is not an object type. Initialize response header or
 		// trailer metadata if defined or else initialize response message.
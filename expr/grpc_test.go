@@ -0,0 +1,42 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestGRPCClientPoolValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Size  string
+		Error string
+	}{
+		{"valid", "4", ""},
+		{"zero", "0", `API test: invalid gRPC client connection pool size "0", must be an integer greater than 0`},
+		{"not a number", "many", `API test: invalid gRPC client connection pool size "many", must be an integer greater than 0`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			dsl := func() {
+				API("test", func() {
+					Meta("grpc:client:pool", c.Size)
+				})
+				Service("Pool", func() {
+					Method("Method", func() {
+						GRPC(func() {})
+					})
+				})
+			}
+			if c.Error == "" {
+				expr.RunDSL(t, dsl)
+			} else {
+				err := expr.RunInvalidDSL(t, dsl)
+				if err.Error() != c.Error {
+					t.Errorf("\ngot error %q\nexpected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package expr
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/eval"
 )
 
 type (
@@ -29,6 +33,13 @@ type (
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
 		Errors []*HTTPErrorExpr
+		// CORS is the default CORS policy applied to all the API
+		// endpoints if any.
+		CORS *HTTPCORSExpr
+		// MethodOverrides lists the methods that may override the
+		// method of POST requests using the X-HTTP-Method-Override
+		// header if any.
+		MethodOverrides []string
 	}
 )
 
@@ -47,6 +58,119 @@ func ExtractHTTPWildcards(path string) []string {
 	return wcs
 }
 
+// HTTPParamStyle returns the serialization style of the path parameter
+// described by att as set with the Style DSL, "simple" by default, and whether
+// the elements of array values are serialized separately (see Explode).
+func HTTPParamStyle(att *AttributeExpr) (style string, explode bool) {
+	style = "simple"
+	if s, ok := att.Meta.Last("http:param:style"); ok && s != "" {
+		style = s
+	}
+	if e, ok := att.Meta["http:param:explode"]; ok {
+		explode = len(e) == 0 || e[len(e)-1] != "false"
+	}
+	return
+}
+
+// HTTPParamDelimiter returns the separator of the elements of the array query
+// string parameter described by att as set with the Delimiter DSL, a comma if
+// the parameter uses Explode(false) and the empty string if the elements are
+// serialized separately (the default).
+func HTTPParamDelimiter(att *AttributeExpr) string {
+	if d, ok := att.Meta.Last("http:param:delimiter"); ok && d != "" {
+		return d
+	}
+	if e, ok := att.Meta.Last("http:param:explode"); ok && e == "false" {
+		return ","
+	}
+	return ""
+}
+
+// HTTPParamFlags returns whether the query string parameter described by att
+// may be given with an empty value (see AllowEmptyValue) and whether its value
+// may contain reserved characters (see AllowReserved).
+func HTTPParamFlags(att *AttributeExpr) (allowEmpty, allowReserved bool) {
+	_, allowEmpty = att.Meta["http:param:allow-empty-value"]
+	_, allowReserved = att.Meta["http:param:allow-reserved"]
+	return
+}
+
+// CryptoFields returns the names of the attributes of the object described by
+// att that are flagged with the "crypto:field" meta, nil if att is not an
+// object.
+func CryptoFields(att *AttributeExpr) []string {
+	obj := AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	var fields []string
+	for _, nat := range *obj {
+		if isCryptoField(nat.Attribute) {
+			fields = append(fields, nat.Name)
+		}
+	}
+	return fields
+}
+
+// isCryptoField returns true if att is flagged with the "crypto:field" meta.
+func isCryptoField(att *AttributeExpr) bool {
+	v, ok := att.Meta.Last("crypto:field")
+	return ok && v != "false"
+}
+
+// ParseHTTPPathPatterns extracts the regular expressions that constrain the
+// wildcards of the given HTTP path, e.g. "/users/{id:[0-9]+}". It returns the
+// path without the regular expressions and the regular expressions indexed by
+// wildcard name.
+func ParseHTTPPathPatterns(path string) (string, map[string]string, error) {
+	var (
+		b        strings.Builder
+		patterns map[string]string
+	)
+	for i := 0; i < len(path); i++ {
+		if path[i] != '{' || i == 0 || path[i-1] != '/' {
+			b.WriteByte(path[i])
+			continue
+		}
+		depth, end := 0, -1
+		for j := i; j < len(path) && end < 0; j++ {
+			switch path[j] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					end = j
+				}
+			}
+		}
+		if end < 0 {
+			return "", nil, fmt.Errorf("missing closing brace in path %q", path)
+		}
+		wildcard := path[i+1 : end]
+		idx := strings.Index(wildcard, ":")
+		if idx < 0 {
+			b.WriteString(path[i : end+1])
+			i = end
+			continue
+		}
+		name, pattern := wildcard[:idx], wildcard[idx+1:]
+		if pattern == "" {
+			return "", nil, fmt.Errorf("empty pattern for wildcard %q in path %q", name, path)
+		}
+		if !strings.HasPrefix(name, "*") && strings.Contains(pattern, "/") {
+			return "", nil, fmt.Errorf("pattern %q of wildcard %q in path %q cannot match slashes", pattern, name, path)
+		}
+		if patterns == nil {
+			patterns = make(map[string]string)
+		}
+		patterns[strings.TrimPrefix(name, "*")] = pattern
+		b.WriteString("{" + name + "}")
+		i = end
+	}
+	return b.String(), patterns, nil
+}
+
 // Service returns the service with the given name if any.
 func (h *HTTPExpr) Service(name string) *HTTPServiceExpr {
 	for _, res := range h.Services {
@@ -75,6 +199,32 @@ func (h *HTTPExpr) EvalName() string {
 	return "API HTTP"
 }
 
+// Validate makes sure the API level CORS policy is valid and that only PUT,
+// PATCH and DELETE may override the method of POST requests.
+func (h *HTTPExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if h.CORS != nil {
+		if err := h.CORS.Validate(); err != nil {
+			verr.AddError(h.CORS, err)
+		}
+	}
+	for _, m := range h.MethodOverrides {
+		if m != "PUT" && m != "PATCH" && m != "DELETE" {
+			verr.Add(h, "invalid method override %q, POST requests may only be overridden with PUT, PATCH or DELETE", m)
+		}
+	}
+	if f, ok := Root.API.Meta.Last("http:error:format"); ok && f != "problem+json" {
+		verr.Add(Root.API, "invalid HTTP error format %q, the only supported format is \"problem+json\"", f)
+	}
+	if c, ok := Root.API.Meta.Last("http:compress"); ok && c != "gzip" && c != "none" {
+		verr.Add(Root.API, "invalid HTTP compression %q, the only supported values are \"gzip\" and \"none\"", c)
+	}
+	if v, ok := Root.API.Meta.Last("http:error:validation"); ok && v != "fields" {
+		verr.Add(Root.API, "invalid HTTP validation error format %q, the only supported format is \"fields\"", v)
+	}
+	return verr
+}
+
 // Finalize initializes Consumes and Produces with defaults if not set.
 func (h *HTTPExpr) Finalize() {
 	if len(h.Consumes) == 0 {
@@ -201,6 +201,15 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 		if IsUnion(resp.Body.Type) {
 			return unionToObject(resp.Body, name, suffix, svc.Name())
 		}
+		if _, ok := resp.Body.Type.(*Array); ok {
+			// Name the element types of bare top-level arrays the
+			// same way as when the body is the whole result (see 3.
+			// below).
+			att := DupAtt(resp.Body)
+			RemovePkgPath(att)
+			renameType(att, name, "Response")
+			return att
+		}
 		if !IsObject(resp.Body.Type) {
 			return resp.Body
 		}
@@ -294,12 +303,17 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 
 // unionToObject returns an object adequate to serialize the given union type.
 func unionToObject(att *AttributeExpr, name, suffix, svcName string) *AttributeExpr {
-	values := AsUnion(att.Type).Values
-	names := make([]interface{}, len(values))
-	vals := make([]string, len(values))
-	for i, nat := range values {
-		names[i] = nat.Attribute.Type.Name()
-		vals[i] = fmt.Sprintf("- %q", nat.Attribute.Type.Name())
+	u := AsUnion(att.Type)
+	d := UnionDiscriminator(u)
+	names := make([]interface{}, len(u.Values))
+	vals := make([]string, len(u.Values))
+	for i, nat := range u.Values {
+		name := nat.Attribute.Type.Name()
+		if d != nil {
+			name, _ = d.ValueOf(nat.Attribute.Type)
+		}
+		names[i] = name
+		vals[i] = fmt.Sprintf("- %q", name)
 	}
 	obj := Object([]*NamedAttributeExpr{{
 		"Type", &AttributeExpr{
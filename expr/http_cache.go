@@ -0,0 +1,109 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPCacheExpr describes the caching directives of the responses of a
+	// HTTP endpoint. The directives are sent in the Cache-Control response
+	// header.
+	HTTPCacheExpr struct {
+		// MaxAge is the duration during which the response is fresh,
+		// nil if the response does not define a max-age directive.
+		MaxAge *time.Duration
+		// Public is true if the response may be stored by shared
+		// caches.
+		Public bool
+		// Private is true if the response may only be stored by the
+		// client cache.
+		Private bool
+		// NoCache is true if caches must revalidate the response with
+		// the server before using it.
+		NoCache bool
+		// NoStore is true if the response must not be stored.
+		NoStore bool
+		// MustRevalidate is true if caches must not use the response
+		// once stale without revalidating it first.
+		MustRevalidate bool
+		// Vary lists the request headers that the response varies by.
+		Vary []string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *HTTPCacheExpr) EvalName() string {
+	var prefix string
+	if c.Endpoint != nil {
+		prefix = c.Endpoint.EvalName() + " "
+	}
+	return prefix + "cache directives"
+}
+
+// Validate makes sure the directives are consistent and that the endpoint does
+// not stream its results.
+func (c *HTTPCacheExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if c.MaxAge == nil && !c.Public && !c.Private && !c.NoCache && !c.NoStore && !c.MustRevalidate {
+		verr.Add(c, "Cache must define at least one directive")
+	}
+	if c.Public && c.Private {
+		verr.Add(c, "Public and Private cannot be used together")
+	}
+	if c.NoStore {
+		if c.Public {
+			verr.Add(c, "Public and NoStore cannot be used together")
+		}
+		if c.MaxAge != nil {
+			verr.Add(c, "MaxAge and NoStore cannot be used together")
+		}
+	}
+	if c.MaxAge != nil && *c.MaxAge < 0 {
+		verr.Add(c, "MaxAge cannot be negative, got %s", *c.MaxAge)
+	}
+	for _, h := range c.Vary {
+		if h == "" || strings.ContainsAny(h, " ,:") {
+			verr.Add(c, "invalid Vary header name %q", h)
+		}
+	}
+	if c.Endpoint != nil && c.Endpoint.MethodExpr != nil && c.Endpoint.MethodExpr.IsStreaming() {
+		verr.Add(c, "Cache cannot be used on endpoints that define a StreamingPayload or a StreamingResult")
+	}
+	return verr
+}
+
+// CacheControl returns the value of the Cache-Control response header. The
+// responses of endpoints that require authentication are private unless the
+// design explicitly makes them public.
+func (c *HTTPCacheExpr) CacheControl() string {
+	var directives []string
+	private := c.Private
+	if !c.Public && c.Endpoint != nil && c.Endpoint.MethodExpr != nil && len(c.Endpoint.MethodExpr.Requirements) > 0 {
+		private = true
+	}
+	if c.Public {
+		directives = append(directives, "public")
+	}
+	if private {
+		directives = append(directives, "private")
+	}
+	if c.NoCache {
+		directives = append(directives, "no-cache")
+	}
+	if c.NoStore {
+		directives = append(directives, "no-store")
+	}
+	if c.MaxAge != nil {
+		directives = append(directives, fmt.Sprintf("max-age=%d", int64(c.MaxAge.Seconds())))
+	}
+	if c.MustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+	return strings.Join(directives, ", ")
+}
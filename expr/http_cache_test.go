@@ -0,0 +1,65 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestCacheDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.CacheValidDSL},
+		{Name: "secured", DSL: testdata.CacheSecuredDSL},
+		{Name: "empty", DSL: testdata.CacheEmptyDSL, Error: "Cache must define at least one directive"},
+		{Name: "public no-store", DSL: testdata.CachePublicNoStoreDSL, Error: "Public and NoStore cannot be used together"},
+		{Name: "streaming", DSL: testdata.CacheStreamingDSL, Error: "Cache cannot be used on endpoints that define a StreamingPayload or a StreamingResult"},
+		{Name: "invalid max-age", DSL: testdata.CacheInvalidMaxAgeDSL, Error: "duration string"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Service  string
+		Expected string
+		MaxAge   time.Duration
+	}{
+		{"public", testdata.CacheValidDSL, "cache-valid", "public, max-age=300", 300 * time.Second},
+		{"secured", testdata.CacheSecuredDSL, "cache-secured", "private, no-cache, max-age=60", time.Minute},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			expr.RunDSL(t, c.DSL)
+			e := expr.Root.API.HTTP.Service(c.Service).Endpoint("method")
+			if e.Cache == nil {
+				t.Fatal("got nil cache")
+			}
+			if e.Cache.MaxAge == nil || *e.Cache.MaxAge != c.MaxAge {
+				t.Errorf("got max age %v, expected %s", e.Cache.MaxAge, c.MaxAge)
+			}
+			if got := e.Cache.CacheControl(); got != c.Expected {
+				t.Errorf("got Cache-Control %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
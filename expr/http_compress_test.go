@@ -0,0 +1,75 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestHTTPCompressValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"gzip", compressDSL("gzip", "none"), ""},
+		{"invalid api", compressDSL("br", ""), `API test: invalid HTTP compression "br", the only supported values are "gzip" and "none"`},
+		{"invalid method", compressDSL("gzip", "deflate"), `service "Compress" HTTP endpoint "Method": invalid HTTP compression "deflate", the only supported values are "gzip" and "none"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("\ngot error %q\nexpected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPEndpointExprCompression(t *testing.T) {
+	cases := []struct {
+		Name     string
+		API      string
+		Method   string
+		Expected string
+	}{
+		{"none", "", "", ""},
+		{"api", "gzip", "", "gzip"},
+		{"method", "", "gzip", "gzip"},
+		{"disabled", "gzip", "none", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, compressDSL(c.API, c.Method))
+			e := root.API.HTTP.Service("Compress").Endpoint("Method")
+			if got := e.Compression(); got != c.Expected {
+				t.Errorf("got compression %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
+
+func compressDSL(api, method string) func() {
+	return func() {
+		API("test", func() {
+			if api != "" {
+				Meta("http:compress", api)
+			}
+		})
+		Service("Compress", func() {
+			Method("Method", func() {
+				if method != "" {
+					Meta("http:compress", method)
+				}
+				HTTP(func() {
+					GET("/")
+				})
+			})
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPConditionalExpr describes the conditional request handling of a
+	// HTTP endpoint. The endpoint responses carry an entity tag computed
+	// from a result attribute and the If-Match and If-None-Match request
+	// headers are evaluated against it.
+	HTTPConditionalExpr struct {
+		// Attribute is the name of the result attribute used to compute
+		// the entity tag.
+		Attribute string
+		// Weak is true if the entity tags are weak validators.
+		Weak bool
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *HTTPConditionalExpr) EvalName() string {
+	suffix := "conditional requests"
+	if c.Attribute != "" {
+		suffix = fmt.Sprintf("conditional requests using %q", c.Attribute)
+	}
+	var prefix string
+	if c.Endpoint != nil {
+		prefix = c.Endpoint.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the entity tag attribute is a result attribute and that
+// the endpoint neither streams nor skips the response encoding.
+func (c *HTTPConditionalExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if c.Attribute == "" {
+		verr.Add(c, "ConditionalRequests must use ETagFrom to define the attribute used to compute the entity tags")
+	}
+	if c.Endpoint == nil || c.Endpoint.MethodExpr == nil {
+		return verr
+	}
+	m := c.Endpoint.MethodExpr
+	if m.IsStreaming() {
+		verr.Add(c, "ConditionalRequests cannot be used on endpoints that define a StreamingPayload or a StreamingResult")
+	}
+	if c.Endpoint.SkipResponseBodyEncodeDecode {
+		verr.Add(c, "ConditionalRequests cannot be used on endpoints that use SkipResponseBodyEncodeDecode")
+	}
+	if c.Attribute != "" {
+		if obj := AsObject(m.Result.Type); obj == nil || obj.Attribute(c.Attribute) == nil {
+			verr.Add(c, "ETagFrom attribute %q is not a result attribute", c.Attribute)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,48 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestConditionalRequestsDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.ConditionalValidDSL},
+		{Name: "missing etag", DSL: testdata.ConditionalMissingETagDSL, Error: "ConditionalRequests must use ETagFrom"},
+		{Name: "unknown attribute", DSL: testdata.ConditionalUnknownAttributeDSL, Error: `ETagFrom attribute "etag" is not a result attribute`},
+		{Name: "streaming", DSL: testdata.ConditionalStreamingDSL, Error: "ConditionalRequests cannot be used on endpoints that define a StreamingPayload or a StreamingResult"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestConditionalRequestsDSLValues(t *testing.T) {
+	expr.RunDSL(t, testdata.ConditionalValidDSL)
+	e := expr.Root.API.HTTP.Service("conditional-valid").Endpoint("method")
+	if e.Conditional == nil {
+		t.Fatal("got nil conditional")
+	}
+	if e.Conditional.Attribute != "version" {
+		t.Errorf("got attribute %q, expected %q", e.Conditional.Attribute, "version")
+	}
+	if !e.Conditional.Weak {
+		t.Error("got strong entity tags, expected weak")
+	}
+}
@@ -0,0 +1,81 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// HTTPCORSExpr describes the Cross-Origin Resource Sharing policy
+	// applied to the HTTP endpoints of an API or service.
+	HTTPCORSExpr struct {
+		// Origins lists the allowed origins. An origin may use the "*"
+		// wildcard to match any origin or a sub-domain wildcard such as
+		// "https://*.example.com".
+		Origins []string
+		// Methods lists the allowed HTTP methods.
+		Methods []string
+		// Headers lists the allowed HTTP request headers.
+		Headers []string
+		// Exposed lists the HTTP response headers exposed to the client.
+		Exposed []string
+		// MaxAge is the number of seconds preflight responses may be
+		// cached by the client.
+		MaxAge uint
+		// Credentials is true if the response to the request can be
+		// exposed when the credentials flag is true.
+		Credentials bool
+		// Parent expression, one of APIExpr or ServiceExpr.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *HTTPCORSExpr) EvalName() string {
+	suffix := fmt.Sprintf("CORS policy for origins %s", strings.Join(c.Origins, ", "))
+	var prefix string
+	if c.Parent != nil {
+		prefix = c.Parent.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the policy defines at least one origin, does not
+// combine wildcard origins with credentials which is forbidden by the CORS
+// specification and that the allowed methods are valid HTTP methods.
+func (c *HTTPCORSExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if len(c.Origins) == 0 {
+		verr.Add(c, "CORS policy must define at least one origin, use Origin to define one")
+	}
+	for _, o := range c.Origins {
+		if o == "*" && c.Credentials {
+			verr.Add(c, "CORS policy cannot allow credentials with the wildcard origin \"*\"")
+		}
+	}
+	for _, m := range c.Methods {
+		if !isMethodToken(m) {
+			verr.Add(c, "CORS allowed method %q is not a valid HTTP method", m)
+		}
+	}
+	return verr
+}
+
+// isMethodToken returns true if m is a valid HTTP method, that is a token as
+// defined by RFC 7230.
+func isMethodToken(m string) bool {
+	if m == "" {
+		return false
+	}
+	for _, r := range m {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
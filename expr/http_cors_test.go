@@ -0,0 +1,50 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestCORSDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.CORSValidDSL},
+		{Name: "wildcard with credentials", DSL: testdata.CORSWildcardCredentialsDSL, Error: `CORS policy cannot allow credentials with the wildcard origin "*"`},
+		{Name: "service wildcard with credentials", DSL: testdata.CORSServiceWildcardCredentialsDSL, Error: `CORS policy cannot allow credentials with the wildcard origin "*"`},
+		{Name: "no origin", DSL: testdata.CORSNoOriginDSL, Error: "CORS policy must define at least one origin, use Origin to define one"},
+		{Name: "invalid method", DSL: testdata.CORSInvalidMethodDSL, Error: `CORS allowed method "PATCH IT" is not a valid HTTP method`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPServiceExprCORSPolicy(t *testing.T) {
+	expr.RunDSL(t, testdata.CORSValidDSL)
+	svc := expr.Root.API.HTTP.Service("cors-valid")
+	if svc == nil {
+		t.Fatal("service not found")
+	}
+	cors := svc.CORSPolicy()
+	if cors == nil {
+		t.Fatal("got nil CORS policy")
+	}
+	if len(cors.Origins) != 1 || cors.Origins[0] != "*" {
+		t.Errorf("got origins %v, expected the service policy origins", cors.Origins)
+	}
+}
@@ -3,12 +3,18 @@ package expr
 import (
 	"fmt"
 	"path"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/dimfeld/httppath"
 	"goa.design/goa/v3/eval"
 )
 
+// FormURLEncodedContentType is the media type of request bodies encoded as
+// HTML forms.
+const FormURLEncodedContentType = "application/x-www-form-urlencoded"
+
 type (
 	// HTTPEndpointExpr describes a HTTP endpoint. It embeds a MethodExpr and
 	// adds HTTP specific properties.
@@ -56,6 +62,10 @@ type (
 		// Responses is the list of all the possible success HTTP
 		// responses.
 		Responses []*HTTPResponseExpr
+		// ResponseTag is the name of the result attribute that selects
+		// the success response when the responses define their own
+		// types (see dsl.Response), empty otherwise.
+		ResponseTag string
 		// HTTPErrors is the list of all the possible error HTTP
 		// responses.
 		HTTPErrors []*HTTPErrorExpr
@@ -64,8 +74,29 @@ type (
 		// MultipartRequest indicates that the request content type for
 		// the endpoint is a multipart type.
 		MultipartRequest bool
+		// RequestContentType is the media type of the request body set
+		// with ContentType in the Body expression if any.
+		RequestContentType string
 		// Redirect defines a redirect for the endpoint.
 		Redirect *HTTPRedirectExpr
+		// Idempotency defines the idempotency key handling of the
+		// endpoint if any.
+		Idempotency *HTTPIdempotencyExpr
+		// WebhookSignature defines the verification of the request
+		// signatures if any.
+		WebhookSignature *HTTPWebhookSignatureExpr
+		// Conditional defines the conditional request handling of the
+		// endpoint if any.
+		Conditional *HTTPConditionalExpr
+		// Cache defines the caching directives of the endpoint responses
+		// if any.
+		Cache *HTTPCacheExpr
+		// WebSocket defines the WebSocket connection used by the
+		// streaming endpoint if any.
+		WebSocket *HTTPWebSocketExpr
+		// SSE defines the server-sent events used by the streaming
+		// endpoint to send its results if any.
+		SSE *HTTPSSEExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -80,6 +111,9 @@ type (
 		Method string
 		// Path is the URL path e.g. "/tasks/{id}"
 		Path string
+		// Patterns lists the regular expressions that constrain the
+		// route wildcards indexed by wildcard name.
+		Patterns map[string]string
 		// Endpoint is the endpoint this route applies to.
 		Endpoint *HTTPEndpointExpr
 		// Meta is an arbitrary set of key/value pairs, see
@@ -175,6 +209,70 @@ func (e *HTTPEndpointExpr) QueryParams() *MappedAttributeExpr {
 	return NewMappedAttributeExpr(at)
 }
 
+// RequestBodyRequired returns true if the requests sent to the endpoint must
+// have a body. The body may be omitted if it is empty, if it is defined with
+// Body("name") and the payload attribute is optional or if it is an object that
+// does not define any required attribute or RequiredOneOf group.
+func (e *HTTPEndpointExpr) RequestBodyRequired() bool {
+	if e.Body == nil || e.Body.Type == Empty {
+		return false
+	}
+	if o, ok := e.Body.Meta["origin:attribute"]; ok {
+		return e.MethodExpr.Payload.IsRequired(o[0])
+	}
+	if _, ok := e.Body.Type.(*Union); ok || !IsObject(e.Body.Type) {
+		return true
+	}
+	requires := func(v *ValidationExpr) bool {
+		return v != nil && (len(v.Required) > 0 || len(v.RequiredOneOf) > 0)
+	}
+	if requires(e.Body.Validation) {
+		return true
+	}
+	if ut, ok := e.Body.Type.(UserType); ok {
+		return requires(ut.Attribute().Validation)
+	}
+	return false
+}
+
+// IsIdempotent returns true if the endpoint is idempotent, that is if sending
+// the same request multiple times has the same effect as sending it once.
+// Endpoints of methods that use Idempotent(true) or Idempotent(false) are
+// idempotent accordingly. Endpoints that handle idempotency keys are
+// idempotent. Other endpoints are idempotent if their first route uses an
+// idempotent HTTP method (GET, HEAD, OPTIONS, TRACE, PUT or DELETE).
+func (e *HTTPEndpointExpr) IsIdempotent() bool {
+	if e.MethodExpr.Idempotent != nil {
+		return *e.MethodExpr.Idempotent
+	}
+	if e.Idempotency != nil {
+		return true
+	}
+	if len(e.Routes) == 0 {
+		return false
+	}
+	switch e.Routes[0].Method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// Compression returns the content encoding used to compress the endpoint
+// request and response bodies as set with the "http:compress" meta, the empty
+// string if the bodies are not compressed. The method meta overrides the API
+// meta, the value "none" disables compression.
+func (e *HTTPEndpointExpr) Compression() string {
+	c, ok := e.MethodExpr.Meta.Last("http:compress")
+	if !ok {
+		c, _ = Root.API.Meta.Last("http:compress")
+	}
+	if c == "none" {
+		return ""
+	}
+	return c
+}
+
 // Prepare computes the request path and query string parameters as well as the
 // headers and body taking into account the inherited values from the service.
 func (e *HTTPEndpointExpr) Prepare() {
@@ -193,6 +291,13 @@ func (e *HTTPEndpointExpr) Prepare() {
 		e.Params = NewEmptyMappedAttributeExpr()
 	}
 
+	// Use the idempotency key handling defined in the method unless the
+	// endpoint overrides it.
+	if e.Idempotency == nil && e.MethodExpr.Idempotency != nil {
+		e.Idempotency = e.MethodExpr.Idempotency
+		e.Idempotency.Endpoint = e
+	}
+
 	// Inherit headers, cookies and params from parent service and API
 	headers := NewEmptyMappedAttributeExpr()
 	headers.Merge(Root.API.HTTP.Headers)
@@ -262,13 +367,20 @@ func (e *HTTPEndpointExpr) Prepare() {
 
 	// Make sure there's a default success response if none define explicitly.
 	if len(e.Responses) == 0 {
-		status := StatusOK
+		status := e.defaultStatus()
 		if e.Redirect != nil {
 			status = e.Redirect.StatusCode
-		} else if e.MethodExpr.Result.Type == Empty && !e.SkipResponseBodyEncodeDecode {
-			status = StatusNoContent
 		}
-		e.Responses = []*HTTPResponseExpr{{StatusCode: status}}
+		resp := &HTTPResponseExpr{StatusCode: status}
+		if e.Redirect != nil && e.Redirect.LocationFrom != "" {
+			// The response has no body and sets the Location header
+			// to the value of the result attribute.
+			resp.Body = &AttributeExpr{Type: Empty}
+			resp.Headers = NewEmptyMappedAttributeExpr()
+			AsObject(resp.Headers.Type).Set(e.Redirect.LocationFrom+":Location", &AttributeExpr{Type: String})
+			resp.Headers.Remap()
+		}
+		e.Responses = []*HTTPResponseExpr{resp}
 	}
 
 	// Error -> ResponseError
@@ -376,6 +488,9 @@ func (e *HTTPEndpointExpr) Validate() error {
 
 	// Redirect is not compatible with Response.
 	if e.Redirect != nil {
+		if err := e.Redirect.Validate(); err != nil {
+			verr.AddError(e.Redirect, err)
+		}
 		found := false
 		for _, r := range e.Responses {
 			if r.StatusCode != e.Redirect.StatusCode {
@@ -388,6 +503,72 @@ func (e *HTTPEndpointExpr) Validate() error {
 		}
 	}
 
+	if idem := e.Idempotency; idem != nil {
+		if err := idem.Validate(); err != nil {
+			verr.AddError(idem, err)
+		}
+		if i := e.MethodExpr.Idempotent; i != nil && !*i {
+			verr.Add(e, "Idempotent(false) cannot be used on endpoints that handle idempotency keys")
+		}
+	}
+
+	if e.WebhookSignature != nil {
+		if err := e.WebhookSignature.Validate(); err != nil {
+			verr.AddError(e.WebhookSignature, err)
+		}
+	}
+
+	if e.MethodExpr.SparseFields {
+		if e.SkipResponseBodyEncodeDecode {
+			verr.Add(e, "SparseFields cannot be used with SkipResponseBodyEncodeDecode")
+		}
+		if e.Params != nil {
+			for _, nat := range *AsObject(e.Params.Type) {
+				if e.Params.ElemName(nat.Name) == SparseFieldsParam {
+					verr.Add(e, "parameter %q is reserved by SparseFields", SparseFieldsParam)
+				}
+			}
+		}
+	}
+
+	if c, ok := e.MethodExpr.Meta.Last("http:compress"); ok && c != "gzip" && c != "none" {
+		verr.Add(e, "invalid HTTP compression %q, the only supported values are \"gzip\" and \"none\"", c)
+	}
+
+	if e.Conditional != nil {
+		if err := e.Conditional.Validate(); err != nil {
+			verr.AddError(e.Conditional, err)
+		}
+	}
+
+	if e.Cache != nil {
+		if err := e.Cache.Validate(); err != nil {
+			verr.AddError(e.Cache, err)
+		}
+	}
+
+	if e.WebSocket != nil {
+		if err := e.WebSocket.Validate(); err != nil {
+			verr.AddError(e.WebSocket, err)
+		}
+	}
+
+	if e.SSE != nil {
+		if err := e.SSE.Validate(); err != nil {
+			verr.AddError(e.SSE, err)
+		}
+	}
+
+	if e.MethodExpr.StreamTrailers != nil && e.MethodExpr.IsResultStreaming() && e.SSE == nil {
+		verr.Add(e, "trailers can only be sent by endpoints that stream their results as server-sent events, use SSE to define the events")
+	}
+
+	if lr := e.MethodExpr.LongRunning; lr != nil && lr.PollMethod != "" {
+		if e.Service.Endpoint(lr.PollMethod) == nil {
+			verr.Add(e, "poll method %q of long-running endpoint must define an HTTP endpoint", lr.PollMethod)
+		}
+	}
+
 	// Validate routes
 
 	// Routes cannot be empty
@@ -457,7 +638,13 @@ func (e *HTTPEndpointExpr) Validate() error {
 			successResp = true
 		}
 	}
-	if hasTags && allTagged {
+	if e.ResponseTag != "" {
+		for _, r := range e.Responses {
+			if r.StatusCode < 400 && r.Tag[0] != e.ResponseTag {
+				verr.Add(r, "Response must define a type when other success responses of the method do.")
+			}
+		}
+	} else if hasTags && allTagged {
 		verr.Add(e, "All responses define a Tag, at least one response must define no Tag.")
 	}
 	if hasTags && !IsObject(e.MethodExpr.Result.Type) {
@@ -467,6 +654,8 @@ func (e *HTTPEndpointExpr) Validate() error {
 	// Make sure parameters and headers use compatible types
 	verr.Merge(e.validateParams())
 	verr.Merge(e.validateHeadersAndCookies())
+	verr.Merge(e.validateNullable())
+	verr.Merge(e.validateCryptoFields())
 
 	// Validate body attribute (required fields exist etc.)
 	if e.Body != nil {
@@ -645,6 +834,20 @@ func (e *HTTPEndpointExpr) Validate() error {
 		// Protocol does not allow HTTP request body to be passed.
 		verr.Add(e, "HTTP endpoint request body must be empty when the endpoint uses streaming. Payload attributes must be mapped to headers and/or params.")
 	}
+	if e.RequestContentType != "" {
+		if e.MultipartRequest {
+			verr.Add(e, "Body cannot set the request content type when the endpoint uses MultipartRequest.")
+		}
+		if e.SkipRequestBodyEncodeDecode {
+			verr.Add(e, "Body cannot set the request content type when the endpoint uses SkipRequestBodyEncodeDecode.")
+		}
+		if e.RequestContentType == FormURLEncodedContentType && body.Type != Empty {
+			verr.Merge(e.validateFormBody(body))
+		}
+	}
+	if e.HasMultipartDecoder() {
+		verr.Merge(e.validateMultipartDecode(body))
+	}
 
 	return verr
 }
@@ -702,6 +905,27 @@ func (e *HTTPEndpointExpr) Finalize() {
 	initAttr(e.Headers, e.MethodExpr.Payload)
 	initAttr(e.Cookies, e.MethodExpr.Payload)
 
+	// Make sure the values of the string path parameters constrained by a
+	// pattern are validated against it.
+	for _, r := range e.Routes {
+		if len(r.Patterns) == 0 {
+			continue
+		}
+		WalkMappedAttr(e.Params, func(_, elem string, att *AttributeExpr) error {
+			pattern, ok := r.Patterns[elem]
+			if !ok || att.Type.Kind() != StringKind {
+				return nil
+			}
+			if att.Validation == nil {
+				att.Validation = &ValidationExpr{}
+			} else {
+				att.Validation = att.Validation.Dup()
+			}
+			att.Validation.Pattern = PathPattern(pattern)
+			return nil
+		})
+	}
+
 	e.Body = httpRequestBody(e)
 	e.Body.Finalize()
 
@@ -758,10 +982,39 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("path parameter %s", name)
 			verr.Merge(a.Validate(ctx, e))
 		}
+		switch style, explode := HTTPParamStyle(a); style {
+		case "simple", "matrix", "label":
+			if explode && !IsArray(a.Type) {
+				verr.Add(e, "path parameter %q cannot be exploded, only array path parameters can be exploded", name)
+			}
+		default:
+			verr.Add(e, "path parameter %q has an invalid style %q, style must be one of \"simple\", \"matrix\" or \"label\"", name, style)
+		}
+		if allowEmpty, allowReserved := HTTPParamFlags(a); allowEmpty || allowReserved {
+			verr.Add(e, "path parameter %q cannot use AllowEmptyValue or AllowReserved, only query string parameters can", name)
+		}
+		if _, ok := a.Meta["http:param:delimiter"]; ok {
+			verr.Add(e, "path parameter %q cannot use Delimiter, only query string parameters can", name)
+		}
 		return nil
 	})
 	WalkMappedAttr(qparams, func(name, _ string, a *AttributeExpr) error {
+		style, explode := HTTPParamStyle(a)
 		switch {
+		case style == "deepObject":
+			if !IsObject(a.Type) {
+				verr.Add(e, "query parameter %q must be an object to use the \"deepObject\" style", name)
+				break
+			}
+			for _, nat := range *AsObject(a.Type) {
+				if IsPrimitive(nat.Attribute.Type) {
+					continue
+				}
+				if arr := AsArray(nat.Attribute.Type); arr != nil && IsPrimitive(arr.ElemType.Type) {
+					continue
+				}
+				verr.Add(e, "attribute %q of deepObject query parameter %q must be a primitive or an array of primitives, nested objects are not supported", nat.Name, name)
+			}
 		case IsObject(a.Type), IsUnion(a.Type):
 			invalidTypeErr(verr, e, name)
 		case IsArray(a.Type):
@@ -773,6 +1026,15 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("query parameter %s", name)
 			verr.Merge(a.Validate(ctx, e))
 		}
+		if _, ok := a.Meta["http:param:style"]; ok && style != "deepObject" {
+			verr.Add(e, "query parameter %q has an invalid style %q, the only style supported by query parameters is \"deepObject\"", name, style)
+		}
+		if explode {
+			verr.Add(e, "query parameter %q cannot be exploded, only array path parameters can be exploded", name)
+		}
+		if HTTPParamDelimiter(a) != "" && !IsArray(a.Type) {
+			verr.Add(e, "query parameter %q must be an array to use Delimiter or Explode(false)", name)
+		}
 		return nil
 	})
 	if e.MethodExpr.Payload != nil {
@@ -803,6 +1065,125 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 	return verr
 }
 
+// validateFormBody makes sure the given request body can be encoded as a
+// form: it must be an object whose attributes are primitives or arrays of
+// primitives, the values of arrays use repeated keys. Nested objects and maps
+// cannot be represented.
+func (e *HTTPEndpointExpr) validateFormBody(body *AttributeExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if _, ok := body.Type.(*Union); ok || !IsObject(body.Type) {
+		verr.Add(e, "%s request body must be an object, got %s", FormURLEncodedContentType, body.Type.Name())
+		return verr
+	}
+	formValue := func(dt DataType) bool {
+		return IsPrimitive(dt) && dt.Kind() != AnyKind
+	}
+	for _, nat := range *AsObject(body.Type) {
+		dt := nat.Attribute.Type
+		if arr := AsArray(dt); arr != nil {
+			dt = arr.ElemType.Type
+		}
+		if !formValue(dt) {
+			verr.Add(e, "attribute %q of %s request body must be a primitive or an array of primitives, got %s", nat.Name, FormURLEncodedContentType, nat.Attribute.Type.Name())
+		}
+	}
+	return verr
+}
+
+// validateNullable makes sure that nullable payload attributes and attributes
+// defined with DurationFormat are only mapped to the request body.
+func (e *HTTPEndpointExpr) validateNullable() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	check := func(kind string, ma *MappedAttributeExpr) {
+		if ma == nil {
+			return
+		}
+		WalkMappedAttr(ma, func(name, elem string, _ *AttributeExpr) error {
+			att := e.MethodExpr.Payload.Find(name)
+			if att.IsNullable() {
+				verr.Add(e, "%s %q cannot be nullable, nullable attributes must be mapped to the request body", kind, elem)
+			}
+			if att.IsDuration() {
+				verr.Add(e, "%s %q cannot use DurationFormat, duration attributes must be mapped to the request body", kind, elem)
+			}
+			return nil
+		})
+	}
+	check("header", e.Headers)
+	check("cookie", e.Cookies)
+	check("parameter", e.Params)
+	return verr
+}
+
+// validateCryptoFields checks that the attributes flagged with the
+// "crypto:field" meta are non-nullable string attributes of the payload or
+// result object and that the endpoint encodes and decodes them.
+func (e *HTTPEndpointExpr) validateCryptoFields() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	check := func(kind string, att *AttributeExpr) bool {
+		var found bool
+		if obj := AsObject(att.Type); obj != nil {
+			for _, nat := range *obj {
+				if isCryptoField(nat.Attribute) {
+					found = true
+					if nat.Attribute.Type != String || nat.Attribute.IsNullable() {
+						verr.Add(e, "%s attribute %q flagged with crypto:field must be a non-nullable string", kind, nat.Name)
+					}
+				}
+				if hasNestedCryptoField(nat.Attribute.Type, make(map[string]struct{})) {
+					verr.Add(e, "%s attribute %q contains attributes flagged with crypto:field, only the top-level %s attributes can be flagged", kind, nat.Name, kind)
+				}
+			}
+		}
+		return found
+	}
+	payload := check("payload", e.MethodExpr.Payload)
+	result := check("result", e.MethodExpr.Result)
+	if !payload && !result {
+		return verr
+	}
+	if e.MethodExpr.IsStreaming() {
+		verr.Add(e, "streaming endpoints cannot define attributes flagged with crypto:field")
+	}
+	if payload && e.SkipRequestBodyEncodeDecode {
+		verr.Add(e, "payload attributes cannot be flagged with crypto:field when using SkipRequestBodyEncodeDecode")
+	}
+	if result && e.SkipResponseBodyEncodeDecode {
+		verr.Add(e, "result attributes cannot be flagged with crypto:field when using SkipResponseBodyEncodeDecode")
+	}
+	return verr
+}
+
+// hasNestedCryptoField returns true if dt defines attributes flagged with the
+// "crypto:field" meta at any depth.
+func hasNestedCryptoField(dt DataType, seen map[string]struct{}) bool {
+	switch t := dt.(type) {
+	case UserType:
+		if _, ok := seen[t.ID()]; ok {
+			return false
+		}
+		seen[t.ID()] = struct{}{}
+		return hasNestedCryptoField(t.Attribute().Type, seen)
+	case *Object:
+		for _, nat := range *t {
+			if isCryptoField(nat.Attribute) || hasNestedCryptoField(nat.Attribute.Type, seen) {
+				return true
+			}
+		}
+	case *Array:
+		return isCryptoField(t.ElemType) || hasNestedCryptoField(t.ElemType.Type, seen)
+	case *Map:
+		return isCryptoField(t.ElemType) || hasNestedCryptoField(t.ElemType.Type, seen)
+	case *Union:
+		for _, nat := range t.Values {
+			if hasNestedCryptoField(nat.Attribute.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // validateHeadersAndCookies makes sure headers and cookies are of an allowed
 // type and the method payload defines the corresponding attributes.
 func (e *HTTPEndpointExpr) validateHeadersAndCookies() *eval.ValidationErrors {
@@ -828,6 +1209,12 @@ func (e *HTTPEndpointExpr) validateHeadersAndCookies() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("header %q", name)
 			verr.Merge(a.Validate(ctx, e))
 		}
+		if allowEmpty, allowReserved := HTTPParamFlags(a); allowEmpty || allowReserved {
+			verr.Add(e, "header %q cannot use AllowEmptyValue or AllowReserved, only query string parameters can", name)
+		}
+		if _, ok := a.Meta["http:param:delimiter"]; ok {
+			verr.Add(e, "header %q cannot use Delimiter, only query string parameters can", name)
+		}
 		return nil
 	})
 	WalkMappedAttr(cookies, func(name, _ string, a *AttributeExpr) error {
@@ -918,8 +1305,39 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 		}
 	}
 
+	// Make sure the wildcard patterns are valid and consistent with the
+	// parameter validations.
+	names := make([]string, 0, len(r.Patterns))
+	for name := range r.Patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pattern := r.Patterns[name]
+		if _, err := regexp.Compile(pattern); err != nil {
+			verr.Add(r, "Invalid pattern %q for wildcard %q: %s", pattern, name, err)
+			continue
+		}
+		var atts []*AttributeExpr
+		WalkMappedAttr(r.Endpoint.Params, func(attName, elem string, att *AttributeExpr) error {
+			if elem == name {
+				atts = append(atts, att)
+				if r.Endpoint.MethodExpr.Payload != nil {
+					atts = append(atts, r.Endpoint.MethodExpr.Payload.Find(attName))
+				}
+			}
+			return nil
+		})
+		for _, att := range atts {
+			if att != nil && att.Validation != nil && att.Validation.Pattern != "" && att.Validation.Pattern != PathPattern(pattern) {
+				verr.Add(r, "Pattern %q of wildcard %q conflicts with the parameter pattern %q", pattern, name, att.Validation.Pattern)
+				break
+			}
+		}
+	}
+
 	// For streaming endpoints, websockets does not support verbs other than GET
-	if r.Endpoint.MethodExpr.IsStreaming() && len(r.Endpoint.Responses) > 0 {
+	if r.Endpoint.MethodExpr.IsStreaming() && r.Endpoint.SSE == nil && len(r.Endpoint.Responses) > 0 {
 		if r.Method != "GET" {
 			verr.Add(r, "WebSocket endpoint supports only \"GET\" method. Got %q.", r.Method)
 		}
@@ -942,6 +1360,13 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 	return verr
 }
 
+// PathPattern returns the regular expression used to validate the values of
+// the path parameters whose wildcard is constrained by pattern. The regular
+// expression matches the entire values.
+func PathPattern(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
 // Params returns all the route parameters across all the base paths. For
 // example for the route "GET /foo/{fooID:foo_id}" Params returns
 // []string{"fooID:foo_id"}.
@@ -1047,6 +1472,19 @@ func initAttrFromDesign(att, patt *AttributeExpr) {
 	}
 }
 
+// defaultStatus returns the status code of the success response of endpoints
+// that do not define any: 202 (Accepted) if the method is long-running, 204 (No
+// Content) if the method has no result and 200 (OK) otherwise.
+func (e *HTTPEndpointExpr) defaultStatus() int {
+	if e.MethodExpr.LongRunning != nil {
+		return StatusAccepted
+	}
+	if e.MethodExpr.Result != nil && e.MethodExpr.Result.Type == Empty && !e.SkipResponseBodyEncodeDecode {
+		return StatusNoContent
+	}
+	return StatusOK
+}
+
 // isEmpty returns true if an attribute is Empty type and it has no bases and
 // references, or if an attribute is an empty object.
 func isEmpty(a *AttributeExpr) bool {
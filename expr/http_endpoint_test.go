@@ -19,6 +19,9 @@ func TestHTTPRouteValidation(t *testing.T) {
 		{"disallow-response-body", testdata.DisallowResponseBodyHeadDSL, `route HEAD "/" of service "DisallowResponseBody" HTTP endpoint "Method": HTTP status 200: Response body defined for HEAD method which does not allow response body.
 route HEAD "/" of service "DisallowResponseBody" HTTP endpoint "Method": HTTP status 404: Response body defined for HEAD method which does not allow response body.`,
 		},
+		{"pattern", testdata.PatternRouteDSL, ""},
+		{"invalid-pattern", testdata.InvalidPatternRouteDSL, `route GET "/{id}" of service "InvalidPatternRoute" HTTP endpoint "Method": Invalid pattern "[0-9" for wildcard "id": error parsing regexp: missing closing ]: ` + "`[0-9`"},
+		{"conflicting-pattern", testdata.ConflictingPatternRouteDSL, `route GET "/{id}" of service "ConflictingPatternRoute" HTTP endpoint "Method": Pattern "[0-9]+" of wildcard "id" conflicts with the parameter pattern "^[a-z]+$"`},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -34,6 +37,89 @@ route HEAD "/" of service "DisallowResponseBody" HTTP endpoint "Method": HTTP st
 	}
 }
 
+func TestHTTPRoutePatterns(t *testing.T) {
+	root := expr.RunDSL(t, testdata.PatternRouteDSL)
+	e := root.API.HTTP.Service("PatternRoute").Endpoint("Method")
+	r := e.Routes[0]
+	if r.Path != "/{id}/{code}" {
+		t.Errorf("got path %q, expected %q", r.Path, "/{id}/{code}")
+	}
+	expected := map[string]string{"id": "[0-9]+", "code": "[a-z]{2}"}
+	for name, pattern := range expected {
+		if r.Patterns[name] != pattern {
+			t.Errorf("got pattern %q for %q, expected %q", r.Patterns[name], name, pattern)
+		}
+		att := e.Params.Find(name)
+		if att.Validation == nil || att.Validation.Pattern != "^(?:"+pattern+")$" {
+			t.Errorf("got validation %v for %q, expected pattern %q", att.Validation, name, "^(?:"+pattern+")$")
+		}
+	}
+	if v := e.MethodExpr.Payload.Find("id").Validation; v != nil && v.Pattern != "" {
+		t.Errorf("got payload attribute pattern %q, expected none", v.Pattern)
+	}
+}
+
+func TestParseHTTPPathPatterns(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Path     string
+		Expected string
+		Patterns map[string]string
+		Error    string
+	}{
+		{"no wildcard", "/users", "/users", nil, ""},
+		{"no pattern", "/users/{id}", "/users/{id}", nil, ""},
+		{"pattern", "/users/{id:[0-9]+}", "/users/{id}", map[string]string{"id": "[0-9]+"}, ""},
+		{"braces", "/users/{id:[0-9]{3}}/{name}", "/users/{id}/{name}", map[string]string{"id": "[0-9]{3}"}, ""},
+		{"catch all", "/files/{*path:.+\\.png}", "/files/{*path}", map[string]string{"path": ".+\\.png"}, ""},
+		{"missing brace", "/users/{id:[0-9]+", "", nil, `missing closing brace in path "/users/{id:[0-9]+"`},
+		{"empty pattern", "/users/{id:}", "", nil, `empty pattern for wildcard "id" in path "/users/{id:}"`},
+		{"slash", "/users/{id:a/b}", "", nil, `pattern "a/b" of wildcard "id" in path "/users/{id:a/b}" cannot match slashes`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			path, patterns, err := expr.ParseHTTPPathPatterns(c.Path)
+			if c.Error != "" {
+				if err == nil || err.Error() != c.Error {
+					t.Fatalf("got error %v, expected %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got unexpected error %q", err)
+			}
+			if path != c.Expected {
+				t.Errorf("got path %q, expected %q", path, c.Expected)
+			}
+			if len(patterns) != len(c.Patterns) {
+				t.Fatalf("got %d patterns, expected %d", len(patterns), len(c.Patterns))
+			}
+			for name, p := range c.Patterns {
+				if patterns[name] != p {
+					t.Errorf("got pattern %q for %q, expected %q", patterns[name], name, p)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPEndpointRequestBodyRequired(t *testing.T) {
+	root := expr.RunDSL(t, testdata.RequestBodyRequiredDSL)
+	svc := root.API.HTTP.Service("Service")
+	cases := map[string]bool{
+		"NoBody":                false,
+		"OptionalBody":          false,
+		"RequiredBody":          true,
+		"OptionalBodyAttribute": false,
+		"ArrayBody":             true,
+	}
+	for name, expected := range cases {
+		if got := svc.Endpoint(name).RequestBodyRequired(); got != expected {
+			t.Errorf("%s: got request body required %v, expected %v", name, got, expected)
+		}
+	}
+}
+
 func TestHTTPEndpointPrepare(t *testing.T) {
 	cases := map[string]struct {
 		DSL     func()
@@ -171,6 +257,48 @@ service "Service" HTTP endpoint "Method": HTTP endpoint response body must be em
 			DSL:   testdata.EndpointPayloadMissingRequired,
 			Error: `service "Service" HTTP endpoint "Method": The following HTTP request body attribute is required but the corresponding method payload attribute is not: nonreq. Use 'Required' to make the attribute required in the method payload as well.`,
 		},
+		"endpoint-invalid-param-style": {
+			DSL: testdata.EndpointInvalidParamStyle,
+			Error: `service "Service" HTTP endpoint "Method": path parameter "id" has an invalid style "form", style must be one of "simple", "matrix" or "label"
+service "Service" HTTP endpoint "Method": query parameter "q" has an invalid style "matrix", the only style supported by query parameters is "deepObject"`,
+		},
+		"endpoint-deep-object-not-object": {
+			DSL:   testdata.EndpointDeepObjectNotObject,
+			Error: `service "Service" HTTP endpoint "Method": query parameter "q" must be an object to use the "deepObject" style`,
+		},
+		"endpoint-deep-object-nested": {
+			DSL:   testdata.EndpointDeepObjectNested,
+			Error: `service "Service" HTTP endpoint "Method": attribute "address" of deepObject query parameter "filter" must be a primitive or an array of primitives, nested objects are not supported`,
+		},
+		"endpoint-exploded-primitive-param": {
+			DSL:   testdata.EndpointExplodedPrimitiveParam,
+			Error: `service "Service" HTTP endpoint "Method": path parameter "id" cannot be exploded, only array path parameters can be exploded`,
+		},
+		"endpoint-invalid-param-delimiter": {
+			DSL: testdata.EndpointInvalidParamDelimiter,
+			Error: `service "Service" HTTP endpoint "Method": path parameter "id" cannot use Delimiter, only query string parameters can
+service "Service" HTTP endpoint "Method": query parameter "q" must be an array to use Delimiter or Explode(false)`,
+		},
+		"endpoint-invalid-param-flags": {
+			DSL: testdata.EndpointInvalidParamFlags,
+			Error: `service "Service" HTTP endpoint "Method": path parameter "id" cannot use AllowEmptyValue or AllowReserved, only query string parameters can`,
+		},
+		"endpoint-invalid-crypto-field": {
+			DSL: testdata.EndpointInvalidCryptoField,
+			Error: `service "Service" HTTP endpoint "Method": payload attribute "pin" flagged with crypto:field must be a non-nullable string
+service "Service" HTTP endpoint "Method": payload attribute "card" contains attributes flagged with crypto:field, only the top-level payload attributes can be flagged`,
+		},
+		"streaming-endpoint-crypto-field": {
+			DSL:   testdata.StreamingEndpointCryptoField,
+			Error: `service "Service" HTTP endpoint "Method": streaming endpoints cannot define attributes flagged with crypto:field`,
+		},
+		"endpoint-typed-responses": {
+			DSL: testdata.EndpointTypedResponses,
+		},
+		"endpoint-typed-responses-untyped-success": {
+			DSL:   testdata.EndpointTypedResponsesUntypedSuccess,
+			Error: `HTTP response of service "Service" HTTP endpoint "Method": Response must define a type when other success responses of the method do.`,
+		},
 		"streaming-endpoint-has-request-body": {
 			DSL: testdata.StreamingEndpointRequestBody,
 			Error: `service "Service" HTTP endpoint "MethodA": HTTP endpoint request body must be empty when the endpoint uses streaming. Payload attributes must be mapped to headers and/or params.
@@ -36,6 +36,82 @@ func TestHTTPErrorResponseValidation(t *testing.T) {
 	}
 }
 
+func TestHTTPErrorFormatValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"problem", errorFormatDSL("problem+json"), ""},
+		{"invalid", errorFormatDSL("xml"), `API test: invalid HTTP error format "xml", the only supported format is "problem+json"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("\ngot error %q\nexpected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPValidationErrorFormatValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"fields", validationErrorFormatDSL("fields"), ""},
+		{"invalid", validationErrorFormatDSL("problem+json"), `API test: invalid HTTP validation error format "problem+json", the only supported format is "fields"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("\ngot error %q\nexpected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func errorFormatDSL(format string) func() {
+	return func() {
+		API("test", func() {
+			Meta("http:error:format", format)
+		})
+		Service("ErrorFormat", func() {
+			Method("Method", func() {
+				HTTP(func() {
+					GET("/")
+				})
+			})
+		})
+	}
+}
+
+func validationErrorFormatDSL(format string) func() {
+	return func() {
+		API("test", func() {
+			Meta("http:error:validation", format)
+		})
+		Service("ValidationErrorFormat", func() {
+			Method("Method", func() {
+				HTTP(func() {
+					GET("/")
+				})
+			})
+		})
+	}
+}
+
 var stringErrorResponseWithHeadersDSL = func() {
 	Service("StringErrorResponseWithHeaders", func() {
 		Method("Method", func() {
@@ -0,0 +1,37 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestFormBodyDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.FormBodyValidDSL},
+		{Name: "nested object", DSL: testdata.FormBodyNestedObjectDSL, Error: `attribute "address" of application/x-www-form-urlencoded request body must be a primitive or an array of primitives, got object`},
+		{Name: "multipart", DSL: testdata.FormBodyMultipartDSL, Error: "Body cannot set the request content type when the endpoint uses MultipartRequest."},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+				e := expr.Root.API.HTTP.Service("form-valid").Endpoint("method")
+				if e.RequestContentType != expr.FormURLEncodedContentType {
+					t.Errorf("got request content type %q, expected %q", e.RequestContentType, expr.FormURLEncodedContentType)
+				}
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
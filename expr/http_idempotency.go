@@ -0,0 +1,59 @@
+package expr
+
+import (
+	"fmt"
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+// DefaultIdempotencyHeader is the name of the HTTP request header used by
+// default to carry the idempotency key.
+const DefaultIdempotencyHeader = "Idempotency-Key"
+
+// DefaultIdempotencyTTL is the default duration during which the response to
+// an idempotent request is replayed.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+type (
+	// HTTPIdempotencyExpr describes the idempotency key handling of a HTTP
+	// endpoint. Requests that carry the same key replay the response of the
+	// first request instead of calling the endpoint again.
+	HTTPIdempotencyExpr struct {
+		// Header is the name of the HTTP request header that carries the
+		// idempotency key.
+		Header string
+		// TTL is the duration during which responses are replayed.
+		TTL time.Duration
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (i *HTTPIdempotencyExpr) EvalName() string {
+	suffix := fmt.Sprintf("idempotency key %q", i.Header)
+	var prefix string
+	if i.Endpoint != nil {
+		prefix = i.Endpoint.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the TTL is positive and that the endpoint does not
+// stream its payload or result as streamed responses cannot be replayed.
+func (i *HTTPIdempotencyExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if i.Header == "" {
+		verr.Add(i, "idempotency key header name cannot be empty")
+	}
+	if i.TTL <= 0 {
+		verr.Add(i, "idempotency TTL must be positive, got %s", i.TTL)
+	}
+	if i.Endpoint != nil && i.Endpoint.MethodExpr != nil {
+		if i.Endpoint.MethodExpr.IsStreaming() {
+			verr.Add(i, "Idempotent cannot be used on endpoints that define a StreamingPayload or a StreamingResult")
+		}
+	}
+	return verr
+}
@@ -0,0 +1,79 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestIdempotentDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.IdempotentValidDSL},
+		{Name: "invalid ttl", DSL: testdata.IdempotentInvalidTTLDSL, Error: "idempotency TTL must be positive, got -1h0m0s"},
+		{Name: "streaming", DSL: testdata.IdempotentStreamingDSL, Error: "Idempotent cannot be used on endpoints that define a StreamingPayload or a StreamingResult"},
+		{Name: "false with keys", DSL: testdata.IdempotentFalseWithKeysDSL, Error: "Idempotent(false) cannot be used on endpoints that handle idempotency keys"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestIdempotentDSLValues(t *testing.T) {
+	expr.RunDSL(t, testdata.IdempotentValidDSL)
+	e := expr.Root.API.HTTP.Service("idempotent-valid").Endpoint("method")
+	if e.Idempotency == nil {
+		t.Fatal("got nil idempotency")
+	}
+	if e.Idempotency.Header != "X-Request-Key" {
+		t.Errorf("got header %q, expected %q", e.Idempotency.Header, "X-Request-Key")
+	}
+	if e.Idempotency.TTL != time.Hour {
+		t.Errorf("got TTL %s, expected %s", e.Idempotency.TTL, time.Hour)
+	}
+}
+
+func TestIdempotentDSLNoHTTP(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.IdempotentNoHTTPDSL)
+	expected := `service "idempotent-no-http" method "method": Idempotent with idempotency keys requires the method to define an HTTP endpoint, use HTTP to define one`
+	if err.Error() != expected {
+		t.Errorf("got error %q, expected %q", err.Error(), expected)
+	}
+	if svc := expr.Root.API.HTTP.Service("idempotent-no-http"); svc != nil {
+		t.Errorf("got HTTP service with %d endpoints, expected none", len(svc.HTTPEndpoints))
+	}
+}
+
+func TestHTTPEndpointExprIsIdempotent(t *testing.T) {
+	expr.RunDSL(t, testdata.IdempotentMethodsDSL)
+	svc := expr.Root.API.HTTP.Service("idempotent-methods")
+	cases := map[string]bool{
+		"get":                true,
+		"post":               false,
+		"post-idempotent":    true,
+		"put-not-idempotent": false,
+		"post-keys":          true,
+	}
+	for name, expected := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := svc.Endpoint(name).IsIdempotent(); got != expected {
+				t.Errorf("got %v, expected %v", got, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package expr
+
+import (
+	"strconv"
+
+	"goa.design/goa/v3/eval"
+)
+
+// HasMultipartDecoder returns true if the endpoint method sets the
+// "multipart:decode" meta to "true", in which case the HTTP server code
+// includes a decoder that maps the multipart request parts to the payload
+// fields.
+func (e *HTTPEndpointExpr) HasMultipartDecoder() bool {
+	v, ok := e.MethodExpr.Meta.Last("multipart:decode")
+	return ok && v == "true"
+}
+
+// MultipartMaxDepth returns the maximum number of nested multipart bodies set
+// with the "multipart:max-depth" meta of the endpoint method, -1 if the meta is
+// not set or is invalid.
+func (e *HTTPEndpointExpr) MultipartMaxDepth() int {
+	v, ok := e.MethodExpr.Meta.Last("multipart:max-depth")
+	if !ok {
+		return -1
+	}
+	d, err := strconv.Atoi(v)
+	if err != nil || d < 0 {
+		return -1
+	}
+	return d
+}
+
+// MultipartMaxPartSize returns the maximum size in bytes of the content of a
+// part set with the "multipart:max-part-size" meta of the endpoint method, -1
+// if the meta is not set or is invalid.
+func (e *HTTPEndpointExpr) MultipartMaxPartSize() int64 {
+	return e.multipartMaxSize("multipart:max-part-size")
+}
+
+// MultipartMaxSize returns the maximum size in bytes of all the parts set with
+// the "multipart:max-size" meta of the endpoint method, -1 if the meta is not
+// set or is invalid.
+func (e *HTTPEndpointExpr) MultipartMaxSize() int64 {
+	return e.multipartMaxSize("multipart:max-size")
+}
+
+// multipartMaxSize returns the positive size set with the given meta of the
+// endpoint method, -1 if the meta is not set or is invalid.
+func (e *HTTPEndpointExpr) multipartMaxSize(key string) int64 {
+	v, ok := e.MethodExpr.Meta.Last(key)
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return -1
+	}
+	return n
+}
+
+// validateMultipartDecode makes sure the attributes of the request body can be
+// decoded from multipart parts when the endpoint method sets the
+// "multipart:decode" meta.
+func (e *HTTPEndpointExpr) validateMultipartDecode(body *AttributeExpr) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !e.MultipartRequest {
+		verr.Add(e, "multipart:decode meta requires the endpoint to use MultipartRequest")
+		return verr
+	}
+	if v, ok := e.MethodExpr.Meta.Last("multipart:max-depth"); ok {
+		if d, err := strconv.Atoi(v); err != nil || d < 0 {
+			verr.Add(e, "multipart:max-depth meta must be a non-negative integer, got %q", v)
+		}
+	}
+	for _, key := range []string{"multipart:max-part-size", "multipart:max-size"} {
+		if v, ok := e.MethodExpr.Meta.Last(key); ok && e.multipartMaxSize(key) < 0 {
+			verr.Add(e, "%s meta must be a positive integer, got %q", key, v)
+		}
+	}
+	if body.Type == Empty {
+		return verr
+	}
+	if _, ok := body.Meta["origin:attribute"]; ok || !isMultipartObject(body.Type) {
+		verr.Add(e, "multipart:decode meta requires the request body to be an object, got %s", body.Type.Name())
+		return verr
+	}
+	seen := make(map[string]struct{})
+	for _, nat := range *AsObject(body.Type) {
+		if !multipartDecodable(nat.Attribute, seen) {
+			verr.Add(e, "attribute %q of type %s cannot be decoded from a multipart part, multipart:decode supports primitives, user types and arrays of these", nat.Name, nat.Attribute.Type.Name())
+		}
+	}
+	return verr
+}
+
+// multipartDecodable returns true if the value of att can be decoded from a
+// multipart part: primitive values are decoded from the part content and the
+// values of user types from the parts of a nested multipart body. Arrays are
+// decoded from the parts sharing the same name.
+func multipartDecodable(att *AttributeExpr, seen map[string]struct{}) bool {
+	if att.IsNullable() || att.IsDuration() {
+		return false
+	}
+	if _, ok := att.Meta["struct:field:type"]; ok {
+		return false
+	}
+	if arr := AsArray(att.Type); arr != nil {
+		if _, ok := att.Type.(UserType); ok {
+			return false
+		}
+		return !IsArray(arr.ElemType.Type) && multipartDecodable(arr.ElemType, seen)
+	}
+	if ut, ok := att.Type.(UserType); ok {
+		if !isMultipartObject(ut) {
+			return false
+		}
+		if _, ok := seen[ut.ID()]; ok {
+			return true
+		}
+		seen[ut.ID()] = struct{}{}
+		for _, nat := range *AsObject(ut) {
+			if !multipartDecodable(nat.Attribute, seen) {
+				return false
+			}
+		}
+		return true
+	}
+	return IsPrimitive(att.Type) && att.Type.Kind() != AnyKind
+}
+
+// isMultipartObject returns true if dt is an object which is not a union.
+func isMultipartObject(dt DataType) bool {
+	if _, ok := dt.(*Union); ok {
+		return false
+	}
+	if ut, ok := dt.(UserType); ok {
+		if _, ok := ut.Attribute().Type.(*Union); ok {
+			return false
+		}
+	}
+	return IsObject(dt)
+}
@@ -0,0 +1,35 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestMultipartDecodeDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.MultipartDecodeValidDSL},
+		{Name: "not multipart", DSL: testdata.MultipartDecodeNotMultipartDSL, Error: "multipart:decode meta requires the endpoint to use MultipartRequest"},
+		{Name: "invalid depth", DSL: testdata.MultipartDecodeInvalidDepthDSL, Error: `multipart:max-depth meta must be a non-negative integer, got "-1"`},
+		{Name: "invalid size", DSL: testdata.MultipartDecodeInvalidSizeDSL, Error: `multipart:max-part-size meta must be a positive integer, got "0"`},
+		{Name: "map", DSL: testdata.MultipartDecodeMapDSL, Error: `attribute "labels" of type map cannot be decoded from a multipart part`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -11,6 +11,10 @@ type (
 	HTTPRedirectExpr struct {
 		// URL is the URL that is being redirected to.
 		URL string
+		// LocationFrom is the name of the method result attribute that
+		// holds the URL being redirected to when the URL is computed by
+		// the method.
+		LocationFrom string
 		// StatusCode is the HTTP status code.
 		StatusCode int
 		// Parent expression, one of HTTPEndpointExpr or HTTPFileServerExpr.
@@ -21,9 +25,43 @@ type (
 // EvalName returns the generic definition name used in error messages.
 func (r *HTTPRedirectExpr) EvalName() string {
 	suffix := fmt.Sprintf("redirect to %s with status code %d", r.URL, r.StatusCode)
+	if r.LocationFrom != "" {
+		suffix = fmt.Sprintf("redirect to result attribute %q with status code %d", r.LocationFrom, r.StatusCode)
+	}
 	var prefix string
 	if r.Parent != nil {
 		prefix = r.Parent.EvalName() + " "
 	}
 	return prefix + suffix
 }
+
+// Validate makes sure the status code is a redirection status code and, if the
+// URL is computed by the method, that the method result defines the string
+// attribute holding the URL.
+func (r *HTTPRedirectExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if r.StatusCode < 300 || r.StatusCode > 399 {
+		verr.Add(r, "redirect status code must be a 3xx status code, got %d", r.StatusCode)
+	}
+	if r.LocationFrom == "" {
+		if r.URL == "" {
+			verr.Add(r, "redirect must define the URL or use LocationFrom")
+		}
+		return verr
+	}
+	e, ok := r.Parent.(*HTTPEndpointExpr)
+	if !ok {
+		verr.Add(r, "LocationFrom can only be used in HTTP endpoints")
+		return verr
+	}
+	if e.MethodExpr.IsStreaming() || e.SkipResponseBodyEncodeDecode {
+		verr.Add(r, "LocationFrom cannot be used with streaming endpoints or SkipResponseBodyEncodeDecode")
+	}
+	att := e.MethodExpr.Result.Find(r.LocationFrom)
+	if att == nil || !IsObject(e.MethodExpr.Result.Type) {
+		verr.Add(r, "result attribute %q does not exist", r.LocationFrom)
+	} else if att.Type != String {
+		verr.Add(r, "result attribute %q must be a string, got %s", r.LocationFrom, att.Type.Name())
+	}
+	return verr
+}
@@ -2,18 +2,21 @@ package expr_test
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
 )
 
 func TestHTTPRedirectExprEvalName(t *testing.T) {
 	cases := map[string]struct {
-		url        string
-		statusCode int
-		parent     eval.Expression
-		expected   string
+		url          string
+		locationFrom string
+		statusCode   int
+		parent       eval.Expression
+		expected     string
 	}{
 		"without parent": {
 			url:        "/redirect/dest",
@@ -32,13 +35,50 @@ func TestHTTPRedirectExprEvalName(t *testing.T) {
 			parent:     &expr.HTTPFileServerExpr{FilePath: "/file.json"},
 			expected:   `file server /file.json redirect to /redirect/dest with status code 301`,
 		},
+		"location from result": {
+			locationFrom: "download_url",
+			statusCode:   http.StatusFound,
+			expected:     `redirect to result attribute "download_url" with status code 302`,
+		},
 	}
 	for k, tc := range cases {
 		t.Run(k, func(t *testing.T) {
-			r := expr.HTTPRedirectExpr{URL: tc.url, StatusCode: tc.statusCode, Parent: tc.parent}
+			r := expr.HTTPRedirectExpr{URL: tc.url, LocationFrom: tc.locationFrom, StatusCode: tc.statusCode, Parent: tc.parent}
 			if actual := r.EvalName(); actual != tc.expected {
 				t.Errorf("got %#v, expected %#v", actual, tc.expected)
 			}
 		})
 	}
 }
+
+func TestHTTPRedirectLocationFromDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.RedirectLocationFromValidDSL},
+		{Name: "unknown", DSL: testdata.RedirectLocationFromUnknownDSL, Error: `result attribute "url" does not exist`},
+		{Name: "not string", DSL: testdata.RedirectLocationFromNotStringDSL, Error: `result attribute "download_url" must be a string, got int`},
+		{Name: "invalid status", DSL: testdata.RedirectInvalidStatusDSL, Error: "redirect status code must be a 3xx status code, got 200"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+				e := expr.Root.API.HTTP.Service("redirect-valid").Endpoint("method")
+				if e.Redirect.StatusCode != http.StatusFound {
+					t.Errorf("got status code %d, expected %d", e.Redirect.StatusCode, http.StatusFound)
+				}
+				if len(e.Responses) != 1 || e.Responses[0].Headers.Find("download_url") == nil {
+					t.Errorf("expected a single response mapping download_url to the Location header")
+				}
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
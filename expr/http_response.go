@@ -90,6 +90,10 @@ type (
 		Body *AttributeExpr
 		// Response Content-Type header value
 		ContentType string
+		// ContentTypes lists the media types the response may be
+		// encoded with. The encoder selects one using the request
+		// Accept header. The first element is equal to ContentType.
+		ContentTypes []string
 		// Tag the value a field of the result must have for this
 		// response to be used.
 		Tag [2]string
@@ -137,14 +141,23 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 
 	// text/html and text/plain can only encode strings so make sure there isn't
 	// an explicit conflict with the content-type and response.
-	if (r.ContentType == "text/html" || r.ContentType == "text/plain") && !e.SkipRequestBodyEncodeDecode {
-		if e.MethodExpr.Result.Type != nil && e.MethodExpr.Result.Type != String && e.MethodExpr.Result.Type != Bytes && r.Body == nil {
-			verr.Add(r, fmt.Sprintf("Result type must be String or Bytes when ContentType is '%s'", r.ContentType))
-		}
-		if r.Body != nil && r.Body.Type != String && r.Body.Type != Bytes {
-			verr.Add(r, fmt.Sprintf("Result type must be String or Bytes when ContentType is '%s'", r.ContentType))
+	cts := r.ContentTypes
+	if len(cts) == 0 {
+		cts = []string{r.ContentType}
+	}
+	for _, ct := range cts {
+		if (ct == "text/html" || ct == "text/plain") && !e.SkipRequestBodyEncodeDecode {
+			if e.MethodExpr.Result.Type != nil && e.MethodExpr.Result.Type != String && e.MethodExpr.Result.Type != Bytes && r.Body == nil {
+				verr.Add(r, fmt.Sprintf("Result type must be String or Bytes when ContentType is '%s'", ct))
+			}
+			if r.Body != nil && r.Body.Type != String && r.Body.Type != Bytes {
+				verr.Add(r, fmt.Sprintf("Result type must be String or Bytes when ContentType is '%s'", ct))
+			}
 		}
 	}
+	if len(r.ContentTypes) > 1 && e.SkipResponseBodyEncodeDecode {
+		verr.Add(r, "Response cannot define multiple content types when using SkipResponseBodyEncodeDecode.")
+	}
 
 	rt, isrt := e.MethodExpr.Result.Type.(*ResultTypeExpr)
 	resultAttributeType := func(name string) DataType {
@@ -230,7 +243,9 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 		if e.SkipResponseBodyEncodeDecode {
 			verr.Add(r, "Cannot define a response body when endpoint uses SkipResponseBodyEncodeDecode.")
 		}
-		if att, ok := r.Body.Meta["origin:attribute"]; ok {
+		if r.Body.Type != Empty && e.MethodExpr.Result.Type == Empty {
+			verr.Add(r, "response defines a body but result is empty")
+		} else if att, ok := r.Body.Meta["origin:attribute"]; ok {
 			if resultAttributeType(att[0]) == nil {
 				verr.Add(r, "body %q has no equivalent attribute in%s result type", att[0], inview)
 			}
@@ -317,6 +332,9 @@ func (r *HTTPResponseExpr) Dup() *HTTPResponseExpr {
 		Parent:      r.Parent,
 		Meta:        r.Meta,
 	}
+	if r.ContentTypes != nil {
+		res.ContentTypes = append([]string{}, r.ContentTypes...)
+	}
 	if r.Body != nil {
 		res.Body = DupAtt(r.Body)
 	}
@@ -32,6 +32,7 @@ func TestHTTPResponseValidation(t *testing.T) {
 		{"missing header result attribute", missingHeaderResultAttributeDSL, `HTTP response of service "MissingHeaderResultAttribute" HTTP endpoint "Method": header "bar" has no equivalent attribute in result type, use notation 'attribute_name:header_name' to identify corresponding result type attribute.`},
 		{"missing cookie result attribute", missingCookieResultAttributeDSL, `HTTP response of service "MissingCookieResultAttribute" HTTP endpoint "Method": cookie "bar" has no equivalent attribute in result type, use notation 'attribute_name:cookie_name' to identify corresponding result type attribute.
 service "MissingCookieResultAttribute" HTTP endpoint "Method": attribute "bar" used in HTTP cookies must be a primitive type.`},
+		{"body empty result", emptyResultResponseWithBodyDSL, `HTTP response of service "EmptyResultResponseWithBody" HTTP endpoint "Method": response defines a body but result is empty`},
 		{"skip encode and gRPC", skipEncodeAndGRPCDSL, `service "SkipEncodeAndGRPC" HTTP endpoint "Method": Endpoint response cannot use SkipResponseBodyEncodeDecode and define a gRPC transport.`},
 	}
 	for _, c := range cases {
@@ -48,6 +49,32 @@ service "MissingCookieResultAttribute" HTTP endpoint "Method": attribute "bar" u
 	}
 }
 
+func TestHTTPResponseDefaultStatus(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected int
+	}{
+		{"empty result", emptyResultEmptyResponseDSL, expr.StatusNoContent},
+		{"non empty result", nonEmptyResultEmptyResponseDSL, expr.StatusOK},
+		{"empty result explicit status", emptyResultAcceptedResponseDSL, expr.StatusAccepted},
+		{"empty result no status", emptyResultNoStatusResponseDSL, expr.StatusOK},
+		{"non empty result no status", nonEmptyResultNoStatusResponseDSL, expr.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, c.DSL)
+			e := root.API.HTTP.Services[0].HTTPEndpoints[0]
+			if len(e.Responses) != 1 {
+				t.Fatalf("got %d responses, expected 1", len(e.Responses))
+			}
+			if actual := e.Responses[0].StatusCode; actual != c.Expected {
+				t.Errorf("got status %d, expected %d", actual, c.Expected)
+			}
+		})
+	}
+}
+
 var emptyResultEmptyResponseDSL = func() {
 	Service("EmptyResultEmptyResponse", func() {
 		Method("Method", func() {
@@ -330,3 +357,57 @@ var skipEncodeAndGRPCDSL = func() {
 		})
 	})
 }
+
+var emptyResultResponseWithBodyDSL = func() {
+	var Status = Type("Status", func() {
+		Attribute("message", String)
+	})
+	Service("EmptyResultResponseWithBody", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK, func() {
+					Body(Status)
+				})
+			})
+		})
+	})
+}
+
+var emptyResultAcceptedResponseDSL = func() {
+	Service("EmptyResultAcceptedResponse", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Response(StatusAccepted)
+			})
+		})
+	})
+}
+
+var emptyResultNoStatusResponseDSL = func() {
+	Service("EmptyResultNoStatusResponse", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Response(func() {
+					Description("Response without explicit status code")
+				})
+			})
+		})
+	})
+}
+
+var nonEmptyResultNoStatusResponseDSL = func() {
+	Service("NonEmptyResultNoStatusResponse", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				POST("/")
+				Response(func() {
+					Description("Response without explicit status code")
+				})
+			})
+		})
+	})
+}
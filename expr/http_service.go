@@ -40,6 +40,9 @@ type (
 		HTTPErrors []*HTTPErrorExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*HTTPFileServerExpr
+		// CORS is the CORS policy applied to the service endpoints. It
+		// overrides the API level policy if any.
+		CORS *HTTPCORSExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Meta MetaExpr
@@ -151,6 +154,16 @@ func (svc *HTTPServiceExpr) Parent() *HTTPServiceExpr {
 	return nil
 }
 
+// CORSPolicy returns the CORS policy that applies to the service endpoints:
+// the service policy if defined, the API policy otherwise. It returns nil if
+// neither the service nor the API define a CORS policy.
+func (svc *HTTPServiceExpr) CORSPolicy() *HTTPCORSExpr {
+	if svc.CORS != nil {
+		return svc.CORS
+	}
+	return Root.API.HTTP.CORS
+}
+
 // HTTPError returns the service HTTP error with given name if any.
 func (svc *HTTPServiceExpr) HTTPError(name string) *HTTPErrorExpr {
 	for _, erro := range svc.HTTPErrors {
@@ -214,12 +227,25 @@ func (svc *HTTPServiceExpr) Validate() error {
 			}
 		}
 	}
+	if svc.CORS != nil {
+		if err := svc.CORS.Validate(); err != nil {
+			verr.AddError(svc.CORS, err)
+		}
+	}
 	if n := svc.CanonicalEndpointName; n != "" {
 		if a := svc.Endpoint(n); a == nil {
 			verr.Add(svc, "Unknown canonical endpoint %s", n)
 		}
 	}
 
+	if p, ok := svc.ServiceExpr.Meta.Last("http:version:path"); ok {
+		if !strings.HasPrefix(p, "/") {
+			verr.Add(svc, "invalid version path %q, path must start with \"/\"", p)
+		}
+		if Root.API.Version == "" {
+			verr.Add(svc, "the \"http:version:path\" meta requires the API to define a version")
+		}
+	}
 	// Validate errors (have status codes and bodies are valid)
 	for _, er := range svc.HTTPErrors {
 		verr.Merge(er.Validate())
@@ -0,0 +1,135 @@
+package expr
+
+import "goa.design/goa/v3/eval"
+
+type (
+	// HTTPSSEExpr describes the server-sent events used by a streaming
+	// HTTP endpoint to send its results.
+	HTTPSSEExpr struct {
+		// Events lists the events sent by the server in the order they
+		// are defined.
+		Events []*SSEEventExpr
+		// IDField is the name of the streaming result attribute whose
+		// value is used as the event ID if any.
+		IDField string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+
+	// SSEEventExpr describes a named server-sent event.
+	SSEEventExpr struct {
+		// Name is the event name.
+		Name string
+		// Type is the type of the event data, nil if the event uses the
+		// streaming result type.
+		Type DataType
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (s *HTTPSSEExpr) EvalName() string {
+	var prefix string
+	if s.Endpoint != nil {
+		prefix = s.Endpoint.EvalName() + " "
+	}
+	return prefix + "SSE"
+}
+
+// Multiple returns true if the server sends more than one kind of event in
+// which case each event corresponds to an attribute of the streaming result.
+func (s *HTTPSSEExpr) Multiple() bool {
+	return len(s.Events) > 1
+}
+
+// Event returns the event with the given name, nil if there isn't one.
+func (s *HTTPSSEExpr) Event(name string) *SSEEventExpr {
+	for _, ev := range s.Events {
+		if ev.Name == name {
+			return ev
+		}
+	}
+	return nil
+}
+
+// Validate makes sure the endpoint only streams its result, that the event
+// types match the streaming result and that the ID field is a string
+// attribute of the result.
+func (s *HTTPSSEExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if len(s.Events) == 0 {
+		verr.Add(s, "SSE must define at least one event")
+	}
+	seen := make(map[string]struct{})
+	for _, ev := range s.Events {
+		if ev.Name == "" {
+			verr.Add(s, "SSE event name cannot be empty")
+		}
+		if _, ok := seen[ev.Name]; ok {
+			verr.Add(s, "SSE event %q is defined multiple times", ev.Name)
+		}
+		seen[ev.Name] = struct{}{}
+	}
+	if s.Endpoint == nil || s.Endpoint.MethodExpr == nil {
+		return verr
+	}
+	m := s.Endpoint.MethodExpr
+	if m.Stream != ServerStreamKind {
+		verr.Add(s, "SSE can only be used on endpoints that define a StreamingResult and no StreamingPayload")
+		return verr
+	}
+	if s.Endpoint.WebSocket != nil {
+		verr.Add(s, "SSE cannot be used together with WebSocket")
+	}
+	if rt, ok := m.Result.Type.(*ResultTypeExpr); ok && rt.HasMultipleViews() {
+		if _, ok := m.Result.Meta["view"]; !ok {
+			verr.Add(s, "SSE requires the view of the streaming result type %q to be set as it defines multiple views", rt.Name())
+		}
+	}
+	res := m.Result
+	if ut, ok := res.Type.(UserType); ok {
+		res = ut.Attribute()
+	}
+	if s.Multiple() {
+		obj := AsObject(res.Type)
+		if obj == nil {
+			verr.Add(s, "SSE defines multiple events so the streaming result must be an object with one attribute per event")
+			return verr
+		}
+		for _, ev := range s.Events {
+			att := obj.Attribute(ev.Name)
+			if att == nil {
+				verr.Add(s, "SSE event %q does not correspond to an attribute of the streaming result", ev.Name)
+				continue
+			}
+			if ev.Type != nil && !Equal(ev.Type, att.Type) {
+				verr.Add(s, "SSE event %q type %q does not match the type %q of the streaming result attribute", ev.Name, ev.Type.Name(), att.Type.Name())
+			}
+			if res.IsRequired(ev.Name) || res.HasDefaultValue(ev.Name) {
+				// The generated code sends the first event whose
+				// attribute is set.
+				verr.Add(s, "SSE event %q attribute of the streaming result cannot be required or have a default value", ev.Name)
+			}
+		}
+		for _, nat := range *obj {
+			if s.Event(nat.Name) == nil && nat.Name != s.IDField {
+				verr.Add(s, "streaming result attribute %q does not correspond to an SSE event", nat.Name)
+			}
+		}
+	} else if len(s.Events) == 1 {
+		if ev := s.Events[0]; ev.Type != nil && !Equal(ev.Type, m.Result.Type) {
+			verr.Add(s, "SSE event %q type %q does not match the streaming result type %q", ev.Name, ev.Type.Name(), m.Result.Type.Name())
+		}
+	}
+	if s.IDField != "" {
+		var att *AttributeExpr
+		if obj := AsObject(res.Type); obj != nil {
+			att = obj.Attribute(s.IDField)
+		}
+		if att == nil {
+			verr.Add(s, "SSE ID field %q is not an attribute of the streaming result", s.IDField)
+		} else if att.Type != String || att.IsNullable() {
+			verr.Add(s, "SSE ID field %q must be a non nullable string", s.IDField)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,59 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestSSEDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.SSEValidDSL},
+		{Name: "single event", DSL: testdata.SSESingleEventDSL},
+		{Name: "not server streaming", DSL: testdata.SSENotServerStreamingDSL, Error: "SSE can only be used on endpoints that define a StreamingResult and no StreamingPayload"},
+		{Name: "no event", DSL: testdata.SSENoEventDSL, Error: "SSE must define at least one event"},
+		{Name: "invalid event type", DSL: testdata.SSEInvalidEventTypeDSL, Error: `SSE event "message" type "int" does not match the streaming result type "string"`},
+		{Name: "unknown event", DSL: testdata.SSEUnknownEventDSL, Error: `SSE event "error" does not correspond to an attribute of the streaming result`},
+		{Name: "required event", DSL: testdata.SSERequiredEventDSL, Error: `SSE event "message" attribute of the streaming result cannot be required or have a default value`},
+		{Name: "invalid id field", DSL: testdata.SSEInvalidIDFieldDSL, Error: `SSE ID field "id" must be a non nullable string`},
+		{Name: "missing id field", DSL: testdata.SSEMissingIDFieldDSL, Error: `SSE ID field "id" is not an attribute of the streaming result`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestSSEDSLValues(t *testing.T) {
+	expr.RunDSL(t, testdata.SSEValidDSL)
+	e := expr.Root.API.HTTP.Service("sse-valid").Endpoint("method")
+	if e.SSE == nil {
+		t.Fatal("got nil SSE")
+	}
+	if !e.SSE.Multiple() {
+		t.Error("got single event, expected multiple events")
+	}
+	if len(e.SSE.Events) != 2 || e.SSE.Events[0].Name != "message" || e.SSE.Events[1].Name != "error" {
+		t.Errorf("got events %v, expected message and error", e.SSE.Events)
+	}
+	if e.SSE.Events[1].Type != nil {
+		t.Errorf("got error event type %v, expected nil", e.SSE.Events[1].Type)
+	}
+	if e.SSE.IDField != "id" {
+		t.Errorf("got ID field %q, expected %q", e.SSE.IDField, "id")
+	}
+}
@@ -0,0 +1,38 @@
+package expr_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestHTTPExprMethodOverride(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected []string
+		Error    string
+	}{
+		{Name: "default", DSL: testdata.MethodOverrideDefaultDSL, Expected: []string{"PUT", "PATCH", "DELETE"}},
+		{Name: "valid", DSL: testdata.MethodOverrideValidDSL, Expected: []string{"PATCH"}},
+		{Name: "unsafe", DSL: testdata.MethodOverrideUnsafeDSL, Error: `invalid method override "GET", POST requests may only be overridden with PUT, PATCH or DELETE`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error != "" {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+				return
+			}
+			root := expr.RunDSL(t, c.DSL)
+			if got := root.API.HTTP.MethodOverrides; !reflect.DeepEqual(got, c.Expected) {
+				t.Errorf("got method overrides %v, expected %v", got, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+// DefaultWebhookSignatureHeader is the name of the HTTP request header used by
+// default to carry the webhook signature.
+const DefaultWebhookSignatureHeader = "X-Signature"
+
+// DefaultWebhookSignatureAlgorithm is the hash function used by default to
+// compute the HMAC webhook signatures.
+const DefaultWebhookSignatureAlgorithm = "sha256"
+
+// WebhookSignatureAlgorithms lists the hash functions that may be used to
+// compute the HMAC webhook signatures.
+var WebhookSignatureAlgorithms = []string{"sha1", "sha256", "sha512"}
+
+type (
+	// HTTPWebhookSignatureExpr describes the verification of the HMAC
+	// signature of the requests sent to a HTTP endpoint. The signature is
+	// computed over the raw request body using a secret shared with the
+	// sender.
+	HTTPWebhookSignatureExpr struct {
+		// Header is the name of the HTTP request header that carries the
+		// signature.
+		Header string
+		// Algorithm is the name of the hash function used to compute
+		// the HMAC.
+		Algorithm string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (s *HTTPWebhookSignatureExpr) EvalName() string {
+	suffix := fmt.Sprintf("webhook signature %q", s.Header)
+	var prefix string
+	if s.Endpoint != nil {
+		prefix = s.Endpoint.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the algorithm is supported and that the endpoint reads
+// the request body as the signature is verified against the raw body before
+// it is decoded.
+func (s *HTTPWebhookSignatureExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if s.Header == "" {
+		verr.Add(s, "webhook signature header name cannot be empty")
+	}
+	supported := false
+	for _, a := range WebhookSignatureAlgorithms {
+		if a == s.Algorithm {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		verr.Add(s, "unsupported webhook signature algorithm %q, must be one of %v", s.Algorithm, WebhookSignatureAlgorithms)
+	}
+	if s.Endpoint != nil && s.Endpoint.MethodExpr != nil {
+		if s.Endpoint.MethodExpr.IsStreaming() {
+			verr.Add(s, "WebhookSignature cannot be used on endpoints that define a StreamingPayload or a StreamingResult")
+		}
+		if s.Endpoint.SkipRequestBodyEncodeDecode {
+			verr.Add(s, "WebhookSignature cannot be used with SkipRequestBodyEncodeDecode")
+		}
+	}
+	return verr
+}
@@ -0,0 +1,48 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestWebhookSignatureDSL(t *testing.T) {
+	cases := []struct {
+		Name      string
+		DSL       func()
+		Service   string
+		Header    string
+		Algorithm string
+		Error     string
+	}{
+		{Name: "valid", DSL: testdata.WebhookSignatureValidDSL, Service: "webhook-signature-valid", Header: "X-Hub-Signature", Algorithm: "sha512"},
+		{Name: "default", DSL: testdata.WebhookSignatureDefaultDSL, Service: "webhook-signature-default", Header: "X-Signature", Algorithm: "sha256"},
+		{Name: "invalid algorithm", DSL: testdata.WebhookSignatureInvalidAlgorithmDSL, Error: `unsupported webhook signature algorithm "md5"`},
+		{Name: "streaming", DSL: testdata.WebhookSignatureStreamingDSL, Error: "WebhookSignature cannot be used on endpoints that define a StreamingPayload or a StreamingResult"},
+		{Name: "skip decode", DSL: testdata.WebhookSignatureSkipDecodeDSL, Error: "WebhookSignature cannot be used with SkipRequestBodyEncodeDecode"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error != "" {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+				return
+			}
+			expr.RunDSL(t, c.DSL)
+			sig := expr.Root.API.HTTP.Service(c.Service).Endpoint("method").WebhookSignature
+			if sig == nil {
+				t.Fatal("got nil webhook signature")
+			}
+			if sig.Header != c.Header {
+				t.Errorf("got header %q, expected %q", sig.Header, c.Header)
+			}
+			if sig.Algorithm != c.Algorithm {
+				t.Errorf("got algorithm %q, expected %q", sig.Algorithm, c.Algorithm)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package expr
+
+import (
+	"fmt"
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+const (
+	// WebSocketFramingText sends the messages as WebSocket text frames.
+	WebSocketFramingText = "text"
+
+	// WebSocketFramingBinary sends the messages as WebSocket binary frames.
+	WebSocketFramingBinary = "binary"
+)
+
+type (
+	// HTTPWebSocketExpr describes the WebSocket connection used by a
+	// streaming HTTP endpoint.
+	HTTPWebSocketExpr struct {
+		// Path is the path used to upgrade the HTTP connection if any.
+		// The path is added to the endpoint routes as a GET route.
+		Path string
+		// Framing is the WebSocket frame type used to send messages,
+		// one of WebSocketFramingText or WebSocketFramingBinary.
+		Framing string
+		// PingInterval is the interval at which the server sends ping
+		// messages. Zero disables the pings.
+		PingInterval time.Duration
+		// PongTimeout is the duration after which the server closes the
+		// connection if no pong message is received. Zero disables the
+		// timeout.
+		PongTimeout time.Duration
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (w *HTTPWebSocketExpr) EvalName() string {
+	suffix := "WebSocket"
+	if w.Path != "" {
+		suffix = fmt.Sprintf("WebSocket %q", w.Path)
+	}
+	var prefix string
+	if w.Endpoint != nil {
+		prefix = w.Endpoint.EvalName() + " "
+	}
+	return prefix + suffix
+}
+
+// Validate makes sure the endpoint streams its payload or result and that the
+// framing and keepalive durations are valid.
+func (w *HTTPWebSocketExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if w.Framing != WebSocketFramingText && w.Framing != WebSocketFramingBinary {
+		verr.Add(w, "WebSocket framing must be %q or %q, got %q", WebSocketFramingText, WebSocketFramingBinary, w.Framing)
+	}
+	if w.PingInterval < 0 {
+		verr.Add(w, "WebSocket ping interval must be positive, got %s", w.PingInterval)
+	}
+	if w.PongTimeout < 0 {
+		verr.Add(w, "WebSocket pong timeout must be positive, got %s", w.PongTimeout)
+	}
+	if w.PongTimeout > 0 {
+		if w.PingInterval == 0 {
+			verr.Add(w, "WebSocket pong timeout requires a ping interval")
+		} else if w.PongTimeout <= w.PingInterval {
+			verr.Add(w, "WebSocket pong timeout (%s) must be greater than the ping interval (%s)", w.PongTimeout, w.PingInterval)
+		}
+	}
+	if w.Endpoint != nil && w.Endpoint.MethodExpr != nil {
+		m := w.Endpoint.MethodExpr
+		if !m.IsStreaming() {
+			verr.Add(w, "WebSocket can only be used on endpoints that define a StreamingPayload or a StreamingResult")
+		}
+		if m.Stream == ClientStreamKind && w.PongTimeout > 0 {
+			// The client does not read from the connection and thus does
+			// not answer pings until it closes the stream.
+			verr.Add(w, "WebSocket pong timeout cannot be used on endpoints that only define a StreamingPayload")
+		}
+	}
+	return verr
+}
@@ -0,0 +1,57 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestWebSocketDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.WebSocketValidDSL},
+		{Name: "invalid framing", DSL: testdata.WebSocketInvalidFramingDSL, Error: `WebSocket framing must be "text" or "binary", got "json"`},
+		{Name: "invalid pong timeout", DSL: testdata.WebSocketInvalidPongTimeoutDSL, Error: "WebSocket pong timeout (30s) must be greater than the ping interval (1m0s)"},
+		{Name: "pong timeout without ping", DSL: testdata.WebSocketPongTimeoutNoPingDSL, Error: "WebSocket pong timeout requires a ping interval"},
+		{Name: "client stream pong timeout", DSL: testdata.WebSocketClientStreamPongTimeoutDSL, Error: "WebSocket pong timeout cannot be used on endpoints that only define a StreamingPayload"},
+		{Name: "not streaming", DSL: testdata.WebSocketNotStreamingDSL, Error: "WebSocket can only be used on endpoints that define a StreamingPayload or a StreamingResult"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestWebSocketDSLValues(t *testing.T) {
+	expr.RunDSL(t, testdata.WebSocketValidDSL)
+	e := expr.Root.API.HTTP.Service("websocket-valid").Endpoint("method")
+	if e.WebSocket == nil {
+		t.Fatal("got nil websocket")
+	}
+	if e.WebSocket.Framing != expr.WebSocketFramingBinary {
+		t.Errorf("got framing %q, expected %q", e.WebSocket.Framing, expr.WebSocketFramingBinary)
+	}
+	if e.WebSocket.PingInterval != 30*time.Second {
+		t.Errorf("got ping interval %s, expected %s", e.WebSocket.PingInterval, 30*time.Second)
+	}
+	if e.WebSocket.PongTimeout != time.Minute {
+		t.Errorf("got pong timeout %s, expected %s", e.WebSocket.PongTimeout, time.Minute)
+	}
+	if len(e.Routes) != 1 || e.Routes[0].Method != "GET" || e.Routes[0].Path != "/ws" {
+		t.Errorf("got routes %v, expected a single GET /ws route", e.Routes)
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"goa.design/goa/v3/eval"
 )
 
-// Register DSL roots.
+// Register DSL roots and transforms.
 func init() {
 	if err := eval.Register(Root); err != nil {
 		panic(err) // bug
@@ -12,4 +12,8 @@ func init() {
 	if err := eval.Register(Root.GeneratedTypes); err != nil {
 		panic(err) // bug
 	}
+	// Transforms run in the order of their names: the generated attributes
+	// must be removed from the payloads before the batch methods reuse them.
+	eval.RegisterTransform("attribute:generated", removeGeneratedAttributes)
+	eval.RegisterTransform("batch", addBatchMethods)
 }
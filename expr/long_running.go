@@ -0,0 +1,108 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// LongRunningExpr describes a method that starts a long-running
+	// operation. The method returns a job that clients poll with another
+	// method of the same service until the operation completes.
+	LongRunningExpr struct {
+		// JobType is the type of the job returned by the method and by
+		// the poll method.
+		JobType UserType
+		// PollMethod is the name of the method used to poll the job.
+		PollMethod string
+		// Method is the method that starts the long-running operation.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (l *LongRunningExpr) EvalName() string {
+	var prefix string
+	if l.Method != nil {
+		prefix = l.Method.EvalName() + " "
+	}
+	return prefix + "long-running operation"
+}
+
+// Poll returns the method used to poll the job, nil if there is none.
+func (l *LongRunningExpr) Poll() *MethodExpr {
+	if l.Method == nil || l.Method.Service == nil {
+		return nil
+	}
+	return l.Method.Service.Method(l.PollMethod)
+}
+
+// Validate makes sure the job type is an object returned by both the method
+// and the poll method and that the attributes of the poll method payload are
+// required primitive attributes of the job so that the payload can be built
+// from the job.
+func (l *LongRunningExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if l.JobType == nil {
+		verr.Add(l, "missing job type, use JobType to set the type of the job returned by the method")
+	} else if !IsObject(l.JobType) {
+		verr.Add(l, "job type %q must be an object", l.JobType.Name())
+	}
+	if l.PollMethod == "" {
+		verr.Add(l, "missing poll method, use PollMethod to set the name of the method used to poll the job")
+	}
+	if l.Method == nil || len(verr.Errors) > 0 {
+		return verr
+	}
+	if l.Method.IsStreaming() {
+		verr.Add(l, "streaming methods cannot be long-running")
+	}
+	if !isJob(l.Method.Result, l.JobType) {
+		verr.Add(l, "method result must be the job type %q", l.JobType.Name())
+	}
+	poll := l.Poll()
+	if poll == nil {
+		verr.Add(l, "poll method %q not found in service %q", l.PollMethod, l.Method.Service.Name)
+		return verr
+	}
+	if poll == l.Method {
+		verr.Add(l, "poll method %q cannot be the long-running method", l.PollMethod)
+		return verr
+	}
+	if poll.IsStreaming() {
+		verr.Add(l, "poll method %q cannot be a streaming method", l.PollMethod)
+	}
+	if !isJob(poll.Result, l.JobType) {
+		verr.Add(l, "poll method %q result must be the job type %q", l.PollMethod, l.JobType.Name())
+	}
+	if poll.Payload == nil || poll.Payload.Type == Empty {
+		return verr
+	}
+	pobj := AsObject(poll.Payload.Type)
+	if pobj == nil {
+		verr.Add(l, "poll method %q payload must be an object", l.PollMethod)
+		return verr
+	}
+	job := l.JobType.Attribute()
+	jobj := AsObject(job.Type)
+	for _, nat := range *pobj {
+		jatt := jobj.Attribute(nat.Name)
+		switch {
+		case jatt == nil:
+			verr.Add(l, "poll method %q payload attribute %q is not a job attribute", l.PollMethod, nat.Name)
+		case !IsPrimitive(jatt.Type) || jatt.Type.Hash() != nat.Attribute.Type.Hash():
+			verr.Add(l, "poll method %q payload attribute %q must be a primitive with the same type as the job attribute", l.PollMethod, nat.Name)
+		case !job.IsRequired(nat.Name):
+			verr.Add(l, "poll method %q payload attribute %q must be a required job attribute", l.PollMethod, nat.Name)
+		}
+	}
+	return verr
+}
+
+// isJob returns true if the type of att is the job type.
+func isJob(att *AttributeExpr, job UserType) bool {
+	if att == nil {
+		return false
+	}
+	ut, ok := att.Type.(UserType)
+	return ok && ut.ID() == job.ID()
+}
@@ -0,0 +1,49 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestLongRunningDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.LongRunningValidDSL},
+		{Name: "missing poll method", DSL: testdata.LongRunningMissingPollDSL, Error: "missing poll method"},
+		{Name: "unknown poll method", DSL: testdata.LongRunningUnknownPollDSL, Error: `poll method "poll" not found in service "long-running-unknown-poll"`},
+		{Name: "result mismatch", DSL: testdata.LongRunningResultMismatchDSL, Error: `poll method "poll" result must be the job type "Job"`},
+		{Name: "unknown payload attribute", DSL: testdata.LongRunningUnknownPayloadAttributeDSL, Error: `poll method "poll" payload attribute "job_id" is not a job attribute`},
+		{Name: "optional payload attribute", DSL: testdata.LongRunningOptionalPayloadAttributeDSL, Error: `poll method "poll" payload attribute "status" must be a required job attribute`},
+		{Name: "poll without HTTP", DSL: testdata.LongRunningPollNoHTTPDSL, Error: `poll method "poll" of long-running endpoint must define an HTTP endpoint`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestLongRunningDefaults(t *testing.T) {
+	root := expr.RunDSL(t, testdata.LongRunningValidDSL)
+	m := root.Service("long-running-valid").Method("start")
+	if ut, ok := m.Result.Type.(expr.UserType); !ok || ut.Name() != "Job" {
+		t.Errorf("got result type %q, expected the job type", m.Result.Type.Name())
+	}
+	e := root.API.HTTP.Service("long-running-valid").Endpoint("start")
+	if s := e.Responses[0].StatusCode; s != expr.StatusAccepted {
+		t.Errorf("got status %d, expected %d", s, expr.StatusAccepted)
+	}
+}
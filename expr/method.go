@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -40,9 +41,69 @@ type (
 		Stream StreamKind
 		// StreamingPayload is the payload sent across the stream.
 		StreamingPayload *AttributeExpr
+		// Since is the first API version in which the method is
+		// available if any.
+		Since string
+		// Until is the last API version in which the method is available
+		// if any.
+		Until string
+		// Sunset is the date after which the deprecated method may stop
+		// being available. The zero value indicates that the method is
+		// not deprecated.
+		Sunset time.Time
+		// Trace describes the OpenTelemetry instrumentation of the
+		// method if any.
+		Trace *TraceExpr
+		// Publish describes the publication of the streamed results to
+		// a message broker if any.
+		Publish *PublishExpr
+		// LongRunning describes the long-running operation started by
+		// the method if any.
+		LongRunning *LongRunningExpr
+		// Concurrency describes the maximum number of requests handled
+		// concurrently by the method if any.
+		Concurrency *ConcurrencyExpr
+		// FeatureGate describes the feature flag that enables the method
+		// at runtime if any.
+		FeatureGate *FeatureGateExpr
+		// SLO describes the service level objectives of the method if
+		// any.
+		SLO *SLOExpr
+		// ServerDefaults lists the payload fields initialized
+		// server-side when absent from the request.
+		ServerDefaults []*ServerDefaultExpr
+		// DefaultsFrom lists the payload fields initialized with the
+		// value of other payload fields when absent from the request.
+		DefaultsFrom []*DefaultFromExpr
+		// StreamTrailers is the object attribute listing the trailers
+		// sent after the streamed results if any.
+		StreamTrailers *AttributeExpr
+		// Idempotent is set when the design declares explicitly whether
+		// the method is idempotent. The transports infer the
+		// idempotency of the method when nil, see
+		// HTTPEndpointExpr.IsIdempotent.
+		Idempotent *bool
+		// Idempotency defines the idempotency key handling of the
+		// method HTTP endpoint when Idempotent is used in the method
+		// expression, see HTTPEndpointExpr.Idempotency. The method
+		// must define an HTTP endpoint when set.
+		Idempotency *HTTPIdempotencyExpr
+		// SparseFields is true if the clients may select the result
+		// fields included in the responses, see SparseFieldNames.
+		SparseFields bool
+		// RequestExamples lists the requests and expected responses
+		// used to generate the method integration tests.
+		RequestExamples []*RequestExampleExpr
+		// Batch describes the batch processed by the method if the
+		// method was generated with the Batch DSL.
+		Batch *BatchExpr
 	}
 )
 
+// SparseFieldsParam is the name of the HTTP query string parameter listing the
+// result fields selected by the clients of methods that use SparseFields.
+const SparseFieldsParam = "fields"
+
 const (
 	// NoStreamKind represents no payload or result stream in method.
 	NoStreamKind StreamKind = iota + 1
@@ -83,7 +144,7 @@ func (m *MethodExpr) EvalName() string {
 }
 
 // Prepare makes sure the payload and result types are initialized (to the Empty
-// type if nil).
+// type if nil) and adds the inherited API errors to the method errors.
 func (m *MethodExpr) Prepare() {
 	if m.Payload == nil {
 		m.Payload = &AttributeExpr{Type: Empty}
@@ -94,6 +155,47 @@ func (m *MethodExpr) Prepare() {
 	if m.Result == nil {
 		m.Result = &AttributeExpr{Type: Empty}
 	}
+	// Inherit the API errors that apply to all methods unless the method or
+	// the service defines an error with the same name. This is done prior
+	// to preparing the transport endpoints so that the API error responses
+	// get inherited as well.
+loop:
+	for _, e := range Root.Errors {
+		if !e.IsInherited() {
+			continue
+		}
+		for _, f := range m.Errors {
+			if e.Name == f.Name {
+				continue loop
+			}
+		}
+		for _, f := range m.Service.Errors {
+			if e.Name == f.Name {
+				continue loop
+			}
+		}
+		m.Errors = append(m.Errors, e)
+	}
+}
+
+// SparseFieldNames returns the names of the result fields that may be
+// selected by the clients of the method: the names of the attributes of the
+// result object or of the result array elements. Only top level fields may be
+// selected.
+func (m *MethodExpr) SparseFieldNames() []string {
+	dt := m.Result.Type
+	if arr := AsArray(dt); arr != nil {
+		dt = arr.ElemType.Type
+	}
+	obj := AsObject(dt)
+	if obj == nil {
+		return nil
+	}
+	names := make([]string, len(*obj))
+	for i, nat := range *obj {
+		names[i] = nat.Name
+	}
+	return names
 }
 
 // Validate validates the method payloads, results, and errors (if any).
@@ -109,6 +211,9 @@ func (m *MethodExpr) Validate() error {
 	} else if len(Root.API.Requirements) > 0 {
 		requirements = Root.API.Requirements
 	}
+	if isNoSecurity(m.Requirements) && len(m.Requirements) > 1 {
+		verr.Add(m, "NoSecurity cannot be used together with Security in method %q of service %q", m.Name, m.Service.Name)
+	}
 	var (
 		hasBasicAuth bool
 		hasAPIKey    bool
@@ -160,6 +265,11 @@ func (m *MethodExpr) Validate() error {
 				verr.Add(m, "security scope %q not found in any of the security schemes.", scope)
 			}
 		}
+		if len(r.RequiredScopes) > 0 {
+			if e := m.Error(ForbiddenErrorName); e != nil && e.Type != ErrorResult {
+				verr.Add(m, "error %q must use the default error type as the method requires scopes", ForbiddenErrorName)
+			}
+		}
 	}
 	if !hasBasicAuth {
 		if hasTag(m.Payload, "security:username") {
@@ -184,6 +294,105 @@ func (m *MethodExpr) Validate() error {
 			verr.Add(m, "payload of method %q of service %q defines a OAuth2 access token attribute, but no OAuth2 security scheme exist", m.Name, m.Service.Name)
 		}
 	}
+	if m.Idempotency != nil && !m.hasHTTPEndpoint() {
+		verr.Add(m, "Idempotent with idempotency keys requires the method to define an HTTP endpoint, use HTTP to define one")
+	}
+	if m.Since != "" {
+		if err := ValidateVersion(m.Since); err != nil {
+			verr.Add(m, "Since: %s", err)
+		}
+	}
+	if m.Until != "" {
+		if err := ValidateVersion(m.Until); err != nil {
+			verr.Add(m, "Until: %s", err)
+		}
+	}
+	if m.Since != "" && m.Until != "" && CompareVersions(m.Since, m.Until) > 0 {
+		verr.Add(m, "version %q given to Since is greater than version %q given to Until", m.Since, m.Until)
+	}
+	if m.Trace != nil {
+		if err := m.Trace.Validate(); err != nil {
+			verr.AddError(m.Trace, err)
+		}
+	}
+	if m.Publish != nil {
+		if err := m.Publish.Validate(); err != nil {
+			verr.AddError(m.Publish, err)
+		}
+	}
+	if m.LongRunning != nil {
+		if err := m.LongRunning.Validate(); err != nil {
+			verr.AddError(m.LongRunning, err)
+		}
+	}
+	examples := make(map[string]struct{}, len(m.RequestExamples))
+	for _, ex := range m.RequestExamples {
+		if _, ok := examples[ex.Name]; ok {
+			verr.Add(m, "request example %q is defined more than once", ex.Name)
+		}
+		examples[ex.Name] = struct{}{}
+		if err := ex.Validate(); err != nil {
+			verr.AddError(ex, err)
+		}
+	}
+	if m.Concurrency != nil {
+		if err := m.Concurrency.Validate(); err != nil {
+			verr.AddError(m.Concurrency, err)
+		}
+	}
+	if m.FeatureGate != nil {
+		if err := m.FeatureGate.Validate(); err != nil {
+			verr.AddError(m.FeatureGate, err)
+		}
+	}
+	if m.SLO != nil {
+		if err := m.SLO.Validate(); err != nil {
+			verr.AddError(m.SLO, err)
+		}
+	}
+	defaults := make(map[string]struct{}, len(m.ServerDefaults))
+	for _, d := range m.ServerDefaults {
+		if _, ok := defaults[d.Field]; ok {
+			verr.Add(m, "server default of attribute %q is defined more than once", d.Field)
+		}
+		defaults[d.Field] = struct{}{}
+		if err := d.Validate(); err != nil {
+			verr.AddError(d, err)
+		}
+	}
+	sources := make(map[string]string, len(m.DefaultsFrom))
+	for _, d := range m.DefaultsFrom {
+		if _, ok := sources[d.Field]; ok {
+			verr.Add(m, "default of attribute %q from another attribute is defined more than once", d.Field)
+		}
+		if _, ok := defaults[d.Field]; ok {
+			verr.Add(m, "attribute %q cannot define both a server default and a default from another attribute", d.Field)
+		}
+		sources[d.Field] = d.Source
+		if err := d.Validate(); err != nil {
+			verr.AddError(d, err)
+		}
+	}
+	for _, d := range m.DefaultsFrom {
+		seen := map[string]struct{}{d.Field: {}}
+		for src, ok := d.Source, true; ok; src, ok = sources[src] {
+			if _, cyc := seen[src]; cyc {
+				verr.Add(m, "defaults from other attributes of %q form a cycle", d.Field)
+				break
+			}
+			seen[src] = struct{}{}
+		}
+	}
+	if m.StreamTrailers != nil {
+		verr.Merge(m.validateStreamTrailers())
+	}
+	if m.SparseFields {
+		if m.IsStreaming() {
+			verr.Add(m, "SparseFields cannot be used with streaming methods")
+		} else if len(m.SparseFieldNames()) == 0 {
+			verr.Add(m, "SparseFields requires a result that is an object or an array of objects")
+		}
+	}
 	if m.StreamingPayload.Type != Empty {
 		verr.Merge(m.StreamingPayload.Validate("streaming_payload", m))
 	}
@@ -262,6 +471,22 @@ func hasTagPrefix(p *AttributeExpr, prefix string) bool {
 	return false
 }
 
+// validateStreamTrailers validates the trailers sent after the streamed
+// results. Trailers are sent as HTTP headers or gRPC metadata and must thus
+// hold primitive values.
+func (m *MethodExpr) validateStreamTrailers() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !m.IsResultStreaming() {
+		verr.Add(m, "trailers can only be defined on methods that stream their result, use StreamingResult to define one")
+	}
+	for _, nat := range *AsObject(m.StreamTrailers.Type) {
+		if !IsPrimitive(nat.Attribute.Type) || nat.Attribute.Type == Bytes || nat.Attribute.Type == Any {
+			verr.Add(m, "trailer %q must be a string, a boolean or a number", nat.Name)
+		}
+	}
+	return verr
+}
+
 // Finalize makes sure the method payload and result types are set. It also
 // projects the result if it is a result type and a view is explicitly set in
 // the design or a result type having at most one view.
@@ -284,6 +509,9 @@ func (m *MethodExpr) Finalize() {
 			rt.Finalize()
 		}
 	}
+	if m.Publish != nil {
+		m.Publish.Finalize()
+	}
 	for _, e := range m.Service.Errors {
 		found := false
 		for _, f := range m.Errors {
@@ -300,22 +528,8 @@ func (m *MethodExpr) Finalize() {
 		e.Finalize()
 	}
 
-	// Inherit security requirements
-	noreq := false
-loop:
-	for _, r := range m.Requirements {
-		// Handle special case of no security
-		for _, s := range r.Schemes {
-			if s.Kind == NoKind {
-				noreq = true
-				break loop
-			}
-		}
-	}
-	if noreq {
-		m.Requirements = nil
-		return
-	}
+	// Inherit security requirements, the requirements defined on the
+	// method override the service and API requirements entirely.
 	if len(m.Requirements) == 0 {
 		if len(m.Service.Requirements) > 0 {
 			m.Requirements = copyReqs(m.Service.Requirements)
@@ -323,6 +537,19 @@ loop:
 			m.Requirements = copyReqs(Root.API.Requirements)
 		}
 	}
+	// Handle special case of no security
+	if isNoSecurity(m.Requirements) {
+		m.Requirements = nil
+	}
+}
+
+// hasHTTPEndpoint returns true if the method defines an HTTP endpoint.
+func (m *MethodExpr) hasHTTPEndpoint() bool {
+	if Root.API == nil || Root.API.HTTP == nil {
+		return false
+	}
+	svc := Root.API.HTTP.Service(m.Service.Name)
+	return svc != nil && svc.Endpoint(m.Name) != nil
 }
 
 // IsStreaming determines whether the method streams payload or result.
@@ -340,12 +567,25 @@ func (m *MethodExpr) IsResultStreaming() bool {
 	return m.Stream == ServerStreamKind || m.Stream == BidirectionalStreamKind
 }
 
+// isNoSecurity returns true if reqs contains a requirement defined with
+// NoSecurity.
+func isNoSecurity(reqs []*SecurityExpr) bool {
+	for _, r := range reqs {
+		for _, s := range r.Schemes {
+			if s.Kind == NoKind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // helper function that duplicates just enough of a security expression so that
 // its scheme names can be overridden without affecting the original.
 func copyReqs(reqs []*SecurityExpr) []*SecurityExpr {
 	reqs2 := make([]*SecurityExpr, len(reqs))
 	for i, req := range reqs {
-		req2 := &SecurityExpr{Scopes: req.Scopes}
+		req2 := &SecurityExpr{Scopes: req.Scopes, RequiredScopes: req.RequiredScopes}
 		schs := make([]*SchemeExpr, len(req.Schemes))
 		for j, sch := range req.Schemes {
 			schs[j] = &SchemeExpr{
@@ -0,0 +1,55 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// MethodDefaultExpr describes a default value of a payload attribute
+	// that only applies to the payloads of specific methods.
+	MethodDefaultExpr struct {
+		// Value is the default value.
+		Value interface{}
+		// Methods lists the names of the methods whose payloads use the
+		// default value.
+		Methods []string
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (d *MethodDefaultExpr) EvalName() string {
+	return "method default"
+}
+
+// AppliesTo returns true if the default value applies to the payload of the
+// method with the given name.
+func (d *MethodDefaultExpr) AppliesTo(method string) bool {
+	for _, m := range d.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate makes sure the default value lists at least one method and that
+// the methods exist.
+func (d *MethodDefaultExpr) Validate(ctx string, parent eval.Expression) *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if len(d.Methods) == 0 {
+		verr.Add(parent, "%sdefault value %#v must list the methods it applies to with Only", ctx, d.Value)
+	}
+	for _, name := range d.Methods {
+		found := false
+		for _, svc := range Root.Services {
+			if svc.Method(name) != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			verr.Add(parent, "%sdefault value %#v applies to unknown method %q", ctx, d.Value, name)
+		}
+	}
+	return verr
+}
@@ -32,6 +32,32 @@ service "AnotherInvalidSecuritySchemesService" method "Method": payload of metho
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a JWT token attribute, but no JWT auth security scheme exist
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a OAuth2 access token attribute, but no OAuth2 security scheme exist`,
 		},
+		{"invalid-required-scopes", testdata.InvalidRequiredScopesDSL,
+			`service "InvalidRequiredScopesService" method "SecureMethod": error "forbidden" must use the default error type as the method requires scopes`,
+		},
+		{"valid-versions", testdata.VersionsDSL, ""},
+		{"invalid-versions", testdata.InvalidVersionsDSL,
+			`service "InvalidVersionsService" method "InvalidSince": Since: invalid version "version2", version must be a semantic version optionally prefixed with "v" such as "v2" or "2.3.1"
+service "InvalidVersionsService" method "InvalidRange": version "v3" given to Since is greater than version "v2.1" given to Until`,
+		},
+		{"valid-trailers", testdata.TrailersDSL, ""},
+		{"valid-api-security", testdata.APISecurityDSL, ""},
+		{"invalid-no-security", testdata.InvalidNoSecurityDSL,
+			`service "InvalidNoSecurityService": NoSecurity cannot be used together with Security in service "InvalidNoSecurityService"
+service "InvalidNoSecurityService" method "Method": NoSecurity cannot be used together with Security in method "Method" of service "InvalidNoSecurityService"`,
+		},
+		{"valid-method-defaults", testdata.MethodDefaultsDSL, ""},
+		{"invalid-method-defaults", testdata.InvalidMethodDefaultsDSL,
+			`service "InvalidMethodDefaultsService" method "Create": field status - default value "active" applies to unknown method "Unknown"
+service "InvalidMethodDefaultsService" method "Create": field status - more than one default value applies to method "Create"
+service "InvalidMethodDefaultsService" method "Create": field tags - default values scoped to methods can only be used on attributes of type Boolean, String or numeric, got array
+service "InvalidMethodDefaultsService" method "Create": field name - attributes with default values scoped to methods cannot be required`,
+		},
+		{"invalid-trailers", testdata.InvalidTrailersDSL,
+			`service "InvalidTrailersService" method "NotStreaming": trailers can only be defined on methods that stream their result, use StreamingResult to define one
+service "InvalidTrailersService" method "InvalidType": trailer "X-Checksum" must be a string, a boolean or a number
+service "InvalidTrailersService" HTTP endpoint "WebSocket": trailers can only be sent by endpoints that stream their results as server-sent events, use SSE to define the events`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -104,6 +130,78 @@ func TestMethodExprError(t *testing.T) {
 	}
 }
 
+func TestMethodExprInheritedErrors(t *testing.T) {
+	root := expr.RunDSL(t, testdata.InheritedErrorsDSL)
+	svc := root.Service("InheritedErrorsService")
+	cases := map[string]struct {
+		Method   string
+		Type     expr.DataType
+		Status   int
+		Inherits bool
+	}{
+		"inherit":  {"Inherit", expr.ErrorResult, expr.StatusInternalServerError, true},
+		"override": {"Override", root.UserType("CustomError"), expr.StatusServiceUnavailable, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			m := svc.Method(tc.Method)
+			if len(m.Errors) != 1 {
+				t.Fatalf("got %d errors, expected 1", len(m.Errors))
+			}
+			erro := m.Errors[0]
+			if erro.Name != "internal_error" {
+				t.Errorf("got error %q, expected %q", erro.Name, "internal_error")
+			}
+			if erro.Type != tc.Type {
+				t.Errorf("got error type %q, expected %q", erro.Type.Name(), tc.Type.Name())
+			}
+			if erro.IsInherited() != tc.Inherits {
+				t.Errorf("got inherited %v, expected %v", erro.IsInherited(), tc.Inherits)
+			}
+			e := root.API.HTTP.ServiceFor(svc).Endpoint(tc.Method)
+			if len(e.HTTPErrors) != 1 {
+				t.Fatalf("got %d HTTP errors, expected 1", len(e.HTTPErrors))
+			}
+			if status := e.HTTPErrors[0].Response.StatusCode; status != tc.Status {
+				t.Errorf("got HTTP status %d, expected %d", status, tc.Status)
+			}
+		})
+	}
+	ge := root.API.GRPC.Service("InheritedErrorsService").Endpoint("Inherit")
+	if len(ge.GRPCErrors) != 1 || ge.GRPCErrors[0].Response.StatusCode != 13 {
+		t.Errorf("got gRPC errors %v, expected the inherited internal_error response", ge.GRPCErrors)
+	}
+}
+
+func TestMethodExprInheritedSecurity(t *testing.T) {
+	root := expr.RunDSL(t, testdata.APISecurityDSL)
+	cases := map[string]struct {
+		Service string
+		Method  string
+		Schemes []string
+	}{
+		"inherit":          {"APISecurityService", "Inherit", []string{"jwt"}},
+		"override":         {"APISecurityService", "Override", []string{"basic"}},
+		"opt-out":          {"APISecurityService", "OptOut", nil},
+		"service-opt-out":  {"NoSecurityService", "Health", nil},
+		"service-override": {"NoSecurityService", "Secured", []string{"jwt"}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			m := root.Service(tc.Service).Method(tc.Method)
+			var schemes []string
+			for _, r := range m.Requirements {
+				for _, s := range r.Schemes {
+					schemes = append(schemes, s.SchemeName)
+				}
+			}
+			if fmt.Sprint(schemes) != fmt.Sprint(tc.Schemes) {
+				t.Errorf("got schemes %v, expected %v", schemes, tc.Schemes)
+			}
+		})
+	}
+}
+
 func TestMethodExprEvalName(t *testing.T) {
 	cases := map[string]struct {
 		name     string
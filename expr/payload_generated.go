@@ -0,0 +1,116 @@
+package expr
+
+import "goa.design/goa/v3/eval"
+
+// removeGeneratedAttributes removes the attributes defined with the Generated
+// DSL from the method payloads. It runs once the DSL is executed so that the
+// transport expressions are prepared and validated against the payloads
+// without the generated attributes.
+func removeGeneratedAttributes(roots []eval.Root) error {
+	for _, root := range roots {
+		r, ok := root.(*RootExpr)
+		if !ok {
+			continue
+		}
+		for _, svc := range r.Services {
+			for _, m := range svc.Methods {
+				if m.Payload != nil {
+					m.Payload = withoutGenerated(m.Payload, m.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// withoutGenerated returns the payload attribute of the method with the given
+// name without its top level generated attributes. The attributes declared
+// explicitly in inline payloads are kept. It returns payload if there is
+// nothing to remove. The payload user types are duplicated and renamed after
+// the method, e.g. "CreatePayload", so that the results and the other methods
+// that use them keep the generated attributes.
+func withoutGenerated(payload *AttributeExpr, method string) *AttributeExpr {
+	var names []string
+	ut, isUT := payload.Type.(UserType)
+	if isUT {
+		names = generatedAttributes(ut.Attribute(), make(map[string]struct{}))
+	} else if obj := AsObject(payload.Type); obj != nil {
+		for _, nat := range *obj {
+			// The attribute may be a copy of a referenced generated
+			// attribute.
+			delete(nat.Attribute.Meta, "generated")
+		}
+		seen := make(map[string]struct{})
+		for _, b := range payload.Bases {
+			names = append(names, generatedAttributes(&AttributeExpr{Type: b}, seen)...)
+		}
+	}
+	if len(names) == 0 {
+		return payload
+	}
+	res := payload
+	target := payload
+	if isUT {
+		dupped := Dup(ut).(UserType)
+		if _, ok := dupped.Attribute().Meta["name:original"]; !ok {
+			if renamer, ok := dupped.(interface{ Rename(string) }); ok {
+				renamer.Rename(concat(method, "Payload"))
+			}
+		}
+		dup := *payload
+		dup.Type = dupped
+		res = &dup
+		target = dupped.Attribute()
+	}
+	mergeBases(target)
+	for _, n := range names {
+		target.Delete(n)
+	}
+	if obj := AsObject(target.Type); obj != nil && len(*obj) == 0 {
+		res.Type = Empty
+	}
+	return res
+}
+
+// generatedAttributes returns the names of the top level generated attributes
+// of att including the attributes of its bases.
+func generatedAttributes(att *AttributeExpr, seen map[string]struct{}) []string {
+	if ut, ok := att.Type.(UserType); ok {
+		if _, ok := seen[ut.ID()]; ok {
+			return nil
+		}
+		seen[ut.ID()] = struct{}{}
+		att = ut.Attribute()
+	}
+	obj := AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	var names []string
+	for _, nat := range *obj {
+		if nat.Attribute.IsGenerated() {
+			names = append(names, nat.Name)
+		}
+	}
+	for _, b := range att.Bases {
+		names = append(names, generatedAttributes(&AttributeExpr{Type: b}, seen)...)
+	}
+	return names
+}
+
+// mergeBases merges the attributes of the bases of att into att so that the
+// generated attributes of the bases can be removed. Finalize would merge them
+// back otherwise.
+func mergeBases(att *AttributeExpr) {
+	bases := att.Bases
+	att.Bases = nil
+	for _, b := range bases {
+		ut, ok := b.(UserType)
+		if !ok {
+			continue
+		}
+		base := DupAtt(ut.Attribute())
+		mergeBases(base)
+		att.Merge(base)
+	}
+}
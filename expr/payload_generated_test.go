@@ -0,0 +1,70 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestGeneratedAttributes(t *testing.T) {
+	root := expr.RunDSL(t, testdata.GeneratedDSL)
+	svc := root.Service("generated")
+	cases := []struct {
+		Method          string
+		PayloadTypeName string
+		Payload         []string
+		Required        []string
+		Result          []string
+	}{
+		{"create", "CreatePayload", []string{"name"}, []string{"name"}, []string{"id", "name", "created_at"}},
+		{"update", "", []string{"id", "name"}, []string{"id", "name"}, []string{"id", "name", "created_at"}},
+		{"increment", "Empty", nil, nil, []string{"value"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			m := svc.Method(c.Method)
+			if c.PayloadTypeName != "" && m.Payload.Type.Name() != c.PayloadTypeName {
+				t.Errorf("got payload type %q, expected %q", m.Payload.Type.Name(), c.PayloadTypeName)
+			}
+			if names := attributeNames(m.Payload); strings.Join(names, ",") != strings.Join(c.Payload, ",") {
+				t.Errorf("got payload attributes %v, expected %v", names, c.Payload)
+			}
+			var required []string
+			if ut, ok := m.Payload.Type.(expr.UserType); ok && ut.Attribute().Validation != nil {
+				required = ut.Attribute().Validation.Required
+			} else if m.Payload.Validation != nil {
+				required = m.Payload.Validation.Required
+			}
+			if strings.Join(required, ",") != strings.Join(c.Required, ",") {
+				t.Errorf("got required payload attributes %v, expected %v", required, c.Required)
+			}
+			if names := attributeNames(m.Result); strings.Join(names, ",") != strings.Join(c.Result, ",") {
+				t.Errorf("got result attributes %v, expected %v", names, c.Result)
+			}
+		})
+	}
+	if id := expr.AsObject(root.UserType("User").Attribute().Type).Attribute("id"); !id.IsGenerated() {
+		t.Error("got non generated id attribute in User type")
+	}
+}
+
+func TestGeneratedAttributesPathParam(t *testing.T) {
+	err := expr.RunInvalidDSL(t, testdata.GeneratedPathParamDSL)
+	if expected := `Route param "id" not found in method payload`; !strings.Contains(err.Error(), expected) {
+		t.Errorf("got error %q, expected to contain %q", err.Error(), expected)
+	}
+}
+
+func attributeNames(att *expr.AttributeExpr) []string {
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	var names []string
+	for _, nat := range *obj {
+		names = append(names, nat.Name)
+	}
+	return names
+}
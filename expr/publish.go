@@ -0,0 +1,97 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PublishExpr describes the publication of the results streamed by a
+	// method to a message broker topic.
+	PublishExpr struct {
+		// Topic is the name of the topic the events are published to.
+		Topic string
+		// Broker is the name of the message broker, e.g. "kafka".
+		Broker string
+		// Key is the name of the result attribute used as message key
+		// if any.
+		Key string
+		// Format is the serialization format of the events, either
+		// "json" or "proto".
+		Format string
+		// Method is the method whose streamed results are published.
+		Method *MethodExpr
+	}
+)
+
+const (
+	// PublishFormatJSON serializes the published events with
+	// encoding/json.
+	PublishFormatJSON = "json"
+	// PublishFormatProto serializes the published events with the
+	// protocol buffer messages generated for the method gRPC endpoint.
+	PublishFormatProto = "proto"
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (p *PublishExpr) EvalName() string {
+	var prefix string
+	if p.Method != nil {
+		prefix = p.Method.EvalName() + " "
+	}
+	return prefix + "publisher"
+}
+
+// Validate makes sure the method streams its results, that the topic and
+// broker are set, that the key is a primitive result attribute and that the
+// method defines a gRPC endpoint if the events are serialized with protocol
+// buffer.
+func (p *PublishExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if p.Topic == "" {
+		verr.Add(p, "missing topic, use Topic to set the topic the events are published to")
+	}
+	if p.Broker == "" {
+		verr.Add(p, "missing broker, use Broker to set the name of the message broker")
+	}
+	switch p.Format {
+	case "", PublishFormatJSON, PublishFormatProto:
+	default:
+		verr.Add(p, "invalid format %q, format must be one of %q or %q", p.Format, PublishFormatJSON, PublishFormatProto)
+	}
+	if p.Method == nil {
+		return verr
+	}
+	if !p.Method.IsResultStreaming() {
+		verr.Add(p, "only methods that define a streaming result can publish events")
+		return verr
+	}
+	if p.Key != "" {
+		res := AsObject(p.Method.Result.Type)
+		if res == nil {
+			verr.Add(p, "key %q can only be used with methods whose result is an object", p.Key)
+		} else if att := res.Attribute(p.Key); att == nil {
+			verr.Add(p, "key %q is not a result attribute", p.Key)
+		} else {
+			dt := att.Type
+			if ut, ok := dt.(UserType); ok {
+				dt = ut.Attribute().Type
+			}
+			if _, ok := dt.(Primitive); !ok || dt.Kind() == AnyKind {
+				verr.Add(p, "key %q must be of type Boolean, String, Bytes or numeric, got %s", p.Key, att.Type.Name())
+			}
+		}
+	}
+	if p.Format == PublishFormatProto {
+		if s := Root.API.GRPC.Service(p.Method.Service.Name); s == nil || s.Endpoint(p.Method.Name) == nil {
+			verr.Add(p, "format %q requires the method to define a gRPC endpoint", PublishFormatProto)
+		}
+	}
+	return verr
+}
+
+// Finalize sets the default serialization format.
+func (p *PublishExpr) Finalize() {
+	if p.Format == "" {
+		p.Format = PublishFormatJSON
+	}
+}
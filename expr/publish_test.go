@@ -0,0 +1,53 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestPublishDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.PublishValidDSL},
+		{Name: "proto", DSL: testdata.PublishProtoDSL},
+		{Name: "not streaming", DSL: testdata.PublishNotStreamingDSL, Error: "only methods that define a streaming result can publish events"},
+		{Name: "missing topic", DSL: testdata.PublishMissingTopicDSL, Error: "missing topic"},
+		{Name: "missing broker", DSL: testdata.PublishMissingBrokerDSL, Error: "missing broker"},
+		{Name: "unknown key", DSL: testdata.PublishUnknownKeyDSL, Error: `key "user_id" is not a result attribute`},
+		{Name: "non primitive key", DSL: testdata.PublishNonPrimitiveKeyDSL, Error: `key "tags" must be of type Boolean, String, Bytes or numeric, got array`},
+		{Name: "invalid format", DSL: testdata.PublishInvalidFormatDSL, Error: `invalid format "avro"`},
+		{Name: "proto without gRPC", DSL: testdata.PublishProtoNoGRPCDSL, Error: `format "proto" requires the method to define a gRPC endpoint`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestPublishDSLValues(t *testing.T) {
+	root := expr.RunDSL(t, testdata.PublishValidDSL)
+	p := root.Service("publish-valid").Method("method").Publish
+	if p == nil {
+		t.Fatal("got nil publish")
+	}
+	if p.Topic != "users.created" || p.Broker != "kafka" || p.Key != "id" {
+		t.Errorf("got topic %q, broker %q and key %q", p.Topic, p.Broker, p.Key)
+	}
+	if p.Format != expr.PublishFormatJSON {
+		t.Errorf("got format %q, expected %q", p.Format, expr.PublishFormatJSON)
+	}
+}
@@ -64,6 +64,34 @@ func NewRandom(seed string) *ExampleGenerator {
 type ExampleGenerator struct {
 	Randomizer
 	seen map[string]*interface{}
+	// seed is the seed of the randomizer of the generator that created this
+	// generator with Seeded, empty if the generator was not created by
+	// Seeded.
+	seed string
+}
+
+// Seeded returns a generator that shares the previously seen values of r and
+// whose randomizer is seeded from the given name if r uses a
+// FakerRandomizer. This makes the examples generated for a type independent of
+// the examples generated before so that changes to unrelated types do not
+// change them. Seeded returns r if it uses any other randomizer.
+func (r *ExampleGenerator) Seeded(name string) *ExampleGenerator {
+	fr, ok := r.Randomizer.(*FakerRandomizer)
+	if !ok {
+		return r
+	}
+	seed := r.seed
+	if seed == "" {
+		seed = fr.Seed
+	}
+	if r.seen == nil {
+		r.seen = make(map[string]*interface{})
+	}
+	return &ExampleGenerator{
+		Randomizer: NewFakerRandomizer(seed + "/" + name),
+		seen:       r.seen,
+		seed:       seed,
+	}
 }
 
 // PreviouslySeen returns the previously seen value for a given ID
@@ -0,0 +1,75 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// RequestExampleExpr describes a request sent to a method together with
+	// the expected response. The example command generates integration
+	// tests from the request examples.
+	RequestExampleExpr struct {
+		// Name is the name of the example.
+		Name string
+		// Payload is the payload sent in the request if any.
+		Payload interface{}
+		// Result is the expected result if any.
+		Result interface{}
+		// Error is the name of the expected method error if any.
+		Error string
+		// Method is the method the requests are sent to.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (r *RequestExampleExpr) EvalName() string {
+	var prefix string
+	if r.Method != nil {
+		prefix = r.Method.EvalName() + " "
+	}
+	return prefix + "request example " + r.Name
+}
+
+// Validate makes sure the example values are compatible with the method
+// payload and result types and that the expected error is a method error. The
+// payload may only be omitted if the method payload is an object.
+func (r *RequestExampleExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if r.Name == "" {
+		verr.Add(r, "request example name cannot be empty")
+	}
+	if r.Result != nil && r.Error != "" {
+		verr.Add(r, "request example cannot define both an expected result and an expected error")
+	}
+	if r.Method == nil {
+		return verr
+	}
+	if r.Payload == nil {
+		if hasType(r.Method.Payload) && !IsObject(r.Method.Payload.Type) {
+			verr.Add(r, "missing request example payload, use ExamplePayload to set the payload sent by the request")
+		}
+	} else {
+		if !hasType(r.Method.Payload) {
+			verr.Add(r, "request example defines a payload but the method does not have one")
+		} else if !r.Method.Payload.Type.IsCompatible(r.Payload) {
+			verr.Add(r, "request example payload %#v is incompatible with the method payload type %s", r.Payload, r.Method.Payload.Type.Name())
+		}
+	}
+	if r.Result != nil {
+		if !hasType(r.Method.Result) {
+			verr.Add(r, "request example defines a result but the method does not have one")
+		} else if !r.Method.Result.Type.IsCompatible(r.Result) {
+			verr.Add(r, "request example result %#v is incompatible with the method result type %s", r.Result, r.Method.Result.Type.Name())
+		}
+	}
+	if r.Error != "" && r.Method.Service != nil && r.Method.Error(r.Error) == nil {
+		verr.Add(r, "request example error %q is not an error of the method", r.Error)
+	}
+	return verr
+}
+
+// hasType returns true if the given attribute is defined and is not empty.
+func hasType(att *AttributeExpr) bool {
+	return att != nil && att.Type != nil && att.Type != Empty
+}
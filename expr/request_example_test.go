@@ -0,0 +1,100 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestRequestExampleValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", requestExampleDSL(func() {
+			ExamplePayload(Val{"a": 1, "b": 2})
+			ExampleResult(3)
+		}), ""},
+		{"expected error", requestExampleDSL(func() {
+			ExamplePayload(Val{"a": 1, "b": 0})
+			ExampleError("overflow")
+		}), ""},
+		{"no payload", requestExampleDSL(func() {
+			ExampleResult(3)
+		}), ""},
+		{"incompatible payload", requestExampleDSL(func() {
+			ExamplePayload("1+2")
+		}), `request example payload "1+2" is incompatible with the method payload type object`},
+		{"incompatible result", requestExampleDSL(func() {
+			ExamplePayload(Val{"a": 1, "b": 2})
+			ExampleResult("3")
+		}), `request example result "3" is incompatible with the method result type int`},
+		{"result and error", requestExampleDSL(func() {
+			ExampleResult(3)
+			ExampleError("overflow")
+		}), "request example cannot define both an expected result and an expected error"},
+		{"unknown error", requestExampleDSL(func() {
+			ExampleError("unknown")
+		}), `request example error "unknown" is not an error of the method`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestExampleDuplicate(t *testing.T) {
+	err := expr.RunInvalidDSL(t, func() {
+		Service("RequestExample", func() {
+			Method("Method", func() {
+				RequestExample("example", func() {})
+				RequestExample("example", func() {})
+			})
+		})
+	})
+	if expected := `request example "example" is defined more than once`; !strings.Contains(err.Error(), expected) {
+		t.Errorf("got error %q, expected to contain %q", err.Error(), expected)
+	}
+}
+
+func TestRequestExampleMissingPayload(t *testing.T) {
+	err := expr.RunInvalidDSL(t, func() {
+		Service("RequestExample", func() {
+			Method("Method", func() {
+				Payload(String)
+				RequestExample("example", func() {})
+			})
+		})
+	})
+	if expected := "missing request example payload"; !strings.Contains(err.Error(), expected) {
+		t.Errorf("got error %q, expected to contain %q", err.Error(), expected)
+	}
+}
+
+func requestExampleDSL(example func()) func() {
+	return func() {
+		Service("RequestExample", func() {
+			Method("Method", func() {
+				Payload(func() {
+					Attribute("a", Int)
+					Attribute("b", Int)
+					Required("a", "b")
+				})
+				Result(Int)
+				Error("overflow")
+				RequestExample("example", example)
+			})
+		})
+	}
+}
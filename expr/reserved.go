@@ -0,0 +1,91 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+
+	"goa.design/goa/v3/eval"
+)
+
+// maxFieldNumber is the largest protocol buffer field number.
+const maxFieldNumber = 1<<29 - 1
+
+// ReservedExpr describes the protocol buffer field numbers and names that
+// cannot be used by the fields of a type, typically because the fields that
+// used them were removed.
+type ReservedExpr struct {
+	// Numbers lists the reserved field numbers.
+	Numbers []uint64
+	// Names lists the reserved field names.
+	Names []string
+	// Type is the type that reserves the field numbers and names.
+	Type UserType
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (r *ReservedExpr) EvalName() string {
+	if r.Type == nil {
+		return "reserved fields"
+	}
+	return fmt.Sprintf("reserved fields of type %q", r.Type.Name())
+}
+
+// Validate makes sure the reserved field numbers are valid and that the fields
+// of the type do not use the reserved field numbers and names.
+func (r *ReservedExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	obj := AsObject(r.Type)
+	if obj == nil {
+		verr.Add(r, "Reserved can only be used on object types, got %s", r.Type.Attribute().Type.Name())
+		return verr
+	}
+	numbers := make(map[uint64]struct{}, len(r.Numbers))
+	for _, n := range r.Numbers {
+		if n == 0 || n > maxFieldNumber {
+			verr.Add(r, "field number %d is out of range, must be between 1 and %d", n, maxFieldNumber)
+		}
+		if _, ok := numbers[n]; ok {
+			verr.Add(r, "field number %d is reserved more than once", n)
+		}
+		numbers[n] = struct{}{}
+	}
+	names := make(map[string]struct{}, len(r.Names))
+	for _, n := range r.Names {
+		if n == "" {
+			verr.Add(r, "field name cannot be empty")
+		}
+		if _, ok := names[n]; ok {
+			verr.Add(r, "field name %q is reserved more than once", n)
+		}
+		names[n] = struct{}{}
+	}
+	for _, nat := range *obj {
+		if _, ok := names[nat.Name]; ok {
+			verr.Add(r, "field name %q is reserved but used by attribute %q", nat.Name, nat.Name)
+		}
+		tag, ok := nat.Attribute.FieldTag()
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(tag, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, ok := numbers[n]; ok {
+			verr.Add(r, "field number %d is reserved but used by attribute %q", n, nat.Name)
+		}
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
+
+// reservedFields returns the protocol buffer field numbers and names reserved by
+// the type of att if any, nil otherwise.
+func reservedFields(att *AttributeExpr) *ReservedExpr {
+	if ut, ok := att.Type.(UserType); ok {
+		return ut.Attribute().Reserved
+	}
+	return att.Reserved
+}
@@ -0,0 +1,52 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestReservedDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.ReservedValidDSL},
+		{Name: "number in use", DSL: testdata.ReservedNumberInUseDSL, Error: `field number 2 is reserved but used by attribute "name"`},
+		{Name: "name in use", DSL: testdata.ReservedNameInUseDSL, Error: `field name "name" is reserved but used by attribute "name"`},
+		{Name: "out of range", DSL: testdata.ReservedOutOfRangeDSL, Error: "field number 0 is out of range, must be between 1 and 536870911"},
+		{Name: "duplicate", DSL: testdata.ReservedDuplicateDSL, Error: `field number 3 is reserved more than once`},
+		{Name: "invalid argument", DSL: testdata.ReservedInvalidArgDSL, Error: "field number (int) or field name (string)"},
+		{Name: "not object", DSL: testdata.ReservedNotObjectDSL, Error: "Reserved can only be used on object types, got string"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestReservedGRPCMessage(t *testing.T) {
+	root := expr.RunDSL(t, testdata.ReservedValidDSL)
+	e := root.API.GRPC.Service("reserved-valid").Endpoint("method")
+	r := e.Request.Reserved
+	if r == nil {
+		t.Fatal("got nil reserved fields on request message")
+	}
+	if len(r.Numbers) != 2 || r.Numbers[0] != 3 || r.Numbers[1] != 5 {
+		t.Errorf("got reserved numbers %v, expected [3 5]", r.Numbers)
+	}
+	if len(r.Names) != 1 || r.Names[0] != "old_name" {
+		t.Errorf("got reserved names %v, expected [old_name]", r.Names)
+	}
+}
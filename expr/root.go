@@ -79,6 +79,43 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 	}
 	walk(mtypes)
 
+	// Discriminators (must be done after user and result types)
+	var discs eval.ExpressionSet
+	for _, types := range [][]UserType{r.Types, r.ResultTypes} {
+		for _, t := range types {
+			if d := t.Attribute().Discriminator; d != nil {
+				d.Base = t
+				discs = append(discs, d)
+			}
+		}
+	}
+	walk(discs)
+
+	// Reserved protocol buffer fields (must be done after user and result
+	// types)
+	var reserved eval.ExpressionSet
+	for _, types := range [][]UserType{r.Types, r.ResultTypes} {
+		for _, t := range types {
+			if res := t.Attribute().Reserved; res != nil {
+				res.Type = t
+				reserved = append(reserved, res)
+			}
+		}
+	}
+	walk(reserved)
+
+	// Field orders (must be done after user and result types)
+	var orders eval.ExpressionSet
+	for _, types := range [][]UserType{r.Types, r.ResultTypes} {
+		for _, t := range types {
+			if o := t.Attribute().FieldOrder; o != nil {
+				o.Type = t
+				orders = append(orders, o)
+			}
+		}
+	}
+	walk(orders)
+
 	// Services
 	walk(eval.ToExpressionSet(r.Services))
 
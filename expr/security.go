@@ -7,6 +7,10 @@ import (
 	"goa.design/goa/v3/eval"
 )
 
+// ForbiddenErrorName is the name of the method error returned by the generated
+// code when the request is not granted the scopes listed with RequiredScopes.
+const ForbiddenErrorName = "forbidden"
+
 // SchemeKind is a type of security scheme.
 type SchemeKind int
 
@@ -47,6 +51,9 @@ type (
 		Schemes []*SchemeExpr
 		// Scopes list the required scopes if any.
 		Scopes []string
+		// RequiredScopes list the scopes that the generated code checks
+		// are granted to the request once authenticated if any.
+		RequiredScopes []string
 	}
 
 	// SchemeExpr defines a security scheme used to authenticate against the
@@ -108,8 +115,9 @@ func (s *SecurityExpr) EvalName() string {
 // DupRequirement creates a copy of the given security requirement.
 func DupRequirement(req *SecurityExpr) *SecurityExpr {
 	dup := &SecurityExpr{
-		Scopes:  req.Scopes,
-		Schemes: make([]*SchemeExpr, 0, len(req.Schemes)),
+		Scopes:         req.Scopes,
+		RequiredScopes: req.RequiredScopes,
+		Schemes:        make([]*SchemeExpr, 0, len(req.Schemes)),
 	}
 	for _, s := range req.Schemes {
 		dup.Schemes = append(dup.Schemes, DupScheme(s))
@@ -0,0 +1,61 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// ServerDefaultExpr describes a payload field initialized server-side
+	// with the value of a Go expression when the request does not provide
+	// it.
+	ServerDefaultExpr struct {
+		// Field is the name of the payload attribute.
+		Field string
+		// Code is the Go expression computing the default value.
+		Code string
+		// Imports lists the import paths of the packages used by Code.
+		Imports []string
+		// Method is the method whose payload field is initialized.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (d *ServerDefaultExpr) EvalName() string {
+	var prefix string
+	if d.Method != nil {
+		prefix = d.Method.EvalName() + " "
+	}
+	return fmt.Sprintf("%sserver default of %q", prefix, d.Field)
+}
+
+// Validate makes sure the expression is set and that the field is an optional
+// payload attribute that does not define a default value.
+func (d *ServerDefaultExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if d.Code == "" {
+		verr.Add(d, "server default expression cannot be empty")
+	}
+	obj := AsObject(d.Method.Payload.Type)
+	if obj == nil {
+		verr.Add(d, "ServerDefault requires a payload that is an object")
+		return verr
+	}
+	att := obj.Attribute(d.Field)
+	if att == nil {
+		verr.Add(d, "payload does not define attribute %q", d.Field)
+		return verr
+	}
+	if d.Method.Payload.IsRequired(d.Field) {
+		verr.Add(d, "attribute %q cannot be required, server defaults only apply to absent values", d.Field)
+	}
+	if att.DefaultValue != nil || att.MethodDefault(d.Method.Name) != nil {
+		verr.Add(d, "attribute %q cannot define both a default value and a server default", d.Field)
+	}
+	if att.IsNullable() {
+		verr.Add(d, "attribute %q cannot be nullable", d.Field)
+	}
+	return verr
+}
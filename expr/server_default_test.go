@@ -0,0 +1,40 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestServerDefaultDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.ServerDefaultValidDSL},
+		{Name: "unknown", DSL: testdata.ServerDefaultUnknownDSL, Error: `payload does not define attribute "updated_at"`},
+		{Name: "required", DSL: testdata.ServerDefaultRequiredDSL, Error: `attribute "created_at" cannot be required`},
+		{Name: "with default", DSL: testdata.ServerDefaultWithDefaultDSL, Error: `attribute "created_at" cannot define both a default value and a server default`},
+		{Name: "duplicate", DSL: testdata.ServerDefaultDuplicateDSL, Error: `server default of attribute "created_at" is defined more than once`},
+		{Name: "no payload", DSL: testdata.ServerDefaultNoPayloadDSL, Error: "ServerDefault requires a payload that is an object"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				root := expr.RunDSL(t, c.DSL)
+				ds := root.Services[0].Methods[0].ServerDefaults
+				if len(ds) != 1 || ds[0].Field != "created_at" || len(ds[0].Imports) != 1 {
+					t.Errorf("got server defaults %v, expected created_at importing time", ds)
+				}
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
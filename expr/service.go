@@ -2,6 +2,8 @@ package expr
 
 import (
 	"fmt"
+	"go/token"
+	"strings"
 
 	"goa.design/goa/v3/eval"
 )
@@ -19,6 +21,9 @@ type (
 		Docs *DocsExpr
 		// Methods is the list of service methods.
 		Methods []*MethodExpr
+		// Batches lists the batch methods generated from the service
+		// methods.
+		Batches []*BatchExpr
 		// Errors list the errors common to all the service methods.
 		Errors []*ErrorExpr
 		// Requirements contains the security requirements that apply to
@@ -75,7 +80,7 @@ func (s *ServiceExpr) Hash() string {
 	return "_service_+" + s.Name
 }
 
-// Validate validates the service methods and errors.
+// Validate validates the service errors and the "struct:pkg:name" meta.
 func (s *ServiceExpr) Validate() error {
 	verr := new(eval.ValidationErrors)
 	for _, e := range s.Errors {
@@ -85,6 +90,28 @@ func (s *ServiceExpr) Validate() error {
 			}
 		}
 	}
+	if isNoSecurity(s.Requirements) && len(s.Requirements) > 1 {
+		verr.Add(s, "NoSecurity cannot be used together with Security in service %q", s.Name)
+	}
+	for _, b := range s.Batches {
+		if err := b.Validate(); err != nil {
+			verr.AddError(b, err)
+		}
+	}
+	if name, ok := s.Meta.Last("struct:pkg:name"); ok {
+		if !token.IsIdentifier(name) || token.IsKeyword(name) || strings.ToLower(name) != name {
+			verr.Add(s, "invalid struct:pkg:name meta %q, value must be a lowercase Go package name such as \"svcv2\"", name)
+		} else if Root != nil {
+			for _, o := range Root.Services {
+				if o == s {
+					break
+				}
+				if n, ok := o.Meta.Last("struct:pkg:name"); ok && n == name {
+					verr.Add(s, "struct:pkg:name meta %q is already used by service %q", name, o.Name)
+				}
+			}
+		}
+	}
 	return verr
 }
 
@@ -95,6 +122,13 @@ func (s *ServiceExpr) Finalize() {
 	}
 }
 
+// IsInherited returns true if the error is defined in the API expression and
+// applies to all the methods.
+func (e *ErrorExpr) IsInherited() bool {
+	_, ok := e.AttributeExpr.Meta["goa:error:inherited"]
+	return ok
+}
+
 // Validate checks that the error name is found in the result meta for
 // custom error types.
 func (e *ErrorExpr) Validate() error {
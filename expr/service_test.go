@@ -94,6 +94,8 @@ func TestServiceExprValidate(t *testing.T) {
 		Error string
 	}{
 		{"service errors", testdata.ServiceErrorDSL, `attribute: error name "a" must be required in type "ServiceError"`},
+		{"invalid package name", testdata.InvalidServicePkgNameDSL, `service "InvalidPkgName": invalid struct:pkg:name meta "Svc-V2", value must be a lowercase Go package name such as "svcv2"`},
+		{"duplicate package name", testdata.DuplicateServicePkgNameDSL, `service "Second": struct:pkg:name meta "svcv2" is already used by service "First"`},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,49 @@
+package expr
+
+import (
+	"time"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// SLOExpr describes the service level objectives of a method. The
+	// objectives are annotations: they are surfaced in the OpenAPI
+	// specifications and in the generated service package for metrics
+	// middlewares but do not change how requests are handled.
+	SLOExpr struct {
+		// LatencyP99 is the target 99th percentile latency of the
+		// method requests, zero if not set.
+		LatencyP99 time.Duration
+		// SuccessRate is the target percentage of successful
+		// requests, zero if not set.
+		SuccessRate float64
+		// Method is the method the objectives apply to.
+		Method *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (s *SLOExpr) EvalName() string {
+	var prefix string
+	if s.Method != nil {
+		prefix = s.Method.EvalName() + " "
+	}
+	return prefix + "SLO"
+}
+
+// Validate makes sure at least one objective is set and that the objectives
+// are in range.
+func (s *SLOExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if s.LatencyP99 == 0 && s.SuccessRate == 0 {
+		verr.Add(s, "SLO must define at least one objective with LatencyP99 or SuccessRate")
+	}
+	if s.LatencyP99 < 0 {
+		verr.Add(s, "latency objective cannot be negative, got %s", s.LatencyP99)
+	}
+	if s.SuccessRate < 0 || s.SuccessRate > 100 {
+		verr.Add(s, "success rate objective must be a percentage between 0 and 100, got %v", s.SuccessRate)
+	}
+	return verr
+}
@@ -0,0 +1,48 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestSLODSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.SLOValidDSL},
+		{Name: "empty", DSL: testdata.SLOEmptyDSL, Error: "SLO must define at least one objective with LatencyP99 or SuccessRate"},
+		{Name: "invalid success rate", DSL: testdata.SLOInvalidSuccessRateDSL, Error: "success rate objective must be a percentage between 0 and 100, got 101"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestSLOObjectives(t *testing.T) {
+	root := expr.RunDSL(t, testdata.SLOValidDSL)
+	s := root.Service("slo-valid").Method("method").SLO
+	if s == nil {
+		t.Fatal("got nil SLO")
+	}
+	if s.LatencyP99 != 200*time.Millisecond {
+		t.Errorf("got latency objective %s, expected 200ms", s.LatencyP99)
+	}
+	if s.SuccessRate != 99.9 {
+		t.Errorf("got success rate objective %v, expected 99.9", s.SuccessRate)
+	}
+}
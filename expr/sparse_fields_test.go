@@ -0,0 +1,88 @@
+package expr_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestSparseFieldsValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"object", sparseFieldsDSL("object", nil), ""},
+		{"array", sparseFieldsDSL("array", nil), ""},
+		{"primitive", sparseFieldsDSL("primitive", nil), `service "SparseFields" method "Method": SparseFields requires a result that is an object or an array of objects`},
+		{"reserved param", sparseFieldsDSL("object", func() { Param("fields") }), `service "SparseFields" HTTP endpoint "Method": parameter "fields" is reserved by SparseFields`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("\ngot error %q\nexpected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestMethodExprSparseFieldNames(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Result   string
+		Expected []string
+	}{
+		{"object", "object", []string{"id", "name", "address"}},
+		{"array", "array", []string{"id", "name", "address"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, sparseFieldsDSL(c.Result, nil))
+			m := root.Service("SparseFields").Method("Method")
+			if got := m.SparseFieldNames(); !reflect.DeepEqual(got, c.Expected) {
+				t.Errorf("got fields %v, expected %v", got, c.Expected)
+			}
+		})
+	}
+}
+
+func sparseFieldsDSL(kind string, params func()) func() {
+	return func() {
+		user := Type("User", func() {
+			Attribute("id", Int)
+			Attribute("name", String)
+			Attribute("address", func() {
+				Attribute("zip", String)
+			})
+		})
+		var result interface{} = user
+		switch kind {
+		case "array":
+			result = ArrayOf(user)
+		case "primitive":
+			result = String
+		}
+		Service("SparseFields", func() {
+			Method("Method", func() {
+				SparseFields()
+				Payload(func() {
+					Attribute("fields", String)
+				})
+				Result(result)
+				HTTP(func() {
+					GET("/")
+					if params != nil {
+						params()
+					}
+				})
+			})
+		})
+	}
+}
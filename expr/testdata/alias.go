@@ -0,0 +1,46 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var AliasValidDSL = func() {
+	Service("alias-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("user_name", String, func() {
+					Alias("username", "userName")
+				})
+				Attribute("email", String)
+			})
+		})
+	})
+}
+
+var AliasAttributeNameDSL = func() {
+	Service("alias-attribute-name", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("user_name", String, func() {
+					Alias("email")
+				})
+				Attribute("email", String)
+			})
+		})
+	})
+}
+
+var AliasDuplicateDSL = func() {
+	Service("alias-duplicate", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("user_name", String, func() {
+					Alias("name")
+				})
+				Attribute("full_name", String, func() {
+					Alias("name")
+				})
+			})
+		})
+	})
+}
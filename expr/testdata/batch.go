@@ -0,0 +1,89 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var BatchValidDSL = func() {
+	Service("batch-valid", func() {
+		Batch("create", func() {
+			MaxItems(10)
+		})
+		Method("create", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Required("name")
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/users")
+			})
+		})
+	})
+}
+
+var BatchUnknownMethodDSL = func() {
+	Service("batch-unknown-method", func() {
+		Batch("create")
+	})
+}
+
+var BatchNoPayloadDSL = func() {
+	Service("batch-no-payload", func() {
+		Batch("list")
+		Method("list", func() {
+			Result(ArrayOf(String))
+		})
+	})
+}
+
+var BatchStreamingDSL = func() {
+	Service("batch-streaming", func() {
+		Batch("create")
+		Method("create", func() {
+			Payload(String)
+			StreamingResult(String)
+		})
+	})
+}
+
+var BatchSecuredDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	Service("batch-secured", func() {
+		Security(JWT)
+		Batch("create")
+		Method("create", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("name", String)
+			})
+		})
+	})
+}
+
+var BatchPathParamDSL = func() {
+	Service("batch-path-param", func() {
+		Batch("update")
+		Method("update", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				PUT("/users/{id}")
+			})
+		})
+	})
+}
+
+var BatchNameConflictDSL = func() {
+	Service("batch-name-conflict", func() {
+		Batch("create")
+		Method("create", func() {
+			Payload(String)
+		})
+		Method("batch_create", func() {
+			Payload(ArrayOf(String))
+		})
+	})
+}
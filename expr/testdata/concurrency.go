@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ConcurrencyValidDSL = func() {
+	Service("concurrency-valid", func() {
+		Method("method", func() {
+			Error("unavailable")
+			MaxConcurrent(10, func() {
+				QueueTimeout("500ms")
+			})
+		})
+	})
+}
+
+var ConcurrencyInvalidLimitDSL = func() {
+	Service("concurrency-invalid-limit", func() {
+		Method("method", func() {
+			MaxConcurrent(0)
+		})
+	})
+}
+
+var ConcurrencyInvalidErrorTypeDSL = func() {
+	Service("concurrency-invalid-error-type", func() {
+		Method("method", func() {
+			Error("unavailable", String)
+			MaxConcurrent(10)
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ConfigValidDSL = func() {
+	API("config-valid", func() {
+		Config(func() {
+			Attribute("port", Int, func() {
+				Default(8080)
+			})
+			Attribute("log_level", String)
+			Required("log_level")
+		})
+	})
+}
+
+var ConfigNonPrimitiveDSL = func() {
+	API("config-non-primitive", func() {
+		Config(func() {
+			Attribute("hosts", ArrayOf(String))
+		})
+	})
+}
+
+var ConfigDuplicateEnvDSL = func() {
+	API("config-duplicate-env", func() {
+		Config(func() {
+			Attribute("db_url", String)
+			Attribute("database", String, func() {
+				Meta("config:env", "DB_URL")
+			})
+		})
+	})
+}
+
+var ConfigDuplicateFlagDSL = func() {
+	API("config-duplicate-flag", func() {
+		Config(func() {
+			Attribute("db_url", String)
+			Attribute("database", String, func() {
+				Meta("config:flag", "db-url")
+			})
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var DefaultFromValidDSL = func() {
+	Service("default-from-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("username", String)
+				Attribute("nickname", String)
+				Attribute("display_name", String)
+				Required("username")
+			})
+			DefaultFrom("display_name", "nickname")
+			DefaultFrom("nickname", "username")
+		})
+	})
+}
+
+var DefaultFromUnknownSourceDSL = func() {
+	Service("default-from-unknown-source", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("display_name", String)
+			})
+			DefaultFrom("display_name", "username")
+		})
+	})
+}
+
+var DefaultFromTypeMismatchDSL = func() {
+	Service("default-from-type-mismatch", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("display_name", String)
+			})
+			DefaultFrom("display_name", "id")
+		})
+	})
+}
+
+var DefaultFromRequiredDSL = func() {
+	Service("default-from-required", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("username", String)
+				Attribute("display_name", String)
+				Required("display_name")
+			})
+			DefaultFrom("display_name", "username")
+		})
+	})
+}
+
+var DefaultFromCycleDSL = func() {
+	Service("default-from-cycle", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("nickname", String)
+				Attribute("display_name", String)
+			})
+			DefaultFrom("display_name", "nickname")
+			DefaultFrom("nickname", "display_name")
+		})
+	})
+}
@@ -0,0 +1,126 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var DiscriminatorValidDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("kind", String)
+		Attribute("name", String)
+		Required("kind", "name")
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+			Map("cat", "Cat")
+		})
+	})
+	var Dog = Type("Dog", func() {
+		Extend(Pet)
+		Attribute("barks", Boolean)
+	})
+	var Cat = Type("Cat", func() {
+		Extend(Pet)
+		Attribute("lives", Int)
+	})
+	Service("discriminator-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				OneOf("pet", func() {
+					Attribute("dog", Dog)
+					Attribute("cat", Cat)
+				})
+			})
+		})
+	})
+}
+
+var DiscriminatorMissingAttributeDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("name", String)
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+		})
+	})
+	Type("Dog", func() {
+		Extend(Pet)
+	})
+}
+
+var DiscriminatorNotStringDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("kind", Int)
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+		})
+	})
+	Type("Dog", func() {
+		Extend(Pet)
+	})
+}
+
+var DiscriminatorEmptyDSL = func() {
+	Type("Pet", func() {
+		Attribute("kind", String)
+		Discriminator("kind", func() {})
+	})
+}
+
+var DiscriminatorDuplicateValueDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("kind", String)
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+			Map("dog", "Cat")
+		})
+	})
+	Type("Dog", func() {
+		Extend(Pet)
+	})
+	Type("Cat", func() {
+		Extend(Pet)
+	})
+}
+
+var DiscriminatorDuplicateTypeDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("kind", String)
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+			Map("hound", "Dog")
+		})
+	})
+	Type("Dog", func() {
+		Extend(Pet)
+	})
+}
+
+var DiscriminatorNotObjectDSL = func() {
+	Type("Pet", func() {
+		Attribute("kind", String)
+		Discriminator("kind", func() {
+			Map("dog", "Name")
+		})
+	})
+	Type("Name", String)
+}
+
+var DiscriminatorMappedTypeMissingAttributeDSL = func() {
+	Type("Pet", func() {
+		Attribute("kind", String)
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+		})
+	})
+	Type("Dog", func() {
+		Attribute("barks", Boolean)
+	})
+}
+
+var DiscriminatorUnknownTypeDSL = func() {
+	Type("Pet", func() {
+		Attribute("kind", String)
+		Discriminator("kind", func() {
+			Map("dog", "Unknown")
+		})
+	})
+}
@@ -37,6 +37,48 @@ var DuplicateWCRouteDSL = func() {
 	})
 }
 
+var PatternRouteDSL = func() {
+	Service("PatternRoute", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("code", String)
+			})
+			HTTP(func() {
+				GET("/{id:[0-9]+}/{code:[a-z]{2}}")
+			})
+		})
+	})
+}
+
+var InvalidPatternRouteDSL = func() {
+	Service("InvalidPatternRoute", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id:[0-9}")
+			})
+		})
+	})
+}
+
+var ConflictingPatternRouteDSL = func() {
+	Service("ConflictingPatternRoute", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Pattern("^[a-z]+$")
+				})
+			})
+			HTTP(func() {
+				GET("/{id:[0-9]+}")
+			})
+		})
+	})
+}
+
 var DisallowResponseBodyHeadDSL = func() {
 	Service("DisallowResponseBody", func() {
 		Method("Method", func() {
@@ -452,6 +494,165 @@ var EndpointPayloadMissingRequired = func() {
 	})
 }
 
+var EndpointInvalidParamStyle = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("q", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("id", func() {
+					Style("form")
+				})
+				Param("q", func() {
+					Style("matrix")
+				})
+			})
+		})
+	})
+}
+
+var EndpointDeepObjectNotObject = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("q", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					Style("deepObject")
+				})
+			})
+		})
+	})
+}
+
+var EndpointDeepObjectNested = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("filter", func() {
+					Attribute("name", String)
+					Attribute("tags", ArrayOf(String))
+					Attribute("address", func() {
+						Attribute("city", String)
+					})
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter", func() {
+					Style("deepObject")
+				})
+			})
+		})
+	})
+}
+
+var EndpointExplodedPrimitiveParam = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("id", func() {
+					Style("matrix")
+					Explode()
+				})
+			})
+		})
+	})
+}
+
+var EndpointInvalidParamDelimiter = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", ArrayOf(Int))
+				Attribute("q", String)
+				Attribute("ids", ArrayOf(Int))
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("id", func() {
+					Delimiter("|")
+				})
+				Param("q", func() {
+					Explode(false)
+				})
+				Param("ids", func() {
+					Explode(false)
+					Delimiter("|")
+				})
+			})
+		})
+	})
+}
+
+var EndpointInvalidParamFlags = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("q", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("id", func() {
+					AllowReserved()
+				})
+				Param("q", func() {
+					AllowEmptyValue()
+					AllowReserved()
+				})
+			})
+		})
+	})
+}
+
+var EndpointInvalidCryptoField = func() {
+	var Card = Type("Card", func() {
+		Attribute("number", String, func() {
+			Meta("crypto:field", "true")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("pin", Int, func() {
+					Meta("crypto:field", "true")
+				})
+				Attribute("card", Card)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var StreamingEndpointCryptoField = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("ssn", String, func() {
+					Meta("crypto:field", "true")
+				})
+			})
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				Param("ssn")
+			})
+		})
+	})
+}
+
 var StreamingEndpointRequestBody = func() {
 	var PT = Type("Payload", func() {
 		Attribute("foo", String)
@@ -705,3 +906,82 @@ var GRPCEndpointWithExtendedTypes = func() {
 		})
 	})
 }
+
+var EndpointTypedResponses = func() {
+	var Full = Type("Full", func() {
+		Attribute("id", String)
+	})
+	var Job = Type("Job", func() {
+		Attribute("href", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK, Full)
+				Response(StatusAccepted, Job)
+				Response(StatusNoContent, Empty)
+				Response(StatusInternalServerError)
+			})
+		})
+	})
+}
+
+var EndpointTypedResponsesUntypedSuccess = func() {
+	var Full = Type("Full", func() {
+		Attribute("id", String)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK, Full)
+				Response(StatusCreated)
+			})
+		})
+	})
+}
+
+var RequestBodyRequiredDSL = func() {
+	Service("Service", func() {
+		Method("NoBody", func() {
+			HTTP(func() {
+				POST("/none")
+			})
+		})
+		Method("OptionalBody", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/optional")
+			})
+		})
+		Method("RequiredBody", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/required")
+			})
+		})
+		Method("OptionalBodyAttribute", func() {
+			Payload(func() {
+				Attribute("body", func() {
+					Attribute("name", String)
+				})
+			})
+			HTTP(func() {
+				POST("/attribute")
+				Body("body")
+			})
+		})
+		Method("ArrayBody", func() {
+			Payload(ArrayOf(String))
+			HTTP(func() {
+				POST("/array")
+			})
+		})
+	})
+}
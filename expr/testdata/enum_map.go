@@ -0,0 +1,92 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var EnumMapValidDSL = func() {
+	Service("enum-map-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("status", String, func() {
+					EnumMap(func() {
+						Value("UNKNOWN", 0)
+						Value("ACTIVE", 1)
+						Fallback("UNKNOWN")
+					})
+				})
+			})
+		})
+	})
+}
+
+var EnumMapNotStringDSL = func() {
+	Service("enum-map-not-string", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("status", Int, func() {
+					EnumMap(func() {
+						Value("UNKNOWN", 0)
+					})
+				})
+			})
+		})
+	})
+}
+
+var EnumMapEmptyDSL = func() {
+	Service("enum-map-empty", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("status", String, func() {
+					EnumMap(func() {})
+				})
+			})
+		})
+	})
+}
+
+var EnumMapDuplicateNameDSL = func() {
+	Service("enum-map-duplicate-name", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("status", String, func() {
+					EnumMap(func() {
+						Value("ACTIVE", 0)
+						Value("ACTIVE", 1)
+					})
+				})
+			})
+		})
+	})
+}
+
+var EnumMapDuplicateNumberDSL = func() {
+	Service("enum-map-duplicate-number", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("status", String, func() {
+					EnumMap(func() {
+						Value("UNKNOWN", 1)
+						Value("ACTIVE", 1)
+					})
+				})
+			})
+		})
+	})
+}
+
+var EnumMapInvalidFallbackDSL = func() {
+	Service("enum-map-invalid-fallback", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("status", String, func() {
+					EnumMap(func() {
+						Value("ACTIVE", 1)
+						Fallback("UNKNOWN")
+					})
+				})
+			})
+		})
+	})
+}
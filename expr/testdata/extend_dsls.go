@@ -0,0 +1,64 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ExtendPayloadDSL = func() {
+	var AuditFields = Type("AuditFields", func() {
+		Attribute("created_by", String)
+		Attribute("updated_by", String)
+		Attribute("tenant_id", String)
+		Required("created_by")
+	})
+	var TenantFields = Type("TenantFields", func() {
+		Attribute("tenant_id", String)
+		Required("tenant_id")
+	})
+	Service("extend-payload", func() {
+		Method("method", func() {
+			Payload(func() {
+				Extend(AuditFields)
+				Extend(TenantFields)
+				Attribute("name", String)
+				Required("name")
+			})
+		})
+	})
+}
+
+var ExtendPayloadConflictDSL = func() {
+	var AuditFields = Type("AuditFields", func() {
+		Attribute("tenant_id", String)
+	})
+	var TenantFields = Type("TenantFields", func() {
+		Attribute("tenant_id", Int)
+	})
+	Service("extend-payload-conflict", func() {
+		Method("method", func() {
+			Payload(func() {
+				Extend(AuditFields)
+				Extend(TenantFields)
+				Attribute("name", String)
+			})
+		})
+	})
+}
+
+var ExtendStripPrefixDSL = func() {
+	var User = Type("User", func() {
+		Attribute("user_name", String)
+		Attribute("user_email", String)
+	})
+	var Account = Type("Account", func() {
+		Extend(User)
+		Attribute("user_id", String)
+		Meta("struct:field:name:strip-prefix", "user_")
+	})
+	Service("extend-strip-prefix", func() {
+		Method("method", func() {
+			Payload(User)
+			Result(Account)
+		})
+	})
+}
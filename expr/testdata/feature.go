@@ -0,0 +1,31 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FeatureGateValidDSL = func() {
+	Service("feature-gate-valid", func() {
+		Method("method", func() {
+			Error("feature_disabled")
+			FeatureGate("new_checkout")
+		})
+	})
+}
+
+var FeatureGateEmptyNameDSL = func() {
+	Service("feature-gate-empty-name", func() {
+		Method("method", func() {
+			FeatureGate("")
+		})
+	})
+}
+
+var FeatureGateInvalidErrorTypeDSL = func() {
+	Service("feature-gate-invalid-error-type", func() {
+		Method("method", func() {
+			Error("feature_disabled", String)
+			FeatureGate("new_checkout")
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FieldOrderValidDSL = func() {
+	Type("Account", func() {
+		Attribute("name", String)
+		Attribute("created_at", String)
+		Attribute("id", String)
+		Attribute("owner", String)
+		FieldOrder("id", "owner")
+	})
+}
+
+var FieldOrderExtendDSL = func() {
+	var Base = Type("Base", func() {
+		Attribute("id", String)
+	})
+	Type("Account", func() {
+		Extend(Base)
+		Attribute("name", String)
+		Attribute("created_at", String)
+		FieldOrder("created_at", "id")
+	})
+}
+
+var FieldOrderResultTypeDSL = func() {
+	ResultType("application/vnd.account", func() {
+		TypeName("Account")
+		Attributes(func() {
+			Attribute("name", String)
+			Attribute("id", String)
+		})
+		FieldOrder("id")
+	})
+}
+
+var FieldOrderUnknownDSL = func() {
+	Type("Account", func() {
+		Attribute("id", String)
+		FieldOrder("id", "unknown")
+	})
+}
+
+var FieldOrderDuplicateDSL = func() {
+	Type("Account", func() {
+		Attribute("id", String)
+		FieldOrder("id", "id")
+	})
+}
+
+var FieldOrderNotObjectDSL = func() {
+	Type("Account", String, func() {
+		FieldOrder("id")
+	})
+}
@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var GeneratedDSL = func() {
+	var Entity = Type("Entity", func() {
+		Attribute("created_at", String, func() {
+			Format(FormatDateTime)
+			Generated()
+		})
+	})
+	var User = Type("User", func() {
+		Extend(Entity)
+		Attribute("id", Int64, func() {
+			Generated()
+		})
+		Attribute("name", String)
+		Required("id", "name")
+	})
+	var Counter = Type("Counter", func() {
+		Attribute("value", Int64, func() {
+			Generated()
+		})
+	})
+	Service("generated", func() {
+		Method("create", func() {
+			Payload(User)
+			Result(User)
+			HTTP(func() {
+				POST("/users")
+			})
+		})
+		Method("update", func() {
+			Payload(func() {
+				Reference(User)
+				Attribute("id", Int64)
+				Attribute("name")
+				Required("id")
+			})
+			Result(User)
+			HTTP(func() {
+				PUT("/users/{id}")
+			})
+		})
+		Method("increment", func() {
+			Payload(Counter)
+			Result(Counter)
+			HTTP(func() {
+				POST("/counters")
+			})
+		})
+	})
+}
+
+var GeneratedPathParamDSL = func() {
+	var User = Type("User", func() {
+		Attribute("id", Int64, func() {
+			Generated()
+		})
+		Attribute("name", String)
+	})
+	Service("generated-path-param", func() {
+		Method("update", func() {
+			Payload(User)
+			HTTP(func() {
+				PUT("/users/{id}")
+			})
+		})
+	})
+}
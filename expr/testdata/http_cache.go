@@ -0,0 +1,98 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CacheValidDSL = func() {
+	Service("cache-valid", func() {
+		Method("method", func() {
+			Result(String)
+			Cache(func() {
+				MaxAge("300s")
+				Public()
+				Vary("Accept-Language")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CacheSecuredDSL = func() {
+	var KeyAuth = APIKeySecurity("key")
+	Service("cache-secured", func() {
+		Method("method", func() {
+			Security(KeyAuth)
+			Payload(func() {
+				APIKey("key", "key", String)
+			})
+			Result(String)
+			Cache(func() {
+				MaxAge("1m")
+				NoCache()
+				Vary("X-API-Key")
+			})
+			HTTP(func() {
+				GET("/")
+				Header("key:X-API-Key")
+			})
+		})
+	})
+}
+
+var CacheEmptyDSL = func() {
+	Service("cache-empty", func() {
+		Method("method", func() {
+			Result(String)
+			Cache(func() {})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CachePublicNoStoreDSL = func() {
+	Service("cache-public-no-store", func() {
+		Method("method", func() {
+			Result(String)
+			Cache(func() {
+				Public()
+				NoStore()
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CacheStreamingDSL = func() {
+	Service("cache-streaming", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			Cache(func() {
+				NoStore()
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CacheInvalidMaxAgeDSL = func() {
+	Service("cache-invalid-max-age", func() {
+		Method("method", func() {
+			Result(String)
+			Cache(func() {
+				MaxAge(300)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
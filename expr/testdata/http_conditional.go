@@ -0,0 +1,69 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ConditionalValidDSL = func() {
+	Service("conditional-valid", func() {
+		Method("method", func() {
+			Result(func() {
+				Attribute("version", Int)
+				Attribute("name", String)
+			})
+			ConditionalRequests(func() {
+				ETagFrom("version")
+				WeakETag()
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ConditionalMissingETagDSL = func() {
+	Service("conditional-missing-etag", func() {
+		Method("method", func() {
+			Result(func() {
+				Attribute("version", Int)
+			})
+			ConditionalRequests(func() {})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ConditionalUnknownAttributeDSL = func() {
+	Service("conditional-unknown-attribute", func() {
+		Method("method", func() {
+			Result(func() {
+				Attribute("version", Int)
+			})
+			ConditionalRequests(func() {
+				ETagFrom("etag")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ConditionalStreamingDSL = func() {
+	Service("conditional-streaming", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("version", Int)
+			})
+			ConditionalRequests(func() {
+				ETagFrom("version")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
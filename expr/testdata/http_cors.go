@@ -0,0 +1,65 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CORSValidDSL = func() {
+	API("cors-valid", func() {
+		CORS(func() {
+			Origin("https://*.example.com")
+			Credentials()
+		})
+	})
+	Service("cors-valid", func() {
+		CORS(func() {
+			Origin("*")
+			Methods("GET")
+		})
+		Method("method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CORSWildcardCredentialsDSL = func() {
+	API("cors-wildcard", func() {
+		CORS(func() {
+			Origin("*")
+			Credentials()
+		})
+	})
+}
+
+var CORSServiceWildcardCredentialsDSL = func() {
+	Service("cors-wildcard", func() {
+		CORS(func() {
+			Origin("https://app.example.com", "*")
+			Credentials()
+		})
+		Method("method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var CORSNoOriginDSL = func() {
+	API("cors-no-origin", func() {
+		CORS(func() {
+			MaxAge(600)
+		})
+	})
+}
+
+var CORSInvalidMethodDSL = func() {
+	API("cors-invalid-method", func() {
+		CORS(func() {
+			Origin("https://app.example.com")
+			Methods("GET", "PATCH IT")
+		})
+	})
+}
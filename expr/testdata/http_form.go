@@ -0,0 +1,59 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FormBodyValidDSL = func() {
+	Service("form-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("username", String)
+				Attribute("remember", Boolean)
+				Attribute("scopes", ArrayOf(String))
+			})
+			HTTP(func() {
+				POST("/")
+				Body(func() {
+					ContentType("application/x-www-form-urlencoded")
+				})
+			})
+		})
+	})
+}
+
+var FormBodyNestedObjectDSL = func() {
+	Service("form-nested-object", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("username", String)
+				Attribute("address", func() {
+					Attribute("city", String)
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Body(func() {
+					ContentType("application/x-www-form-urlencoded")
+				})
+			})
+		})
+	})
+}
+
+var FormBodyMultipartDSL = func() {
+	Service("form-multipart", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("username", String)
+			})
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+				Body(func() {
+					ContentType("application/x-www-form-urlencoded")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var IdempotentValidDSL = func() {
+	Service("idempotent-valid", func() {
+		Method("method", func() {
+			Idempotent(func() {
+				Header("X-Request-Key")
+				TTL("1h")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var IdempotentNoHTTPDSL = func() {
+	Service("idempotent-no-http", func() {
+		Method("method", func() {
+			Idempotent()
+		})
+	})
+}
+
+var IdempotentInvalidTTLDSL = func() {
+	Service("idempotent-invalid-ttl", func() {
+		Method("method", func() {
+			Idempotent(func() {
+				TTL("-1h")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var IdempotentStreamingDSL = func() {
+	Service("idempotent-streaming", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			Idempotent()
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var IdempotentFalseWithKeysDSL = func() {
+	Service("idempotent-false-with-keys", func() {
+		Method("method", func() {
+			Idempotent()
+			Idempotent(false)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var IdempotentMethodsDSL = func() {
+	Service("idempotent-methods", func() {
+		Method("get", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("post", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("post-idempotent", func() {
+			Idempotent(true)
+			HTTP(func() {
+				POST("/idempotent")
+			})
+		})
+		Method("put-not-idempotent", func() {
+			HTTP(func() {
+				PUT("/")
+				Idempotent(false)
+			})
+		})
+		Method("post-keys", func() {
+			Idempotent()
+			HTTP(func() {
+				POST("/keys")
+			})
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MethodOverrideDefaultDSL = func() {
+	API("method-override-default", func() {
+		HTTP(func() {
+			MethodOverride()
+		})
+	})
+}
+
+var MethodOverrideValidDSL = func() {
+	API("method-override-valid", func() {
+		MethodOverride("patch", "PATCH")
+	})
+}
+
+var MethodOverrideUnsafeDSL = func() {
+	API("method-override-unsafe", func() {
+		HTTP(func() {
+			MethodOverride("GET")
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var RedirectLocationFromValidDSL = func() {
+	Service("redirect-valid", func() {
+		Method("method", func() {
+			Result(func() {
+				Attribute("download_url", String)
+			})
+			HTTP(func() {
+				GET("/")
+				RedirectFrom(func() {
+					LocationFrom("download_url")
+				})
+			})
+		})
+	})
+}
+
+var RedirectLocationFromUnknownDSL = func() {
+	Service("redirect-unknown", func() {
+		Method("method", func() {
+			Result(func() {
+				Attribute("download_url", String)
+			})
+			HTTP(func() {
+				GET("/")
+				RedirectFrom(func() {
+					LocationFrom("url")
+				})
+			})
+		})
+	})
+}
+
+var RedirectLocationFromNotStringDSL = func() {
+	Service("redirect-not-string", func() {
+		Method("method", func() {
+			Result(func() {
+				Attribute("download_url", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				RedirectFrom(func() {
+					LocationFrom("download_url")
+				})
+			})
+		})
+	})
+}
+
+var RedirectInvalidStatusDSL = func() {
+	Service("redirect-invalid-status", func() {
+		Method("method", func() {
+			Result(func() {
+				Attribute("download_url", String)
+			})
+			HTTP(func() {
+				GET("/")
+				RedirectFrom(func() {
+					LocationFrom("download_url")
+					Code(StatusOK)
+				})
+			})
+		})
+	})
+}
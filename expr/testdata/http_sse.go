@@ -0,0 +1,157 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SSEValidDSL = func() {
+	var Text = Type("Text", func() {
+		Attribute("text", String)
+	})
+	var Failure = Type("Failure", func() {
+		Attribute("reason", String)
+	})
+	var Notification = Type("Notification", func() {
+		Attribute("id", String)
+		Attribute("message", Text)
+		Attribute("error", Failure)
+	})
+	Service("sse-valid", func() {
+		Method("method", func() {
+			StreamingResult(Notification)
+			HTTP(func() {
+				POST("/")
+				SSE(func() {
+					Event("message", Text)
+					Event("error")
+					EventID("id")
+				})
+			})
+		})
+	})
+}
+
+var SSESingleEventDSL = func() {
+	Service("sse-single-event", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("tick", String)
+				})
+			})
+		})
+	})
+}
+
+var SSENotServerStreamingDSL = func() {
+	Service("sse-not-server-streaming", func() {
+		Method("method", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("message")
+				})
+			})
+		})
+	})
+}
+
+var SSENoEventDSL = func() {
+	Service("sse-no-event", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				SSE(func() {})
+			})
+		})
+	})
+}
+
+var SSEInvalidEventTypeDSL = func() {
+	Service("sse-invalid-event-type", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("message", Int)
+				})
+			})
+		})
+	})
+}
+
+var SSEUnknownEventDSL = func() {
+	Service("sse-unknown-event", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("message", String)
+			})
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("message")
+					Event("error")
+				})
+			})
+		})
+	})
+}
+
+var SSERequiredEventDSL = func() {
+	Service("sse-required-event", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("message", String)
+				Attribute("error", String)
+				Required("message")
+			})
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("message")
+					Event("error")
+				})
+			})
+		})
+	})
+}
+
+var SSEInvalidIDFieldDSL = func() {
+	Service("sse-invalid-id-field", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("id", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("message")
+					EventID("id")
+				})
+			})
+		})
+	})
+}
+
+var SSEMissingIDFieldDSL = func() {
+	Service("sse-missing-id-field", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("message")
+					EventID("id")
+				})
+			})
+		})
+	})
+}
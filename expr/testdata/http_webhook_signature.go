@@ -0,0 +1,69 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var WebhookSignatureValidDSL = func() {
+	Service("webhook-signature-valid", func() {
+		Method("method", func() {
+			Payload(String)
+			WebhookSignature(func() {
+				Header("X-Hub-Signature")
+				Algorithm("sha512")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var WebhookSignatureDefaultDSL = func() {
+	Service("webhook-signature-default", func() {
+		Method("method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+				WebhookSignature()
+			})
+		})
+	})
+}
+
+var WebhookSignatureInvalidAlgorithmDSL = func() {
+	Service("webhook-signature-invalid-algorithm", func() {
+		Method("method", func() {
+			WebhookSignature(func() {
+				Algorithm("md5")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var WebhookSignatureStreamingDSL = func() {
+	Service("webhook-signature-streaming", func() {
+		Method("method", func() {
+			StreamingPayload(String)
+			WebhookSignature()
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var WebhookSignatureSkipDecodeDSL = func() {
+	Service("webhook-signature-skip-decode", func() {
+		Method("method", func() {
+			WebhookSignature()
+			HTTP(func() {
+				POST("/")
+				SkipRequestBodyEncodeDecode()
+			})
+		})
+	})
+}
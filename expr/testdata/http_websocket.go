@@ -0,0 +1,90 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var WebSocketValidDSL = func() {
+	Service("websocket-valid", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				WebSocket(func() {
+					Path("/ws")
+					Framing(WebSocketBinary)
+					PingInterval("30s")
+					PongTimeout("1m")
+				})
+			})
+		})
+	})
+}
+
+var WebSocketInvalidFramingDSL = func() {
+	Service("websocket-invalid-framing", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					Framing("json")
+				})
+			})
+		})
+	})
+}
+
+var WebSocketInvalidPongTimeoutDSL = func() {
+	Service("websocket-invalid-pong-timeout", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					PingInterval("1m")
+					PongTimeout("30s")
+				})
+			})
+		})
+	})
+}
+
+var WebSocketPongTimeoutNoPingDSL = func() {
+	Service("websocket-pong-timeout-no-ping", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					PongTimeout("30s")
+				})
+			})
+		})
+	})
+}
+
+var WebSocketClientStreamPongTimeoutDSL = func() {
+	Service("websocket-client-stream-pong-timeout", func() {
+		Method("method", func() {
+			StreamingPayload(String)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					PingInterval("30s")
+					PongTimeout("1m")
+				})
+			})
+		})
+	})
+}
+
+var WebSocketNotStreamingDSL = func() {
+	Service("websocket-not-streaming", func() {
+		Method("method", func() {
+			HTTP(func() {
+				GET("/")
+				WebSocket()
+			})
+		})
+	})
+}
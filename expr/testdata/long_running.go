@@ -0,0 +1,133 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+// longRunningJob defines the job type used by the long-running DSLs.
+func longRunningJob() expr.UserType {
+	return Type("Job", func() {
+		Attribute("id", String)
+		Attribute("status", String)
+		Attribute("tags", ArrayOf(String))
+		Required("id")
+	})
+}
+
+var LongRunningValidDSL = func() {
+	job := longRunningJob()
+	Service("long-running-valid", func() {
+		Method("start", func() {
+			LongRunning(func() {
+				JobType(job)
+				PollMethod("poll")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("poll", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Required("id")
+			})
+			Result(job)
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
+
+var LongRunningMissingPollDSL = func() {
+	job := longRunningJob()
+	Service("long-running-missing-poll", func() {
+		Method("start", func() {
+			LongRunning(func() {
+				JobType(job)
+			})
+		})
+	})
+}
+
+var LongRunningUnknownPollDSL = func() {
+	job := longRunningJob()
+	Service("long-running-unknown-poll", func() {
+		Method("start", func() {
+			LongRunning(func() {
+				JobType(job)
+				PollMethod("poll")
+			})
+		})
+	})
+}
+
+var LongRunningResultMismatchDSL = func() {
+	job := longRunningJob()
+	Service("long-running-result-mismatch", func() {
+		Method("start", func() {
+			LongRunning(func() {
+				JobType(job)
+				PollMethod("poll")
+			})
+		})
+		Method("poll", func() {
+			Result(String)
+		})
+	})
+}
+
+var LongRunningUnknownPayloadAttributeDSL = func() {
+	job := longRunningJob()
+	Service("long-running-unknown-payload-attribute", func() {
+		Method("start", func() {
+			LongRunning(func() {
+				JobType(job)
+				PollMethod("poll")
+			})
+		})
+		Method("poll", func() {
+			Payload(func() {
+				Attribute("job_id", String)
+			})
+			Result(job)
+		})
+	})
+}
+
+var LongRunningOptionalPayloadAttributeDSL = func() {
+	job := longRunningJob()
+	Service("long-running-optional-payload-attribute", func() {
+		Method("start", func() {
+			LongRunning(func() {
+				JobType(job)
+				PollMethod("poll")
+			})
+		})
+		Method("poll", func() {
+			Payload(func() {
+				Attribute("status", String)
+			})
+			Result(job)
+		})
+	})
+}
+
+var LongRunningPollNoHTTPDSL = func() {
+	job := longRunningJob()
+	Service("long-running-poll-no-http", func() {
+		Method("start", func() {
+			LongRunning(func() {
+				JobType(job)
+				PollMethod("poll")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("poll", func() {
+			Result(job)
+		})
+	})
+}
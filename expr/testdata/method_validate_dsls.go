@@ -71,3 +71,246 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var InvalidRequiredScopesDSL = func() {
+	Service("InvalidRequiredScopesService", func() {
+		Method("SecureMethod", func() {
+			Security(JWTAuth, func() {
+				RequiredScopes("api:read")
+			})
+			Payload(func() {
+				Token("token", String)
+			})
+			Error("forbidden", String) // invalid: must use the default error type
+		})
+	})
+}
+
+var InvalidVersionsDSL = func() {
+	Service("InvalidVersionsService", func() {
+		Method("InvalidSince", func() {
+			Since("version2")
+		})
+		Method("InvalidRange", func() {
+			Since("v3")
+			Until("v2.1")
+		})
+	})
+}
+
+var VersionsDSL = func() {
+	var OldPayload = Type("OldPayload", func() {
+		Attribute("a", String)
+	})
+	Service("VersionsService", func() {
+		Method("Old", func() {
+			Until("v1")
+			Payload(OldPayload)
+			HTTP(func() {
+				POST("/old")
+			})
+		})
+		Method("New", func() {
+			Since("v2")
+			HTTP(func() {
+				GET("/new")
+			})
+		})
+		Method("Always", func() {
+			HTTP(func() {
+				GET("/always")
+			})
+		})
+	})
+	Service("RemovedService", func() {
+		Method("Removed", func() {
+			Until("v1")
+			HTTP(func() {
+				GET("/removed")
+			})
+		})
+	})
+}
+
+var InheritedErrorsDSL = func() {
+	var CustomError = Type("CustomError", func() {
+		Attribute("reason", String)
+	})
+	API("InheritedErrorsAPI", func() {
+		Error("internal_error", func() {
+			Inherited()
+			Fault()
+		})
+		Error("not_found")
+		HTTP(func() {
+			Response("internal_error", StatusInternalServerError)
+			Response("not_found", StatusNotFound)
+		})
+		GRPC(func() {
+			Response("internal_error", CodeInternal)
+		})
+	})
+	Service("InheritedErrorsService", func() {
+		Method("Inherit", func() {
+			HTTP(func() {
+				GET("/")
+			})
+			GRPC(func() {})
+		})
+		Method("Override", func() {
+			Error("internal_error", CustomError)
+			HTTP(func() {
+				GET("/override")
+				Response("internal_error", StatusServiceUnavailable)
+			})
+		})
+	})
+}
+
+var TrailersDSL = func() {
+	Service("TrailersService", func() {
+		Method("List", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				SSE(func() {
+					Event("item")
+				})
+				Response(StatusOK, func() {
+					Trailer("X-Total-Count", Int)
+				})
+			})
+			GRPC(func() {
+				Response(func() {
+					Trailer("X-Total-Count", Int)
+					Trailer("X-Next", String)
+				})
+			})
+		})
+	})
+}
+
+var InvalidTrailersDSL = func() {
+	Service("InvalidTrailersService", func() {
+		Method("NotStreaming", func() {
+			Result(String)
+			GRPC(func() {
+				Response(func() {
+					Trailer("X-Total-Count", Int)
+				})
+			})
+		})
+		Method("InvalidType", func() {
+			StreamingResult(String)
+			GRPC(func() {
+				Response(func() {
+					Trailer("X-Checksum", Bytes)
+				})
+			})
+		})
+		Method("WebSocket", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Trailer("X-Total-Count", Int)
+				})
+			})
+		})
+	})
+}
+
+var MethodDefaultsDSL = func() {
+	var UserPayload = Type("UserPayload", func() {
+		Attribute("status", String, func() {
+			Default("active", func() {
+				Only("Create")
+			})
+		})
+	})
+	Service("MethodDefaultsService", func() {
+		Method("Create", func() {
+			Payload(UserPayload)
+		})
+		Method("Update", func() {
+			Payload(UserPayload)
+		})
+	})
+}
+
+var InvalidMethodDefaultsDSL = func() {
+	var UserPayload = Type("UserPayload", func() {
+		Attribute("status", String, func() {
+			Default("active", func() {
+				Only("Create", "Unknown")
+			})
+			Default("pending", func() {
+				Only("Create")
+			})
+		})
+		Attribute("tags", ArrayOf(String), func() {
+			Default([]string{"new"}, func() {
+				Only("Create")
+			})
+		})
+		Attribute("name", String, func() {
+			Default("anonymous", func() {
+				Only("Create")
+			})
+		})
+		Required("name")
+	})
+	Service("InvalidMethodDefaultsService", func() {
+		Method("Create", func() {
+			Payload(UserPayload)
+		})
+	})
+}
+
+var APISecurityDSL = func() {
+	API("APISecurity", func() {
+		Security(JWTAuth)
+	})
+	Service("APISecurityService", func() {
+		Method("Inherit", func() {
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+		Method("Override", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+		})
+		Method("OptOut", func() {
+			NoSecurity()
+		})
+	})
+	Service("NoSecurityService", func() {
+		NoSecurity()
+		Method("Health", func() {})
+		Method("Secured", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+			})
+		})
+	})
+}
+
+var InvalidNoSecurityDSL = func() {
+	Service("InvalidNoSecurityService", func() {
+		Security(JWTAuth)
+		NoSecurity()
+		Method("Method", func() {
+			Security(BasicAuth)
+			NoSecurity()
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var MultipartDecodeValidDSL = func() {
+	var Person = Type("Person", func() {
+		Attribute("name", String)
+		Attribute("friends", ArrayOf("Person"))
+	})
+	Service("multipart-decode-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("title", String)
+				Attribute("logo", Bytes, func() {
+					Meta("multipart:content-type", "image/*")
+				})
+				Attribute("owner", Person)
+			})
+			Meta("multipart:decode", "true")
+			Meta("multipart:max-depth", "2")
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var MultipartDecodeNotMultipartDSL = func() {
+	Service("multipart-decode-not-multipart", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("title", String)
+			})
+			Meta("multipart:decode", "true")
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var MultipartDecodeInvalidDepthDSL = func() {
+	Service("multipart-decode-invalid-depth", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("title", String)
+			})
+			Meta("multipart:decode", "true")
+			Meta("multipart:max-depth", "-1")
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var MultipartDecodeInvalidSizeDSL = func() {
+	Service("multipart-decode-invalid-size", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("title", String)
+			})
+			Meta("multipart:decode", "true")
+			Meta("multipart:max-part-size", "0")
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var MultipartDecodeMapDSL = func() {
+	Service("multipart-decode-map", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("labels", MapOf(String, String))
+			})
+			Meta("multipart:decode", "true")
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
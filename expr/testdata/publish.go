@@ -0,0 +1,127 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var PublishValidDSL = func() {
+	Service("publish-valid", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("id", Int)
+				Attribute("name", String)
+			})
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+				MessageKey("id")
+			})
+		})
+	})
+}
+
+var PublishProtoDSL = func() {
+	Service("publish-proto", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Field(1, "id", String)
+			})
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+				Serialization("proto")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var PublishNotStreamingDSL = func() {
+	Service("publish-not-streaming", func() {
+		Method("method", func() {
+			Result(String)
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+			})
+		})
+	})
+}
+
+var PublishMissingTopicDSL = func() {
+	Service("publish-missing-topic", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			Publish(func() {
+				Broker("kafka")
+			})
+		})
+	})
+}
+
+var PublishMissingBrokerDSL = func() {
+	Service("publish-missing-broker", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			Publish(func() {
+				Topic("users.created")
+			})
+		})
+	})
+}
+
+var PublishUnknownKeyDSL = func() {
+	Service("publish-unknown-key", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("id", Int)
+			})
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+				MessageKey("user_id")
+			})
+		})
+	})
+}
+
+var PublishNonPrimitiveKeyDSL = func() {
+	Service("publish-non-primitive-key", func() {
+		Method("method", func() {
+			StreamingResult(func() {
+				Attribute("tags", ArrayOf(String))
+			})
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+				MessageKey("tags")
+			})
+		})
+	})
+}
+
+var PublishInvalidFormatDSL = func() {
+	Service("publish-invalid-format", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+				Serialization("avro")
+			})
+		})
+	})
+}
+
+var PublishProtoNoGRPCDSL = func() {
+	Service("publish-proto-no-grpc", func() {
+		Method("method", func() {
+			StreamingResult(String)
+			Publish(func() {
+				Topic("users.created")
+				Broker("kafka")
+				Serialization("proto")
+			})
+		})
+	})
+}
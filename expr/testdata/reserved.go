@@ -0,0 +1,63 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ReservedValidDSL = func() {
+	var Account = Type("Account", func() {
+		Reserved(3, 5, "old_name")
+		Field(1, "id", String)
+		Field(2, "name", String)
+	})
+	Service("reserved-valid", func() {
+		Method("method", func() {
+			Payload(Account)
+			GRPC(func() {})
+		})
+	})
+}
+
+var ReservedNumberInUseDSL = func() {
+	Type("Account", func() {
+		Reserved(2)
+		Field(1, "id", String)
+		Field(2, "name", String)
+	})
+}
+
+var ReservedNameInUseDSL = func() {
+	Type("Account", func() {
+		Reserved("name")
+		Field(1, "id", String)
+		Field(2, "name", String)
+	})
+}
+
+var ReservedOutOfRangeDSL = func() {
+	Type("Account", func() {
+		Reserved(0)
+		Field(1, "id", String)
+	})
+}
+
+var ReservedDuplicateDSL = func() {
+	Type("Account", func() {
+		Reserved(3, "old_name")
+		Reserved(3, "old_name")
+		Field(1, "id", String)
+	})
+}
+
+var ReservedInvalidArgDSL = func() {
+	Type("Account", func() {
+		Reserved(3.5)
+		Field(1, "id", String)
+	})
+}
+
+var ReservedNotObjectDSL = func() {
+	Type("Name", String, func() {
+		Reserved(1)
+	})
+}
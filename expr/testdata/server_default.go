@@ -0,0 +1,73 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ServerDefaultValidDSL = func() {
+	Service("server-default-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("created_at", String)
+			})
+			ServerDefault("created_at", "time.Now().Format(time.RFC3339)", "time")
+		})
+	})
+}
+
+var ServerDefaultUnknownDSL = func() {
+	Service("server-default-unknown", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("created_at", String)
+			})
+			ServerDefault("updated_at", `"now"`)
+		})
+	})
+}
+
+var ServerDefaultRequiredDSL = func() {
+	Service("server-default-required", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("created_at", String)
+				Required("created_at")
+			})
+			ServerDefault("created_at", `"now"`)
+		})
+	})
+}
+
+var ServerDefaultWithDefaultDSL = func() {
+	Service("server-default-with-default", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("created_at", String, func() {
+					Default("now")
+				})
+			})
+			ServerDefault("created_at", `"now"`)
+		})
+	})
+}
+
+var ServerDefaultDuplicateDSL = func() {
+	Service("server-default-duplicate", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("created_at", String)
+			})
+			ServerDefault("created_at", `"now"`)
+			ServerDefault("created_at", `"later"`)
+		})
+	})
+}
+
+var ServerDefaultNoPayloadDSL = func() {
+	Service("server-default-no-payload", func() {
+		Method("method", func() {
+			Payload(String)
+			ServerDefault("created_at", `"now"`)
+		})
+	})
+}
@@ -65,3 +65,21 @@ var ServiceErrorDSL = func() {
 		Method("Method", func() {})
 	})
 }
+
+var InvalidServicePkgNameDSL = func() {
+	Service("InvalidPkgName", func() {
+		Meta("struct:pkg:name", "Svc-V2")
+		Method("Method", func() {})
+	})
+}
+
+var DuplicateServicePkgNameDSL = func() {
+	Service("First", func() {
+		Meta("struct:pkg:name", "svcv2")
+		Method("Method", func() {})
+	})
+	Service("Second", func() {
+		Meta("struct:pkg:name", "svcv2")
+		Method("Method", func() {})
+	})
+}
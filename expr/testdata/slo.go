@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SLOValidDSL = func() {
+	Service("slo-valid", func() {
+		Method("method", func() {
+			SLO(func() {
+				LatencyP99("200ms")
+				SuccessRate(99.9)
+			})
+		})
+	})
+}
+
+var SLOEmptyDSL = func() {
+	Service("slo-empty", func() {
+		Method("method", func() {
+			SLO(func() {})
+		})
+	})
+}
+
+var SLOInvalidSuccessRateDSL = func() {
+	Service("slo-invalid-success-rate", func() {
+		Method("method", func() {
+			SLO(func() {
+				SuccessRate(101)
+			})
+		})
+	})
+}
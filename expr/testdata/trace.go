@@ -0,0 +1,97 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TraceValidDSL = func() {
+	Service("trace-valid", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("tenant_id", String)
+				Attribute("page", Int)
+			})
+			Trace(func() {
+				Attribute("tenant_id")
+				Attribute("page")
+			})
+		})
+	})
+}
+
+var TraceNoAttributeDSL = func() {
+	Service("trace-no-attribute", func() {
+		Method("method", func() {
+			Payload(String)
+			Trace()
+		})
+	})
+}
+
+var TraceUnknownAttributeDSL = func() {
+	Service("trace-unknown-attribute", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("tenant_id", String)
+			})
+			Trace(func() {
+				Attribute("tenant")
+			})
+		})
+	})
+}
+
+var TraceNonPrimitiveAttributeDSL = func() {
+	Service("trace-non-primitive-attribute", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("tags", ArrayOf(String))
+			})
+			Trace(func() {
+				Attribute("tags")
+			})
+		})
+	})
+}
+
+var TraceSensitiveAttributeDSL = func() {
+	Service("trace-sensitive-attribute", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("email", String, func() {
+					Meta("sensitive", "pii")
+				})
+			})
+			Trace(func() {
+				Attribute("email")
+			})
+		})
+	})
+}
+
+var TraceSensitiveTypeDSL = func() {
+	var SSN = Type("SSN", String, func() {
+		Meta("sensitive", "pii")
+	})
+	Service("trace-sensitive-type", func() {
+		Method("method", func() {
+			Payload(func() {
+				Attribute("ssn", SSN)
+			})
+			Trace(func() {
+				Attribute("ssn")
+			})
+		})
+	})
+}
+
+var TraceNonObjectPayloadDSL = func() {
+	Service("trace-non-object-payload", func() {
+		Method("method", func() {
+			Payload(String)
+			Trace(func() {
+				Attribute("id")
+			})
+		})
+	})
+}
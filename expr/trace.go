@@ -0,0 +1,73 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// TraceExpr describes the OpenTelemetry instrumentation of a method. The
+	// generated HTTP server creates a span for each request handled by the
+	// method endpoint.
+	TraceExpr struct {
+		// Attributes lists the payload attributes recorded as span
+		// attributes.
+		Attributes *AttributeExpr
+		// Method is the traced method.
+		Method *MethodExpr
+	}
+)
+
+// Attribute returns the object attribute listing the payload attributes
+// recorded as span attributes. It makes it possible to use Attribute in the
+// Trace DSL.
+func (t *TraceExpr) Attribute() *AttributeExpr {
+	if t.Attributes == nil {
+		t.Attributes = &AttributeExpr{Type: &Object{}}
+	}
+	return t.Attributes
+}
+
+// EvalName returns the generic definition name used in error messages.
+func (t *TraceExpr) EvalName() string {
+	var prefix string
+	if t.Method != nil {
+		prefix = t.Method.EvalName() + " "
+	}
+	return prefix + "trace"
+}
+
+// Validate makes sure the span attributes are primitive payload attributes
+// that are not sensitive.
+func (t *TraceExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if t.Attributes == nil || t.Method == nil {
+		return verr
+	}
+	attrs := AsObject(t.Attributes.Type)
+	if attrs == nil || len(*attrs) == 0 {
+		return verr
+	}
+	payload := AsObject(t.Method.Payload.Type)
+	if payload == nil {
+		verr.Add(t, "span attributes can only be recorded for methods whose payload is an object")
+		return verr
+	}
+	for _, nat := range *attrs {
+		att := payload.Attribute(nat.Name)
+		if att == nil {
+			verr.Add(t, "span attribute %q is not a payload attribute", nat.Name)
+			continue
+		}
+		dt := att.Type
+		if ut, ok := dt.(UserType); ok {
+			dt = ut.Attribute().Type
+		}
+		if _, ok := dt.(Primitive); !ok || dt.Kind() == BytesKind || dt.Kind() == AnyKind {
+			verr.Add(t, "span attribute %q must be of type Boolean, String or numeric, got %s", nat.Name, att.Type.Name())
+		}
+		if att.IsSensitive() {
+			verr.Add(t, "span attribute %q is sensitive and cannot be recorded", nat.Name)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,54 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestTraceDSL(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{Name: "valid", DSL: testdata.TraceValidDSL},
+		{Name: "no attribute", DSL: testdata.TraceNoAttributeDSL},
+		{Name: "unknown attribute", DSL: testdata.TraceUnknownAttributeDSL, Error: `span attribute "tenant" is not a payload attribute`},
+		{Name: "non primitive attribute", DSL: testdata.TraceNonPrimitiveAttributeDSL, Error: `span attribute "tags" must be of type Boolean, String or numeric, got array`},
+		{Name: "sensitive attribute", DSL: testdata.TraceSensitiveAttributeDSL, Error: `span attribute "email" is sensitive and cannot be recorded`},
+		{Name: "sensitive type", DSL: testdata.TraceSensitiveTypeDSL, Error: `span attribute "ssn" is sensitive and cannot be recorded`},
+		{Name: "non object payload", DSL: testdata.TraceNonObjectPayloadDSL, Error: "span attributes can only be recorded for methods whose payload is an object"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				expr.RunDSL(t, c.DSL)
+			} else {
+				err := expr.RunInvalidDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected to contain %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestTraceDSLValues(t *testing.T) {
+	root := expr.RunDSL(t, testdata.TraceValidDSL)
+	m := root.Service("trace-valid").Method("method")
+	if m.Trace == nil {
+		t.Fatal("got nil trace")
+	}
+	attrs := expr.AsObject(m.Trace.Attribute().Type)
+	if len(*attrs) != 2 {
+		t.Fatalf("got %d span attributes, expected 2", len(*attrs))
+	}
+	for i, n := range []string{"tenant_id", "page"} {
+		if (*attrs)[i].Name != n {
+			t.Errorf("got span attribute %q at index %d, expected %q", (*attrs)[i].Name, i, n)
+		}
+	}
+}
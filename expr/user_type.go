@@ -81,7 +81,9 @@ func (u *UserTypeExpr) Hash() string {
 }
 
 // Example produces an example for the user type which is JSON serialization
-// compatible.
+// compatible. The example is the last example defined on the type if any,
+// otherwise it is generated using a randomizer seeded from the type name (see
+// ExampleGenerator.Seeded).
 func (u *UserTypeExpr) Example(r *ExampleGenerator) interface{} {
 	if ex := u.recExample(r); ex != nil {
 		return *ex
@@ -96,7 +98,7 @@ func (u *UserTypeExpr) recExample(r *ExampleGenerator) *interface{} {
 	var ex interface{}
 	pex := &ex
 	r.HaveSeen(u.ID(), pex)
-	actual := u.Type.Example(r)
+	actual := u.AttributeExpr.Example(r.Seeded(u.Name()))
 	*pex = actual
 	return pex
 }
@@ -0,0 +1,213 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semverRegex matches semantic versions as defined by https://semver.org. The
+// leading "v" as well as the minor and patch versions are optional. It is the
+// syntax accepted by parseVersion.
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)(\.(0|[1-9]\d*)){0,2}` +
+	`(-(0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(\.(0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*)?` +
+	`(\+[0-9a-zA-Z-]+(\.[0-9a-zA-Z-]+)*)?$`)
+
+// AvailableIn returns true if the method is available in the given API
+// version, that is if the version is in the range defined by Since and Until.
+func (m *MethodExpr) AvailableIn(version string) bool {
+	if m.Since != "" && CompareVersions(version, m.Since) < 0 {
+		return false
+	}
+	if m.Until != "" && CompareVersions(version, m.Until) > 0 {
+		return false
+	}
+	return true
+}
+
+// FilterVersion removes the methods that are not available in the given API
+// version from the services and their HTTP and gRPC transports. Services whose
+// methods are all removed are removed as well. The user types only used by the
+// removed methods are not generated as code generation only considers the
+// types used by the remaining methods. FilterVersion returns an error if the
+// version is invalid.
+func (r *RootExpr) FilterVersion(version string) error {
+	if err := ValidateVersion(version); err != nil {
+		return err
+	}
+	removed := make(map[*ServiceExpr]struct{})
+	var services []*ServiceExpr
+	for _, s := range r.Services {
+		var methods []*MethodExpr
+		for _, m := range s.Methods {
+			if m.AvailableIn(version) {
+				methods = append(methods, m)
+			}
+		}
+		if len(methods) == 0 && len(s.Methods) > 0 {
+			removed[s] = struct{}{}
+			continue
+		}
+		s.Methods = methods
+		services = append(services, s)
+	}
+	r.Services = services
+	if r.API == nil {
+		return nil
+	}
+	for _, svr := range r.API.Servers {
+		var names []string
+		for _, n := range svr.Services {
+			if r.Service(n) != nil {
+				names = append(names, n)
+			}
+		}
+		svr.Services = names
+	}
+	if r.API.HTTP != nil {
+		var services []*HTTPServiceExpr
+		for _, s := range r.API.HTTP.Services {
+			if _, ok := removed[s.ServiceExpr]; ok {
+				continue
+			}
+			var endpoints []*HTTPEndpointExpr
+			for _, e := range s.HTTPEndpoints {
+				if e.MethodExpr.AvailableIn(version) {
+					endpoints = append(endpoints, e)
+				}
+			}
+			s.HTTPEndpoints = endpoints
+			services = append(services, s)
+		}
+		r.API.HTTP.Services = services
+	}
+	if r.API.GRPC != nil {
+		var services []*GRPCServiceExpr
+		for _, s := range r.API.GRPC.Services {
+			if _, ok := removed[s.ServiceExpr]; ok {
+				continue
+			}
+			var endpoints []*GRPCEndpointExpr
+			for _, e := range s.GRPCEndpoints {
+				if e.MethodExpr.AvailableIn(version) {
+					endpoints = append(endpoints, e)
+				}
+			}
+			s.GRPCEndpoints = endpoints
+			services = append(services, s)
+		}
+		r.API.GRPC.Services = services
+	}
+	return nil
+}
+
+// CompareVersions compares the API versions a and b. Versions are semantic
+// versions optionally prefixed with "v" whose minor and patch components may
+// be omitted, for example "v2" or "1.3.0-beta.1". Missing components are
+// considered to be 0, pre-releases are ordered as defined by
+// https://semver.org and build metadata is ignored. The result is 0 if a == b,
+// -1 if a < b and +1 if a > b. Invalid versions, which ValidateVersion
+// reports, are lower than valid versions and compare lexically with each other.
+func CompareVersions(a, b string) int {
+	va, erra := parseVersion(a)
+	vb, errb := parseVersion(b)
+	switch {
+	case erra != nil && errb != nil:
+		return strings.Compare(a, b)
+	case erra != nil:
+		return -1
+	case errb != nil:
+		return 1
+	}
+	for i := range va.nums {
+		switch {
+		case va.nums[i] < vb.nums[i]:
+			return -1
+		case va.nums[i] > vb.nums[i]:
+			return 1
+		}
+	}
+	return comparePrerelease(va.pre, vb.pre)
+}
+
+// ValidateVersion returns an error if v is not a valid API version.
+func ValidateVersion(v string) error {
+	_, err := parseVersion(v)
+	return err
+}
+
+// semver is a parsed API version.
+type semver struct {
+	// nums contains the major, minor and patch components.
+	nums [3]int
+	// pre contains the dot separated identifiers of the pre-release.
+	pre []string
+}
+
+// parseVersion parses the given API version.
+func parseVersion(v string) (*semver, error) {
+	if !semverRegex.MatchString(v) {
+		return nil, fmt.Errorf("invalid version %q, version must be a semantic version optionally prefixed with \"v\" such as \"v2\" or \"2.3.1\"", v)
+	}
+	s := strings.TrimPrefix(v, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	var res semver
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		res.pre = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+	for i, e := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", v, err)
+		}
+		res.nums[i] = n
+	}
+	return &res, nil
+}
+
+// comparePrerelease compares the pre-release identifiers a and b as defined by
+// https://semver.org: a version without pre-release is greater than a version
+// with one, numeric identifiers compare numerically and are lower than
+// alphanumeric identifiers which compare lexically.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		na, erra := strconv.Atoi(a[i])
+		nb, errb := strconv.Atoi(b[i])
+		switch {
+		case erra == nil && errb == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case erra == nil:
+			return -1
+		case errb == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package expr_test
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/expr/testdata"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		A, B     string
+		Expected int
+	}{
+		{"v1", "v1", 0},
+		{"v1", "1.0", 0},
+		{"v1", "v2", -1},
+		{"v2", "v1", 1},
+		{"v2", "v10", -1},
+		{"v1.2", "v1.10", -1},
+		{"v2.1", "v2", 1},
+		{"2.3.1-beta", "2.3.1", -1},
+		{"2.3.1", "2.3.1-beta", 1},
+		{"2.3.1-alpha", "2.3.1-beta", -1},
+		{"2.3.1-beta.2", "2.3.1-beta.11", -1},
+		{"2.3.1-beta", "2.3.1-beta.1", -1},
+		{"2.3.1-1", "2.3.1-alpha", -1},
+		{"2.3.1+build.5", "2.3.1", 0},
+		{"v2-rc.1", "v1.9", 1},
+	}
+	for _, c := range cases {
+		if actual := expr.CompareVersions(c.A, c.B); actual != c.Expected {
+			t.Errorf("%s vs. %s: got %d, expected %d", c.A, c.B, actual, c.Expected)
+		}
+	}
+}
+
+func TestRootExprFilterVersion(t *testing.T) {
+	cases := []struct {
+		Version  string
+		Expected []string
+		Services int
+	}{
+		{"v1", []string{"Old", "Always"}, 2},
+		{"v1.5", []string{"Always"}, 1},
+		{"v2", []string{"New", "Always"}, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.Version, func(t *testing.T) {
+			root := expr.RunDSL(t, testdata.VersionsDSL)
+			if err := root.FilterVersion(c.Version); err != nil {
+				t.Fatal(err)
+			}
+			if len(root.Services) != c.Services {
+				t.Fatalf("got %d services, expected %d", len(root.Services), c.Services)
+			}
+			if len(root.API.HTTP.Services) != c.Services {
+				t.Fatalf("got %d HTTP services, expected %d", len(root.API.HTTP.Services), c.Services)
+			}
+			if svcs := root.API.Servers[0].Services; len(svcs) != c.Services {
+				t.Fatalf("got server services %v, expected %d", svcs, c.Services)
+			}
+			methods := root.Services[0].Methods
+			endpoints := root.API.HTTP.Services[0].HTTPEndpoints
+			if len(methods) != len(c.Expected) {
+				t.Fatalf("got %d methods, expected %d", len(methods), len(c.Expected))
+			}
+			if len(endpoints) != len(c.Expected) {
+				t.Fatalf("got %d HTTP endpoints, expected %d", len(endpoints), len(c.Expected))
+			}
+			for i, n := range c.Expected {
+				if methods[i].Name != n {
+					t.Errorf("got method %q, expected %q", methods[i].Name, n)
+				}
+				if endpoints[i].MethodExpr.Name != n {
+					t.Errorf("got HTTP endpoint %q, expected %q", endpoints[i].MethodExpr.Name, n)
+				}
+			}
+		})
+	}
+}
+
+func TestRootExprFilterVersionInvalid(t *testing.T) {
+	root := expr.RunDSL(t, testdata.VersionsDSL)
+	if err := root.FilterVersion("2.x"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(root.Services) != 2 {
+		t.Errorf("got %d services, expected the services to be left untouched", len(root.Services))
+	}
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"errors"
+	"strings"
+
+	"goa.design/goa/v3/security"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataValue returns the first value of the given key in md or the empty
+// string if there is none.
+func MetadataValue(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// MetadataCredential returns the credential stored under the given key in md
+// with the authorization scheme prefix (e.g. "Bearer") removed if any.
+func MetadataCredential(md metadata.MD, key string) string {
+	cred := MetadataValue(md, key)
+	if strings.Contains(cred, " ") {
+		cred = strings.SplitN(cred, " ", 2)[1]
+	}
+	return cred
+}
+
+// EncodeAuthError returns a gRPC status error from the given authentication
+// error with the error response encoded in the status details. The status code
+// is PermissionDenied if the error is caused by missing scopes (see
+// security.MissingScopesError) and Unauthenticated otherwise. err is returned
+// unchanged if it is already a gRPC status error.
+func EncodeAuthError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codes.Unauthenticated
+	var serr *security.MissingScopesError
+	if errors.As(err, &serr) {
+		code = codes.PermissionDenied
+	}
+	return NewStatusError(code, err, NewErrorResponse(err))
+}
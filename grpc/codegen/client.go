@@ -3,6 +3,7 @@ package codegen
 import (
 	"path"
 	"path/filepath"
+	"strconv"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -69,6 +70,16 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 			Source: clientInitT,
 			Data:   data,
 		})
+		if size := clientPoolSize(); size > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-pool-init",
+				Source: clientPoolInitT,
+				Data: map[string]interface{}{
+					"Service": data,
+					"Size":    size,
+				},
+			})
+		}
 		for _, e := range data.Endpoints {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "client-endpoint-init",
@@ -99,6 +110,13 @@ func client(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 						Data:   e.ClientStream,
 					})
 				}
+				if e.ClientStream.CloseAndRecv {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "client-stream-close-and-recv",
+						Source: streamCloseAndRecvT,
+						Data:   e.ClientStream,
+					})
+				}
 				if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
 					sections = append(sections, &codegen.SectionTemplate{
 						Name:   "client-stream-set-view",
@@ -181,6 +199,20 @@ func isBearer(schemes []*service.SchemeData) bool {
 	return false
 }
 
+// clientPoolSize returns the size of the client connection pools set with the
+// API "grpc:client:pool" meta, 0 if the meta is not set.
+func clientPoolSize() int {
+	v, ok := expr.Root.API.Meta.Last("grpc:client:pool")
+	if !ok {
+		return 0
+	}
+	size, err := strconv.Atoi(v)
+	if err != nil || size <= 0 {
+		return 0
+	}
+	return size
+}
+
 // input: ServiceData
 const clientStructT = `{{ printf "%s lists the service endpoint gRPC clients." .ClientStruct | comment }}
 type {{ .ClientStruct }} struct {
@@ -199,6 +231,28 @@ func New{{ .ClientStruct }}(cc *grpc.ClientConn, opts ...grpc.CallOption) *{{ .C
 }
 `
 
+// input: map[string]interface{}{"Service": ServiceData, "Size": int}
+const clientPoolInitT = `// PoolSize is the number of connections of the pools created with DialPool as
+// defined in the design.
+const PoolSize = {{ .Size }}
+
+// DialPool creates a pool of PoolSize client connections to target with the
+// given dial options. Use NewPooled{{ .Service.ClientStruct }} to create a client that
+// distributes the requests across the connections of the pool. Closing the
+// pool closes all its connections.
+func DialPool(target string, opts ...grpc.DialOption) (*goagrpc.ClientConnPool, error) {
+	return goagrpc.DialClientConnPool(target, PoolSize, opts...)
+}
+
+{{ printf "NewPooled%s instantiates gRPC client for all the %s service servers that distributes the requests in round-robin order across the connections of pool, skipping the connections in failure." .Service.ClientStruct .Service.Service.Name | comment }}
+func NewPooled{{ .Service.ClientStruct }}(pool *goagrpc.ClientConnPool, opts ...grpc.CallOption) *{{ .Service.ClientStruct }} {
+	return &{{ .Service.ClientStruct }}{
+		grpccli: {{ .Service.ClientInterfaceInit }}(pool),
+		opts:    opts,
+	}
+}
+`
+
 // input: EndpointData
 const clientEndpointInitT = `{{ printf "%s calls the %q function in %s.%s interface." .Method.VarName .Method.VarName .PkgName .ClientInterface | comment }}
 func (c *{{ .ClientStruct }}) {{ .Method.VarName }}() goa.Endpoint {
@@ -416,7 +470,11 @@ func Encode{{ .Method.VarName }}Request(ctx context.Context, v interface{}, md *
 	{{- end }}
 {{- end }}
 {{- if .Request.ClientConvert }}
+	{{- if .Request.ClientConvert.Init.ReturnsError }}
+	return {{ .Request.ClientConvert.Init.Name }}({{ range .Request.ClientConvert.Init.Args }}{{ .Name }}, {{ end }})
+	{{- else }}
 	return {{ .Request.ClientConvert.Init.Name }}({{ range .Request.ClientConvert.Init.Args }}{{ .Name }}, {{ end }}), nil
+	{{- end }}
 {{- else }}
 	return nil, nil
 {{- end }}
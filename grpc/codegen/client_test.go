@@ -46,6 +46,25 @@ func TestClientEndpointInit(t *testing.T) {
 	}
 }
 
+func TestClientPoolInit(t *testing.T) {
+	RunGRPCDSL(t, testdata.ClientPoolDSL)
+	fs := ClientFiles("", expr.Root)
+	sections := fs[0].Section("client-pool-init")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected 1", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.ClientPoolInitCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ClientPoolInitCode))
+	}
+
+	RunGRPCDSL(t, testdata.UnaryRPCsDSL)
+	fs = ClientFiles("", expr.Root)
+	if sections := fs[0].Section("client-pool-init"); len(sections) != 0 {
+		t.Errorf("got %d sections, expected none", len(sections))
+	}
+}
+
 func TestRequestEncoder(t *testing.T) {
 	cases := []struct {
 		Name string
@@ -61,6 +80,7 @@ func TestRequestEncoder(t *testing.T) {
 		{"request-encoder-payload-with-metadata", testdata.MessageWithMetadataDSL, testdata.PayloadWithMetadataRequestEncoderCode},
 		{"request-encoder-payload-with-validate", testdata.MessageWithValidateDSL, testdata.PayloadWithValidateRequestEncoderCode},
 		{"request-encoder-payload-with-security-attributes", testdata.MessageWithSecurityAttrsDSL, testdata.PayloadWithSecurityAttrsRequestEncoderCode},
+		{"request-encoder-payload-with-well-known-types", testdata.WellKnownTypesDSL, testdata.PayloadWithWellKnownTypesRequestEncoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -80,6 +80,7 @@ func clientType(genpkg string, svc *expr.GRPCServiceExpr, seen map[string]struct
 		imports := []*codegen.ImportSpec{
 			{Path: "unicode/utf8"},
 			codegen.GoaImport(""),
+			codegen.GoaNamedImport("grpc", "goagrpc"),
 			{Path: path.Join(genpkg, svcName), Name: sd.Service.PkgName},
 			{Path: path.Join(genpkg, svcName, "views"), Name: sd.Service.ViewsPkg},
 			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: sd.PkgName},
@@ -24,6 +24,8 @@ func TestClientTypeFiles(t *testing.T) {
 		{"client-bidirectional-streaming-same-type", testdata.BidirectionalStreamingRPCSameTypeDSL, testdata.BidirectionalStreamingRPCSameTypeClientTypeCode},
 		{"client-struct-meta-type", testdata.StructMetaTypeDSL, testdata.StructMetaTypeTypeCode},
 		{"client-default-fields", testdata.DefaultFieldsDSL, testdata.DefaultFieldsTypeCode},
+		{"client-well-known-types", testdata.WellKnownTypesDSL, testdata.WellKnownTypesClientTypeCode},
+		{"client-enum-map", testdata.EnumMapDSL, testdata.EnumMapClientTypeCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
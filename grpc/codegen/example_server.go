@@ -88,6 +88,12 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		apiPkg = scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 	}
 	specs = append(specs, &codegen.ImportSpec{Path: rootPath, Name: apiPkg})
+	var healthPkg string
+	if healthEnabled(root) {
+		dir := healthPathName(root)
+		healthPkg = scope.Unique(dir)
+		specs = append(specs, &codegen.ImportSpec{Path: path.Join(genpkg, "grpc", dir), Name: healthPkg})
+	}
 
 	var (
 		sections []*codegen.SectionTemplate
@@ -120,7 +126,8 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				Name:   "server-grpc-register",
 				Source: grpcRegisterSvrT,
 				Data: map[string]interface{}{
-					"Services": svcdata,
+					"Services":  svcdata,
+					"HealthPkg": healthPkg,
 				},
 				FuncMap: map[string]interface{}{
 					"goify":      codegen.Goify,
@@ -189,7 +196,7 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 `
 
-	// input: map[string]interface{}{"Services":[]*ServiceData}
+	// input: map[string]interface{}{"Services":[]*ServiceData, "HealthPkg":string}
 	grpcRegisterSvrT = `
 	// Initialize gRPC server with the middleware.
 	srv := grpc.NewServer(
@@ -219,6 +226,15 @@ func handleGRPCServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	// Register the server reflection service on the server.
 	// See https://grpc.github.io/grpc/core/md_doc_server-reflection.html.
 	reflection.Register(srv)
+{{- if .HealthPkg }}
+
+	// Register the gRPC health checking service on the server. All the
+	// services are reported as SERVING, use healthSvr.SetServingStatus to
+	// update their status.
+	// See https://github.com/grpc/grpc/blob/master/doc/health-checking.md.
+	healthSvr := {{ .HealthPkg }}.NewServer()
+	{{ .HealthPkg }}.Register(srv, healthSvr)
+{{- end }}
 `
 
 	// input: map[string]interface{}{"Services":[]*ServiceData}
@@ -0,0 +1,163 @@
+package codegen
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// HealthData contains the data used to render the gRPC health checking
+	// service code.
+	HealthData struct {
+		// Services lists the gRPC services reported by the health service.
+		Services []*HealthServiceData
+	}
+
+	// HealthServiceData describes a gRPC service reported by the health
+	// service.
+	HealthServiceData struct {
+		// Name is the service name.
+		Name string
+		// ConstName is the name of the constant holding the service full
+		// name.
+		ConstName string
+		// FullName is the gRPC service full name, e.g. "calc.Calc".
+		FullName string
+	}
+)
+
+// healthEnabled returns true if the API defines gRPC services and the
+// "grpc:health" meta is set to "true".
+func healthEnabled(root *expr.RootExpr) bool {
+	if root.API == nil || root.API.GRPC == nil || len(root.API.GRPC.Services) == 0 {
+		return false
+	}
+	v, ok := root.API.Meta.Last("grpc:health")
+	return ok && v == "true"
+}
+
+// healthPathName returns the name of the directory containing the generated
+// health package, "health" unless a gRPC service already uses it.
+func healthPathName(root *expr.RootExpr) string {
+	for _, svc := range root.API.GRPC.Services {
+		if GRPCServices.Get(svc.Name()).Service.PathName == "health" {
+			return "goahealth"
+		}
+	}
+	return "health"
+}
+
+// HealthFiles returns the file implementing the standard gRPC health checking
+// service (grpc.health.v1.Health) for the API gRPC services. It returns nil
+// unless the API "grpc:health" meta is set to "true".
+func HealthFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if !healthEnabled(root) {
+		return nil
+	}
+	var (
+		scope = codegen.NewNameScope()
+		data  = &HealthData{}
+	)
+	scope.Unique("Services")
+	for _, svc := range root.API.GRPC.Services {
+		sd := GRPCServices.Get(svc.Name())
+		data.Services = append(data.Services, &HealthServiceData{
+			Name:      svc.Name(),
+			ConstName: scope.Unique(codegen.Goify(svc.Name(), true) + "Service"),
+			FullName:  pkgName(svc, sd.Service.PathName) + "." + sd.Name,
+		})
+	}
+	pkg := healthPathName(root)
+	fpath := filepath.Join(codegen.Gendir, "grpc", pkg, "health.go")
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "google.golang.org/grpc"},
+		{Path: "google.golang.org/grpc/codes"},
+		{Path: "google.golang.org/grpc/health", Name: "grpchealth"},
+		{Path: "google.golang.org/grpc/health/grpc_health_v1"},
+		{Path: "google.golang.org/grpc/status"},
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("gRPC health checking service", pkg, imports),
+		{Name: "health-services", Source: healthServicesT, Data: data},
+		{Name: "health-server", Source: healthServerT, Data: data},
+	}
+	return []*codegen.File{{Path: fpath, SectionTemplates: sections}}
+}
+
+const (
+	// input: HealthData
+	healthServicesT = `const (
+{{- range .Services }}
+	{{ printf "%s is the full name of the %s gRPC service used to set and check its health status." .ConstName .Name | comment }}
+	{{ .ConstName }} = {{ printf "%q" .FullName }}
+{{- end }}
+)
+
+// Services lists the full names of the gRPC services reported by the health
+// service.
+var Services = []string{ {{- range $i, $s := .Services }}{{ if $i }}, {{ end }}{{ $s.ConstName }}{{ end -}} }
+`
+
+	// input: HealthData
+	healthServerT = `// StatusFunc returns the serving status of the gRPC service with the given full
+// name. The empty name designates the server as a whole.
+type StatusFunc func(ctx context.Context, service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error)
+
+// HealthServer implements the grpc.health.v1.Health service by delegating the
+// health checks to a StatusFunc. The Watch method is not implemented.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	status StatusFunc
+}
+
+// NewServer returns a health server that reports the server as a whole and all
+// the API gRPC services as SERVING. Use SetServingStatus to update the status
+// of a service, for example:
+//
+//	srv.SetServingStatus({{ (index .Services 0).ConstName }}, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+func NewServer() *grpchealth.Server {
+	srv := grpchealth.NewServer()
+	for _, svc := range Services {
+		srv.SetServingStatus(svc, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+	return srv
+}
+
+// NewHealthServer returns a health server that calls fn to compute the
+// serving status of the server and of the API gRPC services.
+func NewHealthServer(fn StatusFunc) *HealthServer {
+	return &HealthServer{status: fn}
+}
+
+// Register registers hs as the grpc.health.v1.Health service of srv. hs is
+// typically the server returned by NewServer or NewHealthServer.
+func Register(srv *grpc.Server, hs grpc_health_v1.HealthServer) {
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+}
+
+// Check returns the serving status of the requested service. It returns a
+// NotFound error if the service is not one of the API gRPC services.
+func (s *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if svc := req.GetService(); svc != "" {
+		found := false
+		for _, n := range Services {
+			if n == svc {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", svc)
+		}
+	}
+	st, err := s.status(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: st}, nil
+}
+`
+)
@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/grpc/codegen/testdata"
+)
+
+func TestHealthFiles(t *testing.T) {
+	root := RunGRPCDSL(t, testdata.HealthDSL)
+	fs := HealthFiles("", root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if p := filepath.Join("gen", "grpc", "health", "health.go"); fs[0].Path != p {
+		t.Errorf("got path %q, expected %q", fs[0].Path, p)
+	}
+	var buf bytes.Buffer
+	for _, s := range fs[0].SectionTemplates[1:] {
+		if err := s.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+	if code != testdata.HealthCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.HealthCode))
+	}
+}
+
+func TestHealthFilesDisabled(t *testing.T) {
+	root := RunGRPCDSL(t, testdata.UnaryRPCsDSL)
+	if fs := HealthFiles("", root); fs != nil {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
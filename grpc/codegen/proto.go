@@ -138,7 +138,34 @@ service {{ .Name }} {
 	{{ if .Method.Description }}{{ .Method.Description | comment }}{{ end }}
 	{{- $serverStream := or (eq .Method.StreamKind 3) (eq .Method.StreamKind 4) }}
 	{{- $clientStream := or (eq .Method.StreamKind 2) (eq .Method.StreamKind 4) }}
-	rpc {{ .Method.VarName }} ({{ if $clientStream }}stream {{ end }}{{ .Request.Message.VarName }}) returns ({{ if $serverStream }}stream {{ end }}{{ .Response.Message.VarName }});
+	rpc {{ .Method.VarName }} ({{ if $clientStream }}stream {{ end }}{{ .Request.Message.VarName }}) returns ({{ if $serverStream }}stream {{ end }}{{ .Response.Message.VarName }}){{ if .HTTPRule }} {
+		option (google.api.http) = {
+		{{- $rule := .HTTPRule }}
+		{{- range $i, $b := .HTTPRule.Bindings }}
+			{{- $indent := "" }}
+			{{- if $i }}{{ $indent = "\t" }}
+			additional_bindings {
+			{{- end }}
+			{{- if $b.Verb }}
+			{{ $indent }}{{ $b.Verb }}: "{{ $b.Path }}"
+			{{- else }}
+			{{ $indent }}custom {
+			{{ $indent }}	kind: "{{ $b.Kind }}"
+			{{ $indent }}	path: "{{ $b.Path }}"
+			{{ $indent }}}
+			{{- end }}
+			{{- if $rule.Body }}
+			{{ $indent }}body: "{{ $rule.Body }}"
+			{{- end }}
+			{{- if $rule.ResponseBody }}
+			{{ $indent }}response_body: "{{ $rule.ResponseBody }}"
+			{{- end }}
+			{{- if $i }}
+			}
+			{{- end }}
+		{{- end }}
+		};
+	}{{ else }};{{ end }}
 	{{- end }}
 }
 `
@@ -67,6 +67,7 @@ func TestMessageDefSection(t *testing.T) {
 		{"primitive", testdata.MessagePrimitiveDSL, testdata.MessagePrimitiveCode},
 		{"with-metadata", testdata.MessageWithMetadataDSL, testdata.MessageWithMetadataCode},
 		{"with-security-attributes", testdata.MessageWithSecurityAttrsDSL, testdata.MessageWithSecurityAttrsCode},
+		{"with-reserved", testdata.MessageWithReservedDSL, testdata.MessageWithReservedCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -91,3 +92,19 @@ func TestMessageDefSection(t *testing.T) {
 		})
 	}
 }
+
+func TestProtoHTTPRules(t *testing.T) {
+	RunGRPCDSL(t, testdata.HTTPTranscodeDSL)
+	fs := ProtoFiles("", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	sections := fs[0].SectionTemplates
+	if len(sections) < 3 {
+		t.Fatalf("got %d sections, expected at least three", len(sections))
+	}
+	code := sectionCode(t, sections[1:3]...)
+	if code != testdata.HTTPTranscodeServiceCode {
+		t.Errorf("got\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.HTTPTranscodeServiceCode))
+	}
+}
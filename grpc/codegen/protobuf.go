@@ -111,8 +111,13 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, sd *ServiceDat
 		}
 	}
 
+	if useWellKnownTypes(sd) {
+		mapWellKnownChildren(att)
+	}
+
 	switch {
 	case expr.IsPrimitive(att.Type):
+		mapEnumMap(att)
 		return
 	case isut:
 		if expr.IsArray(ut) {
@@ -138,6 +143,198 @@ func makeProtoBufMessageR(att *expr.AttributeExpr, tname *string, sd *ServiceDat
 	}
 }
 
+// mapWellKnownChildren maps the child attributes of the given array, map,
+// union or object attribute to the protocol buffer well-known types (see
+// mapWellKnownType). The child attributes are not modified, instead the
+// attribute type is replaced with a copy that holds the mapped children.
+func mapWellKnownChildren(att *expr.AttributeExpr) {
+	switch t := att.Type.(type) {
+	case *expr.Array:
+		if elem := mapWellKnownType(t.ElemType); elem != t.ElemType {
+			att.Type = &expr.Array{ElemType: elem}
+		}
+	case *expr.Map:
+		if elem := mapWellKnownType(t.ElemType); elem != t.ElemType {
+			att.Type = &expr.Map{KeyType: t.KeyType, ElemType: elem}
+		}
+	case *expr.Union:
+		if vals, ok := mapWellKnownAttributes(t.Values); ok {
+			att.Type = &expr.Union{TypeName: t.TypeName, Values: vals}
+		}
+	case *expr.Object:
+		if nats, ok := mapWellKnownAttributes(*t); ok {
+			obj := expr.Object(nats)
+			att.Type = &obj
+		}
+	}
+}
+
+// mapWellKnownAttributes returns a copy of the given named attributes where
+// the attributes mapped to protocol buffer well-known types are replaced with
+// their mapped copies. The boolean is false if no attribute was mapped.
+func mapWellKnownAttributes(nats []*expr.NamedAttributeExpr) ([]*expr.NamedAttributeExpr, bool) {
+	var mapped bool
+	res := make([]*expr.NamedAttributeExpr, len(nats))
+	for i, nat := range nats {
+		res[i] = nat
+		if a := mapWellKnownType(nat.Attribute); a != nat.Attribute {
+			res[i] = &expr.NamedAttributeExpr{Name: nat.Name, Attribute: a}
+			mapped = true
+		}
+	}
+	return res, mapped
+}
+
+// useWellKnownTypes returns true if the "grpc:wellknown" meta is set on the
+// service or on the API.
+func useWellKnownTypes(sd *ServiceData) bool {
+	if sd.Service != nil {
+		if svc := expr.Root.Service(sd.Service.Name); svc != nil {
+			if _, ok := svc.Meta["grpc:wellknown"]; ok {
+				return true
+			}
+		}
+	}
+	if expr.Root.API == nil {
+		return false
+	}
+	_, ok := expr.Root.API.Meta["grpc:wellknown"]
+	return ok
+}
+
+// mapWellKnownType returns a copy of the given string attribute using the
+// date-time or duration format mapped to the google.protobuf.Timestamp or
+// google.protobuf.Duration well-known type by setting the
+// "struct:field:proto" meta. The copy has no validations and no default value
+// as they apply to the string representation only. mapWellKnownType returns
+// the attribute unchanged if it uses neither format or if it already defines
+// the "struct:field:proto" meta.
+func mapWellKnownType(att *expr.AttributeExpr) *expr.AttributeExpr {
+	if att.Type != expr.String || att.Validation == nil {
+		return att
+	}
+	if _, ok := att.Meta["struct:field:proto"]; ok {
+		return att
+	}
+	var proto []string
+	switch att.Validation.Format {
+	case expr.FormatDateTime:
+		proto = []string{"google.protobuf.Timestamp", "google/protobuf/timestamp.proto", "Timestamp", "google.golang.org/protobuf/types/known/timestamppb"}
+	case expr.FormatDuration:
+		proto = []string{"google.protobuf.Duration", "google/protobuf/duration.proto", "Duration", "google.golang.org/protobuf/types/known/durationpb"}
+	default:
+		return att
+	}
+	mapped := expr.DupAtt(att)
+	if mapped.Meta == nil {
+		mapped.Meta = expr.MetaExpr{}
+	}
+	mapped.Meta["struct:field:proto"] = proto
+	mapped.Validation = nil
+	mapped.DefaultValue = nil
+	return mapped
+}
+
+// hasWellKnownType returns true if the given attribute or any of its children
+// is mapped to a protocol buffer well-known type.
+func hasWellKnownType(att *expr.AttributeExpr) bool {
+	return hasWellKnownTypeR(att, make(map[string]struct{}))
+}
+
+// hasWellKnownTypeR is the recursive implementation of hasWellKnownType.
+func hasWellKnownTypeR(att *expr.AttributeExpr, seen map[string]struct{}) bool {
+	if wellKnownType(att) != "" {
+		return true
+	}
+	if ut, ok := att.Type.(expr.UserType); ok {
+		if _, ok := seen[ut.ID()]; ok {
+			return false
+		}
+		seen[ut.ID()] = struct{}{}
+		return hasWellKnownTypeR(ut.Attribute(), seen)
+	}
+	switch {
+	case expr.IsArray(att.Type):
+		return hasWellKnownTypeR(expr.AsArray(att.Type).ElemType, seen)
+	case expr.IsMap(att.Type):
+		return hasWellKnownTypeR(expr.AsMap(att.Type).ElemType, seen)
+	case expr.IsUnion(att.Type):
+		for _, nat := range expr.AsUnion(att.Type).Values {
+			if hasWellKnownTypeR(nat.Attribute, seen) {
+				return true
+			}
+		}
+	case expr.IsObject(att.Type):
+		for _, nat := range *expr.AsObject(att.Type) {
+			if hasWellKnownTypeR(nat.Attribute, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mapEnumMap maps string attributes that define an enum map to int32
+// attributes holding the enum value numbers. The attribute validations accept
+// the enum value numbers unless the enum map defines a fallback value in which
+// case any number is accepted. Aliases of such attributes are mapped as well.
+// The protocol buffer field type is int32 so that it is wire compatible with
+// protocol buffer enums.
+func mapEnumMap(att *expr.AttributeExpr) {
+	base := att
+	if ut, ok := att.Type.(expr.UserType); ok {
+		base = ut.Attribute()
+	}
+	if base.EnumMap == nil {
+		return
+	}
+	if base.Type == expr.String {
+		base.Type = expr.Int32
+		if base.Validation != nil {
+			base.Validation.Values = nil
+			if base.EnumMap.Fallback == "" {
+				base.Validation.Values = base.EnumMap.Numbers()
+			}
+		}
+		if name, ok := base.DefaultValue.(string); ok {
+			base.DefaultValue, _ = base.EnumMap.Number(name)
+		}
+	}
+	for _, a := range []*expr.AttributeExpr{att, base} {
+		if _, ok := a.Meta["struct:field:proto"]; !ok {
+			a.AddMeta("struct:field:proto", "int32")
+		}
+	}
+}
+
+// enumMap returns the enum map of the attributes if they correspond to the
+// service and protocol buffer representations of an enum map, nil otherwise.
+func enumMap(src, tgt *expr.AttributeExpr) *expr.EnumMapExpr {
+	src, tgt = unAlias(src), unAlias(tgt)
+	if src.EnumMap == nil || src.Type == tgt.Type {
+		return nil
+	}
+	return src.EnumMap
+}
+
+// wellKnownType returns "Timestamp" or "Duration" if the given string
+// attribute is mapped to the corresponding protocol buffer well-known type,
+// the empty string otherwise.
+func wellKnownType(att *expr.AttributeExpr) string {
+	if att.Type != expr.String {
+		return ""
+	}
+	switch proto := att.Meta["struct:field:proto"]; {
+	case len(proto) == 0:
+		return ""
+	case proto[0] == "google.protobuf.Timestamp":
+		return "Timestamp"
+	case proto[0] == "google.protobuf.Duration":
+		return "Duration"
+	}
+	return ""
+}
+
 // wrapAttr makes the attribute type a user type by wrapping the given
 // attribute into an attribute named "field".
 func wrapAttr(att *expr.AttributeExpr, tname string, req bool, sd *ServiceData) {
@@ -295,6 +492,22 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 	case *expr.Object:
 		var ss []string
 		ss = append(ss, " {")
+		if r := att.Reserved; r != nil {
+			if len(r.Numbers) > 0 {
+				nums := make([]string, len(r.Numbers))
+				for i, n := range r.Numbers {
+					nums[i] = strconv.FormatUint(n, 10)
+				}
+				ss = append(ss, fmt.Sprintf("\treserved %s;", strings.Join(nums, ", ")))
+			}
+			if len(r.Names) > 0 {
+				names := make([]string, len(r.Names))
+				for i, n := range r.Names {
+					names[i] = strconv.Quote(n)
+				}
+				ss = append(ss, fmt.Sprintf("\treserved %s;", strings.Join(names, ", ")))
+			}
+		}
 		for _, nat := range *actual {
 			if expr.IsUnion(nat.Attribute.Type) {
 				ss = append(ss, protoBufMessageDef(nat.Attribute, sd))
@@ -336,7 +549,7 @@ func protoBufMessageDef(att *expr.AttributeExpr, sd *ServiceData) string {
 // (in *.pb.go) for the given attribute.
 func protoBufGoFullTypeRef(att *expr.AttributeExpr, pkg string, s *codegen.NameScope) string {
 	name := protoBufGoFullTypeName(att, pkg, s)
-	if expr.IsObject(att.Type) || expr.IsUnion(att.Type) {
+	if expr.IsObject(att.Type) || expr.IsUnion(att.Type) || wellKnownType(att) != "" {
 		return "*" + name
 	}
 	return name
@@ -28,6 +28,9 @@ type transformAttrs struct {
 	// source message when proto is false.  (protoc builds union struct type
 	// names from the parent message name).
 	message string
+	// fallible is set to true when the generated code contains conversions
+	// that may fail and thus makes use of the err variable.
+	fallible *bool
 }
 
 // unionData is used by both transformUnion methods.
@@ -55,7 +58,12 @@ var (
 
 // NOTE: can't initialize inline because https://github.com/golang/go/issues/1817
 func init() {
-	fm := template.FuncMap{"transformAttribute": transformAttribute, "convertType": convertType}
+	fm := template.FuncMap{
+		"transformAttribute": transformAttribute,
+		"convertType":        convertType,
+		"convertFails":       convertFails,
+		"assignConvert":      assignConvert,
+	}
 	transformGoArrayT = template.Must(template.New("transformGoArray").Funcs(fm).Parse(transformGoArrayTmpl))
 	transformGoMapT = template.Must(template.New("transformGoMap").Funcs(fm).Parse(transformGoMapTmpl))
 	transformGoUnionToProtoT = template.Must(template.New("transformGoUnionToProto").Funcs(fm).Parse(transformGoUnionToProtoTmpl))
@@ -77,11 +85,19 @@ func init() {
 // newVar if true initializes a target variable with the generated Go code
 // using `:=` operator. If false, it assigns Go code to the target variable
 // using `=`.
-func protoBufTransform(source, target *expr.AttributeExpr, sourceVar, targetVar string, sourceCtx, targetCtx *codegen.AttributeContext, proto, newVar bool) (string, []*codegen.TransformFunctionData, error) {
-	ta := &transformAttrs{TransformAttrs: &codegen.TransformAttrs{
-		SourceCtx: sourceCtx,
-		TargetCtx: targetCtx,
-	}}
+//
+// The returned boolean is true if the generated code contains conversions
+// that may fail. In this case the code declares an err variable and returns
+// nil and the error on failure so that it must be used in a function that
+// returns a pointer and an error.
+func protoBufTransform(source, target *expr.AttributeExpr, sourceVar, targetVar string, sourceCtx, targetCtx *codegen.AttributeContext, proto, newVar bool) (string, []*codegen.TransformFunctionData, bool, error) {
+	ta := &transformAttrs{
+		TransformAttrs: &codegen.TransformAttrs{
+			SourceCtx: sourceCtx,
+			TargetCtx: targetCtx,
+		},
+		fallible: new(bool),
+	}
 	if proto {
 		target = expr.DupAtt(target)
 		removeMeta(target)
@@ -96,15 +112,19 @@ func protoBufTransform(source, target *expr.AttributeExpr, sourceVar, targetVar
 
 	code, err := transformAttribute(source, target, sourceVar, targetVar, newVar, ta)
 	if err != nil {
-		return "", nil, err
+		return "", nil, false, err
+	}
+	fallible := *ta.fallible
+	if fallible {
+		code = "var err error\n" + code
 	}
 
 	funcs, err := transformAttributeHelpers(source, target, ta, make(map[string]*codegen.TransformFunctionData))
 	if err != nil {
-		return "", nil, err
+		return "", nil, false, err
 	}
 
-	return strings.TrimRight(code, "\n"), funcs, nil
+	return strings.TrimRight(code, "\n"), funcs, fallible, nil
 }
 
 // removeMeta removes the meta attributes from the given attribute. This is
@@ -172,12 +192,7 @@ func transformAttribute(source, target *expr.AttributeExpr, sourceVar, targetVar
 				code, err = transformUnionFromProto(source, target, sourceVar, targetVar, ta)
 			}
 		default:
-			assign := "="
-			if newVar {
-				assign = ":="
-			}
-			srcField := convertType(source, target, false, false, sourceVar, ta)
-			code = fmt.Sprintf("%s %s %s\n", targetVar, assign, srcField)
+			code = assignConvert(source, target, false, false, sourceVar, targetVar, newVar, ta)
 		}
 	}
 	if err != nil {
@@ -200,6 +215,59 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			if !expr.IsPrimitive(srcc.Type) {
 				return
 			}
+			if ta.proto && wellKnownType(tgtc) != "" || !ta.proto && wellKnownType(srcc) != "" {
+				// Well-known types are messages so the protocol buffer
+				// field is always a pointer.
+				var (
+					srcField = sourceVar + "." + ta.SourceCtx.Scope.Field(srcc, srcMatt.ElemName(n), true)
+					tgtField = ta.TargetCtx.Scope.Field(tgtc, tgtMatt.ElemName(n), true)
+					srcPtr   = ta.proto && ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr)
+					tgtPtr   = !ta.proto && ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr)
+					exp      = convertType(srcc, tgtc, srcPtr, tgtPtr, srcField, ta)
+				)
+				switch {
+				case ta.proto:
+					// Converting to a well-known type may fail, the
+					// field is set once the message is initialized.
+					code := assignConvert(srcc, tgtc, srcPtr, false, srcField, targetVar+"."+tgtField, false, ta)
+					if srcPtr {
+						code = fmt.Sprintf("if %s != nil {\n%s}\n", srcField, code)
+					}
+					postInitCode += code
+				case tgtPtr:
+					tmp := codegen.Goify(tgtMatt.ElemName(n), false)
+					postInitCode += fmt.Sprintf("if %s != nil {\n%s := %s\n%s.%s = &%s\n}\n", srcField, tmp, exp, targetVar, tgtField, tmp)
+				default:
+					initCode += fmt.Sprintf("\n%s: %s,", tgtField, exp)
+				}
+				return
+			}
+			if enumMap(srcc, tgtc) != nil {
+				var (
+					srcField = sourceVar + "." + ta.SourceCtx.Scope.Field(srcc, srcMatt.ElemName(n), true)
+					tgtField = ta.TargetCtx.Scope.Field(tgtc, tgtMatt.ElemName(n), true)
+					srcPtr   = ta.SourceCtx.IsPrimitivePointer(n, srcMatt.AttributeExpr)
+					tgtPtr   = ta.TargetCtx.IsPrimitivePointer(n, tgtMatt.AttributeExpr)
+					exp      = convertType(srcc, tgtc, srcPtr, tgtPtr, srcField, ta)
+				)
+				switch {
+				case srcPtr:
+					postInitCode += fmt.Sprintf("if %s != nil {\n", srcField)
+					if tgtPtr {
+						tmp := codegen.Goify(tgtMatt.ElemName(n), false)
+						postInitCode += fmt.Sprintf("%s := %s\n%s.%s = &%s\n", tmp, exp, targetVar, tgtField, tmp)
+					} else {
+						postInitCode += fmt.Sprintf("%s.%s = %s\n", targetVar, tgtField, exp)
+					}
+					postInitCode += "}\n"
+				case tgtPtr:
+					tmp := codegen.Goify(tgtMatt.ElemName(n), false)
+					postInitCode += fmt.Sprintf("%s := %s\n%s.%s = &%s\n", tmp, exp, targetVar, tgtField, tmp)
+				default:
+					initCode += fmt.Sprintf("\n%s: %s,", tgtField, exp)
+				}
+				return
+			}
 			var (
 				exp          string
 				srcField     = sourceVar + "." + ta.SourceCtx.Scope.Field(srcc, srcMatt.ElemName(n), true)
@@ -282,7 +350,9 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			tgtVar = targetVar + "." + ta.TargetCtx.Scope.Field(tgtc, tgtMatt.ElemName(n), true)
 		)
 		{
-			if err = codegen.IsCompatible(srcc.Type, tgtc.Type, "", ""); err != nil {
+			// Enum maps are initialized with the other primitive
+			// attributes even though the types differ.
+			if err = codegen.IsCompatible(srcc.Type, tgtc.Type, "", ""); err != nil && enumMap(srcc, tgtc) == nil {
 				if ta.proto {
 					ta.targetInit = ta.TargetCtx.Scope.Name(tgtc, ta.TargetCtx.Pkg(tgtc), ta.TargetCtx.Pointer, ta.TargetCtx.UseDefault)
 					tgtc = unwrapAttr(tgtc)
@@ -294,6 +364,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					return
 				}
 			}
+			err = nil
 			_, isUserType := srcc.Type.(expr.UserType)
 			switch {
 			case expr.IsArray(srcc.Type):
@@ -306,7 +377,7 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					tgtVar = targetVar + ".(" + ref + ")." + codegen.GoifyAtt(tgtc, tgtMatt.ElemName(n), true)
 				}
 				if !expr.IsPrimitive(srcc.Type) {
-					code = fmt.Sprintf("%s = %s(%s)\n", tgtVar, transformHelperName(srcc, tgtc, ta), srcVar)
+					code = assignConvert(srcc, tgtc, false, false, srcVar, tgtVar, false, ta)
 				}
 			case expr.IsObject(srcc.Type):
 				code, err = transformAttribute(srcc, tgtc, srcVar, tgtVar, false, ta)
@@ -597,6 +668,32 @@ func transformUnionFromProto(source, target *expr.AttributeExpr, sourceVar, targ
 // convertType produces code to initialize a target type from a source type
 // held by sourceVar.
 func convertType(src, tgt *expr.AttributeExpr, srcPtr bool, tgtPtr bool, srcVar string, ta *transformAttrs) string {
+	if ta.proto {
+		if wk := wellKnownType(tgt); wk != "" {
+			if srcPtr {
+				srcVar = "*" + srcVar
+			}
+			return fmt.Sprintf("goagrpc.New%s(%s)", wk, srcVar)
+		}
+	} else if wk := wellKnownType(src); wk != "" {
+		return fmt.Sprintf("goagrpc.Format%s(%s)", wk, srcVar)
+	}
+	if em := enumMap(src, tgt); em != nil {
+		if srcPtr {
+			srcVar = "*" + srcVar
+		}
+		if ta.proto {
+			if expr.IsAlias(src.Type) {
+				srcVar = "string(" + srcVar + ")"
+			}
+			return fmt.Sprintf("goagrpc.EnumNumber(%s, %s)", srcVar, enumNumbers(em))
+		}
+		exp := fmt.Sprintf("goagrpc.EnumName(%s, %s, %q)", srcVar, enumNames(em), em.Fallback)
+		if expr.IsAlias(tgt.Type) {
+			exp = fmt.Sprintf("%s(%s)", ta.TargetCtx.Scope.Ref(tgt, ta.TargetCtx.Pkg(tgt)), exp)
+		}
+		return exp
+	}
 	if expr.IsAlias(src.Type) || expr.IsAlias(tgt.Type) {
 		srcp, tgtp := unAlias(src), unAlias(tgt)
 		if srcp.Type == tgtp.Type {
@@ -625,6 +722,34 @@ func convertType(src, tgt *expr.AttributeExpr, srcPtr bool, tgtPtr bool, srcVar
 	return convertPrimitiveFromProto(src, tgt, srcPtr, tgtPtr, srcVar, ta)
 }
 
+// assignConvert returns the code that assigns the value held by srcVar
+// converted with convertType to tgtVar. If the conversion may fail (see
+// convertFails) the code returns nil and the conversion error on failure.
+func assignConvert(src, tgt *expr.AttributeExpr, srcPtr, tgtPtr bool, srcVar, tgtVar string, newVar bool, ta *transformAttrs) string {
+	exp := convertType(src, tgt, srcPtr, tgtPtr, srcVar, ta)
+	if !convertFails(src, tgt, ta) {
+		assign := "="
+		if newVar {
+			assign = ":="
+		}
+		return fmt.Sprintf("%s %s %s\n", tgtVar, assign, exp)
+	}
+	*ta.fallible = true
+	var decl string
+	if newVar {
+		decl = fmt.Sprintf("var %s %s\n", tgtVar, ta.TargetCtx.Scope.Ref(tgt, ta.TargetCtx.Pkg(tgt)))
+	}
+	return fmt.Sprintf("%sif %s, err = %s; err != nil {\n\treturn nil, err\n}\n", decl, tgtVar, exp)
+}
+
+// convertFails returns true if initializing the protocol buffer type tgt from
+// the service type src may fail, that is if tgt is or contains a protocol
+// buffer well-known type. The transform helper functions that initialize such
+// types return an error.
+func convertFails(src, tgt *expr.AttributeExpr, ta *transformAttrs) bool {
+	return ta.proto && hasWellKnownType(tgt)
+}
+
 // convertPrimitive returns the code to convert a primitive type from one
 // representation to another.
 // NOTE: For Int and UInt kinds, protocol buffer Go compiler generates
@@ -648,6 +773,26 @@ func convertPrimitiveFromProto(src, tgt *expr.AttributeExpr, srcPtr, tgtPtr bool
 	return fmt.Sprintf("%s(%s)", tgtType, srcVar)
 }
 
+// enumNumbers returns the Go map literal that maps the enum value names to
+// their numbers.
+func enumNumbers(em *expr.EnumMapExpr) string {
+	vals := make([]string, len(em.Values))
+	for i, v := range em.Values {
+		vals[i] = fmt.Sprintf("%q: %d", v.Name, v.Number)
+	}
+	return "map[string]int32{" + strings.Join(vals, ", ") + "}"
+}
+
+// enumNames returns the Go map literal that maps the enum value numbers to
+// their names.
+func enumNames(em *expr.EnumMapExpr) string {
+	vals := make([]string, len(em.Values))
+	for i, v := range em.Values {
+		vals[i] = fmt.Sprintf("%d: %q", v.Number, v.Name)
+	}
+	return "map[int32]string{" + strings.Join(vals, ", ") + "}"
+}
+
 // transformUnionData returns data needed by both transformUnion functions.
 func transformUnionData(source, target *expr.AttributeExpr, ta *transformAttrs) *unionData {
 	src := expr.AsUnion(source.Type)
@@ -731,7 +876,7 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *transform
 			}
 		case expr.IsObject(source.Type):
 			walkMatches(source, target, func(srcMatt, _ *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
-				if err != nil {
+				if err != nil || enumMap(srcc, tgtc) != nil {
 					return
 				}
 				if err = codegen.IsCompatible(srcc.Type, tgtc.Type, "", ""); err != nil {
@@ -828,18 +973,29 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *transformA
 			if _, ok := seen[name]; ok {
 				return nil, nil
 			}
-			code, err := transformAttribute(ut.Attribute(), target, "v", "res", true, ta)
+			hta := dupTransformAttrs(ta)
+			hta.fallible = new(bool)
+			code, err := transformAttribute(ut.Attribute(), target, "v", "res", true, hta)
 			if err != nil {
 				return nil, err
 			}
+			if *hta.fallible {
+				code = "var err error\n" + code
+			}
+			fails := convertFails(source, target, ta)
 			if !req {
-				code = "if v == nil {\n\treturn nil\n}\n" + code
+				ret := "nil"
+				if fails {
+					ret = "nil, nil"
+				}
+				code = "if v == nil {\n\treturn " + ret + "\n}\n" + code
 			}
 			tfd := &codegen.TransformFunctionData{
 				Name:          name,
 				ParamTypeRef:  ta.SourceCtx.Scope.Ref(source, ta.SourceCtx.Pkg(source)),
 				ResultTypeRef: ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)),
 				Code:          code,
+				ReturnsError:  fails,
 			}
 			seen[name] = tfd
 			data = append(data, tfd)
@@ -938,6 +1094,7 @@ func dupTransformAttrs(ta *transformAttrs) *transformAttrs {
 		targetInit:     ta.targetInit,
 		wrapped:        ta.wrapped,
 		message:        ta.message,
+		fallible:       ta.fallible,
 	}
 }
 
@@ -959,8 +1116,15 @@ for key, val := range {{ .SourceVar }} {
 	transformGoUnionToProtoTmpl = `switch src := {{ .SourceVar }}.(type) {
 {{- range $i, $ref := .SourceValueTypeRefs }}
 case {{ . }}:
-		{{- $val := (convertType (index $.SourceValues $i).Attribute (index $.TargetValues $i).Attribute false false "src" $.TransformAttrs) }}
+	{{- $src := (index $.SourceValues $i).Attribute }}
+	{{- $tgt := (index $.TargetValues $i).Attribute }}
+	{{- if convertFails $src $tgt $.TransformAttrs }}
+		{{ assignConvert $src $tgt false false "src" "val" true $.TransformAttrs }}
+		{{- $.TargetVar }} = &{{ index $.TargetValueTypeNames $i }}{ {{ (index $.TargetFieldNames $i) }}: val }
+	{{- else }}
+		{{- $val := (convertType $src $tgt false false "src" $.TransformAttrs) }}
 		{{ $.TargetVar }} = &{{ index $.TargetValueTypeNames $i }}{ {{ (index $.TargetFieldNames $i) }}: {{ $val }} }
+	{{- end }}
 {{- end }}
 }
 `
@@ -23,6 +23,11 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	for i, svc := range root.API.GRPC.Services {
 		fw[i+svcLen] = serverEncodeDecode(genpkg, svc)
 	}
+	for _, svc := range root.API.GRPC.Services {
+		if f := serverAuth(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
 	return fw
 }
 
@@ -40,9 +45,11 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 		imports := []*codegen.ImportSpec{
 			{Path: "context"},
 			{Path: "errors"},
+			{Path: "fmt"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("grpc", "goagrpc"),
 			{Path: "google.golang.org/grpc/codes"},
+			{Path: "google.golang.org/grpc/metadata"},
 			{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
 			{Path: path.Join(genpkg, svcName, "views"), Name: data.Service.ViewsPkg},
 			{Path: path.Join(genpkg, "grpc", svcName, pbPkgName), Name: data.PkgName},
@@ -108,6 +115,13 @@ func serverFile(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
 						Data:   e.ServerStream,
 					})
 				}
+				if e.ServerStream.Trailers != nil {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "server-stream-set-trailers",
+						Source: streamSetTrailersT,
+						Data:   e.ServerStream,
+					})
+				}
 			}
 		}
 	}
@@ -170,6 +184,33 @@ func serverEncodeDecode(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File
 	return &codegen.File{Path: fpath, SectionTemplates: sections}
 }
 
+// serverAuth returns the file defining the gRPC server interceptors that
+// authenticate the requests made to the secure service methods, nil if the
+// service does not define such methods.
+func serverAuth(genpkg string, svc *expr.GRPCServiceExpr) *codegen.File {
+	data := GRPCServices.Get(svc.Name())
+	if len(data.AuthEndpoints) == 0 {
+		return nil
+	}
+	svcName := data.Service.PathName
+	fpath := filepath.Join(codegen.Gendir, "grpc", svcName, "server", "auth.go")
+	title := fmt.Sprintf("%s gRPC server authentication interceptors", svc.Name())
+	imports := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "google.golang.org/grpc"},
+		{Path: "google.golang.org/grpc/metadata"},
+		codegen.GoaNamedImport("grpc", "goagrpc"),
+		codegen.GoaNamedImport("grpc/middleware", "grpcm"),
+		codegen.GoaImport("security"),
+		{Path: path.Join(genpkg, svcName), Name: data.Service.PkgName},
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", imports),
+		{Name: "server-auth-interceptors", Source: serverAuthInterceptorsT, Data: data},
+	}
+	return &codegen.File{Path: fpath, SectionTemplates: sections}
+}
+
 func transTmplFuncs(s *expr.GRPCServiceExpr) map[string]interface{} {
 	return map[string]interface{}{
 		"goTypeRef": func(dt expr.DataType) string {
@@ -218,6 +259,95 @@ func {{ .ServerInit }}(e *{{ .Service.PkgName }}.Endpoints{{ if .HasUnaryEndpoin
 }
 `
 
+// input: ServiceData
+const serverAuthInterceptorsT = `{{ printf "NewUnaryAuthInterceptor returns a gRPC unary server interceptor that authenticates the requests made to the %q service methods using the given authorization functions. The credentials are read from the request metadata. The interceptor returns an Unauthenticated status error if the authentication fails and a PermissionDenied status error if the request is not granted the required scopes. The context returned by the authorization functions is given to the handler." .Service.Name | comment }}
+func NewUnaryAuthInterceptor(auther {{ .Service.PkgName }}.Auther) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, info.FullMethod, auther)
+		if err != nil {
+			return nil, goagrpc.EncodeAuthError(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+{{ printf "NewStreamAuthInterceptor returns a gRPC stream server interceptor that authenticates the streams opened with the %q service methods using the given authorization functions. See NewUnaryAuthInterceptor." .Service.Name | comment }}
+func NewStreamAuthInterceptor(auther {{ .Service.PkgName }}.Auther) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod, auther)
+		if err != nil {
+			return goagrpc.EncodeAuthError(err)
+		}
+		return handler(srv, grpcm.NewWrappedServerStream(ctx, ss))
+	}
+}
+
+// authenticate runs the authorization functions of the given gRPC method with
+// the credentials read from the request metadata and returns the resulting
+// context. The requests made to the other methods are not authenticated.
+func authenticate(ctx context.Context, method string, auther {{ .Service.PkgName }}.Auther) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var err error
+	switch method {
+{{- range .AuthEndpoints }}
+	case {{ printf "%q" .FullMethodName }}:
+	{{- range $ridx, $r := .Requirements }}
+		{{- if ne $ridx 0 }}
+		if err != nil {
+		{{- end }}
+		{{- range $sidx, $s := .Schemes }}
+			{{- if ne $sidx 0 }}
+			if err == nil {
+			{{- end }}
+			sc := security.{{ .Type }}Scheme{
+				Name: {{ printf "%q" .SchemeName }},
+				Scopes: []string{ {{- range .Scopes }}{{ printf "%q" . }}, {{ end }} },
+				RequiredScopes: []string{ {{- range $r.Scopes }}{{ printf "%q" . }}, {{ end }} },
+			{{- if .Flows }}
+				Flows: []*security.OAuthFlow{
+				{{- range .Flows }}
+					&security.OAuthFlow{
+						Type: "{{ .Type }}",
+					{{- if .AuthorizationURL }}
+						AuthorizationURL: {{ printf "%q" .AuthorizationURL }},
+					{{- end }}
+					{{- if .TokenURL }}
+						TokenURL: {{ printf "%q" .TokenURL }},
+					{{- end }}
+					{{- if .RefreshURL }}
+						RefreshURL: {{ printf "%q" .RefreshURL }},
+					{{- end }}
+					},
+				{{- end }}
+				},
+			{{- end }}
+			}
+			{{- if eq .Type "Basic" }}
+			ctx, err = auther.BasicAuth(ctx, goagrpc.MetadataValue(md, {{ printf "%q" .UsernameKey }}), goagrpc.MetadataValue(md, {{ printf "%q" .PasswordKey }}), &sc)
+			{{- else if eq .Type "APIKey" }}
+			ctx, err = auther.APIKeyAuth(ctx, goagrpc.MetadataValue(md, {{ printf "%q" .CredKey }}), &sc)
+			{{- else }}
+			ctx, err = auther.{{ .Type }}Auth(ctx, goagrpc.MetadataCredential(md, {{ printf "%q" .CredKey }}), &sc)
+			{{- end }}
+			{{- if ne $sidx 0 }}
+			}
+			{{- end }}
+		{{- end }}
+		{{- if $r.RequiredScopes }}
+		if err == nil {
+			err = security.CheckScopes(ctx, []string{ {{- range $r.RequiredScopes }}{{ printf "%q" . }}, {{ end }} })
+		}
+		{{- end }}
+		{{- if ne $ridx 0 }}
+		}
+		{{- end }}
+	{{- end }}
+{{- end }}
+	}
+	return ctx, err
+}
+`
+
 // input: EndpointData
 const handlerInitT = `{{ printf "New%sHandler creates a gRPC handler which serves the %q service %q endpoint." .Method.VarName .ServiceName .Method.Name | comment }}
 func New{{ .Method.VarName }}Handler(endpoint goa.Endpoint, h goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler) goagrpc.{{ if .ServerStream }}Stream{{ else }}Unary{{ end }}Handler {
@@ -269,7 +399,15 @@ func (s *{{ .ServerStruct }}) {{ .Method.VarName }}(
 					var er {{ .Response.ServerConvert.SrcRef }}
 					errors.As(err, &er)
 				{{- end }}
+				{{- if and .Response.ServerConvert .Response.ServerConvert.Init.ReturnsError }}
+					details, cerr := {{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }})
+					if cerr != nil {
+						return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.EncodeError(cerr)
+					}
+					return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.NewStatusError({{ .Response.StatusCode }}, err, details)
+				{{- else }}
 				return {{ if not $.ServerStream }}nil, {{ end }}goagrpc.NewStatusError({{ .Response.StatusCode }}, err, {{ if .Response.ServerConvert }}{{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }}){{ else }}goagrpc.NewErrorResponse(err){{ end }})
+				{{- end }}
 		{{- end }}
 			}
 		}
@@ -408,7 +546,14 @@ func Encode{{ .Method.VarName }}Response(ctx context.Context, v interface{}, hdr
 		return nil, goagrpc.ErrInvalidType("{{ .ServiceName }}", "{{ .Method.Name }}", "{{ .ResultRef }}", v)
 	}
 {{- end }}
+{{- if .Response.ServerConvert.Init.ReturnsError }}
+	resp, err := {{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }})
+	if err != nil {
+		return nil, err
+	}
+{{- else }}
 	resp := {{ .Response.ServerConvert.Init.Name }}({{ range .Response.ServerConvert.Init.Args }}{{ .Name }}, {{ end }})
+{{- end }}
 {{- range .Response.Headers }}
 	{{ template "metadata_encoder" (metadataEncodeDecodeData . "(*hdr)") }}
 {{- end }}
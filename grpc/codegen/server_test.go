@@ -25,6 +25,7 @@ func TestServerGRPCInterface(t *testing.T) {
 		{"bidirectional-streaming-rpc", testdata.BidirectionalStreamingRPCDSL, testdata.BidirectionalStreamingRPCServerInterfaceCode},
 		{"bidirectional-streaming-rpc-with-payload", testdata.BidirectionalStreamingRPCWithPayloadDSL, testdata.BidirectionalStreamingRPCWithPayloadServerInterfaceCode},
 		{"bidirectional-streaming-rpc-with-errors", testdata.BidirectionalStreamingRPCWithErrorsDSL, testdata.BidirectionalStreamingRPCWithErrorsServerInterfaceCode},
+		{"unary-rpc-with-well-known-types-error", testdata.WellKnownTypesDSL, testdata.WellKnownTypesErrorServerInterfaceCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -128,6 +129,7 @@ func TestResponseEncoder(t *testing.T) {
 		{"response-encoder-result-with-metadata", testdata.MessageWithMetadataDSL, testdata.ResultWithMetadataResponseEncoderCode},
 		{"response-encoder-result-with-validate", testdata.MessageWithValidateDSL, testdata.ResultWithValidateResponseEncoderCode},
 		{"response-encoder-result-collection", testdata.MessageResultTypeCollectionDSL, testdata.ResultCollectionResponseEncoderCode},
+		{"response-encoder-result-with-well-known-types", testdata.WellKnownTypesDSL, testdata.ResultWithWellKnownTypesResponseEncoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -147,3 +149,30 @@ func TestResponseEncoder(t *testing.T) {
 		})
 	}
 }
+
+func TestServerAuth(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"secure-service", testdata.SecureServiceDSL, testdata.SecureServiceServerAuthCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunGRPCDSL(t, c.DSL)
+			fs := ServerFiles("", expr.Root)
+			if len(fs) != 3 {
+				t.Fatalf("got %d files, expected three", len(fs))
+			}
+			sections := fs[2].Section("server-auth-interceptors")
+			if len(sections) == 0 {
+				t.Fatalf("got zero sections, expected at least one")
+			}
+			code := codegen.SectionsCode(t, sections)
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs. expected:\n%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
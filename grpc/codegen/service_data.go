@@ -52,6 +52,9 @@ type (
 		// ClientInterfaceInit is the name of the client constructor function in
 		// the generated pb.go package.
 		ClientInterfaceInit string
+		// AuthEndpoints lists the endpoints authenticated by the generated
+		// server interceptors.
+		AuthEndpoints []*AuthEndpointData
 		// Scope is the name scope for protocol buffers
 		Scope *codegen.NameScope
 
@@ -94,6 +97,9 @@ type (
 		MessageSchemes service.SchemesData
 		// Errors describes the method gRPC errors.
 		Errors []*ErrorData
+		// HTTPRule describes the google.api.http annotation of the
+		// method if the API enables HTTP transcoding.
+		HTTPRule *HTTPRuleData
 
 		// server side
 
@@ -118,6 +124,70 @@ type (
 		ClientStream *StreamData
 	}
 
+	// HTTPRuleData describes the google.api.http annotation used by
+	// grpc-gateway to transcode the HTTP requests made to a method.
+	HTTPRuleData struct {
+		// Bindings lists the HTTP routes of the method, the first binding
+		// is the main one and the others are additional bindings.
+		Bindings []*HTTPBindingData
+		// Body is the name of the request message field mapped to the
+		// HTTP request body, "*" if all the fields not bound by the path
+		// are mapped to the body and empty if there is no request body.
+		Body string
+		// ResponseBody is the name of the response message field mapped
+		// to the HTTP response body, empty if the whole message is.
+		ResponseBody string
+	}
+
+	// HTTPBindingData describes a HTTP route of a google.api.http
+	// annotation.
+	HTTPBindingData struct {
+		// Verb is the lower case HTTP method for the verbs that the
+		// annotation supports directly (e.g. "get"), empty otherwise.
+		Verb string
+		// Kind is the HTTP method for the other verbs (e.g. "HEAD").
+		Kind string
+		// Path is the path template where the path parameters refer
+		// to the request message fields.
+		Path string
+	}
+
+	// AuthEndpointData contains the data used to generate the code that
+	// authenticates the requests made to a secure endpoint in the server
+	// interceptors.
+	AuthEndpointData struct {
+		// FullMethodName is the full name of the gRPC method as given to
+		// the server interceptors (e.g. "/pkg.Service/Method").
+		FullMethodName string
+		// Requirements lists the endpoint security requirements.
+		Requirements []*AuthRequirementData
+	}
+
+	// AuthRequirementData describes a security requirement authenticated by
+	// the server interceptors.
+	AuthRequirementData struct {
+		// Schemes lists the requirement schemes.
+		Schemes []*AuthSchemeData
+		// Scopes lists the scopes required by the requirement schemes.
+		Scopes []string
+		// RequiredScopes lists the scopes checked once the request is
+		// authenticated.
+		RequiredScopes []string
+	}
+
+	// AuthSchemeData describes a security scheme whose credentials are read
+	// from the request metadata by the server interceptors.
+	AuthSchemeData struct {
+		*service.SchemeData
+		// CredKey is the metadata key holding the API key, the JWT token or
+		// the OAuth2 access token.
+		CredKey string
+		// UsernameKey is the metadata key holding the basic auth username.
+		UsernameKey string
+		// PasswordKey is the metadata key holding the basic auth password.
+		PasswordKey string
+	}
+
 	// MetadataData describes a gRPC metadata field.
 	MetadataData struct {
 		// Name is the name of the metadata key.
@@ -278,6 +348,11 @@ type (
 		ReturnTypePkg string
 		// ReturnIsStruct is true if the return type is a struct.
 		ReturnIsStruct bool
+		// ReturnsError is true if the constructor returns an error in
+		// addition to the initialized value. This is the case when
+		// converting to protocol buffer well-known types which fails on
+		// invalid input.
+		ReturnsError bool
 		// Code is the transformation code.
 		Code string
 	}
@@ -353,6 +428,12 @@ type (
 		// MustClose indicates whether to generate the Close() function
 		// for the stream.
 		MustClose bool
+		// CloseAndRecv indicates whether to generate the CloseAndRecv()
+		// function for the stream.
+		CloseAndRecv bool
+		// Trailers contains the data needed to render the SetTrailers
+		// function of server streams if the method defines trailers.
+		Trailers *service.TrailersData
 	}
 
 	// validateKind is a type to determine where the validation code is generated
@@ -638,14 +719,136 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 			ClientInterface:  sd.ClientInterface,
 		}
 		sd.Endpoints = append(sd.Endpoints, ed)
+		if ad := buildAuthEndpointData(gs, sd, ed); ad != nil {
+			sd.AuthEndpoints = append(sd.AuthEndpoints, ad)
+		}
 		if e.MethodExpr.IsStreaming() {
 			ed.ServerStream = buildStreamData(e, sd, true)
 			ed.ClientStream = buildStreamData(e, sd, false)
 		}
+		if transcodeHTTP() {
+			ed.HTTPRule = buildHTTPRuleData(e)
+			if _, ok := imported[annotationsProto]; ed.HTTPRule != nil && !ok {
+				imported[annotationsProto] = struct{}{}
+				sd.ProtoImports = append(sd.ProtoImports, annotationsProto)
+			}
+		}
 	}
 	return sd
 }
 
+// annotationsProto is the proto file defining the google.api.http option.
+const annotationsProto = "google/api/annotations.proto"
+
+// transcodeHTTP returns true if the API enables the generation of the
+// google.api.http annotations with the "grpc:http:transcode" meta.
+func transcodeHTTP() bool {
+	t, _ := expr.Root.API.Meta.Last("grpc:http:transcode")
+	return t == "true"
+}
+
+// buildHTTPRuleData returns the data used to render the google.api.http
+// annotation of the given endpoint. It returns nil if the method does not
+// define a HTTP endpoint or if it streams its payload as grpc-gateway does not
+// transcode client streams.
+func buildHTTPRuleData(e *expr.GRPCEndpointExpr) *HTTPRuleData {
+	if e.MethodExpr.IsPayloadStreaming() {
+		return nil
+	}
+	hs := expr.Root.API.HTTP.Service(e.Service.Name())
+	if hs == nil {
+		return nil
+	}
+	he := hs.Endpoint(e.Name())
+	if he == nil {
+		return nil
+	}
+	// fieldName returns the name of the request message field corresponding
+	// to the given payload attribute.
+	fieldName := func(att string) string {
+		if !expr.IsObject(e.MethodExpr.Payload.Type) {
+			return "field"
+		}
+		return codegen.SnakeCase(protoBufify(att, false, false))
+	}
+	rule := &HTTPRuleData{}
+	for _, r := range he.Routes {
+		for _, p := range r.FullPaths() {
+			path := expr.HTTPWildcardRegex.ReplaceAllStringFunc(p, func(w string) string {
+				name := expr.HTTPWildcardRegex.FindStringSubmatch(w)[1]
+				field := fieldName(he.Params.KeyName(name))
+				if strings.HasPrefix(w, "/{*") {
+					return "/{" + field + "=**}"
+				}
+				return "/{" + field + "}"
+			})
+			b := &HTTPBindingData{Path: path}
+			switch r.Method {
+			case "GET", "PUT", "POST", "DELETE", "PATCH":
+				b.Verb = strings.ToLower(r.Method)
+			default:
+				b.Kind = r.Method
+			}
+			rule.Bindings = append(rule.Bindings, b)
+		}
+	}
+	if he.Body != nil && he.Body.Type != expr.Empty {
+		rule.Body = "*"
+		if o, ok := he.Body.Meta["origin:attribute"]; ok {
+			rule.Body = fieldName(o[0])
+		}
+	}
+	if len(he.Responses) > 0 && he.Responses[0].Body != nil && expr.IsObject(e.MethodExpr.Result.Type) {
+		if o, ok := he.Responses[0].Body.Meta["origin:attribute"]; ok {
+			rule.ResponseBody = codegen.SnakeCase(protoBufify(o[0], false, false))
+		}
+	}
+	return rule
+}
+
+// buildAuthEndpointData returns the data used to authenticate the requests
+// made to the given endpoint in the server interceptors. It returns nil if the
+// endpoint is not secure or if the credentials of any of the endpoint security
+// schemes are read from the request message.
+func buildAuthEndpointData(gs *expr.GRPCServiceExpr, sd *ServiceData, ed *EndpointData) *AuthEndpointData {
+	if len(ed.Method.Requirements) == 0 {
+		return nil
+	}
+	keys := make(map[string]string, len(ed.Request.Metadata))
+	for _, m := range ed.Request.Metadata {
+		keys[m.AttributeName] = m.Name
+	}
+	reqs := make([]*AuthRequirementData, 0, len(ed.Method.Requirements))
+	for _, r := range ed.Method.Requirements {
+		schemes := make([]*AuthSchemeData, 0, len(r.Schemes))
+		for _, sch := range r.Schemes {
+			s := &AuthSchemeData{SchemeData: sch}
+			if sch.Type == "Basic" {
+				s.UsernameKey = keys[sch.UsernameAttr]
+				s.PasswordKey = keys[sch.PasswordAttr]
+				if s.UsernameKey == "" || s.PasswordKey == "" {
+					return nil
+				}
+			} else {
+				s.CredKey = keys[sch.KeyAttr]
+				if s.CredKey == "" {
+					return nil
+				}
+			}
+			schemes = append(schemes, s)
+		}
+		reqs = append(reqs, &AuthRequirementData{
+			Schemes:        schemes,
+			Scopes:         r.Scopes,
+			RequiredScopes: r.RequiredScopes,
+		})
+	}
+	return &AuthEndpointData{
+		FullMethodName: fmt.Sprintf("/%s.%s/%s", pkgName(gs, sd.Service.PathName), sd.Name, ed.Method.VarName),
+		Requirements:   reqs,
+	}
+}
+
 // collectMessages recurses through the attribute to gather all the messages.
 func collectMessages(at *expr.AttributeExpr, sd *ServiceData, seen map[string]struct{}) (data []*service.UserTypeData, imports []string) {
 	if at == nil {
@@ -1005,6 +1208,7 @@ func buildInitData(source, target *expr.AttributeExpr, sourceVar, targetVar stri
 		name     string
 		isStruct bool
 		code     string
+		fallible bool
 		helpers  []*codegen.TransformFunctionData
 		args     []*InitArgData
 		err      error
@@ -1034,7 +1238,7 @@ func buildInitData(source, target *expr.AttributeExpr, sourceVar, targetVar stri
 			n = protoBufGoTypeName(source, sd.Scope)
 		}
 		name += n
-		code, helpers, err = protoBufTransform(source, target, sourceVar, targetVar, srcCtx, tgtCtx, proto, true)
+		code, helpers, fallible, err = protoBufTransform(source, target, sourceVar, targetVar, srcCtx, tgtCtx, proto, true)
 		if err != nil {
 			panic(err) // bug
 		}
@@ -1055,6 +1259,7 @@ func buildInitData(source, target *expr.AttributeExpr, sourceVar, targetVar stri
 		ReturnTypeRef:  tgtCtx.Scope.Ref(target, tgtCtx.Pkg(target)),
 		ReturnIsStruct: isStruct,
 		ReturnTypePkg:  tgtCtx.Pkg(target),
+		ReturnsError:   fallible,
 		Code:           code,
 		Args:           args,
 	}
@@ -1154,6 +1359,8 @@ func buildStreamData(e *expr.GRPCEndpointExpr, sd *ServiceData, svr bool) *Strea
 		recvRef     string
 		recvConvert *ConvertData
 		mustClose   bool
+		closeRecv   bool
+		trailers    *service.TrailersData
 		typ         string
 
 		svc            = sd.Service
@@ -1196,6 +1403,7 @@ func buildStreamData(e *expr.GRPCEndpointExpr, sd *ServiceData, svr bool) *Strea
 				}
 			}
 			mustClose = md.ServerStream.MustClose
+			trailers = md.Trailers
 		} else {
 			typ = "client"
 			varn = md.ClientStream.VarName
@@ -1225,6 +1433,7 @@ func buildStreamData(e *expr.GRPCEndpointExpr, sd *ServiceData, svr bool) *Strea
 				}
 			}
 			mustClose = md.ClientStream.MustClose
+			closeRecv = md.ClientStream.CloseAndRecv
 		}
 		if sendConvert != nil {
 			sendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint gRPC stream.", sendName, sendConvert.TgtName, md.Name)
@@ -1248,6 +1457,8 @@ func buildStreamData(e *expr.GRPCEndpointExpr, sd *ServiceData, svr bool) *Strea
 		RecvRef:          recvRef,
 		RecvConvert:      recvConvert,
 		MustClose:        mustClose,
+		CloseAndRecv:     closeRecv,
+		Trailers:         trailers,
 	}
 }
 
@@ -1371,7 +1582,7 @@ func isEmpty(dt expr.DataType) bool {
 
 // input: InitData
 const typeInitT = `{{ comment .Description }}
-func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ .ReturnTypeRef }} {
+func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ if .ReturnsError }}({{ .ReturnTypeRef }}, error){{ else }}{{ .ReturnTypeRef }}{{ end }} {
 	{{ .Code }}
 {{- if .ReturnIsStruct }}
 	{{- range .Args }}
@@ -1380,7 +1591,7 @@ func {{ .Name }}({{ range .Args }}{{ .Name }} {{ .TypeRef }}, {{ end }}) {{ .Ret
 		{{- end }}
 	{{- end }}
 {{- end }}
-	return {{ .ReturnVarName }}
+	return {{ .ReturnVarName }}{{ if .ReturnsError }}, nil{{ end }}
 }
 `
 
@@ -1416,8 +1627,15 @@ func (s *{{ .VarName }}) {{ .SendName }}(res {{ .SendRef }}) error {
 		vres := {{ .Endpoint.ServicePkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(res, s.view)
 	{{- end }}
 {{- end }}
+{{- if .SendConvert.Init.ReturnsError }}
+	v, err := {{ .SendConvert.Init.Name }}({{ if and .Endpoint.Method.ViewedResult (eq .Type "server") }}vres.Projected{{ else }}res{{ end }})
+	if err != nil {
+		return err
+	}
+{{- else }}
 	v := {{ .SendConvert.Init.Name }}({{ if and .Endpoint.Method.ViewedResult (eq .Type "server") }}vres.Projected{{ else }}res{{ end }})
-	return s.stream.{{ .SendName }}(v)
+{{- end }}
+	return goagrpc.StreamError(s.stream.{{ .SendName }}(v))
 }
 `
 
@@ -1429,7 +1647,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvRef }}, error) {
 	var res {{ .RecvRef }}
 	v, err := s.stream.{{ .RecvName }}()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 {{- if and .Endpoint.Method.ViewedResult (eq .Type "client") }}
 	proj := {{ .RecvConvert.Init.Name }}({{ range .RecvConvert.Init.Args }}{{ .Name }}, {{ end }})
@@ -1475,6 +1693,34 @@ func (s *{{ .VarName }}) Close() error {
 }
 `
 
+// streamCloseAndRecvT renders the function implementing the CloseAndRecv
+// method in client bidirectional stream interface.
+// input: StreamData
+const streamCloseAndRecvT = `{{ printf "CloseAndRecv stops sending messages to the %q endpoint gRPC stream and reads the next instance of %q from the stream." .Endpoint.Method.Name .RecvConvert.SrcName | comment }}
+func (s *{{ .VarName }}) CloseAndRecv() ({{ .RecvRef }}, error) {
+	if err := s.stream.CloseSend(); err != nil {
+		var res {{ .RecvRef }}
+		return res, goagrpc.StreamError(err)
+	}
+	return s.{{ .RecvName }}()
+}
+`
+
+// streamSetTrailersT renders the function implementing the SetTrailers method
+// in server stream interface.
+// input: StreamData
+const streamSetTrailersT = `{{ printf "SetTrailers sets the trailers sent in the trailer metadata once the results have been streamed." | comment }}
+func (s *{{ .VarName }}) SetTrailers(t *{{ .Endpoint.ServicePkgName }}.{{ .Trailers.VarName }}) {
+	md := metadata.MD{}
+	{{- range .Trailers.Fields }}
+	if t.{{ .FieldName }} != nil {
+		md.Set({{ printf "%q" .Name }}, {{ if .IsString }}*t.{{ .FieldName }}{{ else }}fmt.Sprint(*t.{{ .FieldName }}){{ end }})
+	}
+	{{- end }}
+	s.stream.SetTrailer(md)
+}
+`
+
 // streamSetViewT renders the function implementing the SetView method in
 // server stream interface.
 // input: StreamData
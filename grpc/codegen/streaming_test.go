@@ -38,6 +38,7 @@ func TestStreaming(t *testing.T) {
 			{"server-stream-send", &testdata.ServerStreamingServerSendCode},
 			{"server-stream-close", &testdata.ServerStreamingServerCloseCode},
 			{"server-stream-set-view", nil},
+			{"server-stream-set-trailers", nil},
 			{"client-stream-struct-type", &testdata.ServerStreamingClientStructCode},
 			{"client-stream-recv", &testdata.ServerStreamingClientRecvCode},
 		}},
@@ -55,6 +56,9 @@ func TestStreaming(t *testing.T) {
 			{"client-stream-recv", &testdata.ServerStreamingResultCollectionWithExplicitViewClientRecvCode},
 			{"client-stream-set-view", nil},
 		}},
+		{"server-streaming-trailers", testdata.ServerStreamingTrailersDSL, []*sectionExpectation{
+			{"server-stream-set-trailers", &testdata.ServerStreamingTrailersServerSetTrailersCode},
+		}},
 		{"server-streaming-primitive", testdata.ServerStreamingRPCDSL, []*sectionExpectation{
 			{"server-stream-send", &testdata.ServerStreamingPrimitiveServerSendCode},
 			{"client-stream-recv", &testdata.ServerStreamingPrimitiveClientRecvCode},
@@ -99,6 +103,11 @@ func TestStreaming(t *testing.T) {
 			{"client-stream-send", &testdata.BidirectionalStreamingClientSendCode},
 			{"client-stream-recv", &testdata.BidirectionalStreamingClientRecvCode},
 			{"client-stream-close", &testdata.BidirectionalStreamingClientCloseCode},
+			{"client-stream-close-and-recv", &testdata.BidirectionalStreamingClientCloseAndRecvCode},
+		}},
+		{"bidirectional-streaming-well-known-types", testdata.WellKnownTypesStreamingDSL, []*sectionExpectation{
+			{"server-stream-send", &testdata.WellKnownTypesStreamingServerSendCode},
+			{"client-stream-send", &testdata.WellKnownTypesStreamingClientSendCode},
 		}},
 	}
 
@@ -253,3 +253,26 @@ func (c *Client) MethodBidirectionalStreamingRPCWithErrors() goa.Endpoint {
 	}
 }
 `
+
+const ClientPoolInitCode = `// PoolSize is the number of connections of the pools created with DialPool as
+// defined in the design.
+const PoolSize = 4
+
+// DialPool creates a pool of PoolSize client connections to target with the
+// given dial options. Use NewPooledClient to create a client that
+// distributes the requests across the connections of the pool. Closing the
+// pool closes all its connections.
+func DialPool(target string, opts ...grpc.DialOption) (*goagrpc.ClientConnPool, error) {
+	return goagrpc.DialClientConnPool(target, PoolSize, opts...)
+}
+
+// NewPooledClient instantiates gRPC client for all the ServiceClientPool
+// service servers that distributes the requests in round-robin order across
+// the connections of pool, skipping the connections in failure.
+func NewPooledClient(pool *goagrpc.ClientConnPool, opts ...grpc.CallOption) *Client {
+	return &Client{
+		grpccli: service_client_poolpb.NewServiceClientPoolClient(pool),
+		opts:    opts,
+	}
+}
+`
@@ -392,3 +392,136 @@ func NewProtoMethodRequest(payload *defaultfields.MethodPayload) *default_fields
 	return message
 }
 `
+
+const WellKnownTypesClientTypeCode = `// NewProtoMethodRequest builds the gRPC request type from the payload of the
+// "Method" endpoint of the "WellKnownTypes" service.
+func NewProtoMethodRequest(payload *wellknowntypes.MethodPayload) (*well_known_typespb.MethodRequest, error) {
+	var err error
+	message := &well_known_typespb.MethodRequest{
+		Name: payload.Name,
+	}
+	if message.Created, err = goagrpc.NewTimestamp(payload.Created); err != nil {
+		return nil, err
+	}
+	if payload.Updated != nil {
+		if message.Updated, err = goagrpc.NewTimestamp(*payload.Updated); err != nil {
+			return nil, err
+		}
+	}
+	if payload.Timeout != nil {
+		if message.Timeout, err = goagrpc.NewDuration(*payload.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	if payload.History != nil {
+		message.History = make([]*timestamppb.Timestamp, len(payload.History))
+		for i, val := range payload.History {
+			if message.History[i], err = goagrpc.NewTimestamp(val); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if payload.Window != nil {
+		if message.Window, err = svcWellknowntypesWindowToWellKnownTypespbWindow(payload.Window); err != nil {
+			return nil, err
+		}
+	}
+	if payload.Timeouts != nil {
+		message.Timeouts = make(map[string]*durationpb.Duration, len(payload.Timeouts))
+		for key, val := range payload.Timeouts {
+			tk := key
+			var tv *durationpb.Duration
+			if tv, err = goagrpc.NewDuration(val); err != nil {
+				return nil, err
+			}
+			message.Timeouts[tk] = tv
+		}
+	}
+	return message, nil
+}
+
+// NewMethodResult builds the result type of the "Method" endpoint of the
+// "WellKnownTypes" service from the gRPC response type.
+func NewMethodResult(message *well_known_typespb.MethodResponse) *wellknowntypes.MethodResult {
+	result := &wellknowntypes.MethodResult{
+		At: goagrpc.FormatTimestamp(message.At),
+	}
+	if message.Elapsed != nil {
+		elapsed := goagrpc.FormatDuration(message.Elapsed)
+		result.Elapsed = &elapsed
+	}
+	return result
+}
+
+// NewMethodExpiredError builds the error type of the "Method" endpoint of the
+// "WellKnownTypes" service from the gRPC error response type.
+func NewMethodExpiredError(message *well_known_typespb.MethodExpiredError) *wellknowntypes.Expired {
+	er := &wellknowntypes.Expired{
+		Since: goagrpc.FormatTimestamp(message.Since),
+	}
+	return er
+}
+
+// protobufWellKnownTypespbWindowToWellknowntypesWindow builds a value of type
+// *wellknowntypes.Window from a value of type *well_known_typespb.Window.
+func protobufWellKnownTypespbWindowToWellknowntypesWindow(v *well_known_typespb.Window) *wellknowntypes.Window {
+	if v == nil {
+		return nil
+	}
+	res := &wellknowntypes.Window{
+		Start: goagrpc.FormatTimestamp(v.Start),
+	}
+	if v.Length != nil {
+		length := goagrpc.FormatDuration(v.Length)
+		res.Length = &length
+	}
+
+	return res
+}
+
+// svcWellknowntypesWindowToWellKnownTypespbWindow builds a value of type
+// *well_known_typespb.Window from a value of type *wellknowntypes.Window.
+func svcWellknowntypesWindowToWellKnownTypespbWindow(v *wellknowntypes.Window) (*well_known_typespb.Window, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var err error
+	res := &well_known_typespb.Window{}
+	if res.Start, err = goagrpc.NewTimestamp(v.Start); err != nil {
+		return nil, err
+	}
+	if v.Length != nil {
+		if res.Length, err = goagrpc.NewDuration(*v.Length); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+`
+
+const EnumMapClientTypeCode = `// NewProtoMethodRequest builds the gRPC request type from the payload of the
+// "Method" endpoint of the "EnumMap" service.
+func NewProtoMethodRequest(payload *enummap.MethodPayload) *enum_mappb.MethodRequest {
+	message := &enum_mappb.MethodRequest{
+		Kind: goagrpc.EnumNumber(payload.Kind, map[string]int32{"PERSONAL": 1, "BUSINESS": 2}),
+		Name: payload.Name,
+	}
+	if payload.Status != nil {
+		status := goagrpc.EnumNumber(string(*payload.Status), map[string]int32{"UNKNOWN": 0, "ACTIVE": 1})
+		message.Status = &status
+	}
+	level := goagrpc.EnumNumber(payload.Level, map[string]int32{"LOW": 0, "HIGH": 5})
+	message.Level = &level
+	return message
+}
+
+// NewMethodResult builds the result type of the "Method" endpoint of the
+// "EnumMap" service from the gRPC response type.
+func NewMethodResult(message *enum_mappb.MethodResponse) *enummap.MethodResult {
+	result := &enummap.MethodResult{
+		Status: enummap.Status(goagrpc.EnumName(message.Status, map[int32]string{0: "UNKNOWN", 1: "ACTIVE"}, "UNKNOWN")),
+	}
+	return result
+}
+`
@@ -146,6 +146,20 @@ var ServerStreamingRPCDSL = func() {
 	})
 }
 
+var ServerStreamingTrailersDSL = func() {
+	Service("ServiceServerStreamingTrailersRPC", func() {
+		Method("MethodServerStreamingTrailersRPC", func() {
+			StreamingResult(String)
+			GRPC(func() {
+				Response(func() {
+					Trailer("X-Total-Count", Int)
+					Trailer("X-Next", String)
+				})
+			})
+		})
+	})
+}
+
 var ServerStreamingUserTypeDSL = func() {
 	var UT = Type("UserType", func() {
 		Field(1, "IntField", Int)
@@ -371,6 +385,25 @@ var MessageUserTypeWithPrimitivesDSL = func() {
 	})
 }
 
+var MessageWithReservedDSL = func() {
+	var Nested = Type("Nested", func() {
+		Reserved("old_name")
+		Field(1, "name", String)
+	})
+	var PayloadT = Type("PayloadT", func() {
+		Reserved(2, 5)
+		Reserved("removed")
+		Field(1, "id", String)
+		Field(3, "nested", Nested)
+	})
+	Service("ServiceMessageWithReserved", func() {
+		Method("MethodMessageWithReserved", func() {
+			Payload(PayloadT)
+			GRPC(func() {})
+		})
+	})
+}
+
 var MessageUserTypeWithAliasMessageDSL = func() {
 	var IntAlias = Type("IntAlias", Int)
 	var PayloadT = Type("PayloadT", func() {
@@ -947,3 +980,237 @@ var DefaultFieldsDSL = func() {
 		})
 	})
 }
+
+var WellKnownTypesDSL = func() {
+	var Window = Type("Window", func() {
+		Field(1, "start", String, func() { Format(FormatDateTime) })
+		Field(2, "length", String, func() { Format(FormatDuration) })
+		Required("start")
+	})
+	var Expired = Type("Expired", func() {
+		Field(1, "since", String, func() { Format(FormatDateTime) })
+		Required("since")
+	})
+	API("WellKnownTypes", func() {
+		Meta("grpc:wellknown")
+	})
+	Service("WellKnownTypes", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Field(1, "created", String, func() { Format(FormatDateTime) })
+				Field(2, "updated", String, func() { Format(FormatDateTime) })
+				Field(3, "timeout", String, func() { Format(FormatDuration) })
+				Field(4, "history", ArrayOf(String, func() { Format(FormatDateTime) }))
+				Field(5, "name", String)
+				Field(6, "window", Window)
+				Field(7, "timeouts", MapOf(String, String, func() {
+					Elem(func() { Format(FormatDuration) })
+				}))
+				Required("created")
+			})
+			Result(func() {
+				Field(1, "at", String, func() { Format(FormatDateTime) })
+				Field(2, "elapsed", String, func() { Format(FormatDuration) })
+				Required("at")
+			})
+			Error("expired", Expired)
+			GRPC(func() {
+				Response("expired", CodeDeadlineExceeded)
+			})
+		})
+	})
+}
+
+var WellKnownTypesStreamingDSL = func() {
+	API("WellKnownTypes", func() {
+		Meta("grpc:wellknown")
+	})
+	Service("WellKnownTypes", func() {
+		Method("Method", func() {
+			StreamingPayload(func() {
+				Field(1, "timeout", String, func() { Format(FormatDuration) })
+			})
+			StreamingResult(func() {
+				Field(1, "at", String, func() { Format(FormatDateTime) })
+				Required("at")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var EnumMapDSL = func() {
+	var Status = Type("Status", String, func() {
+		EnumMap(func() {
+			Value("UNKNOWN", 0)
+			Value("ACTIVE", 1)
+			Fallback("UNKNOWN")
+		})
+	})
+	Service("EnumMap", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Field(1, "kind", String, func() {
+					EnumMap(func() {
+						Value("PERSONAL", 1)
+						Value("BUSINESS", 2)
+					})
+				})
+				Field(2, "status", Status)
+				Field(3, "level", String, func() {
+					EnumMap(func() {
+						Value("LOW", 0)
+						Value("HIGH", 5)
+					})
+					Default("HIGH")
+				})
+				Field(4, "name", String)
+				Required("kind")
+			})
+			Result(func() {
+				Field(1, "status", Status)
+				Required("status")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var SecureServiceDSL = func() {
+	var JWTAuth = JWTSecurity("jwt", func() {
+		Scope("api:read", "Read-only access")
+		Scope("api:write", "Read and write access")
+	})
+	var APIKeyAuth = APIKeySecurity("api_key", func() {})
+	var BasicAuth = BasicAuthSecurity("basic", func() {})
+	Service("SecureService", func() {
+		Method("SecureMethod", func() {
+			Security(JWTAuth, func() {
+				Scope("api:write")
+			})
+			Security(APIKeyAuth)
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+				Field(1, "id", String)
+			})
+			GRPC(func() {
+				Metadata(func() {
+					Attribute("key:api-key")
+				})
+			})
+		})
+		Method("BasicMethod", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+			GRPC(func() {})
+		})
+		Method("UnsecureMethod", func() {
+			NoSecurity()
+			GRPC(func() {})
+		})
+	})
+}
+
+var HTTPTranscodeDSL = func() {
+	API("Transcode", func() {
+		Meta("grpc:http:transcode", "true")
+	})
+	Service("TranscodedService", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Field(1, "bookID", String)
+				Field(2, "view", String)
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/books/{bookID}")
+				GET("/shelves/{*bookID}")
+				Param("view")
+			})
+			GRPC(func() {})
+		})
+		Method("Create", func() {
+			Payload(func() {
+				Field(1, "shelf", Int)
+				Field(2, "title", String)
+			})
+			HTTP(func() {
+				POST("/shelves/{shelf}/books")
+			})
+			GRPC(func() {})
+		})
+		Method("Update", func() {
+			Payload(func() {
+				Field(1, "id", String)
+				Field(2, "book", func() {
+					Field(1, "title", String)
+				})
+			})
+			Result(func() {
+				Field(1, "book", func() {
+					Field(1, "title", String)
+				})
+			})
+			HTTP(func() {
+				PATCH("/books/{id}")
+				Body("book")
+				Response(func() {
+					Body("book")
+				})
+			})
+			GRPC(func() {})
+		})
+		Method("Check", func() {
+			Payload(String)
+			HTTP(func() {
+				HEAD("/books/{id}")
+			})
+			GRPC(func() {})
+		})
+		Method("Upload", func() {
+			StreamingPayload(String)
+			HTTP(func() {
+				GET("/upload")
+			})
+			GRPC(func() {})
+		})
+		Method("Ping", func() {
+			GRPC(func() {})
+		})
+	})
+}
+
+var HealthDSL = func() {
+	API("HealthAPI", func() {
+		Meta("grpc:health", "true")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			GRPC(func() {})
+		})
+	})
+	Service("AnotherService", func() {
+		Meta("struct:pkg:name", "anothersvc")
+		Method("Method", func() {
+			GRPC(func() {})
+		})
+		GRPC(func() {
+			Package("another.v1")
+		})
+	})
+}
+
+var ClientPoolDSL = func() {
+	API("PoolAPI", func() {
+		Meta("grpc:client:pool", "4")
+	})
+	Service("ServiceClientPool", func() {
+		Method("MethodClientPool", func() {
+			GRPC(func() {})
+		})
+	})
+}
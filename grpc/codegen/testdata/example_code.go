@@ -250,3 +250,78 @@ const ExampleCLICode = `func doGRPC(scheme, host string, timeout int, debug bool
 	return cli.ParseEndpoint(conn)
 }
 `
+
+const HealthServerHandleCode = `// handleGRPCServer starts configures and starts a gRPC server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleGRPCServer(ctx context.Context, u *url.URL, serviceEndpoints *service.Endpoints, anotherServiceEndpoints *anothersvc.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to gRPC requests and
+	// responses.
+	var (
+		serviceServer        *servicesvr.Server
+		anotherServiceServer *anothersvcsvr.Server
+	)
+	{
+		serviceServer = servicesvr.New(serviceEndpoints, nil)
+		anotherServiceServer = anothersvcsvr.New(anotherServiceEndpoints, nil)
+	}
+
+	// Initialize gRPC server with the middleware.
+	srv := grpc.NewServer(
+		grpcmiddleware.WithUnaryServerChain(
+			grpcmdlwr.UnaryRequestID(),
+			grpcmdlwr.UnaryServerLog(adapter),
+		),
+	)
+
+	// Register the servers.
+	servicepb.RegisterServiceServer(srv, serviceServer)
+	another_servicepb.RegisterAnotherServiceServer(srv, anotherServiceServer)
+
+	for svc, info := range srv.GetServiceInfo() {
+		for _, m := range info.Methods {
+			logger.Printf("serving gRPC method %s", svc+"/"+m.Name)
+		}
+	}
+
+	// Register the server reflection service on the server.
+	// See https://grpc.github.io/grpc/core/md_doc_server-reflection.html.
+	reflection.Register(srv)
+
+	// Register the gRPC health checking service on the server. All the
+	// services are reported as SERVING, use healthSvr.SetServingStatus to
+	// update their status.
+	// See https://github.com/grpc/grpc/blob/master/doc/health-checking.md.
+	healthSvr := health.NewServer()
+	health.Register(srv, healthSvr)
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start gRPC server in a separate goroutine.
+		go func() {
+			lis, err := net.Listen("tcp", u.Host)
+			if err != nil {
+				errc <- err
+			}
+			logger.Printf("gRPC server listening on %q", u.Host)
+			errc <- srv.Serve(lis)
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down gRPC server at %q", u.Host)
+		srv.Stop()
+	}()
+}
+`
@@ -0,0 +1,73 @@
+package testdata
+
+const HealthCode = `const (
+	// AnotherServiceService is the full name of the AnotherService gRPC service
+	// used to set and check its health status.
+	AnotherServiceService = "another.v1.AnotherService"
+	// ServiceService is the full name of the Service gRPC service used to set and
+	// check its health status.
+	ServiceService = "service.Service"
+)
+
+// Services lists the full names of the gRPC services reported by the health
+// service.
+var Services = []string{AnotherServiceService, ServiceService}
+
+// StatusFunc returns the serving status of the gRPC service with the given full
+// name. The empty name designates the server as a whole.
+type StatusFunc func(ctx context.Context, service string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error)
+
+// HealthServer implements the grpc.health.v1.Health service by delegating the
+// health checks to a StatusFunc. The Watch method is not implemented.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	status StatusFunc
+}
+
+// NewServer returns a health server that reports the server as a whole and all
+// the API gRPC services as SERVING. Use SetServingStatus to update the status
+// of a service, for example:
+//
+//	srv.SetServingStatus(AnotherServiceService, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+func NewServer() *grpchealth.Server {
+	srv := grpchealth.NewServer()
+	for _, svc := range Services {
+		srv.SetServingStatus(svc, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
+	return srv
+}
+
+// NewHealthServer returns a health server that calls fn to compute the
+// serving status of the server and of the API gRPC services.
+func NewHealthServer(fn StatusFunc) *HealthServer {
+	return &HealthServer{status: fn}
+}
+
+// Register registers hs as the grpc.health.v1.Health service of srv. hs is
+// typically the server returned by NewServer or NewHealthServer.
+func Register(srv *grpc.Server, hs grpc_health_v1.HealthServer) {
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+}
+
+// Check returns the serving status of the requested service. It returns a
+// NotFound error if the service is not one of the API gRPC services.
+func (s *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if svc := req.GetService(); svc != "" {
+		found := false
+		for _, n := range Services {
+			if n == svc {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", svc)
+		}
+	}
+	st, err := s.status(ctx, req.GetService())
+	if err != nil {
+		return nil, err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: st}, nil
+}
+`
@@ -504,3 +504,70 @@ message MethodRequest {
 message MethodResponse {
 }
 `
+
+const HTTPTranscodeServiceCode = `
+syntax = "proto3";
+
+package transcoded_service;
+
+option go_package = "/transcoded_servicepb";
+import "google/api/annotations.proto";
+
+// Service is the TranscodedService service interface.
+service TranscodedService {
+	// Show implements Show.
+	rpc Show (ShowRequest) returns (ShowResponse) {
+		option (google.api.http) = {
+			get: "/books/{book_id}"
+			additional_bindings {
+				get: "/shelves/{book_id=**}"
+			}
+		};
+	}
+	// Create implements Create.
+	rpc Create (CreateRequest) returns (CreateResponse) {
+		option (google.api.http) = {
+			post: "/shelves/{shelf}/books"
+			body: "*"
+		};
+	}
+	// Update implements Update.
+	rpc Update (UpdateRequest) returns (UpdateResponse) {
+		option (google.api.http) = {
+			patch: "/books/{id}"
+			body: "book"
+			response_body: "book"
+		};
+	}
+	// Check implements Check.
+	rpc Check (CheckRequest) returns (CheckResponse) {
+		option (google.api.http) = {
+			custom {
+				kind: "HEAD"
+				path: "/books/{field}"
+			}
+		};
+	}
+	// Upload implements Upload.
+	rpc Upload (stream UploadStreamingRequest) returns (UploadResponse);
+	// Ping implements Ping.
+	rpc Ping (PingRequest) returns (PingResponse);
+}
+`
+
+const MessageWithReservedCode = `
+message MethodMessageWithReservedRequest {
+	reserved 2, 5;
+	reserved "removed";
+	optional string id = 1;
+	Nested nested = 3;
+}
+
+message Nested {
+	reserved "old_name";
+	optional string name = 1;
+}
+
+message MethodMessageWithReservedResponse {
+}
+`
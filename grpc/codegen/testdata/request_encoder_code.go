@@ -126,3 +126,13 @@ func EncodeMethodMessageWithSecurityRequest(ctx context.Context, v interface{},
 	return NewProtoMethodMessageWithSecurityRequest(payload), nil
 }
 `
+
+const PayloadWithWellKnownTypesRequestEncoderCode = `// EncodeMethodRequest encodes requests sent to WellKnownTypes Method endpoint.
+func EncodeMethodRequest(ctx context.Context, v interface{}, md *metadata.MD) (interface{}, error) {
+	payload, ok := v.(*wellknowntypes.MethodPayload)
+	if !ok {
+		return nil, goagrpc.ErrInvalidType("WellKnownTypes", "Method", "*wellknowntypes.MethodPayload", v)
+	}
+	return NewProtoMethodRequest(payload)
+}
+`
@@ -116,3 +116,18 @@ func EncodeMethodMessageUserTypeWithNestedUserTypesResponse(ctx context.Context,
 	return resp, nil
 }
 `
+
+const ResultWithWellKnownTypesResponseEncoderCode = `// EncodeMethodResponse encodes responses from the "WellKnownTypes" service
+// "Method" endpoint.
+func EncodeMethodResponse(ctx context.Context, v interface{}, hdr, trlr *metadata.MD) (interface{}, error) {
+	result, ok := v.(*wellknowntypes.MethodResult)
+	if !ok {
+		return nil, goagrpc.ErrInvalidType("WellKnownTypes", "Method", "*wellknowntypes.MethodResult", v)
+	}
+	resp, err := NewProtoMethodResponse(result)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+`
@@ -0,0 +1,65 @@
+package testdata
+
+const SecureServiceServerAuthCode = `// NewUnaryAuthInterceptor returns a gRPC unary server interceptor that
+// authenticates the requests made to the "SecureService" service methods using
+// the given authorization functions. The credentials are read from the request
+// metadata. The interceptor returns an Unauthenticated status error if the
+// authentication fails and a PermissionDenied status error if the request is
+// not granted the required scopes. The context returned by the authorization
+// functions is given to the handler.
+func NewUnaryAuthInterceptor(auther secureservice.Auther) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, info.FullMethod, auther)
+		if err != nil {
+			return nil, goagrpc.EncodeAuthError(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewStreamAuthInterceptor returns a gRPC stream server interceptor that
+// authenticates the streams opened with the "SecureService" service methods
+// using the given authorization functions. See NewUnaryAuthInterceptor.
+func NewStreamAuthInterceptor(auther secureservice.Auther) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod, auther)
+		if err != nil {
+			return goagrpc.EncodeAuthError(err)
+		}
+		return handler(srv, grpcm.NewWrappedServerStream(ctx, ss))
+	}
+}
+
+// authenticate runs the authorization functions of the given gRPC method with
+// the credentials read from the request metadata and returns the resulting
+// context. The requests made to the other methods are not authenticated.
+func authenticate(ctx context.Context, method string, auther secureservice.Auther) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var err error
+	switch method {
+	case "/secure_service.SecureService/SecureMethod":
+		sc := security.JWTScheme{
+			Name:           "jwt",
+			Scopes:         []string{"api:read", "api:write"},
+			RequiredScopes: []string{"api:write"},
+		}
+		ctx, err = auther.JWTAuth(ctx, goagrpc.MetadataCredential(md, "authorization"), &sc)
+		if err != nil {
+			sc := security.APIKeyScheme{
+				Name:           "api_key",
+				Scopes:         []string{},
+				RequiredScopes: []string{},
+			}
+			ctx, err = auther.APIKeyAuth(ctx, goagrpc.MetadataValue(md, "api-key"), &sc)
+		}
+	case "/secure_service.SecureService/BasicMethod":
+		sc := security.BasicScheme{
+			Name:           "basic",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = auther.BasicAuth(ctx, goagrpc.MetadataValue(md, "user"), goagrpc.MetadataValue(md, "pass"), &sc)
+	}
+	return ctx, err
+}
+`
@@ -265,3 +265,29 @@ func (s *Server) MethodBidirectionalStreamingRPCWithErrors(stream service_bidire
 	return nil
 }
 `
+
+const WellKnownTypesErrorServerInterfaceCode = `// Method implements the "Method" method in
+// well_known_typespb.WellKnownTypesServer interface.
+func (s *Server) Method(ctx context.Context, message *well_known_typespb.MethodRequest) (*well_known_typespb.MethodResponse, error) {
+	ctx = context.WithValue(ctx, goa.MethodKey, "Method")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "WellKnownTypes")
+	resp, err := s.MethodH.Handle(ctx, message)
+	if err != nil {
+		var en goa.GoaErrorNamer
+		if errors.As(err, &en) {
+			switch en.GoaErrorName() {
+			case "expired":
+				var er *wellknowntypes.Expired
+				errors.As(err, &er)
+				details, cerr := NewMethodExpiredError(er)
+				if cerr != nil {
+					return nil, goagrpc.EncodeError(cerr)
+				}
+				return nil, goagrpc.NewStatusError(codes.DeadlineExceeded, err, details)
+			}
+		}
+		return nil, goagrpc.EncodeError(err)
+	}
+	return resp.(*well_known_typespb.MethodResponse), nil
+}
+`
@@ -27,7 +27,7 @@ func NewProtoMethodPayloadWithNestedTypesResponse() *service_payload_with_nested
 func ValidateMethodPayloadWithNestedTypesRequest(message *service_payload_with_nested_typespb.MethodPayloadWithNestedTypesRequest) (err error) {
 	if message.AParams != nil {
 		if err2 := ValidateAParams(message.AParams); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "message.a_params"))
 		}
 	}
 	return
@@ -35,12 +35,15 @@ func ValidateMethodPayloadWithNestedTypesRequest(message *service_payload_with_n
 
 // ValidateAParams runs the validations defined on AParams.
 func ValidateAParams(aParams *service_payload_with_nested_typespb.AParams) (err error) {
-	for _, v := range aParams.A {
-		if v != nil {
-			if err2 := ValidateArrayOfString(v); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+	for k, v := range aParams.A {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if v != nil {
+				if err2 := ValidateArrayOfString(v); err2 != nil {
+					err = goa.MergeErrors(err, goa.NestErrors(err2, "aParams.a[key]"))
+				}
 			}
-		}
+			return
+		}(), "aParams.a", k))
 	}
 	return
 }
@@ -395,12 +398,15 @@ func NewProtoMethodElemValidationResponse() *service_elem_validationpb.MethodEle
 // ValidateMethodElemValidationRequest runs the validations defined on
 // MethodElemValidationRequest.
 func ValidateMethodElemValidationRequest(message *service_elem_validationpb.MethodElemValidationRequest) (err error) {
-	for _, v := range message.Foo {
-		if v != nil {
-			if err2 := ValidateArrayOfString(v); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+	for k, v := range message.Foo {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if v != nil {
+				if err2 := ValidateArrayOfString(v); err2 != nil {
+					err = goa.MergeErrors(err, goa.NestErrors(err2, "message.foo[key]"))
+				}
 			}
-		}
+			return
+		}(), "message.foo", k))
 	}
 	return
 }
@@ -554,3 +560,146 @@ func NewProtoMethodResponse() *default_fieldspb.MethodResponse {
 	return message
 }
 `
+
+const WellKnownTypesServerTypeCode = `// NewMethodPayload builds the payload of the "Method" endpoint of the
+// "WellKnownTypes" service from the gRPC request type.
+func NewMethodPayload(message *well_known_typespb.MethodRequest) *wellknowntypes.MethodPayload {
+	v := &wellknowntypes.MethodPayload{
+		Created: goagrpc.FormatTimestamp(message.Created),
+		Name:    message.Name,
+	}
+	if message.Updated != nil {
+		updated := goagrpc.FormatTimestamp(message.Updated)
+		v.Updated = &updated
+	}
+	if message.Timeout != nil {
+		timeout := goagrpc.FormatDuration(message.Timeout)
+		v.Timeout = &timeout
+	}
+	if message.History != nil {
+		v.History = make([]string, len(message.History))
+		for i, val := range message.History {
+			v.History[i] = goagrpc.FormatTimestamp(val)
+		}
+	}
+	if message.Window != nil {
+		v.Window = protobufWellKnownTypespbWindowToWellknowntypesWindow(message.Window)
+	}
+	if message.Timeouts != nil {
+		v.Timeouts = make(map[string]string, len(message.Timeouts))
+		for key, val := range message.Timeouts {
+			tk := key
+			tv := goagrpc.FormatDuration(val)
+			v.Timeouts[tk] = tv
+		}
+	}
+	return v
+}
+
+// NewProtoMethodResponse builds the gRPC response type from the result of the
+// "Method" endpoint of the "WellKnownTypes" service.
+func NewProtoMethodResponse(result *wellknowntypes.MethodResult) (*well_known_typespb.MethodResponse, error) {
+	var err error
+	message := &well_known_typespb.MethodResponse{}
+	if message.At, err = goagrpc.NewTimestamp(result.At); err != nil {
+		return nil, err
+	}
+	if result.Elapsed != nil {
+		if message.Elapsed, err = goagrpc.NewDuration(*result.Elapsed); err != nil {
+			return nil, err
+		}
+	}
+	return message, nil
+}
+
+// NewMethodExpiredError builds the gRPC error response type from the error of
+// the "Method" endpoint of the "WellKnownTypes" service.
+func NewMethodExpiredError(er *wellknowntypes.Expired) (*well_known_typespb.MethodExpiredError, error) {
+	var err error
+	message := &well_known_typespb.MethodExpiredError{}
+	if message.Since, err = goagrpc.NewTimestamp(er.Since); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// protobufWellKnownTypespbWindowToWellknowntypesWindow builds a value of type
+// *wellknowntypes.Window from a value of type *well_known_typespb.Window.
+func protobufWellKnownTypespbWindowToWellknowntypesWindow(v *well_known_typespb.Window) *wellknowntypes.Window {
+	if v == nil {
+		return nil
+	}
+	res := &wellknowntypes.Window{
+		Start: goagrpc.FormatTimestamp(v.Start),
+	}
+	if v.Length != nil {
+		length := goagrpc.FormatDuration(v.Length)
+		res.Length = &length
+	}
+
+	return res
+}
+
+// svcWellknowntypesWindowToWellKnownTypespbWindow builds a value of type
+// *well_known_typespb.Window from a value of type *wellknowntypes.Window.
+func svcWellknowntypesWindowToWellKnownTypespbWindow(v *wellknowntypes.Window) (*well_known_typespb.Window, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var err error
+	res := &well_known_typespb.Window{}
+	if res.Start, err = goagrpc.NewTimestamp(v.Start); err != nil {
+		return nil, err
+	}
+	if v.Length != nil {
+		if res.Length, err = goagrpc.NewDuration(*v.Length); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+`
+
+const EnumMapServerTypeCode = `// NewMethodPayload builds the payload of the "Method" endpoint of the
+// "EnumMap" service from the gRPC request type.
+func NewMethodPayload(message *enum_mappb.MethodRequest) *enummap.MethodPayload {
+	v := &enummap.MethodPayload{
+		Kind: goagrpc.EnumName(message.Kind, map[int32]string{1: "PERSONAL", 2: "BUSINESS"}, ""),
+		Name: message.Name,
+	}
+	if message.Status != nil {
+		status := enummap.Status(goagrpc.EnumName(*message.Status, map[int32]string{0: "UNKNOWN", 1: "ACTIVE"}, "UNKNOWN"))
+		v.Status = &status
+	}
+	if message.Level != nil {
+		v.Level = goagrpc.EnumName(*message.Level, map[int32]string{0: "LOW", 5: "HIGH"}, "")
+	}
+	if message.Level == nil {
+		v.Level = "HIGH"
+	}
+	return v
+}
+
+// NewProtoMethodResponse builds the gRPC response type from the result of the
+// "Method" endpoint of the "EnumMap" service.
+func NewProtoMethodResponse(result *enummap.MethodResult) *enum_mappb.MethodResponse {
+	message := &enum_mappb.MethodResponse{
+		Status: goagrpc.EnumNumber(string(result.Status), map[string]int32{"UNKNOWN": 0, "ACTIVE": 1}),
+	}
+	return message
+}
+
+// ValidateMethodRequest runs the validations defined on MethodRequest.
+func ValidateMethodRequest(message *enum_mappb.MethodRequest) (err error) {
+	if !(message.Kind == 1 || message.Kind == 2) {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError("message.kind", message.Kind, []interface{}{1, 2}))
+	}
+	if message.Level != nil {
+		if !(*message.Level == 0 || *message.Level == 5) {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("message.level", *message.Level, []interface{}{0, 5}))
+		}
+	}
+	return
+}
+`
@@ -13,7 +13,7 @@ var ServerStreamingServerSendCode = `// Send streams instances of
 // to the "MethodServerStreamingUserTypeRPC" endpoint gRPC stream.
 func (s *MethodServerStreamingUserTypeRPCServerStream) Send(res *serviceserverstreamingusertyperpc.UserType) error {
 	v := NewProtoUserTypeMethodServerStreamingUserTypeRPCResponse(res)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -23,6 +23,20 @@ var ServerStreamingServerCloseCode = `func (s *MethodServerStreamingUserTypeRPCS
 }
 `
 
+var ServerStreamingTrailersServerSetTrailersCode = `// SetTrailers sets the trailers sent in the trailer metadata once the results
+// have been streamed.
+func (s *MethodServerStreamingTrailersRPCServerStream) SetTrailers(t *serviceserverstreamingtrailersrpc.MethodServerStreamingTrailersRPCTrailers) {
+	md := metadata.MD{}
+	if t.XTotalCount != nil {
+		md.Set("X-Total-Count", fmt.Sprint(*t.XTotalCount))
+	}
+	if t.XNext != nil {
+		md.Set("X-Next", *t.XNext)
+	}
+	s.stream.SetTrailer(md)
+}
+`
+
 var ServerStreamingClientStructCode = `// MethodServerStreamingUserTypeRPCClientStream implements the
 // serviceserverstreamingusertyperpc.MethodServerStreamingUserTypeRPCClientStream
 // interface.
@@ -38,7 +52,7 @@ func (s *MethodServerStreamingUserTypeRPCClientStream) Recv() (*serviceserverstr
 	var res *serviceserverstreamingusertyperpc.UserType
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodServerStreamingUserTypeRPCResponseUserType(v), nil
 }
@@ -59,7 +73,7 @@ var ServerStreamingResultWithViewsServerSendCode = `// Send streams instances of
 func (s *MethodServerStreamingUserTypeRPCServerStream) Send(res *serviceserverstreamingusertyperpc.ResultType) error {
 	vres := serviceserverstreamingusertyperpc.NewViewedResultType(res, s.view)
 	v := NewProtoResultTypeViewMethodServerStreamingUserTypeRPCResponse(vres.Projected)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -85,7 +99,7 @@ func (s *MethodServerStreamingUserTypeRPCClientStream) Recv() (*serviceserverstr
 	var res *serviceserverstreamingusertyperpc.ResultType
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	proj := NewMethodServerStreamingUserTypeRPCResponseResultTypeView(v)
 	vres := &serviceserverstreamingusertyperpcviews.ResultType{Projected: proj, View: s.view}
@@ -109,7 +123,7 @@ var ServerStreamingResultCollectionWithExplicitViewServerSendCode = `// Send str
 func (s *MethodServerStreamingResultTypeCollectionWithExplicitViewServerStream) Send(res serviceserverstreamingresulttypecollectionwithexplicitview.ResultTypeCollection) error {
 	vres := serviceserverstreamingresulttypecollectionwithexplicitview.NewViewedResultTypeCollection(res, "tiny")
 	v := NewProtoResultTypeCollectionViewResultTypeCollection(vres.Projected)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -121,7 +135,7 @@ func (s *MethodServerStreamingResultTypeCollectionWithExplicitViewClientStream)
 	var res serviceserverstreamingresulttypecollectionwithexplicitview.ResultTypeCollection
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	proj := NewResultTypeCollectionResultTypeCollection(v)
 	vres := serviceserverstreamingresulttypecollectionwithexplicitviewviews.ResultTypeCollection{Projected: proj, View: "tiny"}
@@ -137,7 +151,7 @@ var ServerStreamingPrimitiveServerSendCode = `// Send streams instances of
 // "MethodServerStreamingRPC" endpoint gRPC stream.
 func (s *MethodServerStreamingRPCServerStream) Send(res string) error {
 	v := NewProtoMethodServerStreamingRPCResponse(res)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -148,7 +162,7 @@ func (s *MethodServerStreamingRPCClientStream) Recv() (string, error) {
 	var res string
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodServerStreamingRPCResponseMethodServerStreamingRPCResponse(v), nil
 }
@@ -159,7 +173,7 @@ var ServerStreamingArrayServerSendCode = `// Send streams instances of
 // "MethodServerStreamingArray" endpoint gRPC stream.
 func (s *MethodServerStreamingArrayServerStream) Send(res []int) error {
 	v := NewProtoMethodServerStreamingArrayResponse(res)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -170,7 +184,7 @@ func (s *MethodServerStreamingArrayClientStream) Recv() ([]int, error) {
 	var res []int
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodServerStreamingArrayResponseMethodServerStreamingArrayResponse(v), nil
 }
@@ -181,7 +195,7 @@ var ServerStreamingMapServerSendCode = `// Send streams instances of
 // "MethodServerStreamingMap" endpoint gRPC stream.
 func (s *MethodServerStreamingMapServerStream) Send(res map[string]*serviceserverstreamingmap.UserType) error {
 	v := NewProtoMethodServerStreamingMapResponse(res)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -192,7 +206,7 @@ func (s *MethodServerStreamingMapClientStream) Recv() (map[string]*serviceserver
 	var res map[string]*serviceserverstreamingmap.UserType
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodServerStreamingMapResponseMethodServerStreamingMapResponse(v), nil
 }
@@ -205,7 +219,7 @@ func (s *MethodServerStreamingRPCClientStream) Recv() (*serviceserverstreamingrp
 	var res *serviceserverstreamingrpc.UserType
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodServerStreamingRPCResponseUserType(v), nil
 }
@@ -217,7 +231,7 @@ func (s *OtherMethodServerStreamingRPCClientStream) Recv() (*serviceserverstream
 	var res *serviceserverstreamingrpc.UserType
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewOtherMethodServerStreamingRPCResponseUserType(v), nil
 }
@@ -235,7 +249,7 @@ var ClientStreamingServerSendCode = `// SendAndClose streams instances of
 // "MethodClientStreamingRPC" endpoint gRPC stream.
 func (s *MethodClientStreamingRPCServerStream) SendAndClose(res string) error {
 	v := NewProtoMethodClientStreamingRPCResponse(res)
-	return s.stream.SendAndClose(v)
+	return goagrpc.StreamError(s.stream.SendAndClose(v))
 }
 `
 
@@ -246,7 +260,7 @@ func (s *MethodClientStreamingRPCServerStream) Recv() (int, error) {
 	var res int
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodClientStreamingRPCStreamingRequestMethodClientStreamingRPCStreamingRequest(v), nil
 }
@@ -264,7 +278,7 @@ var ClientStreamingClientSendCode = `// Send streams instances of
 // the "MethodClientStreamingRPC" endpoint gRPC stream.
 func (s *MethodClientStreamingRPCClientStream) Send(res int) error {
 	v := NewProtoMethodClientStreamingRPCStreamingRequest(res)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -275,7 +289,7 @@ func (s *MethodClientStreamingRPCClientStream) CloseAndRecv() (string, error) {
 	var res string
 	v, err := s.stream.CloseAndRecv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodClientStreamingRPCResponseMethodClientStreamingRPCResponse(v), nil
 }
@@ -309,7 +323,7 @@ var BidirectionalStreamingServerSendCode = `// Send streams instances of
 func (s *MethodBidirectionalStreamingRPCServerStream) Send(res *servicebidirectionalstreamingrpc.ID) error {
 	vres := servicebidirectionalstreamingrpc.NewViewedID(res, "default")
 	v := NewProtoIDViewMethodBidirectionalStreamingRPCResponse(vres.Projected)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -320,7 +334,7 @@ func (s *MethodBidirectionalStreamingRPCServerStream) Recv() (int, error) {
 	var res int
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	return NewMethodBidirectionalStreamingRPCStreamingRequestMethodBidirectionalStreamingRPCStreamingRequest(v), nil
 }
@@ -346,7 +360,7 @@ var BidirectionalStreamingClientSendCode = `// Send streams instances of
 // to the "MethodBidirectionalStreamingRPC" endpoint gRPC stream.
 func (s *MethodBidirectionalStreamingRPCClientStream) Send(res int) error {
 	v := NewProtoMethodBidirectionalStreamingRPCStreamingRequest(res)
-	return s.stream.Send(v)
+	return goagrpc.StreamError(s.stream.Send(v))
 }
 `
 
@@ -357,7 +371,7 @@ func (s *MethodBidirectionalStreamingRPCClientStream) Recv() (*servicebidirectio
 	var res *servicebidirectionalstreamingrpc.ID
 	v, err := s.stream.Recv()
 	if err != nil {
-		return res, err
+		return res, goagrpc.StreamError(err)
 	}
 	proj := NewMethodBidirectionalStreamingRPCResponseIDView(v)
 	vres := &servicebidirectionalstreamingrpcviews.ID{Projected: proj, View: "default"}
@@ -373,3 +387,38 @@ var BidirectionalStreamingClientCloseCode = `func (s *MethodBidirectionalStreami
 	return s.stream.CloseSend()
 }
 `
+
+var BidirectionalStreamingClientCloseAndRecvCode = `// CloseAndRecv stops sending messages to the "MethodBidirectionalStreamingRPC"
+// endpoint gRPC stream and reads the next instance of
+// "service_bidirectional_streaming_rpcpb.MethodBidirectionalStreamingRPCResponse"
+// from the stream.
+func (s *MethodBidirectionalStreamingRPCClientStream) CloseAndRecv() (*servicebidirectionalstreamingrpc.ID, error) {
+	if err := s.stream.CloseSend(); err != nil {
+		var res *servicebidirectionalstreamingrpc.ID
+		return res, goagrpc.StreamError(err)
+	}
+	return s.Recv()
+}
+`
+
+var WellKnownTypesStreamingServerSendCode = `// Send streams instances of "well_known_typespb.MethodResponse" to the
+// "Method" endpoint gRPC stream.
+func (s *MethodServerStream) Send(res *wellknowntypes.MethodResult) error {
+	v, err := NewProtoMethodResultMethodResponse(res)
+	if err != nil {
+		return err
+	}
+	return goagrpc.StreamError(s.stream.Send(v))
+}
+`
+
+var WellKnownTypesStreamingClientSendCode = `// Send streams instances of "well_known_typespb.MethodStreamingRequest" to the
+// "Method" endpoint gRPC stream.
+func (s *MethodClientStream) Send(res *wellknowntypes.MethodStreamingPayload) error {
+	v, err := NewProtoMethodStreamingPayloadMethodStreamingRequest(res)
+	if err != nil {
+		return err
+	}
+	return goagrpc.StreamError(s.stream.Send(v))
+}
+`
@@ -0,0 +1,16 @@
+package grpc
+
+// EnumNumber returns the number of the enum value with the given name using
+// the given mapping. It returns 0 if there is no such value.
+func EnumNumber(name string, numbers map[string]int32) int32 {
+	return numbers[name]
+}
+
+// EnumName returns the name of the enum value with the given number using the
+// given mapping. It returns fallback if there is no such value.
+func EnumName(number int32, names map[int32]string, fallback string) string {
+	if name, ok := names[number]; ok {
+		return name
+	}
+	return fallback
+}
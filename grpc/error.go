@@ -1,10 +1,14 @@
 package grpc
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	goapb "goa.design/goa/v3/grpc/pb"
 	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -29,6 +33,13 @@ type (
 		// Is the error a server-side fault?
 		Fault bool
 	}
+
+	// streamContextError is a gRPC status error returned by a stream
+	// whose context was canceled or whose deadline was exceeded.
+	streamContextError struct {
+		err error
+		ctx error
+	}
 )
 
 // NewErrorResponse creates a new ErrorResponse protocol buffer message from
@@ -76,7 +87,9 @@ func NewStatusError(code codes.Code, err error, details ...protoiface.MessageV1)
 // EncodeError returns a gRPC status error from the given error with the error
 // response encoded in the status details. If error is a goa ServiceError type
 // it implements a heuristic to compute the status code from the Timeout,
-// Fault, and Temporary characteristics of the ServiceError. If error is not a
+// Fault, and Temporary characteristics of the ServiceError. The status code is
+// PermissionDenied if the ServiceError wraps a security.MissingScopesError
+// (e.g. when the scopes required by the method are missing). If error is not a
 // ServiceError or a gRPC status error it returns a gRPC status error with
 // Unknown code and Fault characteristic set.
 func EncodeError(err error) error {
@@ -101,6 +114,10 @@ func EncodeError(err error) error {
 			if gerr.Temporary {
 				code = codes.Unavailable
 			}
+			var serr *security.MissingScopesError
+			if errors.As(gerr, &serr) {
+				code = codes.PermissionDenied
+			}
 		}
 		return NewStatusError(code, err, NewErrorResponse(err))
 	}
@@ -124,6 +141,26 @@ func DecodeError(err error) proto.Message {
 	return details[0].(proto.Message)
 }
 
+// StreamError returns the error reported by the Send and Recv methods of the
+// generated streams given the error returned by the underlying gRPC stream.
+// io.EOF is returned as is so that callers can detect the end of the stream.
+// The status errors with the Canceled and DeadlineExceeded codes, returned when
+// the stream context is canceled, its deadline is exceeded or the peer resets
+// the stream, match context.Canceled and context.DeadlineExceeded respectively
+// with errors.Is. The other errors are returned as is.
+func StreamError(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	switch status.Code(err) {
+	case codes.Canceled:
+		return &streamContextError{err: err, ctx: context.Canceled}
+	case codes.DeadlineExceeded:
+		return &streamContextError{err: err, ctx: context.DeadlineExceeded}
+	}
+	return err
+}
+
 // ErrInvalidType is the error returned when the wrong type is given to a
 // encoder or decoder.
 func ErrInvalidType(svc, m, expected string, actual interface{}) error {
@@ -135,3 +172,24 @@ func ErrInvalidType(svc, m, expected string, actual interface{}) error {
 func (c *ClientError) Error() string {
 	return fmt.Sprintf("[%s %s]: %s", c.Service, c.Method, c.Message)
 }
+
+// Error returns the status error message.
+func (e *streamContextError) Error() string {
+	return e.err.Error()
+}
+
+// Is returns true if target is the context error.
+func (e *streamContextError) Is(target error) bool {
+	return target == e.ctx
+}
+
+// Unwrap returns the status error.
+func (e *streamContextError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus returns the status so that status.FromError and status.Code
+// handle the error.
+func (e *streamContextError) GRPCStatus() *status.Status {
+	return status.Convert(e.err)
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamError(t *testing.T) {
+	cases := []struct {
+		Name string
+		Err  error
+		Is   error
+		Code codes.Code
+	}{
+		{"eof", io.EOF, io.EOF, codes.Unknown},
+		{"canceled", status.Error(codes.Canceled, "stream reset"), context.Canceled, codes.Canceled},
+		{"deadline", status.Error(codes.DeadlineExceeded, "too slow"), context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"other", status.Error(codes.Internal, "boom"), nil, codes.Internal},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := StreamError(c.Err)
+			if c.Is != nil && !errors.Is(err, c.Is) {
+				t.Errorf("got error %v, expected to match %v", err, c.Is)
+			}
+			if c.Is == nil && err != c.Err {
+				t.Errorf("got error %v, expected %v", err, c.Err)
+			}
+			if code := status.Code(err); code != c.Code {
+				t.Errorf("got code %s, expected %s", code, c.Code)
+			}
+		})
+	}
+	if err := StreamError(nil); err != nil {
+		t.Errorf("got error %v, expected nil", err)
+	}
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ClientConnPool is a pool of gRPC client connections. ClientConnPool
+// implements grpc.ClientConnInterface so that it can be given to the
+// protoc-generated client constructors, it distributes the calls across the
+// connections in round-robin order. The connections in the TRANSIENT_FAILURE
+// or SHUTDOWN state are skipped, if no connection is usable the call is made
+// on the next connection so that it fails with the connection error.
+type ClientConnPool struct {
+	conns []*grpc.ClientConn
+	next  uint32
+}
+
+// NewClientConnPool returns a pool made of the given connections.
+func NewClientConnPool(conns ...*grpc.ClientConn) (*ClientConnPool, error) {
+	if len(conns) == 0 {
+		return nil, errors.New("gRPC client connection pool must contain at least one connection")
+	}
+	return &ClientConnPool{conns: conns}, nil
+}
+
+// DialClientConnPool creates a pool of size client connections to target.
+// The connections are created with grpc.Dial and the given options. The
+// connections already created are closed if one fails.
+func DialClientConnPool(target string, size int, opts ...grpc.DialOption) (*ClientConnPool, error) {
+	if size <= 0 {
+		return nil, errors.New("gRPC client connection pool size must be greater than 0")
+	}
+	conns := make([]*grpc.ClientConn, 0, size)
+	for i := 0; i < size; i++ {
+		cc, err := grpc.Dial(target, opts...)
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			return nil, err
+		}
+		conns = append(conns, cc)
+	}
+	return &ClientConnPool{conns: conns}, nil
+}
+
+// Size returns the number of connections in the pool.
+func (p *ClientConnPool) Size() int {
+	return len(p.conns)
+}
+
+// Available returns the number of connections in the pool that are not in
+// the TRANSIENT_FAILURE or SHUTDOWN state.
+func (p *ClientConnPool) Available() int {
+	var n int
+	for _, cc := range p.conns {
+		if usable(cc) {
+			n++
+		}
+	}
+	return n
+}
+
+// Invoke performs a unary RPC on the next usable connection of the pool.
+func (p *ClientConnPool) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream begins a streaming RPC on the next usable connection of the
+// pool.
+func (p *ClientConnPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+// Close closes all the connections of the pool and returns the first error
+// if any.
+func (p *ClientConnPool) Close() error {
+	var err error
+	for _, cc := range p.conns {
+		if cerr := cc.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// pick returns the next usable connection in round-robin order or the next
+// connection if none is usable.
+func (p *ClientConnPool) pick() *grpc.ClientConn {
+	size := uint32(len(p.conns))
+	start := atomic.AddUint32(&p.next, 1) - 1
+	for i := uint32(0); i < size; i++ {
+		if cc := p.conns[(start+i)%size]; usable(cc) {
+			return cc
+		}
+	}
+	return p.conns[start%size]
+}
+
+// usable returns true if the connection is not in the TRANSIENT_FAILURE or
+// SHUTDOWN state.
+func usable(cc *grpc.ClientConn) bool {
+	switch cc.GetState() {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestClientConnPool(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(l)
+	defer srv.Stop()
+
+	pool, err := DialClientConnPool(l.Addr().String(), 3, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	if pool.Size() != 3 {
+		t.Errorf("got size %d, expected 3", pool.Size())
+	}
+	pool.conns[0].Close()
+	if n := pool.Available(); n != 2 {
+		t.Errorf("got %d available connections, expected 2", n)
+	}
+	cli := grpc_health_v1.NewHealthClient(pool)
+	for i := 0; i < 2*pool.Size(); i++ {
+		if _, err := cli.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}); err != nil {
+			t.Fatalf("call %d: got error %v", i, err)
+		}
+	}
+}
+
+func TestNewClientConnPoolEmpty(t *testing.T) {
+	if _, err := NewClientConnPool(); err == nil {
+		t.Error("got no error, expected empty pool error")
+	}
+	if _, err := DialClientConnPool("localhost:0", 0); err == nil {
+		t.Error("got no error, expected invalid size error")
+	}
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewTimestamp returns the protocol buffer timestamp corresponding to the given
+// RFC3339 date time. It returns an error if the value is not a valid date
+// time.
+func NewTimestamp(v string) (*timestamppb.Timestamp, error) {
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date-time value %q: %w", v, err)
+	}
+	return timestamppb.New(t), nil
+}
+
+// FormatTimestamp returns the RFC3339 representation of the given protocol
+// buffer timestamp in UTC. It returns the empty string if ts is nil.
+func FormatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339Nano)
+}
+
+// NewDuration returns the protocol buffer duration corresponding to the given
+// Go duration string. It returns an error if the value is not a valid
+// duration.
+func NewDuration(v string) (*durationpb.Duration, error) {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration value %q: %w", v, err)
+	}
+	return durationpb.New(d), nil
+}
+
+// FormatDuration returns the Go representation of the given protocol buffer
+// duration, for example "1h30m0s". It returns the empty string if d is nil.
+func FormatDuration(d *durationpb.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.AsDuration().String()
+}
@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"testing"
+)
+
+func TestNewTimestamp(t *testing.T) {
+	ts, err := NewTimestamp("2024-01-02T03:04:05Z")
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if got := FormatTimestamp(ts); got != "2024-01-02T03:04:05Z" {
+		t.Errorf("got %q, expected %q", got, "2024-01-02T03:04:05Z")
+	}
+	if _, err := NewTimestamp("not a date"); err == nil {
+		t.Error("got nil error, expected an error for an invalid date time")
+	}
+}
+
+func TestNewDuration(t *testing.T) {
+	d, err := NewDuration("1h30m")
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if got := FormatDuration(d); got != "1h30m0s" {
+		t.Errorf("got %q, expected %q", got, "1h30m0s")
+	}
+	if _, err := NewDuration("forever"); err == nil {
+		t.Error("got nil error, expected an error for an invalid duration")
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Cipher encrypts and decrypts the values of the payload and result attributes
+// flagged with the "crypto:field" meta. The generated servers decrypt the
+// flagged payload fields after decoding the requests and encrypt the flagged
+// result fields prior to encoding the responses. field is the name of the
+// attribute as defined in the design.
+type Cipher interface {
+	// Encrypt returns the encrypted representation of plaintext.
+	Encrypt(ctx context.Context, field, plaintext string) (string, error)
+	// Decrypt returns the plaintext value encrypted in ciphertext.
+	Decrypt(ctx context.Context, field, ciphertext string) (string, error)
+}
+
+// errNoCipher is the error returned when a field must be encrypted or decrypted
+// but the server was created without a cipher.
+var errNoCipher = errors.New("no cipher configured to encrypt and decrypt fields")
+
+// DecryptField decrypts the value of the given payload field in place. It does
+// nothing if v is nil. The error returned when decryption fails is a
+// "decode_payload" service error so that the response is a bad request.
+func DecryptField(ctx context.Context, c Cipher, field string, v *string) error {
+	if v == nil {
+		return nil
+	}
+	if c == nil {
+		return errNoCipher
+	}
+	plain, err := c.Decrypt(ctx, field, *v)
+	if err != nil {
+		return goa.DecodePayloadError(fmt.Sprintf("invalid encrypted value for %q", field))
+	}
+	*v = plain
+	return nil
+}
+
+// EncryptField encrypts the value of the given result field in place. It does
+// nothing if v is nil.
+func EncryptField(ctx context.Context, c Cipher, field string, v *string) error {
+	if v == nil {
+		return nil
+	}
+	if c == nil {
+		return errNoCipher
+	}
+	enc, err := c.Encrypt(ctx, field, *v)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %q: %w", field, err)
+	}
+	*v = enc
+	return nil
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type prefixCipher struct{}
+
+func (prefixCipher) Encrypt(_ context.Context, field, plaintext string) (string, error) {
+	return field + ":" + plaintext, nil
+}
+
+func (prefixCipher) Decrypt(_ context.Context, field, ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, field+":") {
+		return "", errors.New("bad ciphertext")
+	}
+	return strings.TrimPrefix(ciphertext, field+":"), nil
+}
+
+func TestCipherFields(t *testing.T) {
+	ctx := context.Background()
+	v := "secret"
+	if err := EncryptField(ctx, prefixCipher{}, "ssn", &v); err != nil {
+		t.Fatalf("unexpected encrypt error: %s", err)
+	}
+	if v != "ssn:secret" {
+		t.Errorf("got encrypted value %q, expected %q", v, "ssn:secret")
+	}
+	if err := DecryptField(ctx, prefixCipher{}, "ssn", &v); err != nil {
+		t.Fatalf("unexpected decrypt error: %s", err)
+	}
+	if v != "secret" {
+		t.Errorf("got decrypted value %q, expected %q", v, "secret")
+	}
+	if err := DecryptField(ctx, prefixCipher{}, "ssn", nil); err != nil {
+		t.Errorf("unexpected error for nil value: %s", err)
+	}
+}
+
+func TestDecryptFieldError(t *testing.T) {
+	v := "garbage"
+	err := DecryptField(context.Background(), prefixCipher{}, "ssn", &v)
+	var serr *goa.ServiceError
+	if !errors.As(err, &serr) {
+		t.Fatalf("got error %v, expected a service error", err)
+	}
+	if serr.Name != "decode_payload" {
+		t.Errorf("got error name %q, expected %q", serr.Name, "decode_payload")
+	}
+	if v != "garbage" {
+		t.Errorf("got value %q, expected it to be left unchanged", v)
+	}
+	if err := DecryptField(context.Background(), nil, "ssn", &v); err == nil {
+		t.Error("expected an error when no cipher is configured")
+	}
+}
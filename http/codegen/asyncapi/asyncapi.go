@@ -0,0 +1,94 @@
+/*
+Package asyncapi implements a plugin that generates an AsyncAPI 2.x document
+describing the streaming HTTP endpoints of the API.
+
+Enable the plugin by importing the package in the design:
+
+	import (
+	    _ "goa.design/goa/v3/http/codegen/asyncapi"
+	    . "goa.design/goa/v3/dsl"
+	)
+
+The "gen" command then generates the gen/http/asyncapi.json and
+gen/http/asyncapi.yaml files. The document defines one channel per path of
+each endpoint whose method uses StreamingResult or StreamingPayload. Clients
+subscribe to the streamed results and publish the streamed payloads, the
+message payloads are described with the same JSON schemas as in the OpenAPI
+specifications. The channels of WebSocket endpoints define a "ws" binding and
+the operations of server-sent events endpoints an "http" binding, the servers
+use the corresponding protocols. The security schemes required by the
+endpoints are carried over.
+*/
+package asyncapi
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+func init() {
+	codegen.RegisterPluginLast("asyncapi", "gen", nil, Generate)
+}
+
+// Generate appends the AsyncAPI document files to the generated files.
+func Generate(_ string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			files = append(files, Files(r)...)
+		}
+	}
+	return files, nil
+}
+
+// Files returns the AsyncAPI document files in JSON and YAML formats, nil if
+// the API does not define streaming HTTP endpoints.
+func Files(root *expr.RootExpr) []*codegen.File {
+	doc := New(root)
+	if doc == nil {
+		return nil
+	}
+	dir := filepath.Join(codegen.Gendir, "http")
+	return []*codegen.File{
+		{
+			Path: filepath.Join(dir, "asyncapi.json"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "asyncapi",
+				FuncMap: template.FuncMap{"toJSON": toJSON},
+				Source:  "{{ toJSON .}}",
+				Data:    doc,
+			}},
+		},
+		{
+			Path: filepath.Join(dir, "asyncapi.yaml"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "asyncapi",
+				FuncMap: template.FuncMap{"toYAML": toYAML},
+				Source:  "{{ toYAML .}}",
+				Data:    doc,
+			}},
+		},
+	}
+}
+
+func toJSON(d interface{}) string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		panic("asyncapi: " + err.Error()) // bug
+	}
+	return string(b)
+}
+
+func toYAML(d interface{}) string {
+	b, err := yaml.Marshal(d)
+	if err != nil {
+		panic("asyncapi: " + err.Error()) // bug
+	}
+	return string(b)
+}
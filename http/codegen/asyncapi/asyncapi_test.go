@@ -0,0 +1,72 @@
+package asyncapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/asyncapi/testdata"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"websocket", testdata.WebSocketDSL},
+		{"sse", testdata.SSEDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := httpcodegen.RunHTTPDSL(t, c.DSL)
+			doc := New(root)
+			if doc == nil {
+				t.Fatal("got nil document")
+			}
+			b, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				t.Fatalf("failed to serialize document: %s", err)
+			}
+			golden := filepath.Join("testdata", "golden", c.Name+".json.golden")
+			if *update {
+				if err := os.WriteFile(golden, b, 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			want = bytes.ReplaceAll(want, []byte{'\r', '\n'}, []byte{'\n'})
+			if !bytes.Equal(b, want) {
+				t.Errorf("result does not match the golden file, got vs. expected:\n%s\n", codegen.Diff(t, string(b), string(want)))
+			}
+		})
+	}
+}
+
+func TestFiles(t *testing.T) {
+	root := httpcodegen.RunHTTPDSL(t, testdata.NoStreamingDSL)
+	if fs := Files(root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+	root = httpcodegen.RunHTTPDSL(t, testdata.SSEDSL)
+	fs := Files(root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	for i, name := range []string{"asyncapi.json", "asyncapi.yaml"} {
+		if want := filepath.Join("gen", "http", name); fs[i].Path != want {
+			t.Errorf("got path %q, expected %q", fs[i].Path, want)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+// definitionsPrefix is the prefix of the references to the JSON schema
+// definitions built by the openapi package.
+const definitionsPrefix = "#/definitions/"
+
+// schemasPrefix is the prefix of the references to the AsyncAPI component
+// schemas.
+const schemasPrefix = "#/components/schemas/"
+
+// New returns the AsyncAPI document describing the streaming HTTP endpoints of
+// the given API. It returns nil if the design does not define streaming HTTP
+// endpoints.
+func New(root *expr.RootExpr) *Document {
+	if root == nil || root.API == nil || root.API.HTTP == nil {
+		return nil
+	}
+	var (
+		api      = root.API
+		channels = make(map[string]*Channel)
+		schemes  = make(map[string]*SecurityScheme)
+		hasWS    bool
+		hasSSE   bool
+	)
+	for _, svc := range api.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			if !e.MethodExpr.IsStreaming() || len(e.Routes) == 0 {
+				continue
+			}
+			ch := buildChannel(api, e)
+			if e.SSE != nil {
+				hasSSE = true
+			} else {
+				hasWS = true
+			}
+			for _, path := range e.Routes[0].FullPaths() {
+				channels[channelName(path)] = ch
+			}
+			for _, r := range e.Requirements {
+				for _, s := range r.Schemes {
+					schemes[s.Hash()] = buildSecurityScheme(s)
+				}
+			}
+		}
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	servers := buildServers(api, hasWS, hasSSE)
+	if len(servers) > 0 && hasWS && hasSSE {
+		seen := make(map[*Channel]struct{})
+		for _, ch := range channels {
+			if _, ok := seen[ch]; ok {
+				continue // endpoint with multiple paths
+			}
+			seen[ch] = struct{}{}
+			protocols := []string{"ws", "wss"}
+			if ch.Bindings == nil {
+				protocols = []string{"http", "https"}
+			}
+			for name, s := range servers {
+				if s.Protocol == protocols[0] || s.Protocol == protocols[1] {
+					ch.Servers = append(ch.Servers, name)
+				}
+			}
+			sort.Strings(ch.Servers)
+		}
+	}
+
+	var comps *Components
+	{
+		schemas := make(map[string]*openapi.Schema)
+		for name, s := range openapi.ReferencedSchemas(channels, openapi.Definitions, definitionsPrefix) {
+			schemas[name] = rewriteRefs(s)
+		}
+		seen := make(map[*Channel]struct{})
+		for _, ch := range channels {
+			if _, ok := seen[ch]; ok {
+				continue
+			}
+			seen[ch] = struct{}{}
+			for _, op := range []*Operation{ch.Subscribe, ch.Publish} {
+				if op != nil {
+					rewriteMessageRefs(op.Message)
+				}
+			}
+			for _, p := range ch.Parameters {
+				p.Schema = rewriteRefs(p.Schema)
+			}
+		}
+		if len(schemas) > 0 || len(schemes) > 0 {
+			comps = &Components{}
+			if len(schemas) > 0 {
+				comps.Schemas = schemas
+			}
+			if len(schemes) > 0 {
+				comps.SecuritySchemes = schemes
+			}
+		}
+	}
+
+	return &Document{
+		AsyncAPI:           Version,
+		Info:               buildInfo(api),
+		Servers:            servers,
+		DefaultContentType: "application/json",
+		Channels:           channels,
+		Components:         comps,
+	}
+}
+
+// buildInfo builds the AsyncAPI Info object.
+func buildInfo(api *expr.APIExpr) *Info {
+	ver := api.Version
+	if ver == "" {
+		ver = "1.0" // cannot be empty as per AsyncAPI spec
+	}
+	title := api.Title
+	if title == "" {
+		title = "Goa API" // cannot be empty as per AsyncAPI spec
+	}
+	return &Info{Title: title, Version: ver, Description: api.Description}
+}
+
+// buildServers builds the AsyncAPI servers from the first HTTP URI of each
+// API host. The servers use the "ws" and "wss" protocols if the API defines
+// WebSocket endpoints and the "http" and "https" protocols if it defines
+// server-sent events endpoints.
+func buildServers(api *expr.APIExpr, ws, sse bool) map[string]*Server {
+	servers := make(map[string]*Server)
+	for _, svr := range api.Servers {
+		for _, host := range svr.Hosts {
+			var uri expr.URIExpr
+			for _, u := range host.URIs {
+				if s := u.Scheme(); s == "http" || s == "https" {
+					uri = u
+					break
+				}
+			}
+			if uri == "" {
+				continue
+			}
+			var (
+				secure  = uri.Scheme() == "https"
+				url     = strings.SplitN(string(uri), "://", 2)[1]
+				vars    = buildServerVariables(host)
+				prefix  = host.Name
+				wsProto = "ws"
+				sseProt = "http"
+			)
+			if len(api.Servers) > 1 {
+				prefix = svr.Name + "-" + host.Name
+			}
+			if secure {
+				wsProto = "wss"
+				sseProt = "https"
+			}
+			if ws {
+				servers[prefix+"-"+wsProto] = &Server{URL: url, Protocol: wsProto, Description: svr.Description, Variables: vars}
+			}
+			if sse {
+				servers[prefix+"-"+sseProt] = &Server{URL: url, Protocol: sseProt, Description: svr.Description, Variables: vars}
+			}
+		}
+	}
+	if len(servers) == 0 {
+		return nil
+	}
+	return servers
+}
+
+// buildServerVariables builds the variables of the URLs of the given host.
+func buildServerVariables(host *expr.HostExpr) map[string]*ServerVariable {
+	obj := expr.AsObject(host.Variables.Type)
+	if obj == nil || len(*obj) == 0 {
+		return nil
+	}
+	vars := make(map[string]*ServerVariable, len(*obj))
+	for _, v := range *obj {
+		sv := &ServerVariable{Default: v.Attribute.DefaultValue, Description: v.Attribute.Description}
+		if v.Attribute.Validation != nil && len(v.Attribute.Validation.Values) > 0 {
+			sv.Enum = v.Attribute.Validation.Values
+			if sv.Default == nil {
+				sv.Default = sv.Enum[0]
+			}
+		}
+		vars[v.Name] = sv
+	}
+	return vars
+}
+
+// buildChannel builds the channel describing the given streaming endpoint.
+// The clients subscribe to the streaming results and publish the streaming
+// payloads.
+func buildChannel(api *expr.APIExpr, e *expr.HTTPEndpointExpr) *Channel {
+	var (
+		m   = e.MethodExpr
+		svc = e.Service.Name()
+		ch  = &Channel{Description: m.Description}
+	)
+	if m.Stream == expr.ServerStreamKind || m.Stream == expr.BidirectionalStreamKind {
+		ch.Subscribe = &Operation{
+			OperationID: fmt.Sprintf("%s#%s#subscribe", svc, m.Name),
+			Summary:     fmt.Sprintf("Receive the results streamed by the %s %s method", svc, m.Name),
+			Security:    buildSecurityRequirements(e.Requirements),
+			Message:     buildResultMessage(api, e),
+		}
+	}
+	if m.Stream == expr.ClientStreamKind || m.Stream == expr.BidirectionalStreamKind {
+		ch.Publish = &Operation{
+			OperationID: fmt.Sprintf("%s#%s#publish", svc, m.Name),
+			Summary:     fmt.Sprintf("Send the payloads streamed to the %s %s method", svc, m.Name),
+			Security:    buildSecurityRequirements(e.Requirements),
+			Message:     buildMessage(api, m.StreamingPayload, typeName(m.StreamingPayload)),
+		}
+	}
+	if e.SSE != nil {
+		ch.Subscribe.Bindings = map[string]interface{}{
+			"http": map[string]interface{}{
+				"type":           "response",
+				"bindingVersion": bindingVersion,
+			},
+		}
+	} else {
+		ch.Bindings = map[string]interface{}{
+			"ws": map[string]interface{}{
+				"method":         e.Routes[0].Method,
+				"bindingVersion": bindingVersion,
+			},
+		}
+	}
+	if params := e.PathParams(); params != nil {
+		_ = expr.WalkMappedAttr(params, func(name, elem string, att *expr.AttributeExpr) error {
+			if ch.Parameters == nil {
+				ch.Parameters = make(map[string]*Parameter)
+			}
+			ch.Parameters[elem] = &Parameter{
+				Description: att.Description,
+				Schema:      openapi.AttributeTypeSchema(api, att),
+			}
+			return nil
+		})
+	}
+	return ch
+}
+
+// buildResultMessage builds the message describing the streaming results of
+// the given endpoint. The messages of server-sent events endpoints are named
+// after the events and list one alternative per event if the endpoint sends
+// more than one kind of event.
+func buildResultMessage(api *expr.APIExpr, e *expr.HTTPEndpointExpr) *Message {
+	res := e.MethodExpr.Result
+	if e.SSE == nil {
+		return buildMessage(api, res, typeName(res))
+	}
+	msgs := make([]*Message, len(e.SSE.Events))
+	for i, ev := range e.SSE.Events {
+		att := res
+		if e.SSE.Multiple() {
+			if a := expr.AsObject(res.Type).Attribute(ev.Name); a != nil {
+				att = a
+			}
+		}
+		if ev.Type != nil {
+			att = &expr.AttributeExpr{Type: ev.Type}
+		}
+		msgs[i] = buildMessage(api, att, ev.Name)
+	}
+	if len(msgs) == 1 {
+		return msgs[0]
+	}
+	return &Message{OneOf: msgs}
+}
+
+// buildMessage builds the message whose payload is described by att.
+func buildMessage(api *expr.APIExpr, att *expr.AttributeExpr, name string) *Message {
+	return &Message{
+		Name:        name,
+		Description: att.Description,
+		Payload:     openapi.AttributeTypeSchema(api, att),
+	}
+}
+
+// typeName returns the name of the type of att if it is a user type, an
+// empty string otherwise.
+func typeName(att *expr.AttributeExpr) string {
+	if ut, ok := att.Type.(expr.UserType); ok {
+		return ut.Name()
+	}
+	return ""
+}
+
+// buildSecurityRequirements builds the AsyncAPI security requirements for the
+// given security expressions.
+func buildSecurityRequirements(reqs []*expr.SecurityExpr) []map[string][]string {
+	if len(reqs) == 0 {
+		return nil
+	}
+	srs := make([]map[string][]string, len(reqs))
+	for i, req := range reqs {
+		sr := make(map[string][]string, len(req.Schemes))
+		for _, sch := range req.Schemes {
+			// Only OAuth2 requirements list scopes as per AsyncAPI spec.
+			scopes := []string{}
+			if sch.Kind == expr.OAuth2Kind {
+				scopes = append(scopes, req.Scopes...)
+			}
+			sr[sch.Hash()] = scopes
+		}
+		srs[i] = sr
+	}
+	return srs
+}
+
+// buildSecurityScheme builds the AsyncAPI security scheme from the given
+// security scheme definition.
+func buildSecurityScheme(se *expr.SchemeExpr) *SecurityScheme {
+	switch se.Kind {
+	case expr.BasicAuthKind:
+		return &SecurityScheme{Type: "http", Scheme: "basic", Description: se.Description}
+	case expr.APIKeyKind:
+		return &SecurityScheme{Type: "httpApiKey", Name: se.Name, In: se.In, Description: se.Description}
+	case expr.JWTKind:
+		return &SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: se.Description}
+	case expr.OAuth2Kind:
+		scopes := make(map[string]string, len(se.Scopes))
+		for _, scope := range se.Scopes {
+			scopes[scope.Name] = scope.Description
+		}
+		var flows OAuthFlows
+		for _, f := range se.Flows {
+			flow := &OAuthFlow{
+				AuthorizationURL: f.AuthorizationURL,
+				TokenURL:         f.TokenURL,
+				RefreshURL:       f.RefreshURL,
+				Scopes:           scopes,
+			}
+			switch f.Kind {
+			case expr.AuthorizationCodeFlowKind:
+				flows.AuthorizationCode = flow
+			case expr.ClientCredentialsFlowKind:
+				flow.AuthorizationURL = ""
+				flows.ClientCredentials = flow
+			case expr.ImplicitFlowKind:
+				flow.TokenURL = ""
+				flows.Implicit = flow
+			case expr.PasswordFlowKind:
+				flow.AuthorizationURL = ""
+				flows.Password = flow
+			}
+		}
+		return &SecurityScheme{Type: "oauth2", Flows: &flows, Description: se.Description}
+	}
+	return nil
+}
+
+// channelName returns the AsyncAPI channel name for the given HTTP path. The
+// path wildcards become channel parameters.
+func channelName(path string) string {
+	return strings.ReplaceAll(path, "{*", "{")
+}
+
+// rewriteMessageRefs rewrites the references to the JSON schema definitions
+// made by the payloads of msg and its alternatives.
+func rewriteMessageRefs(msg *Message) {
+	if msg.Payload != nil {
+		msg.Payload = rewriteRefs(msg.Payload)
+	}
+	for _, m := range msg.OneOf {
+		rewriteMessageRefs(m)
+	}
+}
+
+// rewriteRefs returns a copy of s where the references to the JSON schema
+// definitions are replaced with references to the AsyncAPI component schemas.
+// The definitions are shared with the OpenAPI specifications and thus cannot
+// be modified.
+func rewriteRefs(s *openapi.Schema) *openapi.Schema {
+	b, err := json.Marshal(s)
+	if err != nil {
+		panic("asyncapi: " + err.Error()) // bug
+	}
+	b = []byte(strings.ReplaceAll(string(b), `"$ref":"`+definitionsPrefix, `"$ref":"`+schemasPrefix))
+	var res openapi.Schema
+	if err := json.Unmarshal(b, &res); err != nil {
+		panic("asyncapi: " + err.Error()) // bug
+	}
+	return &res
+}
@@ -0,0 +1,110 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var WebSocketDSL = func() {
+	var JWT = JWTSecurity("jwt", func() {
+		Scope("chat:read")
+	})
+	var Message = Type("Message", func() {
+		Attribute("room", String)
+		Attribute("text", String, "Message text")
+		Required("room", "text")
+	})
+	API("chat", func() {
+		Title("Chat API")
+		Version("2.0")
+		Server("chat", func() {
+			Host("production", func() {
+				URI("https://chat.example.com")
+			})
+		})
+	})
+	Service("chat", func() {
+		Method("talk", func() {
+			Description("Exchange messages in a room.")
+			Security(JWT, func() {
+				Scope("chat:read")
+			})
+			Payload(func() {
+				Token("token", String)
+				Attribute("room", String, "Room name")
+				Required("room")
+			})
+			StreamingPayload(Message)
+			StreamingResult(Message)
+			HTTP(func() {
+				GET("/rooms/{room}/talk")
+			})
+		})
+		Method("list", func() {
+			Result(ArrayOf(String))
+			HTTP(func() {
+				GET("/rooms")
+			})
+		})
+	})
+}
+
+var SSEDSL = func() {
+	var Tick = ResultType("application/vnd.tick", func() {
+		Attribute("id", String)
+		Attribute("count", Int)
+		Required("id")
+	})
+	var Alert = Type("Alert", func() {
+		Attribute("level", String)
+	})
+	var Notification = Type("Notification", func() {
+		Attribute("tick", Tick)
+		Attribute("alert", Alert)
+	})
+	API("monitor", func() {
+		Server("monitor", func() {
+			Host("dev", func() {
+				URI("http://localhost:8080")
+			})
+		})
+	})
+	Service("monitor", func() {
+		Method("ticks", func() {
+			StreamingResult(Tick)
+			HTTP(func() {
+				GET("/ticks")
+				SSE(func() {
+					Event("tick")
+					EventID("id")
+				})
+			})
+		})
+		Method("notifications", func() {
+			StreamingResult(Notification)
+			HTTP(func() {
+				GET("/notifications")
+				SSE(func() {
+					Event("tick", Tick)
+					Event("alert", Alert)
+				})
+			})
+		})
+		Method("watch", func() {
+			StreamingResult(Alert)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+	})
+}
+
+var NoStreamingDSL = func() {
+	Service("plain", func() {
+		Method("show", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package asyncapi
+
+import "goa.design/goa/v3/http/codegen/openapi"
+
+// Version is the AsyncAPI specification version of the generated documents.
+const Version = "2.6.0"
+
+// bindingVersion is the version of the WebSocket and HTTP bindings.
+const bindingVersion = "0.1.0"
+
+type (
+	// Document is the root object of an AsyncAPI document.
+	Document struct {
+		AsyncAPI           string              `json:"asyncapi" yaml:"asyncapi"`
+		Info               *Info               `json:"info" yaml:"info"`
+		Servers            map[string]*Server  `json:"servers,omitempty" yaml:"servers,omitempty"`
+		DefaultContentType string              `json:"defaultContentType,omitempty" yaml:"defaultContentType,omitempty"`
+		Channels           map[string]*Channel `json:"channels" yaml:"channels"`
+		Components         *Components         `json:"components,omitempty" yaml:"components,omitempty"`
+	}
+
+	// Info provides metadata about the API.
+	Info struct {
+		Title       string `json:"title" yaml:"title"`
+		Version     string `json:"version" yaml:"version"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// Server describes a message broker, in this case the HTTP server
+	// that accepts the WebSocket connections or sends the server-sent
+	// events.
+	Server struct {
+		URL         string                     `json:"url" yaml:"url"`
+		Protocol    string                     `json:"protocol" yaml:"protocol"`
+		Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+		Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+	}
+
+	// ServerVariable describes a variable of a server URL.
+	ServerVariable struct {
+		Enum        []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
+		Default     interface{}   `json:"default,omitempty" yaml:"default,omitempty"`
+		Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
+	// Channel describes the operations available on a channel, that is a
+	// streaming endpoint path.
+	Channel struct {
+		Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+		Servers     []string               `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Subscribe   *Operation             `json:"subscribe,omitempty" yaml:"subscribe,omitempty"`
+		Publish     *Operation             `json:"publish,omitempty" yaml:"publish,omitempty"`
+		Parameters  map[string]*Parameter  `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		Bindings    map[string]interface{} `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+	}
+
+	// Operation describes the messages sent to (publish) or received from
+	// (subscribe) a channel by the clients.
+	Operation struct {
+		OperationID string                 `json:"operationId" yaml:"operationId"`
+		Summary     string                 `json:"summary,omitempty" yaml:"summary,omitempty"`
+		Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+		Security    []map[string][]string  `json:"security,omitempty" yaml:"security,omitempty"`
+		Bindings    map[string]interface{} `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+		Message     *Message               `json:"message" yaml:"message"`
+	}
+
+	// Message describes a message sent or received on a channel. OneOf
+	// lists the alternative messages if the operation sends more than one
+	// kind of message.
+	Message struct {
+		Name        string          `json:"name,omitempty" yaml:"name,omitempty"`
+		Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+		Payload     *openapi.Schema `json:"payload,omitempty" yaml:"payload,omitempty"`
+		OneOf       []*Message      `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	}
+
+	// Parameter describes a channel parameter, that is a path parameter.
+	Parameter struct {
+		Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+		Schema      *openapi.Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	}
+
+	// Components holds the schemas and security schemes referenced by the
+	// document.
+	Components struct {
+		Schemas         map[string]*openapi.Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+		SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+	}
+
+	// SecurityScheme defines a security scheme used by the operations.
+	SecurityScheme struct {
+		Type         string      `json:"type" yaml:"type"`
+		Description  string      `json:"description,omitempty" yaml:"description,omitempty"`
+		Name         string      `json:"name,omitempty" yaml:"name,omitempty"`
+		In           string      `json:"in,omitempty" yaml:"in,omitempty"`
+		Scheme       string      `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+		BearerFormat string      `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+		Flows        *OAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+	}
+
+	// OAuthFlows lists the supported OAuth2 flows.
+	OAuthFlows struct {
+		Implicit          *OAuthFlow `json:"implicit,omitempty" yaml:"implicit,omitempty"`
+		Password          *OAuthFlow `json:"password,omitempty" yaml:"password,omitempty"`
+		ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
+		AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+	}
+
+	// OAuthFlow describes an OAuth2 flow.
+	OAuthFlow struct {
+		AuthorizationURL string            `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+		TokenURL         string            `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+		RefreshURL       string            `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+		Scopes           map[string]string `json:"scopes" yaml:"scopes"`
+	}
+)
@@ -17,6 +17,9 @@ func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketClientFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := sseClientFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := clientEncodeDecodeFile(genpkg, svc); f != nil {
@@ -49,6 +52,12 @@ func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
+	if data.RequestIDHeader != "" || hasClientLog(data) {
+		codegen.AddImport(sections[0], codegen.GoaNamedImport("http/middleware", "httpmdlwr"))
+	}
+	if hasClientLog(data) {
+		codegen.AddImport(sections[0], codegen.GoaImport("middleware"))
+	}
 	sections = append(sections, &codegen.SectionTemplate{
 		Name:    "client-struct",
 		Source:  clientStructT,
@@ -73,6 +82,14 @@ func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		FuncMap: map[string]interface{}{"hasWebSocket": hasWebSocket},
 	})
 
+	if hasClientLog(data) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-use-logger",
+			Source: clientUseLoggerT,
+			Data:   data,
+		})
+	}
+
 	for _, e := range data.Endpoints {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "client-endpoint-init",
@@ -81,6 +98,7 @@ func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			FuncMap: map[string]interface{}{
 				"isWebSocketEndpoint": isWebSocketEndpoint,
 				"responseStructPkg":   responseStructPkg,
+				"sseEventNames":       sseEventNames,
 			},
 		})
 	}
@@ -250,6 +268,17 @@ func requestStructPkg(m *service.MethodData, def string) string {
 	return def
 }
 
+// hasClientLog returns true if the client logs the requests made to at least
+// one of the service endpoints.
+func hasClientLog(data *ServiceData) bool {
+	for _, e := range data.Endpoints {
+		if e.ClientLog != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func responseStructPkg(m *service.MethodData, def string) string {
 	if m.ResultLoc != nil {
 		return m.ResultLoc.PackageName()
@@ -280,7 +309,9 @@ type {{ .ClientStruct }} struct {
 `
 
 // input: ServiceData
-const clientInitT = `{{ printf "New%s instantiates HTTP clients for all the %s service servers." .ClientStruct .Service.Name | comment }}
+const clientInitT = `{{- $doc := printf "New%s instantiates HTTP clients for all the %s service servers." .ClientStruct .Service.Name }}
+{{- if .RequestIDHeader }}{{ $doc = printf "%s The requests set the %q header to the request ID stored in the request context if any." $doc .RequestIDHeader }}{{ end }}
+{{- comment $doc }}
 func New{{ .ClientStruct }}(
 	scheme string,
 	host string,
@@ -297,6 +328,9 @@ func New{{ .ClientStruct }}(
 	if cfn == nil {
 		cfn = &ConnConfigurer{}
 	}
+{{- end }}
+{{- if .RequestIDHeader }}
+	doer = httpmdlwr.WrapRequestIDDoer(doer, {{ printf "%q" .RequestIDHeader }})
 {{- end }}
 	return &{{ .ClientStruct }}{
 		{{- range .Endpoints }}
@@ -315,6 +349,17 @@ func New{{ .ClientStruct }}(
 }
 `
 
+// input: ServiceData
+const clientUseLoggerT = `{{ printf "UseLogger wraps the endpoint doers so that the requests made to the %s service endpoints and the corresponding responses are logged with logger." .Service.Name | comment }}
+func (c *{{ .ClientStruct }}) UseLogger(logger middleware.Logger) {
+{{- range .Endpoints }}
+	{{- if .ClientLog }}
+	c.{{ .Method.VarName }}Doer = httpmdlwr.WrapLogDoer(c.{{ .Method.VarName }}Doer, logger, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}{{ if .ClientLog.Body }}, httpmdlwr.LogBodyOption({{ range $i, $f := .ClientLog.Redact }}{{ if $i }}, {{ end }}{{ printf "%q" $f }}{{ end }}){{ end }})
+	{{- end }}
+{{- end }}
+}
+`
+
 // input: EndpointData
 const endpointInitT = `{{ printf "%s returns an endpoint that makes HTTP requests to the %s service %s server." .EndpointInit .ServiceName .Method.Name | comment }}
 func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.VarName }} {{ .MultipartRequestEncoder.FuncName }}{{ end }}) goa.Endpoint {
@@ -372,6 +417,16 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			{{- end }}
 		{{- end }}
 		return stream, nil
+	{{- else if .ClientSSE }}
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .ClientSSE.Response.StatusCode }} {
+			return decodeResponse(resp)
+		}
+		return &{{ .ClientSSE.VarName }}{body: resp.Body, reader: goahttp.NewSSEReader(resp.Body, {{ sseEventNames .ClientSSE.Events }})}, nil
 	{{- else }}
 		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
 		if err != nil {
@@ -490,7 +545,55 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 		values := req.URL.Query()
 	{{- end }}
 	{{- range .Payload.Request.QueryParams }}
-		{{- if .MapQueryParams }}
+		{{- template "query_param_encoding" . }}
+	{{- end }}
+	{{- if .Payload.Request.QueryParams }}
+		req.URL.RawQuery = values.Encode()
+	{{- end }}
+	{{- if .MultipartRequestEncoder }}
+		if err := encoder(req).Encode(p); err != nil {
+			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+	{{- else if .Payload.Request.ClientBody }}
+		{{- if .Payload.Request.ContentType }}
+		req.Header.Set("Content-Type", {{ printf "%q" .Payload.Request.ContentType }})
+		{{- end }}
+		{{- if .Payload.Request.ClientBody.Init }}
+		body := {{ .Payload.Request.ClientBody.Init.Name }}({{ range .Payload.Request.ClientBody.Init.ClientArgs }}{{ if .FieldPointer }}&{{ end }}{{ .VarName }}, {{ end }})
+		{{- else }}
+		body := p{{ if .Payload.Request.PayloadAttr }}.{{ .Payload.Request.PayloadAttr }}{{ end }}
+		{{- end }}
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+	{{- end }}
+	{{- if .BasicScheme }}{{ with .BasicScheme }}
+		{{- if not .UsernameRequired }}
+		if p.{{ .UsernameField }} != nil {
+		{{- end }}
+		{{- if not .PasswordRequired }}
+		if p.{{ .PasswordField }} != nil {
+		{{- end }}
+		req.SetBasicAuth({{ if .UsernamePointer }}*{{ end }}p.{{ .UsernameField }}, {{ if .PasswordPointer }}*{{ end }}p.{{ .PasswordField }})
+		{{- if not .UsernameRequired }}
+		}
+		{{- end }}
+		{{- if not .PasswordRequired }}
+		}
+		{{- end }}
+	{{- end }}{{ end }}
+		return nil
+	}
+}
+
+{{- define "query_param_encoding" }}
+		{{- if .DeepObject }}
+		if p.{{ .FieldName }} != nil {
+			{{- range .DeepObject.Fields }}
+				{{- template "query_param_encoding" . }}
+			{{- end }}
+		}
+		{{- else if .MapQueryParams }}
 		for key, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 			{{ template "type_conversion" (typeConversionData .Type.KeyType.Type (aliasedType .FieldType).KeyType.Type "keyStr" "key") }}
 			{{- if eq .Type.ElemType.Type.Name "array" }}
@@ -503,10 +606,23 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			values.Add(keyStr, valueStr)
 			{{- end }}
     }
+		{{- else if and .StringSlice .Delimiter }}
+			if len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}) > 0 {
+				values.Add("{{ .Name }}", goahttp.EncodeDelimitedQueryParam({{ printf "%q" .Delimiter }}, p{{ if .FieldName }}.{{ .FieldName }}{{ end }}...))
+			}
 		{{- else if .StringSlice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				values.Add("{{ .Name }}", value)
 			}
+		{{- else if and .Slice .Delimiter }}
+			if len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}) > 0 {
+				elems := make([]string, 0, len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}))
+				for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+					{{ template "type_conversion" (typeConversionData .Type.ElemType.Type (aliasedType .FieldType).ElemType.Type "valueStr" "value") }}
+					elems = append(elems, valueStr)
+				}
+				values.Add("{{ .Name }}", goahttp.EncodeDelimitedQueryParam({{ printf "%q" .Delimiter }}, elems...))
+			}
 		{{- else if .Slice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type (aliasedType .FieldType).ElemType.Type "valueStr" "value") }}
@@ -538,42 +654,7 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 				values.Add("{{ .Name }}", pStr)
 			{{- end }}
 		{{- end }}
-	{{- end }}
-	{{- if .Payload.Request.QueryParams }}
-		req.URL.RawQuery = values.Encode()
-	{{- end }}
-	{{- if .MultipartRequestEncoder }}
-		if err := encoder(req).Encode(p); err != nil {
-			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
-		}
-	{{- else if .Payload.Request.ClientBody }}
-		{{- if .Payload.Request.ClientBody.Init }}
-		body := {{ .Payload.Request.ClientBody.Init.Name }}({{ range .Payload.Request.ClientBody.Init.ClientArgs }}{{ if .FieldPointer }}&{{ end }}{{ .VarName }}, {{ end }})
-		{{- else }}
-		body := p{{ if .Payload.Request.PayloadAttr }}.{{ .Payload.Request.PayloadAttr }}{{ end }}
-		{{- end }}
-		if err := encoder(req).Encode(&body); err != nil {
-			return goahttp.ErrEncodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
-		}
-	{{- end }}
-	{{- if .BasicScheme }}{{ with .BasicScheme }}
-		{{- if not .UsernameRequired }}
-		if p.{{ .UsernameField }} != nil {
-		{{- end }}
-		{{- if not .PasswordRequired }}
-		if p.{{ .PasswordField }} != nil {
-		{{- end }}
-		req.SetBasicAuth({{ if .UsernamePointer }}*{{ end }}p.{{ .UsernameField }}, {{ if .PasswordPointer }}*{{ end }}p.{{ .PasswordField }})
-		{{- if not .UsernameRequired }}
-		}
-		{{- end }}
-		{{- if not .PasswordRequired }}
-		}
-		{{- end }}
-	{{- end }}{{ end }}
-		return nil
-	}
-}
+{{- end }}
 
 {{- define "map_conversion" }}
   for k{{ if not (eq .Type.KeyType.Type.Name "string") }}Raw{{ end }}, value := range {{ .SourceVar }}{{ if .SourceField }}.{{ .SourceField }}{{ end }} {
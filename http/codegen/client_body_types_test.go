@@ -78,6 +78,7 @@ func TestClientTypes(t *testing.T) {
 		{"client-result-type-validate", testdata.ResultTypeValidateDSL, ResultTypeValidateClientTypesFile},
 		{"client-with-result-collection", testdata.ResultWithResultCollectionDSL, WithResultCollectionClientTypesFile},
 		{"client-empty-error-response-body", testdata.EmptyErrorResponseBodyDSL, EmptyErrorResponseBodyClientTypesFile},
+		{"client-nullable", testdata.PayloadNullableDSL, NullableClientTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -668,7 +669,7 @@ func NewMethodResultWithResultCollectionResultOK(body *MethodResultWithResultCol
 func ValidateMethodResultWithResultCollectionResponseBody(body *MethodResultWithResultCollectionResponseBody) (err error) {
 	if body.A != nil {
 		if err2 := ValidateResulttypeResponseBody(body.A); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "body.a"))
 		}
 	}
 	return
@@ -679,7 +680,7 @@ func ValidateMethodResultWithResultCollectionResponseBody(body *MethodResultWith
 func ValidateResulttypeResponseBody(body *ResulttypeResponseBody) (err error) {
 	if body.X != nil {
 		if err2 := ValidateRtCollectionResponseBody(body.X); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "body.x"))
 		}
 	}
 	return
@@ -688,12 +689,15 @@ func ValidateResulttypeResponseBody(body *ResulttypeResponseBody) (err error) {
 // ValidateRtCollectionResponseBody runs the validations defined on
 // RtCollectionResponseBody
 func ValidateRtCollectionResponseBody(body RtCollectionResponseBody) (err error) {
-	for _, e := range body {
-		if e != nil {
-			if err2 := ValidateRtResponseBody(e); err2 != nil {
-				err = goa.MergeErrors(err, err2)
+	for i, e := range body {
+		err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+			if e != nil {
+				if err2 := ValidateRtResponseBody(e); err2 != nil {
+					err = goa.MergeErrors(err, goa.NestErrors(err2, "body[*]"))
+				}
 			}
-		}
+			return
+		}(), "body", i))
 	}
 	return
 }
@@ -733,3 +737,91 @@ func NewMethodEmptyErrorResponseBodyNotFound(inHeader string) serviceemptyerrorr
 	return v
 }
 `
+
+const NullableClientTypesFile = `// MethodNullableRequestBody is the type of the "ServiceNullable" service
+// "MethodNullable" endpoint HTTP request body.
+type MethodNullableRequestBody struct {
+	Name     *string              ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+	Nickname goa.Nullable[string] ` + "`" + `form:"nickname,omitempty" json:"nickname,omitempty" xml:"nickname,omitempty"` + "`" + `
+	Settings *SettingsRequestBody ` + "`" + `form:"settings,omitempty" json:"settings,omitempty" xml:"settings,omitempty"` + "`" + `
+}
+
+// MarshalJSON encodes MethodNullableRequestBody omitting the nullable
+// attributes that are not set.
+func (body MethodNullableRequestBody) MarshalJSON() ([]byte, error) {
+	type plain MethodNullableRequestBody
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	if !body.Nickname.Set {
+		unset = append(unset, "nickname")
+	}
+	return goa.OmitJSONKeys(data, unset...)
+}
+
+// MethodNullableResponseBody is the type of the "ServiceNullable" service
+// "MethodNullable" endpoint HTTP response body.
+type MethodNullableResponseBody struct {
+	Age goa.Nullable[int] ` + "`" + `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"` + "`" + `
+}
+
+// MarshalJSON encodes MethodNullableResponseBody omitting the nullable
+// attributes that are not set.
+func (body MethodNullableResponseBody) MarshalJSON() ([]byte, error) {
+	type plain MethodNullableResponseBody
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	if !body.Age.Set {
+		unset = append(unset, "age")
+	}
+	return goa.OmitJSONKeys(data, unset...)
+}
+
+// SettingsRequestBody is used to define fields on request body types.
+type SettingsRequestBody struct {
+	Locale goa.Nullable[string] ` + "`" + `form:"locale,omitempty" json:"locale,omitempty" xml:"locale,omitempty"` + "`" + `
+}
+
+// MarshalJSON encodes SettingsRequestBody omitting the nullable attributes
+// that are not set.
+func (body SettingsRequestBody) MarshalJSON() ([]byte, error) {
+	type plain SettingsRequestBody
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	if !body.Locale.Set {
+		unset = append(unset, "locale")
+	}
+	return goa.OmitJSONKeys(data, unset...)
+}
+
+// NewMethodNullableRequestBody builds the HTTP request body from the payload
+// of the "MethodNullable" endpoint of the "ServiceNullable" service.
+func NewMethodNullableRequestBody(p *servicenullable.MethodNullablePayload) *MethodNullableRequestBody {
+	body := &MethodNullableRequestBody{
+		Name:     p.Name,
+		Nickname: p.Nickname,
+	}
+	if p.Settings != nil {
+		body.Settings = marshalServicenullableSettingsToSettingsRequestBody(p.Settings)
+	}
+	return body
+}
+
+// NewMethodNullableResultOK builds a "ServiceNullable" service
+// "MethodNullable" endpoint result from a HTTP "OK" response.
+func NewMethodNullableResultOK(body *MethodNullableResponseBody) *servicenullable.MethodNullableResult {
+	v := &servicenullable.MethodNullableResult{
+		Age: body.Age,
+	}
+
+	return v
+}
+`
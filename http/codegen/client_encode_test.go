@@ -119,6 +119,7 @@ func TestClientEncode(t *testing.T) {
 		{"body-string", testdata.PayloadBodyStringDSL, testdata.PayloadBodyStringEncodeCode},
 		{"body-string-validate", testdata.PayloadBodyStringValidateDSL, testdata.PayloadBodyStringValidateEncodeCode},
 		{"body-user", testdata.PayloadBodyUserDSL, testdata.PayloadBodyUserEncodeCode},
+		{"body-form", testdata.PayloadBodyFormDSL, testdata.PayloadBodyFormEncodeCode},
 		{"body-user-validate", testdata.PayloadBodyUserValidateDSL, testdata.PayloadBodyUserValidateEncodeCode},
 		{"body-array-string", testdata.PayloadBodyArrayStringDSL, testdata.PayloadBodyArrayStringEncodeCode},
 		{"body-array-string-validate", testdata.PayloadBodyArrayStringValidateDSL, testdata.PayloadBodyArrayStringValidateEncodeCode},
@@ -168,6 +169,7 @@ func TestClientEncode(t *testing.T) {
 		{"query-array-alias-validate", testdata.QueryArrayAliasValidateDSL, testdata.QueryArrayAliasValidateEncodeCode},
 		{"query-map-alias", testdata.QueryMapAliasDSL, testdata.QueryMapAliasEncodeCode},
 		{"query-map-alias-validate", testdata.QueryMapAliasValidateDSL, testdata.QueryMapAliasValidateEncodeCode},
+		{"query-deep-object", testdata.PayloadQueryDeepObjectDSL, testdata.PayloadQueryDeepObjectEncodeCode},
 		{"query-array-nested-alias-validate", testdata.QueryArrayNestedAliasValidateDSL, testdata.QueryArrayNestedAliasValidateEncodeCode},
 	}
 	golden := makeGolden(t, "testdata/payload_encode_functions.go")
@@ -210,6 +212,7 @@ func TestClientBuildRequest(t *testing.T) {
 		{"path-string-required", testdata.PayloadPathStringValidateDSL, testdata.PathStringRequiredRequestBuildCode},
 		{"path-string-default", testdata.PayloadPathStringDefaultDSL, testdata.PathStringDefaultRequestBuildCode},
 		{"path-object", testdata.PayloadPathObjectDSL, testdata.PathObjectRequestBuildCode},
+		{"path-string-idempotent", testdata.PayloadPathStringIdempotentDSL, testdata.PathStringIdempotentRequestBuildCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -18,6 +18,8 @@ func TestClientInit(t *testing.T) {
 	}{
 		{"multiple endpoints", testdata.ServerMultiEndpointsDSL, testdata.MultipleEndpointsClientInitCode, 2, 2},
 		{"streaming", testdata.StreamingResultDSL, testdata.StreamingClientInitCode, 3, 2},
+		{"request id", testdata.ServerRequestIDDSL, testdata.RequestIDClientInitCode, 2, 2},
+		{"client log", testdata.ClientLogDSL, testdata.ClientLogClientUseLoggerCode, 3, 3},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
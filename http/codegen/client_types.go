@@ -69,16 +69,29 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 	for _, a := range svc.HTTPEndpoints {
 		adata := data.Endpoint(a.Name())
 		if data := adata.Payload.Request.ClientBody; data != nil {
-			if _, ok := seen[data.Name]; ok {
+			// Bodies that are not user types (e.g. arrays) share the
+			// name of their type kind, use the type reference instead.
+			key := data.Name
+			if data.Def == "" {
+				key = data.VarName
+			}
+			if _, ok := seen[key]; ok {
 				continue
 			}
-			seen[data.Name] = struct{}{}
+			seen[key] = struct{}{}
 			if data.Def != "" {
 				sections = append(sections, &codegen.SectionTemplate{
 					Name:   "client-request-body",
 					Source: typeDeclT,
 					Data:   data,
 				})
+				if len(data.Nullables) > 0 {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "client-request-body-marshal",
+						Source: marshalNullablesT,
+						Data:   data,
+					})
+				}
 			}
 			if data.Init != nil {
 				initData = append(initData, data.Init)
@@ -125,6 +138,13 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 						Source: typeDeclT,
 						Data:   data,
 					})
+					if len(data.Nullables) > 0 {
+						sections = append(sections, &codegen.SectionTemplate{
+							Name:   "client-response-body-marshal",
+							Source: marshalNullablesT,
+							Data:   data,
+						})
+					}
 				}
 				if data.ValidateDef != "" {
 					validatedTypes = append(validatedTypes, data)
@@ -149,6 +169,13 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 							Source: typeDeclT,
 							Data:   data,
 						})
+						if len(data.Nullables) > 0 {
+							sections = append(sections, &codegen.SectionTemplate{
+								Name:   "client-error-body-marshal",
+								Source: marshalNullablesT,
+								Data:   data,
+							})
+						}
 					}
 					if data.ValidateDef != "" {
 						validatedTypes = append(validatedTypes, data)
@@ -165,6 +192,13 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				Source: typeDeclT,
 				Data:   data,
 			})
+			if len(data.Nullables) > 0 {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-body-attributes-marshal",
+					Source: marshalNullablesT,
+					Data:   data,
+				})
+			}
 		}
 
 		if data.ValidateDef != "" {
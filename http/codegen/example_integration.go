@@ -0,0 +1,399 @@
+package codegen
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// integrationData contains the data needed to render the integration
+	// tests of a service.
+	integrationData struct {
+		// Name is the name of the test function.
+		Name string
+		// Service is the service name.
+		Service string
+		// Constructor is the name of the example service constructor.
+		Constructor string
+		// CustomValidations is the name of the example function that
+		// returns the service custom validations if any.
+		CustomValidations string
+		// ServicePkg is the name of the service package.
+		ServicePkg string
+		// ServerPkg is the name of the HTTP server package.
+		ServerPkg string
+		// ClientPkg is the name of the HTTP client package.
+		ClientPkg string
+		// ClientFunc is the name of the function that starts the server
+		// and returns the client.
+		ClientFunc string
+		// ServerArgs lists the HTTP server constructor arguments that
+		// follow the error formatter.
+		ServerArgs []string
+		// WebSocket is true if the HTTP client uses websockets.
+		WebSocket bool
+		// Endpoints lists the expressions used to initialize the service
+		// client, "nil" for the methods that cannot be tested.
+		Endpoints []string
+		// Methods lists the tested methods.
+		Methods []*integrationMethodData
+	}
+
+	// integrationMethodData contains the data needed to render the tests of
+	// a method.
+	integrationMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the name of the service client method.
+		VarName string
+		// HasResult is true if the method returns a result.
+		HasResult bool
+		// Examples lists the request examples.
+		Examples []*integrationExampleData
+	}
+
+	// integrationExampleData contains the data needed to render the test of
+	// a request example.
+	integrationExampleData struct {
+		// Name is the name of the example.
+		Name string
+		// Payload is the Go literal of the payload if any.
+		Payload string
+		// Result is the Go literal of the expected result if any.
+		Result string
+		// Error is the name of the expected error if any.
+		Error string
+	}
+)
+
+// ExampleIntegrationFiles returns the integration_test.go file that tests the
+// example service implementations using the request examples defined in the
+// design, nil if the design does not define request examples that can be
+// tested.
+func ExampleIntegrationFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	fpath := "integration_test.go"
+	if _, err := os.Stat(fpath); !os.IsNotExist(err) {
+		return nil // file already exists, skip it.
+	}
+
+	// determine the unique API package name different from the service names
+	scope := codegen.NewNameScope()
+	for _, svc := range root.Services {
+		scope.Unique(service.Services.Get(svc.Name).PkgName)
+	}
+	apipkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
+
+	var (
+		tests     []*integrationData
+		specs     []*codegen.ImportSpec
+		creds     bool
+		usesPtr   bool
+		hasResult bool
+		hasError  bool
+		hasWS     bool
+	)
+	for _, svc := range root.API.HTTP.Services {
+		sd := HTTPServices.Get(svc.Name())
+		l := &sampleLiteral{scope: sd.Service.Scope, pkg: sd.Service.PkgName, all: true}
+		data := integrationTestData(svc, sd, l, &creds)
+		if data == nil {
+			continue
+		}
+		data.ServicePkg = sd.Service.PkgName
+		data.ServerPkg = scope.Unique(sd.Service.PkgName + "svr")
+		data.ClientPkg = scope.Unique(sd.Service.PkgName + "c")
+		specs = append(specs,
+			&codegen.ImportSpec{Path: path.Join(genpkg, sd.Service.PathName), Name: data.ServicePkg},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "http", sd.Service.PathName, "server"), Name: data.ServerPkg},
+			&codegen.ImportSpec{Path: path.Join(genpkg, "http", sd.Service.PathName, "client"), Name: data.ClientPkg},
+		)
+		for _, m := range data.Methods {
+			for _, ex := range m.Examples {
+				hasResult = hasResult || ex.Result != ""
+				hasError = hasError || ex.Error != ""
+			}
+		}
+		hasWS = hasWS || data.WebSocket
+		usesPtr = usesPtr || l.usesPtr
+		tests = append(tests, data)
+	}
+	if len(tests) == 0 {
+		return nil
+	}
+
+	imports := []*codegen.ImportSpec{{Path: "context"}}
+	if hasError {
+		imports = append(imports, &codegen.ImportSpec{Path: "errors"})
+	}
+	imports = append(imports,
+		&codegen.ImportSpec{Path: "io"},
+		&codegen.ImportSpec{Path: "log"},
+		&codegen.ImportSpec{Path: "net/http"},
+		&codegen.ImportSpec{Path: "net/http/httptest"},
+		&codegen.ImportSpec{Path: "net/url"},
+	)
+	if creds {
+		imports = append(imports, &codegen.ImportSpec{Path: "os"})
+	}
+	if hasResult {
+		imports = append(imports, &codegen.ImportSpec{Path: "reflect"})
+	}
+	if creds {
+		imports = append(imports, &codegen.ImportSpec{Path: "strings"})
+	}
+	imports = append(imports, &codegen.ImportSpec{Path: "testing"})
+	imports = append(imports, codegen.GoaNamedImport("http", "goahttp"))
+	if hasError {
+		imports = append(imports, codegen.GoaImport(""))
+	}
+	if hasWS {
+		imports = append(imports, &codegen.ImportSpec{Path: "github.com/gorilla/websocket"})
+	}
+	imports = append(imports, specs...)
+
+	sections := []*codegen.SectionTemplate{codegen.Header("", apipkg, imports)}
+	if creds {
+		sections = append(sections, &codegen.SectionTemplate{Name: "integration-token-provider", Source: integrationTokenProviderT})
+	}
+	for _, t := range tests {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "integration-test",
+			Source: integrationTestT,
+			Data:   t,
+		})
+	}
+	for _, t := range tests {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "integration-client",
+			Source: integrationClientT,
+			Data:   t,
+		})
+	}
+	if usesPtr {
+		sections = append(sections, &codegen.SectionTemplate{Name: "integration-ptr", Source: integrationPtrT})
+	}
+	return []*codegen.File{{
+		Path:             fpath,
+		SectionTemplates: sections,
+		SkipExist:        true,
+	}}
+}
+
+// integrationTestData returns the data needed to render the integration tests
+// of the given service, nil if the service does not define request examples
+// that can be tested. creds is set if the tests send security credentials.
+func integrationTestData(hs *expr.HTTPServiceExpr, sd *ServiceData, l *sampleLiteral, creds *bool) *integrationData {
+	var (
+		svc  = sd.Service
+		data = &integrationData{
+			Name:        "Test" + svc.StructName + "Integration",
+			Service:     svc.Name,
+			Constructor: "New" + svc.StructName,
+			ClientFunc:  "new" + svc.StructName + "IntegrationClient",
+			WebSocket:   hasWebSocket(sd),
+		}
+	)
+	if len(svc.CustomValidations) > 0 {
+		data.CustomValidations = "New" + svc.StructName + "CustomValidations"
+	}
+	for _, m := range svc.Methods {
+		if ed := sd.Endpoint(m.Name); ed != nil && ed.MultipartRequestEncoder == nil {
+			data.Endpoints = append(data.Endpoints, "hc."+ed.EndpointInit+"()")
+		} else {
+			data.Endpoints = append(data.Endpoints, "nil")
+		}
+	}
+	for _, e := range hs.HTTPEndpoints {
+		meth := e.MethodExpr
+		if len(meth.RequestExamples) == 0 || !integrationTestable(e) {
+			continue
+		}
+		m := svc.Method(meth.Name)
+		md := &integrationMethodData{Name: m.Name, VarName: m.VarName, HasResult: m.ResultRef != ""}
+		for _, ex := range meth.RequestExamples {
+			exd := &integrationExampleData{Name: ex.Name, Error: ex.Error}
+			if m.PayloadRef != "" {
+				exd.Payload = integrationPayload(l, meth, ex.Payload, creds)
+			}
+			if ex.Result != nil {
+				exd.Result = l.value(meth.Result, ex.Result)
+				if expr.IsPrimitive(meth.Result.Type) {
+					exd.Result = fmt.Sprintf("%s(%s)", l.scope.GoFullTypeRef(meth.Result, l.pkg), exd.Result)
+				}
+			}
+			md.Examples = append(md.Examples, exd)
+		}
+		data.Methods = append(data.Methods, md)
+	}
+	if len(data.Methods) == 0 {
+		return nil
+	}
+	if hasWebSocket(sd) {
+		data.ServerArgs = append(data.ServerArgs, "&websocket.Upgrader{}", "nil")
+	}
+	if hasIdempotency(sd) {
+		data.ServerArgs = append(data.ServerArgs, "goahttp.NewMemoryIdempotencyStore()")
+	}
+	if hasWebhookSignature(sd) {
+		data.ServerArgs = append(data.ServerArgs, "nil")
+	}
+	if hasCipher(sd) {
+		data.ServerArgs = append(data.ServerArgs, "nil")
+	}
+	for _, e := range sd.Endpoints {
+		if e.MultipartRequestDecoder != nil {
+			data.ServerArgs = append(data.ServerArgs, e.MultipartRequestDecoder.FuncName)
+		}
+	}
+	for range sd.FileServers {
+		data.ServerArgs = append(data.ServerArgs, "nil")
+	}
+	return data
+}
+
+// integrationTestable returns true if the request examples of the given
+// endpoint can be tested: streaming endpoints, multipart endpoints, endpoints
+// that verify webhook signatures and endpoints that skip the request or
+// response body encoding are not.
+func integrationTestable(e *expr.HTTPEndpointExpr) bool {
+	return !e.MethodExpr.IsStreaming() &&
+		!e.MultipartRequest &&
+		e.WebhookSignature == nil &&
+		!e.SkipRequestBodyEncodeDecode &&
+		!e.SkipResponseBodyEncodeDecode
+}
+
+// integrationPayload returns the Go literal of the payload v of the given
+// method. The security credentials are initialized with the tokenProvider
+// function, creds is set if the payload contains credentials.
+func integrationPayload(l *sampleLiteral, m *expr.MethodExpr, v interface{}, creds *bool) string {
+	att := m.Payload
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return l.value(att, v)
+	}
+	providers := make(map[string]string)
+	for _, req := range m.Requirements {
+		for _, sch := range req.Schemes {
+			tags := map[expr.SchemeKind][][2]string{
+				expr.BasicAuthKind: {{"security:username", "username"}, {"security:password", "password"}},
+				expr.APIKeyKind:    {{"security:apikey:" + sch.SchemeName, "key"}},
+				expr.JWTKind:       {{"security:token", "token"}},
+				expr.OAuth2Kind:    {{"security:accesstoken", "token"}},
+			}[sch.Kind]
+			for _, t := range tags {
+				if n := expr.TaggedAttribute(att, t[0]); n != "" {
+					providers[n] = fmt.Sprintf("tokenProvider(%q, %q)", sch.SchemeName, t[1])
+				}
+			}
+		}
+	}
+	ex, _ := v.(map[string]interface{})
+	var fields []string
+	for _, nat := range *obj {
+		field := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		if p, ok := providers[nat.Name]; ok {
+			*creds = true
+			if att.IsPrimitivePointer(nat.Name, true) {
+				l.usesPtr = true
+				p = "ptr(" + p + ")"
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s,", field, p))
+			continue
+		}
+		if lit := l.field(att, nat, ex[nat.Name]); lit != "" {
+			fields = append(fields, fmt.Sprintf("%s: %s,", field, lit))
+		}
+	}
+	return l.object(att, fields)
+}
+
+const (
+	integrationTokenProviderT = `
+// tokenProvider returns the credentials sent by the integration tests to the
+// methods secured with the given security scheme. name is "username" or
+// "password" for basic auth schemes, "key" for API key schemes and "token" for
+// JWT and OAuth2 schemes. The default implementation reads the credentials
+// from environment variables, e.g. JWT_TOKEN for the token of the "jwt"
+// scheme.
+var tokenProvider = func(scheme, name string) string {
+	return os.Getenv(strings.ToUpper(scheme + "_" + name))
+}
+`
+
+	// input: integrationData
+	integrationTestT = `
+{{ printf "%s sends the request examples defined in the design to the %s service HTTP server and checks the responses." .Name .Service | comment }}
+func {{ .Name }}(t *testing.T) {
+	c := {{ .ClientFunc }}(t)
+{{- range $m := .Methods }}
+	t.Run({{ printf "%q" .Name }}, func(t *testing.T) {
+	{{- range .Examples }}
+		t.Run({{ printf "%q" .Name }}, func(t *testing.T) {
+			{{ if .Result }}res, {{ else if $m.HasResult }}_, {{ end }}err := c.{{ $m.VarName }}(context.Background(){{ if .Payload }}, {{ .Payload }}{{ end }})
+		{{- if .Error }}
+			var named goa.GoaErrorNamer
+			if !errors.As(err, &named) || named.GoaErrorName() != {{ printf "%q" .Error }} {
+				t.Errorf("got error %v, expected %q error", err, {{ printf "%q" .Error }})
+			}
+		{{- else }}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			{{- if .Result }}
+			expected := {{ .Result }}
+			if !reflect.DeepEqual(res, expected) {
+				t.Errorf("got result %+v, expected %+v", res, expected)
+			}
+			{{- end }}
+		{{- end }}
+		})
+	{{- end }}
+	})
+{{- end }}
+}
+`
+
+	// input: integrationData
+	integrationClientT = `
+{{ printf "%s starts the %s service HTTP server and returns a client that sends requests to it. The server is stopped when the test completes." .ClientFunc .Service | comment }}
+func {{ .ClientFunc }}(t *testing.T) *{{ .ServicePkg }}.Client {
+	svc := {{ .Constructor }}(log.New(io.Discard, "", 0))
+	mux := goahttp.NewMuxer()
+	eh := func(_ context.Context, _ http.ResponseWriter, err error) {
+		t.Errorf("server error: %v", err)
+	}
+{{- if .CustomValidations }}
+	endpoints, err := {{ .ServicePkg }}.NewEndpoints(svc, {{ .CustomValidations }}())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := {{ .ServerPkg }}.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh, nil{{ range .ServerArgs }}, {{ . }}{{ end }})
+{{- else }}
+	server := {{ .ServerPkg }}.New({{ .ServicePkg }}.NewEndpoints(svc), mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh, nil{{ range .ServerArgs }}, {{ . }}{{ end }})
+{{- end }}
+	{{ .ServerPkg }}.Mount(mux, server)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := {{ .ClientPkg }}.NewClient(u.Scheme, u.Host, ts.Client(), goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if .WebSocket }}, nil, nil{{ end }})
+	return {{ .ServicePkg }}.NewClient({{ range $i, $e := .Endpoints }}{{ if $i }}, {{ end }}{{ $e }}{{ end }})
+}
+`
+
+	integrationPtrT = `
+// ptr returns a pointer to the given value.
+func ptr[T any](v T) *T {
+	return &v
+}
+`
+)
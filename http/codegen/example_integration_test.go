@@ -0,0 +1,46 @@
+package codegen
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestExampleIntegrationFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"integration", testdata.IntegrationDSL, testdata.IntegrationExampleCode},
+		{"no request examples", testdata.ServerVersionDSL, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ExampleIntegrationFiles("gen", expr.Root)
+			if c.Code == "" {
+				if len(fs) != 0 {
+					t.Fatalf("got %d files, expected 0", len(fs))
+				}
+				return
+			}
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			var buf bytes.Buffer
+			for _, s := range fs[0].SectionTemplates[1:] {
+				if err := s.Write(&buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+			if code != c.Code {
+				t.Errorf("invalid code for %s: got\n%s\ngot vs. expected:\n%s", fs[0].Path, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
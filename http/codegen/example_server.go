@@ -100,9 +100,16 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 				"Services": svcdata,
 				"APIPkg":   apiPkg,
 			},
-			FuncMap: map[string]interface{}{"needStream": needStream, "hasWebSocket": hasWebSocket},
+			FuncMap: map[string]interface{}{"needStream": needStream, "hasWebSocket": hasWebSocket, "hasIdempotency": hasIdempotency, "hasWebhookSignature": hasWebhookSignature, "hasCipher": hasCipher},
+		},
+		{
+			Name:   "server-http-middleware",
+			Source: httpSvrMiddlewareT,
+			Data: map[string]interface{}{
+				"Services":        svcdata,
+				"MethodOverrides": root.API.HTTP.MethodOverrides,
+			},
 		},
-		{Name: "server-http-middleware", Source: httpSvrMiddlewareT},
 		{
 			Name:   "server-http-end",
 			Source: httpSvrEndT,
@@ -147,18 +154,31 @@ func dummyMultipartFile(genpkg string, root *expr.RootExpr, svc *expr.HTTPServic
 		data := HTTPServices.Get(svc.Name())
 		specs = append(specs, &codegen.ImportSpec{
 			Path: path.Join(genpkg, data.Service.PathName),
-			Name: scope.Unique(data.Service.PkgName, "svc"),
+			Name: data.Service.PkgName,
 		})
 
+		var svrPkg string
+		for _, e := range data.Endpoints {
+			if e.MultipartRequestDecoder != nil && e.MultipartRequestDecoder.Decode != nil {
+				svrPkg = scope.Unique(data.Service.PkgName+"svr", "svr")
+				specs = append(specs, &codegen.ImportSpec{
+					Path: path.Join(genpkg, "http", data.Service.PathName, "server"),
+					Name: svrPkg,
+				})
+				break
+			}
+		}
+
 		apiPkg := scope.Unique(strings.ToLower(codegen.Goify(root.API.Name, false)), "api")
 		sections = []*codegen.SectionTemplate{codegen.Header("", apiPkg, specs)}
 		for _, e := range data.Endpoints {
 			if e.MultipartRequestDecoder != nil {
 				mustGen = true
 				sections = append(sections, &codegen.SectionTemplate{
-					Name:   "dummy-multipart-request-decoder",
-					Source: dummyMultipartRequestDecoderImplT,
-					Data:   e.MultipartRequestDecoder,
+					Name:    "dummy-multipart-request-decoder",
+					Source:  dummyMultipartRequestDecoderImplT,
+					Data:    e.MultipartRequestDecoder,
+					FuncMap: map[string]interface{}{"serverPkg": func() string { return svrPkg }},
 				})
 			}
 			if e.MultipartRequestEncoder != nil {
@@ -185,8 +205,12 @@ const (
 	// input: MultipartData
 	dummyMultipartRequestDecoderImplT = `{{ printf "%s implements the multipart decoder for service %q endpoint %q. The decoder must populate the argument p after encoding." .FuncName .ServiceName .MethodName | comment }}
 func {{ .FuncName }}(mr *multipart.Reader, p *{{ .Payload.Ref }}) error {
+{{- if .Decode }}
+	return {{ serverPkg }}.{{ .Decode.FuncName }}(mr, p)
+{{- else }}
 	// Add multipart request decoder logic here
 	return nil
+{{- end }}
 }
 `
 
@@ -251,7 +275,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- end }}
 	{{- range $svc := .Services }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh, nil{{ if hasWebSocket $svc }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ range .FileServers }}, nil{{ end }})
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh, nil{{ if hasWebSocket $svc }}, upgrader, nil{{ end }}{{ if hasIdempotency $svc }}, goahttp.NewMemoryIdempotencyStore(){{ end }}{{ if hasWebhookSignature $svc }}, nil{{ end }}{{ if hasCipher $svc }}, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ range .FileServers }}, nil{{ end }})
 		{{-  else }}
 		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh, nil{{ range .FileServers }}, nil{{ end }})
 		{{-  end }}
@@ -273,6 +297,7 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- end }}
 `
 
+	// input: map[string]interface{}{"Services":[]*ServiceData, "MethodOverrides":[]string}
 	httpSvrMiddlewareT = `
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
@@ -280,6 +305,10 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{
 		handler = httpmdlwr.Log(adapter)(handler)
 		handler = httpmdlwr.RequestID()(handler)
+	{{- if and .MethodOverrides .Services }}
+		// Override the method of POST requests before they are routed.
+		handler = {{ (index .Services 0).Service.PkgName }}svr.MethodOverrideMiddleware()(handler)
+	{{- end }}
 	}
 `
 
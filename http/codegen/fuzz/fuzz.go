@@ -0,0 +1,217 @@
+/*
+Package fuzz implements a plugin that generates Go fuzz tests for the HTTP
+server request decoders.
+
+Enable the plugin by importing the package in the design:
+
+	import (
+	    _ "goa.design/goa/v3/http/codegen/fuzz"
+	    . "goa.design/goa/v3/dsl"
+	)
+
+The "gen" command then generates a fuzz_test.go file in the HTTP server
+package of each service. The file defines one fuzz target per endpoint, for
+example FuzzDecodeShowRequest, that routes requests built from arbitrary query
+strings and bodies to the generated request decoder and fails if the decoder
+panics. The seed corpus contains a request built from the design examples.
+Run the targets with:
+
+	go test -fuzz=FuzzDecodeShowRequest ./gen/http/<service>/server
+
+Endpoints that do not decode a payload, that skip the request body decoding or
+that use a user provided multipart decoder do not get a fuzz target.
+*/
+package fuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"regexp"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	goahttp "goa.design/goa/v3/http"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// targetData contains the data needed to render a fuzz target.
+type targetData struct {
+	// Name is the name of the fuzz target.
+	Name string
+	// MethodName is the name of the endpoint method.
+	MethodName string
+	// RequestDecoder is the name of the request decoder function.
+	RequestDecoder string
+	// Verb is the HTTP method of the route used to build the requests.
+	Verb string
+	// Pattern is the route path as registered in the muxer.
+	Pattern string
+	// Path is the request path built from the path parameter examples.
+	Path string
+	// Query is the query string built from the query parameter examples.
+	Query string
+	// Body is the JSON encoding of the request body example.
+	Body string
+}
+
+// wildcard matches the route path wildcards.
+var wildcard = regexp.MustCompile(`{\*?([a-zA-Z0-9_]+)}`)
+
+func init() {
+	codegen.RegisterPluginLast("fuzz", "gen", nil, Generate)
+}
+
+// Generate appends the fuzz test files to the generated files.
+func Generate(_ string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			files = append(files, Files(r)...)
+		}
+	}
+	return files, nil
+}
+
+// Files returns the fuzz test files, one per HTTP service that defines at
+// least one endpoint with a request decoder.
+func Files(root *expr.RootExpr) []*codegen.File {
+	if root.API == nil || root.API.HTTP == nil {
+		return nil
+	}
+	var fw []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		if f := fuzzFile(svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// fuzzFile returns the file containing the fuzz targets of the given service,
+// nil if none of the service endpoints decodes a request.
+func fuzzFile(svc *expr.HTTPServiceExpr) *codegen.File {
+	data := httpcodegen.HTTPServices.Get(svc.Name())
+	var targets []*targetData
+	for _, e := range data.Endpoints {
+		if !supported(e) {
+			continue
+		}
+		targets = append(targets, buildTargetData(e))
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	var (
+		path  = filepath.Join(codegen.Gendir, "http", data.Service.PathName, "server", "fuzz_test.go")
+		title = fmt.Sprintf("%s HTTP server request decoder fuzz tests", svc.Name())
+	)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "bytes"},
+			{Path: "net/http"},
+			{Path: "net/http/httptest"},
+			{Path: "testing"},
+			codegen.GoaNamedImport("http", "goahttp"),
+		}),
+	}
+	for _, t := range targets {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "fuzz-decode-request",
+			Source: fuzzTargetT,
+			Data:   t,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// supported returns true if a fuzz target can exercise the request decoder of
+// the given endpoint.
+func supported(e *httpcodegen.EndpointData) bool {
+	return e.Payload.Ref != "" &&
+		e.MultipartRequestDecoder == nil &&
+		!e.Method.SkipRequestBodyEncodeDecode &&
+		len(e.Routes) > 0
+}
+
+// buildTargetData computes the data needed to render the fuzz target of the
+// given endpoint. The requests are routed through the first endpoint route.
+func buildTargetData(e *httpcodegen.EndpointData) *targetData {
+	var (
+		route = e.Routes[0]
+		req   = e.Payload.Request
+		query = url.Values{}
+		body  string
+	)
+	params := make(map[string]*httpcodegen.ParamData, len(req.PathParams))
+	for _, p := range req.PathParams {
+		params[p.Name] = p
+	}
+	path := wildcard.ReplaceAllStringFunc(route.Path, func(w string) string {
+		p, ok := params[wildcard.FindStringSubmatch(w)[1]]
+		if !ok {
+			return w
+		}
+		return url.PathEscape(goahttp.EncodePathParam(p.Name, p.Style, p.Explode, exampleValues(p.Example)...))
+	})
+	for _, p := range req.QueryParams {
+		if p.Map || p.MapStringSlice {
+			continue
+		}
+		for _, v := range exampleValues(p.Example) {
+			query.Add(p.Name, v)
+		}
+	}
+	if req.ServerBody != nil && req.ServerBody.Example != nil {
+		if b, err := json.Marshal(req.ServerBody.Example); err == nil {
+			body = string(b)
+		}
+	}
+	return &targetData{
+		Name:           "Fuzz" + e.RequestDecoder,
+		MethodName:     e.Method.Name,
+		RequestDecoder: e.RequestDecoder,
+		Verb:           route.Verb,
+		Pattern:        route.Path,
+		Path:           path,
+		Query:          query.Encode(),
+		Body:           body,
+	}
+}
+
+// exampleValues returns the string representation of the given primitive or
+// array example value.
+func exampleValues(ex interface{}) []string {
+	if ex == nil {
+		return nil
+	}
+	v := reflect.ValueOf(ex)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []string{fmt.Sprint(ex)}
+	}
+	vals := make([]string, v.Len())
+	for i := range vals {
+		vals[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return vals
+}
+
+// input: targetData
+const fuzzTargetT = `{{ printf "%s feeds arbitrary query strings and bodies to the %q endpoint request decoder and fails if it panics." .Name .MethodName | comment }}
+func {{ .Name }}(f *testing.F) {
+	f.Add({{ printf "%q" .Query }}, []byte({{ printf "%q" .Body }}))
+	mux := goahttp.NewMuxer()
+	decodeRequest := {{ .RequestDecoder }}(mux, goahttp.RequestDecoder)
+	mux.Handle({{ printf "%q" .Verb }}, {{ printf "%q" .Pattern }}, func(w http.ResponseWriter, r *http.Request) {
+		decodeRequest(r)
+	})
+	f.Fuzz(func(t *testing.T, query string, body []byte) {
+		r := httptest.NewRequest({{ printf "%q" .Verb }}, {{ printf "%q" .Path }}, bytes.NewReader(body))
+		r.URL.RawQuery = query
+		r.RequestURI = r.URL.RequestURI()
+		mux.ServeHTTP(httptest.NewRecorder(), r)
+	})
+}
+`
@@ -0,0 +1,36 @@
+package fuzz
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/fuzz/testdata"
+)
+
+func TestFiles(t *testing.T) {
+	root := httpcodegen.RunHTTPDSL(t, testdata.FuzzDSL)
+	fs := Files(root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if want := filepath.Join("gen", "http", "documents", "server", "fuzz_test.go"); fs[0].Path != want {
+		t.Errorf("got path %q, expected %q", fs[0].Path, want)
+	}
+	sections := fs[0].Section("fuzz-decode-request")
+	if len(sections) != 1 {
+		t.Fatalf("got %d fuzz targets, expected 1", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.FuzzDecodeUpdateRequestCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.FuzzDecodeUpdateRequestCode))
+	}
+}
+
+func TestFilesNoDecoder(t *testing.T) {
+	root := httpcodegen.RunHTTPDSL(t, testdata.NoDecoderDSL)
+	if fs := Files(root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
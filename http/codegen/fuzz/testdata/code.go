@@ -0,0 +1,19 @@
+package testdata
+
+var FuzzDecodeUpdateRequestCode = `// FuzzDecodeUpdateRequest feeds arbitrary query strings and bodies to the
+// "update" endpoint request decoder and fails if it panics.
+func FuzzDecodeUpdateRequest(f *testing.F) {
+	f.Add("tags=a&tags=b", []byte("{\"title\":\"Report\"}"))
+	mux := goahttp.NewMuxer()
+	decodeRequest := DecodeUpdateRequest(mux, goahttp.RequestDecoder)
+	mux.Handle("PUT", "/documents/{id}", func(w http.ResponseWriter, r *http.Request) {
+		decodeRequest(r)
+	})
+	f.Fuzz(func(t *testing.T, query string, body []byte) {
+		r := httptest.NewRequest("PUT", "/documents/42", bytes.NewReader(body))
+		r.URL.RawQuery = query
+		r.RequestURI = r.URL.RequestURI()
+		mux.ServeHTTP(httptest.NewRecorder(), r)
+	})
+}
+`
@@ -0,0 +1,54 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FuzzDSL = func() {
+	Service("documents", func() {
+		Method("update", func() {
+			Payload(func() {
+				Attribute("id", Int, func() {
+					Example(42)
+				})
+				Attribute("tags", ArrayOf(String), func() {
+					Example([]string{"a", "b"})
+				})
+				Attribute("title", String, func() {
+					Example("Report")
+				})
+				Required("id")
+			})
+			HTTP(func() {
+				PUT("/documents/{id}")
+				Param("tags")
+			})
+		})
+		Method("list", func() {
+			Result(ArrayOf(String))
+			HTTP(func() {
+				GET("/documents")
+			})
+		})
+		Method("upload", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/documents")
+				Header("name:X-Name")
+				SkipRequestBodyEncodeDecode()
+			})
+		})
+	})
+}
+
+var NoDecoderDSL = func() {
+	Service("health", func() {
+		Method("check", func() {
+			HTTP(func() {
+				GET("/health")
+			})
+		})
+	})
+}
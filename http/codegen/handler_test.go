@@ -24,6 +24,9 @@ func TestHandlerInit(t *testing.T) {
 		{"no payload result", testdata.ServerNoPayloadResultDSL, testdata.ServerNoPayloadResultHandlerConstructorCode},
 		{"payload result", testdata.ServerPayloadResultDSL, testdata.ServerPayloadResultHandlerConstructorCode},
 		{"payload result error", testdata.ServerPayloadResultErrorDSL, testdata.ServerPayloadResultErrorHandlerConstructorCode},
+		{"conditional", testdata.ServerConditionalDSL, testdata.ServerConditionalHandlerConstructorCode},
+		{"sunset", testdata.ServerSunsetDSL, testdata.ServerSunsetHandlerConstructorCode},
+		{"trace", testdata.ServerTraceDSL, testdata.ServerTraceHandlerConstructorCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,265 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// MultipartDecodeData contains the data needed to render the decoder
+	// that maps the parts of a multipart request to the payload fields.
+	MultipartDecodeData struct {
+		// FuncName is the name of the decoder function.
+		FuncName string
+		// ServiceName is the name of the service.
+		ServiceName string
+		// MethodName is the name of the method.
+		MethodName string
+		// PayloadRef is the reference to the service payload type.
+		PayloadRef string
+		// MaxDepth is the Go expression for the maximum number of
+		// nested multipart bodies.
+		MaxDepth string
+		// MaxPartSize is the Go expression for the maximum size of the
+		// content of a part.
+		MaxPartSize string
+		// MaxSize is the Go expression for the maximum size of all the
+		// parts.
+		MaxSize string
+		// PartsDecoder is the name of the function that decodes the
+		// payload from the request parts.
+		PartsDecoder string
+	}
+
+	// MultipartPartsDecoderData contains the data needed to render a
+	// function that decodes a value of an object type from multipart
+	// parts.
+	MultipartPartsDecoderData struct {
+		// Name is the name of the function.
+		Name string
+		// TypeRef is the reference to the decoded type.
+		TypeRef string
+		// Code is the body of the function.
+		Code string
+	}
+)
+
+// buildMultipartDecodeData returns the data needed to render the decoder
+// funcName that maps the parts of the multipart request of e to the fields of
+// the payload of type payloadRef. It records the functions that decode the
+// payload and the user types of its attributes from multipart parts in sd.
+func buildMultipartDecodeData(e *expr.HTTPEndpointExpr, funcName, payloadRef string, sd *ServiceData) *MultipartDecodeData {
+	maxDepth := "goahttp.DefaultMultipartMaxDepth"
+	if d := e.MultipartMaxDepth(); d >= 0 {
+		maxDepth = strconv.Itoa(d)
+	}
+	maxPartSize := "goahttp.DefaultMultipartMaxPartSize"
+	if n := e.MultipartMaxPartSize(); n > 0 {
+		maxPartSize = strconv.FormatInt(n, 10)
+	}
+	maxSize := "goahttp.DefaultMultipartMaxSize"
+	if n := e.MultipartMaxSize(); n > 0 {
+		maxSize = strconv.FormatInt(n, 10)
+	}
+	obj := e.MethodExpr.Payload
+	if ut, ok := obj.Type.(expr.UserType); ok {
+		obj = ut.Attribute()
+	}
+	var names []string
+	if bobj := expr.AsObject(e.Body.Type); bobj != nil {
+		for _, nat := range *bobj {
+			names = append(names, nat.Name)
+		}
+	}
+	decoder := "decode" + codegen.Goify(sd.Service.Name, true) + codegen.Goify(e.Name(), true) + "MultipartParts"
+	d := &MultipartPartsDecoderData{Name: decoder, TypeRef: payloadRef}
+	sd.MultipartPartsDecoders = append(sd.MultipartPartsDecoders, d)
+	d.Code = multipartPartsDecodeCode(obj, names, "&"+strings.TrimPrefix(payloadRef, "*")+"{}", sd)
+	return &MultipartDecodeData{
+		FuncName:     funcName,
+		ServiceName:  sd.Service.Name,
+		MethodName:   e.Name(),
+		PayloadRef:   payloadRef,
+		MaxDepth:     maxDepth,
+		MaxPartSize:  maxPartSize,
+		MaxSize:      maxSize,
+		PartsDecoder: decoder,
+	}
+}
+
+// multipartUserTypeDecoder returns the name of the function that decodes a
+// value of the given user type from multipart parts, recording the function
+// in sd if needed.
+func multipartUserTypeDecoder(ut expr.UserType, sd *ServiceData) string {
+	svc := sd.Service
+	name := "decode" + codegen.Goify(svc.Scope.GoTypeName(&expr.AttributeExpr{Type: ut}), true) + "MultipartParts"
+	for _, d := range sd.MultipartPartsDecoders {
+		if d.Name == name {
+			return name
+		}
+	}
+	att := &expr.AttributeExpr{Type: ut}
+	d := &MultipartPartsDecoderData{
+		Name:    name,
+		TypeRef: svc.Scope.GoFullTypeRef(att, svc.PkgName),
+	}
+	// Record the function before generating its code so that recursive
+	// types reuse it.
+	sd.MultipartPartsDecoders = append(sd.MultipartPartsDecoders, d)
+	obj := ut.Attribute()
+	var names []string
+	for _, nat := range *expr.AsObject(obj.Type) {
+		names = append(names, nat.Name)
+	}
+	d.Code = multipartPartsDecodeCode(obj, names, "&"+svc.Scope.GoFullTypeName(att, svc.PkgName)+"{}", sd)
+	return name
+}
+
+// multipartPartsDecodeCode returns the body of the function that decodes the
+// attributes of obj with the given names from multipart parts. init is the Go
+// expression that initializes the decoded value.
+func multipartPartsDecodeCode(obj *expr.AttributeExpr, names []string, init string, sd *ServiceData) string {
+	var (
+		b        strings.Builder
+		required []string
+	)
+	for _, n := range names {
+		if obj.IsRequired(n) {
+			required = append(required, n)
+		}
+	}
+	b.WriteString("var err error\n")
+	b.WriteString("v := " + init + "\n")
+	if len(required) > 0 {
+		b.WriteString("seen := make(map[string]struct{})\n")
+	}
+	for _, n := range names {
+		att := obj.Find(n)
+		if !obj.HasDefaultValue(n) || obj.IsRequired(n) || !expr.IsPrimitive(att.Type) {
+			continue
+		}
+		fmt.Fprintf(&b, "v.%s = %#v\n", codegen.GoifyAtt(att, n, true), att.DefaultValue)
+	}
+	b.WriteString("for _, part := range parts {\n")
+	if len(required) > 0 {
+		b.WriteString("seen[part.Name] = struct{}{}\n")
+	}
+	b.WriteString("switch part.Name {\n")
+	for _, n := range names {
+		att := obj.Find(n)
+		field := "v." + codegen.GoifyAtt(att, n, true)
+		fmt.Fprintf(&b, "case %q:\n", n)
+		if types, ok := att.Meta["multipart:content-type"]; ok {
+			fmt.Fprintf(&b, "if perr := goahttp.ValidateMultipartContentType(%q, part", n)
+			for _, t := range types {
+				fmt.Fprintf(&b, ", %q", t)
+			}
+			b.WriteString("); perr != nil {\nerr = goa.MergeErrors(err, perr)\ncontinue\n}\n")
+		}
+		elem, isArray := att, false
+		if arr := expr.AsArray(att.Type); arr != nil {
+			elem, isArray = arr.ElemType, true
+		}
+		assign := func(val string, ptr bool) {
+			switch {
+			case isArray:
+				fmt.Fprintf(&b, "%s = append(%s, %s)\n", field, field, val)
+			case ptr:
+				fmt.Fprintf(&b, "%s = &%s\n", field, val)
+			default:
+				fmt.Fprintf(&b, "%s = %s\n", field, val)
+			}
+		}
+		if ut, ok := elem.Type.(expr.UserType); ok {
+			fmt.Fprintf(&b, "if part.Parts == nil {\nerr = goa.MergeErrors(err, goa.InvalidFieldTypeError(%q, part.ContentType, \"nested multipart body\"))\ncontinue\n}\n", n)
+			fmt.Fprintf(&b, "val, perr := %s(part.Parts)\n", multipartUserTypeDecoder(ut, sd))
+			b.WriteString("if perr != nil {\nerr = goa.MergeErrors(err, perr)\ncontinue\n}\n")
+			assign("val", false)
+			continue
+		}
+		ptr := !isArray && obj.IsPrimitivePointer(n, true)
+		switch elem.Type.Kind() {
+		case expr.BytesKind:
+			assign("part.Data", false)
+			continue
+		case expr.StringKind:
+			if ptr {
+				b.WriteString("val := string(part.Data)\n")
+				assign("val", true)
+			} else {
+				assign("string(part.Data)", false)
+			}
+			continue
+		}
+		parse, conv, expected := multipartParse(elem.Type.Kind())
+		fmt.Fprintf(&b, "raw, perr := %s\n", parse)
+		fmt.Fprintf(&b, "if perr != nil {\nerr = goa.MergeErrors(err, goa.InvalidFieldTypeError(%q, string(part.Data), %q))\ncontinue\n}\n", n, expected)
+		if conv == "" {
+			assign("raw", ptr)
+			continue
+		}
+		fmt.Fprintf(&b, "val := %s(raw)\n", conv)
+		assign("val", ptr)
+	}
+	b.WriteString("}\n}\n")
+	for _, n := range required {
+		fmt.Fprintf(&b, "if _, ok := seen[%q]; !ok {\nerr = goa.MergeErrors(err, goa.MissingFieldError(%q, \"multipart body\"))\n}\n", n, n)
+	}
+	b.WriteString("return v, err")
+	return b.String()
+}
+
+// multipartParse returns the Go expression that parses the content of a part
+// holding a value of the given kind, the conversion applied to the parsed
+// value if any and the description of the kind used in errors.
+func multipartParse(kind expr.Kind) (parse, conv, expected string) {
+	switch kind {
+	case expr.BooleanKind:
+		return "strconv.ParseBool(string(part.Data))", "", "boolean"
+	case expr.IntKind:
+		return "strconv.ParseInt(string(part.Data), 10, strconv.IntSize)", "int", "integer"
+	case expr.Int32Kind:
+		return "strconv.ParseInt(string(part.Data), 10, 32)", "int32", "integer"
+	case expr.Int64Kind:
+		return "strconv.ParseInt(string(part.Data), 10, 64)", "", "integer"
+	case expr.UIntKind:
+		return "strconv.ParseUint(string(part.Data), 10, strconv.IntSize)", "uint", "unsigned integer"
+	case expr.UInt32Kind:
+		return "strconv.ParseUint(string(part.Data), 10, 32)", "uint32", "unsigned integer"
+	case expr.UInt64Kind:
+		return "strconv.ParseUint(string(part.Data), 10, 64)", "", "unsigned integer"
+	case expr.Float32Kind:
+		return "strconv.ParseFloat(string(part.Data), 32)", "float32", "float"
+	case expr.Float64Kind:
+		return "strconv.ParseFloat(string(part.Data), 64)", "", "float"
+	default:
+		panic(fmt.Sprintf("unsupported multipart part kind %d", kind)) // bug
+	}
+}
+
+// input: MultipartDecodeData
+const multipartDecodeT = `{{ printf "%s decodes the multipart request of the %q service %q endpoint into the payload. The parts are mapped to the payload fields by name, the parts of nested multipart bodies to the fields of user types. Requests nesting multipart bodies deeper than the maximum depth or exceeding the maximum sizes are rejected. The function may be given to New as the multipart request decoder." .FuncName .ServiceName .MethodName | comment }}
+func {{ .FuncName }}(mr *multipart.Reader, p *{{ .PayloadRef }}) error {
+	parts, err := goahttp.ReadMultipart(mr, {{ .MaxDepth }}, {{ .MaxPartSize }}, {{ .MaxSize }})
+	if err != nil {
+		return err
+	}
+	v, err := {{ .PartsDecoder }}(parts)
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+`
+
+// input: MultipartPartsDecoderData
+const multipartPartsDecoderT = `{{ printf "%s decodes a value of type %s from the given multipart parts." .Name .TypeRef | comment }}
+func {{ .Name }}(parts []*goahttp.MultipartPart) ({{ .TypeRef }}, error) {
+	{{ .Code }}
+}
+`
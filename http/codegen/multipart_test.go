@@ -103,6 +103,23 @@ func TestServerMultipartNewFunc(t *testing.T) {
 	}
 }
 
+func TestServerMultipartDecode(t *testing.T) {
+	RunHTTPDSL(t, testdata.PayloadMultipartNestedDSL)
+	fs := ServerFiles("gen", expr.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var code string
+	for _, s := range fs[1].SectionTemplates {
+		if s.Name == "multipart-decode" || s.Name == "multipart-parts-decoder" {
+			code += codegen.SectionCode(t, s)
+		}
+	}
+	if code != testdata.MultipartNestedDecodeCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.MultipartNestedDecodeCode))
+	}
+}
+
 func TestClientMultipartNewFunc(t *testing.T) {
 	const genpkg = "gen"
 	cases := []struct {
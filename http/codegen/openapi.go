@@ -1,7 +1,11 @@
 package codegen
 
 import (
+	"path/filepath"
+	"strings"
+
 	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
 	"goa.design/goa/v3/expr"
 	openapiv2 "goa.design/goa/v3/http/codegen/openapi/v2"
 	openapiv3 "goa.design/goa/v3/http/codegen/openapi/v3"
@@ -32,3 +36,56 @@ func OpenAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
 	}
 	return files, nil
 }
+
+// OpenAPIServiceFiles returns the files for the OpenAPI specs of each HTTP
+// service of the given API. The specs of a service only describe the service
+// endpoints and the schemas they reference and are generated in the service
+// directory (e.g. gen/http/<service>/openapi.json).
+func OpenAPIServiceFiles(root *expr.RootExpr) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		dir := filepath.Join(codegen.Gendir, "http", service.Services.Get(svc.Name()).PathName)
+
+		// OpenAPI v2
+		fs, err := openapiv2.ServiceFiles(root, svc, dir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fs...)
+
+		// OpenAPI v3
+		fs, err = openapiv3.ServiceFiles(root, svc, dir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fs...)
+	}
+	return files, nil
+}
+
+// HideOpenAPIFeatures omits the methods gated by the given feature flags from
+// the OpenAPI specifications by setting their "openapi:generate" meta to
+// "false". The types only used by these methods are omitted as well.
+func HideOpenAPIFeatures(root *expr.RootExpr, features []string) {
+	if len(features) == 0 {
+		return
+	}
+	hidden := make(map[string]struct{}, len(features))
+	for _, f := range features {
+		hidden[strings.TrimSpace(f)] = struct{}{}
+	}
+	for _, svc := range root.Services {
+		for _, m := range svc.Methods {
+			if m.FeatureGate == nil {
+				continue
+			}
+			if _, ok := hidden[m.FeatureGate.Name]; !ok {
+				continue
+			}
+			if m.Meta == nil {
+				m.Meta = expr.MetaExpr{}
+			}
+			m.Meta["openapi:generate"] = []string{"false"}
+		}
+	}
+}
@@ -24,6 +24,35 @@ func ExtensionsFromExpr(mdata expr.MetaExpr) map[string]interface{} {
 	return swag
 }
 
+// ExtensionsFromMethod generates the openapi extensions of the operations of
+// the given method. The extensions include the extensions defined in the
+// method meta, the "x-idempotent" extension if the method idempotency is
+// declared explicitly and the "x-slo" extension if the method defines service
+// level objectives.
+func ExtensionsFromMethod(m *expr.MethodExpr) map[string]interface{} {
+	exts := ExtensionsFromExpr(m.Meta)
+	if m.Idempotent == nil && m.SLO == nil {
+		return exts
+	}
+	if exts == nil {
+		exts = make(map[string]interface{})
+	}
+	if _, ok := exts["x-idempotent"]; !ok && m.Idempotent != nil {
+		exts["x-idempotent"] = *m.Idempotent
+	}
+	if _, ok := exts["x-slo"]; !ok && m.SLO != nil {
+		slo := make(map[string]interface{})
+		if m.SLO.LatencyP99 > 0 {
+			slo["latencyP99"] = m.SLO.LatencyP99.String()
+		}
+		if m.SLO.SuccessRate > 0 {
+			slo["successRate"] = m.SLO.SuccessRate
+		}
+		exts["x-slo"] = slo
+	}
+	return exts
+}
+
 // extensionsFromExprWithPrefix generates openapi extensions from
 // the given meta expression with keys starting the given prefix.
 func extensionsFromExprWithPrefix(mdata expr.MetaExpr, prefix string) map[string]interface{} {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -24,6 +25,7 @@ type (
 		Description  string             `json:"description,omitempty" yaml:"description,omitempty"`
 		DefaultValue interface{}        `json:"default,omitempty" yaml:"default,omitempty"`
 		Example      interface{}        `json:"example,omitempty" yaml:"example,omitempty"`
+		Nullable     bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
 
 		// Hyper schema
 		Media     *Media  `json:"media,omitempty" yaml:"media,omitempty"`
@@ -50,10 +52,27 @@ type (
 		// Union
 		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
 
+		// Composition
+		AllOf []*Schema `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+		OneOf []*Schema `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+		Not   *Schema   `json:"not,omitempty" yaml:"not,omitempty"`
+
+		// Discriminator identifies the schemas of the types that extend
+		// the type described by the schema, OpenAPI v3 only.
+		Discriminator *Discriminator `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+
 		// Extensions defines the OpenAPI extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
 	}
 
+	// Discriminator represents an OpenAPI v3 discriminator object.
+	Discriminator struct {
+		// PropertyName is the name of the discriminator property.
+		PropertyName string `json:"propertyName" yaml:"propertyName"`
+		// Mapping maps the discriminator values to schema references.
+		Mapping map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	}
+
 	// Type is the JSON type enum.
 	Type string
 
@@ -428,6 +447,7 @@ func (s *Schema) Dup() *Schema {
 		Title:                s.Title,
 		Media:                s.Media,
 		ReadOnly:             s.ReadOnly,
+		Nullable:             s.Nullable,
 		PathStart:            s.PathStart,
 		Links:                s.Links,
 		Ref:                  s.Ref,
@@ -442,6 +462,10 @@ func (s *Schema) Dup() *Schema {
 		MaxItems:             s.MaxItems,
 		Required:             s.Required,
 		AdditionalProperties: s.AdditionalProperties,
+		AllOf:                s.AllOf,
+		OneOf:                s.OneOf,
+		Not:                  s.Not,
+		Discriminator:        s.Discriminator,
 	}
 	for n, p := range s.Properties {
 		js.Properties[n] = p.Dup()
@@ -467,6 +491,22 @@ func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr)
 	s.Description = at.Description
 	s.Example = at.Example(api.ExampleGenerator)
 	s.Extensions = ExtensionsFromExpr(at.Meta)
+	s.ReadOnly = at.IsGenerated()
+	if at.IsNullable() {
+		// OpenAPI v2 does not support nullable values, use the de-facto
+		// standard extension.
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]interface{})
+		}
+		s.Extensions["x-nullable"] = true
+	}
+	if unit := at.Unit(); unit != "" {
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]interface{})
+		}
+		s.Extensions["x-unit"] = unit
+		s.Description = appendNote(s.Description, UnitDescription(unit))
+	}
 	initAttributeValidation(s, at)
 
 	return s
@@ -508,6 +548,56 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 		}
 	}
 	s.Required = val.Required
+	s.Description = appendNote(s.Description, EqualFieldsDescription(val))
+	s.Description = appendNote(s.Description, FieldGroupsDescription(val))
+}
+
+// UnitDescription returns the text documenting the unit of an attribute in its
+// schema description.
+func UnitDescription(unit string) string {
+	return fmt.Sprintf("Unit: %s.", unit)
+}
+
+// appendNote appends note to the description desc on a new line.
+func appendNote(desc, note string) string {
+	if note == "" {
+		return desc
+	}
+	if desc == "" {
+		return note
+	}
+	return desc + "\n" + note
+}
+
+// EqualFieldsDescription returns the text documenting the EqualFields
+// validations of val. JSON schema cannot express these validations so they are
+// documented in the schema description instead.
+func EqualFieldsDescription(val *expr.ValidationExpr) string {
+	notes := make([]string, len(val.EqualFields))
+	for i, f := range val.EqualFields {
+		notes[i] = fmt.Sprintf("%s must be equal to %s.", f[1], f[0])
+	}
+	return strings.Join(notes, "\n")
+}
+
+// FieldGroupsDescription returns the text documenting the MutuallyExclusive and
+// RequiredOneOf validations of val. The groups listed by both validations are
+// documented as requiring exactly one attribute.
+func FieldGroupsDescription(val *expr.ValidationExpr) string {
+	var notes []string
+	for _, g := range val.RequiredOneOf {
+		if val.HasMutuallyExclusive(g...) {
+			notes = append(notes, fmt.Sprintf("Exactly one of %s must be set.", strings.Join(g, ", ")))
+		} else {
+			notes = append(notes, fmt.Sprintf("At least one of %s must be set.", strings.Join(g, ", ")))
+		}
+	}
+	for _, g := range val.MutuallyExclusive {
+		if !val.HasRequiredOneOf(g...) {
+			notes = append(notes, fmt.Sprintf("At most one of %s may be set.", strings.Join(g, ", ")))
+		}
+	}
+	return strings.Join(notes, "\n")
 }
 
 // toSchemaHrefs produces hrefs that replace the path wildcards with JSON
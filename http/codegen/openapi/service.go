@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+)
+
+// ServiceRoot returns a copy of root whose HTTP expression only defines the
+// given service. It is used to build the specification of a single service.
+func ServiceRoot(root *expr.RootExpr, svc *expr.HTTPServiceExpr) *expr.RootExpr {
+	h := *root.API.HTTP
+	h.Services = []*expr.HTTPServiceExpr{svc}
+	api := *root.API
+	api.HTTP = &h
+	r := *root
+	r.API = &api
+	return &r
+}
+
+// ReferencedSchemas returns the schemas in defs referenced by v directly or
+// indirectly via other schemas. prefix is the prefix of the references to the
+// schemas in defs, e.g. "#/definitions/".
+func ReferencedSchemas(v interface{}, defs map[string]*Schema, prefix string) map[string]*Schema {
+	res := make(map[string]*Schema)
+	var visit func(interface{})
+	visit = func(v interface{}) {
+		for _, ref := range schemaRefs(v) {
+			if !strings.HasPrefix(ref, prefix) {
+				continue
+			}
+			name := strings.TrimPrefix(ref, prefix)
+			if _, ok := res[name]; ok {
+				continue
+			}
+			s, ok := defs[name]
+			if !ok {
+				continue
+			}
+			res[name] = s
+			visit(s)
+		}
+	}
+	visit(v)
+	return res
+}
+
+// schemaRefs returns the values of the "$ref" fields found in the JSON
+// representation of v.
+func schemaRefs(v interface{}) []string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic("openapi: " + err.Error()) // bug
+	}
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		panic("openapi: " + err.Error()) // bug
+	}
+	var refs []string
+	var collect func(interface{})
+	collect = func(v interface{}) {
+		switch actual := v.(type) {
+		case map[string]interface{}:
+			for k, val := range actual {
+				if ref, ok := val.(string); ok && k == "$ref" {
+					refs = append(refs, ref)
+					continue
+				}
+				collect(val)
+			}
+		case []interface{}:
+			for _, val := range actual {
+				collect(val)
+			}
+		}
+	}
+	collect(raw)
+	return refs
+}
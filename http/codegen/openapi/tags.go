@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 
 	"goa.design/goa/v3/expr"
@@ -19,9 +20,13 @@ type Tag struct {
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 	// Extensions defines the OpenAPI extensions.
 	Extensions map[string]interface{} `json:"-" yaml:"-"`
+	// Order is the position of the tag in the list of tags of the
+	// specification, nil if the design does not specify one.
+	Order *int `json:"-" yaml:"-"`
 }
 
 // TagsFromExpr extracts the OpenAPI related metadata from the given expression.
+// The tags are sorted with SortTags.
 func TagsFromExpr(mdata expr.MetaExpr) (tags []*Tag) {
 	var keys []string
 	for k := range mdata {
@@ -53,12 +58,16 @@ func TagsFromExpr(mdata expr.MetaExpr) (tags []*Tag) {
 			switch chunks[3] {
 			case "desc":
 				tag.Description = mdata[key][0]
-			case "url":
+			case "order":
+				if o, err := strconv.Atoi(mdata[key][0]); err == nil {
+					tag.Order = &o
+				}
+			case "url", "externalDocs:url":
 				if tag.ExternalDocs == nil {
 					tag.ExternalDocs = &ExternalDocs{}
 				}
 				tag.ExternalDocs.URL = mdata[key][0]
-			case "url:desc":
+			case "url:desc", "externalDocs:desc":
 				if tag.ExternalDocs == nil {
 					tag.ExternalDocs = &ExternalDocs{}
 				}
@@ -72,10 +81,27 @@ func TagsFromExpr(mdata expr.MetaExpr) (tags []*Tag) {
 			}
 		}
 	}
+	SortTags(tags)
 
 	return
 }
 
+// SortTags sorts the tags by ascending order. Tags without an order are listed
+// after the ordered tags, tags with the same order or without order are sorted
+// by name.
+func SortTags(tags []*Tag) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		oi, oj := tags[i].Order, tags[j].Order
+		switch {
+		case oi != nil && oj != nil && *oi != *oj:
+			return *oi < *oj
+		case (oi == nil) != (oj == nil):
+			return oi != nil
+		}
+		return tags[i].Name < tags[j].Name
+	})
+}
+
 // TagNamesFromExpr computes the names of the OpenAPI tags specified in the
 // given metadata expressions.
 func TagNamesFromExpr(mdata expr.MetaExpr) (tagNames []string) {
@@ -283,6 +283,15 @@ func paramsFromExpr(params *expr.MappedAttributeExpr, path string) []*Parameter
 				break
 			}
 		}
+		if style, _ := expr.HTTPParamStyle(at); in == "query" && style == "deepObject" {
+			// OpenAPI v2 does not support the deepObject style,
+			// describe each property with a separate parameter.
+			for _, nat := range *expr.AsObject(at.Type) {
+				name := fmt.Sprintf("%s[%s]", pn, nat.Name)
+				res = append(res, paramFor(nat.Attribute, name, in, required && at.IsRequired(nat.Name)))
+			}
+			return nil
+		}
 		param := paramFor(at, pn, in, required)
 		res = append(res, param)
 		return nil
@@ -332,6 +341,24 @@ func paramsFromHeaders(endpoint *expr.HTTPEndpointExpr) []*Parameter {
 	return params
 }
 
+// sparseFieldsParam returns the query string parameter used to select the
+// result fields of methods that use SparseFields.
+func sparseFieldsParam(m *expr.MethodExpr) *Parameter {
+	names := m.SparseFieldNames()
+	enum := make([]interface{}, len(names))
+	for i, n := range names {
+		enum[i] = n
+	}
+	return &Parameter{
+		Name:             expr.SparseFieldsParam,
+		In:               "query",
+		Description:      "Comma separated list of the top level result fields included in the response. All the fields are included when not set.",
+		Type:             "array",
+		Items:            &Items{Type: "string", Enum: enum},
+		CollectionFormat: "csv",
+	}
+}
+
 func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter {
 	alias := at
 	if expr.IsAlias(at.Type) {
@@ -345,6 +372,10 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 		Required:    required,
 		Type:        at.Type.Name(),
 	}
+	if in == "query" {
+		// OpenAPI v2 does not support the allowReserved flag.
+		p.AllowEmptyValue, _ = expr.HTTPParamFlags(alias)
+	}
 	if expr.IsArray(at.Type) {
 		p.Items = itemsFromExpr(expr.AsArray(at.Type).ElemType)
 		p.CollectionFormat = "multi"
@@ -366,6 +397,25 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 		p.Format = "byte"
 	}
 	p.Extensions = openapi.ExtensionsFromExpr(at.Meta)
+	if d := expr.HTTPParamDelimiter(alias); in == "query" && d != "" && expr.IsArray(at.Type) {
+		switch d {
+		case ",":
+			p.CollectionFormat = "csv"
+		case "|":
+			p.CollectionFormat = "pipes"
+		case " ":
+			p.CollectionFormat = "ssv"
+		case "\t":
+			p.CollectionFormat = "tsv"
+		default:
+			// OpenAPI v2 does not support custom delimiters.
+			p.CollectionFormat = "csv"
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]interface{})
+			}
+			p.Extensions["x-delimiter"] = d
+		}
+	}
 	initValidations(alias, p)
 	return p
 }
@@ -429,6 +479,25 @@ func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 			Description: at.Description,
 			Type:        at.Type.Name(),
 		}
+		switch at.Type {
+		case expr.Int, expr.UInt, expr.UInt32, expr.UInt64:
+			header.Type = "integer"
+		case expr.Int32, expr.Int64:
+			header.Type = "integer"
+			header.Format = at.Type.Name()
+		case expr.Float32:
+			header.Type = "number"
+			header.Format = "float"
+		case expr.Float64:
+			header.Type = "number"
+			header.Format = "double"
+		case expr.Bytes:
+			header.Type = "string"
+			header.Format = "byte"
+		}
+		if expr.IsArray(at.Type) {
+			header.Items = itemsFromExpr(expr.AsArray(at.Type).ElemType)
+		}
 		initValidations(at, header)
 		res[n] = header
 		return nil
@@ -521,10 +590,13 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		key = expr.HTTPWildcardRegex.ReplaceAllString(key, "/{$1}")
 		params := paramsFromExpr(endpoint.Params, key)
 		params = append(params, paramsFromHeaders(endpoint)...)
+		if endpoint.MethodExpr.SparseFields {
+			params = append(params, sparseFieldsParam(endpoint.MethodExpr))
+		}
 		produces := []string{}
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
-			if endpoint.MethodExpr.IsStreaming() {
+			if endpoint.MethodExpr.IsStreaming() && endpoint.SSE == nil {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -535,16 +607,20 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			}
 			resp := responseSpecFromExpr(s, root, r, endpoint.Service.Name())
 			responses[strconv.Itoa(r.StatusCode)] = resp
-			if r.ContentType != "" {
+			cts := r.ContentTypes
+			if len(cts) == 0 && r.ContentType != "" {
+				cts = []string{r.ContentType}
+			}
+			for _, rct := range cts {
 				foundCT := false
 				for _, ct := range produces {
-					if ct == r.ContentType {
+					if ct == rct {
 						foundCT = true
 						break
 					}
 				}
 				if !foundCT {
-					produces = append(produces, r.ContentType)
+					produces = append(produces, rct)
 				}
 			}
 		}
@@ -556,9 +632,17 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		var consumes []string
 		if endpoint.MultipartRequest {
 			consumes = []string{"multipart/form-data"}
+		} else if endpoint.RequestContentType != "" {
+			consumes = []string{endpoint.RequestContentType}
 		}
 
-		if endpoint.Body.Type != expr.Empty {
+		if endpoint.RequestContentType == expr.FormURLEncodedContentType && expr.IsObject(endpoint.Body.Type) {
+			// Form bodies are described with one formData parameter
+			// per attribute.
+			for _, nat := range *expr.AsObject(endpoint.Body.Type) {
+				params = append(params, paramFor(nat.Attribute, nat.Name, "formData", endpoint.Body.IsRequired(nat.Name)))
+			}
+		} else if endpoint.Body.Type != expr.Empty {
 			in := "body"
 			if endpoint.MultipartRequest {
 				in = "formData"
@@ -595,7 +679,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		}
 
 		// replace http with ws for streaming endpoints
-		if endpoint.MethodExpr.IsStreaming() {
+		if endpoint.MethodExpr.IsStreaming() && endpoint.SSE == nil {
 			for i := len(schemes) - 1; i >= 0; i-- {
 				if schemes[i] == "http" {
 					news := append([]string{"ws"}, schemes[i+1:]...)
@@ -608,7 +692,7 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			}
 		}
 
-		description := endpoint.Description()
+		description := openapi.DescriptionWithVersions(endpoint.Description(), endpoint.MethodExpr)
 
 		requirements := make([]map[string][]string, len(endpoint.Requirements))
 		for i, req := range endpoint.Requirements {
@@ -646,8 +730,8 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 			Produces:     produces,
 			Responses:    responses,
 			Schemes:      schemes,
-			Deprecated:   false,
-			Extensions:   openapi.ExtensionsFromExpr(endpoint.MethodExpr.Meta),
+			Deprecated:   !endpoint.MethodExpr.Sunset.IsZero(),
+			Extensions:   openapi.ExtensionsFromMethod(endpoint.MethodExpr),
 			Security:     requirements,
 		}
 
@@ -9,6 +9,7 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
 )
 
 // Files returns the OpenAPI v2 specification files in JSON and YAML formats.
@@ -17,6 +18,29 @@ func Files(root *expr.RootExpr) ([]*codegen.File, error) {
 	if err != nil {
 		return nil, err
 	}
+	return specFiles(spec, filepath.Join(codegen.Gendir, "http")), nil
+}
+
+// ServiceFiles returns the OpenAPI v2 specification files in JSON and YAML
+// formats of the given service. The specification only describes the service
+// endpoints and the schemas they reference including the schemas shared with
+// other services. The files are generated in the given directory.
+func ServiceFiles(root *expr.RootExpr, svc *expr.HTTPServiceExpr, dir string) ([]*codegen.File, error) {
+	root = openapi.ServiceRoot(root, svc)
+	spec, err := NewV2(root, root.API.Servers[0].Hosts[0])
+	if err != nil {
+		return nil, err
+	}
+	defs := spec.Definitions
+	spec.Definitions = nil
+	if refs := openapi.ReferencedSchemas(spec, defs, "#/definitions/"); len(refs) > 0 {
+		spec.Definitions = refs
+	}
+	return specFiles(spec, dir), nil
+}
+
+// specFiles returns the JSON and YAML files rendering spec in dir.
+func specFiles(spec interface{}, dir string) []*codegen.File {
 	jsonSection := &codegen.SectionTemplate{
 		Name:    "openapi",
 		FuncMap: template.FuncMap{"toJSON": toJSON},
@@ -31,14 +55,14 @@ func Files(root *expr.RootExpr) ([]*codegen.File, error) {
 	}
 	return []*codegen.File{
 		{
-			Path:             filepath.Join(codegen.Gendir, "http", "openapi.json"),
+			Path:             filepath.Join(dir, "openapi.json"),
 			SectionTemplates: []*codegen.SectionTemplate{jsonSection},
 		},
 		{
-			Path:             filepath.Join(codegen.Gendir, "http", "openapi.yaml"),
+			Path:             filepath.Join(dir, "openapi.yaml"),
 			SectionTemplates: []*codegen.SectionTemplate{yamlSection},
 		},
-	}, nil
+	}
 }
 
 func toJSON(d interface{}) string {
@@ -40,6 +40,9 @@ func TestSections(t *testing.T) {
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
 		{"path-with-wildcards", testdata.PathWithWildcardDSL},
+		{"query-deep-object", testdata.QueryDeepObjectDSL},
+		{"query-param-flags", testdata.QueryParamFlagsDSL},
+		{"explicit-body-array", testdata.ResultExplicitBodyArrayDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -127,6 +130,7 @@ func TestValidations(t *testing.T) {
 		{"string", testdata.StringValidationDSL},
 		{"integer", testdata.IntValidationDSL},
 		{"array", testdata.ArrayValidationDSL},
+		{"field-groups", testdata.FieldGroupsValidationDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -3,7 +3,6 @@ package openapiv3
 import (
 	"fmt"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -234,15 +233,17 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	// request body
 	var requestBody *RequestBodyRef
 	if e.Body.Type != expr.Empty {
-		ct := "application/json" // TBD: need a way to specify method media type in design...
-		if e.MultipartRequest {
+		ct := "application/json"
+		if e.RequestContentType != "" {
+			ct = e.RequestContentType
+		} else if e.MultipartRequest {
 			ct = "multipart/form-data"
 		}
 		mt := &MediaType{Schema: bodies.RequestBody}
 		initExamples(mt, e.Body, rand)
 		requestBody = &RequestBodyRef{Value: &RequestBody{
 			Description: e.Body.Description,
-			Required:    e.Body.Type != expr.Empty,
+			Required:    e.RequestBodyRequired(),
 			Content:     map[string]*MediaType{ct: mt},
 			Extensions:  openapi.ExtensionsFromExpr(e.Body.Meta),
 		}}
@@ -253,6 +254,9 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	{
 		ps := paramsFromPath(e.Params, key, rand)
 		ps = append(ps, paramsFromHeadersAndCookies(e, rand)...)
+		if m.SparseFields {
+			ps = append(ps, sparseFieldsParam(m))
+		}
 		params = make([]*ParameterRef, len(ps))
 		for i, p := range ps {
 			params[i] = &ParameterRef{Value: p}
@@ -264,7 +268,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	{
 		responses = make(map[string]*ResponseRef, len(e.Responses))
 		for _, r := range e.Responses {
-			if e.MethodExpr.IsStreaming() {
+			if e.MethodExpr.IsStreaming() && e.SSE == nil {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -319,15 +323,15 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	return &Operation{
 		Tags:         tagNames,
 		Summary:      summary,
-		Description:  e.Description(),
+		Description:  openapi.DescriptionWithVersions(e.Description(), m),
 		OperationID:  parseOperationIDTemplate(operationIDFormat, svc.Name(), e.Name(), routeIndex),
 		Parameters:   params,
 		RequestBody:  requestBody,
 		Responses:    responses,
 		Security:     buildSecurityRequirements(e.Requirements),
-		Deprecated:   false,
+		Deprecated:   !m.Sunset.IsZero(),
 		ExternalDocs: openapi.DocsFromExpr(m.Docs, m.Meta),
-		Extensions:   openapi.ExtensionsFromExpr(m.Meta),
+		Extensions:   openapi.ExtensionsFromMethod(m),
 	}
 }
 
@@ -621,18 +625,12 @@ func buildTags(api *expr.APIExpr) []*openapi.Tag {
 		}
 	}
 
-	// sort tag names alphabetically
-	var keys []string
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
 	var tags []*openapi.Tag
 	{
-		for _, k := range keys {
-			tags = append(tags, m[k])
+		for _, t := range m {
+			tags = append(tags, t)
 		}
+		openapi.SortTags(tags)
 
 		if len(tags) == 0 {
 			// add service name and description to the tags since we tag every
@@ -141,13 +141,19 @@ func TestBuildOperation(t *testing.T) {
 		Name: "request_object_body",
 		DSL:  dsls.RequestObjectBody(svcName, "request_object_body"),
 
+		ExpectedRequestBody: &requestBody{"", tobj("name", tstring), false},
+		ExpectedResponses:   responses{"204": {Description: "No Content response."}},
+	}, {
+		Name: "request_required_object_body",
+		DSL:  dsls.RequestRequiredObjectBody(svcName, "request_required_object_body"),
+
 		ExpectedRequestBody: &requestBody{"", tobj("name", tstring), true},
 		ExpectedResponses:   responses{"204": {Description: "No Content response."}},
 	}, {
 		Name: "request_streaming_string_body",
 		DSL:  dsls.RequestObjectBody(svcName, "request_streaming_string_body"),
 
-		ExpectedRequestBody: &requestBody{"", tobj("name", tstring), true},
+		ExpectedRequestBody: &requestBody{"", tobj("name", tstring), false},
 		ExpectedResponses:   responses{"204": {Description: "No Content response."}},
 	}, {
 		Name: "response_array_of_string",
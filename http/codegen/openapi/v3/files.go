@@ -9,11 +9,32 @@ import (
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
 )
 
 // Files returns the OpenAPI v3 specification files in JSON and YAML formats.
 func Files(root *expr.RootExpr) ([]*codegen.File, error) {
 	spec := New(root)
+	return specFiles(spec, filepath.Join(codegen.Gendir, "http")), nil
+}
+
+// ServiceFiles returns the OpenAPI v3 specification files in JSON and YAML
+// formats of the given service. The specification only describes the service
+// endpoints and the schemas they reference including the schemas shared with
+// other services. The files are generated in the given directory.
+func ServiceFiles(root *expr.RootExpr, svc *expr.HTTPServiceExpr, dir string) ([]*codegen.File, error) {
+	root = openapi.ServiceRoot(root, svc)
+	spec := New(root)
+	defs := spec.Components.Schemas
+	spec.Components.Schemas = nil
+	if refs := openapi.ReferencedSchemas(spec, defs, "#/components/schemas/"); len(refs) > 0 {
+		spec.Components.Schemas = refs
+	}
+	return specFiles(spec, dir), nil
+}
+
+// specFiles returns the JSON and YAML files rendering spec in dir.
+func specFiles(spec interface{}, dir string) []*codegen.File {
 	jsonSection := &codegen.SectionTemplate{
 		Name:    "openapi_v3",
 		FuncMap: template.FuncMap{"toJSON": toJSON},
@@ -26,17 +47,16 @@ func Files(root *expr.RootExpr) ([]*codegen.File, error) {
 		Source:  "{{ toYAML .}}",
 		Data:    spec,
 	}
-
 	return []*codegen.File{
 		{
-			Path:             filepath.Join(codegen.Gendir, "http", "openapi3.json"),
+			Path:             filepath.Join(dir, "openapi3.json"),
 			SectionTemplates: []*codegen.SectionTemplate{jsonSection},
 		},
 		{
-			Path:             filepath.Join(codegen.Gendir, "http", "openapi3.yaml"),
+			Path:             filepath.Join(dir, "openapi3.yaml"),
 			SectionTemplates: []*codegen.SectionTemplate{yamlSection},
 		},
-	}, nil
+	}
 }
 
 func toJSON(d interface{}) string {
@@ -42,9 +42,20 @@ func TestFiles(t *testing.T) {
 		{"with-spaces", testdata.WithSpacesDSL},
 		{"with-map", testdata.WithMapDSL},
 		{"path-with-wildcards", testdata.PathWithWildcardDSL},
+		{"path-param-style", testdata.PathParamStyleDSL},
+		{"query-deep-object", testdata.QueryDeepObjectDSL},
+		{"query-param-flags", testdata.QueryParamFlagsDSL},
+		{"discriminator", testdata.DiscriminatorDSL},
 		{"with-tags", testdata.WithTagsDSL},
 		{"with-tags-swagger", testdata.WithTagsSwaggerDSL},
+		{"with-tags-order", testdata.WithTagsOrderDSL},
+		{"generated-attribute", testdata.GeneratedAttributeDSL},
+		{"query-param-delimiter", testdata.QueryParamDelimiterDSL},
+		{"slo", testdata.SLODSL},
+		{"explicit-body-array", testdata.ResultExplicitBodyArrayDSL},
 		{"typename", testdata.TypenameDSL},
+		{"multiple-content-types", testdata.MultipleContentTypesDSL},
+		{"method-versions", testdata.MethodVersionsDSL},
 		// TestEndpoints
 		{"endpoint", testdata.ExtensionDSL},
 		{"endpoint-swagger", testdata.ExtensionSwaggerDSL},
@@ -52,6 +63,7 @@ func TestFiles(t *testing.T) {
 		{"string", testdata.StringValidationDSL},
 		{"integer", testdata.IntValidationDSL},
 		{"array", testdata.ArrayValidationDSL},
+		{"field-groups", testdata.FieldGroupsValidationDSL},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -24,7 +24,42 @@ func paramsFromPath(params *expr.MappedAttributeExpr, path string, rand *expr.Ex
 				break
 			}
 		}
-		res = append(res, paramFor(at, pn, in, required, rand))
+		param := paramFor(at, pn, in, required, rand)
+		switch style, explode := expr.HTTPParamStyle(at); {
+		case in == "path" && style != "simple":
+			param.Style = style
+			param.Explode = &explode
+		case in == "query" && style == "deepObject":
+			explode = true
+			param.Style = style
+			param.Explode = &explode
+			// The parameter types are not listed in the components,
+			// inline the object schema.
+			obj := at
+			if ut, ok := at.Type.(expr.UserType); ok {
+				obj = ut.Attribute()
+			}
+			param.Schema = newSchemafier(rand).schemafy(obj)
+		case in == "query" && expr.HTTPParamDelimiter(at) != "":
+			delimiter := expr.HTTPParamDelimiter(at)
+			explode = false
+			param.Explode = &explode
+			switch delimiter {
+			case "|":
+				param.Style = "pipeDelimited"
+			case " ":
+				param.Style = "spaceDelimited"
+			default:
+				param.Style = "form"
+				if delimiter != "," {
+					if param.Extensions == nil {
+						param.Extensions = make(map[string]interface{})
+					}
+					param.Extensions["x-delimiter"] = delimiter
+				}
+			}
+		}
+		res = append(res, param)
 		return nil
 	})
 	return res
@@ -54,6 +89,28 @@ func paramsFromHeadersAndCookies(endpoint *expr.HTTPEndpointExpr, rand *expr.Exa
 	return params
 }
 
+// sparseFieldsParam returns the query string parameter used to select the
+// result fields of methods that use SparseFields.
+func sparseFieldsParam(m *expr.MethodExpr) *Parameter {
+	names := m.SparseFieldNames()
+	enum := make([]interface{}, len(names))
+	for i, n := range names {
+		enum[i] = n
+	}
+	explode := false
+	return &Parameter{
+		Name:        expr.SparseFieldsParam,
+		In:          "query",
+		Description: "Comma separated list of the top level result fields included in the response. All the fields are included when not set.",
+		Style:       "form",
+		Explode:     &explode,
+		Schema: &openapi.Schema{
+			Type:  openapi.Array,
+			Items: &openapi.Schema{Type: openapi.String, Enum: enum},
+		},
+	}
+}
+
 // paramFor converts the given attribute into a OpenAPI spec parameter.
 func paramFor(att *expr.AttributeExpr, name, in string, required bool, rand *expr.ExampleGenerator) *Parameter {
 	param := &Parameter{
@@ -65,6 +122,13 @@ func paramFor(att *expr.AttributeExpr, name, in string, required bool, rand *exp
 		Schema:          newSchemafier(rand).schemafy(att),
 		Extensions:      openapi.ExtensionsFromExpr(att.Meta),
 	}
+	if in == "query" {
+		// Parameters using AllowEmptyValue or AllowReserved get exactly
+		// the flags declared in the design.
+		if allowEmpty, allowReserved := expr.HTTPParamFlags(att); allowEmpty || allowReserved {
+			param.AllowEmptyValue, param.AllowReserved = allowEmpty, allowReserved
+		}
+	}
 	initExamples(param, att, rand)
 	return param
 }
@@ -39,12 +39,18 @@ func responseFromExpr(r *expr.HTTPResponseExpr, bodies map[int][]*openapi.Schema
 	var content map[string]*MediaType
 	{
 		if r.Body.Type != expr.Empty {
-			content = make(map[string]*MediaType)
-			content[ct] = &MediaType{
-				Schema:     bodies[r.StatusCode][0],
-				Extensions: openapi.ExtensionsFromExpr(r.Body.Meta),
+			cts := r.ContentTypes
+			if len(cts) == 0 {
+				cts = []string{ct}
+			}
+			content = make(map[string]*MediaType, len(cts))
+			for _, ct := range cts {
+				content[ct] = &MediaType{
+					Schema:     bodies[r.StatusCode][0],
+					Extensions: openapi.ExtensionsFromExpr(r.Body.Meta),
+				}
+				initExamples(content[ct], r.Body, rand)
 			}
-			initExamples(content[ct], r.Body, rand)
 		}
 	}
 	desc := r.Description
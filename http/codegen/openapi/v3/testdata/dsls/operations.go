@@ -45,6 +45,22 @@ var RequestObjectBody = func(svc, met string) func() {
 	}
 }
 
+var RequestRequiredObjectBody = func(svc, met string) func() {
+	return func() {
+		var _ = Service(svc, func() {
+			Method(met, func() {
+				Payload(func() {
+					Attribute("name")
+					Required("name")
+				})
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
+
 var RequestStreamingStringBody = func(svc, met string) func() {
 	return func() {
 		var _ = Service(svc, func() {
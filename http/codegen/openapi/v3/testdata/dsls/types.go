@@ -47,6 +47,41 @@ func ObjectBodyDSL(svcName, metName string) func() {
 	}
 }
 
+func EqualFieldsBodyDSL(svcName, metName string) func() {
+	return func() {
+		var _ = Service(svcName, func() {
+			Method(metName, func() {
+				Payload(func() {
+					Attribute("password")
+					Attribute("password_confirm")
+					EqualFields("password", "password_confirm")
+				})
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
+
+func UnitBodyDSL(svcName, metName string) func() {
+	return func() {
+		var _ = Service(svcName, func() {
+			Method(metName, func() {
+				Payload(func() {
+					Attribute("temperature", Float64, "Reading temperature", func() {
+						Unit("celsius")
+						Minimum(-273.15)
+					})
+				})
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
+
 func MapBodyDSL(svcName, metName string) func() {
 	return func() {
 		var _ = Service(svcName, func() {
@@ -63,9 +63,11 @@ func buildBodyTypes(api *expr.APIExpr) (map[string]map[string]*EndpointBodies, m
 	bodies := make(map[string]map[string]*EndpointBodies)
 	sf := newSchemafier(api.ExampleGenerator)
 
-	// Generates the types referenced from the endpoints.
+	// Generates the types referenced from the endpoints. The discriminated
+	// types are always generated as the body types do not keep track of
+	// the types they extend.
 	for _, t := range expr.Root.Types {
-		if !mustGenerateType(t.Attribute().Meta) {
+		if !mustGenerateType(t.Attribute().Meta) && t.Attribute().Discriminator == nil {
 			continue
 		}
 		sf.schemafy(&expr.AttributeExpr{Type: t})
@@ -246,7 +248,7 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 			typeName := sf.uniquify(codegen.Goify(name, true))
 			s.Ref = toRef(typeName)
 			sf.hashes[h] = append(sf.hashes[h], s.Ref)
-			sf.schemas[typeName] = sf.schemafy(t.Attribute(), true)
+			sf.schemas[typeName] = sf.inherit(t, sf.schemafy(t.Attribute(), true))
 			return s // All other schema properties are set in the reference
 		}
 		// Alias primitive type
@@ -263,6 +265,21 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 	s.DefaultValue = toStringMap(attr.DefaultValue)
 	s.Example = attr.Example(sf.rand)
 	s.Extensions = openapi.ExtensionsFromExpr(attr.Meta)
+	s.Nullable = attr.IsNullable()
+	s.ReadOnly = attr.IsGenerated()
+	if unit := attr.Unit(); unit != "" {
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]interface{})
+		}
+		s.Extensions["x-unit"] = unit
+		if s.Description != "" {
+			s.Description += "\n"
+		}
+		s.Description += openapi.UnitDescription(unit)
+	}
+	if d := attr.Discriminator; d != nil {
+		s.Discriminator = sf.discriminator(d)
+	}
 
 	// Validations
 	val := attr.Validation
@@ -299,10 +316,109 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 		}
 	}
 	s.Required = val.Required
+	if note := openapi.EqualFieldsDescription(val); note != "" {
+		if s.Description != "" {
+			s.Description += "\n"
+		}
+		s.Description += note
+	}
+	initFieldGroups(s, val)
 
 	return s
 }
 
+// initFieldGroups sets the schema keywords that express the MutuallyExclusive
+// and RequiredOneOf validations of val. A group listed by both validations
+// requires exactly one attribute and maps to "oneOf", a RequiredOneOf group
+// maps to "anyOf" and a MutuallyExclusive group to "not" with "anyOf" listing
+// all the pairs of attributes. The constraints are combined with "allOf" when
+// there are more than one.
+func initFieldGroups(s *openapi.Schema, val *expr.ValidationExpr) {
+	required := func(names ...string) *openapi.Schema {
+		return &openapi.Schema{Required: names}
+	}
+	var constraints []*openapi.Schema
+	for _, g := range val.RequiredOneOf {
+		alts := make([]*openapi.Schema, len(g))
+		for i, n := range g {
+			alts[i] = required(n)
+		}
+		if val.HasMutuallyExclusive(g...) {
+			constraints = append(constraints, &openapi.Schema{OneOf: alts})
+		} else {
+			constraints = append(constraints, &openapi.Schema{AnyOf: alts})
+		}
+	}
+	for _, g := range val.MutuallyExclusive {
+		if val.HasRequiredOneOf(g...) {
+			continue
+		}
+		var pairs []*openapi.Schema
+		for i, n := range g {
+			for _, o := range g[i+1:] {
+				pairs = append(pairs, required(n, o))
+			}
+		}
+		constraints = append(constraints, &openapi.Schema{Not: &openapi.Schema{AnyOf: pairs}})
+	}
+	switch len(constraints) {
+	case 0:
+	case 1:
+		c := constraints[0]
+		s.OneOf, s.AnyOf, s.Not = c.OneOf, c.AnyOf, c.Not
+	default:
+		s.AllOf = constraints
+	}
+}
+
+// discriminator returns the OpenAPI discriminator object that maps the values
+// of d to the schemas of the corresponding types.
+func (sf *schemafier) discriminator(d *expr.DiscriminatorExpr) *openapi.Discriminator {
+	mapping := make(map[string]string, len(d.Mappings))
+	for _, m := range d.Mappings {
+		mapping[m.Value] = sf.schemafy(&expr.AttributeExpr{Type: m.Type}).Ref
+	}
+	return &openapi.Discriminator{PropertyName: d.PropertyName, Mapping: mapping}
+}
+
+// inherit returns the schema s of the type t using "allOf" to combine a
+// reference to the schema of the discriminated base type extended by t with s
+// if there is one, s otherwise. The attributes of the base type are removed
+// from s so that s only describes the attributes defined by t.
+func (sf *schemafier) inherit(t expr.UserType, s *openapi.Schema) *openapi.Schema {
+	base := expr.DiscriminatedBase(t.Attribute())
+	if base == nil || s == nil {
+		return s
+	}
+	d := base.Attribute().Discriminator
+	if ex, ok := s.Example.(map[string]interface{}); ok {
+		if v, ok := d.ValueOf(t); ok {
+			ex[d.PropertyName] = v
+		}
+	}
+	ref := sf.schemafy(&expr.AttributeExpr{Type: base})
+	obj := expr.AsObject(base)
+	var required []string
+	for _, n := range s.Required {
+		if obj.Attribute(n) == nil {
+			required = append(required, n)
+		}
+	}
+	s.Required = required
+	for n := range s.Properties {
+		if obj.Attribute(n) != nil {
+			delete(s.Properties, n)
+		}
+	}
+	res := &openapi.Schema{
+		Description: s.Description,
+		Example:     s.Example,
+		AllOf:       []*openapi.Schema{ref, s},
+	}
+	s.Description, s.Example = "", nil
+	return res
+}
+
 // uniquify returns n if n is not a known type name. Otherwise uniquify appends
 // the smallest integer greater than 1 to n so the result is not a known type
 // name.
@@ -417,6 +533,14 @@ func hashAttribute(att *expr.AttributeExpr, h hash.Hash64, seen map[string]*uint
 			kh := hashString(m.Name, h)
 			vh := hashAttribute(m.Attribute, h, seen)
 			*res = *res ^ orderedHash(kh, *vh, h)
+			if m.Attribute.IsNullable() {
+				// Objects with different nullable attributes should
+				// produce different hashes.
+				*res = *res ^ hashString("nullable:"+m.Name, h)
+			}
+			if m.Attribute.IsGenerated() {
+				*res = *res ^ hashString("generated:"+m.Name, h)
+			}
 		}
 		// Objects with a different set of required attributes should produce
 		// different hashes.
@@ -13,10 +13,11 @@ import (
 
 // describes a type for comparison in tests.
 type typ struct {
-	Type      string
-	Format    string
-	Props     []attr
-	SkipProps bool
+	Type        string
+	Format      string
+	Description string
+	Props       []attr
+	SkipProps   bool
 }
 
 type attr struct {
@@ -89,6 +90,25 @@ func TestBuildBodyTypes(t *testing.T) {
 
 		ExpectedType:          tobj("name", tstring, "age", tint),
 		ExpectedResponseTypes: rt{204: tempty},
+	}, {
+		Name: "equal_fields_body",
+		DSL:  dsls.EqualFieldsBodyDSL(svcName, "equal_fields_body"),
+
+		ExpectedType: typ{
+			Type:        "object",
+			Description: "password_confirm must be equal to password.",
+			Props:       []attr{{Name: "password", Val: tstring}, {Name: "password_confirm", Val: tstring}},
+		},
+		ExpectedResponseTypes: rt{204: tempty},
+	}, {
+		Name: "unit_body",
+		DSL:  dsls.UnitBodyDSL(svcName, "unit_body"),
+
+		ExpectedType: typ{
+			Type:  "object",
+			Props: []attr{{Name: "temperature", Val: typ{Type: "number", Description: "Reading temperature\nUnit: celsius."}}},
+		},
+		ExpectedResponseTypes: rt{204: tempty},
 	}, {
 		Name: "map_body",
 		DSL:  dsls.MapBodyDSL(svcName, "map_body"),
@@ -233,6 +253,11 @@ func matchesSchemaWithPrefix(t *testing.T, ctx string, s *openapi.Schema, types
 			t.Errorf("%s: %sgot format %q, expected %q", ctx, prefix, s.Format, tt.Format)
 		}
 	}
+	if tt.Description != "" {
+		if s.Description != tt.Description {
+			t.Errorf("%s: %sgot description %q, expected %q", ctx, prefix, s.Description, tt.Description)
+		}
+	}
 	if tt.Type == "object" {
 		if tt.SkipProps {
 			return
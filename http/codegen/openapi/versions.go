@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/expr"
+)
+
+// DescriptionWithVersions appends the range of API versions in which the
+// method is available as defined by the Since and Until DSL to the given
+// description. The description is returned unchanged if the method does not
+// define a range or if its "openapi:versions" meta is set to "false".
+func DescriptionWithVersions(desc string, m *expr.MethodExpr) string {
+	if m.Since == "" && m.Until == "" {
+		return desc
+	}
+	if v, ok := m.Meta["openapi:versions"]; ok && len(v) > 0 && v[0] == "false" {
+		return desc
+	}
+	var versions string
+	switch {
+	case m.Until == "":
+		versions = fmt.Sprintf("Available since API version %s.", m.Since)
+	case m.Since == "":
+		versions = fmt.Sprintf("Available until API version %s.", m.Until)
+	default:
+		versions = fmt.Sprintf("Available from API version %s to %s.", m.Since, m.Until)
+	}
+	if desc == "" {
+		return versions
+	}
+	return desc + "\n\n" + versions
+}
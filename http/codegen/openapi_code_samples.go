@@ -0,0 +1,362 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// codeSamplesExtension is the key of the meta used to render the code samples
+// in the OpenAPI operations.
+const codeSamplesExtension = "openapi:extension:x-codeSamples"
+
+type (
+	// codeSample is an element of the x-codeSamples OpenAPI extension.
+	codeSample struct {
+		Lang   string `json:"lang"`
+		Label  string `json:"label"`
+		Source string `json:"source"`
+	}
+
+	// codeSampleData contains the data used to render the code sample of
+	// an endpoint.
+	codeSampleData struct {
+		// StdImports lists the standard library imports of the sample.
+		StdImports []*codegen.ImportSpec
+		// Imports lists the other imports of the sample.
+		Imports []*codegen.ImportSpec
+		// Scheme is the scheme of the server URL.
+		Scheme string
+		// Host is the host of the server URL.
+		Host string
+		// ClientPkg is the name of the generated HTTP client package.
+		ClientPkg string
+		// WebSocket is true if the HTTP client uses websockets.
+		WebSocket bool
+		// ServicePkg is the name of the generated service package.
+		ServicePkg string
+		// Endpoints lists the expressions used to initialize the service
+		// client, "nil" for the methods that cannot be used.
+		Endpoints []string
+		// Method is the name of the service client method.
+		Method string
+		// Vars lists the declarations of the variables referenced by the
+		// payload.
+		Vars []string
+		// Payload is the Go literal of the payload if any.
+		Payload string
+		// HasResult is true if the method returns a result.
+		HasResult bool
+	}
+
+	// sampleLiteral renders example values as Go literals.
+	sampleLiteral struct {
+		scope *codegen.NameScope
+		pkg   string
+		rand  *expr.ExampleGenerator
+		// all indicates whether the object literals set all the
+		// attributes present in the example values instead of only the
+		// required attributes. The optional primitive attributes are
+		// initialized with the ptr function in this case.
+		all bool
+		// usesPtr is set when a literal uses the ptr function.
+		usesPtr bool
+	}
+)
+
+// AddOpenAPICodeSamples documents the usage of the generated Go HTTP client in
+// the OpenAPI operations with the "x-codeSamples" extension when the API
+// "openapi:codesamples" meta is set to "true". The code samples are added to
+// the meta of the methods so that both the OpenAPI v2 and v3 specifications
+// include them. Methods may set the meta to "false" to omit their sample.
+// Streaming, multipart and methods that skip the request or response body
+// encoding do not have samples.
+func AddOpenAPICodeSamples(genpkg string, root *expr.RootExpr) {
+	if v, ok := root.API.Meta.Last("openapi:codesamples"); !ok || v != "true" {
+		return
+	}
+	scheme, host := sampleServer(root.API)
+	for _, svc := range root.API.HTTP.Services {
+		sd := HTTPServices.Get(svc.Name())
+		endpoints := make([]string, len(sd.Service.Methods))
+		for i, m := range sd.Service.Methods {
+			endpoints[i] = "nil"
+			if ed := sd.Endpoint(m.Name); ed != nil && ed.MultipartRequestEncoder == nil {
+				endpoints[i] = "hc." + ed.EndpointInit + "()"
+			}
+		}
+		for _, e := range svc.HTTPEndpoints {
+			m := e.MethodExpr
+			if v, ok := m.Meta.Last("openapi:codesamples"); ok && v == "false" {
+				continue
+			}
+			if _, ok := m.Meta[codeSamplesExtension]; ok {
+				continue
+			}
+			if m.IsStreaming() || e.MultipartRequest || e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode {
+				continue
+			}
+			src := codeSampleSource(genpkg, root.API, sd, e, scheme, host, endpoints)
+			b, err := json.Marshal([]*codeSample{{Lang: "Go", Label: "Go client", Source: src}})
+			if err != nil {
+				panic(err) // bug
+			}
+			if m.Meta == nil {
+				m.Meta = expr.MetaExpr{}
+			}
+			m.Meta[codeSamplesExtension] = []string{string(b)}
+		}
+	}
+}
+
+// codeSampleSource returns the Go program that calls the given endpoint using
+// the generated clients.
+func codeSampleSource(genpkg string, api *expr.APIExpr, sd *ServiceData, e *expr.HTTPEndpointExpr, scheme, host string, endpoints []string) string {
+	var (
+		svc  = sd.Service
+		md   = svc.Method(e.MethodExpr.Name)
+		data = &codeSampleData{
+			Scheme:     scheme,
+			Host:       host,
+			ClientPkg:  svc.PkgName + "c",
+			ServicePkg: svc.PkgName,
+			Endpoints:  endpoints,
+			Method:     md.VarName,
+			HasResult:  md.ResultRef != "",
+			WebSocket:  hasWebSocket(sd),
+		}
+	)
+	data.StdImports = []*codegen.ImportSpec{{Path: "context"}}
+	if data.HasResult {
+		data.StdImports = append(data.StdImports, &codegen.ImportSpec{Path: "fmt"})
+	}
+	data.StdImports = append(data.StdImports, &codegen.ImportSpec{Path: "log"}, &codegen.ImportSpec{Path: "net/http"})
+	data.Imports = append(data.Imports,
+		codegen.GoaNamedImport("http", "goahttp"),
+		&codegen.ImportSpec{Path: genpkg + "/" + svc.PathName, Name: svc.PkgName},
+		&codegen.ImportSpec{Path: genpkg + "/http/" + svc.PathName + "/client", Name: data.ClientPkg},
+	)
+	if md.PayloadRef != "" {
+		l := &sampleLiteral{scope: svc.Scope, pkg: svc.PkgName, rand: expr.NewRandom(api.Name)}
+		data.Payload, data.Vars = l.payload(e)
+	}
+	var buf bytes.Buffer
+	if err := codeSampleTmpl.Execute(&buf, data); err != nil {
+		panic(err) // bug
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return buf.String()
+	}
+	return string(src)
+}
+
+// sampleServer returns the scheme and host of the first HTTP URL of the API
+// servers. The URL variables are replaced with their default values.
+func sampleServer(api *expr.APIExpr) (string, string) {
+	for _, svr := range api.Servers {
+		for _, h := range svr.Hosts {
+			for _, uri := range h.URIs {
+				if s := uri.Scheme(); s != "http" && s != "https" {
+					continue
+				}
+				u := string(uri)
+				if obj := expr.AsObject(h.Variables.Type); obj != nil {
+					for _, p := range uri.Params() {
+						if att := obj.Attribute(p); att != nil && att.DefaultValue != nil {
+							u = strings.ReplaceAll(u, "{"+p+"}", fmt.Sprint(att.DefaultValue))
+						}
+					}
+				}
+				if pu, err := url.Parse(u); err == nil && pu.Host != "" {
+					return pu.Scheme, pu.Host
+				}
+			}
+		}
+	}
+	return "http", "localhost:80"
+}
+
+// payload returns the Go literal of the payload of the endpoint method and
+// the declarations of the variables it references. The literal only sets the
+// required attributes and the security credentials.
+func (l *sampleLiteral) payload(e *expr.HTTPEndpointExpr) (string, []string) {
+	var (
+		att   = e.MethodExpr.Payload
+		creds = make(map[string]string)
+		vars  []string
+	)
+	for _, req := range e.MethodExpr.Requirements {
+		for _, sch := range req.Schemes {
+			tags := map[expr.SchemeKind][][2]string{
+				expr.BasicAuthKind: {{"security:username", "USERNAME"}, {"security:password", "PASSWORD"}},
+				expr.APIKeyKind:    {{"security:apikey:" + sch.SchemeName, "API_KEY"}},
+				expr.JWTKind:       {{"security:token", "TOKEN"}},
+				expr.OAuth2Kind:    {{"security:accesstoken", "ACCESS_TOKEN"}},
+			}[sch.Kind]
+			for _, t := range tags {
+				if n := expr.TaggedAttribute(att, t[0]); n != "" {
+					creds[n] = t[1]
+				}
+			}
+		}
+	}
+	obj := expr.AsObject(att.Type)
+	if obj == nil || len(creds) == 0 {
+		return l.value(att, att.Example(l.rand)), nil
+	}
+	ex, _ := att.Example(l.rand).(map[string]interface{})
+	var fields []string
+	for _, nat := range *obj {
+		field := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		if cred, ok := creds[nat.Name]; ok {
+			v := fmt.Sprintf("%q", cred)
+			if att.IsPrimitivePointer(nat.Name, true) {
+				name := codegen.Goify(nat.Name, false)
+				vars = append(vars, fmt.Sprintf("%s := %s", name, v))
+				v = "&" + name
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s, // security credentials", field, v))
+			continue
+		}
+		if !att.IsRequired(nat.Name) {
+			continue
+		}
+		if v, ok := ex[nat.Name]; ok {
+			if lit := l.value(nat.Attribute, v); lit != "" {
+				fields = append(fields, fmt.Sprintf("%s: %s,", field, lit))
+			}
+		}
+	}
+	return l.object(att, fields), vars
+}
+
+// value returns the Go literal of the example value v of the attribute att or
+// the empty string if the value cannot be represented.
+func (l *sampleLiteral) value(att *expr.AttributeExpr, v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if expr.IsObject(dt) {
+			return l.objectValue(att, v)
+		}
+		return l.value(dt.Attribute(), v)
+	case *expr.Object:
+		return l.objectValue(att, v)
+	case *expr.Array:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return ""
+		}
+		elems := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if lit := l.value(dt.ElemType, rv.Index(i).Interface()); lit != "" {
+				elems = append(elems, lit)
+			}
+		}
+		return l.scope.GoFullTypeRef(att, l.pkg) + "{" + strings.Join(elems, ", ") + "}"
+	case *expr.Map:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return ""
+		}
+		elems := make([]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			kl, el := l.value(dt.KeyType, iter.Key().Interface()), l.value(dt.ElemType, iter.Value().Interface())
+			if kl != "" && el != "" {
+				elems = append(elems, kl+": "+el)
+			}
+		}
+		sort.Strings(elems)
+		return l.scope.GoFullTypeRef(att, l.pkg) + "{" + strings.Join(elems, ", ") + "}"
+	case *expr.Union:
+		return ""
+	}
+	switch att.Type.Kind() {
+	case expr.BytesKind:
+		return fmt.Sprintf("[]byte(%q)", fmt.Sprint(v))
+	case expr.StringKind:
+		return fmt.Sprintf("%q", fmt.Sprint(v))
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
+
+// objectValue returns the Go literal of the object example v. The literal
+// only sets the required attributes unless l.all is true.
+func (l *sampleLiteral) objectValue(att *expr.AttributeExpr, v interface{}) string {
+	ex, _ := v.(map[string]interface{})
+	var fields []string
+	for _, nat := range *expr.AsObject(att.Type) {
+		if !l.all && !att.IsRequired(nat.Name) {
+			continue
+		}
+		if lit := l.field(att, nat, ex[nat.Name]); lit != "" {
+			fields = append(fields, fmt.Sprintf("%s: %s,", codegen.GoifyAtt(nat.Attribute, nat.Name, true), lit))
+		}
+	}
+	return l.object(att, fields)
+}
+
+// field returns the Go literal of the example value v of the attribute nat of
+// the object attribute att. Optional primitive attributes are initialized
+// with the ptr function.
+func (l *sampleLiteral) field(att *expr.AttributeExpr, nat *expr.NamedAttributeExpr, v interface{}) string {
+	lit := l.value(nat.Attribute, v)
+	if lit == "" || !att.IsPrimitivePointer(nat.Name, true) {
+		return lit
+	}
+	l.usesPtr = true
+	return fmt.Sprintf("ptr[%s](%s)", l.scope.GoFullTypeRef(nat.Attribute, l.pkg), lit)
+}
+
+// object returns the Go literal of the object attribute att initialized with
+// the given fields.
+func (l *sampleLiteral) object(att *expr.AttributeExpr, fields []string) string {
+	if len(fields) == 0 {
+		return "&" + l.scope.GoFullTypeName(att, l.pkg) + "{}"
+	}
+	return "&" + l.scope.GoFullTypeName(att, l.pkg) + "{\n" + strings.Join(fields, "\n") + "\n}"
+}
+
+// input: codeSampleData
+var codeSampleTmpl = template.Must(template.New("code-sample").Parse(codeSampleT))
+
+const codeSampleT = `package main
+
+import (
+{{- range .StdImports }}
+	{{ .Code }}
+{{- end }}
+{{ range .Imports }}
+	{{ .Code }}
+{{- end }}
+)
+
+func main() {
+	hc := {{ .ClientPkg }}.NewClient({{ printf "%q" .Scheme }}, {{ printf "%q" .Host }}, http.DefaultClient, goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if .WebSocket }}, nil, nil{{ end }})
+	c := {{ .ServicePkg }}.NewClient({{ range $i, $e := .Endpoints }}{{ if $i }}, {{ end }}{{ $e }}{{ end }})
+{{- range .Vars }}
+	{{ . }}
+{{- end }}
+	{{ if .HasResult }}res, {{ end }}err := c.{{ .Method }}(context.Background(){{ if .Payload }}, {{ .Payload }}{{ end }})
+	if err != nil {
+		log.Fatal(err)
+	}
+{{- if .HasResult }}
+	fmt.Println(res)
+{{- end }}
+}
+`
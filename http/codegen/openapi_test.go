@@ -1,9 +1,15 @@
 package codegen
 
 import (
+	"bytes"
+	"encoding/json"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
+	"text/template"
 
+	"goa.design/goa/v3/codegen"
 	openapi "goa.design/goa/v3/http/codegen/openapi"
 	"goa.design/goa/v3/http/codegen/testdata"
 )
@@ -58,3 +64,164 @@ func TestOutputPath(t *testing.T) {
 		t.Errorf("invalid output path %#v", o[3].Path)
 	}
 }
+
+func TestOpenAPIServiceFiles(t *testing.T) {
+	// Reset global variables
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	root := RunHTTPDSL(t, testdata.ServiceSchemasDSL)
+	if _, err := OpenAPIFiles(root); err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	fs, err := OpenAPIServiceFiles(root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	cases := []struct {
+		Path    string
+		Paths   []string
+		Schemas []string
+	}{
+		{filepath.Join("gen", "http", "service_a", "openapi.json"), []string{"/a"}, []string{"ServiceAMethodARequestBody", "ServiceAMethodAResponseBody", "SharedRequestBody"}},
+		{filepath.Join("gen", "http", "service_a", "openapi.yaml"), nil, nil},
+		{filepath.Join("gen", "http", "service_a", "openapi3.json"), []string{"/a"}, []string{"MethodARequestBody", "Shared"}},
+		{filepath.Join("gen", "http", "service_a", "openapi3.yaml"), nil, nil},
+		{filepath.Join("gen", "http", "service_b", "openapi.json"), []string{"/b"}, []string{"ServiceBMethodBRequestBody", "ServiceBMethodBResponseBody", "SharedRequestBody"}},
+		{filepath.Join("gen", "http", "service_b", "openapi.yaml"), nil, nil},
+		{filepath.Join("gen", "http", "service_b", "openapi3.json"), []string{"/b"}, []string{"MethodBRequestBody", "Shared"}},
+		{filepath.Join("gen", "http", "service_b", "openapi3.yaml"), nil, nil},
+	}
+	if len(fs) != len(cases) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(cases))
+	}
+	for i, c := range cases {
+		f := fs[i]
+		if f.Path != c.Path {
+			t.Errorf("got path %q, expected %q", f.Path, c.Path)
+		}
+		if c.Paths == nil {
+			continue
+		}
+		var buf bytes.Buffer
+		s := f.SectionTemplates[0]
+		tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+		if err := tmpl.Execute(&buf, s.Data); err != nil {
+			t.Fatalf("%s: failed to render template: %s", c.Path, err)
+		}
+		var spec struct {
+			Paths       map[string]interface{}
+			Definitions map[string]interface{}
+			Components  struct {
+				Schemas map[string]interface{}
+			}
+		}
+		if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+			t.Fatalf("%s: invalid JSON: %s", c.Path, err)
+		}
+		schemas := spec.Definitions
+		if schemas == nil {
+			schemas = spec.Components.Schemas
+		}
+		if got := sortedKeys(spec.Paths); !reflect.DeepEqual(got, c.Paths) {
+			t.Errorf("%s: got paths %v, expected %v", c.Path, got, c.Paths)
+		}
+		if got := sortedKeys(schemas); !reflect.DeepEqual(got, c.Schemas) {
+			t.Errorf("%s: got schemas %v, expected %v", c.Path, got, c.Schemas)
+		}
+	}
+}
+
+func TestAddOpenAPICodeSamples(t *testing.T) {
+	root := RunHTTPDSL(t, testdata.CodeSamplesDSL)
+	AddOpenAPICodeSamples("example.com/gen", root)
+	svc := root.Service("Samples")
+	if _, ok := svc.Method("hidden").Meta["openapi:extension:x-codeSamples"]; ok {
+		t.Error("got code sample for method with openapi:codesamples set to false")
+	}
+	v, ok := svc.Method("create").Meta.Last("openapi:extension:x-codeSamples")
+	if !ok {
+		t.Fatal("got no code sample")
+	}
+	var samples []struct {
+		Lang   string
+		Label  string
+		Source string
+	}
+	if err := json.Unmarshal([]byte(v), &samples); err != nil {
+		t.Fatalf("invalid code samples JSON: %s", err)
+	}
+	if len(samples) != 1 || samples[0].Lang != "Go" {
+		t.Fatalf("got samples %v, expected one Go sample", samples)
+	}
+	if samples[0].Source != codeSampleCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", samples[0].Source, codegen.Diff(t, samples[0].Source, codeSampleCode))
+	}
+}
+
+func TestHideOpenAPIFeatures(t *testing.T) {
+	// Reset global variables
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	root := RunHTTPDSL(t, testdata.FeatureGateDSL)
+	HideOpenAPIFeatures(root, []string{"new_checkout"})
+	fs, err := OpenAPIFiles(root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	expected := []string{"/", "/preview"}
+	for _, f := range fs {
+		if filepath.Ext(f.Path) != ".json" {
+			continue
+		}
+		var buf bytes.Buffer
+		s := f.SectionTemplates[0]
+		tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+		if err := tmpl.Execute(&buf, s.Data); err != nil {
+			t.Fatalf("%s: failed to render template: %s", f.Path, err)
+		}
+		var spec struct {
+			Paths map[string]interface{}
+		}
+		if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+			t.Fatalf("%s: invalid JSON: %s", f.Path, err)
+		}
+		if got := sortedKeys(spec.Paths); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: got paths %v, expected %v", f.Path, got, expected)
+		}
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+const codeSampleCode = `package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	samplesc "example.com/gen/http/samples/client"
+	samples "example.com/gen/samples"
+	goahttp "goa.design/goa/v3/http"
+)
+
+func main() {
+	hc := samplesc.NewClient("https", "api.example.com", http.DefaultClient, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+	c := samples.NewClient(hc.Create(), hc.Hidden())
+	res, err := c.Create(context.Background(), &samples.CreatePayload{
+		Token: "TOKEN", // security credentials
+		Name:  "goa",
+		Tags:  []string{"a", "b"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(res)
+}
+`
@@ -44,6 +44,7 @@ func pathSections(svc *expr.HTTPServiceExpr, pkg string) []*codegen.SectionTempl
 			{Path: "net/url"},
 			{Path: "strconv"},
 			{Path: "strings"},
+			codegen.GoaNamedImport("http", "goahttp"),
 		}),
 	}
 	sdata := HTTPServices.Get(svc.Name())
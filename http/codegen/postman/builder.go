@@ -0,0 +1,337 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+const (
+	// baseURLVar is the name of the collection variable holding the base
+	// URL of the requests.
+	baseURLVar = "baseUrl"
+	// authTokenVar is the name of the collection variable holding the
+	// token of the JWT and OAuth2 security schemes.
+	authTokenVar = "authToken"
+)
+
+// wildcardRegex matches the path wildcards, e.g. "{id}" or "{*path}".
+var wildcardRegex = regexp.MustCompile(`\{\*?([^{}]+)\}`)
+
+// variables collects the collection variables in the order they are first
+// referenced.
+type variables struct {
+	vars []*Variable
+	seen map[string]struct{}
+}
+
+// New returns the Postman collection listing one request per HTTP endpoint of
+// the given API. It returns nil if the design does not define HTTP endpoints.
+func New(root *expr.RootExpr) *Collection {
+	if root == nil || root.API == nil || root.API.HTTP == nil {
+		return nil
+	}
+	var (
+		api   = root.API
+		vars  = &variables{seen: make(map[string]struct{})}
+		items []*Item
+	)
+	baseURL, hostVars := buildBaseURL(api)
+	vars.add(&Variable{Key: baseURLVar, Value: baseURL, Type: "string"})
+	for _, v := range hostVars {
+		vars.add(v)
+	}
+	for _, svc := range api.HTTP.Services {
+		var reqs []*Item
+		for _, e := range svc.HTTPEndpoints {
+			if len(e.Routes) == 0 || e.MethodExpr.IsStreaming() && e.SSE == nil {
+				// WebSocket endpoints cannot be described with Postman
+				// HTTP requests.
+				continue
+			}
+			reqs = append(reqs, &Item{
+				Name:    e.Name(),
+				Request: buildRequest(api, e, vars),
+			})
+		}
+		if len(reqs) == 0 {
+			continue
+		}
+		items = append(items, &Item{
+			Name:        svc.Name(),
+			Description: svc.Description(),
+			Item:        reqs,
+		})
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return &Collection{
+		Info:     buildInfo(api),
+		Item:     items,
+		Variable: vars.vars,
+	}
+}
+
+// add adds v to the collection variables unless a variable with the same key
+// was already added.
+func (vs *variables) add(v *Variable) {
+	if _, ok := vs.seen[v.Key]; ok {
+		return
+	}
+	vs.seen[v.Key] = struct{}{}
+	vs.vars = append(vs.vars, v)
+}
+
+// buildInfo builds the collection Info object.
+func buildInfo(api *expr.APIExpr) *Info {
+	name := api.Title
+	if name == "" {
+		name = api.Name
+	}
+	return &Info{
+		Name:        name,
+		Description: api.Description,
+		Version:     api.Version,
+		Schema:      Schema,
+	}
+}
+
+// buildBaseURL returns the first HTTP URI of the first API host and the
+// collection variables initialized with the default values of the URI
+// variables.
+func buildBaseURL(api *expr.APIExpr) (string, []*Variable) {
+	for _, svr := range api.Servers {
+		for _, host := range svr.Hosts {
+			for _, u := range host.URIs {
+				if s := u.Scheme(); s != "http" && s != "https" {
+					continue
+				}
+				var (
+					obj  = expr.AsObject(host.Variables.Type)
+					vars []*Variable
+				)
+				for _, p := range u.Params() {
+					var att *expr.AttributeExpr
+					if obj != nil {
+						att = obj.Attribute(p)
+					}
+					v := &Variable{Key: p, Type: "string"}
+					if att != nil {
+						v.Description = att.Description
+						if att.DefaultValue != nil {
+							v.Value = fmt.Sprint(att.DefaultValue)
+						} else if att.Validation != nil && len(att.Validation.Values) > 0 {
+							v.Value = fmt.Sprint(att.Validation.Values[0])
+						}
+					}
+					vars = append(vars, v)
+				}
+				return strings.TrimSuffix(wildcardRegex.ReplaceAllString(string(u), "{{$1}}"), "/"), vars
+			}
+		}
+	}
+	return "http://localhost", nil
+}
+
+// buildRequest builds the request of the given endpoint. The path and query
+// string parameters and the headers reference collection variables that are
+// initialized with examples.
+func buildRequest(api *expr.APIExpr, e *expr.HTTPEndpointExpr, vars *variables) *Request {
+	var (
+		route = e.Routes[0]
+		path  = route.FullPaths()[0]
+		req   = &Request{
+			Method:      route.Method,
+			Header:      []*Header{},
+			Description: e.MethodExpr.Description,
+		}
+		authIn, authName string
+	)
+	if len(e.Requirements) > 0 && len(e.Requirements[0].Schemes) > 0 {
+		var sch *expr.SchemeExpr
+		sch, req.Auth = buildAuth(e.Requirements[0], vars)
+		if req.Auth != nil && sch.Kind != expr.BasicAuthKind {
+			authIn, authName = sch.In, sch.Name
+		}
+	}
+
+	if params := e.PathParams(); params != nil {
+		_ = expr.WalkMappedAttr(params, func(_, elem string, att *expr.AttributeExpr) error {
+			vars.add(paramVariable(api, elem, att))
+			return nil
+		})
+	}
+	path = wildcardRegex.ReplaceAllString(path, "{{$1}}")
+	url := &URL{
+		Host: []string{"{{" + baseURLVar + "}}"},
+		Path: strings.Split(strings.TrimPrefix(path, "/"), "/"),
+	}
+	var query []string
+	if params := e.QueryParams(); params != nil {
+		_ = expr.WalkMappedAttr(params, func(_, elem string, att *expr.AttributeExpr) error {
+			if authIn == "query" && elem == authName {
+				return nil
+			}
+			vars.add(paramVariable(api, elem, att))
+			url.Query = append(url.Query, &QueryParam{
+				Key:         elem,
+				Value:       "{{" + elem + "}}",
+				Description: att.Description,
+			})
+			query = append(query, elem+"={{"+elem+"}}")
+			return nil
+		})
+	}
+	url.Raw = "{{" + baseURLVar + "}}" + path
+	if len(query) > 0 {
+		url.Raw += "?" + strings.Join(query, "&")
+	}
+	req.URL = url
+
+	_ = expr.WalkMappedAttr(e.Headers, func(_, elem string, att *expr.AttributeExpr) error {
+		if authIn == "header" && elem == authName {
+			return nil
+		}
+		vars.add(paramVariable(api, elem, att))
+		req.Header = append(req.Header, &Header{
+			Key:         elem,
+			Value:       "{{" + elem + "}}",
+			Description: att.Description,
+		})
+		return nil
+	})
+
+	if e.Body != nil && e.Body.Type != expr.Empty {
+		raw, err := json.MarshalIndent(openapi.ToStringMap(e.Body.Example(api.ExampleGenerator)), "", "  ")
+		if err != nil {
+			panic("postman: " + err.Error()) // bug
+		}
+		req.Header = append(req.Header, &Header{Key: "Content-Type", Value: "application/json"})
+		req.Body = &Body{
+			Mode:    "raw",
+			Raw:     string(raw),
+			Options: &BodyOptions{Raw: &RawOptions{Language: "json"}},
+		}
+	}
+	return req
+}
+
+// paramVariable returns the collection variable holding the value of the
+// parameter or header with the given name.
+func paramVariable(api *expr.APIExpr, name string, att *expr.AttributeExpr) *Variable {
+	return &Variable{
+		Key:         name,
+		Value:       exampleString(att.Example(api.ExampleGenerator)),
+		Type:        "string",
+		Description: att.Description,
+	}
+}
+
+// exampleString returns the string representation of the example of a
+// parameter. Arrays are represented by their first element.
+func exampleString(ex interface{}) string {
+	if ex == nil {
+		return ""
+	}
+	if v := reflect.ValueOf(ex); v.Kind() == reflect.Slice {
+		if _, ok := ex.([]byte); !ok {
+			if v.Len() == 0 {
+				return ""
+			}
+			ex = v.Index(0).Interface()
+		}
+	}
+	return fmt.Sprint(ex)
+}
+
+// buildAuth builds the Postman auth config of the first scheme of the given
+// security requirement. Postman supports a single auth config per request so
+// the other schemes are ignored. buildAuth returns the scheme that was used
+// and nil if the scheme cannot be represented.
+func buildAuth(req *expr.SecurityExpr, vars *variables) (*expr.SchemeExpr, *Auth) {
+	sch := req.Schemes[0]
+	token := &Variable{Key: authTokenVar, Value: "", Type: "string", Description: "Token used to authenticate the requests."}
+	switch sch.Kind {
+	case expr.BasicAuthKind:
+		vars.add(&Variable{Key: "username", Value: "", Type: "string"})
+		vars.add(&Variable{Key: "password", Value: "", Type: "string"})
+		return sch, &Auth{
+			Type: "basic",
+			Basic: []*AuthAttribute{
+				{Key: "username", Value: "{{username}}", Type: "string"},
+				{Key: "password", Value: "{{password}}", Type: "string"},
+			},
+		}
+	case expr.APIKeyKind:
+		vars.add(&Variable{Key: sch.SchemeName, Value: "", Type: "string", Description: sch.Description})
+		return sch, apiKeyAuth(sch, "{{"+sch.SchemeName+"}}")
+	case expr.JWTKind:
+		vars.add(token)
+		if sch.In == "query" {
+			return sch, apiKeyAuth(sch, "{{"+authTokenVar+"}}")
+		}
+		return sch, &Auth{
+			Type:   "bearer",
+			Bearer: []*AuthAttribute{{Key: "token", Value: "{{" + authTokenVar + "}}", Type: "string"}},
+		}
+	case expr.OAuth2Kind:
+		vars.add(token)
+		addTo := "header"
+		if sch.In == "query" {
+			addTo = "queryParams"
+		}
+		attrs := []*AuthAttribute{
+			{Key: "accessToken", Value: "{{" + authTokenVar + "}}", Type: "string"},
+			{Key: "addTokenTo", Value: addTo, Type: "string"},
+		}
+		if len(req.Scopes) > 0 {
+			attrs = append(attrs, &AuthAttribute{Key: "scope", Value: strings.Join(req.Scopes, " "), Type: "string"})
+		}
+		if len(sch.Flows) > 0 {
+			f := sch.Flows[0]
+			var grant string
+			switch f.Kind {
+			case expr.AuthorizationCodeFlowKind:
+				grant = "authorization_code"
+			case expr.ImplicitFlowKind:
+				grant = "implicit"
+			case expr.PasswordFlowKind:
+				grant = "password_credentials"
+			case expr.ClientCredentialsFlowKind:
+				grant = "client_credentials"
+			}
+			attrs = append(attrs, &AuthAttribute{Key: "grant_type", Value: grant, Type: "string"})
+			if f.AuthorizationURL != "" {
+				attrs = append(attrs, &AuthAttribute{Key: "authUrl", Value: f.AuthorizationURL, Type: "string"})
+			}
+			if f.TokenURL != "" {
+				attrs = append(attrs, &AuthAttribute{Key: "accessTokenUrl", Value: f.TokenURL, Type: "string"})
+			}
+		}
+		return sch, &Auth{Type: "oauth2", OAuth2: attrs}
+	}
+	return sch, nil
+}
+
+// apiKeyAuth returns the Postman API key auth config that sets the header or
+// query string parameter of the given scheme to value.
+func apiKeyAuth(sch *expr.SchemeExpr, value string) *Auth {
+	in := "header"
+	if sch.In == "query" {
+		in = "query"
+	}
+	return &Auth{
+		Type: "apikey",
+		APIKey: []*AuthAttribute{
+			{Key: "key", Value: sch.Name, Type: "string"},
+			{Key: "value", Value: value, Type: "string"},
+			{Key: "in", Value: in, Type: "string"},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Package postman implements a plugin that generates a Postman collection
+(format v2.1) listing the requests of the HTTP endpoints of the API.
+
+Enable the plugin by importing the package in the design:
+
+	import (
+	    _ "goa.design/goa/v3/http/codegen/postman"
+	    . "goa.design/goa/v3/dsl"
+	)
+
+The "gen" command then generates the gen/http/postman_collection.json file.
+The collection defines one folder per service containing one request per
+method. The requests use the first route of the endpoints and are sent to the
+URL held by the "baseUrl" collection variable, initialized with the first HTTP
+URI of the API. The path and query string parameters and the headers reference
+collection variables initialized with examples and the request bodies are
+examples of the endpoint bodies. The security scheme required by an endpoint
+is mapped to the corresponding Postman auth config, the JWT and OAuth2 tokens
+are read from the "authToken" collection variable. WebSocket endpoints are not
+included in the collection.
+*/
+package postman
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+func init() {
+	codegen.RegisterPluginLast("postman", "gen", nil, Generate)
+}
+
+// Generate appends the Postman collection file to the generated files.
+func Generate(_ string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			files = append(files, Files(r)...)
+		}
+	}
+	return files, nil
+}
+
+// Files returns the Postman collection file, nil if the API does not define
+// HTTP endpoints.
+func Files(root *expr.RootExpr) []*codegen.File {
+	col := New(root)
+	if col == nil {
+		return nil
+	}
+	return []*codegen.File{{
+		Path: filepath.Join(codegen.Gendir, "http", "postman_collection.json"),
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:    "postman",
+			FuncMap: template.FuncMap{"toJSON": toJSON},
+			Source:  "{{ toJSON .}}",
+			Data:    col,
+		}},
+	}}
+}
+
+func toJSON(d interface{}) string {
+	b, err := json.Marshal(d)
+	if err != nil {
+		panic("postman: " + err.Error()) // bug
+	}
+	return string(b)
+}
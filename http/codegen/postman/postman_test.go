@@ -0,0 +1,68 @@
+package postman
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/postman/testdata"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"params", testdata.ParamsDSL},
+		{"security", testdata.SecurityDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := httpcodegen.RunHTTPDSL(t, c.DSL)
+			col := New(root)
+			if col == nil {
+				t.Fatal("got nil collection")
+			}
+			b, err := json.MarshalIndent(col, "", "  ")
+			if err != nil {
+				t.Fatalf("failed to serialize collection: %s", err)
+			}
+			golden := filepath.Join("testdata", "golden", c.Name+".json.golden")
+			if *update {
+				if err := os.WriteFile(golden, b, 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			want = bytes.ReplaceAll(want, []byte{'\r', '\n'}, []byte{'\n'})
+			if !bytes.Equal(b, want) {
+				t.Errorf("result does not match the golden file, got vs. expected:\n%s\n", codegen.Diff(t, string(b), string(want)))
+			}
+		})
+	}
+}
+
+func TestFiles(t *testing.T) {
+	root := httpcodegen.RunHTTPDSL(t, testdata.WebSocketOnlyDSL)
+	if fs := Files(root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+	root = httpcodegen.RunHTTPDSL(t, testdata.ParamsDSL)
+	fs := Files(root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if want := filepath.Join("gen", "http", "postman_collection.json"); fs[0].Path != want {
+		t.Errorf("got path %q, expected %q", fs[0].Path, want)
+	}
+}
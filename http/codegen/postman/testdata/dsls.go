@@ -0,0 +1,146 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ParamsDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String, "Item name", func() {
+			Example("hammer")
+		})
+		Attribute("price", Float64, func() {
+			Example(9.99)
+		})
+		Required("name")
+	})
+	API("store", func() {
+		Title("Store API")
+		Description("Store items")
+		Version("1.0")
+		Server("store", func() {
+			Host("production", func() {
+				URI("https://{region}.store.example.com/api")
+				Variable("region", String, "Deployment region", func() {
+					Default("us")
+				})
+			})
+		})
+	})
+	Service("items", func() {
+		Description("The items service manages the store items.")
+		Method("update", func() {
+			Description("Update an item.")
+			Payload(func() {
+				Attribute("id", Int, "Item ID", func() {
+					Example(42)
+				})
+				Attribute("dry_run", Boolean, "Validate only", func() {
+					Example(true)
+				})
+				Attribute("request_id", String, "Request ID", func() {
+					Example("abc")
+				})
+				Attribute("tags", ArrayOf(String), "Item tags", func() {
+					Example([]string{"tools", "sale"})
+				})
+				Attribute("item", Item)
+				Required("id", "item")
+			})
+			HTTP(func() {
+				PUT("/items/{id}")
+				Param("dry_run")
+				Param("tags")
+				Header("request_id:X-Request-Id")
+				Body("item")
+			})
+		})
+		Method("download", func() {
+			Payload(func() {
+				Attribute("path", String, func() {
+					Example("a/b.txt")
+				})
+			})
+			HTTP(func() {
+				GET("/files/{*path}")
+			})
+		})
+		Method("watch", func() {
+			StreamingResult(Item)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+	})
+}
+
+var SecurityDSL = func() {
+	var Basic = BasicAuthSecurity("basic")
+	var Key = APIKeySecurity("api_key", func() {
+		Description("Secret key")
+	})
+	var JWT = JWTSecurity("jwt")
+	var OAuth2 = OAuth2Security("oauth2", func() {
+		ClientCredentialsFlow("https://auth.example.com/token", "")
+		Scope("api:read")
+	})
+	Service("secure", func() {
+		Method("basic", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+			HTTP(func() {
+				POST("/basic")
+			})
+		})
+		Method("key", func() {
+			Security(Key)
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/key")
+				Param("key:k")
+			})
+		})
+		Method("jwt", func() {
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/jwt")
+			})
+		})
+		Method("oauth2", func() {
+			Security(OAuth2, func() {
+				Scope("api:read")
+			})
+			Payload(func() {
+				AccessToken("token", String)
+			})
+			HTTP(func() {
+				GET("/oauth2")
+			})
+		})
+		Method("public", func() {
+			HTTP(func() {
+				GET("/public")
+			})
+		})
+	})
+}
+
+var WebSocketOnlyDSL = func() {
+	Service("chat", func() {
+		Method("talk", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/talk")
+			})
+		})
+	})
+}
@@ -0,0 +1,105 @@
+package postman
+
+// Schema is the URL of the JSON schema of the Postman collection format used by
+// the generated collections.
+const Schema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type (
+	// Collection is the root object of a Postman collection.
+	Collection struct {
+		Info     *Info       `json:"info"`
+		Item     []*Item     `json:"item"`
+		Variable []*Variable `json:"variable,omitempty"`
+	}
+
+	// Info provides metadata about the collection.
+	Info struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Version     string `json:"version,omitempty"`
+		Schema      string `json:"schema"`
+	}
+
+	// Item is either a folder grouping the requests of a service or a
+	// request.
+	Item struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description,omitempty"`
+		Item        []*Item  `json:"item,omitempty"`
+		Request     *Request `json:"request,omitempty"`
+	}
+
+	// Request describes a HTTP request.
+	Request struct {
+		Method      string    `json:"method"`
+		Header      []*Header `json:"header"`
+		Body        *Body     `json:"body,omitempty"`
+		URL         *URL      `json:"url"`
+		Auth        *Auth     `json:"auth,omitempty"`
+		Description string    `json:"description,omitempty"`
+	}
+
+	// URL describes the URL of a request.
+	URL struct {
+		Raw   string        `json:"raw"`
+		Host  []string      `json:"host"`
+		Path  []string      `json:"path,omitempty"`
+		Query []*QueryParam `json:"query,omitempty"`
+	}
+
+	// QueryParam describes a query string parameter.
+	QueryParam struct {
+		Key         string `json:"key"`
+		Value       string `json:"value"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// Header describes a request header.
+	Header struct {
+		Key         string `json:"key"`
+		Value       string `json:"value"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// Body describes a request body.
+	Body struct {
+		Mode    string       `json:"mode"`
+		Raw     string       `json:"raw"`
+		Options *BodyOptions `json:"options,omitempty"`
+	}
+
+	// BodyOptions describes how Postman renders a raw request body.
+	BodyOptions struct {
+		Raw *RawOptions `json:"raw"`
+	}
+
+	// RawOptions sets the language of a raw request body.
+	RawOptions struct {
+		Language string `json:"language"`
+	}
+
+	// Auth describes the authentication of a request. Only the attributes
+	// of the auth type are set.
+	Auth struct {
+		Type   string           `json:"type"`
+		Basic  []*AuthAttribute `json:"basic,omitempty"`
+		Bearer []*AuthAttribute `json:"bearer,omitempty"`
+		APIKey []*AuthAttribute `json:"apikey,omitempty"`
+		OAuth2 []*AuthAttribute `json:"oauth2,omitempty"`
+	}
+
+	// AuthAttribute is a configuration attribute of an auth type.
+	AuthAttribute struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		Type  string `json:"type"`
+	}
+
+	// Variable is a collection variable.
+	Variable struct {
+		Key         string `json:"key"`
+		Value       string `json:"value"`
+		Type        string `json:"type"`
+		Description string `json:"description,omitempty"`
+	}
+)
@@ -19,6 +19,9 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketServerFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := sseServerFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := serverEncodeDecodeFile(genpkg, svc); f != nil {
@@ -37,6 +40,9 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	funcs := map[string]interface{}{
 		"join":                    func(ss []string, s string) string { return strings.Join(ss, s) },
 		"hasWebSocket":            hasWebSocket,
+		"hasIdempotency":          hasIdempotency,
+		"hasWebhookSignature":     hasWebhookSignature,
+		"hasCipher":               hasCipher,
 		"isWebSocketEndpoint":     isWebSocketEndpoint,
 		"viewedServerBody":        viewedServerBody,
 		"mustDecodeRequest":       mustDecodeRequest,
@@ -46,12 +52,14 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "errors"},
 			{Path: "fmt"},
 			{Path: "io"},
 			{Path: "mime/multipart"},
 			{Path: "net/http"},
 			{Path: "path"},
 			{Path: "strings"},
+			{Path: "time"},
 			{Path: "github.com/gorilla/websocket"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
@@ -59,6 +67,20 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
+	if hasTrace(data) {
+		codegen.AddImport(sections[0],
+			&codegen.ImportSpec{Path: "go.opentelemetry.io/otel"},
+			&codegen.ImportSpec{Path: "go.opentelemetry.io/otel/attribute"},
+			&codegen.ImportSpec{Path: "go.opentelemetry.io/otel/codes"},
+			&codegen.ImportSpec{Path: "go.opentelemetry.io/otel/trace"},
+		)
+	}
+	if hasTrace(data) || data.RequestIDHeader != "" || len(data.MethodOverrides) > 0 {
+		codegen.AddImport(sections[0], codegen.GoaNamedImport("http/middleware", "httpmdlwr"))
+	}
+	if data.RequestIDHeader != "" {
+		codegen.AddImport(sections[0], codegen.GoaImport("middleware"))
+	}
 
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-struct", Source: serverStructT, Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mountpoint", Source: mountPointStructT, Data: data})
@@ -82,10 +104,28 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	for _, e := range data.Endpoints {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler", Source: serverHandlerT, Data: e})
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler-init", Source: serverHandlerInitT, FuncMap: funcs, Data: e})
+		if e.Cipher != nil {
+			sections = append(sections, &codegen.SectionTemplate{Name: "server-cipher-endpoint", Source: cipherEndpointT, Data: e})
+		}
 	}
 	for _, s := range data.FileServers {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-files", Source: fileServerT, FuncMap: funcs, Data: s})
 	}
+	if data.CORS != nil {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-cors", Source: corsHandlerT, Data: data.CORS})
+	}
+	if data.Options != nil {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-options", Source: optionsHandlerT, Data: data.Options})
+	}
+	if data.Version != nil {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-version", Source: versionHandlerT, Data: data.Version})
+	}
+	if data.RequestIDHeader != "" {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-request-id", Source: requestIDMiddlewareT, Data: data})
+	}
+	if len(data.MethodOverrides) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-method-override", Source: methodOverrideMiddlewareT, Data: data, FuncMap: funcs})
+	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
@@ -145,6 +185,13 @@ func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.F
 				Data:    e.MultipartRequestDecoder,
 			})
 		}
+		if e.MultipartRequestDecoder != nil && e.MultipartRequestDecoder.Decode != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "multipart-decode",
+				Source: multipartDecodeT,
+				Data:   e.MultipartRequestDecoder.Decode,
+			})
+		}
 		if len(e.Errors) > 0 {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "error-encoder",
@@ -161,6 +208,13 @@ func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.F
 			Data:   h,
 		})
 	}
+	for _, d := range data.MultipartPartsDecoders {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "multipart-parts-decoder",
+			Source: multipartPartsDecoderT,
+			Data:   d,
+		})
+	}
 
 	// If all endpoints use skip encoding and decoding of both payloads and
 	// results and define no error then this file is irrelevant.
@@ -192,6 +246,50 @@ func mustDecodeRequest(e *EndpointData) bool {
 	return e.Payload.Ref != ""
 }
 
+// hasCipher returns true if at least one of the service endpoints encrypts or
+// decrypts fields.
+func hasCipher(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.Cipher != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIdempotency returns true if at least one of the service endpoints handles
+// idempotency keys.
+func hasIdempotency(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.Idempotency != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasWebhookSignature returns true if at least one of the service endpoints
+// verifies the request signatures.
+func hasWebhookSignature(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.WebhookSignature != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTrace returns true if at least one of the service endpoints is
+// instrumented with OpenTelemetry.
+func hasTrace(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.Trace != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // conversionData creates a template context suitable for executing the
 // "type_conversion" template.
 func conversionData(varName, name string, dt expr.DataType) map[string]interface{} {
@@ -276,6 +374,15 @@ type {{ .ServerStruct }} struct {
 	{{- range .FileServers }}
 	{{ .VarName }} http.Handler
 	{{- end }}
+	{{- if .CORS }}
+	{{ .CORS.VarName }} http.Handler
+	{{- end }}
+	{{- if .Options }}
+	{{ .Options.VarName }} http.Handler
+	{{- end }}
+	{{- if .Version }}
+	{{ .Version.VarName }} http.Handler
+	{{- end }}
 }
 `
 
@@ -292,7 +399,13 @@ type {{ .MountPointStruct }} struct {
 `
 
 // input: ServiceData
-const serverInitT = `{{ printf "%s instantiates HTTP handlers for all the %s service endpoints using the provided encoder and decoder. The handlers are mounted on the given mux using the HTTP verb and path defined in the design. errhandler is called whenever a response fails to be encoded. formatter is used to format errors returned by the service methods prior to encoding. Both errhandler and formatter are optional and can be nil." .ServerInit .Service.Name | comment }}
+const serverInitT = `{{- $doc := printf "%s instantiates HTTP handlers for all the %s service endpoints using the provided encoder and decoder. The handlers are mounted on the given mux using the HTTP verb and path defined in the design. errhandler is called whenever a response fails to be encoded. formatter is used to format errors returned by the service methods prior to encoding. Both errhandler and formatter are optional and can be nil." .ServerInit .Service.Name }}
+{{- if hasIdempotency . }}{{ $doc = printf "%s idempotency records the responses replayed to requests that reuse an idempotency key." $doc }}{{ end }}
+{{- if hasWebhookSignature . }}{{ $doc = printf "%s webhookSecret is the secret used to verify the HMAC signatures of the requests, the requests are rejected if it is empty." $doc }}{{ end }}
+{{- if hasCipher . }}{{ $doc = printf "%s cipher decrypts and encrypts the payload and result fields flagged with the \"crypto:field\" meta." $doc }}{{ end }}
+{{- if .RequestIDHeader }}{{ $doc = printf "%s The handlers are wrapped with the middleware returned by RequestIDMiddleware." $doc }}{{ end }}
+{{- if .ProblemErrors }}{{ $doc = printf "%s The errors are encoded as RFC 7807 problem details." $doc }}{{ end }}
+{{- comment $doc }}
 func {{ .ServerInit }}(
 	e *{{ .Service.PkgName }}.Endpoints,
 	mux goahttp.Muxer,
@@ -304,6 +417,15 @@ func {{ .ServerInit }}(
 	upgrader goahttp.Upgrader,
 	configurer *ConnConfigurer,
 	{{- end }}
+	{{- if hasIdempotency . }}
+	idempotency goahttp.IdempotencyStore,
+	{{- end }}
+	{{- if hasWebhookSignature . }}
+	webhookSecret []byte,
+	{{- end }}
+	{{- if hasCipher . }}
+	cipher goahttp.Cipher,
+	{{- end }}
 	{{- range .Endpoints }}
 		{{- if .MultipartRequestDecoder }}
 	{{ .MultipartRequestDecoder.VarName }} {{ .MultipartRequestDecoder.FuncName }},
@@ -323,7 +445,7 @@ func {{ .ServerInit }}(
 		{{ .ArgName }} = http.Dir(".")
 	}
 	{{- end }}
-	return &{{ .ServerStruct }}{
+	{{ if .RequestIDHeader }}s := {{ else }}return {{ end }}&{{ .ServerStruct }}{
 		Mounts: []*{{ .MountPointStruct }}{
 			{{- range $e := .Endpoints }}
 				{{- range $e.Routes }}
@@ -336,14 +458,91 @@ func {{ .ServerInit }}(
 			{"{{ $filepath }}", "GET", "{{ . }}"},
 				{{- end }}
 			{{- end }}
+			{{- if .CORS }}
+				{{- $cors := .CORS }}
+				{{- range .CORS.Paths }}
+			{"{{ $cors.VarName }}", "OPTIONS", "{{ .Path }}"},
+				{{- end }}
+			{{- end }}
+			{{- if .Options }}
+				{{- $options := .Options }}
+				{{- range .Options.Paths }}
+			{"{{ $options.VarName }}", "OPTIONS", "{{ .Path }}"},
+				{{- end }}
+			{{- end }}
+			{{- if .Version }}
+			{"{{ .Version.VarName }}", "GET", "{{ .Version.Path }}"},
+			{{- end }}
 		},
 		{{- range .Endpoints }}
-		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}decoder{{ end }}, encoder, errhandler, formatter{{ if isWebSocketEndpoint . }}, upgrader, configurer.{{ .Method.VarName }}Fn{{ end }}),
+		{{ .Method.VarName }}: {{ if .Gzip }}goahttp.Gzip(goahttp.GzipMinSize)({{ end }}{{ if .SparseFields }}goahttp.SparseFields({{ range $i, $f := .SparseFields }}{{ if $i }}, {{ end }}{{ printf "%q" $f }}{{ end }})({{ end }}{{ if .WebhookSignature }}goahttp.VerifyWebhookSignature(webhookSecret, {{ printf "%q" .WebhookSignature.Header }}, {{ printf "%q" .WebhookSignature.Algorithm }})({{ end }}{{ if .Idempotency }}goahttp.Idempotent(idempotency, {{ printf "%q" .Idempotency.Scope }}, {{ printf "%q" .Idempotency.Header }}, {{ .Idempotency.TTL }})({{ end }}{{ .HandlerInit }}({{ if .Cipher }}{{ .Cipher.EndpointInit }}(e.{{ .Method.VarName }}, cipher){{ else }}e.{{ .Method.VarName }}{{ end }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}decoder{{ end }}, encoder, errhandler, formatter{{ if isWebSocketEndpoint . }}, upgrader, configurer.{{ .Method.VarName }}Fn{{ end }}){{ if .Idempotency }}){{ end }}{{ if .WebhookSignature }}){{ end }}{{ if .SparseFields }}){{ end }}{{ if .Gzip }}){{ end }},
 		{{- end }}
 		{{- range .FileServers }}
 		{{ .VarName }}: http.FileServer({{ .ArgName }}),
 		{{- end }}
+		{{- if .CORS }}
+		{{ .CORS.VarName }}: {{ .CORS.HandlerInit }}(),
+		{{- end }}
+		{{- if .Options }}
+		{{ .Options.VarName }}: {{ .Options.HandlerInit }}(),
+		{{- end }}
+		{{- if .Version }}
+		{{ .Version.VarName }}: {{ .Version.HandlerInit }}(),
+		{{- end }}
 	}
+{{- if .RequestIDHeader }}
+	s.Use(RequestIDMiddleware())
+	return s
+{{- end }}
+}
+`
+
+// input: EndpointData
+const cipherEndpointT = `{{ printf "%s wraps the %q service %q endpoint to decrypt the payload fields and encrypt the result fields flagged with the \"crypto:field\" meta using cipher." .Cipher.EndpointInit .ServiceName .Method.Name | comment }}
+func {{ .Cipher.EndpointInit }}(endpoint goa.Endpoint, cipher goahttp.Cipher) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+	{{- if .Cipher.PayloadFields }}
+		p := req.({{ .Cipher.PayloadRef }})
+		{{- range .Cipher.PayloadFields }}
+		if err := goahttp.DecryptField(ctx, cipher, {{ printf "%q" .Name }}, {{ .Ref }}); err != nil {
+			return nil, err
+		}
+		{{- end }}
+	{{- end }}
+		res, err := endpoint(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	{{- if .Cipher.ResultFields }}
+		r, ok := res.({{ .Cipher.ResultRef }})
+		if !ok || r == nil {
+			return res, nil
+		}
+		{{- range .Cipher.ResultFields }}
+		if err := goahttp.EncryptField(ctx, cipher, {{ printf "%q" .Name }}, {{ .Ref }}); err != nil {
+			return nil, err
+		}
+		{{- end }}
+	{{- end }}
+		return res, nil
+	}
+}
+`
+
+// input: ServiceData
+const requestIDMiddlewareT = `{{ printf "RequestIDMiddleware returns a HTTP middleware that initializes the request context with the request ID read from the %q header or with a new UUID if the request does not define the header." .RequestIDHeader | comment }}
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return httpmdlwr.RequestID(
+		httpmdlwr.RequestIDHeaderOption({{ printf "%q" .RequestIDHeader }}),
+		httpmdlwr.RequestIDFuncOption(middleware.UUID),
+	)
+}
+`
+
+// input: ServiceData
+const methodOverrideMiddlewareT = `{{ printf "MethodOverrideMiddleware returns a HTTP middleware that overrides the method of POST requests with the value of the X-HTTP-Method-Override header if it is one of %s. The middleware must wrap the muxer so that the request method is overridden before the request is routed." (join .MethodOverrides ", ") | comment }}
+func MethodOverrideMiddleware() func(http.Handler) http.Handler {
+	return httpmdlwr.MethodOverride({{ range $i, $m := .MethodOverrides }}{{ if $i }}, {{ end }}{{ printf "%q" $m }}{{ end }})
 }
 `
 
@@ -363,6 +562,15 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 {{- range .Endpoints }}
 	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
 {{- end }}
+{{- if .CORS }}
+	s.{{ .CORS.VarName }} = m(s.{{ .CORS.VarName }})
+{{- end }}
+{{- if .Options }}
+	s.{{ .Options.VarName }} = m(s.{{ .Options.VarName }})
+{{- end }}
+{{- if .Version }}
+	s.{{ .Version.VarName }} = m(s.{{ .Version.VarName }})
+{{- end }}
 }
 `
 
@@ -370,7 +578,16 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 const serverMountT = `{{ printf "%s configures the mux to serve the %s endpoints." .MountServer .Service.Name | comment }}
 func {{ .MountServer }}(mux goahttp.Muxer, h *{{ .ServerStruct }}) {
 	{{- range .Endpoints }}
-	{{ .MountHandler }}(mux, h.{{ .Method.VarName }})
+	{{ .MountHandler }}(mux, {{ if $.CORS }}{{ $.CORS.Handle }}(h.{{ .Method.VarName }}){{ else }}h.{{ .Method.VarName }}{{ end }})
+	{{- end }}
+	{{- if .CORS }}
+	{{ .CORS.MountHandler }}(mux, h.{{ .CORS.VarName }})
+	{{- end }}
+	{{- if .Options }}
+	{{ .Options.MountHandler }}(mux, h.{{ .Options.VarName }})
+	{{- end }}
+	{{- if .Version }}
+	{{ .Version.MountHandler }}(mux, {{ if $.CORS }}{{ $.CORS.Handle }}(h.{{ .Version.VarName }}){{ else }}h.{{ .Version.VarName }}{{ end }})
 	{{- end }}
 	{{- range .FileServers }}
 		{{- if .Redirect }}
@@ -403,7 +620,7 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 		}
 	}
 	{{- range .Routes }}
-	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
+	mux.Handle("{{ .Verb }}", "{{ .Path }}", {{ if .Patterns }}goahttp.MatchPathPatterns(mux, map[string]string{ {{- range $i, $p := .Patterns }}{{ if $i }}, {{ end }}{{ printf "%q" $p.Name }}: {{ printf "%q" $p.Pattern }}{{ end }}}, f){{ else }}f{{ end }})
 	{{- end }}
 }
 `
@@ -424,6 +641,95 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 }
 `
 
+// input: CORSData
+const corsHandlerT = `{{ printf "%s configures the mux to serve the CORS preflight requests made to the service endpoints." .MountHandler | comment }}
+func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	h = {{ .Handle }}(h)
+	{{- range .Paths }}
+	mux.Handle("OPTIONS", "{{ .Path }}", {{ if .Allow }}goahttp.AllowMethods({{ printf "%q" .Allow }}, h){{ else }}h.ServeHTTP{{ end }})
+	{{- end }}
+}
+
+{{ printf "%s creates a HTTP handler which returns a simple 204 response to CORS preflight requests." .HandlerInit | comment }}
+func {{ .HandlerInit }}() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+{{ printf "%s applies the CORS response headers to requests made by allowed origins." .Handle | comment }}
+func {{ .Handle }}(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	{{- if .Vary }}
+		// The response depends on the origin whether it is allowed or not.
+		w.Header().Add("Vary", "Origin")
+	{{- end }}
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if {{ range $i, $o := .Origins }}{{ if $i }} || {{ end }}goahttp.MatchOrigin(origin, {{ printf "%q" $o }}){{ end }} {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		{{- if not .Vary }}
+			w.Header().Add("Vary", "Origin")
+		{{- end }}
+		{{- if .Exposed }}
+			w.Header().Set("Access-Control-Expose-Headers", {{ printf "%q" .Exposed }})
+		{{- end }}
+		{{- if .Credentials }}
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		{{- end }}
+			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+				// We are handling a preflight request
+			{{- if .Methods }}
+				w.Header().Set("Access-Control-Allow-Methods", {{ printf "%q" .Methods }})
+			{{- end }}
+			{{- if .Headers }}
+				w.Header().Set("Access-Control-Allow-Headers", {{ printf "%q" .Headers }})
+			{{- end }}
+			{{- if .MaxAge }}
+				w.Header().Set("Access-Control-Max-Age", {{ printf "%q" .MaxAge }})
+			{{- end }}
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+`
+
+// input: OptionsData
+const optionsHandlerT = `{{ printf "%s configures the mux to serve the OPTIONS requests made to the service endpoints. The responses list the methods accepted by the request path in the Allow header." .MountHandler | comment }}
+func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	{{- range .Paths }}
+	mux.Handle("OPTIONS", "{{ .Path }}", goahttp.AllowMethods({{ printf "%q" .Allow }}, h))
+	{{- end }}
+}
+
+{{ printf "%s creates a HTTP handler which returns a simple 204 response to OPTIONS requests." .HandlerInit | comment }}
+func {{ .HandlerInit }}() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+`
+
+// input: VersionData
+const versionHandlerT = `{{ printf "%s configures the mux to serve the API version." .MountHandler | comment }}
+func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "{{ .Path }}", h.ServeHTTP)
+}
+
+{{ printf "%s creates a HTTP handler which writes the API version in a JSON response." .HandlerInit | comment }}
+func {{ .HandlerInit }}() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte({{ printf "%q" .Body }}))
+	})
+}
+`
+
 // input: EndpointData
 const serverHandlerInitT = `{{ printf "%s creates a HTTP handler which loads the HTTP request and calls the %q service %q endpoint." .HandlerInit .ServiceName .Method.Name | comment }}
 func {{ .HandlerInit }}(
@@ -444,19 +750,50 @@ func {{ .HandlerInit }}(
 		{{- if mustDecodeRequest . }}
 		decodeRequest  = {{ .RequestDecoder }}(mux, decoder)
 		{{- end }}
-		{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+		{{- if not (or .Redirect (isWebSocketEndpoint .) .ServerSSE) }}
 		encodeResponse = {{ .ResponseEncoder }}(encoder)
 		{{- end }}
 		{{- if (or (mustDecodeRequest .) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
-		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else }}goahttp.ErrorEncoder{{ end }}(encoder, formatter)
+		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else if .ProblemErrors }}goahttp.ProblemErrorEncoder{{ else if .ValidationErrors }}goahttp.ValidationErrorEncoder{{ else }}goahttp.ErrorEncoder{{ end }}(encoder, formatter)
 		{{- end }}
 	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	)
 	{{- end }}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	{{- if .Trace }}
+		ctx, span := otel.Tracer({{ printf "%q" .Trace.TracerName }}).Start(r.Context(), {{ printf "%q" .Trace.SpanName }},
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", {{ printf "%q" .Trace.Route }}),
+			),
+		)
+		rc := httpmdlwr.CaptureResponse(w)
+		w = rc
+		defer func() {
+			status := rc.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			span.End()
+		}()
+		ctx = context.WithValue(ctx, goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+	{{- else }}
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+	{{- end }}
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .Sunset }}
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", {{ printf "%q" .Sunset }})
+	{{- end }}
+	{{- if .Conditional }}
+		ctx = context.WithValue(ctx, goahttp.IfMatchKey, r.Header.Get("If-Match"))
+	{{- end }}
 
 	{{- if mustDecodeRequest . }}
 		{{ if .Redirect }}_{{ else }}payload{{ end }}, err := decodeRequest(r)
@@ -469,6 +806,19 @@ func {{ .HandlerInit }}(
 	{{- else if not .Redirect }}
 		var err error
 	{{- end }}
+	{{- if and .Trace .Trace.Attributes (mustDecodeRequest .) (not .Redirect) }}
+		if p, ok := payload.({{ .Payload.Ref }}); ok {
+		{{- range .Trace.Attributes }}
+			{{- if .Check }}
+			if {{ .Check }} {
+				span.SetAttributes(attribute.{{ .Func }}({{ printf "%q" .Key }}, {{ .Value }}))
+			}
+			{{- else }}
+			span.SetAttributes(attribute.{{ .Func }}({{ printf "%q" .Key }}, {{ .Value }}))
+			{{- end }}
+		{{- end }}
+		}
+	{{- end }}
 	{{- if isWebSocketEndpoint . }}
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithCancel(ctx)
@@ -485,6 +835,15 @@ func {{ .HandlerInit }}(
 		{{- end }}
 		}
 		_, err = endpoint(ctx, v)
+	{{- else if .ServerSSE }}
+		stream := &{{ .ServerSSE.VarName }}{w: w, r: r}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: stream,
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
 	{{- else if .Method.SkipRequestBodyEncodeDecode }}
 		data := &{{ .ServicePkgName }}.{{ .Method.RequestStruct }}{ {{ if .Payload.Ref }}Payload: payload.({{ .Payload.Ref }}), {{ end }}Body: r.Body }
 		res, err := endpoint(ctx, data)
@@ -495,6 +854,9 @@ func {{ .HandlerInit }}(
 	{{- end }}
 	{{- if not .Redirect }}
 		if err != nil {
+			{{- if .Trace }}
+			span.RecordError(err)
+			{{- end }}
 			{{- if isWebSocketEndpoint . }}
 			if _, werr := w.Write(nil); werr == http.ErrHijacked {
 				// Response writer has been hijacked, do not encode the error
@@ -502,17 +864,40 @@ func {{ .HandlerInit }}(
 				return
 			}
 			{{- end }}
+			{{- if .ServerSSE }}
+			if stream.started {
+				// Events have been sent, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
+			{{- end }}
+			{{- if .Conditional }}
+			if errors.Is(err, goahttp.ErrPreconditionFailed) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
 			return
 		}
 	{{- end }}
+	{{- if .Conditional }}
+		if v, ok := res.({{ .Conditional.ResultRef }}); ok {
+			etag := goahttp.ETag({{ .Conditional.ETag }}, {{ .Conditional.Weak }})
+			w.Header().Set("ETag", etag)
+			if code := goahttp.CheckConditions(r, etag); code != 0 {
+				w.WriteHeader(code)
+				return
+			}
+		}
+	{{- end }}
 	{{- if .Method.SkipResponseBodyEncodeDecode }}
 		o := res.(*{{ .ServicePkgName }}.{{ .Method.ResponseStruct }})
 		defer o.Body.Close()
 	{{- end }}
-	{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+	{{- if not (or .Redirect (isWebSocketEndpoint .) .ServerSSE) }}
 		if err := encodeResponse(ctx, w, {{ if and .Method.SkipResponseBodyEncodeDecode .Result.Ref }}o.Result{{ else }}res{{ end }}); err != nil {
 			errhandler(ctx, w, err)
 			{{- if .Method.SkipResponseBodyEncodeDecode }}
@@ -553,7 +938,15 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 			body {{ .Payload.Request.ServerBody.VarName }}
 			err  error
 		)
+	{{- if .Payload.Request.UseNumber }}
+		dec := decoder(r)
+		if nd, ok := dec.(interface{ UseNumber() }); ok {
+			nd.UseNumber()
+		}
+		err = dec.Decode(&body)
+	{{- else }}
 		err = decoder(r).Decode(&body)
+	{{- end }}
 		if err != nil {
 	{{- if .Payload.Request.MustHaveBody }}
 			if err == io.EOF {
@@ -580,7 +973,11 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 	{{- template "request_elements" .Payload.Request }}
 	{{- if .Payload.Request.MustValidate }}
 		if err != nil {
+		{{- if .MissingParamError }}
+			return nil, goa.RenameMissingParamError(err, {{ printf "%q" .MissingParamError }})
+		{{- else }}
 			return nil, err
+		{{- end }}
 		}
 	{{- end }}
 	{{- if .Payload.Request.PayloadInit }}
@@ -651,6 +1048,13 @@ const requestElementsT = `{{- define "request_elements" }}
 		)
 
 {{- range .PathParams }}
+	{{- if .Style }}
+		if v, perr := goahttp.DecodePathParam(params["{{ .Name }}"], "{{ .Name }}", "{{ .Style }}", {{ .Explode }}); perr != nil {
+			err = goa.MergeErrors(err, perr)
+		} else {
+			params["{{ .Name }}"] = v
+		}
+	{{- end }}
 	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
 		{{ .VarName }} = params["{{ .Name }}"]
 
@@ -667,14 +1071,18 @@ const requestElementsT = `{{- define "request_elements" }}
 {{- end }}
 
 {{- range .QueryParams }}
+	{{- template "query_param" . }}
+{{- end }}
+
+{{- range .Headers }}
 	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
-		{{ .VarName }} = r.URL.Query().Get("{{ .Name }}")
+		{{ .VarName }} = r.Header.Get("{{ .Name }}")
 		if {{ .VarName }} == "" {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
 		}
 
 	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		{{ .VarName }}Raw := r.URL.Query().Get("{{ .Name }}")
+		{{ .VarName }}Raw := r.Header.Get("{{ .Name }}")
 		if {{ .VarName }}Raw != "" {
 			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
 		}
@@ -684,27 +1092,22 @@ const requestElementsT = `{{- define "request_elements" }}
 		{{- end }}
 
 	{{- else if .StringSlice }}
-		{{ .VarName }} = r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }} = r.Header["{{ .CanonicalName }}"]
 		{{- if .Required }}
 		if {{ .VarName }} == nil {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
 		}
 		{{- else if .DefaultValue }}
 		if {{ .VarName }} == nil {
-			{{ .VarName }} = []string{
-                {{- range $i, $v := .DefaultValue }}
-                    {{- if $i }}{{ print ", " }}{{ end }}
-                    {{- printf "%q" $v -}}
-                {{- end -}} }
+			{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
 		}
 		{{- end }}
 
 	{{- else if .Slice }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()["{{ .Name }}"]
-		{{- if .Required }}
-		if {{ .VarName }}Raw == nil {
-			return nil, goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+		{{ .VarName }}Raw := r.Header["{{ .CanonicalName }}"]
+		{{ if .Required }}if {{ .VarName }}Raw == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
 		}
 		{{- else if .DefaultValue }}
 		if {{ .VarName }}Raw == nil {
@@ -722,66 +1125,65 @@ const requestElementsT = `{{- define "request_elements" }}
 		{{- end }}
 	}
 
-	{{- else if .Map }}
+	{{- else }}{{/* not string, not any and not slice */}}
 	{
-		{{ .VarName }}Raw := r.URL.Query()
+		{{ .VarName }}Raw := r.Header.Get("{{ .Name }}")
 		{{- if .Required }}
-		if len({{ .VarName }}Raw) == 0 {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+		if {{ .VarName }}Raw == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
 		}
 		{{- else if .DefaultValue }}
-		if len({{ .VarName }}Raw) == 0 {
+		if {{ .VarName }}Raw == "" {
 			{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
 		}
 		{{- end }}
 
 		{{- if .DefaultValue }}else {
 		{{- else if not .Required }}
-		if len({{ .VarName }}Raw) != 0 {
+		if {{ .VarName }}Raw != "" {
 		{{- end }}
-		for keyRaw, valRaw := range {{ .VarName }}Raw {
-			if strings.HasPrefix(keyRaw, "{{ .Name }}[") {
-				{{- template "map_conversion" (mapQueryDecodeData .Type .VarName 0) }}
-			}
-		}
+		{{- template "type_conversion" . }}
 		{{- if or .DefaultValue (not .Required) }}
 		}
 		{{- end }}
 	}
+	{{- end }}
+	{{- if .Validate }}
+		{{ .Validate }}
+	{{- end }}
+{{- end }}
 
-	{{- else if .MapQueryParams }}
-	{
-		{{ .VarName }}Raw := r.URL.Query()
-		{{- if .Required }}
-		if len({{ .VarName }}Raw) == 0 {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
-		}
-		{{- else if .DefaultValue }}
-		if len({{ .VarName }}Raw) == 0 {
-			{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
+{{- range .Cookies }}
+	c, {{ if not .Required }}_{{ else }}err{{ end }} = r.Cookie("{{ .Name }}")
+	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
+		if err == http.ErrNoCookie {
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
+		} else {
+			{{ .VarName }} = c.Value
 		}
-		{{- end }}
 
-		{{- if .DefaultValue }}else {
-		{{- else if not .Required }}
-		if len({{ .VarName }}Raw) != 0 {
-		{{- end }}
-		for keyRaw, valRaw := range {{ .VarName }}Raw {
-			if strings.HasPrefix(keyRaw, "{{ .Name }}[") {
-				{{- template "map_conversion" (mapQueryDecodeData .Type .VarName 0) }}
-			}
+	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
+		var {{ .VarName }}Raw string
+		if c != nil {
+			{{ .VarName }}Raw = c.Value
 		}
-		{{- if or .DefaultValue (not .Required) }}
+		if {{ .VarName }}Raw != "" {
+			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+		}
+		{{- if .DefaultValue }} else {
+			{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
 		}
 		{{- end }}
-	}
 
-	{{- else }}{{/* not string, not any, not slice and not map */}}
+	{{- else }}{{/* not string and not any */}}
 	{
-		{{ .VarName }}Raw := r.URL.Query().Get("{{ .Name }}")
+		var {{ .VarName }}Raw string
+		if c != nil {
+			{{ .VarName }}Raw = c.Value
+		}
 		{{- if .Required }}
 		if {{ .VarName }}Raw == "" {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
 		}
 		{{- else if .DefaultValue }}
 		if {{ .VarName }}Raw == "" {
@@ -798,22 +1200,31 @@ const requestElementsT = `{{- define "request_elements" }}
 		}
 		{{- end }}
 	}
-
 	{{- end }}
-		{{- if .Validate }}
+	{{- if .Validate }}
 		{{ .Validate }}
-		{{- end }}
+	{{- end }}
+{{- end }}
+{{- end }}
 {{- end }}
 
-{{- range .Headers }}
+{{- define "missing_query_param" -}}
+	goa.{{ if .MissingParam }}MissingParamError{{ else }}MissingFieldError{{ end }}("{{ .Name }}", "query string")
+{{- end }}
+
+{{- define "query_slice" -}}
+	{{ if .Delimiter }}goahttp.DecodeDelimitedQueryParam(r.URL.Query()["{{ .Name }}"], {{ printf "%q" .Delimiter }}){{ else }}r.URL.Query()["{{ .Name }}"]{{ end }}
+{{- end }}
+
+{{- define "query_param" }}
 	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
-		{{ .VarName }} = r.Header.Get("{{ .Name }}")
-		if {{ .VarName }} == "" {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
+		{{ .VarName }} = r.URL.Query().Get("{{ .Name }}")
+		if {{ if .AllowEmptyValue }}!r.URL.Query().Has("{{ .Name }}"){{ else }}{{ .VarName }} == ""{{ end }} {
+			err = goa.MergeErrors(err, {{ template "missing_query_param" . }})
 		}
 
 	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		{{ .VarName }}Raw := r.Header.Get("{{ .Name }}")
+		{{ .VarName }}Raw := r.URL.Query().Get("{{ .Name }}")
 		if {{ .VarName }}Raw != "" {
 			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
 		}
@@ -823,22 +1234,27 @@ const requestElementsT = `{{- define "request_elements" }}
 		{{- end }}
 
 	{{- else if .StringSlice }}
-		{{ .VarName }} = r.Header["{{ .CanonicalName }}"]
+		{{ .VarName }} = {{ template "query_slice" . }}
 		{{- if .Required }}
 		if {{ .VarName }} == nil {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
+			err = goa.MergeErrors(err, {{ template "missing_query_param" . }})
 		}
 		{{- else if .DefaultValue }}
 		if {{ .VarName }} == nil {
-			{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
+			{{ .VarName }} = []string{
+                {{- range $i, $v := .DefaultValue }}
+                    {{- if $i }}{{ print ", " }}{{ end }}
+                    {{- printf "%q" $v -}}
+                {{- end -}} }
 		}
 		{{- end }}
 
 	{{- else if .Slice }}
 	{
-		{{ .VarName }}Raw := r.Header["{{ .CanonicalName }}"]
-		{{ if .Required }}if {{ .VarName }}Raw == nil {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
+		{{ .VarName }}Raw := {{ template "query_slice" . }}
+		{{- if .Required }}
+		if {{ .VarName }}Raw == nil {
+			err = goa.MergeErrors(err, {{ template "missing_query_param" . }})
 		}
 		{{- else if .DefaultValue }}
 		if {{ .VarName }}Raw == nil {
@@ -856,65 +1272,84 @@ const requestElementsT = `{{- define "request_elements" }}
 		{{- end }}
 	}
 
-	{{- else }}{{/* not string, not any and not slice */}}
+	{{- else if .DeepObject }}
+		if goahttp.HasDeepObjectParam(r.URL.Query(), "{{ .Name }}") {
+			var (
+		{{- range .DeepObject.Fields }}
+				{{ .VarName }} {{ .TypeRef }}
+		{{- end }}
+			)
+		{{- range .DeepObject.Fields }}
+			{{- template "query_param" . }}
+		{{- end }}
+			{{ .VarName }} = &{{ .DeepObject.TypeName }}{}
+			{{ .DeepObject.Init }}
+		}
+		{{- if .Required }} else {
+			err = goa.MergeErrors(err, {{ template "missing_query_param" . }})
+		}
+		{{- end }}
+
+	{{- else if .Map }}
 	{
-		{{ .VarName }}Raw := r.Header.Get("{{ .Name }}")
+		{{ .VarName }}Raw := r.URL.Query()
 		{{- if .Required }}
-		if {{ .VarName }}Raw == "" {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "header"))
+		if len({{ .VarName }}Raw) == 0 {
+			err = goa.MergeErrors(err, {{ template "missing_query_param" . }})
 		}
 		{{- else if .DefaultValue }}
-		if {{ .VarName }}Raw == "" {
+		if len({{ .VarName }}Raw) == 0 {
 			{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
 		}
 		{{- end }}
 
 		{{- if .DefaultValue }}else {
 		{{- else if not .Required }}
-		if {{ .VarName }}Raw != "" {
+		if len({{ .VarName }}Raw) != 0 {
 		{{- end }}
-		{{- template "type_conversion" . }}
+		for keyRaw, valRaw := range {{ .VarName }}Raw {
+			if strings.HasPrefix(keyRaw, "{{ .Name }}[") {
+				{{- template "map_conversion" (mapQueryDecodeData .Type .VarName 0) }}
+			}
+		}
 		{{- if or .DefaultValue (not .Required) }}
 		}
 		{{- end }}
 	}
-	{{- end }}
-	{{- if .Validate }}
-		{{ .Validate }}
-	{{- end }}
-{{- end }}
 
-{{- range .Cookies }}
-	c, {{ if not .Required }}_{{ else }}err{{ end }} = r.Cookie("{{ .Name }}")
-	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
-		if err == http.ErrNoCookie {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
-		} else {
-			{{ .VarName }} = c.Value
+	{{- else if .MapQueryParams }}
+	{
+		{{ .VarName }}Raw := r.URL.Query()
+		{{- if .Required }}
+		if len({{ .VarName }}Raw) == 0 {
+			err = goa.MergeErrors(err, {{ template "missing_query_param" . }})
 		}
-
-	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		var {{ .VarName }}Raw string
-		if c != nil {
-			{{ .VarName }}Raw = c.Value
+		{{- else if .DefaultValue }}
+		if len({{ .VarName }}Raw) == 0 {
+			{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
 		}
-		if {{ .VarName }}Raw != "" {
-			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+		{{- end }}
+
+		{{- if .DefaultValue }}else {
+		{{- else if not .Required }}
+		if len({{ .VarName }}Raw) != 0 {
+		{{- end }}
+		for keyRaw, valRaw := range {{ .VarName }}Raw {
+			if strings.HasPrefix(keyRaw, "{{ .Name }}[") {
+				{{- template "map_conversion" (mapQueryDecodeData .Type .VarName 0) }}
+			}
 		}
-		{{- if .DefaultValue }} else {
-			{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+		{{- if or .DefaultValue (not .Required) }}
 		}
 		{{- end }}
+	}
 
-	{{- else }}{{/* not string and not any */}}
+	{{- else }}{{/* not string, not any, not slice and not map */}}
 	{
-		var {{ .VarName }}Raw string
-		if c != nil {
-			{{ .VarName }}Raw = c.Value
-		}
+		{{ .VarName }}Raw := r.URL.Query().Get("{{ .Name }}")
 		{{- if .Required }}
 		if {{ .VarName }}Raw == "" {
-			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
+			err = goa.MergeErrors(err, {{ template "missing_query_param" . }})
 		}
 		{{- else if .DefaultValue }}
 		if {{ .VarName }}Raw == "" {
@@ -922,21 +1357,18 @@ const requestElementsT = `{{- define "request_elements" }}
 		}
 		{{- end }}
 
-		{{- if .DefaultValue }}else {
-		{{- else if not .Required }}
+		{{- if or .DefaultValue .Required }}else {
+		{{- else }}
 		if {{ .VarName }}Raw != "" {
 		{{- end }}
 		{{- template "type_conversion" . }}
-		{{- if or .DefaultValue (not .Required) }}
 		}
-		{{- end }}
 	}
+
 	{{- end }}
-	{{- if .Validate }}
+		{{- if .Validate }}
 		{{ .Validate }}
-	{{- end }}
-{{- end }}
-{{- end }}
+		{{- end }}
 {{- end }}
 
 {{- define "path_conversion" }}
@@ -1007,7 +1439,7 @@ const typeConversionT = `{{- define "slice_conversion" }}
 	{{- else if eq .Type.Name "int" }}
 		v, err2 := strconv.ParseInt({{ .VarName }}Raw, 10, strconv.IntSize)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "integer"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "integer"))
 		}
 		{{- if .Pointer }}
 		pv := int(v)
@@ -1018,7 +1450,7 @@ const typeConversionT = `{{- define "slice_conversion" }}
 	{{- else if eq .Type.Name "int32" }}
 		v, err2 := strconv.ParseInt({{ .VarName }}Raw, 10, 32)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "integer"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "integer"))
 		}
 		{{- if .Pointer }}
 		pv := int32(v)
@@ -1029,13 +1461,13 @@ const typeConversionT = `{{- define "slice_conversion" }}
 	{{- else if eq .Type.Name "int64" }}
 		v, err2 := strconv.ParseInt({{ .VarName }}Raw, 10, 64)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "integer"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "integer"))
 		}
 		{{ .VarName }} = {{ if .Pointer}}&{{ end }}v
 	{{- else if eq .Type.Name "uint" }}
 		v, err2 := strconv.ParseUint({{ .VarName }}Raw, 10, strconv.IntSize)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "unsigned integer"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "unsigned integer"))
 		}
 		{{- if .Pointer }}
 		pv := uint(v)
@@ -1046,7 +1478,7 @@ const typeConversionT = `{{- define "slice_conversion" }}
 	{{- else if eq .Type.Name "uint32" }}
 		v, err2 := strconv.ParseUint({{ .VarName }}Raw, 10, 32)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "unsigned integer"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "unsigned integer"))
 		}
 		{{- if .Pointer }}
 		pv := uint32(v)
@@ -1057,13 +1489,13 @@ const typeConversionT = `{{- define "slice_conversion" }}
 	{{- else if eq .Type.Name "uint64" }}
 		v, err2 := strconv.ParseUint({{ .VarName }}Raw, 10, 64)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "unsigned integer"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "unsigned integer"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else if eq .Type.Name "float32" }}
 		v, err2 := strconv.ParseFloat({{ .VarName }}Raw, 32)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "float"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "float"))
 		}
 		{{- if .Pointer }}
 		pv := float32(v)
@@ -1074,13 +1506,13 @@ const typeConversionT = `{{- define "slice_conversion" }}
 	{{- else if eq .Type.Name "float64" }}
 		v, err2 := strconv.ParseFloat({{ .VarName }}Raw, 64)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "float"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "float"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else if eq .Type.Name "boolean" }}
 		v, err2 := strconv.ParseBool({{ .VarName }}Raw)
 		if err2 != nil {
-			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else }}
@@ -1095,55 +1527,55 @@ const typeConversionT = `{{- define "slice_conversion" }}
 		{{- else if eq .Type.ElemType.Type.Name "int" }}
 			v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of integers"))
 			}
 			{{ .VarName }}[i] = int(v)
 		{{- else if eq .Type.ElemType.Type.Name "int32" }}
 			v, err2 := strconv.ParseInt(rv, 10, 32)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of integers"))
 			}
 			{{ .VarName }}[i] = int32(v)
 		{{- else if eq .Type.ElemType.Type.Name "int64" }}
 			v, err2 := strconv.ParseInt(rv, 10, 64)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of integers"))
 			}
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "uint" }}
 			v, err2 := strconv.ParseUint(rv, 10, strconv.IntSize)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of unsigned integers"))
 			}
 			{{ .VarName }}[i] = uint(v)
 		{{- else if eq .Type.ElemType.Type.Name "uint32" }}
 			v, err2 := strconv.ParseUint(rv, 10, 32)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of unsigned integers"))
 			}
 			{{ .VarName }}[i] = uint32(v)
 		{{- else if eq .Type.ElemType.Type.Name "uint64" }}
 			v, err2 := strconv.ParseUint(rv, 10, 64)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of unsigned integers"))
 			}
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "float32" }}
 			v, err2 := strconv.ParseFloat(rv, 32)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of floats"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of floats"))
 			}
 			{{ .VarName }}[i] = float32(v)
 		{{- else if eq .Type.ElemType.Type.Name "float64" }}
 			v, err2 := strconv.ParseFloat(rv, 64)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of floats"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of floats"))
 			}
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "boolean" }}
 			v, err2 := strconv.ParseBool(rv)
 			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of booleans"))
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" (or .ErrorContext .VarName) }}, {{ .VarName}}Raw, "array of booleans"))
 			}
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "any" }}
@@ -1158,7 +1590,25 @@ const typeConversionT = `{{- define "slice_conversion" }}
 const responseEncoderT = `{{ printf "%s returns an encoder for responses returned by the %s %s endpoint." .ResponseEncoder .ServiceName .Method.Name | comment }}
 func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
 	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
-	{{- if .Result.MustInit }}
+	{{- if .Cache }}
+		w.Header().Set("Cache-Control", {{ printf "%q" .Cache.Control }})
+		{{- if .Cache.Vary }}
+		w.Header().Set("Vary", {{ printf "%q" .Cache.Vary }})
+		{{- end }}
+	{{- end }}
+	{{- if .RedirectFrom }}
+		{{- if .Method.ViewedResult }}
+		res := v.({{ .Method.ViewedResult.FullRef }})
+		{{- else }}
+		res, _ := v.({{ .Result.Ref }})
+		{{- end }}
+		if {{ if .RedirectFrom.Pointer }}{{ .RedirectFrom.FieldRef }} == nil || *{{ end }}{{ .RedirectFrom.FieldRef }} == "" {
+			return fmt.Errorf("cannot redirect: result attribute %q is empty", {{ printf "%q" .RedirectFrom.Name }})
+		}
+		w.Header().Set("Location", {{ if .RedirectFrom.Pointer }}*{{ end }}{{ .RedirectFrom.FieldRef }})
+		w.WriteHeader({{ .RedirectFrom.StatusCode }})
+		return nil
+	{{- else if .Result.MustInit }}
 		{{- if .Method.ViewedResult }}
 			res := v.({{ .Method.ViewedResult.FullRef }})
 			{{- if not .Method.ViewedResult.ViewName }}
@@ -1168,7 +1618,7 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 			res, _ := v.({{ .Result.Ref }})
 		{{- end }}
 		{{- range .Result.Responses }}
-			{{- if .ContentType }}
+			{{- if and .ContentType (not .ContentTypes) }}
 				ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "{{ .ContentType }}")
 			{{- end }}
 			{{- if .TagName }}
@@ -1177,6 +1627,14 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 				{{- else }}
 					if {{ if .ViewedResult }}*{{ end }}res.{{ if .ViewedResult }}Projected.{{ end }}{{ .TagName }} == {{ printf "%q" .TagValue }} {
 				{{- end }}
+			{{- end }}
+			{{- if .ContentTypes }}
+				ct, ok := goahttp.NegotiateContentType(ctx{{ range .ContentTypes }}, {{ printf "%q" . }}{{ end }})
+				if !ok {
+					w.WriteHeader(http.StatusNotAcceptable)
+					return nil
+				}
+				ctx = context.WithValue(ctx, goahttp.ContentTypeKey, ct)
 			{{- end -}}
 			{{ template "response" . }}
 			{{- if .ServerBody }}
@@ -1188,6 +1646,9 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 				}
 			{{- end }}
 		{{- end }}
+		{{- if .Result.ResponseTag }}
+			return fmt.Errorf("invalid {{ .Result.ResponseTag }} value %q", res.{{ .Result.ResponseTag }})
+		{{- end }}
 	{{- else }}
 		{{- with (index .Result.Responses 0) }}
 			w.WriteHeader({{ .StatusCode }})
@@ -1201,8 +1662,15 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 // input: EndpointData
 const errorEncoderT = `{{ printf "%s returns an encoder for errors returned by the %s %s endpoint." .ErrorEncoder .Method.Name .ServiceName | comment }}
 func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
-	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	encodeError := goahttp.{{ if .ProblemErrors }}Problem{{ else if .ValidationErrors }}Validation{{ end }}ErrorEncoder(encoder, formatter)
 	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+	{{- if .ErrorContentTypes }}
+		ct, ok := goahttp.NegotiateContentType(ctx{{ range .ErrorContentTypes }}, {{ printf "%q" . }}{{ end }})
+		if !ok {
+			ct = {{ printf "%q" (index .ErrorContentTypes 0) }}
+		}
+		ctx = context.WithValue(ctx, goahttp.ContentTypeKey, ct)
+	{{- end }}
 		var en goa.GoaErrorNamer
 		if !errors.As(v, &en) {
 			return encodeError(ctx, w, v)
@@ -1216,9 +1684,13 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 			{{- with .Response}}
 				{{- if .ContentType }}
 					ctx = context.WithValue(ctx, goahttp.ContentTypeKey, "{{ .ContentType }}")
+				{{- else if and $.ProblemErrors .ServerBody }}
+					ctx = context.WithValue(ctx, goahttp.ContentTypeKey, goahttp.ProblemContentType)
 				{{- end }}
 				{{- template "response" . }}
-				{{- if .ServerBody }}
+				{{- if and $.ProblemErrors .ServerBody }}
+				return enc.Encode(goahttp.NewProblemDetailsWithBody(v, {{ .StatusCode }}, body))
+				{{- else if .ServerBody }}
 				return enc.Encode(body)
 				{{- else }}
 				return nil
@@ -1352,6 +1824,15 @@ const responseT = `{{ define "response" -}}
 	{{- if .ErrorHeader }}
 	w.Header().Set("goa-error", res.GoaErrorName())
 	{{- end }}
+	{{- if .Location }}
+		{{- if .Location.Checks }}
+	if {{ range $i, $check := .Location.Checks }}{{ if $i }} && {{ end }}{{ $check }}{{ end }} {
+		w.Header().Set("Location", {{ .Location.PathInit }}({{ range $i, $arg := .Location.Args }}{{ if $i }}, {{ end }}{{ $arg }}{{ end }}))
+	}
+		{{- else }}
+	w.Header().Set("Location", {{ .Location.PathInit }}({{ range $i, $arg := .Location.Args }}{{ if $i }}, {{ end }}{{ $arg }}{{ end }}))
+		{{- end }}
+	{{- end }}
 	w.WriteHeader({{ .StatusCode }})
 {{- end }}
 
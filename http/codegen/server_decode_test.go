@@ -36,6 +36,7 @@ func TestDecode(t *testing.T) {
 		{"decode-query-string", testdata.PayloadQueryStringDSL, testdata.PayloadQueryStringDecodeCode},
 		{"decode-query-string-validate", testdata.PayloadQueryStringValidateDSL, testdata.PayloadQueryStringValidateDecodeCode},
 		{"decode-query-string-not-required-validate", testdata.PayloadQueryStringNotRequiredValidateDSL, testdata.PayloadQueryStringNotRequiredValidateDecodeCode},
+		{"decode-query-missing-param-error", testdata.PayloadQueryMissingParamErrorDSL, testdata.PayloadQueryMissingParamErrorDecodeCode},
 		{"decode-query-bytes", testdata.PayloadQueryBytesDSL, testdata.PayloadQueryBytesDecodeCode},
 		{"decode-query-bytes-validate", testdata.PayloadQueryBytesValidateDSL, testdata.PayloadQueryBytesValidateDecodeCode},
 		{"decode-query-any", testdata.PayloadQueryAnyDSL, testdata.PayloadQueryAnyDecodeCode},
@@ -80,6 +81,9 @@ func TestDecode(t *testing.T) {
 		{"decode-query-map-bool-array-string-validate", testdata.PayloadQueryMapBoolArrayStringValidateDSL, testdata.PayloadQueryMapBoolArrayStringValidateDecodeCode},
 		{"decode-query-map-bool-array-bool", testdata.PayloadQueryMapBoolArrayBoolDSL, testdata.PayloadQueryMapBoolArrayBoolDecodeCode},
 		{"decode-query-map-bool-array-bool-validate", testdata.PayloadQueryMapBoolArrayBoolValidateDSL, testdata.PayloadQueryMapBoolArrayBoolValidateDecodeCode},
+		{"decode-query-deep-object", testdata.PayloadQueryDeepObjectDSL, testdata.PayloadQueryDeepObjectDecodeCode},
+		{"decode-query-deep-object-types", testdata.PayloadQueryDeepObjectTypesDSL, testdata.PayloadQueryDeepObjectTypesDecodeCode},
+		{"decode-query-allow-empty-value", testdata.PayloadQueryAllowEmptyValueDSL, testdata.PayloadQueryAllowEmptyValueDecodeCode},
 
 		{"decode-query-primitive-string-validate", testdata.PayloadQueryPrimitiveStringValidateDSL, testdata.PayloadQueryPrimitiveStringValidateDecodeCode},
 		{"decode-query-primitive-bool-validate", testdata.PayloadQueryPrimitiveBoolValidateDSL, testdata.PayloadQueryPrimitiveBoolValidateDecodeCode},
@@ -103,6 +107,7 @@ func TestDecode(t *testing.T) {
 		{"decode-path-string-validate", testdata.PayloadPathStringValidateDSL, testdata.PayloadPathStringValidateDecodeCode},
 		{"decode-path-array-string", testdata.PayloadPathArrayStringDSL, testdata.PayloadPathArrayStringDecodeCode},
 		{"decode-path-array-string-validate", testdata.PayloadPathArrayStringValidateDSL, testdata.PayloadPathArrayStringValidateDecodeCode},
+		{"decode-path-style", testdata.PayloadPathStyleDSL, testdata.PayloadPathStyleDecodeCode},
 
 		{"decode-path-primitive-string-validate", testdata.PayloadPathPrimitiveStringValidateDSL, testdata.PayloadPathPrimitiveStringValidateDecodeCode},
 		{"decode-path-primitive-bool-validate", testdata.PayloadPathPrimitiveBoolValidateDSL, testdata.PayloadPathPrimitiveBoolValidateDecodeCode},
@@ -167,6 +172,7 @@ func TestDecode(t *testing.T) {
 
 		{"decode-body-query-object", testdata.PayloadBodyQueryObjectDSL, testdata.PayloadBodyQueryObjectDecodeCode},
 		{"decode-body-query-object-validate", testdata.PayloadBodyQueryObjectValidateDSL, testdata.PayloadBodyQueryObjectValidateDecodeCode},
+		{"decode-body-json-number-validate", testdata.PayloadBodyJSONNumberValidateDSL, testdata.PayloadBodyJSONNumberValidateDecodeCode},
 		{"decode-body-query-user", testdata.PayloadBodyQueryUserDSL, testdata.PayloadBodyQueryUserDecodeCode},
 		{"decode-body-query-user-validate", testdata.PayloadBodyQueryUserValidateDSL, testdata.PayloadBodyQueryUserValidateDecodeCode},
 
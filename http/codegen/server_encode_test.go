@@ -74,14 +74,26 @@ func TestEncode(t *testing.T) {
 		{"explicit-body-result-collection", testdata.ExplicitBodyResultCollectionDSL, testdata.ExplicitBodyResultCollectionEncodeCode},
 		{"explicit-content-type-result", testdata.ExplicitContentTypeResultDSL, testdata.ExplicitContentTypeResultEncodeCode},
 		{"explicit-content-type-response", testdata.ExplicitContentTypeResponseDSL, testdata.ExplicitContentTypeResponseEncodeCode},
+		{"multiple-content-types-response", testdata.MultipleContentTypesResponseDSL, testdata.MultipleContentTypesResponseEncodeCode},
+		{"redirect-location-from-response", testdata.RedirectLocationFromResponseDSL, testdata.RedirectLocationFromResponseEncodeCode},
 
 		{"tag-string", testdata.ResultTagStringDSL, testdata.ResultTagStringEncodeCode},
 		{"tag-string-required", testdata.ResultTagStringRequiredDSL, testdata.ResultTagStringRequiredEncodeCode},
 		{"tag-result-multiple-views", testdata.ResultMultipleViewsTagDSL, testdata.ResultMultipleViewsTagEncodeCode},
 
 		{"empty-server-response", testdata.EmptyServerResponseDSL, testdata.EmptyServerResponseEncodeCode},
+		{"empty-result-accepted-response", testdata.EmptyResultAcceptedResponseDSL, testdata.EmptyResultAcceptedResponseEncodeCode},
+		{"empty-result-no-status-response", testdata.EmptyResultNoStatusResponseDSL, testdata.EmptyResultNoStatusResponseEncodeCode},
 		{"empty-server-response-with-tags", testdata.EmptyServerResponseWithTagsDSL, testdata.EmptyServerResponseWithTagsEncodeCode},
 
+		{"typed-responses", testdata.ResultTypedResponsesDSL, testdata.ResultTypedResponsesEncodeCode},
+
+		{"cache", testdata.ResultCacheDSL, testdata.ResultCacheEncodeCode},
+		{"cache-secured", testdata.ResultCacheSecuredDSL, testdata.ResultCacheSecuredEncodeCode},
+
+		{"long-running", testdata.ResultLongRunningDSL, testdata.ResultLongRunningEncodeCode},
+		{"long-running-viewed", testdata.ResultLongRunningViewedDSL, testdata.ResultLongRunningViewedEncodeCode},
+
 		{"result-with-custom-pkg-type", testdata.ResultWithCustomPkgTypeDSL, testdata.ResultWithCustomPkgTypeEncodeCode},
 		{"result-with-embedded-custom-pkg-type", testdata.EmbeddedCustomPkgTypeDSL, testdata.ResultWithEmbeddedCustomPkgTypeEncodeCode},
 	}
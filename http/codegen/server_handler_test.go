@@ -20,6 +20,7 @@ func TestServerHandler(t *testing.T) {
 		{"server simple routing", testdata.ServerSimpleRoutingDSL, testdata.ServerSimpleRoutingCode},
 		{"server trailing slash routing", testdata.ServerTrailingSlashRoutingDSL, testdata.ServerTrailingSlashRoutingCode},
 		{"server simple routing with a redirect", testdata.ServerSimpleRoutingWithRedirectDSL, testdata.ServerSimpleRoutingCode},
+		{"server path pattern routing", testdata.ServerPathPatternDSL, testdata.ServerPathPatternCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
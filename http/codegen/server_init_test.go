@@ -24,6 +24,13 @@ func TestServerInit(t *testing.T) {
 		{"mixed", testdata.ServerMixedDSL, testdata.ServerMixedConstructorCode, 2, 3},
 		{"multipart", testdata.ServerMultipartDSL, testdata.ServerMultipartConstructorCode, 2, 4},
 		{"streaming", testdata.StreamingResultDSL, testdata.ServerStreamingConstructorCode, 3, 3},
+		{"cors", testdata.ServerCORSDSL, testdata.ServerCORSConstructorCode, 2, 3},
+		{"idempotent", testdata.ServerIdempotentDSL, testdata.ServerIdempotentConstructorCode, 2, 3},
+		{"webhook signature", testdata.ServerWebhookSignatureDSL, testdata.ServerWebhookSignatureConstructorCode, 2, 3},
+		{"cipher", testdata.ServerCipherDSL, testdata.ServerCipherConstructorCode, 2, 3},
+		{"request id", testdata.ServerRequestIDDSL, testdata.ServerRequestIDConstructorCode, 2, 3},
+		{"gzip", testdata.ServerGzipDSL, testdata.ServerGzipConstructorCode, 2, 3},
+		{"sparse fields", testdata.ServerSparseFieldsDSL, testdata.ServerSparseFieldsConstructorCode, 2, 3},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -43,3 +50,29 @@ func TestServerInit(t *testing.T) {
 		})
 	}
 }
+
+func TestServerCipherEndpoint(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"payload and viewed result", testdata.ServerCipherDSL, testdata.ServerCipherEndpointCode},
+		{"result", testdata.ServerCipherResultDSL, testdata.ServerCipherResultEndpointCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, expr.Root)
+			sections := fs[0].Section("server-cipher-endpoint")
+			if len(sections) != 1 {
+				t.Fatalf("got %d cipher endpoint sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
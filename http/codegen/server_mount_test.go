@@ -28,6 +28,17 @@ func TestServerMount(t *testing.T) {
 		{"multiple files mounter /w prefix path", testdata.ServerMultipleFilesWithPrefixPathDSL, testdata.ServerMultipleFilesWithPrefixPathMounterCode, 3, "server-files"},
 		{"multiple files with a redirect constructor", testdata.ServerMultipleFilesWithRedirectDSL, testdata.ServerMultipleFilesWithRedirectConstructorCode, 0, "server-mount"},
 		{"multiple files with a redirect mounter", testdata.ServerMultipleFilesWithRedirectDSL, testdata.ServerMultipleFilesMounterCode, 3, "server-files"},
+		{"cors constructor", testdata.ServerCORSDSL, testdata.ServerCORSMountCode, 0, "server-mount"},
+		{"cors handlers", testdata.ServerCORSDSL, testdata.ServerCORSHandlersCode, 0, "server-cors"},
+		{"cors service override handlers", testdata.ServerCORSServiceOverrideDSL, testdata.ServerCORSServiceOverrideHandlersCode, 0, "server-cors"},
+		{"cors wildcard handlers", testdata.ServerCORSWildcardDSL, testdata.ServerCORSWildcardHandlersCode, 0, "server-cors"},
+		{"options constructor", testdata.ServerOptionsDSL, testdata.ServerOptionsMountCode, 0, "server-mount"},
+		{"options handlers", testdata.ServerOptionsDSL, testdata.ServerOptionsHandlersCode, 0, "server-options"},
+		{"options cors handlers", testdata.ServerOptionsCORSDSL, testdata.ServerOptionsCORSHandlersCode, 0, "server-cors"},
+		{"version constructor", testdata.ServerVersionDSL, testdata.ServerVersionMountCode, 0, "server-mount"},
+		{"version handlers", testdata.ServerVersionDSL, testdata.ServerVersionHandlersCode, 0, "server-version"},
+		{"request id middleware", testdata.ServerRequestIDDSL, testdata.ServerRequestIDMiddlewareCode, 0, "server-request-id"},
+		{"method override middleware", testdata.ServerMethodOverrideDSL, testdata.ServerMethodOverrideMiddlewareCode, 0, "server-method-override"},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
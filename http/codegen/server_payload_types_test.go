@@ -96,6 +96,7 @@ func TestPayloadConstructor(t *testing.T) {
 		{"body-query-user", testdata.PayloadBodyQueryUserDSL, testdata.PayloadBodyQueryUserConstructorCode},
 		{"body-query-user-validate", testdata.PayloadBodyQueryUserValidateDSL, testdata.PayloadBodyQueryUserValidateConstructorCode},
 		{"body-union", testdata.PayloadBodyUnionDSL, testdata.PayloadBodyUnionConstructorCode},
+		{"body-discriminated-union", testdata.PayloadBodyDiscriminatedUnionDSL, testdata.PayloadBodyDiscriminatedUnionConstructorCode},
 		{"body-query-user-union", testdata.PayloadBodyQueryUserUnionDSL, testdata.PayloadBodyQueryUserUnionConstructorCode},
 		{"body-query-user-union-validate", testdata.PayloadBodyQueryUserUnionValidateDSL, testdata.PayloadBodyQueryUserUnionValidateConstructorCode},
 
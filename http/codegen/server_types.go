@@ -76,6 +76,20 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 					Source: typeDeclT,
 					Data:   data,
 				})
+				if len(data.Aliases) > 0 {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "request-body-unmarshal",
+						Source: unmarshalAliasesT,
+						Data:   data,
+					})
+				}
+				if len(data.Nullables) > 0 {
+					sections = append(sections, &codegen.SectionTemplate{
+						Name:   "request-body-marshal",
+						Source: marshalNullablesT,
+						Data:   data,
+					})
+				}
 			}
 			if data.ValidateDef != "" {
 				validatedTypes = append(validatedTypes, data)
@@ -109,6 +123,13 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 							Source: typeDeclT,
 							Data:   tdata,
 						})
+						if len(tdata.Nullables) > 0 {
+							sections = append(sections, &codegen.SectionTemplate{
+								Name:   "response-server-body-marshal",
+								Source: marshalNullablesT,
+								Data:   tdata,
+							})
+						}
 					}
 					if tdata.Init != nil {
 						initData = append(initData, tdata.Init)
@@ -134,6 +155,13 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 							Source: typeDeclT,
 							Data:   data,
 						})
+						if len(data.Nullables) > 0 {
+							sections = append(sections, &codegen.SectionTemplate{
+								Name:   "error-body-marshal",
+								Source: marshalNullablesT,
+								Data:   data,
+							})
+						}
 					}
 					if data.Init != nil {
 						initData = append(initData, data.Init)
@@ -154,6 +182,20 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				Source: typeDeclT,
 				Data:   tdata,
 			})
+			if len(tdata.Aliases) > 0 {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "server-body-attributes-unmarshal",
+					Source: unmarshalAliasesT,
+					Data:   tdata,
+				})
+			}
+			if len(tdata.Nullables) > 0 {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "server-body-attributes-marshal",
+					Source: marshalNullablesT,
+					Data:   tdata,
+				})
+			}
 		}
 
 		if tdata.ValidateDef != "" {
@@ -240,6 +282,57 @@ const typeDeclT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
 `
 
+// input: TypeData
+const unmarshalAliasesT = `{{ printf "UnmarshalJSON decodes the JSON representation of %s accepting the aliases of its attribute names. The attribute names take precedence over the aliases." .VarName | comment }}
+func (body *{{ .VarName }}) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	aliases := [][]string{
+	{{- range .Aliases }}
+		{ {{- printf "%q" .Name }}{{ range .Aliases }}, {{ printf "%q" . }}{{ end }}},
+	{{- end }}
+	}
+	for _, names := range aliases {
+		for _, alias := range names[1:] {
+			v, ok := raw[alias]
+			if !ok {
+				continue
+			}
+			delete(raw, alias)
+			if _, ok := raw[names[0]]; !ok {
+				raw[names[0]] = v
+			}
+		}
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	type plain {{ .VarName }}
+	return json.Unmarshal(data, (*plain)(body))
+}
+`
+
+// input: TypeData
+const marshalNullablesT = `{{ printf "MarshalJSON encodes %s omitting the nullable attributes that are not set." .VarName | comment }}
+func (body {{ .VarName }}) MarshalJSON() ([]byte, error) {
+	type plain {{ .VarName }}
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	{{- range .Nullables }}
+	if !body.{{ .FieldName }}.Set {
+		unset = append(unset, {{ printf "%q" .Name }})
+	}
+	{{- end }}
+	return goa.OmitJSONKeys(data, unset...)
+}
+`
+
 // input: InitData
 const serverTypeInitT = `{{ comment .Description }}
 func {{ .Name }}({{- range .ServerArgs }}{{ .VarName }} {{ .TypeRef }}, {{ end }}) {{ .ReturnTypeRef }} {
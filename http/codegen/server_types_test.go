@@ -24,6 +24,9 @@ func TestServerTypes(t *testing.T) {
 		{"server-with-result-view", testdata.ResultWithResultViewDSL, ResultWithResultViewServerTypesFile},
 		{"server-empty-error-response-body", testdata.EmptyErrorResponseBodyDSL, ""},
 		{"server-with-error-custom-pkg", testdata.WithErrorCustomPkgDSL, WithErrorCustomPkgServerTypesFile},
+		{"server-aliases", testdata.PayloadAliasesDSL, AliasesServerTypesFile},
+		{"server-nullable", testdata.PayloadNullableDSL, NullableServerTypesFile},
+		{"server-explicit-body-array", testdata.ResultExplicitBodyArrayDSL, ExplicitBodyArrayServerTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -95,12 +98,12 @@ func ValidateMethodARequestBody(body *MethodARequestBody) (err error) {
 	}
 	if body.Object != nil {
 		if err2 := ValidateBPayloadRequestBody(body.Object); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "body.object"))
 		}
 	}
 	if body.DupObj != nil {
 		if err2 := ValidateBPayloadRequestBody(body.DupObj); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "body.dup_obj"))
 		}
 	}
 	return
@@ -181,7 +184,7 @@ func ValidateMethodBRequestBody(body *MethodBRequestBody) (err error) {
 	}
 	if body.C != nil {
 		if err2 := ValidateAPayloadRequestBody(body.C); err2 != nil {
-			err = goa.MergeErrors(err, err2)
+			err = goa.MergeErrors(err, goa.NestErrors(err2, "body.c"))
 		}
 	}
 	return
@@ -321,3 +324,211 @@ func NewMethodWithErrorCustomPkgErrorNameResponseBody(res *custom.CustomError) *
 	return body
 }
 `
+
+const AliasesServerTypesFile = `// MethodAliasesRequestBody is the type of the "ServiceAliases" service
+// "MethodAliases" endpoint HTTP request body.
+type MethodAliasesRequestBody struct {
+	UserName *string             ` + "`" + `form:"user_name,omitempty" json:"user_name,omitempty" xml:"user_name,omitempty"` + "`" + `
+	Address  *AddressRequestBody ` + "`" + `form:"address,omitempty" json:"address,omitempty" xml:"address,omitempty"` + "`" + `
+}
+
+// UnmarshalJSON decodes the JSON representation of MethodAliasesRequestBody
+// accepting the aliases of its attribute names. The attribute names take
+// precedence over the aliases.
+func (body *MethodAliasesRequestBody) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	aliases := [][]string{
+		{"user_name", "username", "userName"},
+	}
+	for _, names := range aliases {
+		for _, alias := range names[1:] {
+			v, ok := raw[alias]
+			if !ok {
+				continue
+			}
+			delete(raw, alias)
+			if _, ok := raw[names[0]]; !ok {
+				raw[names[0]] = v
+			}
+		}
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	type plain MethodAliasesRequestBody
+	return json.Unmarshal(data, (*plain)(body))
+}
+
+// AddressRequestBody is used to define fields on request body types.
+type AddressRequestBody struct {
+	ZipCode *string ` + "`" + `form:"zip_code,omitempty" json:"zip_code,omitempty" xml:"zip_code,omitempty"` + "`" + `
+}
+
+// UnmarshalJSON decodes the JSON representation of AddressRequestBody
+// accepting the aliases of its attribute names. The attribute names take
+// precedence over the aliases.
+func (body *AddressRequestBody) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	aliases := [][]string{
+		{"zip_code", "zip"},
+	}
+	for _, names := range aliases {
+		for _, alias := range names[1:] {
+			v, ok := raw[alias]
+			if !ok {
+				continue
+			}
+			delete(raw, alias)
+			if _, ok := raw[names[0]]; !ok {
+				raw[names[0]] = v
+			}
+		}
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	type plain AddressRequestBody
+	return json.Unmarshal(data, (*plain)(body))
+}
+
+// NewMethodAliasesPayload builds a ServiceAliases service MethodAliases
+// endpoint payload.
+func NewMethodAliasesPayload(body *MethodAliasesRequestBody) *servicealiases.MethodAliasesPayload {
+	v := &servicealiases.MethodAliasesPayload{
+		UserName: *body.UserName,
+	}
+	if body.Address != nil {
+		v.Address = unmarshalAddressRequestBodyToServicealiasesAddress(body.Address)
+	}
+
+	return v
+}
+
+// ValidateMethodAliasesRequestBody runs the validations defined on
+// MethodAliasesRequestBody
+func ValidateMethodAliasesRequestBody(body *MethodAliasesRequestBody) (err error) {
+	if body.UserName == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("user_name", "body"))
+	}
+	return
+}
+`
+
+const ExplicitBodyArrayServerTypesFile = `// MethodExplicitBodyArrayResponseBody is the type of the
+// "ServiceExplicitBodyArray" service "MethodExplicitBodyArray" endpoint HTTP
+// response body.
+type MethodExplicitBodyArrayResponseBody []*EntryResponse
+
+// EntryResponse is used to define fields on response body types.
+type EntryResponse struct {
+	ID   int     ` + "`" + `form:"id" json:"id" xml:"id"` + "`" + `
+	Name *string ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+}
+
+// NewMethodExplicitBodyArrayResponseBody builds the HTTP response body from
+// the result of the "MethodExplicitBodyArray" endpoint of the
+// "ServiceExplicitBodyArray" service.
+func NewMethodExplicitBodyArrayResponseBody(res *serviceexplicitbodyarray.MethodExplicitBodyArrayResult) MethodExplicitBodyArrayResponseBody {
+	body := make([]*EntryResponse, len(res.Items))
+	for i, val := range res.Items {
+		body[i] = marshalServiceexplicitbodyarrayEntryToEntryResponse(val)
+	}
+	return body
+}
+`
+
+const NullableServerTypesFile = `// MethodNullableRequestBody is the type of the "ServiceNullable" service
+// "MethodNullable" endpoint HTTP request body.
+type MethodNullableRequestBody struct {
+	Name     *string              ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+	Nickname goa.Nullable[string] ` + "`" + `form:"nickname,omitempty" json:"nickname,omitempty" xml:"nickname,omitempty"` + "`" + `
+	Settings *SettingsRequestBody ` + "`" + `form:"settings,omitempty" json:"settings,omitempty" xml:"settings,omitempty"` + "`" + `
+}
+
+// MarshalJSON encodes MethodNullableRequestBody omitting the nullable
+// attributes that are not set.
+func (body MethodNullableRequestBody) MarshalJSON() ([]byte, error) {
+	type plain MethodNullableRequestBody
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	if !body.Nickname.Set {
+		unset = append(unset, "nickname")
+	}
+	return goa.OmitJSONKeys(data, unset...)
+}
+
+// MethodNullableResponseBody is the type of the "ServiceNullable" service
+// "MethodNullable" endpoint HTTP response body.
+type MethodNullableResponseBody struct {
+	Age goa.Nullable[int] ` + "`" + `form:"age,omitempty" json:"age,omitempty" xml:"age,omitempty"` + "`" + `
+}
+
+// MarshalJSON encodes MethodNullableResponseBody omitting the nullable
+// attributes that are not set.
+func (body MethodNullableResponseBody) MarshalJSON() ([]byte, error) {
+	type plain MethodNullableResponseBody
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	if !body.Age.Set {
+		unset = append(unset, "age")
+	}
+	return goa.OmitJSONKeys(data, unset...)
+}
+
+// SettingsRequestBody is used to define fields on request body types.
+type SettingsRequestBody struct {
+	Locale goa.Nullable[string] ` + "`" + `form:"locale,omitempty" json:"locale,omitempty" xml:"locale,omitempty"` + "`" + `
+}
+
+// MarshalJSON encodes SettingsRequestBody omitting the nullable attributes
+// that are not set.
+func (body SettingsRequestBody) MarshalJSON() ([]byte, error) {
+	type plain SettingsRequestBody
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	if !body.Locale.Set {
+		unset = append(unset, "locale")
+	}
+	return goa.OmitJSONKeys(data, unset...)
+}
+
+// NewMethodNullableResponseBody builds the HTTP response body from the result
+// of the "MethodNullable" endpoint of the "ServiceNullable" service.
+func NewMethodNullableResponseBody(res *servicenullable.MethodNullableResult) *MethodNullableResponseBody {
+	body := &MethodNullableResponseBody{
+		Age: res.Age,
+	}
+	return body
+}
+
+// NewMethodNullablePayload builds a ServiceNullable service MethodNullable
+// endpoint payload.
+func NewMethodNullablePayload(body *MethodNullableRequestBody) *servicenullable.MethodNullablePayload {
+	v := &servicenullable.MethodNullablePayload{
+		Name:     body.Name,
+		Nickname: body.Nickname,
+	}
+	if body.Settings != nil {
+		v.Settings = unmarshalSettingsRequestBodyToServicenullableSettings(body.Settings)
+	}
+
+	return v
+}
+`
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -82,11 +83,117 @@ type (
 		// ServerTransformHelpers is the list of transform functions
 		// required by the various server side constructors.
 		ServerTransformHelpers []*codegen.TransformFunctionData
+		// MultipartPartsDecoders lists the functions that decode the
+		// payloads and the user types of their attributes from the
+		// parts of the multipart requests.
+		MultipartPartsDecoders []*MultipartPartsDecoderData
 		// ClientTransformHelpers is the list of transform functions
 		// required by the various client side constructors.
 		ClientTransformHelpers []*codegen.TransformFunctionData
 		// Scope initialized with all the server and client types.
 		Scope *codegen.NameScope
+		// CORS contains the data needed to render the CORS handlers if
+		// the service or the API define a CORS policy.
+		CORS *CORSData
+		// Options contains the data needed to render the OPTIONS
+		// handlers if the API enables them with the
+		// "http:options:generate" meta and the service does not define
+		// a CORS policy.
+		Options *OptionsData
+		// Version contains the data needed to render the handler that
+		// serves the API version if the service enables it with the
+		// "http:version:path" meta.
+		Version *VersionData
+		// RequestIDHeader is the name of the header used to propagate
+		// the request IDs if the API defines one.
+		RequestIDHeader string
+		// MethodOverrides lists the methods that may override the
+		// method of POST requests if the API uses MethodOverride.
+		MethodOverrides []string
+		// ProblemErrors is true if the API encodes the errors as RFC
+		// 7807 problem details.
+		ProblemErrors bool
+	}
+
+	// CORSData contains the data needed to render the CORS middleware and
+	// preflight handlers of a service.
+	CORSData struct {
+		// Origins lists the allowed origins.
+		Origins []string
+		// Methods is the value of the Access-Control-Allow-Methods
+		// header.
+		Methods string
+		// Headers is the value of the Access-Control-Allow-Headers
+		// header.
+		Headers string
+		// Exposed is the value of the Access-Control-Expose-Headers
+		// header.
+		Exposed string
+		// MaxAge is the value of the Access-Control-Max-Age header.
+		MaxAge string
+		// Credentials is true if the Access-Control-Allow-Credentials
+		// header must be set.
+		Credentials bool
+		// Vary is true if the policy allows specific origins so that
+		// all the responses must set the "Vary: Origin" header.
+		Vary bool
+		// Paths lists the request paths that must handle preflight
+		// requests.
+		Paths []*OptionsPathData
+		// HandlerInit is the name of the preflight handler constructor.
+		HandlerInit string
+		// MountHandler is the name of the preflight handler mount
+		// function.
+		MountHandler string
+		// Handle is the name of the middleware applying the policy
+		// response headers.
+		Handle string
+		// VarName is the name of the server struct field holding the
+		// preflight handler.
+		VarName string
+	}
+
+	// OptionsData contains the data needed to render the handlers that
+	// answer the OPTIONS requests made to the service endpoint paths.
+	OptionsData struct {
+		// Paths lists the request paths that must handle OPTIONS
+		// requests.
+		Paths []*OptionsPathData
+		// HandlerInit is the name of the OPTIONS handler constructor.
+		HandlerInit string
+		// MountHandler is the name of the OPTIONS handler mount
+		// function.
+		MountHandler string
+		// VarName is the name of the server struct field holding the
+		// OPTIONS handler.
+		VarName string
+	}
+
+	// VersionData contains the data needed to render the handler that
+	// serves the API version.
+	VersionData struct {
+		// Path is the request path of the version endpoint.
+		Path string
+		// Body is the JSON response body listing the API version.
+		Body string
+		// HandlerInit is the name of the version handler constructor.
+		HandlerInit string
+		// MountHandler is the name of the version handler mount
+		// function.
+		MountHandler string
+		// VarName is the name of the server struct field holding the
+		// version handler.
+		VarName string
+	}
+
+	// OptionsPathData describes a request path that handles OPTIONS
+	// requests.
+	OptionsPathData struct {
+		// Path is the request path.
+		Path string
+		// Allow is the value of the Allow response header, empty if the
+		// API does not generate OPTIONS handlers.
+		Allow string
 	}
 
 	// EndpointData contains the data used to render the code related to a
@@ -138,6 +245,23 @@ type (
 		ResponseEncoder string
 		// ErrorEncoder is the name of the error encoder function.
 		ErrorEncoder string
+		// ErrorContentTypes lists the media types the error encoder
+		// selects from using the request Accept header. The first media
+		// type is used when none is acceptable. Empty unless the success
+		// responses declare more than one media type.
+		ErrorContentTypes []string
+		// ProblemErrors is true if the errors are encoded as RFC 7807
+		// problem details.
+		ProblemErrors bool
+		// ValidationErrors is true if the field validation errors are
+		// encoded as a 422 response listing the invalid fields.
+		ValidationErrors bool
+		// MissingParamError is the name of the method error used to
+		// report missing required query parameters, empty if the
+		// request has no required query parameter or if the method does
+		// not define an error with the default type and the 400 status
+		// code.
+		MissingParamError string
 		// MultipartRequestDecoder indicates the request decoder for
 		// multipart content type.
 		MultipartRequestDecoder *MultipartData
@@ -146,6 +270,38 @@ type (
 		ServerWebSocket *WebSocketData
 		// Redirect defines a redirect for the endpoint.
 		Redirect *RedirectData
+		// RedirectFrom defines the redirect to the URL computed by the
+		// endpoint method if any.
+		RedirectFrom *RedirectFromData
+		// Idempotency defines the idempotency key handling of the
+		// endpoint if any.
+		Idempotency *IdempotencyData
+		// WebhookSignature defines the verification of the request
+		// signatures if any.
+		WebhookSignature *WebhookSignatureData
+		// Conditional defines the conditional request handling of the
+		// endpoint if any.
+		Conditional *ConditionalData
+		// Cache defines the caching directives of the endpoint
+		// responses if any.
+		Cache *CacheData
+		// Cipher defines the encryption of the payload and result
+		// fields flagged with the "crypto:field" meta if any.
+		Cipher *CipherData
+		// Sunset is the HTTP-date written in the "Sunset" response
+		// header if the endpoint method is deprecated, empty otherwise.
+		Sunset string
+		// Gzip is true if the endpoint handler decompresses the gzip
+		// request bodies and compresses the response bodies as set
+		// with the "http:compress" meta.
+		Gzip bool
+		// SparseFields lists the names of the result fields the clients
+		// may select with the "fields" query string parameter if the
+		// method uses SparseFields.
+		SparseFields []string
+		// Trace defines the OpenTelemetry instrumentation of the
+		// endpoint if any.
+		Trace *TraceData
 
 		// client
 
@@ -166,9 +322,20 @@ type (
 		// ClientWebSocket holds the data to render the client struct which
 		// implements the client stream interface.
 		ClientWebSocket *WebSocketData
+		// ServerSSE holds the data to render the server struct which
+		// implements the server stream interface of endpoints that send
+		// their results as server-sent events.
+		ServerSSE *SSEData
+		// ClientSSE holds the data to render the client struct which
+		// implements the client stream interface of endpoints that
+		// receive their results as server-sent events.
+		ClientSSE *SSEData
 		// BuildStreamPayload is the name of the function used to create the
 		// payload for endpoints that use SkipRequestBodyEncodeDecode.
 		BuildStreamPayload string
+		// ClientLog defines the logging of the client requests if the
+		// API enables it with the "log:client" meta.
+		ClientLog *ClientLogData
 	}
 
 	// FileServerData lists the data needed to generate file servers.
@@ -202,6 +369,136 @@ type (
 		StatusCode string
 	}
 
+	// RedirectFromData lists the data needed to generate the response
+	// encoder of an endpoint that redirects to the URL computed by the
+	// method.
+	RedirectFromData struct {
+		// Name is the name of the result attribute holding the URL.
+		Name string
+		// FieldRef is the reference to the result field holding the URL
+		// in the response encoder.
+		FieldRef string
+		// Pointer is true if the result field is a pointer.
+		Pointer bool
+		// StatusCode is the HTTP status code.
+		StatusCode string
+	}
+
+	// IdempotencyData lists the data needed to generate the idempotency key
+	// handling of an endpoint.
+	IdempotencyData struct {
+		// Scope identifies the endpoint in the idempotency store keys.
+		Scope string
+		// Header is the name of the HTTP request header carrying the
+		// idempotency key.
+		Header string
+		// TTL is the Go expression for the duration during which
+		// responses are replayed.
+		TTL string
+	}
+
+	// WebhookSignatureData lists the data needed to generate the
+	// verification of the request signatures of an endpoint.
+	WebhookSignatureData struct {
+		// Header is the name of the HTTP request header carrying the
+		// signature.
+		Header string
+		// Algorithm is the name of the hash function used to compute
+		// the HMAC.
+		Algorithm string
+	}
+
+	// ConditionalData lists the data needed to generate the conditional
+	// request handling of an endpoint.
+	ConditionalData struct {
+		// ResultRef is the reference to the type of the endpoint
+		// result "res".
+		ResultRef string
+		// ETag is the Go expression for the value used to compute the
+		// entity tag given the endpoint result "v" of type ResultRef.
+		ETag string
+		// Weak is true if the entity tags are weak validators.
+		Weak bool
+	}
+
+	// CacheData lists the data needed to generate the caching headers of
+	// the endpoint responses.
+	CacheData struct {
+		// Control is the value of the Cache-Control header.
+		Control string
+		// Vary is the value of the Vary header if any.
+		Vary string
+	}
+
+	// CipherData lists the data needed to generate the endpoint wrapper
+	// that decrypts the flagged payload fields and encrypts the flagged
+	// result fields.
+	CipherData struct {
+		// EndpointInit is the name of the function that wraps the
+		// service endpoint.
+		EndpointInit string
+		// PayloadRef is the reference to the payload type.
+		PayloadRef string
+		// PayloadFields lists the payload fields to decrypt given the
+		// payload "p".
+		PayloadFields []*CipherFieldData
+		// ResultRef is the reference to the result type returned by the
+		// service endpoint.
+		ResultRef string
+		// ResultFields lists the result fields to encrypt given the
+		// result "r".
+		ResultFields []*CipherFieldData
+	}
+
+	// CipherFieldData describes a field flagged with the "crypto:field"
+	// meta.
+	CipherFieldData struct {
+		// Name is the name of the attribute.
+		Name string
+		// Ref is the Go expression for the pointer to the field value.
+		Ref string
+	}
+
+	// ClientLogData lists the data needed to generate the logging of the
+	// requests made by the client to an endpoint.
+	ClientLogData struct {
+		// Body is true if the request and response bodies are logged.
+		Body bool
+		// Redact lists the names of the body fields flagged with the
+		// "sensitive" meta whose values are redacted from the logs.
+		Redact []string
+	}
+
+	// TraceData lists the data needed to generate the OpenTelemetry
+	// instrumentation of an endpoint.
+	TraceData struct {
+		// TracerName is the name of the tracer used to create the spans.
+		TracerName string
+		// SpanName is the name of the spans.
+		SpanName string
+		// Route is the path of the endpoint first route.
+		Route string
+		// Attributes lists the payload attributes recorded as span
+		// attributes.
+		Attributes []*TraceAttributeData
+	}
+
+	// TraceAttributeData describes a payload attribute recorded as a span
+	// attribute.
+	TraceAttributeData struct {
+		// Key is the span attribute key.
+		Key string
+		// Func is the name of the function of the OpenTelemetry
+		// attribute package used to build the span attribute.
+		Func string
+		// Value is the Go expression for the span attribute value given
+		// the endpoint payload "p".
+		Value string
+		// Check is the Go expression that must be true for the span
+		// attribute to be recorded if any.
+		Check string
+	}
+
 	// PayloadData contains the payload information required to generate the
 	// transport decode (server) and encode (client) code.
 	PayloadData struct {
@@ -239,6 +536,10 @@ type (
 		// the result variable only if there are multiple responses, or the
 		// response has a body, a header or a cookie.
 		MustInit bool
+		// ResponseTag is the name of the result field that selects the
+		// success response when the responses define their own types,
+		// empty otherwise.
+		ResponseTag string
 	}
 
 	// ErrorGroupData contains the error information required to generate
@@ -301,6 +602,13 @@ type (
 		// Multipart if true indicates the request is a multipart
 		// request.
 		Multipart bool
+		// ContentType is the media type of the request body set in the
+		// design if any.
+		ContentType string
+		// UseNumber is true if the request body decoder must decode
+		// numbers into json.Number values rather than float64 to
+		// preserve precision.
+		UseNumber bool
 	}
 
 	// ResponseData describes a response.
@@ -316,6 +624,10 @@ type (
 		// ContentType contains the value of the response
 		// "Content-Type" header.
 		ContentType string
+		// ContentTypes lists the media types the encoder selects from
+		// using the request Accept header if the response defines more
+		// than one.
+		ContentTypes []string
 		// ErrorHeader contains the value of the response "goa-error"
 		// header if any.
 		ErrorHeader string
@@ -357,6 +669,24 @@ type (
 		// ViewedResult indicates whether the response body type is a
 		// result type.
 		ViewedResult *service.ViewedResultTypeData
+		// Location contains the data needed to set the Location header
+		// of the 202 responses of long-running endpoints.
+		Location *LocationData
+	}
+
+	// LocationData contains the data needed to set the Location header of
+	// a response to the path of the endpoint that polls the job returned
+	// by a long-running endpoint.
+	LocationData struct {
+		// PathInit is the name of the function that builds the path of
+		// the poll endpoint.
+		PathInit string
+		// Args lists the expressions that compute the path parameters
+		// from the job.
+		Args []string
+		// Checks lists the projected job fields that must not be nil
+		// for the header to be set if the job is a viewed result.
+		Checks []string
 	}
 
 	// InitData contains the data required to render a constructor.
@@ -452,6 +782,18 @@ type (
 		// PathInit contains the information needed to render and call
 		// the path constructor for the route.
 		PathInit *InitData
+		// Patterns lists the regular expressions that constrain the
+		// route wildcards sorted by wildcard name.
+		Patterns []*PathPatternData
+	}
+
+	// PathPatternData describes the regular expression that constrains a
+	// route wildcard.
+	PathPatternData struct {
+		// Name is the wildcard name.
+		Name string
+		// Pattern is the regular expression.
+		Pattern string
 	}
 
 	// Element defines the common fields needed to generate HTTP request and
@@ -467,6 +809,10 @@ type (
 		StringSlice bool
 		// Slice is true if the attribute type is an array.
 		Slice bool
+		// ErrorContext is the name of the element used in the decoding
+		// errors, e.g. "filter[age]" for the property of a deepObject
+		// query parameter. The variable name is used if empty.
+		ErrorContext string
 	}
 
 	// ParamData describes a HTTP request parameter (query string or path
@@ -482,6 +828,54 @@ type (
 		// to the entire payload (empty string) or a payload attribute
 		// (attribute name).
 		MapQueryParams *string
+		// Style is the serialization style of a path parameter that
+		// does not use the default "simple" style.
+		Style string
+		// Explode is true if the elements of an array path parameter
+		// are serialized separately.
+		Explode bool
+		// DeepObject describes the properties of a query parameter
+		// that uses the "deepObject" style, nil otherwise.
+		DeepObject *DeepObjectData
+		// AllowEmptyValue is true if the query parameter may be given
+		// with an empty value.
+		AllowEmptyValue bool
+		// Delimiter is the separator of the elements of an array query
+		// parameter serialized in a single value, empty if the elements
+		// are serialized separately.
+		Delimiter string
+		// MissingParam is true if the parameter is required and the
+		// method reports missing parameters using its bad request
+		// error.
+		MissingParam bool
+	}
+
+	// DeepObjectData describes a query parameter that uses the "deepObject"
+	// style, e.g. "filter[name]=x&filter[age]=5". Each property is decoded
+	// into a separate variable and the variables are then used to
+	// initialize the parameter.
+	DeepObjectData struct {
+		// Fields describes the object properties. The names of the
+		// parameters are the names of the property query string keys,
+		// e.g. "filter[name]".
+		Fields []*ParamData
+		// TypeName is the name of the service type of the parameter.
+		TypeName string
+		// Init is the code that initializes the parameter fields with
+		// the property variables.
+		Init string
+	}
+
+	// pathStyleData describes the serialization style of a path
+	// parameter used by the path constructors.
+	pathStyleData struct {
+		// Name is the name of the path parameter.
+		Name string
+		// Style is the serialization style if not "simple".
+		Style string
+		// Explode is true if the elements of an array path parameter
+		// are serialized separately.
+		Explode bool
 	}
 
 	// HeaderData describes a HTTP request or response header.
@@ -529,6 +923,27 @@ type (
 		Example interface{}
 		// View is the view used to render the (result) type if any.
 		View string
+		// Aliases lists the attributes of the type that define aliases
+		// accepted when decoding the type if any.
+		Aliases []*AliasData
+		// Nullables lists the nullable attributes of the type if any.
+		Nullables []*NullableData
+	}
+
+	// AliasData describes the aliases of an attribute.
+	AliasData struct {
+		// Name is the attribute name.
+		Name string
+		// Aliases lists the attribute aliases.
+		Aliases []string
+	}
+
+	// NullableData describes a nullable attribute of a body type.
+	NullableData struct {
+		// FieldName is the name of the struct field.
+		FieldName string
+		// Name is the name of the attribute in the encoded body.
+		Name string
 	}
 
 	// MultipartData contains the data needed to render multipart
@@ -547,6 +962,10 @@ type (
 		// Payload is the payload data required to generate
 		// encoder/decoder.
 		Payload *PayloadData
+		// Decode contains the data needed to render the decoder that
+		// maps the request parts to the payload fields if the method
+		// sets the "multipart:decode" meta.
+		Decode *MultipartDecodeData
 	}
 )
 
@@ -594,6 +1013,9 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		ServerTypeNames:  make(map[string]bool),
 		ClientTypeNames:  make(map[string]bool),
 		Scope:            scope,
+		RequestIDHeader:  expr.Root.API.RequestIDHeader,
+		MethodOverrides:  expr.Root.API.HTTP.MethodOverrides,
+		ProblemErrors:    problemErrors(),
 	}
 
 	for _, s := range hs.FileServers {
@@ -645,6 +1067,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				)
 				{
 					initArgs := make([]*InitArgData, len(params))
+					styles := make([]*pathStyleData, len(params))
 					pathParamsObj := expr.AsObject(a.PathParams().Type)
 					suffix := ""
 					if i > 0 {
@@ -654,6 +1077,10 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 					name := fmt.Sprintf("%s%sPath%s", ep.VarName, svc.StructName, suffix)
 					for j, arg := range params {
 						patt := pathParamsObj.Attribute(arg)
+						styles[j] = &pathStyleData{Name: arg}
+						if style, explode := expr.HTTPParamStyle(patt); style != "simple" {
+							styles[j].Style, styles[j].Explode = style, explode
+						}
 						att := makeHTTPType(patt)
 						pointer := a.Params.IsPrimitivePointer(arg, true)
 						if expr.IsObject(a.MethodExpr.Payload.Type) {
@@ -689,6 +1116,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 					err := pathInitTmpl.Execute(&buffer, map[string]interface{}{
 						"Args":       initArgs,
 						"PathParams": pathParamsObj,
+						"Styles":     styles,
 						"PathFormat": pf,
 					})
 					if err != nil {
@@ -710,6 +1138,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 					Verb:     strings.ToUpper(r.Method),
 					Path:     rpath,
 					PathInit: init,
+					Patterns: buildPathPatternsData(r),
 				})
 			}
 		}
@@ -787,7 +1216,10 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				"Args":         args,
 				"PathInit":     routes[0].PathInit,
 				"Verb":         routes[0].Verb,
-				"IsStreaming":  a.MethodExpr.IsStreaming(),
+				"IsStreaming":  a.MethodExpr.IsStreaming() && a.SSE == nil,
+			}
+			if a.MethodExpr.Idempotent != nil || a.Idempotency != nil {
+				data["Idempotent"] = a.IsIdempotent()
 			}
 			if a.SkipRequestBodyEncodeDecode {
 				data["RequestStruct"] = pkg + "." + ep.RequestStruct
@@ -806,31 +1238,41 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		}
 
 		ad := &EndpointData{
-			Method:          ep,
-			ServiceName:     svc.Name,
-			ServiceVarName:  svc.VarName,
-			ServicePkgName:  svc.PkgName,
-			Payload:         payload,
-			Result:          buildResultData(a, rd),
-			Errors:          buildErrorsData(a, rd),
-			HeaderSchemes:   hsch,
-			BodySchemes:     bosch,
-			QuerySchemes:    qsch,
-			BasicScheme:     basch,
-			Routes:          routes,
-			MountHandler:    fmt.Sprintf("Mount%sHandler", ep.VarName),
-			HandlerInit:     fmt.Sprintf("New%sHandler", ep.VarName),
-			RequestDecoder:  fmt.Sprintf("Decode%sRequest", ep.VarName),
-			ResponseEncoder: fmt.Sprintf("Encode%sResponse", ep.VarName),
-			ErrorEncoder:    fmt.Sprintf("Encode%sError", ep.VarName),
-			ClientStruct:    "Client",
-			EndpointInit:    ep.VarName,
-			RequestInit:     requestInit,
-			RequestEncoder:  requestEncoder,
-			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
-			Requirements:    reqs,
-		}
-		if a.MethodExpr.IsStreaming() {
+			Method:           ep,
+			ServiceName:      svc.Name,
+			ServiceVarName:   svc.VarName,
+			ServicePkgName:   svc.PkgName,
+			Payload:          payload,
+			Result:           buildResultData(a, rd),
+			Errors:           buildErrorsData(a, rd),
+			HeaderSchemes:    hsch,
+			BodySchemes:      bosch,
+			QuerySchemes:     qsch,
+			BasicScheme:      basch,
+			Routes:           routes,
+			MountHandler:     fmt.Sprintf("Mount%sHandler", ep.VarName),
+			HandlerInit:      fmt.Sprintf("New%sHandler", ep.VarName),
+			RequestDecoder:   fmt.Sprintf("Decode%sRequest", ep.VarName),
+			ResponseEncoder:  fmt.Sprintf("Encode%sResponse", ep.VarName),
+			ErrorEncoder:     fmt.Sprintf("Encode%sError", ep.VarName),
+			ProblemErrors:    rd.ProblemErrors,
+			ValidationErrors: validationErrors(),
+			ClientStruct:     "Client",
+			EndpointInit:     ep.VarName,
+			RequestInit:      requestInit,
+			RequestEncoder:   requestEncoder,
+			ResponseDecoder:  fmt.Sprintf("Decode%sResponse", ep.VarName),
+			Requirements:     reqs,
+			Gzip:             a.Compression() == "gzip",
+		}
+		ad.ErrorContentTypes = errorContentTypes(a)
+		ad.MissingParamError = missingParamError(a, payload.Request)
+		if ad.MissingParamError != "" {
+			markMissingParams(payload.Request.QueryParams)
+		}
+		if a.SSE != nil {
+			initSSEData(ad, a, rd)
+		} else if a.MethodExpr.IsStreaming() {
 			initWebSocketData(ad, a, rd)
 		}
 
@@ -843,6 +1285,10 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				MethodName:  ep.Name,
 				Payload:     ad.Payload,
 			}
+			if a.HasMultipartDecoder() {
+				ad.MultipartRequestDecoder.Decode = buildMultipartDecodeData(a,
+					fmt.Sprintf("Decode%s%sMultipart", svc.StructName, ep.VarName), ad.Payload.Ref, rd)
+			}
 			ad.MultipartRequestEncoder = &MultipartData{
 				FuncName:    fmt.Sprintf("%s%sEncoderFunc", svc.StructName, ep.VarName),
 				InitName:    fmt.Sprintf("New%s%sEncoder", svc.StructName, ep.VarName),
@@ -858,15 +1304,99 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		}
 
 		if a.Redirect != nil {
-			ad.Redirect = &RedirectData{
-				URL:        a.Redirect.URL,
-				StatusCode: statusCodeToHTTPConst(a.Redirect.StatusCode),
+			if name := a.Redirect.LocationFrom; name != "" {
+				ref := "res."
+				ptr := ep.ViewedResult != nil || a.MethodExpr.Result.IsPrimitivePointer(name, true)
+				if ep.ViewedResult != nil {
+					ref += "Projected."
+				}
+				ad.RedirectFrom = &RedirectFromData{
+					Name:       name,
+					FieldRef:   ref + codegen.GoifyAtt(a.MethodExpr.Result.Find(name), name, true),
+					Pointer:    ptr,
+					StatusCode: statusCodeToHTTPConst(a.Redirect.StatusCode),
+				}
+			} else {
+				ad.Redirect = &RedirectData{
+					URL:        a.Redirect.URL,
+					StatusCode: statusCodeToHTTPConst(a.Redirect.StatusCode),
+				}
 			}
 		}
 
+		if a.Idempotency != nil {
+			ad.Idempotency = &IdempotencyData{
+				Scope:  svc.Name + "." + ep.Name,
+				Header: a.Idempotency.Header,
+				TTL:    codegen.DurationToGo(a.Idempotency.TTL),
+			}
+		}
+
+		if a.WebhookSignature != nil {
+			ad.WebhookSignature = &WebhookSignatureData{
+				Header:    a.WebhookSignature.Header,
+				Algorithm: a.WebhookSignature.Algorithm,
+			}
+		}
+
+		if a.Conditional != nil {
+			ref, val := ad.Result.Ref, "v"
+			if ep.ViewedResult != nil {
+				ref, val = ep.ViewedResult.FullRef, "v.Projected"
+			}
+			att := expr.AsObject(a.MethodExpr.Result.Type).Attribute(a.Conditional.Attribute)
+			ad.Conditional = &ConditionalData{
+				ResultRef: ref,
+				ETag:      val + "." + codegen.GoifyAtt(att, a.Conditional.Attribute, true),
+				Weak:      a.Conditional.Weak,
+			}
+		}
+
+		if a.Cache != nil {
+			ad.Cache = &CacheData{
+				Control: a.Cache.CacheControl(),
+				Vary:    strings.Join(a.Cache.Vary, ", "),
+			}
+		}
+
+		if len(expr.CryptoFields(a.MethodExpr.Payload)) > 0 || len(expr.CryptoFields(a.MethodExpr.Result)) > 0 {
+			ad.Cipher = buildCipherData(a, ad)
+		}
+
+		if !a.MethodExpr.Sunset.IsZero() {
+			ad.Sunset = a.MethodExpr.Sunset.UTC().Format(http.TimeFormat)
+		}
+
+		if a.MethodExpr.Trace != nil {
+			ad.Trace = buildTraceData(a, svc.Name)
+		}
+
+		if a.MethodExpr.SparseFields {
+			ad.SparseFields = a.MethodExpr.SparseFieldNames()
+		}
+
+		if clientLog() && ad.ClientWebSocket == nil {
+			ad.ClientLog = buildClientLogData(a)
+		}
+
 		rd.Endpoints = append(rd.Endpoints, ad)
 	}
 
+	if cors := hs.CORSPolicy(); cors != nil {
+		rd.CORS = buildCORSData(cors, rd)
+	} else if generateOptions() {
+		rd.Options = buildOptionsData(hs, rd)
+	}
+	if p, ok := hs.ServiceExpr.Meta.Last("http:version:path"); ok {
+		rd.Version = &VersionData{
+			Path:         p,
+			Body:         fmt.Sprintf(`{"version":%q}`, expr.Root.API.Version),
+			HandlerInit:  rd.Scope.Unique("NewVersionHandler"),
+			MountHandler: rd.Scope.Unique("MountVersionHandler"),
+			VarName:      rd.Scope.Unique("Version"),
+		}
+	}
+
 	for _, a := range hs.HTTPEndpoints {
 		collectUserTypes(a.Body.Type, func(ut expr.UserType) {
 			if d := attributeTypeData(ut, true, true, true, rd); d != nil {
@@ -916,6 +1446,315 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 	return rd
 }
 
+// buildCORSData returns the data needed to render the CORS handlers of the
+// service described by sd using the given policy. The allowed methods default
+// to the HTTP methods used by the service endpoints.
+func buildCORSData(cors *expr.HTTPCORSExpr, sd *ServiceData) *CORSData {
+	var (
+		paths    []string
+		verbs    []string
+		seen     = make(map[string]struct{})
+		seenVerb = make(map[string]struct{})
+		explicit = make(map[string]struct{})
+	)
+	for _, e := range sd.Endpoints {
+		for _, r := range e.Routes {
+			if r.Verb == "OPTIONS" {
+				explicit[r.Path] = struct{}{}
+			}
+		}
+	}
+	for _, e := range sd.Endpoints {
+		for _, r := range e.Routes {
+			if _, ok := seenVerb[r.Verb]; !ok {
+				seenVerb[r.Verb] = struct{}{}
+				verbs = append(verbs, r.Verb)
+			}
+			if _, ok := explicit[r.Path]; ok {
+				continue
+			}
+			if _, ok := seen[r.Path]; ok {
+				continue
+			}
+			seen[r.Path] = struct{}{}
+			paths = append(paths, r.Path)
+		}
+	}
+	methods := cors.Methods
+	if len(methods) == 0 {
+		methods = verbs
+	}
+	var allowed map[string][]string
+	if generateOptions() {
+		allowed, _ = allowedMethods()
+	}
+	pathsData := make([]*OptionsPathData, len(paths))
+	for i, p := range paths {
+		pathsData[i] = &OptionsPathData{Path: p}
+		if vs, ok := allowed[p]; ok {
+			pathsData[i].Allow = strings.Join(vs, ", ")
+		}
+	}
+	var maxAge string
+	if cors.MaxAge > 0 {
+		maxAge = strconv.FormatUint(uint64(cors.MaxAge), 10)
+	}
+	var vary bool
+	for _, o := range cors.Origins {
+		if o != "*" {
+			vary = true
+			break
+		}
+	}
+	return &CORSData{
+		Origins:      cors.Origins,
+		Methods:      strings.Join(methods, ", "),
+		Headers:      strings.Join(cors.Headers, ", "),
+		Exposed:      strings.Join(cors.Exposed, ", "),
+		MaxAge:       maxAge,
+		Credentials:  cors.Credentials,
+		Vary:         vary,
+		Paths:        pathsData,
+		HandlerInit:  sd.Scope.Unique("NewCORSHandler"),
+		MountHandler: sd.Scope.Unique("MountCORSHandler"),
+		Handle:       sd.Scope.Unique("HandleCORS"),
+		VarName:      sd.Scope.Unique("CORS"),
+	}
+}
+
+// buildOptionsData returns the data needed to render the handlers that answer
+// the OPTIONS requests made to the paths of the service described by hs. Paths
+// shared by multiple services are handled by the first service that defines
+// them and paths with an explicit OPTIONS endpoint are skipped.
+func buildOptionsData(hs *expr.HTTPServiceExpr, sd *ServiceData) *OptionsData {
+	allowed, owners := allowedMethods()
+	var (
+		paths []*OptionsPathData
+		seen  = make(map[string]struct{})
+	)
+	for _, e := range hs.HTTPEndpoints {
+		for _, r := range e.Routes {
+			for _, p := range r.FullPaths() {
+				if _, ok := seen[p]; ok {
+					continue
+				}
+				seen[p] = struct{}{}
+				vs, ok := allowed[p]
+				if !ok || owners[p] != hs.Name() {
+					continue
+				}
+				paths = append(paths, &OptionsPathData{Path: p, Allow: strings.Join(vs, ", ")})
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return &OptionsData{
+		Paths:        paths,
+		HandlerInit:  sd.Scope.Unique("NewOptionsHandler"),
+		MountHandler: sd.Scope.Unique("MountOptionsHandler"),
+		VarName:      sd.Scope.Unique("Options"),
+	}
+}
+
+// allowedMethods returns the HTTP methods accepted by each request path of the
+// API, OPTIONS included, and the name of the first service that defines each
+// path. Paths for which the design defines an explicit OPTIONS endpoint are
+// omitted.
+func allowedMethods() (map[string][]string, map[string]string) {
+	var (
+		allowed  = make(map[string][]string)
+		owners   = make(map[string]string)
+		explicit = make(map[string]struct{})
+	)
+	for _, hs := range expr.Root.API.HTTP.Services {
+		for _, e := range hs.HTTPEndpoints {
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					if r.Method == "OPTIONS" {
+						explicit[p] = struct{}{}
+					}
+					if _, ok := owners[p]; !ok {
+						owners[p] = hs.Name()
+					}
+					found := false
+					for _, v := range allowed[p] {
+						if v == r.Method {
+							found = true
+							break
+						}
+					}
+					if !found {
+						allowed[p] = append(allowed[p], r.Method)
+					}
+				}
+			}
+		}
+	}
+	for p := range explicit {
+		delete(allowed, p)
+	}
+	for p, vs := range allowed {
+		allowed[p] = append(vs, "OPTIONS")
+	}
+	return allowed, owners
+}
+
+// buildCipherData computes the data needed to render the endpoint wrapper that
+// decrypts and encrypts the fields of the given endpoint flagged with the
+// "crypto:field" meta.
+func buildCipherData(e *expr.HTTPEndpointExpr, ed *EndpointData) *CipherData {
+	var (
+		m  = e.MethodExpr
+		cd = &CipherData{EndpointInit: fmt.Sprintf("New%sCipherEndpoint", ed.Method.VarName)}
+	)
+	ref := func(att *expr.AttributeExpr, v, name string, pointer bool) string {
+		field := v + "." + codegen.GoifyAtt(expr.AsObject(att.Type).Attribute(name), name, true)
+		if pointer {
+			return field
+		}
+		return "&" + field
+	}
+	for _, name := range expr.CryptoFields(m.Payload) {
+		cd.PayloadRef = ed.Payload.Ref
+		cd.PayloadFields = append(cd.PayloadFields, &CipherFieldData{
+			Name: name,
+			Ref:  ref(m.Payload, "p", name, m.Payload.IsPrimitivePointer(name, true)),
+		})
+	}
+	for _, name := range expr.CryptoFields(m.Result) {
+		var (
+			v       = "r"
+			pointer = m.Result.IsPrimitivePointer(name, true)
+		)
+		cd.ResultRef = ed.Result.Ref
+		if ed.Method.ViewedResult != nil {
+			// The fields of projected types are always pointers.
+			cd.ResultRef = ed.Method.ViewedResult.FullRef
+			v = "r.Projected"
+			pointer = true
+		}
+		cd.ResultFields = append(cd.ResultFields, &CipherFieldData{
+			Name: name,
+			Ref:  ref(m.Result, v, name, pointer),
+		})
+	}
+	return cd
+}
+
+// buildClientLogData computes the data needed to generate the logging of the
+// requests made by the client to the given endpoint. Bodies are never logged
+// for endpoints that stream their requests or responses.
+func buildClientLogData(e *expr.HTTPEndpointExpr) *ClientLogData {
+	if !clientLogBody() || e.SSE != nil || e.MultipartRequest || e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode {
+		return &ClientLogData{}
+	}
+	seen := make(map[string]struct{})
+	fields := sensitiveFields(e.Body, seen, nil)
+	for _, r := range e.Responses {
+		fields = sensitiveFields(r.Body, seen, fields)
+	}
+	for _, er := range e.HTTPErrors {
+		fields = sensitiveFields(er.Response.Body, seen, fields)
+	}
+	sort.Strings(fields)
+	return &ClientLogData{Body: true, Redact: fields}
+}
+
+// sensitiveFields appends the names of the attributes of att flagged with the
+// "sensitive" meta, at any depth, to fields. seen records the visited user
+// types and the names already appended.
+func sensitiveFields(att *expr.AttributeExpr, seen map[string]struct{}, fields []string) []string {
+	if att == nil || att.Type == nil {
+		return fields
+	}
+	if ut, ok := att.Type.(expr.UserType); ok {
+		if _, ok := seen["type:"+ut.ID()]; ok {
+			return fields
+		}
+		seen["type:"+ut.ID()] = struct{}{}
+	}
+	switch dt := att.Type.(type) {
+	case *expr.Array:
+		return sensitiveFields(dt.ElemType, seen, fields)
+	case *expr.Map:
+		return sensitiveFields(dt.ElemType, seen, fields)
+	}
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		if ut, ok := att.Type.(expr.UserType); ok {
+			return sensitiveFields(ut.Attribute(), seen, fields)
+		}
+		return fields
+	}
+	for _, nat := range *obj {
+		if nat.Attribute.IsSensitive() {
+			if _, ok := seen[nat.Name]; !ok {
+				seen[nat.Name] = struct{}{}
+				fields = append(fields, nat.Name)
+			}
+			continue
+		}
+		fields = sensitiveFields(nat.Attribute, seen, fields)
+	}
+	return fields
+}
+
+// buildTraceData computes the data needed to generate the OpenTelemetry
+// instrumentation of the given endpoint.
+func buildTraceData(e *expr.HTTPEndpointExpr, svcName string) *TraceData {
+	m := e.MethodExpr
+	data := &TraceData{
+		TracerName: svcName,
+		SpanName:   svcName + "." + m.Name,
+		Route:      e.Routes[0].FullPaths()[0],
+	}
+	attrs := expr.AsObject(m.Trace.Attribute().Type)
+	payload := expr.AsObject(m.Payload.Type)
+	if attrs == nil || payload == nil {
+		return data
+	}
+	for _, nat := range *attrs {
+		att := payload.Attribute(nat.Name)
+		if att == nil {
+			continue
+		}
+		field := "p." + codegen.GoifyAtt(att, nat.Name, true)
+		value := field
+		var check string
+		switch {
+		case att.IsNullable():
+			check = field + ".HasValue()"
+			value = field + ".Value"
+		case m.Payload.IsPrimitivePointer(nat.Name, true):
+			check = field + " != nil"
+			value = "*" + field
+		}
+		var fn, typ string
+		switch att.Type.Kind() {
+		case expr.BooleanKind:
+			fn, typ = "Bool", "bool"
+		case expr.StringKind:
+			fn, typ = "String", "string"
+		case expr.Float32Kind, expr.Float64Kind:
+			fn, typ = "Float64", "float64"
+		default:
+			fn, typ = "Int64", "int64"
+		}
+		if _, ok := att.Type.(expr.UserType); ok || codegen.GoNativeTypeName(att.Type) != typ {
+			value = typ + "(" + value + ")"
+		}
+		data.Attributes = append(data.Attributes, &TraceAttributeData{
+			Key:   nat.Name,
+			Func:  fn,
+			Value: value,
+			Check: check,
+		})
+	}
+	return data
+}
+
 // makeHTTPType traverses the attribute recursively and performs these actions:
 //
 // * removes aliased user type by replacing them with the underlying type.
@@ -962,12 +1801,13 @@ func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}) *e
 		att.Type = &obj
 	case *expr.Union:
 		values := expr.AsUnion(dt).Values
+		typeNames := expr.UnionTypeNames(dt)
 		names := make([]interface{}, len(values))
 		vals := make([]string, len(values))
 		bases := make([]expr.DataType, len(values))
 		for i, nat := range values {
-			names[i] = nat.Name
-			vals[i] = fmt.Sprintf("- %q", nat.Name)
+			names[i] = typeNames[i]
+			vals[i] = fmt.Sprintf("- %q", typeNames[i])
 			bases[i] = nat.Attribute.Type
 		}
 		obj := expr.Object([]*expr.NamedAttributeExpr{
@@ -999,6 +1839,20 @@ func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}) *e
 	return att
 }
 
+// buildPathPatternsData returns the data needed to render the regular
+// expressions that constrain the wildcards of the given route.
+func buildPathPatternsData(r *expr.RouteExpr) []*PathPatternData {
+	if len(r.Patterns) == 0 {
+		return nil
+	}
+	patterns := make([]*PathPatternData, 0, len(r.Patterns))
+	for name, pattern := range r.Patterns {
+		patterns = append(patterns, &PathPatternData{Name: name, Pattern: pattern})
+	}
+	sort.Slice(patterns, func(i, j int) bool { return patterns[i].Name < patterns[j].Name })
+	return patterns
+}
+
 // buildPayloadData returns the data structure used to describe the endpoint
 // payload including the HTTP request details. It also returns the user types
 // used by the request body type recursively if any.
@@ -1022,13 +1876,13 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 			serverBodyData = buildRequestBodyType(e.Body, payload, e, true, sd)
 			clientBodyData = buildRequestBodyType(e.Body, payload, e, false, sd)
 			paramsData     = extractPathParams(e.PathParams(), payload, sd.Scope)
-			queryData      = extractQueryParams(e.QueryParams(), payload, sd.Scope)
+			queryData      = extractQueryParams(e.QueryParams(), payload, svc.PkgName, sd.Scope)
 			headersData    = extractHeaders(e.Headers, payload, svcctx, sd.Scope)
 			cookiesData    = extractCookies(e.Cookies, payload, svcctx, sd.Scope)
 			origin         string
 
 			mustValidate bool
-			mustHaveBody = true
+			mustHaveBody = e.RequestBodyRequired()
 		)
 		{
 			if e.MapQueryParams != nil {
@@ -1078,7 +1932,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 			}
 			if !mustValidate {
 				for _, p := range paramsData {
-					if p.Validate != "" || needConversion(p.Type) {
+					if p.Validate != "" || p.Style != "" || needConversion(p.Type) {
 						mustValidate = true
 						break
 					}
@@ -1106,9 +1960,6 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 				// transformation.
 				if o, ok := e.Body.Meta["origin:attribute"]; ok {
 					origin = o[0]
-					if !payload.IsRequired(o[0]) {
-						mustHaveBody = false
-					}
 				}
 			}
 		}
@@ -1124,6 +1975,8 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 			MustHaveBody: mustHaveBody,
 			MustValidate: mustValidate,
 			Multipart:    e.MultipartRequest,
+			ContentType:  e.RequestContentType,
+			UseNumber:    useNumber(e.Body),
 		}
 	}
 
@@ -1450,16 +2303,28 @@ func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 	}
 
 	var (
-		mustInit  bool
-		responses []*ResponseData
+		mustInit    bool
+		responses   []*ResponseData
+		responseTag string
 	)
 	{
+		if e.ResponseTag != "" {
+			responseTag = codegen.Goify(e.ResponseTag, true)
+		}
 		viewed := false
 		if ep.ViewedResult != nil {
 			result = expr.AsObject(ep.ViewedResult.Type).Attribute("projected")
 			viewed = true
 		}
 		responses = buildResponses(e, result, viewed, sd)
+		if e.MethodExpr.LongRunning != nil {
+			for _, r := range responses {
+				if r.StatusCode == "http.StatusAccepted" {
+					r.Location = buildLocationData(e, viewed, sd)
+					mustInit = true
+				}
+			}
+		}
 		for _, r := range responses {
 			// response has a body, headers, cookies or tag
 			if len(r.ServerBody) > 0 || len(r.Headers) > 0 || len(r.Cookies) > 0 || r.TagName != "" {
@@ -1468,12 +2333,47 @@ func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 		}
 	}
 	return &ResultData{
-		IsStruct:  expr.IsObject(result.Type),
-		Name:      name,
-		Ref:       ref,
-		Responses: responses,
-		View:      view,
-		MustInit:  mustInit,
+		IsStruct:    expr.IsObject(result.Type),
+		Name:        name,
+		Ref:         ref,
+		Responses:   responses,
+		View:        view,
+		MustInit:    mustInit,
+		ResponseTag: responseTag,
+	}
+}
+
+// buildLocationData builds the data needed to set the Location header of the
+// responses of the long-running endpoint e to the path of the first route of
+// the poll endpoint. The path parameters are the job attributes of the same
+// name.
+func buildLocationData(e *expr.HTTPEndpointExpr, viewed bool, sd *ServiceData) *LocationData {
+	var (
+		lr     = e.MethodExpr.LongRunning
+		poll   = e.Service.Endpoint(lr.PollMethod)
+		job    = expr.AsObject(lr.JobType)
+		args   []string
+		checks []string
+	)
+	for _, p := range expr.ExtractHTTPWildcards(poll.Routes[0].FullPaths()[0]) {
+		att := job.Attribute(p)
+		arg := "res." + codegen.GoifyAtt(att, p, true)
+		if viewed {
+			// The projected fields are nil if the view does not
+			// render the attribute.
+			field := "res.Projected." + codegen.GoifyAtt(att, p, true)
+			checks = append(checks, field+" != nil")
+			arg = "*" + field
+		}
+		if expr.IsAlias(att.Type) {
+			arg = codegen.GoNativeTypeName(att.Type) + "(" + arg + ")"
+		}
+		args = append(args, arg)
+	}
+	return &LocationData{
+		PathInit: sd.Service.Method(lr.PollMethod).VarName + sd.Service.StructName + "Path",
+		Args:     args,
+		Checks:   checks,
 	}
 }
 
@@ -1725,12 +2625,17 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 						tagPtr = viewed || result.IsPrimitivePointer(resp.Tag[0], true)
 					}
 				}
+				var contentTypes []string
+				if len(resp.ContentTypes) > 1 {
+					contentTypes = resp.ContentTypes
+				}
 				responses = append(responses, &ResponseData{
 					StatusCode:   statusCodeToHTTPConst(resp.StatusCode),
 					Description:  resp.Description,
 					Headers:      headersData,
 					Cookies:      cookiesData,
 					ContentType:  resp.ContentType,
+					ContentTypes: contentTypes,
 					ServerBody:   serverBodyData,
 					ClientBody:   clientBodyData,
 					ResultInit:   init,
@@ -2087,6 +2992,14 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 			}
 		}
 	}
+	var aliases []*AliasData
+	if svr && def != "" {
+		aliases = buildAliasesData(body)
+	}
+	var nullables []*NullableData
+	if def != "" {
+		nullables = buildNullablesData(body)
+	}
 	return &TypeData{
 		Name:        name,
 		VarName:     varname,
@@ -2097,6 +3010,8 @@ func buildRequestBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointExp
 		ValidateDef: validateDef,
 		ValidateRef: validateRef,
 		Example:     body.Example(expr.Root.API.ExampleGenerator),
+		Aliases:     aliases,
+		Nullables:   nullables,
 	}
 }
 
@@ -2279,6 +3194,10 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 			}
 		}
 	}
+	var nullables []*NullableData
+	if def != "" {
+		nullables = buildNullablesData(body)
+	}
 	return &TypeData{
 		Name:        name,
 		VarName:     varname,
@@ -2290,9 +3209,98 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, loc *codegen.Location,
 		ValidateRef: validateRef,
 		Example:     body.Example(expr.Root.API.ExampleGenerator),
 		View:        viewName,
+		Nullables:   nullables,
+	}
+}
+
+// problemErrors returns true if the API uses the "problem+json" HTTP error
+// format.
+func problemErrors() bool {
+	f, _ := expr.Root.API.Meta.Last("http:error:format")
+	return f == "problem+json"
+}
+
+// validationErrors returns true if the API encodes the field validation
+// errors as a list of field errors with the "http:error:validation" meta.
+func validationErrors() bool {
+	v, _ := expr.Root.API.Meta.Last("http:error:validation")
+	return v == "fields"
+}
+
+// missingParamError returns the name of the error of the endpoint with the
+// default error type and the 400 status code if the request has required query
+// parameters, the empty string otherwise.
+func missingParamError(e *expr.HTTPEndpointExpr, req *RequestData) string {
+	required := false
+	for _, p := range req.QueryParams {
+		if p.Required {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return ""
+	}
+	for _, v := range e.HTTPErrors {
+		if v.Response.StatusCode == http.StatusBadRequest && v.ErrorExpr.Type == expr.ErrorResult {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// markMissingParams sets the MissingParam field of the required query
+// parameters including the properties of deepObject parameters.
+func markMissingParams(params []*ParamData) {
+	for _, p := range params {
+		p.MissingParam = p.Required
+		if p.DeepObject != nil {
+			markMissingParams(p.DeepObject.Fields)
+		}
 	}
 }
 
+// errorContentTypes returns the media types declared by the success responses
+// of the endpoint in order of declaration if there are more than one.
+func errorContentTypes(e *expr.HTTPEndpointExpr) []string {
+	var cts []string
+	seen := make(map[string]struct{})
+	for _, r := range e.Responses {
+		for _, ct := range r.ContentTypes {
+			if _, ok := seen[ct]; ok {
+				continue
+			}
+			seen[ct] = struct{}{}
+			cts = append(cts, ct)
+		}
+	}
+	if len(cts) < 2 {
+		return nil
+	}
+	return cts
+}
+
+// clientLog returns true if the API enables the logging of the client requests
+// with the "log:client" meta.
+func clientLog() bool {
+	l, _ := expr.Root.API.Meta.Last("log:client")
+	return l == "true"
+}
+
+// clientLogBody returns true if the API enables the logging of the client
+// request and response bodies with the "log:client:body" meta.
+func clientLogBody() bool {
+	l, _ := expr.Root.API.Meta.Last("log:client:body")
+	return l == "true"
+}
+
+// generateOptions returns true if the API enables the generation of the
+// handlers that answer OPTIONS requests with the "http:options:generate" meta.
+func generateOptions() bool {
+	g, _ := expr.Root.API.Meta.Last("http:options:generate")
+	return g == "true"
+}
+
 func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, _ bool, c *expr.AttributeExpr) error {
@@ -2302,6 +3310,12 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 			stringSlice = arr.ElemType.Type.Kind() == expr.StringKind
 		}
 
+		var style string
+		s, explode := expr.HTTPParamStyle(c)
+		if s != "simple" {
+			style = s
+		}
+
 		c = makeHTTPType(c)
 		var (
 			varn = scope.Name(codegen.Goify(name, false))
@@ -2321,6 +3335,8 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 		params = append(params, &ParamData{
 			Map:            false,
 			MapStringSlice: false,
+			Style:          style,
+			Explode:        explode,
 			Element: &Element{
 				Name:          elem,
 				AttributeName: name,
@@ -2349,14 +3365,21 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 	return params
 }
 
-func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
+func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, svcPkg string, scope *codegen.NameScope) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *expr.AttributeExpr) error {
+		if style, _ := expr.HTTPParamStyle(c); style == "deepObject" {
+			params = append(params, extractDeepObjectParam(name, elem, required, c, service, svcPkg, scope))
+			return nil
+		}
+
 		// The StringSlice field of ParamData must be false for aliased primitive types
 		var stringSlice bool
 		if arr := expr.AsArray(c.Type); arr != nil {
 			stringSlice = arr.ElemType.Type.Kind() == expr.StringKind
 		}
+		allowEmpty, _ := expr.HTTPParamFlags(c)
+		delimiter := expr.HTTPParamDelimiter(c)
 
 		c = makeHTTPType(c)
 		var (
@@ -2381,7 +3404,9 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 			ft = service.Find(name).Type
 		}
 		params = append(params, &ParamData{
-			Map: mp != nil,
+			Map:             mp != nil,
+			AllowEmptyValue: allowEmpty,
+			Delimiter:       delimiter,
 			MapStringSlice: mp != nil &&
 				mp.KeyType.Type.Kind() == expr.StringKind &&
 				mp.ElemType.Type.Kind() == expr.ArrayKind &&
@@ -2414,6 +3439,99 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 	return params
 }
 
+// extractDeepObjectParam returns the data for the query parameter with the
+// given name that uses the "deepObject" style. The parameter variable uses the
+// service type so that it can be assigned to the payload as is.
+func extractDeepObjectParam(name, elem string, required bool, c, service *expr.AttributeExpr, svcPkg string, scope *codegen.NameScope) *ParamData {
+	var (
+		varn      = scope.Name(codegen.Goify(name, false))
+		fieldName = codegen.Goify(name, true)
+		ft        = service.Find(name).Type
+		ctx       = serviceContext("", scope)
+		data      = &DeepObjectData{TypeName: scope.GoFullTypeName(c, svcPkg)}
+		args      []*codegen.InitArgData
+	)
+	for _, nat := range *expr.AsObject(c.Type) {
+		// The StringSlice field of ParamData must be false for aliased primitive types
+		var stringSlice bool
+		if arr := expr.AsArray(nat.Attribute.Type); arr != nil {
+			stringSlice = arr.ElemType.Type.Kind() == expr.StringKind
+		}
+		fc := makeHTTPType(nat.Attribute)
+		var (
+			fvarn    = scope.Name(codegen.Goify(name+"_"+nat.Name, false))
+			felem    = fmt.Sprintf("%s[%s]", elem, nat.Name)
+			freq     = c.IsRequired(nat.Name)
+			pointer  = c.IsPrimitivePointer(nat.Name, true)
+			typeRef  = scope.GoTypeRef(fc)
+			property = codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		)
+		if pointer {
+			typeRef = "*" + typeRef
+		}
+		data.Fields = append(data.Fields, &ParamData{
+			Element: &Element{
+				Slice:         expr.IsArray(fc.Type),
+				StringSlice:   stringSlice,
+				Name:          felem,
+				AttributeName: nat.Name,
+				ErrorContext:  felem,
+				AttributeData: &AttributeData{
+					Description:  nat.Attribute.Description,
+					FieldName:    fieldName + "." + property,
+					FieldPointer: pointer,
+					FieldType:    nat.Attribute.Type,
+					VarName:      fvarn,
+					Required:     freq,
+					Type:         fc.Type,
+					TypeName:     scope.GoTypeName(fc),
+					TypeRef:      typeRef,
+					Pointer:      pointer,
+					Validate:     codegen.ValidationCodeWithContext(fc, nil, ctx, freq, expr.IsAlias(fc.Type), fvarn, felem),
+					DefaultValue: nat.Attribute.DefaultValue,
+					Example:      nat.Attribute.Example(expr.Root.API.ExampleGenerator),
+				},
+			},
+		})
+		args = append(args, &codegen.InitArgData{
+			Name:         fvarn,
+			Pointer:      pointer,
+			Type:         fc.Type,
+			FieldName:    property,
+			FieldPointer: pointer,
+			FieldType:    nat.Attribute.Type,
+		})
+	}
+	init, _, err := codegen.InitStructFields(args, varn, "", svcPkg)
+	if err != nil {
+		panic(err) // bug
+	}
+	data.Init = strings.TrimSuffix(init, "\n")
+	return &ParamData{
+		DeepObject: data,
+		Element: &Element{
+			Name:          elem,
+			AttributeName: name,
+			AttributeData: &AttributeData{
+				Description: c.Description,
+				FieldName:   fieldName,
+				FieldType:   ft,
+				VarName:     varn,
+				Required:    required,
+				// Use the service type so that the parameter can
+				// be assigned to the payload field as is.
+				Type: ft,
+				// The CLI unmarshals the flag JSON into the type
+				// name, use a pointer so that the parameter is nil
+				// when the flag is not set.
+				TypeName: scope.GoFullTypeRef(c, svcPkg),
+				TypeRef:  scope.GoFullTypeRef(c, svcPkg),
+				Example:  c.Example(expr.Root.API.ExampleGenerator),
+			},
+		},
+	}
+}
+
 func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svcCtx *codegen.AttributeContext, scope *codegen.NameScope) []*HeaderData {
 	var headers []*HeaderData
 	codegen.WalkMappedAttr(a, func(name, elem string, required bool, _ *expr.AttributeExpr) error {
@@ -2601,6 +3719,7 @@ func attributeTypeData(ut expr.UserType, req, ptr, server bool, rd *ServiceData)
 		desc        string
 		validate    string
 		validateRef string
+		aliases     []*AliasData
 
 		att  = &expr.AttributeExpr{Type: ut}
 		hctx = httpContext("", rd.Scope, req, server)
@@ -2612,6 +3731,9 @@ func attributeTypeData(ut expr.UserType, req, ptr, server bool, rd *ServiceData)
 			ctx = "response"
 		}
 		desc = name + " is used to define fields on " + ctx + " body types."
+		if server {
+			aliases = buildAliasesData(ut.Attribute())
+		}
 		if req || !req && !server {
 			// generate validations for responses client-side and for
 			// requests server-side and CLI
@@ -2630,7 +3752,39 @@ func attributeTypeData(ut expr.UserType, req, ptr, server bool, rd *ServiceData)
 		ValidateDef: validate,
 		ValidateRef: validateRef,
 		Example:     att.Example(expr.Root.API.ExampleGenerator),
+		Aliases:     aliases,
+		Nullables:   buildNullablesData(ut.Attribute()),
+	}
+}
+
+// buildAliasesData returns the aliases of the attributes of the given object.
+func buildAliasesData(att *expr.AttributeExpr) []*AliasData {
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	var aliases []*AliasData
+	for _, nat := range *obj {
+		if len(nat.Attribute.Aliases) > 0 {
+			aliases = append(aliases, &AliasData{Name: nat.Name, Aliases: nat.Attribute.Aliases})
+		}
+	}
+	return aliases
+}
+
+// buildNullablesData returns the nullable attributes of the given object.
+func buildNullablesData(att *expr.AttributeExpr) []*NullableData {
+	if expr.AsObject(att.Type) == nil {
+		return nil
 	}
+	var nullables []*NullableData
+	codegen.WalkMappedAttr(expr.NewMappedAttributeExpr(att), func(name, elem string, _ bool, at *expr.AttributeExpr) error {
+		if at.IsNullable() {
+			nullables = append(nullables, &NullableData{FieldName: codegen.GoifyAtt(at, name, true), Name: elem})
+		}
+		return nil
+	})
+	return nullables
 }
 
 // httpContext returns a context for attributes of types used to marshal and
@@ -2747,6 +3901,23 @@ func AddMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}) {
 	}
 }
 
+// useNumber returns true if the given body attribute or any of its child
+// attributes overrides its Go type with json.Number using the
+// "struct:field:type" meta.
+func useNumber(body *expr.AttributeExpr) bool {
+	if body == nil || body.Type == expr.Empty {
+		return false
+	}
+	errFound := errors.New("found")
+	err := codegen.Walk(body, func(a *expr.AttributeExpr) error {
+		if t, _ := codegen.GetMetaType(a); t == "json.Number" {
+			return errFound
+		}
+		return nil
+	})
+	return err == errFound
+}
+
 // needInit returns true if and only if the given type is or makes use of user
 // types.
 func needInit(dt expr.DataType) bool {
@@ -2778,10 +3949,15 @@ func needInit(dt expr.DataType) bool {
 // upgradeParams returns the data required to render the websocket_upgrade
 // template.
 func upgradeParams(e *EndpointData, fn string) map[string]interface{} {
-	return map[string]interface{}{
+	params := map[string]interface{}{
 		"ViewedResult": e.Method.ViewedResult,
 		"Function":     fn,
 	}
+	if ws := e.ServerWebSocket; ws != nil && ws.Config != nil {
+		params["Config"] = ws.Config
+		params["ReadControl"] = ws.Kind == expr.ServerStreamKind
+	}
+	return params
 }
 
 // needStream returns true if at least one method in the defined services
@@ -2809,7 +3985,9 @@ const (
 		{{- end }}
 	{{- end }}
 	return fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}
-	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .VarName }}Slice, ",")
+	{{- $style := index $.Styles $i }}
+	{{- if $style.Style }}goahttp.EncodePathParam({{ printf "%q" $style.Name }}, {{ printf "%q" $style.Style }}, {{ $style.Explode }}, {{ if eq (index $.PathParams $i).Attribute.Type.Name "array" }}{{ .VarName }}Slice...{{ else }}fmt.Sprint({{ .VarName }}){{ end }})
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .VarName }}Slice, ",")
 	{{- else }}{{ .VarName }}
 	{{- end }}, {{ end }})
 {{- else }}
@@ -2894,7 +4072,11 @@ const (
 		return nil, goahttp.ErrInvalidURL("{{ .ServiceName }}", "{{ .EndpointName }}", u.String(), err)
 	}
 	if ctx != nil {
+	{{- if ne .Idempotent nil }}
+		req = req.WithContext(context.WithValue(ctx, goahttp.IdempotentKey, {{ .Idempotent }}))
+	{{- else }}
 		req = req.WithContext(ctx)
+	{{- end }}
 	}
 
 	return req, nil`
@@ -0,0 +1,410 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// SSEData contains the data needed to render the struct types that
+	// implement the server and client stream interfaces of endpoints that
+	// send their results as server-sent events.
+	SSEData struct {
+		// VarName is the name of the struct.
+		VarName string
+		// Type is type of the stream (server or client).
+		Type string
+		// Interface is the fully qualified name of the interface that
+		// the struct implements.
+		Interface string
+		// Endpoint is endpoint data that defines the streaming result.
+		Endpoint *EndpointData
+		// Response is the successful response data for the streaming
+		// endpoint.
+		Response *ResponseData
+		// SendName is the name of the send function.
+		SendName string
+		// SendDesc is the description for the send function.
+		SendDesc string
+		// SendTypeRef is the fully qualified type ref sent through the
+		// stream.
+		SendTypeRef string
+		// RecvName is the name of the receive function.
+		RecvName string
+		// RecvDesc is the description for the recv function.
+		RecvDesc string
+		// RecvTypeRef is the fully qualified type ref received from the
+		// stream.
+		RecvTypeRef string
+		// PkgName is the service package name.
+		PkgName string
+		// Events lists the events sent by the server.
+		Events []*SSEEventData
+		// Multiple is true if the server sends more than one kind of
+		// event, each event corresponds to a field of the result body.
+		Multiple bool
+		// IDField is the name of the result field whose value is used as
+		// the event ID, empty if the events have no ID.
+		IDField string
+		// IDPointer is true if the result field whose value is used as
+		// the event ID is a pointer.
+		IDPointer bool
+		// Trailers contains the data needed to send the trailers after
+		// the events if the method defines any.
+		Trailers *service.TrailersData
+	}
+
+	// SSEEventData contains the data needed to render the code that sends
+	// and receives a server-sent event.
+	SSEEventData struct {
+		// Name is the event name.
+		Name string
+		// FieldName is the name of the response body field that holds
+		// the event data when the server sends multiple events.
+		FieldName string
+	}
+)
+
+// initSSEData initializes the server-sent events related data in ed.
+func initSSEData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
+	var (
+		events    []*SSEEventData
+		idField   string
+		idPointer bool
+
+		md  = ed.Method
+		svc = sd.Service
+		res = e.MethodExpr.Result
+	)
+	if ut, ok := res.Type.(expr.UserType); ok {
+		res = ut.Attribute()
+	}
+	obj := expr.AsObject(res.Type)
+	for _, ev := range e.SSE.Events {
+		data := &SSEEventData{Name: ev.Name}
+		if e.SSE.Multiple() {
+			data.FieldName = codegen.GoifyAtt(obj.Attribute(ev.Name), ev.Name, true)
+		}
+		events = append(events, data)
+	}
+	if e.SSE.IDField != "" {
+		idField = codegen.GoifyAtt(obj.Attribute(e.SSE.IDField), e.SSE.IDField, true)
+		idPointer = res.IsPrimitivePointer(e.SSE.IDField, true)
+	}
+	ed.ServerSSE = &SSEData{
+		VarName:     md.ServerStream.VarName,
+		Type:        "server",
+		Interface:   fmt.Sprintf("%s.%s", svc.PkgName, md.ServerStream.Interface),
+		Endpoint:    ed,
+		Response:    ed.Result.Responses[0],
+		SendName:    md.ServerStream.SendName,
+		SendDesc:    fmt.Sprintf("%s streams instances of %q to the %q endpoint as server-sent events.", md.ServerStream.SendName, ed.Result.Name, md.Name),
+		SendTypeRef: ed.Result.Ref,
+		PkgName:     svc.PkgName,
+		Events:      events,
+		Multiple:    e.SSE.Multiple(),
+		IDField:     idField,
+		IDPointer:   idPointer,
+		Trailers:    md.Trailers,
+	}
+	ed.ClientSSE = &SSEData{
+		VarName:     md.ClientStream.VarName,
+		Type:        "client",
+		Interface:   fmt.Sprintf("%s.%s", svc.PkgName, md.ClientStream.Interface),
+		Endpoint:    ed,
+		Response:    ed.Result.Responses[0],
+		RecvName:    md.ClientStream.RecvName,
+		RecvDesc:    fmt.Sprintf("%s reads instances of %q from the %q endpoint server-sent events.", md.ClientStream.RecvName, ed.Result.Name, md.Name),
+		RecvTypeRef: ed.Result.Ref,
+		PkgName:     svc.PkgName,
+		Events:      events,
+		Multiple:    e.SSE.Multiple(),
+		IDField:     idField,
+	}
+}
+
+// sseServerFile returns the file implementing the server-sent events server
+// streaming if any.
+func sseServerFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasSSE(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s server-sent events server streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "fmt"},
+			{Path: "net/http"},
+			{Path: "sync"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		if e.ServerSSE == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-sse-struct-type",
+			Source: sseServerStructTypeT,
+			Data:   e.ServerSSE,
+		}, &codegen.SectionTemplate{
+			Name:    "server-sse-send",
+			Source:  sseSendT,
+			Data:    e.ServerSSE,
+			FuncMap: map[string]interface{}{"viewedServerBody": viewedServerBody},
+		}, &codegen.SectionTemplate{
+			Name:   "server-sse-close",
+			Source: sseCloseT,
+			Data:   e.ServerSSE,
+		})
+		if e.ServerSSE.Trailers != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-sse-set-trailers",
+				Source: sseSetTrailersT,
+				Data:   e.ServerSSE,
+			})
+		}
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "server", "sse.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// sseClientFile returns the file implementing the server-sent events client
+// streaming if any.
+func sseClientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasSSE(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s server-sent events client streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "encoding/json"},
+			{Path: "io"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		if e.ClientSSE == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-sse-struct-type",
+			Source: sseClientStructTypeT,
+			Data:   e.ClientSSE,
+		}, &codegen.SectionTemplate{
+			Name:   "client-sse-recv",
+			Source: sseRecvT,
+			Data:   e.ClientSSE,
+		})
+	}
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "client", "sse.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// hasSSE returns true if at least one of the endpoints in the service sends
+// its results as server-sent events.
+func hasSSE(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.ServerSSE != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// sseEventNames returns the names of the events received by the client
+// formatted as Go string literals.
+func sseEventNames(events []*SSEEventData) string {
+	var names string
+	for i, ev := range events {
+		if i > 0 {
+			names += ", "
+		}
+		names += fmt.Sprintf("%q", ev.Name)
+	}
+	return names
+}
+
+const (
+	// sseServerStructTypeT renders the server struct type that implements the
+	// server stream interface.
+	// input: SSEData
+	sseServerStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+	once sync.Once
+	{{ comment "started is true once the response headers have been written." }}
+	started bool
+	{{ comment "w is the HTTP response writer used to send the events." }}
+	w http.ResponseWriter
+	{{ comment "r is the HTTP request." }}
+	r *http.Request
+}
+`
+
+	// sseClientStructTypeT renders the client struct type that implements the
+	// client stream interface.
+	// input: SSEData
+	sseClientStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+	{{ comment "body is the HTTP response body." }}
+	body io.ReadCloser
+	{{ comment "reader reads the events from the response body." }}
+	reader *goahttp.SSEReader
+}
+`
+
+	// sseSendT renders the function implementing the Send method of the
+	// server stream interface.
+	// input: SSEData
+	sseSendT = `{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+	s.start()
+	{{- if .Endpoint.Method.ViewedResult }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
+	{{- else }}
+	res := v
+	{{- end }}
+	{{- $body := "res" }}
+	{{- if and .Response.ServerBody (index .Response.ServerBody 0).Init }}
+		{{- $body = "body" }}
+		{{- if .Endpoint.Method.ViewedResult }}
+			{{- $vsb := (viewedServerBody .Response.ServerBody .Endpoint.Method.ViewedResult.ViewName) }}
+	body := {{ $vsb.Init.Name }}({{ range $vsb.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- else }}
+	body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
+		{{- end }}
+	{{- end }}
+	{{- if .IDField }}
+		{{- if .IDPointer }}
+	var id string
+	if v.{{ .IDField }} != nil {
+		id = *v.{{ .IDField }}
+	}
+		{{- else }}
+	id := v.{{ .IDField }}
+		{{- end }}
+	{{- end }}
+	{{- if .Multiple }}
+	switch {
+		{{- range .Events }}
+	case {{ $body }}.{{ .FieldName }} != nil:
+		return goahttp.WriteSSEEvent(s.w, {{ if $.IDField }}id{{ else }}""{{ end }}, {{ printf "%q" .Name }}, {{ $body }}.{{ .FieldName }})
+		{{- end }}
+	}
+	return fmt.Errorf("{{ .Endpoint.ServiceName }}: no event set in {{ .Endpoint.Method.Name }} result")
+	{{- else }}
+	return goahttp.WriteSSEEvent(s.w, {{ if .IDField }}id{{ else }}""{{ end }}, {{ printf "%q" (index .Events 0).Name }}, {{ $body }})
+	{{- end }}
+}
+`
+
+	// sseCloseT renders the function implementing the Close method of the
+	// server stream interface.
+	// input: SSEData
+	sseCloseT = `{{ printf "Close ends the %q endpoint server-sent events stream." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	s.start()
+	return nil
+}
+
+{{ comment "start writes the response headers the first time it is called. Headers are written here so that authorization logic in the endpoint is executed before any event is sent." }}
+func (s *{{ .VarName }}) start() {
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		{{- if .Trailers }}
+		{{- range .Trailers.Fields }}
+		s.w.Header().Add("Trailer", {{ printf "%q" .Name }})
+		{{- end }}
+		{{- end }}
+		s.w.WriteHeader({{ .Response.StatusCode }})
+		s.started = true
+	})
+}
+`
+
+	// sseSetTrailersT renders the function implementing the SetTrailers
+	// method of the server stream interface. The trailers are announced in
+	// the Trailer header by start and set after the response headers have
+	// been written so that they are sent after the events.
+	// input: SSEData
+	sseSetTrailersT = `{{ printf "SetTrailers sets the trailers sent once the %q endpoint events have been sent." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) SetTrailers(t *{{ .PkgName }}.{{ .Trailers.VarName }}) {
+	s.start()
+	{{- range .Trailers.Fields }}
+	if t.{{ .FieldName }} != nil {
+		s.w.Header().Set({{ printf "%q" .Name }}, {{ if .IsString }}*t.{{ .FieldName }}{{ else }}fmt.Sprint(*t.{{ .FieldName }}){{ end }})
+	}
+	{{- end }}
+}
+`
+
+	// sseRecvT renders the function implementing the Recv method of the
+	// client stream interface.
+	// input: SSEData
+	sseRecvT = `{{ comment .RecvDesc }}
+func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+	var (
+		rv   {{ .RecvTypeRef }}
+		body {{ .Response.ClientBody.VarName }}
+	)
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	{{- if .Multiple }}
+	switch ev.Event {
+		{{- range .Events }}
+	case {{ printf "%q" .Name }}:
+		err = json.Unmarshal(ev.Data, &body.{{ .FieldName }})
+		{{- end }}
+	}
+	{{- else }}
+	err = json.Unmarshal(ev.Data, &body)
+	{{- end }}
+	if err != nil {
+		return rv, goahttp.ErrDecodingError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	{{- if and .Multiple .IDField }}
+	if ev.ID != "" {
+		body.{{ .IDField }} = &ev.ID
+	}
+	{{- end }}
+	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
+	{{ .Response.ClientBody.ValidateRef }}
+	if err != nil {
+		return rv, goahttp.ErrValidationError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	{{- end }}
+	{{- if .Response.ResultInit }}
+	res := {{ .Response.ResultInit.Name }}({{ range .Response.ResultInit.ClientArgs }}{{ .Ref }}, {{ end }})
+		{{- if .Endpoint.Method.ViewedResult }}{{ with .Endpoint.Method.ViewedResult }}
+	vres := {{ if not .IsCollection }}&{{ end }}{{ .ViewsPkg }}.{{ .VarName }}{res, {{ printf "%q" .ViewName }} }
+	if err := {{ .ViewsPkg }}.Validate{{ $.Endpoint.Method.Result }}(vres); err != nil {
+		return rv, goahttp.ErrValidationError("{{ $.Endpoint.ServiceName }}", "{{ $.Endpoint.Method.Name }}", err)
+	}
+	return {{ $.PkgName }}.{{ .ResultInit.Name }}(vres){{ end }}, nil
+		{{- else }}
+	return res, nil
+		{{- end }}
+	{{- else }}
+	return body, nil
+	{{- end }}
+}
+`
+)
@@ -196,6 +196,33 @@ func TestServerStreaming(t *testing.T) {
 			{"server-websocket-send", &testdata.BidirectionalStreamingUserTypeMapServerStreamSendCode},
 			{"server-websocket-recv", &testdata.BidirectionalStreamingUserTypeMapServerStreamRecvCode},
 		}},
+
+		// explicit websocket
+		{"websocket-streaming-result", testdata.WebSocketStreamingResultDSL, []*sectionExpectation{
+			{"server-websocket-struct-type", &testdata.WebSocketStreamingResultServerStructCode},
+			{"server-websocket-send", &testdata.WebSocketStreamingResultServerStreamSendCode},
+			{"server-websocket-close", &testdata.WebSocketStreamingResultServerStreamCloseCode},
+		}},
+		{"websocket-bidirectional-streaming", testdata.WebSocketBidirectionalStreamingDSL, []*sectionExpectation{
+			{"server-websocket-send", &testdata.WebSocketBidirectionalStreamingServerStreamSendCode},
+			{"server-websocket-recv", &testdata.WebSocketBidirectionalStreamingServerStreamRecvCode},
+		}},
+		// server-sent events
+		{"sse-streaming-result", testdata.SSEStreamingResultDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.SSEStreamingResultServerHandlerInitCode},
+			{"server-sse-struct-type", &testdata.SSEStreamingResultServerStructCode},
+			{"server-sse-send", &testdata.SSEStreamingResultServerStreamSendCode},
+			{"server-sse-close", &testdata.SSEStreamingResultServerStreamCloseCode},
+			{"server-websocket-send", nil},
+		}},
+		{"sse-single-event", testdata.SSESingleEventDSL, []*sectionExpectation{
+			{"server-sse-send", &testdata.SSESingleEventServerStreamSendCode},
+			{"server-sse-set-trailers", nil},
+		}},
+		{"sse-trailers", testdata.SSETrailersDSL, []*sectionExpectation{
+			{"server-sse-close", &testdata.SSETrailersServerStreamCloseCode},
+			{"server-sse-set-trailers", &testdata.SSETrailersServerStreamSetTrailersCode},
+		}},
 	}
 
 	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
@@ -327,6 +354,7 @@ func TestClientStreaming(t *testing.T) {
 			{"client-websocket-send", &testdata.BidirectionalStreamingClientStreamSendCode},
 			{"client-websocket-recv", &testdata.BidirectionalStreamingClientStreamRecvCode},
 			{"client-websocket-close", &testdata.BidirectionalStreamingClientStreamCloseCode},
+			{"client-websocket-close-and-recv", &testdata.BidirectionalStreamingClientStreamCloseAndRecvCode},
 			{"client-websocket-set-view", nil},
 		}},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadDSL, []*sectionExpectation{
@@ -377,6 +405,25 @@ func TestClientStreaming(t *testing.T) {
 			{"client-websocket-send", &testdata.BidirectionalStreamingUserTypeMapClientStreamSendCode},
 			{"client-websocket-recv", &testdata.BidirectionalStreamingUserTypeMapClientStreamRecvCode},
 		}},
+
+		// explicit websocket
+		{"websocket-streaming-result", testdata.WebSocketStreamingResultDSL, []*sectionExpectation{
+			{"client-websocket-recv", &testdata.WebSocketStreamingResultClientStreamRecvCode},
+		}},
+		{"websocket-bidirectional-streaming", testdata.WebSocketBidirectionalStreamingDSL, []*sectionExpectation{
+			{"client-websocket-send", &testdata.WebSocketBidirectionalStreamingClientStreamSendCode},
+			{"client-websocket-recv", &testdata.WebSocketBidirectionalStreamingClientStreamRecvCode},
+		}},
+		// server-sent events
+		{"sse-streaming-result", testdata.SSEStreamingResultDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.SSEStreamingResultClientEndpointCode},
+			{"client-sse-struct-type", &testdata.SSEStreamingResultClientStructCode},
+			{"client-sse-recv", &testdata.SSEStreamingResultClientStreamRecvCode},
+			{"client-websocket-recv", nil},
+		}},
+		{"sse-single-event", testdata.SSESingleEventDSL, []*sectionExpectation{
+			{"client-sse-recv", &testdata.SSESingleEventClientStreamRecvCode},
+		}},
 	}
 	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
 	runTests(t, cases, filesFn)
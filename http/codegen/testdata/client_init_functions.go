@@ -49,5 +49,37 @@ func NewClient(
 		configurer:                cfn,
 	}
 }
+`
+
+	RequestIDClientInitCode = `// NewClient instantiates HTTP clients for all the ServiceRequestID service
+// servers. The requests set the "X-Correlation-Id" header to the request ID
+// stored in the request context if any.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) *Client {
+	doer = httpmdlwr.WrapRequestIDDoer(doer, "X-Correlation-Id")
+	return &Client{
+		MethodRequestIDDoer: doer,
+		RestoreResponseBody: restoreBody,
+		scheme:              scheme,
+		host:                host,
+		decoder:             dec,
+		encoder:             enc,
+	}
+}
+`
+
+	ClientLogClientUseLoggerCode = `// UseLogger wraps the endpoint doers so that the requests made to the
+// ServiceClientLog service endpoints and the corresponding responses are
+// logged with logger.
+func (c *Client) UseLogger(logger middleware.Logger) {
+	c.MethodClientLogDoer = httpmdlwr.WrapLogDoer(c.MethodClientLogDoer, logger, "ServiceClientLog", "MethodClientLog", httpmdlwr.LogBodyOption("number", "password"))
+	c.MethodClientLogStreamDoer = httpmdlwr.WrapLogDoer(c.MethodClientLogStreamDoer, logger, "ServiceClientLog", "MethodClientLogStream")
+}
 `
 )
@@ -111,3 +111,32 @@ func (c *Client) BuildMethodPathObjectRequest(ctx context.Context, v interface{}
 	return req, nil
 }
 `
+
+const PathStringIdempotentRequestBuildCode = `// BuildMethodPathStringIdempotentRequest instantiates a HTTP request object
+// with method and path set to call the "ServicePathStringIdempotent" service
+// "MethodPathStringIdempotent" endpoint
+func (c *Client) BuildMethodPathStringIdempotentRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	var (
+		p string
+	)
+	{
+		p, ok := v.(*servicepathstringidempotent.MethodPathStringIdempotentPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("ServicePathStringIdempotent", "MethodPathStringIdempotent", "*servicepathstringidempotent.MethodPathStringIdempotentPayload", v)
+		}
+		if p.P != nil {
+			p = *p.P
+		}
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodPathStringIdempotentServicePathStringIdempotentPath(p)}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServicePathStringIdempotent", "MethodPathStringIdempotent", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(context.WithValue(ctx, goahttp.IdempotentKey, true))
+	}
+
+	return req, nil
+}
+`
@@ -30,6 +30,23 @@ var DefaultErrorResponseWithContentTypeDSL = func() {
 	})
 }
 
+var NegotiatedErrorResponseDSL = func() {
+	Service("ServiceNegotiatedErrorResponse", func() {
+		Method("MethodNegotiatedErrorResponse", func() {
+			Result(String)
+			Error("bad_request")
+			HTTP(func() {
+				GET("/one/two")
+				Response(StatusOK, func() {
+					ContentType("application/json")
+					ContentType("application/xml")
+				})
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
+
 var PrimitiveErrorResponseDSL = func() {
 	Service("ServicePrimitiveErrorResponse", func() {
 		Method("MethodPrimitiveErrorResponse", func() {
@@ -97,6 +114,43 @@ var ServiceErrorResponseDSL = func() {
 	})
 }
 
+var ProblemErrorResponseDSL = func() {
+	var ConflictError = Type("ConflictError", func() {
+		ErrorName("name", String)
+		Attribute("existing", String)
+		Required("name")
+	})
+	var _ = API("test", func() {
+		Meta("http:error:format", "problem+json")
+	})
+	Service("ServiceProblemErrorResponse", func() {
+		Method("MethodProblemErrorResponse", func() {
+			Error("not_found")
+			Error("conflict", ConflictError)
+			HTTP(func() {
+				GET("/one/two")
+				Response("not_found", StatusNotFound)
+				Response("conflict", StatusConflict)
+			})
+		})
+	})
+}
+
+var ValidationErrorResponseDSL = func() {
+	var _ = API("test", func() {
+		Meta("http:error:validation", "fields")
+	})
+	Service("ServiceValidationErrorResponse", func() {
+		Method("MethodValidationErrorResponse", func() {
+			Error("not_found")
+			HTTP(func() {
+				GET("/one/two")
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
+
 var APIErrorResponseDSL = func() {
 	var _ = API("test", func() {
 		Error("bad_request")
@@ -499,6 +499,107 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 	}()
 }
 
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		_, _ = w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
+
+	MethodOverrideServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceMethodOverrideEndpoints *servicemethodoverride.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Provide the transport specific request decoder and response encoder.
+	// The goa http package has built-in support for JSON, XML and gob.
+	// Other encodings can be used by providing the corresponding functions,
+	// see goa.design/implement/encoding.
+	var (
+		dec = goahttp.RequestDecoder
+		enc = goahttp.ResponseEncoder
+	)
+
+	// Build the service HTTP request multiplexer and configure it to serve
+	// HTTP requests to the service endpoints.
+	var mux goahttp.Muxer
+	{
+		mux = goahttp.NewMuxer()
+	}
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceMethodOverrideServer *servicemethodoverridesvr.Server
+	)
+	{
+		eh := errorHandler(logger)
+		serviceMethodOverrideServer = servicemethodoverridesvr.New(serviceMethodOverrideEndpoints, mux, dec, enc, eh, nil)
+		if debug {
+			servers := goahttp.Servers{
+				serviceMethodOverrideServer,
+			}
+			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
+		}
+	}
+	// Configure the mux.
+	servicemethodoverridesvr.Mount(mux, serviceMethodOverrideServer)
+
+	// Wrap the multiplexer with additional middlewares. Middlewares mounted
+	// here apply to all the service endpoints.
+	var handler http.Handler = mux
+	{
+		handler = httpmdlwr.Log(adapter)(handler)
+		handler = httpmdlwr.RequestID()(handler)
+		// Override the method of POST requests before they are routed.
+		handler = servicemethodoverridesvr.MethodOverrideMiddleware()(handler)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: handler, ReadHeaderTimeout: time.Second * 60}
+	for _, m := range serviceMethodOverrideServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", u.Host)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", u.Host)
+
+		// Shutdown gracefully with a 30s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			logger.Printf("failed to shutdown: %v", err)
+		}
+	}()
+}
+
 // errorHandler returns a function that writes and logs the given error.
 // The function also writes and logs the error unique ID so that it's possible
 // to correlate.
@@ -618,5 +719,92 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+`
+
+	IntegrationExampleCode = `// tokenProvider returns the credentials sent by the integration tests to the
+// methods secured with the given security scheme. name is "username" or
+// "password" for basic auth schemes, "key" for API key schemes and "token" for
+// JWT and OAuth2 schemes. The default implementation reads the credentials
+// from environment variables, e.g. JWT_TOKEN for the token of the "jwt"
+// scheme.
+var tokenProvider = func(scheme, name string) string {
+	return os.Getenv(strings.ToUpper(scheme + "_" + name))
+}
+
+// TestCalcIntegration sends the request examples defined in the design to the
+// Calc service HTTP server and checks the responses.
+func TestCalcIntegration(t *testing.T) {
+	c := newCalcIntegrationClient(t)
+	t.Run("Divide", func(t *testing.T) {
+		t.Run("four by two", func(t *testing.T) {
+			res, err := c.Divide(context.Background(), &calc.DividePayload{
+				A: 4,
+				B: 2,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := int(2)
+			if !reflect.DeepEqual(res, expected) {
+				t.Errorf("got result %+v, expected %+v", res, expected)
+			}
+		})
+		t.Run("division by zero", func(t *testing.T) {
+			_, err := c.Divide(context.Background(), &calc.DividePayload{
+				A: 4,
+				B: 0,
+			})
+			var named goa.GoaErrorNamer
+			if !errors.As(err, &named) || named.GoaErrorName() != "div_by_zero" {
+				t.Errorf("got error %v, expected %q error", err, "div_by_zero")
+			}
+		})
+	})
+	t.Run("Add", func(t *testing.T) {
+		t.Run("with note", func(t *testing.T) {
+			res, err := c.Add(context.Background(), &calc.AddPayload{
+				Token: ptr(tokenProvider("jwt", "token")),
+				A:     1,
+				Note:  ptr[string]("hi"),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := &calc.Sum{
+				Value: 1,
+				Note:  ptr[string]("hi"),
+			}
+			if !reflect.DeepEqual(res, expected) {
+				t.Errorf("got result %+v, expected %+v", res, expected)
+			}
+		})
+	})
+}
+
+// newCalcIntegrationClient starts the Calc service HTTP server and returns a
+// client that sends requests to it. The server is stopped when the test
+// completes.
+func newCalcIntegrationClient(t *testing.T) *calc.Client {
+	svc := NewCalc(log.New(io.Discard, "", 0))
+	mux := goahttp.NewMuxer()
+	eh := func(_ context.Context, _ http.ResponseWriter, err error) {
+		t.Errorf("server error: %v", err)
+	}
+	server := calcsvr.New(calc.NewEndpoints(svc), mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh, nil, &websocket.Upgrader{}, nil)
+	calcsvr.Mount(mux, server)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := calcc.NewClient(u.Scheme, u.Host, ts.Client(), goahttp.RequestEncoder, goahttp.ResponseDecoder, false, nil, nil)
+	return calc.NewClient(hc.Divide(), hc.Add(), hc.Watch())
+}
+
+// ptr returns a pointer to the given value.
+func ptr[T any](v T) *T {
+	return &v
+}
 `
 )
@@ -241,3 +241,165 @@ func NewMethodPayloadResultErrorHandler(
 	})
 }
 `
+
+var ServerConditionalHandlerConstructorCode = `// NewMethodConditionalHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceConditional" service "MethodConditional"
+// endpoint.
+func NewMethodConditionalHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodConditionalRequest(mux, decoder)
+		encodeResponse = EncodeMethodConditionalResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodConditional")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceConditional")
+		ctx = context.WithValue(ctx, goahttp.IfMatchKey, r.Header.Get("If-Match"))
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if errors.Is(err, goahttp.ErrPreconditionFailed) {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if v, ok := res.(*serviceconditional.MethodConditionalResult); ok {
+			etag := goahttp.ETag(v.Version, true)
+			w.Header().Set("ETag", etag)
+			if code := goahttp.CheckConditions(r, etag); code != 0 {
+				w.WriteHeader(code)
+				return
+			}
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerTraceHandlerConstructorCode = `// NewMethodTraceHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceTrace" service "MethodTrace" endpoint.
+func NewMethodTraceHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodTraceRequest(mux, decoder)
+		encodeResponse = EncodeMethodTraceResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := otel.Tracer("ServiceTrace").Start(r.Context(), "ServiceTrace.MethodTrace",
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", "/{tenant_id}/documents/{id}"),
+			),
+		)
+		rc := httpmdlwr.CaptureResponse(w)
+		w = rc
+		defer func() {
+			status := rc.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			span.End()
+		}()
+		ctx = context.WithValue(ctx, goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodTrace")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceTrace")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if p, ok := payload.(*servicetrace.MethodTracePayload); ok {
+			span.SetAttributes(attribute.String("id", p.ID))
+			if p.TenantID != nil {
+				span.SetAttributes(attribute.String("tenant_id", *p.TenantID))
+			}
+			if p.Page != nil {
+				span.SetAttributes(attribute.Int64("page", int64(*p.Page)))
+			}
+			if p.Verbose.HasValue() {
+				span.SetAttributes(attribute.Bool("verbose", p.Verbose.Value))
+			}
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			span.RecordError(err)
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerSunsetHandlerConstructorCode = `// NewMethodSunsetHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceSunset" service "MethodSunset" endpoint.
+func NewMethodSunsetHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodSunsetResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodSunset")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceSunset")
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", "Wed, 31 Dec 2025 00:00:00 GMT")
+		var err error
+		res, err := endpoint(ctx, nil)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
@@ -64,6 +64,40 @@ var MultiSimpleDSL = func() {
 	})
 }
 
+var CLIFlagsDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	var BasicAuth = BasicAuthSecurity("basic")
+	Service("ServiceCLIFlags", func() {
+		Method("MethodSecret", func() {
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+				Attribute("tags", ArrayOf(String))
+				Attribute("ids", ArrayOf(Int))
+				Attribute("body", MapOf(String, String))
+				Required("token")
+			})
+			HTTP(func() {
+				POST("/")
+				Param("tags")
+				Param("ids")
+				Body("body")
+			})
+		})
+		Method("MethodBasic", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Required("user", "pass")
+			})
+			HTTP(func() {
+				GET("/basic")
+			})
+		})
+	})
+}
+
 var MultiRequiredPayloadDSL = func() {
 	Service("ServiceMultiRequired1", func() {
 		Method("MethodMultiRequiredPayload", func() {
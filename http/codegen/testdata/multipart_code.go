@@ -370,3 +370,143 @@ func NewServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersE
 	}
 }
 `
+
+var MultipartNestedDecodeCode = `// DecodeServiceMultipartNestedMethodMultipartNestedMultipart decodes the
+// multipart request of the "ServiceMultipartNested" service
+// "MethodMultipartNested" endpoint into the payload. The parts are mapped to
+// the payload fields by name, the parts of nested multipart bodies to the
+// fields of user types. Requests nesting multipart bodies deeper than the
+// maximum depth or exceeding the maximum sizes are rejected. The function may
+// be given to New as the multipart request decoder.
+func DecodeServiceMultipartNestedMethodMultipartNestedMultipart(mr *multipart.Reader, p **servicemultipartnested.MethodMultipartNestedPayload) error {
+	parts, err := goahttp.ReadMultipart(mr, 3, goahttp.DefaultMultipartMaxPartSize, 1048576)
+	if err != nil {
+		return err
+	}
+	v, err := decodeServiceMultipartNestedMethodMultipartNestedMultipartParts(parts)
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+// decodeServiceMultipartNestedMethodMultipartNestedMultipartParts decodes a
+// value of type *servicemultipartnested.MethodMultipartNestedPayload from the
+// given multipart parts.
+func decodeServiceMultipartNestedMethodMultipartNestedMultipartParts(parts []*goahttp.MultipartPart) (*servicemultipartnested.MethodMultipartNestedPayload, error) {
+	var err error
+	v := &servicemultipartnested.MethodMultipartNestedPayload{}
+	seen := make(map[string]struct{})
+	for _, part := range parts {
+		seen[part.Name] = struct{}{}
+		switch part.Name {
+		case "title":
+			v.Title = string(part.Data)
+		case "tags":
+			v.Tags = append(v.Tags, string(part.Data))
+		case "score":
+			raw, perr := strconv.ParseFloat(string(part.Data), 64)
+			if perr != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("score", string(part.Data), "float"))
+				continue
+			}
+			v.Score = &raw
+		case "owner":
+			if part.Parts == nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("owner", part.ContentType, "nested multipart body"))
+				continue
+			}
+			val, perr := decodePersonMultipartParts(part.Parts)
+			if perr != nil {
+				err = goa.MergeErrors(err, perr)
+				continue
+			}
+			v.Owner = val
+		case "home":
+			if part.Parts == nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("home", part.ContentType, "nested multipart body"))
+				continue
+			}
+			val, perr := decodeAddressMultipartParts(part.Parts)
+			if perr != nil {
+				err = goa.MergeErrors(err, perr)
+				continue
+			}
+			v.Home = val
+		}
+	}
+	if _, ok := seen["title"]; !ok {
+		err = goa.MergeErrors(err, goa.MissingFieldError("title", "multipart body"))
+	}
+	if _, ok := seen["owner"]; !ok {
+		err = goa.MergeErrors(err, goa.MissingFieldError("owner", "multipart body"))
+	}
+	return v, err
+}
+// decodePersonMultipartParts decodes a value of type
+// *servicemultipartnested.Person from the given multipart parts.
+func decodePersonMultipartParts(parts []*goahttp.MultipartPart) (*servicemultipartnested.Person, error) {
+	var err error
+	v := &servicemultipartnested.Person{}
+	v.Age = 18
+	for _, part := range parts {
+		switch part.Name {
+		case "name":
+			val := string(part.Data)
+			v.Name = &val
+		case "age":
+			raw, perr := strconv.ParseInt(string(part.Data), 10, 32)
+			if perr != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("age", string(part.Data), "integer"))
+				continue
+			}
+			val := int32(raw)
+			v.Age = val
+		case "addresses":
+			if part.Parts == nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("addresses", part.ContentType, "nested multipart body"))
+				continue
+			}
+			val, perr := decodeAddressMultipartParts(part.Parts)
+			if perr != nil {
+				err = goa.MergeErrors(err, perr)
+				continue
+			}
+			v.Addresses = append(v.Addresses, val)
+		}
+	}
+	return v, err
+}
+// decodeAddressMultipartParts decodes a value of type
+// *servicemultipartnested.Address from the given multipart parts.
+func decodeAddressMultipartParts(parts []*goahttp.MultipartPart) (*servicemultipartnested.Address, error) {
+	var err error
+	v := &servicemultipartnested.Address{}
+	seen := make(map[string]struct{})
+	for _, part := range parts {
+		seen[part.Name] = struct{}{}
+		switch part.Name {
+		case "street":
+			v.Street = string(part.Data)
+		case "zip":
+			raw, perr := strconv.ParseUint(string(part.Data), 10, 32)
+			if perr != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("zip", string(part.Data), "unsigned integer"))
+				continue
+			}
+			val := uint32(raw)
+			v.Zip = &val
+		case "proof":
+			if perr := goahttp.ValidateMultipartContentType("proof", part, "application/pdf", "image/*"); perr != nil {
+				err = goa.MergeErrors(err, perr)
+				continue
+			}
+			v.Proof = part.Data
+		}
+	}
+	if _, ok := seen["street"]; !ok {
+		err = goa.MergeErrors(err, goa.MissingFieldError("street", "multipart body"))
+	}
+	return v, err
+}
+`
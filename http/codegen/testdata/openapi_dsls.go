@@ -70,6 +70,67 @@ var MultipleServicesDSL = func() {
 	})
 }
 
+var ServiceSchemasDSL = func() {
+	var Shared = Type("Shared", func() {
+		Attribute("name", String, func() {
+			Example("")
+		})
+	})
+	var OnlyA = Type("OnlyA", func() {
+		Attribute("shared", Shared)
+	})
+	var OnlyB = Type("OnlyB", func() {
+		Attribute("shared", Shared)
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	Service("serviceA", func() {
+		Method("methodA", func() {
+			Payload(OnlyA)
+			Result(Shared)
+			HTTP(func() {
+				POST("/a")
+			})
+		})
+	})
+	Service("serviceB", func() {
+		Method("methodB", func() {
+			Payload(OnlyB)
+			Result(Shared)
+			HTTP(func() {
+				POST("/b")
+			})
+		})
+	})
+}
+
+var FeatureGateDSL = func() {
+	Service("features", func() {
+		Method("checkout", func() {
+			FeatureGate("new_checkout")
+			HTTP(func() {
+				POST("/checkout")
+			})
+		})
+		Method("preview", func() {
+			FeatureGate("beta")
+			HTTP(func() {
+				GET("/preview")
+			})
+		})
+		Method("list", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var MultipleViewsDSL = func() {
 	var ResultT = ResultType("application/json", func() {
 		ContentType("application/vnd.custom+json")
@@ -284,6 +345,33 @@ var ArrayValidationDSL = func() {
 	})
 }
 
+var FieldGroupsValidationDSL = func() {
+	var Lookup = Type("Lookup", func() {
+		Attribute("id", String)
+		Attribute("email", String)
+		Attribute("phone", String)
+		Attribute("tags", ArrayOf(String))
+		MutuallyExclusive("id", "email", "phone")
+		RequiredOneOf("id", "email", "phone")
+		MutuallyExclusive("phone", "tags")
+	})
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(Lookup)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var ExtensionDSL = func() {
 	var PayloadT = Type("Payload", func() {
 		Attribute("string", String, func() {
@@ -533,6 +621,99 @@ var PathWithWildcardDSL = func() {
 	})
 }
 
+var PathParamStyleDSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/{id}/{tags}")
+				Param("id", func() {
+					Style("matrix")
+				})
+				Param("tags", func() {
+					Style("label")
+					Explode()
+				})
+			})
+		})
+	})
+}
+
+var QueryDeepObjectDSL = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("name", String)
+		Attribute("tags", ArrayOf(String))
+		Required("name")
+	})
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("filter", Filter)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter", func() {
+					Style("deepObject")
+				})
+			})
+		})
+	})
+}
+
+var QueryParamFlagsDSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("q", String)
+				Attribute("path", String)
+				Required("q")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					AllowEmptyValue()
+				})
+				Param("path", func() {
+					AllowReserved()
+				})
+			})
+		})
+	})
+}
+
+var DiscriminatorDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("kind", String)
+		Attribute("name", String)
+		Required("kind", "name")
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+			Map("cat", "Cat")
+		})
+	})
+	var Dog = Type("Dog", func() {
+		Extend(Pet)
+		Attribute("barks", Boolean)
+		Required("barks")
+	})
+	Type("Cat", func() {
+		Extend(Pet)
+		Attribute("lives", Int)
+	})
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(Pet)
+			Result(Dog)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var WithTagsDSL = func() {
 	Service("test service", func() {
 		HTTP(func() {
@@ -578,6 +759,89 @@ var WithTagsDSL = func() {
 	})
 }
 
+var WithTagsOrderDSL = func() {
+	Service("test service", func() {
+		HTTP(func() {
+			Meta("openapi:tag:Zebra:desc", "Ordered first")
+			Meta("openapi:tag:Zebra:order", "1")
+			Meta("openapi:tag:Zebra:externalDocs:url", "https://example.com/zebra")
+			Meta("openapi:tag:Zebra:externalDocs:desc", "Zebra docs")
+			Meta("openapi:tag:Alpaca:desc", "Ordered second")
+			Meta("openapi:tag:Alpaca:order", "2")
+			Meta("openapi:tag:Yak:desc", "Not ordered")
+			Meta("openapi:tag:Bison:desc", "Not ordered")
+		})
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("int_map", Int)
+			})
+			HTTP(func() {
+				Meta("openapi:tag:Yak")
+				Meta("openapi:tag:Zebra")
+				POST("/{*int_map}")
+			})
+		})
+	})
+}
+
+var GeneratedAttributeDSL = func() {
+	var User = Type("User", func() {
+		Attribute("id", Int64, func() {
+			Generated()
+		})
+		Attribute("name", String)
+		Required("id", "name")
+	})
+	Service("users", func() {
+		Method("create", func() {
+			Payload(User)
+			Result(User)
+			HTTP(func() {
+				POST("/users")
+				Response(StatusCreated)
+			})
+		})
+	})
+}
+
+var QueryParamDelimiterDSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("names", ArrayOf(String))
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Explode(false)
+				})
+				Param("names", func() {
+					Delimiter("|")
+				})
+				Param("tags", func() {
+					Delimiter(";")
+				})
+			})
+		})
+	})
+}
+
+var SLODSL = func() {
+	Service("test service", func() {
+		Method("test endpoint", func() {
+			SLO(func() {
+				LatencyP99("200ms")
+				SuccessRate(99.9)
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var WithTagsSwaggerDSL = func() {
 	Service("test service", func() {
 		HTTP(func() {
@@ -685,3 +949,107 @@ var TypenameDSL = func() {
 		})
 	})
 }
+
+var MultipleContentTypesDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	var Resp = Type("Resp", func() {
+		Attribute("value", String, func() {
+			Example("")
+		})
+	})
+	var _ = Service("testService", func() {
+		Method("testEndpoint", func() {
+			Result(Resp)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					ContentType("application/json")
+					ContentType("application/xml")
+				})
+			})
+		})
+	})
+}
+
+var MethodVersionsDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Host("localhost", func() {
+				URI("https://goa.design")
+			})
+		})
+	})
+	var _ = Service("testService", func() {
+		Method("since", func() {
+			Description("Method available since v2")
+			Since("v2")
+			HTTP(func() {
+				GET("/since")
+			})
+		})
+		Method("until", func() {
+			Until("v3")
+			HTTP(func() {
+				GET("/until")
+			})
+		})
+		Method("range", func() {
+			Since("v2")
+			Until("v3")
+			HTTP(func() {
+				GET("/range")
+			})
+		})
+		Method("hidden", func() {
+			Since("v2")
+			Meta("openapi:versions", "false")
+			HTTP(func() {
+				GET("/hidden")
+			})
+		})
+	})
+}
+
+var CodeSamplesDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	API("test", func() {
+		Meta("openapi:codesamples", "true")
+		Server("test", func() {
+			Host("dev", func() {
+				URI("https://api.example.com")
+			})
+		})
+	})
+	Service("Samples", func() {
+		Method("create", func() {
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+				Attribute("name", String, func() {
+					Example("goa")
+				})
+				Attribute("tags", ArrayOf(String), func() {
+					Example([]string{"a", "b"})
+				})
+				Attribute("description", String)
+				Required("token", "name", "tags")
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("hidden", func() {
+			Meta("openapi:codesamples", "false")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -149,14 +149,14 @@ func ParseEndpoint(
 		serviceMultiSimple1MethodMultiSimpleNoPayloadFlags = flag.NewFlagSet("method-multi-simple-no-payload", flag.ExitOnError)
 
 		serviceMultiSimple1MethodMultiSimplePayloadFlags    = flag.NewFlagSet("method-multi-simple-payload", flag.ExitOnError)
-		serviceMultiSimple1MethodMultiSimplePayloadBodyFlag = serviceMultiSimple1MethodMultiSimplePayloadFlags.String("body", "REQUIRED", "")
+		serviceMultiSimple1MethodMultiSimplePayloadBodyFlag = goa.JSONFlag(serviceMultiSimple1MethodMultiSimplePayloadFlags, "body", "REQUIRED", "")
 
 		serviceMultiSimple2Flags = flag.NewFlagSet("service-multi-simple2", flag.ContinueOnError)
 
 		serviceMultiSimple2MethodMultiSimpleNoPayloadFlags = flag.NewFlagSet("method-multi-simple-no-payload", flag.ExitOnError)
 
 		serviceMultiSimple2MethodMultiSimplePayloadFlags    = flag.NewFlagSet("method-multi-simple-payload", flag.ExitOnError)
-		serviceMultiSimple2MethodMultiSimplePayloadBodyFlag = serviceMultiSimple2MethodMultiSimplePayloadFlags.String("body", "REQUIRED", "")
+		serviceMultiSimple2MethodMultiSimplePayloadBodyFlag = goa.JSONFlag(serviceMultiSimple2MethodMultiSimplePayloadFlags, "body", "REQUIRED", "")
 	)
 	serviceMultiSimple1Flags.Usage = serviceMultiSimple1Usage
 	serviceMultiSimple1MethodMultiSimpleNoPayloadFlags.Usage = serviceMultiSimple1MethodMultiSimpleNoPayloadUsage
@@ -283,7 +283,7 @@ func ParseEndpoint(
 		serviceMultiRequired1Flags = flag.NewFlagSet("service-multi-required1", flag.ContinueOnError)
 
 		serviceMultiRequired1MethodMultiRequiredPayloadFlags    = flag.NewFlagSet("method-multi-required-payload", flag.ExitOnError)
-		serviceMultiRequired1MethodMultiRequiredPayloadBodyFlag = serviceMultiRequired1MethodMultiRequiredPayloadFlags.String("body", "REQUIRED", "")
+		serviceMultiRequired1MethodMultiRequiredPayloadBodyFlag = goa.JSONFlag(serviceMultiRequired1MethodMultiRequiredPayloadFlags, "body", "REQUIRED", "")
 
 		serviceMultiRequired2Flags = flag.NewFlagSet("service-multi-required2", flag.ContinueOnError)
 
@@ -412,7 +412,7 @@ func ParseEndpoint(
 		serviceMultiMethodMultiNoPayloadFlags = flag.NewFlagSet("method-multi-no-payload", flag.ExitOnError)
 
 		serviceMultiMethodMultiPayloadFlags    = flag.NewFlagSet("method-multi-payload", flag.ExitOnError)
-		serviceMultiMethodMultiPayloadBodyFlag = serviceMultiMethodMultiPayloadFlags.String("body", "REQUIRED", "")
+		serviceMultiMethodMultiPayloadBodyFlag = goa.JSONFlag(serviceMultiMethodMultiPayloadFlags, "body", "REQUIRED", "")
 		serviceMultiMethodMultiPayloadBFlag    = serviceMultiMethodMultiPayloadFlags.String("b", "", "")
 		serviceMultiMethodMultiPayloadAFlag    = serviceMultiMethodMultiPayloadFlags.String("a", "", "")
 	)
@@ -648,7 +648,7 @@ func BuildMethodMultiPayloadPayload(serviceMultiMethodMultiPayloadBody string, s
 	{
 		err = json.Unmarshal([]byte(serviceMultiMethodMultiPayloadBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"c\": {\n         \"att\": false,\n         \"att10\": \"Aspernatur quo error explicabo pariatur.\",\n         \"att11\": \"Q3VtcXVlIHZvbHVwdGF0ZW0u\",\n         \"att12\": \"Distinctio aliquam nihil blanditiis ut.\",\n         \"att13\": [\n            \"Nihil excepturi deserunt quasi omnis sed.\",\n            \"Sit maiores aperiam autem non ea rem.\"\n         ],\n         \"att14\": {\n            \"Excepturi totam.\": \"Ut aut facilis vel ipsam.\",\n            \"Minima et aut non sunt consequuntur.\": \"Et consequuntur porro quasi.\",\n            \"Quis voluptates quaerat et temporibus facere.\": \"Ipsam eaque sunt maxime suscipit.\"\n         },\n         \"att15\": {\n            \"inline\": \"Ea alias repellat nobis veritatis.\"\n         },\n         \"att2\": 3504438334001971349,\n         \"att3\": 2005839040,\n         \"att4\": 5845720715558772393,\n         \"att5\": 12124006045301819638,\n         \"att6\": 3731236027,\n         \"att7\": 10708117302649141570,\n         \"att8\": 0.11815318,\n         \"att9\": 0.30907290919538355\n      }\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"c\": {\n         \"att\": false,\n         \"att10\": \"Qui voluptatum magnam.\",\n         \"att11\": \"TmVtbyByYXRpb25lIHF1aWJ1c2RhbSBwcm92aWRlbnQgdGVtcG9yYS4=\",\n         \"att12\": \"Non et est.\",\n         \"att13\": [\n            \"Ipsum porro perspiciatis et dolores quia.\",\n            \"Atque ratione cupiditate.\",\n            \"Quia et consequuntur nam omnis non corporis.\",\n            \"Nulla praesentium harum sit eligendi.\"\n         ],\n         \"att14\": {\n            \"Ut vero doloremque.\": \"Et ut sunt.\"\n         },\n         \"att15\": {\n            \"inline\": \"Porro quaerat ipsa autem.\"\n         },\n         \"att2\": 7383324657837539461,\n         \"att3\": 1734146600,\n         \"att4\": 3368923973410471018,\n         \"att5\": 13810633237283090143,\n         \"att6\": 388253347,\n         \"att7\": 18321815050558481493,\n         \"att8\": 0.22821684,\n         \"att9\": 0.7985025532709547\n      }\n   }'")
 		}
 	}
 	var b *string
@@ -709,7 +709,7 @@ func BuildMethodBodyQueryPathObjectPayload(serviceBodyQueryPathObjectMethodBodyQ
 	{
 		err = json.Unmarshal([]byte(serviceBodyQueryPathObjectMethodBodyQueryPathObjectBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"a\": \"Ullam aut.\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"a\": \"Quia tempore quia quaerat hic.\"\n   }'")
 		}
 	}
 	var c2 string
@@ -872,7 +872,7 @@ func ParseEndpoint(
 		serviceBodyPrimitiveArrayStringValidateFlags = flag.NewFlagSet("service-body-primitive-array-string-validate", flag.ContinueOnError)
 
 		serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateFlags = flag.NewFlagSet("method-body-primitive-array-string-validate", flag.ExitOnError)
-		serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidatePFlag = serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateFlags.String("p", "REQUIRED", "[]string is the payload type of the ServiceBodyPrimitiveArrayStringValidate service MethodBodyPrimitiveArrayStringValidate method.")
+		serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidatePFlag = goa.ArrayFlag(serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateFlags, "p", "REQUIRED", "[]string is the payload type of the ServiceBodyPrimitiveArrayStringValidate service MethodBodyPrimitiveArrayStringValidate method.", true)
 	)
 	serviceBodyPrimitiveArrayStringValidateFlags.Usage = serviceBodyPrimitiveArrayStringValidateUsage
 	serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateFlags.Usage = serviceBodyPrimitiveArrayStringValidateMethodBodyPrimitiveArrayStringValidateUsage
@@ -1008,7 +1008,7 @@ func BuildMethodBodyInlineObjectPayload(serviceBodyInlineObjectMethodBodyInlineO
 	{
 		err = json.Unmarshal([]byte(serviceBodyInlineObjectMethodBodyInlineObjectBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"a\": \"Ullam aut.\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"a\": \"Itaque inventore optio.\"\n   }'")
 		}
 	}
 	v := &servicebodyinlineobject.MethodBodyInlineObjectPayload{
@@ -1029,7 +1029,7 @@ func BuildMethodBodyInlineObjectPayload(serviceBodyInlineObjectMethodBodyInlineO
 	{
 		err = json.Unmarshal([]byte(serviceBodyInlineObjectMethodBodyInlineObjectBody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"a\": \"Ullam aut.\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"a\": \"Itaque inventore optio.\"\n   }'")
 		}
 	}
 	v := &servicebodyinlineobject.MethodBodyInlineObjectPayload{
@@ -1059,7 +1059,7 @@ func ParseEndpoint(
 		serviceMapQueryPrimitiveArrayFlags = flag.NewFlagSet("service-map-query-primitive-array", flag.ContinueOnError)
 
 		serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayFlags = flag.NewFlagSet("map-query-primitive-array", flag.ExitOnError)
-		serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayPFlag = serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayFlags.String("p", "REQUIRED", "map[string][]uint is the payload type of the ServiceMapQueryPrimitiveArray service MapQueryPrimitiveArray method.")
+		serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayPFlag = goa.JSONFlag(serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayFlags, "p", "REQUIRED", "map[string][]uint is the payload type of the ServiceMapQueryPrimitiveArray service MapQueryPrimitiveArray method.")
 	)
 	serviceMapQueryPrimitiveArrayFlags.Usage = serviceMapQueryPrimitiveArrayUsage
 	serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayFlags.Usage = serviceMapQueryPrimitiveArrayMapQueryPrimitiveArrayUsage
@@ -1175,7 +1175,7 @@ func BuildMethodMapQueryObjectPayload(serviceMapQueryObjectMethodMapQueryObjectB
 	{
 		err = json.Unmarshal([]byte(serviceMapQueryObjectMethodMapQueryObjectC), &c)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for c, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"1484745265794365762\": [\n         \"Similique aspernatur.\",\n         \"Error explicabo.\",\n         \"Minima cumque voluptatem et distinctio aliquam.\",\n         \"Blanditiis ut eaque.\"\n      ],\n      \"4925854623691091547\": [\n         \"Eos aut ipsam.\",\n         \"Aliquam tempora.\"\n      ],\n      \"7174751143827362498\": [\n         \"Facilis minus explicabo nemo eos vel repellat.\",\n         \"Voluptatum magni aperiam qui.\"\n      ]\n   }'")
+			return nil, fmt.Errorf("invalid JSON for c, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"1933576090881074823\": [\n         \"Doloribus qui quia.\",\n         \"Et tempora et quae.\"\n      ],\n      \"2139806046876113332\": [\n         \"Optio quia ullam aut.\",\n         \"Iste perspiciatis.\",\n         \"Harum et.\",\n         \"Neque nisi quibusdam nisi sint sunt.\"\n      ],\n      \"2929115566830881500\": [\n         \"Assumenda fuga est sint maxime.\",\n         \"Qui molestiae iure.\",\n         \"Consequuntur sint voluptate.\"\n      ]\n   }'")
 		}
 	}
 	v := &servicemapqueryobject.PayloadType{
@@ -1297,7 +1297,7 @@ func BuildMethodBodyPrimitiveArrayUserPayload(serviceBodyPrimitiveArrayUserMetho
 		if serviceBodyPrimitiveArrayUserMethodBodyPrimitiveArrayUserA != "" {
 			err = json.Unmarshal([]byte(serviceBodyPrimitiveArrayUserMethodBodyPrimitiveArrayUserA), &a)
 			if err != nil {
-				return nil, fmt.Errorf("invalid JSON for a, \nerror: %s, \nexample of valid JSON:\n%s", err, "'[\n      \"Perspiciatis repellendus harum et est.\",\n      \"Nisi quibusdam nisi sint sunt beatae.\"\n   ]'")
+				return nil, fmt.Errorf("invalid JSON for a, \nerror: %s, \nexample of valid JSON:\n%s", err, "'[\n      \"Molestias recusandae doloribus qui quia.\",\n      \"Et tempora et quae.\",\n      \"Itaque inventore optio.\",\n      \"Ullam aut.\"\n   ]'")
 			}
 		}
 	}
@@ -1316,7 +1316,7 @@ func BuildMethodAPayload(serviceWithParamsAndHeadersBlockMethodABody string, ser
 	{
 		err = json.Unmarshal([]byte(serviceWithParamsAndHeadersBlockMethodABody), &body)
 		if err != nil {
-			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"body\": \"Inventore optio quia ullam aut iste iste.\"\n   }'")
+			return nil, fmt.Errorf("invalid JSON for body, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"body\": \"Exercitationem labore doloribus dolores.\"\n   }'")
 		}
 	}
 	var path uint
@@ -1374,3 +1374,117 @@ func BuildMethodAPayload(serviceWithParamsAndHeadersBlockMethodABody string, ser
 	return v, nil
 }
 `
+
+var CLIFlagsParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
+// line.
+func ParseEndpoint(
+	scheme, host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restore bool,
+) (goa.Endpoint, interface{}, error) {
+	var (
+		serviceCLIFlagsFlags = flag.NewFlagSet("service-cli-flags", flag.ContinueOnError)
+
+		serviceCLIFlagsMethodSecretFlags     = flag.NewFlagSet("method-secret", flag.ExitOnError)
+		serviceCLIFlagsMethodSecretBodyFlag  = goa.JSONFlag(serviceCLIFlagsMethodSecretFlags, "body", "REQUIRED", "")
+		serviceCLIFlagsMethodSecretTagsFlag  = goa.ArrayFlag(serviceCLIFlagsMethodSecretFlags, "tags", "", "", true)
+		serviceCLIFlagsMethodSecretIdsFlag   = goa.ArrayFlag(serviceCLIFlagsMethodSecretFlags, "ids", "", "", false)
+		serviceCLIFlagsMethodSecretTokenFlag = goa.SecretFlag(serviceCLIFlagsMethodSecretFlags, "token", "", "", "SERVICE_CLI_FLAGS_TOKEN", true)
+
+		serviceCLIFlagsMethodBasicFlags    = flag.NewFlagSet("method-basic", flag.ExitOnError)
+		serviceCLIFlagsMethodBasicUserFlag = serviceCLIFlagsMethodBasicFlags.String("user", "REQUIRED", "")
+		serviceCLIFlagsMethodBasicPassFlag = goa.SecretFlag(serviceCLIFlagsMethodBasicFlags, "pass", "", "", "SERVICE_CLI_FLAGS_PASS", true)
+	)
+	serviceCLIFlagsFlags.Usage = serviceCLIFlagsUsage
+	serviceCLIFlagsMethodSecretFlags.Usage = serviceCLIFlagsMethodSecretUsage
+	serviceCLIFlagsMethodBasicFlags.Usage = serviceCLIFlagsMethodBasicUsage
+
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	if flag.NArg() < 2 { // two non flag args are required: SERVICE and ENDPOINT (aka COMMAND)
+		return nil, nil, fmt.Errorf("not enough arguments")
+	}
+
+	var (
+		svcn string
+		svcf *flag.FlagSet
+	)
+	{
+		svcn = flag.Arg(0)
+		switch svcn {
+		case "service-cli-flags":
+			svcf = serviceCLIFlagsFlags
+		default:
+			return nil, nil, fmt.Errorf("unknown service %q", svcn)
+		}
+	}
+	if err := svcf.Parse(flag.Args()[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		epn string
+		epf *flag.FlagSet
+	)
+	{
+		epn = svcf.Arg(0)
+		switch svcn {
+		case "service-cli-flags":
+			switch epn {
+			case "method-secret":
+				epf = serviceCLIFlagsMethodSecretFlags
+
+			case "method-basic":
+				epf = serviceCLIFlagsMethodBasicFlags
+
+			}
+
+		}
+	}
+	if epf == nil {
+		return nil, nil, fmt.Errorf("unknown %q endpoint %q", svcn, epn)
+	}
+
+	// Parse endpoint flags if any
+	if svcf.NArg() > 1 {
+		if err := epf.Parse(svcf.Args()[1:]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Read the secret flags that are not set from the environment or prompt
+	// for them
+	if err := goa.ReadSecretFlags(epf); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		data     interface{}
+		endpoint goa.Endpoint
+		err      error
+	)
+	{
+		switch svcn {
+		case "service-cli-flags":
+			c := servicecliflagsc.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "method-secret":
+				endpoint = c.MethodSecret()
+				data, err = servicecliflagsc.BuildMethodSecretPayload(*serviceCLIFlagsMethodSecretBodyFlag, *serviceCLIFlagsMethodSecretTagsFlag, *serviceCLIFlagsMethodSecretIdsFlag, *serviceCLIFlagsMethodSecretTokenFlag)
+			case "method-basic":
+				endpoint = c.MethodBasic()
+				data, err = servicecliflagsc.BuildMethodBasicPayload(*serviceCLIFlagsMethodBasicUserFlag, *serviceCLIFlagsMethodBasicPassFlag)
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return endpoint, data, nil
+}
+`
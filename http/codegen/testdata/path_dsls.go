@@ -246,3 +246,24 @@ var PathInterfaceSliceParamDSL = func() {
 		})
 	})
 }
+
+var PathStyleParamsDSL = func() {
+	Service("ServicePathStyleParams", func() {
+		Method("MethodPathStyleParams", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("one/{id}/two/{tags}")
+				Param("id", func() {
+					Style("matrix")
+				})
+				Param("tags", func() {
+					Style("label")
+					Explode()
+				})
+			})
+		})
+	})
+}
@@ -174,3 +174,13 @@ func MethodPathInterfaceSliceParamServicePathInterfaceSliceParamPath(a []interfa
 	return fmt.Sprintf("/one/%v/two", strings.Join(aSlice, ","))
 }
 `
+
+var PathStyleParamsCode = `// MethodPathStyleParamsServicePathStyleParamsPath returns the URL path to the ServicePathStyleParams service MethodPathStyleParams HTTP endpoint.
+func MethodPathStyleParamsServicePathStyleParamsPath(id int, tags []string) string {
+	tagsSlice := make([]string, len(tags))
+	for i, v := range tags {
+		tagsSlice[i] = url.QueryEscape(v)
+	}
+	return fmt.Sprintf("/one/%v/two/%v", goahttp.EncodePathParam("id", "matrix", false, fmt.Sprint(id)), goahttp.EncodePathParam("tags", "label", true, tagsSlice...))
+}
+`
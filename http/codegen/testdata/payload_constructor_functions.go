@@ -824,6 +824,27 @@ func NewMethodBodyUnionUnion(body *MethodBodyUnionRequestBody) *servicebodyunion
 }
 `
 
+var PayloadBodyDiscriminatedUnionConstructorCode = `// NewMethodBodyDiscriminatedUnionUnion builds a ServiceBodyDiscriminatedUnion
+// service MethodBodyDiscriminatedUnion endpoint payload.
+func NewMethodBodyDiscriminatedUnionUnion(body *MethodBodyDiscriminatedUnionRequestBody) *servicebodydiscriminatedunion.Union {
+	v := &servicebodydiscriminatedunion.Union{}
+	if body.Values != nil {
+		switch *body.Values.Type {
+		case "dog":
+			var val *servicebodydiscriminatedunion.Dog
+			json.Unmarshal([]byte(*body.Values.Value), &val)
+			v.Values = val
+		case "cat":
+			var val *servicebodydiscriminatedunion.Cat
+			json.Unmarshal([]byte(*body.Values.Value), &val)
+			v.Values = val
+		}
+	}
+
+	return v
+}
+`
+
 var PayloadBodyQueryUserUnionConstructorCode = `// NewMethodBodyQueryUserUnionPayloadType builds a ServiceBodyQueryUserUnion
 // service MethodBodyQueryUserUnion endpoint payload.
 func NewMethodBodyQueryUserUnionPayloadType(body *MethodBodyQueryUserUnionRequestBody, b *string) *servicebodyqueryuserunion.PayloadType {
@@ -40,12 +40,13 @@ func DecodeMethodQueryBoolValidateRequest(mux goahttp.Muxer, decoder func(*http.
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseBool(qRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "boolean"))
+				}
+				q = v
 			}
-			v, err2 := strconv.ParseBool(qRaw)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "boolean"))
-			}
-			q = v
 		}
 		if !(q == true) {
 			err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", q, []interface{}{true}))
@@ -101,12 +102,13 @@ func DecodeMethodQueryIntValidateRequest(mux goahttp.Muxer, decoder func(*http.R
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseInt(qRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "integer"))
+				}
+				q = int(v)
 			}
-			v, err2 := strconv.ParseInt(qRaw, 10, strconv.IntSize)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "integer"))
-			}
-			q = int(v)
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -162,12 +164,13 @@ func DecodeMethodQueryInt32ValidateRequest(mux goahttp.Muxer, decoder func(*http
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseInt(qRaw, 10, 32)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "integer"))
+				}
+				q = int32(v)
 			}
-			v, err2 := strconv.ParseInt(qRaw, 10, 32)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "integer"))
-			}
-			q = int32(v)
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -222,12 +225,13 @@ func DecodeMethodQueryInt64ValidateRequest(mux goahttp.Muxer, decoder func(*http
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseInt(qRaw, 10, 64)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "integer"))
+				}
+				q = v
 			}
-			v, err2 := strconv.ParseInt(qRaw, 10, 64)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "integer"))
-			}
-			q = v
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -283,12 +287,13 @@ func DecodeMethodQueryUIntValidateRequest(mux goahttp.Muxer, decoder func(*http.
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseUint(qRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "unsigned integer"))
+				}
+				q = uint(v)
 			}
-			v, err2 := strconv.ParseUint(qRaw, 10, strconv.IntSize)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "unsigned integer"))
-			}
-			q = uint(v)
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -344,12 +349,13 @@ func DecodeMethodQueryUInt32ValidateRequest(mux goahttp.Muxer, decoder func(*htt
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseUint(qRaw, 10, 32)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "unsigned integer"))
+				}
+				q = uint32(v)
 			}
-			v, err2 := strconv.ParseUint(qRaw, 10, 32)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "unsigned integer"))
-			}
-			q = uint32(v)
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -404,12 +410,13 @@ func DecodeMethodQueryUInt64ValidateRequest(mux goahttp.Muxer, decoder func(*htt
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseUint(qRaw, 10, 64)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "unsigned integer"))
+				}
+				q = v
 			}
-			v, err2 := strconv.ParseUint(qRaw, 10, 64)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "unsigned integer"))
-			}
-			q = v
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -465,12 +472,13 @@ func DecodeMethodQueryFloat32ValidateRequest(mux goahttp.Muxer, decoder func(*ht
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseFloat(qRaw, 32)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "float"))
+				}
+				q = float32(v)
 			}
-			v, err2 := strconv.ParseFloat(qRaw, 32)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "float"))
-			}
-			q = float32(v)
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -525,12 +533,13 @@ func DecodeMethodQueryFloat64ValidateRequest(mux goahttp.Muxer, decoder func(*ht
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseFloat(qRaw, 64)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "float"))
+				}
+				q = v
 			}
-			v, err2 := strconv.ParseFloat(qRaw, 64)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "float"))
-			}
-			q = v
 		}
 		if q < 1 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError("q", q, 1, true))
@@ -588,6 +597,42 @@ func DecodeMethodQueryStringValidateRequest(mux goahttp.Muxer, decoder func(*htt
 }
 `
 
+var PayloadQueryMissingParamErrorDecodeCode = `// DecodeMethodQueryMissingParamErrorRequest returns a decoder for requests
+// sent to the ServiceQueryMissingParamError MethodQueryMissingParamError
+// endpoint.
+func DecodeMethodQueryMissingParamErrorRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q     string
+			limit int
+			err   error
+		)
+		q = r.URL.Query().Get("q")
+		if q == "" {
+			err = goa.MergeErrors(err, goa.MissingParamError("q", "query string"))
+		}
+		{
+			limitRaw := r.URL.Query().Get("limit")
+			if limitRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingParamError("limit", "query string"))
+			} else {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				limit = int(v)
+			}
+		}
+		if err != nil {
+			return nil, goa.RenameMissingParamError(err, "bad_request")
+		}
+		payload := NewMethodQueryMissingParamErrorPayload(q, limit)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadQueryStringNotRequiredValidateDecodeCode = `// DecodeMethodQueryStringNotRequiredValidateRequest returns a decoder for
 // requests sent to the ServiceQueryStringNotRequiredValidate
 // MethodQueryStringNotRequiredValidate endpoint.
@@ -648,8 +693,9 @@ func DecodeMethodQueryBytesValidateRequest(mux goahttp.Muxer, decoder func(*http
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				q = []byte(qRaw)
 			}
-			q = []byte(qRaw)
 		}
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
@@ -750,7 +796,7 @@ func DecodeMethodQueryArrayBoolValidateRequest(mux goahttp.Muxer, decoder func(*
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]bool, len(qRaw))
 			for i, rv := range qRaw {
@@ -764,10 +810,13 @@ func DecodeMethodQueryArrayBoolValidateRequest(mux goahttp.Muxer, decoder func(*
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -821,7 +870,7 @@ func DecodeMethodQueryArrayIntValidateRequest(mux goahttp.Muxer, decoder func(*h
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]int, len(qRaw))
 			for i, rv := range qRaw {
@@ -835,10 +884,13 @@ func DecodeMethodQueryArrayIntValidateRequest(mux goahttp.Muxer, decoder func(*h
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -893,7 +945,7 @@ func DecodeMethodQueryArrayInt32ValidateRequest(mux goahttp.Muxer, decoder func(
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]int32, len(qRaw))
 			for i, rv := range qRaw {
@@ -907,10 +959,13 @@ func DecodeMethodQueryArrayInt32ValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -965,7 +1020,7 @@ func DecodeMethodQueryArrayInt64ValidateRequest(mux goahttp.Muxer, decoder func(
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]int64, len(qRaw))
 			for i, rv := range qRaw {
@@ -979,10 +1034,13 @@ func DecodeMethodQueryArrayInt64ValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1037,7 +1095,7 @@ func DecodeMethodQueryArrayUIntValidateRequest(mux goahttp.Muxer, decoder func(*
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]uint, len(qRaw))
 			for i, rv := range qRaw {
@@ -1051,10 +1109,13 @@ func DecodeMethodQueryArrayUIntValidateRequest(mux goahttp.Muxer, decoder func(*
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1109,7 +1170,7 @@ func DecodeMethodQueryArrayUInt32ValidateRequest(mux goahttp.Muxer, decoder func
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]uint32, len(qRaw))
 			for i, rv := range qRaw {
@@ -1123,10 +1184,13 @@ func DecodeMethodQueryArrayUInt32ValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1181,7 +1245,7 @@ func DecodeMethodQueryArrayUInt64ValidateRequest(mux goahttp.Muxer, decoder func
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]uint64, len(qRaw))
 			for i, rv := range qRaw {
@@ -1195,10 +1259,13 @@ func DecodeMethodQueryArrayUInt64ValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1253,7 +1320,7 @@ func DecodeMethodQueryArrayFloat32ValidateRequest(mux goahttp.Muxer, decoder fun
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]float32, len(qRaw))
 			for i, rv := range qRaw {
@@ -1267,10 +1334,13 @@ func DecodeMethodQueryArrayFloat32ValidateRequest(mux goahttp.Muxer, decoder fun
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1325,7 +1395,7 @@ func DecodeMethodQueryArrayFloat64ValidateRequest(mux goahttp.Muxer, decoder fun
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]float64, len(qRaw))
 			for i, rv := range qRaw {
@@ -1339,10 +1409,13 @@ func DecodeMethodQueryArrayFloat64ValidateRequest(mux goahttp.Muxer, decoder fun
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if e < 1 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 1 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("q[*]", e, 1, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1385,10 +1458,13 @@ func DecodeMethodQueryArrayStringValidateRequest(mux goahttp.Muxer, decoder func
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == "val") {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1435,7 +1511,7 @@ func DecodeMethodQueryArrayBytesValidateRequest(mux goahttp.Muxer, decoder func(
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([][]byte, len(qRaw))
 			for i, rv := range qRaw {
@@ -1445,10 +1521,13 @@ func DecodeMethodQueryArrayBytesValidateRequest(mux goahttp.Muxer, decoder func(
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if len(e) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[*]", e, len(e), 2, true))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if len(e) < 2 {
+					err = goa.MergeErrors(err, goa.InvalidLengthError("q[*]", e, len(e), 2, true))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1494,7 +1573,7 @@ func DecodeMethodQueryArrayAnyValidateRequest(mux goahttp.Muxer, decoder func(*h
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]interface{}, len(qRaw))
 			for i, rv := range qRaw {
@@ -1504,10 +1583,13 @@ func DecodeMethodQueryArrayAnyValidateRequest(mux goahttp.Muxer, decoder func(*h
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if !(e == "val" || e == 1) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val", 1}))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == "val" || e == 1) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val", 1}))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -1588,9 +1670,12 @@ func DecodeMethodQueryMapStringStringValidateRequest(mux goahttp.Muxer, decoder
 			if !(k == "key") {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
-			if !(v == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(v == "val") {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -1693,9 +1778,12 @@ func DecodeMethodQueryMapStringBoolValidateRequest(mux goahttp.Muxer, decoder fu
 			if !(k == "key") {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
-			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(v == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -1790,9 +1878,12 @@ func DecodeMethodQueryMapBoolStringValidateRequest(mux goahttp.Muxer, decoder fu
 			if !(k == true) {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
-			if !(v == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(v == "val") {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{"val"}))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -1905,9 +1996,12 @@ func DecodeMethodQueryMapBoolBoolValidateRequest(mux goahttp.Muxer, decoder func
 			if !(k == false) {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{false}))
 			}
-			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(v == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -1989,9 +2083,12 @@ func DecodeMethodQueryMapStringArrayStringValidateRequest(mux goahttp.Muxer, dec
 			if !(k == "key") {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
-			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if len(v) < 2 {
+					err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -2099,9 +2196,12 @@ func DecodeMethodQueryMapStringArrayBoolValidateRequest(mux goahttp.Muxer, decod
 			if !(k == "key") {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{"key"}))
 			}
-			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if len(v) < 2 {
+					err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -2196,9 +2296,12 @@ func DecodeMethodQueryMapBoolArrayStringValidateRequest(mux goahttp.Muxer, decod
 			if !(k == true) {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
-			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if len(v) < 2 {
+					err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -2315,9 +2418,12 @@ func DecodeMethodQueryMapBoolArrayBoolValidateRequest(mux goahttp.Muxer, decoder
 			if !(k == true) {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
-			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if len(v) < 2 {
+					err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -2368,12 +2474,13 @@ func DecodeMethodQueryPrimitiveBoolValidateRequest(mux goahttp.Muxer, decoder fu
 			qRaw := r.URL.Query().Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			} else {
+				v, err2 := strconv.ParseBool(qRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "boolean"))
+				}
+				q = v
 			}
-			v, err2 := strconv.ParseBool(qRaw)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "boolean"))
-			}
-			q = v
 		}
 		if !(q == true) {
 			err = goa.MergeErrors(err, goa.InvalidEnumValueError("q", q, []interface{}{true}))
@@ -2404,10 +2511,13 @@ func DecodeMethodQueryPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, dec
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == "val") {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{"val"}))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -2431,7 +2541,7 @@ func DecodeMethodQueryPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]bool, len(qRaw))
 			for i, rv := range qRaw {
@@ -2445,10 +2555,13 @@ func DecodeMethodQueryPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		if len(q) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("q", q, len(q), 1, true))
 		}
-		for _, e := range q {
-			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
-			}
+		for i, e := range q {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[*]", e, []interface{}{true}))
+				}
+				return
+			}(), "q", i))
 		}
 		if err != nil {
 			return nil, err
@@ -2495,12 +2608,18 @@ func DecodeMethodQueryPrimitiveMapStringArrayStringValidateRequest(mux goahttp.M
 		}
 		for k, v := range q {
 			err = goa.MergeErrors(err, goa.ValidatePattern("q.key", k, "key"))
-			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
-			}
-			for _, e := range v {
-				err = goa.MergeErrors(err, goa.ValidatePattern("q[key][*]", e, "val"))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if len(v) < 2 {
+					err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
+				}
+				for i, e := range v {
+					err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+						err = goa.MergeErrors(err, goa.ValidatePattern("q[key][*]", e, "val"))
+						return
+					}(), "q[key]", i))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -2555,9 +2674,12 @@ func DecodeMethodQueryPrimitiveMapStringBoolValidateRequest(mux goahttp.Muxer, d
 		}
 		for k, v := range q {
 			err = goa.MergeErrors(err, goa.ValidatePattern("q.key", k, "key"))
-			if !(v == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
-			}
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(v == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key]", v, []interface{}{true}))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -2621,14 +2743,20 @@ func DecodeMethodQueryPrimitiveMapBoolArrayBoolValidateRequest(mux goahttp.Muxer
 			if !(k == true) {
 				err = goa.MergeErrors(err, goa.InvalidEnumValueError("q.key", k, []interface{}{true}))
 			}
-			if len(v) < 2 {
-				err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
-			}
-			for _, e := range v {
-				if !(e == false) {
-					err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key][*]", e, []interface{}{false}))
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if len(v) < 2 {
+					err = goa.MergeErrors(err, goa.InvalidLengthError("q[key]", v, len(v), 2, true))
 				}
-			}
+				for i, e := range v {
+					err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+						if !(e == false) {
+							err = goa.MergeErrors(err, goa.InvalidEnumValueError("q[key][*]", e, []interface{}{false}))
+						}
+						return
+					}(), "q[key]", i))
+				}
+				return
+			}(), "q", k))
 		}
 		if err != nil {
 			return nil, err
@@ -2937,6 +3065,50 @@ func DecodeMethodPathStringValidateRequest(mux goahttp.Muxer, decoder func(*http
 }
 `
 
+var PayloadPathStyleDecodeCode = `// DecodeMethodPathStyleRequest returns a decoder for requests sent to the
+// ServicePathStyle MethodPathStyle endpoint.
+func DecodeMethodPathStyleRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id  string
+			ids []int
+			err error
+
+			params = mux.Vars(r)
+		)
+		if v, perr := goahttp.DecodePathParam(params["id"], "id", "label", false); perr != nil {
+			err = goa.MergeErrors(err, perr)
+		} else {
+			params["id"] = v
+		}
+		id = params["id"]
+		if v, perr := goahttp.DecodePathParam(params["ids"], "ids", "matrix", true); perr != nil {
+			err = goa.MergeErrors(err, perr)
+		} else {
+			params["ids"] = v
+		}
+		{
+			idsRaw := params["ids"]
+			idsRawSlice := strings.Split(idsRaw, ",")
+			ids = make([]int, len(idsRawSlice))
+			for i, rv := range idsRawSlice {
+				v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ids", idsRaw, "array of integers"))
+				}
+				ids[i] = int(v)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodPathStylePayload(id, ids)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadPathArrayStringDecodeCode = `// DecodeMethodPathArrayStringRequest returns a decoder for requests sent to
 // the ServicePathArrayString MethodPathArrayString endpoint.
 func DecodeMethodPathArrayStringRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -3072,10 +3244,13 @@ func DecodeMethodPathPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, deco
 		if len(p) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("p", p, len(p), 1, true))
 		}
-		for _, e := range p {
-			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{"val"}))
-			}
+		for i, e := range p {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == "val") {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{"val"}))
+				}
+				return
+			}(), "p", i))
 		}
 		if err != nil {
 			return nil, err
@@ -3113,10 +3288,13 @@ func DecodeMethodPathPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decode
 		if len(p) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("p", p, len(p), 1, true))
 		}
-		for _, e := range p {
-			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{true}))
-			}
+		for i, e := range p {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("p[*]", e, []interface{}{true}))
+				}
+				return
+			}(), "p", i))
 		}
 		if err != nil {
 			return nil, err
@@ -3196,10 +3374,13 @@ func DecodeMethodHeaderArrayStringValidateRequest(mux goahttp.Muxer, decoder fun
 			err error
 		)
 		h = r.Header["H"]
-		for _, e := range h {
-			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{"val"}))
-			}
+		for i, e := range h {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == "val") {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{"val"}))
+				}
+				return
+			}(), "h", i))
 		}
 		if err != nil {
 			return nil, err
@@ -3286,8 +3467,11 @@ func DecodeMethodHeaderPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, de
 		if len(h) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("h", h, len(h), 1, true))
 		}
-		for _, e := range h {
-			err = goa.MergeErrors(err, goa.ValidatePattern("h[*]", e, "val"))
+		for i, e := range h {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				err = goa.MergeErrors(err, goa.ValidatePattern("h[*]", e, "val"))
+				return
+			}(), "h", i))
 		}
 		if err != nil {
 			return nil, err
@@ -3325,10 +3509,13 @@ func DecodeMethodHeaderPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, deco
 		if len(h) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("h", h, len(h), 1, true))
 		}
-		for _, e := range h {
-			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{true}))
-			}
+		for i, e := range h {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("h[*]", e, []interface{}{true}))
+				}
+				return
+			}(), "h", i))
 		}
 		if err != nil {
 			return nil, err
@@ -3625,9 +3812,10 @@ func DecodeMethodBodyStringRequest(mux goahttp.Muxer, decoder func(*http.Request
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		payload := NewMethodBodyStringPayload(&body)
 
@@ -3673,9 +3861,10 @@ func DecodeMethodBodyUserRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		payload := NewMethodBodyUserPayloadType(&body)
 
@@ -3777,9 +3966,10 @@ func DecodeMethodBodyObjectRequest(mux goahttp.Muxer, decoder func(*http.Request
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		payload := NewMethodBodyObjectPayload(body)
 
@@ -3801,9 +3991,10 @@ func DecodeMethodBodyObjectValidateRequest(mux goahttp.Muxer, decoder func(*http
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		payload := NewMethodBodyObjectValidatePayload(body)
 
@@ -3823,9 +4014,10 @@ func DecodeMethodBodyUnionRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyUnionRequestBody(&body)
 		if err != nil {
@@ -3875,9 +4067,10 @@ func DecodeMethodBodyUnionUserRequest(mux goahttp.Muxer, decoder func(*http.Requ
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyUnionUserRequestBody(&body)
 		if err != nil {
@@ -3927,9 +4120,10 @@ func DecodeMethodBodyArrayStringRequest(mux goahttp.Muxer, decoder func(*http.Re
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		payload := NewMethodBodyArrayStringPayload(&body)
 
@@ -3950,9 +4144,10 @@ func DecodeMethodBodyArrayStringValidateRequest(mux goahttp.Muxer, decoder func(
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyArrayStringValidateRequestBody(&body)
 		if err != nil {
@@ -3976,9 +4171,10 @@ func DecodeMethodBodyArrayUserRequest(mux goahttp.Muxer, decoder func(*http.Requ
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyArrayUserRequestBody(&body)
 		if err != nil {
@@ -4002,9 +4198,10 @@ func DecodeMethodBodyArrayUserValidateRequest(mux goahttp.Muxer, decoder func(*h
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyArrayUserValidateRequestBody(&body)
 		if err != nil {
@@ -4028,9 +4225,10 @@ func DecodeMethodBodyMapStringRequest(mux goahttp.Muxer, decoder func(*http.Requ
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		payload := NewMethodBodyMapStringPayload(&body)
 
@@ -4050,9 +4248,10 @@ func DecodeMethodBodyMapStringValidateRequest(mux goahttp.Muxer, decoder func(*h
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyMapStringValidateRequestBody(&body)
 		if err != nil {
@@ -4076,9 +4275,10 @@ func DecodeMethodBodyMapUserRequest(mux goahttp.Muxer, decoder func(*http.Reques
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyMapUserRequestBody(&body)
 		if err != nil {
@@ -4102,9 +4302,10 @@ func DecodeMethodBodyMapUserValidateRequest(mux goahttp.Muxer, decoder func(*htt
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodBodyMapUserValidateRequestBody(&body)
 		if err != nil {
@@ -4194,10 +4395,13 @@ func DecodeMethodBodyPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, deco
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
-			if !(e == "val") {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{"val"}))
-			}
+		for i, e := range body {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == "val") {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{"val"}))
+				}
+				return
+			}(), "body", i))
 		}
 		if err != nil {
 			return nil, err
@@ -4228,10 +4432,13 @@ func DecodeMethodBodyPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
-			if !(e == true) {
-				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{true}))
-			}
+		for i, e := range body {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if !(e == true) {
+					err = goa.MergeErrors(err, goa.InvalidEnumValueError("body[*]", e, []interface{}{true}))
+				}
+				return
+			}(), "body", i))
 		}
 		if err != nil {
 			return nil, err
@@ -4262,12 +4469,15 @@ func DecodeMethodBodyPrimitiveArrayUserValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
-			if e != nil {
-				if err2 := ValidatePayloadTypeRequestBody(e); err2 != nil {
-					err = goa.MergeErrors(err, err2)
+		for i, e := range body {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e != nil {
+					if err2 := ValidatePayloadTypeRequestBody(e); err2 != nil {
+						err = goa.MergeErrors(err, goa.NestErrors(err2, "body[*]"))
+					}
 				}
-			}
+				return
+			}(), "body", i))
 		}
 		if err != nil {
 			return nil, err
@@ -4346,8 +4556,11 @@ func DecodeMethodBodyPrimitiveArrayUserValidateRequest(mux goahttp.Muxer, decode
 		if len(body) < 1 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("body", body, len(body), 1, true))
 		}
-		for _, e := range body {
-			err = goa.MergeErrors(err, goa.ValidatePattern("body[*]", e, "pattern"))
+		for i, e := range body {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				err = goa.MergeErrors(err, goa.ValidatePattern("body[*]", e, "pattern"))
+				return
+			}(), "body", i))
 		}
 		if err != nil {
 			return nil, err
@@ -4370,9 +4583,10 @@ func DecodeMethodBodyQueryObjectRequest(mux goahttp.Muxer, decoder func(*http.Re
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 
 		var (
@@ -4439,9 +4653,10 @@ func DecodeMethodBodyQueryUserRequest(mux goahttp.Muxer, decoder func(*http.Requ
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 
 		var (
@@ -4507,9 +4722,10 @@ func DecodeMethodBodyPathObjectRequest(mux goahttp.Muxer, decoder func(*http.Req
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 
 		var (
@@ -4574,9 +4790,10 @@ func DecodeMethodBodyPathUserRequest(mux goahttp.Muxer, decoder func(*http.Reque
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 
 		var (
@@ -4640,9 +4857,10 @@ func DecodeMethodBodyQueryPathObjectRequest(mux goahttp.Muxer, decoder func(*htt
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 
 		var (
@@ -4718,9 +4936,10 @@ func DecodeMethodBodyQueryPathUserRequest(mux goahttp.Muxer, decoder func(*http.
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 
 		var (
@@ -4883,9 +5102,10 @@ func DecodeMethodMapQueryObjectRequest(mux goahttp.Muxer, decoder func(*http.Req
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 		err = ValidateMethodMapQueryObjectRequestBody(&body)
 		if err != nil {
@@ -5002,9 +5222,10 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		err = decoder(r).Decode(&body)
 		if err != nil {
 			if err == io.EOF {
-				return nil, goa.MissingPayloadError()
+				err = nil
+			} else {
+				return nil, goa.DecodePayloadError(err.Error())
 			}
-			return nil, goa.DecodePayloadError(err.Error())
 		}
 
 		var (
@@ -5039,12 +5260,13 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			optionalButRequiredParamRaw := r.URL.Query().Get("optional_but_required_param")
 			if optionalButRequiredParamRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("optional_but_required_param", "query string"))
+			} else {
+				v, err2 := strconv.ParseFloat(optionalButRequiredParamRaw, 32)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("optionalButRequiredParam", optionalButRequiredParamRaw, "float"))
+				}
+				optionalButRequiredParam = float32(v)
 			}
-			v, err2 := strconv.ParseFloat(optionalButRequiredParamRaw, 32)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("optionalButRequiredParam", optionalButRequiredParamRaw, "float"))
-			}
-			optionalButRequiredParam = float32(v)
 		}
 		required = r.Header.Get("required")
 		if required == "" {
@@ -5245,10 +5467,13 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		if len(array) < 3 {
 			err = goa.MergeErrors(err, goa.InvalidLengthError("array", array, len(array), 3, true))
 		}
-		for _, e := range array {
-			if e < 10 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 10, true))
-			}
+		for i, e := range array {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 10 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 10, true))
+				}
+				return
+			}(), "array", i))
 		}
 		if err != nil {
 			return nil, err
@@ -5385,10 +5610,13 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 				}
 			}
 		}
-		for _, e := range array {
-			if e < 10 {
-				err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 10, true))
-			}
+		for i, e := range array {
+			err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+				if e < 10 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 10, true))
+				}
+				return
+			}(), "array", i))
 		}
 		if err != nil {
 			return nil, err
@@ -5497,3 +5725,165 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 	}
 }
 `
+
+var PayloadBodyJSONNumberValidateDecodeCode = `// DecodeMethodBodyJSONNumberValidateRequest returns a decoder for requests
+// sent to the ServiceBodyJSONNumberValidate MethodBodyJSONNumberValidate
+// endpoint.
+func DecodeMethodBodyJSONNumberValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyJSONNumberValidateRequestBody
+			err  error
+		)
+		dec := decoder(r)
+		if nd, ok := dec.(interface{ UseNumber() }); ok {
+			nd.UseNumber()
+		}
+		err = dec.Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyJSONNumberValidateRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodBodyJSONNumberValidatePayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryDeepObjectDecodeCode = `// DecodeMethodQueryDeepObjectRequest returns a decoder for requests sent to
+// the ServiceQueryDeepObject MethodQueryDeepObject endpoint.
+func DecodeMethodQueryDeepObjectRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			filter *servicequerydeepobject.Filter
+			q      *string
+			err    error
+		)
+		if goahttp.HasDeepObjectParam(r.URL.Query(), "filter") {
+			var (
+				filterName   string
+				filterMinAge *int
+				filterTags   []string
+			)
+			filterName = r.URL.Query().Get("filter[name]")
+			if filterName == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("filter[name]", "query string"))
+			}
+			{
+				filterMinAgeRaw := r.URL.Query().Get("filter[min_age]")
+				if filterMinAgeRaw != "" {
+					v, err2 := strconv.ParseInt(filterMinAgeRaw, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("filter[min_age]", filterMinAgeRaw, "integer"))
+					}
+					pv := int(v)
+					filterMinAge = &pv
+				}
+			}
+			if filterMinAge != nil {
+				if *filterMinAge < 0 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("filter[min_age]", *filterMinAge, 0, true))
+				}
+			}
+			filterTags = r.URL.Query()["filter[tags]"]
+			filter = &servicequerydeepobject.Filter{}
+			filter.Name = filterName
+			filter.MinAge = filterMinAge
+			filter.Tags = filterTags
+		}
+		qRaw := r.URL.Query().Get("q")
+		if qRaw != "" {
+			q = &qRaw
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryDeepObjectPayload(filter, q)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryDeepObjectTypesDecodeCode = `// DecodeMethodQueryDeepObjectTypesRequest returns a decoder for requests sent
+// to the ServiceQueryDeepObjectTypes MethodQueryDeepObjectTypes endpoint.
+func DecodeMethodQueryDeepObjectTypesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			filter *servicequerydeepobjecttypes.Filter
+			err    error
+		)
+		if goahttp.HasDeepObjectParam(r.URL.Query(), "filter") {
+			var (
+				filterAge int
+				filterIds []uint
+			)
+			{
+				filterAgeRaw := r.URL.Query().Get("filter[age]")
+				if filterAgeRaw == "" {
+					err = goa.MergeErrors(err, goa.MissingFieldError("filter[age]", "query string"))
+				} else {
+					v, err2 := strconv.ParseInt(filterAgeRaw, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("filter[age]", filterAgeRaw, "integer"))
+					}
+					filterAge = int(v)
+				}
+			}
+			{
+				filterIdsRaw := r.URL.Query()["filter[ids]"]
+				if filterIdsRaw != nil {
+					filterIds = make([]uint, len(filterIdsRaw))
+					for i, rv := range filterIdsRaw {
+						v, err2 := strconv.ParseUint(rv, 10, strconv.IntSize)
+						if err2 != nil {
+							err = goa.MergeErrors(err, goa.InvalidFieldTypeError("filter[ids]", filterIdsRaw, "array of unsigned integers"))
+						}
+						filterIds[i] = uint(v)
+					}
+				}
+			}
+			filter = &servicequerydeepobjecttypes.Filter{}
+			filter.Age = filterAge
+			filter.Ids = filterIds
+		} else {
+			err = goa.MergeErrors(err, goa.MissingFieldError("filter", "query string"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryDeepObjectTypesPayload(filter)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryAllowEmptyValueDecodeCode = `// DecodeMethodQueryAllowEmptyValueRequest returns a decoder for requests sent
+// to the ServiceQueryAllowEmptyValue MethodQueryAllowEmptyValue endpoint.
+func DecodeMethodQueryAllowEmptyValueRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   string
+			err error
+		)
+		q = r.URL.Query().Get("q")
+		if !r.URL.Query().Has("q") {
+			err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryAllowEmptyValuePayload(q)
+
+		return payload, nil
+	}
+}
+`
@@ -320,6 +320,25 @@ var PayloadQueryStringValidateDSL = func() {
 	})
 }
 
+var PayloadQueryMissingParamErrorDSL = func() {
+	Service("ServiceQueryMissingParamError", func() {
+		Method("MethodQueryMissingParamError", func() {
+			Payload(func() {
+				Attribute("q", String)
+				Attribute("limit", Int)
+				Required("q", "limit")
+			})
+			Error("bad_request")
+			HTTP(func() {
+				GET("/")
+				Param("q")
+				Param("limit")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
+
 var PayloadQueryStringNotRequiredValidateDSL = func() {
 	Service("ServiceQueryStringNotRequiredValidate", func() {
 		Method("MethodQueryStringNotRequiredValidate", func() {
@@ -1423,6 +1442,20 @@ var PayloadPathStringDSL = func() {
 	})
 }
 
+var PayloadPathStringIdempotentDSL = func() {
+	Service("ServicePathStringIdempotent", func() {
+		Method("MethodPathStringIdempotent", func() {
+			Payload(func() {
+				Attribute("p", String)
+			})
+			Idempotent(true)
+			HTTP(func() {
+				POST("/{p}")
+			})
+		})
+	})
+}
+
 var PayloadPathStringValidateDSL = func() {
 	Service("ServicePathStringValidate", func() {
 		Method("MethodPathStringValidate", func() {
@@ -1484,6 +1517,92 @@ var PayloadPathArrayStringDSL = func() {
 	})
 }
 
+var PayloadPathStyleDSL = func() {
+	Service("ServicePathStyle", func() {
+		Method("MethodPathStyle", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("ids", ArrayOf(Int))
+			})
+			HTTP(func() {
+				GET("/{id}/{ids}")
+				Param("id", func() {
+					Style("label")
+				})
+				Param("ids", func() {
+					Style("matrix")
+					Explode()
+				})
+			})
+		})
+	})
+}
+
+var PayloadQueryDeepObjectDSL = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("name", String)
+		Attribute("min_age", Int, func() {
+			Minimum(0)
+		})
+		Attribute("tags", ArrayOf(String))
+		Required("name")
+	})
+	Service("ServiceQueryDeepObject", func() {
+		Method("MethodQueryDeepObject", func() {
+			Payload(func() {
+				Attribute("filter", Filter)
+				Attribute("q", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter", func() {
+					Style("deepObject")
+				})
+				Param("q")
+			})
+		})
+	})
+}
+
+var PayloadQueryDeepObjectTypesDSL = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("age", Int)
+		Attribute("ids", ArrayOf(UInt))
+		Required("age")
+	})
+	Service("ServiceQueryDeepObjectTypes", func() {
+		Method("MethodQueryDeepObjectTypes", func() {
+			Payload(func() {
+				Attribute("filter", Filter)
+				Required("filter")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter", func() {
+					Style("deepObject")
+				})
+			})
+		})
+	})
+}
+
+var PayloadQueryAllowEmptyValueDSL = func() {
+	Service("ServiceQueryAllowEmptyValue", func() {
+		Method("MethodQueryAllowEmptyValue", func() {
+			Payload(func() {
+				Attribute("q", String)
+				Required("q")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					AllowEmptyValue()
+				})
+			})
+		})
+	})
+}
+
 var PayloadPathArrayStringValidateDSL = func() {
 	Service("ServicePathArrayStringValidate", func() {
 		Method("MethodPathArrayStringValidate", func() {
@@ -2039,6 +2158,24 @@ var PayloadBodyObjectDSL = func() {
 	})
 }
 
+var PayloadBodyFormDSL = func() {
+	Service("ServiceBodyForm", func() {
+		Method("MethodBodyForm", func() {
+			Payload(func() {
+				Attribute("username", String)
+				Attribute("scopes", ArrayOf(String))
+				Required("username")
+			})
+			HTTP(func() {
+				POST("/")
+				Body(func() {
+					ContentType("application/x-www-form-urlencoded")
+				})
+			})
+		})
+	})
+}
+
 var PayloadBodyObjectValidateDSL = func() {
 	Service("ServiceBodyObjectValidate", func() {
 		Method("MethodBodyObjectValidate", func() {
@@ -2074,6 +2211,39 @@ var PayloadBodyUnionDSL = func() {
 	})
 }
 
+var PayloadBodyDiscriminatedUnionDSL = func() {
+	var Pet = Type("Pet", func() {
+		Attribute("kind", String)
+		Required("kind")
+		Discriminator("kind", func() {
+			Map("dog", "Dog")
+			Map("cat", "Cat")
+		})
+	})
+	var Dog = Type("Dog", func() {
+		Extend(Pet)
+		Attribute("barks", Boolean)
+	})
+	var Cat = Type("Cat", func() {
+		Extend(Pet)
+		Attribute("lives", Int)
+	})
+	var Union = Type("Union", func() {
+		OneOf("Values", func() {
+			Attribute("Dog", Dog)
+			Attribute("Cat", Cat)
+		})
+	})
+	Service("ServiceBodyDiscriminatedUnion", func() {
+		Method("MethodBodyDiscriminatedUnion", func() {
+			Payload(Union)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var PayloadBodyUnionValidateDSL = func() {
 	var UnionValidate = Type("UnionValidate", func() {
 		OneOf("Values", func() {
@@ -2481,6 +2651,27 @@ var PayloadBodyQueryObjectValidateDSL = func() {
 	})
 }
 
+var PayloadBodyJSONNumberValidateDSL = func() {
+	Service("ServiceBodyJSONNumberValidate", func() {
+		Method("MethodBodyJSONNumberValidate", func() {
+			Payload(func() {
+				Attribute("amount", Float64, func() {
+					Meta("struct:field:type", "json.Number", "encoding/json")
+					Minimum(0)
+				})
+				Attribute("fee", Float64, func() {
+					Meta("struct:field:type", "json.Number", "encoding/json")
+					Maximum(100)
+				})
+				Required("amount")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var PayloadBodyQueryUserDSL = func() {
 	var PayloadType = Type("PayloadType", func() {
 		Attribute("a", String)
@@ -2979,6 +3170,44 @@ var PayloadMultipartUserTypeDSL = func() {
 	})
 }
 
+var PayloadMultipartNestedDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("street", String)
+		Attribute("zip", UInt32)
+		Attribute("proof", Bytes, func() {
+			Meta("multipart:content-type", "application/pdf", "image/*")
+		})
+		Required("street")
+	})
+	var Person = Type("Person", func() {
+		Attribute("name", String)
+		Attribute("age", Int32, func() {
+			Default(18)
+		})
+		Attribute("addresses", ArrayOf(Address))
+	})
+	Service("ServiceMultipartNested", func() {
+		Method("MethodMultipartNested", func() {
+			Meta("multipart:decode", "true")
+			Meta("multipart:max-depth", "3")
+			Meta("multipart:max-size", "1048576")
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("title", String)
+				Attribute("tags", ArrayOf(String))
+				Attribute("score", Float64)
+				Attribute("owner", Person)
+				Attribute("home", Address)
+				Required("title", "owner")
+			})
+			HTTP(func() {
+				POST("/{id}")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
 var PayloadMultipartArrayTypeDSL = func() {
 	var PayloadType = Type("PayloadType", func() {
 		Attribute("a", String, func() {
@@ -3380,3 +3609,52 @@ var QueryArrayNestedAliasValidateDSL = func() {
 		})
 	})
 }
+
+var PayloadAliasesDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("zip_code", String, func() {
+			Alias("zip")
+		})
+	})
+	Service("ServiceAliases", func() {
+		Method("MethodAliases", func() {
+			Payload(func() {
+				Attribute("user_name", String, func() {
+					Alias("username", "userName")
+				})
+				Attribute("address", Address)
+				Required("user_name")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PayloadNullableDSL = func() {
+	var Settings = Type("Settings", func() {
+		Attribute("locale", String, func() {
+			Nullable()
+		})
+	})
+	Service("ServiceNullable", func() {
+		Method("MethodNullable", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("nickname", String, func() {
+					Nullable()
+				})
+				Attribute("settings", Settings)
+			})
+			Result(func() {
+				Attribute("age", Int, func() {
+					Nullable()
+				})
+			})
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
@@ -2613,3 +2613,48 @@ func EncodeMethodARequest(encoder func(*http.Request) goahttp.Encoder) func(*htt
 	}
 }
 `
+
+var PayloadQueryDeepObjectEncodeCode = `// EncodeMethodQueryDeepObjectRequest returns an encoder for requests sent to
+// the ServiceQueryDeepObject MethodQueryDeepObject server.
+func EncodeMethodQueryDeepObjectRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequerydeepobject.MethodQueryDeepObjectPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryDeepObject", "MethodQueryDeepObject", "*servicequerydeepobject.MethodQueryDeepObjectPayload", v)
+		}
+		values := req.URL.Query()
+		if p.Filter != nil {
+			values.Add("filter[name]", p.Filter.Name)
+			if p.Filter.MinAge != nil {
+				values.Add("filter[min_age]", fmt.Sprintf("%v", *p.Filter.MinAge))
+			}
+			for _, value := range p.Filter.Tags {
+				values.Add("filter[tags]", value)
+			}
+		}
+		if p.Q != nil {
+			values.Add("q", *p.Q)
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
+var PayloadBodyFormEncodeCode = `// EncodeMethodBodyFormRequest returns an encoder for requests sent to the
+// ServiceBodyForm MethodBodyForm server.
+func EncodeMethodBodyFormRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicebodyform.MethodBodyFormPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceBodyForm", "MethodBodyForm", "*servicebodyform.MethodBodyFormPayload", v)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		body := NewMethodBodyFormRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("ServiceBodyForm", "MethodBodyForm", err)
+		}
+		return nil
+	}
+}
+`
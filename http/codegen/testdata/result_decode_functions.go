@@ -579,10 +579,13 @@ func DecodeMethodAResponse(decoder func(*http.Response) goahttp.Decoder, restore
 					}
 				}
 			}
-			for _, e := range array {
-				if e < 5 {
-					err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 5, true))
-				}
+			for i, e := range array {
+				err = goa.MergeErrors(err, goa.ElemErrors(func() (err error) {
+					if e < 5 {
+						err = goa.MergeErrors(err, goa.InvalidRangeError("array[*]", e, 5, true))
+					}
+					return
+				}(), "array", i))
 			}
 			if err != nil {
 				return nil, goahttp.ErrValidationError("ServiceHeaderArrayValidateResponse", "MethodA", err)
@@ -1151,6 +1151,44 @@ var ExplicitContentTypeResponseDSL = func() {
 	})
 }
 
+var MultipleContentTypesResponseDSL = func() {
+	var ResultType = ResultType("ResultType", func() {
+		Attribute("a", String)
+		Attribute("b", String)
+	})
+	Service("ServiceMultipleContentTypesResponse", func() {
+		Method("MethodMultipleContentTypesResponse", func() {
+			Result(ResultType)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					ContentType("application/json")
+					ContentType("application/xml")
+				})
+			})
+		})
+	})
+}
+
+var RedirectLocationFromResponseDSL = func() {
+	Service("ServiceRedirectLocationFromResponse", func() {
+		Method("MethodRedirectLocationFromResponse", func() {
+			Result(func() {
+				Attribute("download_url", String)
+				Attribute("size", Int)
+				Required("download_url")
+			})
+			HTTP(func() {
+				GET("/")
+				RedirectFrom(func() {
+					LocationFrom("download_url")
+					Code(StatusTemporaryRedirect)
+				})
+			})
+		})
+	})
+}
+
 var ResultBodyArrayStringDSL = func() {
 	Service("ServiceBodyArrayString", func() {
 		Method("MethodBodyArrayString", func() {
@@ -1400,6 +1438,30 @@ var EmptyServerResponseDSL = func() {
 	})
 }
 
+var EmptyResultAcceptedResponseDSL = func() {
+	Service("ServiceEmptyResultAcceptedResponse", func() {
+		Method("MethodEmptyResultAcceptedResponse", func() {
+			HTTP(func() {
+				POST("/")
+				Response(StatusAccepted)
+			})
+		})
+	})
+}
+
+var EmptyResultNoStatusResponseDSL = func() {
+	Service("ServiceEmptyResultNoStatusResponse", func() {
+		Method("MethodEmptyResultNoStatusResponse", func() {
+			HTTP(func() {
+				POST("/")
+				Response(func() {
+					Description("Response without explicit status code")
+				})
+			})
+		})
+	})
+}
+
 var EmptyServerResponseWithTagsDSL = func() {
 	Service("ServiceEmptyServerResponseWithTags", func() {
 		Method("MethodEmptyServerResponseWithTags", func() {
@@ -1596,3 +1658,151 @@ var ValidateErrorResponseTypeDSL = func() {
 		})
 	})
 }
+
+var ResultTypedResponsesDSL = func() {
+	var Full = Type("Full", func() {
+		Attribute("id", String)
+		Required("id")
+	})
+	var Job = Type("Job", func() {
+		Attribute("href", String)
+		Required("href")
+	})
+	Service("ServiceTypedResponses", func() {
+		Method("MethodTypedResponses", func() {
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK, Full)
+				Response(StatusAccepted, Job, func() {
+					Description("The job is still running.")
+				})
+				Response(StatusNoContent, Empty)
+			})
+		})
+	})
+}
+
+var ResultCacheDSL = func() {
+	Service("ServiceCache", func() {
+		Method("MethodCache", func() {
+			Result(func() {
+				Attribute("b", String)
+			})
+			Cache(func() {
+				MaxAge("5m")
+				Public()
+				MustRevalidate()
+				Vary("Accept-Language", "Accept-Encoding")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ResultCacheSecuredDSL = func() {
+	var Basic = BasicAuthSecurity("basic")
+	Service("ServiceCacheSecured", func() {
+		Method("MethodCacheSecured", func() {
+			Security(Basic)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Cache(func() {
+					MaxAge("60s")
+					Vary("Authorization")
+				})
+			})
+		})
+	})
+}
+
+var ResultLongRunningDSL = func() {
+	var Job = Type("Job", func() {
+		Attribute("id", String)
+		Attribute("tenant", Int)
+		Attribute("status", String)
+		Required("id", "tenant")
+	})
+	Service("ServiceLongRunning", func() {
+		Method("MethodLongRunning", func() {
+			LongRunning(func() {
+				JobType(Job)
+				PollMethod("MethodPoll")
+			})
+			HTTP(func() {
+				POST("/jobs")
+			})
+		})
+		Method("MethodPoll", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("tenant", Int)
+				Required("id", "tenant")
+			})
+			Result(Job)
+			HTTP(func() {
+				GET("/tenants/{tenant}/jobs/{id}")
+			})
+		})
+	})
+}
+
+var ResultLongRunningViewedDSL = func() {
+	var Job = ResultType("application/vnd.job", func() {
+		TypeName("Job")
+		Attribute("id", String)
+		Attribute("status", String)
+		Required("id")
+	})
+	Service("ServiceLongRunningViewed", func() {
+		Method("MethodLongRunningViewed", func() {
+			LongRunning(func() {
+				JobType(Job)
+				PollMethod("MethodPoll")
+			})
+			HTTP(func() {
+				POST("/jobs")
+			})
+		})
+		Method("MethodPoll", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Required("id")
+			})
+			Result(Job)
+			HTTP(func() {
+				GET("/jobs/{id}")
+			})
+		})
+	})
+}
+
+var ResultExplicitBodyArrayDSL = func() {
+	var Entry = Type("Entry", func() {
+		Attribute("id", Int)
+		Attribute("name", String)
+		Required("id")
+	})
+	Service("ServiceExplicitBodyArray", func() {
+		Method("MethodExplicitBodyArray", func() {
+			Result(func() {
+				Attribute("items", ArrayOf(Entry))
+				Attribute("total", Int)
+				Required("items", "total")
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Header("total:X-Total")
+					Body("items")
+				})
+			})
+		})
+	})
+}
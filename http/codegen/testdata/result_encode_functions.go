@@ -814,6 +814,42 @@ func EncodeMethodExplicitContentTypeResponseResponse(encoder func(context.Contex
 }
 `
 
+var MultipleContentTypesResponseEncodeCode = `// EncodeMethodMultipleContentTypesResponseResponse returns an encoder for
+// responses returned by the ServiceMultipleContentTypesResponse
+// MethodMultipleContentTypesResponse endpoint.
+func EncodeMethodMultipleContentTypesResponseResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicemultiplecontenttypesresponseviews.Resulttype)
+		ct, ok := goahttp.NegotiateContentType(ctx, "application/json", "application/xml")
+		if !ok {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return nil
+		}
+		ctx = context.WithValue(ctx, goahttp.ContentTypeKey, ct)
+		enc := encoder(ctx, w)
+		body := NewMethodMultipleContentTypesResponseResponseBody(res.Projected)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var RedirectLocationFromResponseEncodeCode = `// EncodeMethodRedirectLocationFromResponseResponse returns an encoder for
+// responses returned by the ServiceRedirectLocationFromResponse
+// MethodRedirectLocationFromResponse endpoint.
+func EncodeMethodRedirectLocationFromResponseResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res, _ := v.(*serviceredirectlocationfromresponse.MethodRedirectLocationFromResponseResult)
+		if res.DownloadURL == "" {
+			return fmt.Errorf("cannot redirect: result attribute %q is empty", "download_url")
+		}
+		w.Header().Set("Location", res.DownloadURL)
+		w.WriteHeader(http.StatusTemporaryRedirect)
+		return nil
+	}
+}
+`
+
 var ResultBodyPrimitiveStringEncodeCode = `// EncodeMethodBodyPrimitiveStringResponse returns an encoder for responses
 // returned by the ServiceBodyPrimitiveString MethodBodyPrimitiveString
 // endpoint.
@@ -1020,6 +1056,28 @@ func EncodeMethodEmptyServerResponseResponse(encoder func(context.Context, http.
 }
 `
 
+var EmptyResultAcceptedResponseEncodeCode = `// EncodeMethodEmptyResultAcceptedResponseResponse returns an encoder for
+// responses returned by the ServiceEmptyResultAcceptedResponse
+// MethodEmptyResultAcceptedResponse endpoint.
+func EncodeMethodEmptyResultAcceptedResponseResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.WriteHeader(http.StatusAccepted)
+		return nil
+	}
+}
+`
+
+var EmptyResultNoStatusResponseEncodeCode = `// EncodeMethodEmptyResultNoStatusResponseResponse returns an encoder for
+// responses returned by the ServiceEmptyResultNoStatusResponse
+// MethodEmptyResultNoStatusResponse endpoint.
+func EncodeMethodEmptyResultNoStatusResponseResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+`
+
 var EmptyServerResponseWithTagsEncodeCode = `// EncodeMethodEmptyServerResponseWithTagsResponse returns an encoder for
 // responses returned by the ServiceEmptyServerResponseWithTags
 // MethodEmptyServerResponseWithTags endpoint.
@@ -1063,3 +1121,89 @@ func EncodeMethodResultWithEmbeddedCustomPkgTypeDSLResponse(encoder func(context
 	}
 }
 `
+
+var ResultTypedResponsesEncodeCode = `// EncodeMethodTypedResponsesResponse returns an encoder for responses returned
+// by the ServiceTypedResponses MethodTypedResponses endpoint.
+func EncodeMethodTypedResponsesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res, _ := v.(*servicetypedresponses.MethodTypedResponsesResult)
+		if res.Status == "ok" {
+			enc := encoder(ctx, w)
+			body := NewMethodTypedResponsesOKResponseBody(res)
+			w.WriteHeader(http.StatusOK)
+			return enc.Encode(body)
+		}
+		if res.Status == "accepted" {
+			enc := encoder(ctx, w)
+			body := NewMethodTypedResponsesAcceptedResponseBody(res)
+			w.WriteHeader(http.StatusAccepted)
+			return enc.Encode(body)
+		}
+		if res.Status == "no_content" {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+		return fmt.Errorf("invalid Status value %q", res.Status)
+	}
+}
+`
+
+var ResultCacheEncodeCode = `// EncodeMethodCacheResponse returns an encoder for responses returned by the
+// ServiceCache MethodCache endpoint.
+func EncodeMethodCacheResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.Header().Set("Cache-Control", "public, max-age=300, must-revalidate")
+		w.Header().Set("Vary", "Accept-Language, Accept-Encoding")
+		res, _ := v.(*servicecache.MethodCacheResult)
+		enc := encoder(ctx, w)
+		body := NewMethodCacheResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultCacheSecuredEncodeCode = `// EncodeMethodCacheSecuredResponse returns an encoder for responses returned
+// by the ServiceCacheSecured MethodCacheSecured endpoint.
+func EncodeMethodCacheSecuredResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.Header().Set("Cache-Control", "private, max-age=60")
+		w.Header().Set("Vary", "Authorization")
+		res, _ := v.(string)
+		enc := encoder(ctx, w)
+		body := res
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultLongRunningEncodeCode = `// EncodeMethodLongRunningResponse returns an encoder for responses returned by
+// the ServiceLongRunning MethodLongRunning endpoint.
+func EncodeMethodLongRunningResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res, _ := v.(*servicelongrunning.Job)
+		enc := encoder(ctx, w)
+		body := NewMethodLongRunningResponseBody(res)
+		w.Header().Set("Location", MethodPollServiceLongRunningPath(res.Tenant, res.ID))
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultLongRunningViewedEncodeCode = `// EncodeMethodLongRunningViewedResponse returns an encoder for responses
+// returned by the ServiceLongRunningViewed MethodLongRunningViewed endpoint.
+func EncodeMethodLongRunningViewedResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicelongrunningviewedviews.Job)
+		enc := encoder(ctx, w)
+		body := NewMethodLongRunningViewedResponseBody(res.Projected)
+		if res.Projected.ID != nil {
+			w.Header().Set("Location", MethodPollServiceLongRunningViewedPath(*res.Projected.ID))
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+`
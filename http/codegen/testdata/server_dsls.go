@@ -268,3 +268,463 @@ var ServerSimpleRoutingWithRedirectDSL = func() {
 		})
 	})
 }
+
+var ServerCORSDSL = func() {
+	API("test", func() {
+		CORS(func() {
+			Origin("https://app.example.com", "https://*.example.com")
+			Headers("Authorization")
+			Expose("X-Request-Id")
+			MaxAge(600)
+		})
+	})
+	Service("ServiceCORS", func() {
+		Method("MethodA", func() {
+			HTTP(func() {
+				GET("/resources")
+			})
+		})
+		Method("MethodB", func() {
+			HTTP(func() {
+				POST("/resources")
+			})
+		})
+		Method("MethodC", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				DELETE("/resources/{id}")
+			})
+		})
+	})
+}
+
+var ServerCORSServiceOverrideDSL = func() {
+	API("test", func() {
+		CORS(func() {
+			Origin("*")
+		})
+	})
+	Service("ServiceCORSOverride", func() {
+		CORS(func() {
+			Origin("https://admin.example.com")
+			Methods("get", "put")
+			Credentials()
+		})
+		Method("MethodA", func() {
+			HTTP(func() {
+				PUT("/admin")
+			})
+		})
+	})
+}
+
+var ServerCORSWildcardDSL = func() {
+	API("test", func() {
+		CORS(func() {
+			Origin("*")
+		})
+	})
+	Service("ServiceCORSWildcard", func() {
+		Method("MethodA", func() {
+			HTTP(func() {
+				GET("/resources")
+			})
+		})
+	})
+}
+
+var ServerOptionsDSL = func() {
+	API("test", func() {
+		Meta("http:options:generate", "true")
+	})
+	Service("ServiceOptions", func() {
+		Method("MethodA", func() {
+			HTTP(func() {
+				GET("/resources")
+			})
+		})
+		Method("MethodB", func() {
+			HTTP(func() {
+				POST("/resources")
+			})
+		})
+		Method("MethodC", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/resources/{id}")
+				DELETE("/resources/{id}")
+			})
+		})
+		Method("MethodD", func() {
+			HTTP(func() {
+				PUT("/settings")
+			})
+		})
+		Method("MethodE", func() {
+			HTTP(func() {
+				OPTIONS("/settings")
+			})
+		})
+	})
+}
+
+var ServerOptionsCORSDSL = func() {
+	API("test", func() {
+		Meta("http:options:generate", "true")
+		CORS(func() {
+			Origin("https://app.example.com")
+		})
+	})
+	Service("ServiceOptionsCORS", func() {
+		Method("MethodA", func() {
+			HTTP(func() {
+				GET("/resources")
+			})
+		})
+		Method("MethodB", func() {
+			HTTP(func() {
+				POST("/resources")
+			})
+		})
+	})
+}
+
+var ServerIdempotentDSL = func() {
+	Service("ServiceIdempotent", func() {
+		Method("MethodA", func() {
+			Payload(func() {
+				Attribute("amount", Int)
+			})
+			Idempotent(func() {
+				Header("X-Idempotency-Key")
+				TTL("90m")
+			})
+			HTTP(func() {
+				POST("/payments")
+			})
+		})
+		Method("MethodB", func() {
+			HTTP(func() {
+				GET("/payments")
+			})
+		})
+	})
+}
+
+var ServerWebhookSignatureDSL = func() {
+	Service("ServiceWebhookSignature", func() {
+		Method("MethodA", func() {
+			Payload(func() {
+				Attribute("event", String)
+			})
+			WebhookSignature(func() {
+				Header("X-Hub-Signature-256")
+			})
+			HTTP(func() {
+				POST("/webhooks")
+			})
+		})
+		Method("MethodB", func() {
+			HTTP(func() {
+				GET("/webhooks")
+			})
+		})
+	})
+}
+
+var ServerTraceDSL = func() {
+	Service("ServiceTrace", func() {
+		Method("MethodTrace", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("tenant_id", String)
+				Attribute("page", Int32)
+				Attribute("verbose", Boolean, func() {
+					Nullable()
+				})
+				Required("id")
+			})
+			Result(String)
+			Trace(func() {
+				Attribute("id")
+				Attribute("tenant_id")
+				Attribute("page")
+				Attribute("verbose")
+			})
+			HTTP(func() {
+				POST("/{tenant_id}/documents/{id}")
+				Param("page")
+			})
+		})
+	})
+}
+
+var ServerConditionalDSL = func() {
+	Service("ServiceConditional", func() {
+		Method("MethodConditional", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(func() {
+				Attribute("version", Int)
+				Attribute("name", String)
+			})
+			ConditionalRequests(func() {
+				ETagFrom("version")
+				WeakETag()
+			})
+			HTTP(func() {
+				GET("/documents/{id}")
+			})
+		})
+	})
+}
+
+var ServerSunsetDSL = func() {
+	Service("ServiceSunset", func() {
+		Method("MethodSunset", func() {
+			Sunset("2025-12-31")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerCipherDSL = func() {
+	var Account = ResultType("application/vnd.account", func() {
+		Attribute("id", String)
+		Attribute("iban", String, func() {
+			Meta("crypto:field", "true")
+		})
+	})
+	Service("ServiceCipher", func() {
+		Method("MethodCipher", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("ssn", String, func() {
+					Meta("crypto:field", "true")
+				})
+				Attribute("pin", String, func() {
+					Meta("crypto:field", "true")
+				})
+				Required("ssn")
+			})
+			Result(Account)
+			HTTP(func() {
+				POST("/accounts")
+			})
+		})
+		Method("MethodPlain", func() {
+			HTTP(func() {
+				GET("/accounts")
+			})
+		})
+	})
+}
+
+var ServerCipherResultDSL = func() {
+	Service("ServiceCipherResult", func() {
+		Method("MethodCipherResult", func() {
+			Result(func() {
+				Attribute("token", String, func() {
+					Meta("crypto:field", "true")
+				})
+				Required("token")
+			})
+			HTTP(func() {
+				GET("/token")
+			})
+		})
+	})
+}
+
+var ServerRequestIDDSL = func() {
+	API("RequestIDAPI", func() {
+		RequestID("X-Correlation-Id")
+	})
+	Service("ServiceRequestID", func() {
+		Method("MethodRequestID", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerGzipDSL = func() {
+	API("GzipAPI", func() {
+		Meta("http:compress", "gzip")
+	})
+	Service("ServiceGzip", func() {
+		Method("MethodGzip", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodNoGzip", func() {
+			Meta("http:compress", "none")
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServerSparseFieldsDSL = func() {
+	var User = Type("User", func() {
+		Attribute("id", Int)
+		Attribute("name", String)
+	})
+	Service("ServiceSparseFields", func() {
+		Method("MethodSparseFields", func() {
+			SparseFields()
+			Result(User)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ClientLogDSL = func() {
+	API("ClientLogAPI", func() {
+		Meta("log:client", "true")
+		Meta("log:client:body", "true")
+	})
+	var Card = Type("Card", func() {
+		Attribute("number", String, func() {
+			Meta("sensitive", "secret")
+		})
+		Attribute("holder", String)
+	})
+	Service("ServiceClientLog", func() {
+		Method("MethodClientLog", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("password", String, func() {
+					Meta("sensitive", "secret")
+				})
+				Attribute("cards", ArrayOf(Card))
+			})
+			Result(Card)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodClientLogStream", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+				SSE(func() {
+					Event("message")
+				})
+			})
+		})
+	})
+}
+
+var ServerPathPatternDSL = func() {
+	Service("ServicePathPattern", func() {
+		Method("MethodPathPattern", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("code", String)
+			})
+			HTTP(func() {
+				GET("/{id:[0-9]+}/{code:[a-z]{2}}")
+			})
+		})
+	})
+}
+
+var ServerVersionDSL = func() {
+	API("test", func() {
+		Version("2.3.1")
+	})
+	Service("ServiceVersion", func() {
+		Meta("http:version:path", "/version")
+		Method("MethodA", func() {
+			HTTP(func() {
+				GET("/resources")
+			})
+		})
+	})
+}
+
+var ServerMethodOverrideDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			MethodOverride("PATCH", "DELETE")
+		})
+	})
+	Service("ServiceMethodOverride", func() {
+		Method("MethodA", func() {
+			HTTP(func() {
+				PATCH("/resources")
+			})
+		})
+	})
+}
+
+var IntegrationDSL = func() {
+	var JWT = JWTSecurity("jwt")
+	var Sum = Type("Sum", func() {
+		Attribute("value", Int64)
+		Attribute("note", String)
+		Required("value")
+	})
+	Service("Calc", func() {
+		Error("div_by_zero")
+		Method("Divide", func() {
+			Payload(func() {
+				Attribute("a", Int)
+				Attribute("b", Int)
+				Required("a", "b")
+			})
+			Result(Int)
+			RequestExample("four by two", func() {
+				ExamplePayload(Val{"a": 4, "b": 2})
+				ExampleResult(2)
+			})
+			RequestExample("division by zero", func() {
+				ExamplePayload(Val{"a": 4, "b": 0})
+				ExampleError("div_by_zero")
+			})
+			HTTP(func() {
+				GET("/div/{a}/{b}")
+				Response("div_by_zero", StatusBadRequest)
+			})
+		})
+		Method("Add", func() {
+			Security(JWT)
+			Payload(func() {
+				Token("token", String)
+				Attribute("a", Int64)
+				Attribute("note", String)
+				Required("a")
+			})
+			Result(Sum)
+			RequestExample("with note", func() {
+				ExamplePayload(Val{"a": 1, "note": "hi"})
+				ExampleResult(Val{"value": 1, "note": "hi"})
+			})
+			HTTP(func() {
+				POST("/add")
+			})
+		})
+		Method("Watch", func() {
+			StreamingResult(Sum)
+			RequestExample("ignored", func() {})
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+	})
+}
@@ -273,3 +273,502 @@ func MountServerTrailingSlashRoutingHandler(mux goahttp.Muxer, h http.Handler) {
 	mux.Handle("GET", "/trailing/slash/", f)
 }
 `
+
+var ServerCORSConstructorCode = `// New instantiates HTTP handlers for all the ServiceCORS service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil.
+func New(
+	e *servicecors.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodA", "GET", "/resources"},
+			{"MethodB", "POST", "/resources"},
+			{"MethodC", "DELETE", "/resources/{id}"},
+			{"CORS", "OPTIONS", "/resources"},
+			{"CORS", "OPTIONS", "/resources/{id}"},
+		},
+		MethodA: NewMethodAHandler(e.MethodA, mux, decoder, encoder, errhandler, formatter),
+		MethodB: NewMethodBHandler(e.MethodB, mux, decoder, encoder, errhandler, formatter),
+		MethodC: NewMethodCHandler(e.MethodC, mux, decoder, encoder, errhandler, formatter),
+		CORS:    NewCORSHandler(),
+	}
+}
+`
+
+var ServerCORSMountCode = `// Mount configures the mux to serve the ServiceCORS endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodAHandler(mux, HandleCORS(h.MethodA))
+	MountMethodBHandler(mux, HandleCORS(h.MethodB))
+	MountMethodCHandler(mux, HandleCORS(h.MethodC))
+	MountCORSHandler(mux, h.CORS)
+}
+
+// Mount configures the mux to serve the ServiceCORS endpoints.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
+
+var ServerCORSHandlersCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORS(h)
+	mux.Handle("OPTIONS", "/resources", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/resources/{id}", h.ServeHTTP)
+}
+
+// NewCORSHandler creates a HTTP handler which returns a simple 204 response to
+// CORS preflight requests.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleCORS applies the CORS response headers to requests made by allowed
+// origins.
+func HandleCORS(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The response depends on the origin whether it is allowed or not.
+		w.Header().Add("Vary", "Origin")
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if goahttp.MatchOrigin(origin, "https://app.example.com") || goahttp.MatchOrigin(origin, "https://*.example.com") {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Expose-Headers", "X-Request-Id")
+			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+				// We are handling a preflight request
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE")
+				w.Header().Set("Access-Control-Allow-Headers", "Authorization")
+				w.Header().Set("Access-Control-Max-Age", "600")
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+`
+
+var ServerCORSServiceOverrideHandlersCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORS(h)
+	mux.Handle("OPTIONS", "/admin", h.ServeHTTP)
+}
+
+// NewCORSHandler creates a HTTP handler which returns a simple 204 response to
+// CORS preflight requests.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleCORS applies the CORS response headers to requests made by allowed
+// origins.
+func HandleCORS(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The response depends on the origin whether it is allowed or not.
+		w.Header().Add("Vary", "Origin")
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if goahttp.MatchOrigin(origin, "https://admin.example.com") {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+				// We are handling a preflight request
+				w.Header().Set("Access-Control-Allow-Methods", "GET, PUT")
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+`
+
+var ServerCORSWildcardHandlersCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORS(h)
+	mux.Handle("OPTIONS", "/resources", h.ServeHTTP)
+}
+
+// NewCORSHandler creates a HTTP handler which returns a simple 204 response to
+// CORS preflight requests.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleCORS applies the CORS response headers to requests made by allowed
+// origins.
+func HandleCORS(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if goahttp.MatchOrigin(origin, "*") {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+				// We are handling a preflight request
+				w.Header().Set("Access-Control-Allow-Methods", "GET")
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+`
+
+var ServerOptionsMountCode = `// Mount configures the mux to serve the ServiceOptions endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodAHandler(mux, h.MethodA)
+	MountMethodBHandler(mux, h.MethodB)
+	MountMethodCHandler(mux, h.MethodC)
+	MountMethodDHandler(mux, h.MethodD)
+	MountMethodEHandler(mux, h.MethodE)
+	MountOptionsHandler(mux, h.Options)
+}
+
+// Mount configures the mux to serve the ServiceOptions endpoints.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
+
+var ServerOptionsHandlersCode = `// MountOptionsHandler configures the mux to serve the OPTIONS requests made to
+// the service endpoints. The responses list the methods accepted by the
+// request path in the Allow header.
+func MountOptionsHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("OPTIONS", "/resources", goahttp.AllowMethods("GET, POST, OPTIONS", h))
+	mux.Handle("OPTIONS", "/resources/{id}", goahttp.AllowMethods("GET, DELETE, OPTIONS", h))
+}
+
+// NewOptionsHandler creates a HTTP handler which returns a simple 204 response
+// to OPTIONS requests.
+func NewOptionsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+`
+
+var ServerOptionsCORSHandlersCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = HandleCORS(h)
+	mux.Handle("OPTIONS", "/resources", goahttp.AllowMethods("GET, POST, OPTIONS", h))
+}
+
+// NewCORSHandler creates a HTTP handler which returns a simple 204 response to
+// CORS preflight requests.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleCORS applies the CORS response headers to requests made by allowed
+// origins.
+func HandleCORS(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The response depends on the origin whether it is allowed or not.
+		w.Header().Add("Vary", "Origin")
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if goahttp.MatchOrigin(origin, "https://app.example.com") {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != "" {
+				// We are handling a preflight request
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+`
+
+var ServerIdempotentConstructorCode = `// New instantiates HTTP handlers for all the ServiceIdempotent service
+// endpoints using the provided encoder and decoder. The handlers are mounted
+// on the given mux using the HTTP verb and path defined in the design.
+// errhandler is called whenever a response fails to be encoded. formatter is
+// used to format errors returned by the service methods prior to encoding.
+// Both errhandler and formatter are optional and can be nil. idempotency
+// records the responses replayed to requests that reuse an idempotency key.
+func New(
+	e *serviceidempotent.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	idempotency goahttp.IdempotencyStore,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodA", "POST", "/payments"},
+			{"MethodB", "GET", "/payments"},
+		},
+		MethodA: goahttp.Idempotent(idempotency, "ServiceIdempotent.MethodA", "X-Idempotency-Key", 90*time.Minute)(NewMethodAHandler(e.MethodA, mux, decoder, encoder, errhandler, formatter)),
+		MethodB: NewMethodBHandler(e.MethodB, mux, decoder, encoder, errhandler, formatter),
+	}
+}
+`
+
+var ServerWebhookSignatureConstructorCode = `// New instantiates HTTP handlers for all the ServiceWebhookSignature service
+// endpoints using the provided encoder and decoder. The handlers are mounted
+// on the given mux using the HTTP verb and path defined in the design.
+// errhandler is called whenever a response fails to be encoded. formatter is
+// used to format errors returned by the service methods prior to encoding.
+// Both errhandler and formatter are optional and can be nil. webhookSecret is
+// the secret used to verify the HMAC signatures of the requests, the requests
+// are rejected if it is empty.
+func New(
+	e *servicewebhooksignature.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	webhookSecret []byte,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodA", "POST", "/webhooks"},
+			{"MethodB", "GET", "/webhooks"},
+		},
+		MethodA: goahttp.VerifyWebhookSignature(webhookSecret, "X-Hub-Signature-256", "sha256")(NewMethodAHandler(e.MethodA, mux, decoder, encoder, errhandler, formatter)),
+		MethodB: NewMethodBHandler(e.MethodB, mux, decoder, encoder, errhandler, formatter),
+	}
+}
+`
+
+var ServerRequestIDConstructorCode = `// New instantiates HTTP handlers for all the ServiceRequestID service
+// endpoints using the provided encoder and decoder. The handlers are mounted
+// on the given mux using the HTTP verb and path defined in the design.
+// errhandler is called whenever a response fails to be encoded. formatter is
+// used to format errors returned by the service methods prior to encoding.
+// Both errhandler and formatter are optional and can be nil. The handlers are
+// wrapped with the middleware returned by RequestIDMiddleware.
+func New(
+	e *servicerequestid.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	s := &Server{
+		Mounts: []*MountPoint{
+			{"MethodRequestID", "GET", "/"},
+		},
+		MethodRequestID: NewMethodRequestIDHandler(e.MethodRequestID, mux, decoder, encoder, errhandler, formatter),
+	}
+	s.Use(RequestIDMiddleware())
+	return s
+}
+`
+
+var ServerRequestIDMiddlewareCode = `// RequestIDMiddleware returns a HTTP middleware that initializes the request
+// context with the request ID read from the "X-Correlation-Id" header or with
+// a new UUID if the request does not define the header.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return httpmdlwr.RequestID(
+		httpmdlwr.RequestIDHeaderOption("X-Correlation-Id"),
+		httpmdlwr.RequestIDFuncOption(middleware.UUID),
+	)
+}
+`
+
+var ServerMethodOverrideMiddlewareCode = `// MethodOverrideMiddleware returns a HTTP middleware that overrides the method
+// of POST requests with the value of the X-HTTP-Method-Override header if it
+// is one of PATCH, DELETE. The middleware must wrap the muxer so that the
+// request method is overridden before the request is routed.
+func MethodOverrideMiddleware() func(http.Handler) http.Handler {
+	return httpmdlwr.MethodOverride("PATCH", "DELETE")
+}
+`
+
+var ServerPathPatternCode = `// MountMethodPathPatternHandler configures the mux to serve the
+// "ServicePathPattern" service "MethodPathPattern" endpoint.
+func MountMethodPathPatternHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/{id}/{code}", goahttp.MatchPathPatterns(mux, map[string]string{"code": "[a-z]{2}", "id": "[0-9]+"}, f))
+}
+`
+
+var ServerCipherConstructorCode = `// New instantiates HTTP handlers for all the ServiceCipher service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil. cipher decrypts and encrypts the
+// payload and result fields flagged with the "crypto:field" meta.
+func New(
+	e *servicecipher.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+	cipher goahttp.Cipher,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodCipher", "POST", "/accounts"},
+			{"MethodPlain", "GET", "/accounts"},
+		},
+		MethodCipher: NewMethodCipherHandler(NewMethodCipherCipherEndpoint(e.MethodCipher, cipher), mux, decoder, encoder, errhandler, formatter),
+		MethodPlain:  NewMethodPlainHandler(e.MethodPlain, mux, decoder, encoder, errhandler, formatter),
+	}
+}
+`
+
+var ServerCipherEndpointCode = `// NewMethodCipherCipherEndpoint wraps the "ServiceCipher" service
+// "MethodCipher" endpoint to decrypt the payload fields and encrypt the result
+// fields flagged with the "crypto:field" meta using cipher.
+func NewMethodCipherCipherEndpoint(endpoint goa.Endpoint, cipher goahttp.Cipher) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*servicecipher.MethodCipherPayload)
+		if err := goahttp.DecryptField(ctx, cipher, "ssn", &p.Ssn); err != nil {
+			return nil, err
+		}
+		if err := goahttp.DecryptField(ctx, cipher, "pin", p.Pin); err != nil {
+			return nil, err
+		}
+		res, err := endpoint(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := res.(*servicecipherviews.Account)
+		if !ok || r == nil {
+			return res, nil
+		}
+		if err := goahttp.EncryptField(ctx, cipher, "iban", r.Projected.Iban); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+`
+
+var ServerCipherResultEndpointCode = `// NewMethodCipherResultCipherEndpoint wraps the "ServiceCipherResult" service
+// "MethodCipherResult" endpoint to decrypt the payload fields and encrypt the
+// result fields flagged with the "crypto:field" meta using cipher.
+func NewMethodCipherResultCipherEndpoint(endpoint goa.Endpoint, cipher goahttp.Cipher) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := endpoint(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := res.(*servicecipherresult.MethodCipherResultResult)
+		if !ok || r == nil {
+			return res, nil
+		}
+		if err := goahttp.EncryptField(ctx, cipher, "token", &r.Token); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+`
+
+var ServerVersionMountCode = `// Mount configures the mux to serve the ServiceVersion endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodAHandler(mux, h.MethodA)
+	MountVersionHandler(mux, h.Version)
+}
+
+// Mount configures the mux to serve the ServiceVersion endpoints.
+func (s *Server) Mount(mux goahttp.Muxer) {
+	Mount(mux, s)
+}
+`
+
+var ServerVersionHandlersCode = `// MountVersionHandler configures the mux to serve the API version.
+func MountVersionHandler(mux goahttp.Muxer, h http.Handler) {
+	mux.Handle("GET", "/version", h.ServeHTTP)
+}
+
+// NewVersionHandler creates a HTTP handler which writes the API version in a
+// JSON response.
+func NewVersionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{\"version\":\"2.3.1\"}"))
+	})
+}
+`
+
+var ServerGzipConstructorCode = `// New instantiates HTTP handlers for all the ServiceGzip service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil.
+func New(
+	e *servicegzip.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodGzip", "POST", "/"},
+			{"MethodNoGzip", "GET", "/"},
+		},
+		MethodGzip:   goahttp.Gzip(goahttp.GzipMinSize)(NewMethodGzipHandler(e.MethodGzip, mux, decoder, encoder, errhandler, formatter)),
+		MethodNoGzip: NewMethodNoGzipHandler(e.MethodNoGzip, mux, decoder, encoder, errhandler, formatter),
+	}
+}
+`
+
+var ServerSparseFieldsConstructorCode = `// New instantiates HTTP handlers for all the ServiceSparseFields service
+// endpoints using the provided encoder and decoder. The handlers are mounted
+// on the given mux using the HTTP verb and path defined in the design.
+// errhandler is called whenever a response fails to be encoded. formatter is
+// used to format errors returned by the service methods prior to encoding.
+// Both errhandler and formatter are optional and can be nil.
+func New(
+	e *servicesparsefields.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodSparseFields", "GET", "/"},
+		},
+		MethodSparseFields: goahttp.SparseFields("id", "name")(NewMethodSparseFieldsHandler(e.MethodSparseFields, mux, decoder, encoder, errhandler, formatter)),
+	}
+}
+`
@@ -2262,6 +2262,19 @@ func (s *BidirectionalStreamingMethodClientStream) Close() error {
 }
 `
 
+var BidirectionalStreamingClientStreamCloseAndRecvCode = `// CloseAndRecv stops sending messages to the "BidirectionalStreamingMethod"
+// endpoint websocket connection and reads the next instance of
+// "bidirectionalstreamingservice.UserType" from the connection.
+func (s *BidirectionalStreamingMethodClientStream) CloseAndRecv() (*bidirectionalstreamingservice.UserType, error) {
+	// Send a nil payload to the server implying end of message
+	if err := s.conn.WriteJSON(nil); err != nil {
+		var rv *bidirectionalstreamingservice.UserType
+		return rv, err
+	}
+	return s.Recv()
+}
+`
+
 var BidirectionalStreamingNoPayloadServerHandlerInitCode = `// NewBidirectionalStreamingNoPayloadMethodHandler creates a HTTP handler which
 // loads the HTTP request and calls the
 // "BidirectionalStreamingNoPayloadService" service
@@ -3324,3 +3337,433 @@ func (s *BidirectionalStreamingUserTypeMapMethodClientStream) Recv() (map[string
 	return res, nil
 }
 `
+
+var WebSocketStreamingResultServerStructCode = `// WebSocketStreamingResultMethodServerStream implements the
+// websocketstreamingresultservice.WebSocketStreamingResultMethodServerStream
+// interface.
+type WebSocketStreamingResultMethodServerStream struct {
+	once sync.Once
+	// upgrader is the websocket connection upgrader.
+	upgrader goahttp.Upgrader
+	// configurer is the websocket connection configurer.
+	configurer goahttp.ConnConfigureFunc
+	// cancel is the context cancellation function which cancels the request
+	// context when invoked.
+	cancel context.CancelFunc
+	// w is the HTTP response writer used in upgrading the connection.
+	w http.ResponseWriter
+	// r is the HTTP request.
+	r *http.Request
+	// stop stops sending the keepalive ping messages.
+	stop func()
+	// conn is the underlying websocket connection.
+	conn *websocket.Conn
+}
+`
+
+var WebSocketStreamingResultServerStreamSendCode = `// Send streams instances of "websocketstreamingresultservice.UserType" to the
+// "WebSocketStreamingResultMethod" endpoint websocket connection.
+func (s *WebSocketStreamingResultMethodServerStream) Send(v *websocketstreamingresultservice.UserType) error {
+	var err error
+	// Upgrade the HTTP connection to a websocket connection only once. Connection
+	// upgrade is done here so that authorization logic in the endpoint is executed
+	// before calling the actual service method which may call Send().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.configurer != nil {
+			conn = s.configurer(conn, s.cancel)
+		}
+		s.conn = conn
+		s.stop = goahttp.KeepAlive(conn, 30*time.Second, 1*time.Minute, s.cancel)
+		// Process the control messages so that closing the connection cancels the
+		// request context.
+		go goahttp.ReadControl(conn, s.cancel)
+	})
+	if err != nil {
+		return err
+	}
+	res := v
+	body := NewWebSocketStreamingResultMethodResponseBody(res)
+	return s.conn.WriteJSON(body)
+}
+`
+
+var WebSocketStreamingResultServerStreamCloseCode = `// Close closes the "WebSocketStreamingResultMethod" endpoint websocket
+// connection.
+func (s *WebSocketStreamingResultMethodServerStream) Close() error {
+	var err error
+	if s.conn == nil {
+		return nil
+	}
+	if s.stop != nil {
+		s.stop()
+	}
+	if err = s.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "server closing connection"),
+		time.Now().Add(time.Second),
+	); err != nil {
+		return err
+	}
+	return s.conn.Close()
+}
+`
+
+var WebSocketBidirectionalStreamingServerStreamSendCode = `// Send streams instances of "websocketbidirectionalstreamingservice.UserType"
+// to the "WebSocketBidirectionalStreamingMethod" endpoint websocket connection.
+func (s *WebSocketBidirectionalStreamingMethodServerStream) Send(v *websocketbidirectionalstreamingservice.UserType) error {
+	var err error
+	// Upgrade the HTTP connection to a websocket connection only once. Connection
+	// upgrade is done here so that authorization logic in the endpoint is executed
+	// before calling the actual service method which may call Send().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.configurer != nil {
+			conn = s.configurer(conn, s.cancel)
+		}
+		s.conn = conn
+		s.stop = goahttp.KeepAlive(conn, 10*time.Second, 0, s.cancel)
+	})
+	if err != nil {
+		return err
+	}
+	res := v
+	body := NewWebSocketBidirectionalStreamingMethodResponseBody(res)
+	return goahttp.WriteBinaryJSON(s.conn, body)
+}
+`
+
+var WebSocketBidirectionalStreamingServerStreamRecvCode = `// Recv reads instances of "websocketbidirectionalstreamingservice.Request"
+// from the "WebSocketBidirectionalStreamingMethod" endpoint websocket
+// connection.
+func (s *WebSocketBidirectionalStreamingMethodServerStream) Recv() (*websocketbidirectionalstreamingservice.Request, error) {
+	var (
+		rv  *websocketbidirectionalstreamingservice.Request
+		msg *WebSocketBidirectionalStreamingMethodStreamingBody
+		err error
+	)
+	// Upgrade the HTTP connection to a websocket connection only once. Connection
+	// upgrade is done here so that authorization logic in the endpoint is executed
+	// before calling the actual service method which may call Recv().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.configurer != nil {
+			conn = s.configurer(conn, s.cancel)
+		}
+		s.conn = conn
+		s.stop = goahttp.KeepAlive(conn, 10*time.Second, 0, s.cancel)
+	})
+	if err != nil {
+		return rv, err
+	}
+	if err = s.conn.ReadJSON(&msg); err != nil {
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			return rv, io.EOF
+		}
+		return rv, err
+	}
+	if msg == nil {
+		return rv, io.EOF
+	}
+	return NewWebSocketBidirectionalStreamingMethodStreamingBody(msg), nil
+}
+`
+
+var WebSocketStreamingResultClientStreamRecvCode = `// Recv reads instances of "websocketstreamingresultservice.UserType" from the
+// "WebSocketStreamingResultMethod" endpoint websocket connection.
+func (s *WebSocketStreamingResultMethodClientStream) Recv() (*websocketstreamingresultservice.UserType, error) {
+	var (
+		rv   *websocketstreamingresultservice.UserType
+		body WebSocketStreamingResultMethodResponseBody
+		err  error
+	)
+	err = s.conn.ReadJSON(&body)
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+		s.conn.Close()
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	res := NewWebSocketStreamingResultMethodUserTypeOK(&body)
+	return res, nil
+}
+`
+
+var WebSocketBidirectionalStreamingClientStreamSendCode = `// Send streams instances of "websocketbidirectionalstreamingservice.Request"
+// to the "WebSocketBidirectionalStreamingMethod" endpoint websocket connection.
+func (s *WebSocketBidirectionalStreamingMethodClientStream) Send(v *websocketbidirectionalstreamingservice.Request) error {
+	body := NewWebSocketBidirectionalStreamingMethodStreamingBody(v)
+	return goahttp.WriteBinaryJSON(s.conn, body)
+}
+`
+
+var WebSocketBidirectionalStreamingClientStreamRecvCode = `// Recv reads instances of "websocketbidirectionalstreamingservice.UserType"
+// from the "WebSocketBidirectionalStreamingMethod" endpoint websocket
+// connection.
+func (s *WebSocketBidirectionalStreamingMethodClientStream) Recv() (*websocketbidirectionalstreamingservice.UserType, error) {
+	var (
+		rv   *websocketbidirectionalstreamingservice.UserType
+		body WebSocketBidirectionalStreamingMethodResponseBody
+		err  error
+	)
+	err = s.conn.ReadJSON(&body)
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+		return rv, io.EOF
+	}
+	if err != nil {
+		return rv, err
+	}
+	res := NewWebSocketBidirectionalStreamingMethodUserTypeOK(&body)
+	return res, nil
+}
+`
+
+var SSEStreamingResultServerHandlerInitCode = `// NewSSEStreamingResultMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "SSEStreamingResultService" service
+// "SSEStreamingResultMethod" endpoint.
+func NewSSEStreamingResultMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest = DecodeSSEStreamingResultMethodRequest(mux, decoder)
+		encodeError   = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SSEStreamingResultMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "SSEStreamingResultService")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		stream := &SSEStreamingResultMethodServerStream{w: w, r: r}
+		v := &ssestreamingresultservice.SSEStreamingResultMethodEndpointInput{
+			Stream:  stream,
+			Payload: payload.(*ssestreamingresultservice.SSEStreamingResultMethodPayload),
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if stream.started {
+				// Events have been sent, do not encode the error
+				errhandler(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var SSEStreamingResultServerStructCode = `// SSEStreamingResultMethodServerStream implements the
+// ssestreamingresultservice.SSEStreamingResultMethodServerStream interface.
+type SSEStreamingResultMethodServerStream struct {
+	once sync.Once
+	// started is true once the response headers have been written.
+	started bool
+	// w is the HTTP response writer used to send the events.
+	w http.ResponseWriter
+	// r is the HTTP request.
+	r *http.Request
+}
+`
+
+var SSEStreamingResultServerStreamSendCode = `// Send streams instances of "ssestreamingresultservice.Notification" to the
+// "SSEStreamingResultMethod" endpoint as server-sent events.
+func (s *SSEStreamingResultMethodServerStream) Send(v *ssestreamingresultservice.Notification) error {
+	s.start()
+	res := v
+	body := NewSSEStreamingResultMethodResponseBody(res)
+	var id string
+	if v.ID != nil {
+		id = *v.ID
+	}
+	switch {
+	case body.Message != nil:
+		return goahttp.WriteSSEEvent(s.w, id, "message", body.Message)
+	case body.Error != nil:
+		return goahttp.WriteSSEEvent(s.w, id, "error", body.Error)
+	}
+	return fmt.Errorf("SSEStreamingResultService: no event set in SSEStreamingResultMethod result")
+}
+`
+
+var SSEStreamingResultServerStreamCloseCode = `// Close ends the "SSEStreamingResultMethod" endpoint server-sent events stream.
+func (s *SSEStreamingResultMethodServerStream) Close() error {
+	s.start()
+	return nil
+}
+
+// start writes the response headers the first time it is called. Headers are
+// written here so that authorization logic in the endpoint is executed before
+// any event is sent.
+func (s *SSEStreamingResultMethodServerStream) start() {
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+}
+`
+
+var SSESingleEventServerStreamSendCode = `// Send streams instances of "ssesingleeventservice.Tick" to the
+// "SSESingleEventMethod" endpoint as server-sent events.
+func (s *SSESingleEventMethodServerStream) Send(v *ssesingleeventservice.Tick) error {
+	s.start()
+	res := ssesingleeventservice.NewViewedTick(v, "default")
+	body := NewSSESingleEventMethodResponseBody(res.Projected)
+	id := v.ID
+	return goahttp.WriteSSEEvent(s.w, id, "tick", body)
+}
+`
+
+var SSETrailersServerStreamCloseCode = `// Close ends the "SSETrailersMethod" endpoint server-sent events stream.
+func (s *SSETrailersMethodServerStream) Close() error {
+	s.start()
+	return nil
+}
+
+// start writes the response headers the first time it is called. Headers are
+// written here so that authorization logic in the endpoint is executed before
+// any event is sent.
+func (s *SSETrailersMethodServerStream) start() {
+	s.once.Do(func() {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.Header().Add("Trailer", "X-Total-Count")
+		s.w.Header().Add("Trailer", "X-Next")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	})
+}
+`
+
+var SSETrailersServerStreamSetTrailersCode = `// SetTrailers sets the trailers sent once the "SSETrailersMethod" endpoint
+// events have been sent.
+func (s *SSETrailersMethodServerStream) SetTrailers(t *ssetrailersservice.SSETrailersMethodTrailers) {
+	s.start()
+	if t.XTotalCount != nil {
+		s.w.Header().Set("X-Total-Count", fmt.Sprint(*t.XTotalCount))
+	}
+	if t.XNext != nil {
+		s.w.Header().Set("X-Next", *t.XNext)
+	}
+}
+`
+
+var SSEStreamingResultClientEndpointCode = `// SSEStreamingResultMethod returns an endpoint that makes HTTP requests to the
+// SSEStreamingResultService service SSEStreamingResultMethod server.
+func (c *Client) SSEStreamingResultMethod() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeSSEStreamingResultMethodRequest(c.encoder)
+		decodeResponse = DecodeSSEStreamingResultMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildSSEStreamingResultMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := c.SSEStreamingResultMethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("SSEStreamingResultService", "SSEStreamingResultMethod", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		return &SSEStreamingResultMethodClientStream{body: resp.Body, reader: goahttp.NewSSEReader(resp.Body, "message", "error")}, nil
+	}
+}
+`
+
+var SSEStreamingResultClientStructCode = `// SSEStreamingResultMethodClientStream implements the
+// ssestreamingresultservice.SSEStreamingResultMethodClientStream interface.
+type SSEStreamingResultMethodClientStream struct {
+	// body is the HTTP response body.
+	body io.ReadCloser
+	// reader reads the events from the response body.
+	reader *goahttp.SSEReader
+}
+`
+
+var SSEStreamingResultClientStreamRecvCode = `// Recv reads instances of "ssestreamingresultservice.Notification" from the
+// "SSEStreamingResultMethod" endpoint server-sent events.
+func (s *SSEStreamingResultMethodClientStream) Recv() (*ssestreamingresultservice.Notification, error) {
+	var (
+		rv   *ssestreamingresultservice.Notification
+		body SSEStreamingResultMethodResponseBody
+	)
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	switch ev.Event {
+	case "message":
+		err = json.Unmarshal(ev.Data, &body.Message)
+	case "error":
+		err = json.Unmarshal(ev.Data, &body.Error)
+	}
+	if err != nil {
+		return rv, goahttp.ErrDecodingError("SSEStreamingResultService", "SSEStreamingResultMethod", err)
+	}
+	if ev.ID != "" {
+		body.ID = &ev.ID
+	}
+	res := NewSSEStreamingResultMethodNotificationOK(&body)
+	return res, nil
+}
+`
+
+var SSESingleEventClientStreamRecvCode = `// Recv reads instances of "ssesingleeventservice.Tick" from the
+// "SSESingleEventMethod" endpoint server-sent events.
+func (s *SSESingleEventMethodClientStream) Recv() (*ssesingleeventservice.Tick, error) {
+	var (
+		rv   *ssesingleeventservice.Tick
+		body SSESingleEventMethodResponseBody
+	)
+	ev, err := s.reader.Next()
+	if err != nil {
+		s.body.Close()
+		return rv, err
+	}
+	err = json.Unmarshal(ev.Data, &body)
+	if err != nil {
+		return rv, goahttp.ErrDecodingError("SSESingleEventService", "SSESingleEventMethod", err)
+	}
+	res := NewSSESingleEventMethodTickOK(&body)
+	vres := &ssesingleeventserviceviews.Tick{res, "default"}
+	if err := ssesingleeventserviceviews.ValidateTick(vres); err != nil {
+		return rv, goahttp.ErrValidationError("SSESingleEventService", "SSESingleEventMethod", err)
+	}
+	return ssesingleeventservice.NewTick(vres), nil
+}
+`
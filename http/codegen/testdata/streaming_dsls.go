@@ -754,3 +754,114 @@ var BidirectionalStreamingResultCollectionWithExplicitViewDSL = func() {
 		})
 	})
 }
+
+var WebSocketStreamingResultDSL = func() {
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+	})
+	Service("WebSocketStreamingResultService", func() {
+		Method("WebSocketStreamingResultMethod", func() {
+			StreamingResult(Result)
+			HTTP(func() {
+				WebSocket(func() {
+					Path("/events/ws")
+					PingInterval("30s")
+					PongTimeout("1m")
+				})
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var WebSocketBidirectionalStreamingDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+	})
+	Service("WebSocketBidirectionalStreamingService", func() {
+		Method("WebSocketBidirectionalStreamingMethod", func() {
+			StreamingPayload(Request)
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				WebSocket(func() {
+					Framing(WebSocketBinary)
+					PingInterval("10s")
+				})
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var SSEStreamingResultDSL = func() {
+	var Message = Type("Message", func() {
+		Attribute("text", String)
+	})
+	var Failure = Type("Failure", func() {
+		Attribute("reason", String)
+	})
+	var Result = Type("Notification", func() {
+		Attribute("id", String)
+		Attribute("message", Message)
+		Attribute("error", Failure)
+	})
+	Service("SSEStreamingResultService", func() {
+		Method("SSEStreamingResultMethod", func() {
+			Payload(func() {
+				Attribute("last_event_id", String)
+			})
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/events")
+				Header("last_event_id:Last-Event-ID")
+				SSE(func() {
+					Event("message", Message)
+					Event("error", Failure)
+					EventID("id")
+				})
+			})
+		})
+	})
+}
+
+var SSETrailersDSL = func() {
+	Service("SSETrailersService", func() {
+		Method("SSETrailersMethod", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/items")
+				SSE(func() {
+					Event("item")
+				})
+				Response(StatusOK, func() {
+					Trailer("X-Total-Count", Int)
+					Trailer("X-Next", String)
+				})
+			})
+		})
+	})
+}
+
+var SSESingleEventDSL = func() {
+	var Result = ResultType("application/vnd.tick", func() {
+		Attribute("id", String)
+		Attribute("count", Int)
+		Required("id")
+	})
+	Service("SSESingleEventService", func() {
+		Method("SSESingleEventMethod", func() {
+			StreamingResult(Result)
+			HTTP(func() {
+				POST("/ticks")
+				SSE(func() {
+					Event("tick")
+					EventID("id")
+				})
+			})
+		})
+	})
+}
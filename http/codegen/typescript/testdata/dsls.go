@@ -0,0 +1,90 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SimpleDSL = func() {
+	Service("simple", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", Int, "Resource ID")
+				Attribute("fields", ArrayOf(String))
+				Attribute("version", String)
+				Required("id")
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/{id}")
+				Param("fields")
+				Header("version:X-Version")
+			})
+		})
+	})
+}
+
+var BodyDSL = func() {
+	var Address = Type("Address", func() {
+		Description("Address is a postal address.")
+		Attribute("street", String)
+		Attribute("zip-code", String)
+		Required("street")
+	})
+	var Account = ResultType("application/vnd.account", func() {
+		TypeName("Account")
+		Attributes(func() {
+			Attribute("name", String)
+			Attribute("nickname", String, func() {
+				Nullable()
+			})
+			Attribute("kind", String, func() {
+				Enum("personal", "business")
+			})
+			Attribute("address", Address)
+			Attribute("labels", MapOf(String, Int))
+		})
+	})
+	Service("accounts", func() {
+		Error("not_found")
+		Method("create", func() {
+			Payload(func() {
+				Attribute("org", Int)
+				Attribute("name", String)
+				Attribute("address", Address)
+				Required("org", "name")
+			})
+			Result(Account)
+			Error("bad_request")
+			HTTP(func() {
+				POST("/orgs/{org}/accounts")
+				Response(StatusCreated, func() {
+					Header("name:Location")
+				})
+				Response("not_found", StatusNotFound)
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+		Method("list", func() {
+			Result(ArrayOf(Account))
+			HTTP(func() {
+				GET("/accounts")
+			})
+		})
+	})
+}
+
+var UnsupportedDSL = func() {
+	Service("events", func() {
+		Method("watch", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+		Method("ping", func() {
+			HTTP(func() {
+				GET("/ping")
+			})
+		})
+	})
+}
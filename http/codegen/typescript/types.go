@@ -0,0 +1,254 @@
+package typescript
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// typeScope keeps track of the TypeScript declarations generated for
+	// the design user types.
+	typeScope struct {
+		// decls lists the declarations in the order they are generated.
+		decls []*typeDecl
+		// names maps the user type IDs to their TypeScript names.
+		names map[string]string
+		// taken lists the TypeScript names already in use.
+		taken map[string]struct{}
+	}
+
+	// typeDecl is a TypeScript type declaration.
+	typeDecl struct {
+		// Name is the TypeScript type name.
+		Name string
+		// Description is the type description.
+		Description string
+		// Interface is true if the declaration is an interface, false
+		// if it is a type alias.
+		Interface bool
+		// Def is the type definition: the interface body or the aliased
+		// type.
+		Def string
+	}
+)
+
+// identRegex matches valid JavaScript identifiers.
+var identRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// reservedNames lists the TypeScript global names that must not be shadowed by
+// the generated types.
+var reservedNames = map[string]struct{}{
+	"Array": {}, "Boolean": {}, "Date": {}, "Error": {}, "Map": {},
+	"Number": {}, "Object": {}, "Promise": {}, "Record": {}, "Response": {},
+	"Set": {}, "String": {}, "APIError": {}, "ClientOptions": {},
+}
+
+func newTypeScope() *typeScope {
+	return &typeScope{
+		names: make(map[string]string),
+		taken: make(map[string]struct{}),
+	}
+}
+
+// Ref returns the TypeScript type of the given attribute, generating the
+// declarations of the user types it uses.
+func (s *typeScope) Ref(att *expr.AttributeExpr) string {
+	ref := s.ref(att)
+	if att.IsNullable() {
+		ref += " | null"
+	}
+	return ref
+}
+
+// Named is similar to Ref but declares inline object types using the given name
+// so that they can be referenced by the client code.
+func (s *typeScope) Named(att *expr.AttributeExpr, name string) string {
+	if _, ok := att.Type.(*expr.Object); !ok {
+		return s.Ref(att)
+	}
+	name = s.unique(name)
+	decl := &typeDecl{Name: name, Description: att.Description, Interface: true}
+	s.decls = append(s.decls, decl)
+	decl.Def = s.objectDef(att, "")
+	return name
+}
+
+func (s *typeScope) ref(att *expr.AttributeExpr) string {
+	switch actual := att.Type.(type) {
+	case expr.Primitive:
+		if att.Validation != nil && len(att.Validation.Values) > 0 {
+			return literals(att.Validation.Values)
+		}
+		return primitive(actual)
+	case *expr.Array:
+		elem := s.Ref(actual.ElemType)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case *expr.Map:
+		key := "string"
+		if k, ok := actual.KeyType.Type.(expr.Primitive); ok && primitive(k) == "number" {
+			key = "number"
+		}
+		return fmt.Sprintf("Record<%s, %s>", key, s.Ref(actual.ElemType))
+	case *expr.Object:
+		return s.objectDef(att, "")
+	case *expr.Union:
+		vals := make([]string, len(actual.Values))
+		for i, v := range actual.Values {
+			vals[i] = fmt.Sprintf("{ Type: %q; Value: %s }", v.Name, s.Ref(v.Attribute))
+		}
+		return strings.Join(vals, " | ")
+	case expr.UserType:
+		if actual == expr.Empty {
+			return "Record<string, never>"
+		}
+		return s.userType(actual)
+	default:
+		panic(fmt.Sprintf("unknown data type %T", actual)) // bug
+	}
+}
+
+// userType returns the name of the TypeScript declaration generated for ut.
+func (s *typeScope) userType(ut expr.UserType) string {
+	if name, ok := s.names[ut.ID()]; ok {
+		return name
+	}
+	name := codegen.Goify(ut.Name(), true)
+	if ut == expr.ErrorResult {
+		name = "ServiceError"
+	}
+	name = s.unique(name)
+	s.names[ut.ID()] = name
+	decl := &typeDecl{Name: name, Description: ut.Attribute().Description}
+	s.decls = append(s.decls, decl)
+	if expr.IsObject(ut) {
+		decl.Interface = true
+		decl.Def = s.objectDef(ut.Attribute(), "")
+	} else {
+		decl.Def = s.ref(ut.Attribute())
+	}
+	return name
+}
+
+// objectDef returns the TypeScript definition of the given object attribute.
+// Attributes that are not required are optional properties.
+func (s *typeScope) objectDef(att *expr.AttributeExpr, indent string) string {
+	obj := expr.AsObject(att.Type)
+	if len(*obj) == 0 {
+		return "{}"
+	}
+	lines := []string{"{"}
+	for _, nat := range *obj {
+		if nat.Attribute.Description != "" {
+			lines = append(lines, indent+"  /** "+comment(nat.Attribute.Description)+" */")
+		}
+		opt := "?"
+		if att.IsRequired(nat.Name) {
+			opt = ""
+		}
+		var def string
+		if expr.IsObject(nat.Attribute.Type) {
+			if _, ok := nat.Attribute.Type.(expr.UserType); !ok {
+				def = s.objectDef(nat.Attribute, indent+"  ")
+			}
+		}
+		if def == "" {
+			def = s.Ref(nat.Attribute)
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s%s: %s;", indent, property(nat.Name), opt, def))
+	}
+	lines = append(lines, indent+"}")
+	return strings.Join(lines, "\n")
+}
+
+// Decls returns the type declarations sorted by name.
+func (s *typeScope) Decls() []*typeDecl {
+	decls := make([]*typeDecl, len(s.decls))
+	copy(decls, s.decls)
+	sort.Slice(decls, func(i, j int) bool { return decls[i].Name < decls[j].Name })
+	return decls
+}
+
+// unique returns name or name suffixed with the smallest integer that makes it
+// unique and not a reserved name.
+func (s *typeScope) unique(name string) string {
+	_, reserved := reservedNames[name]
+	_, taken := s.taken[name]
+	if reserved || taken {
+		i := 2
+		for {
+			n := name + strconv.Itoa(i)
+			if _, ok := s.taken[n]; !ok {
+				name = n
+				break
+			}
+			i++
+		}
+	}
+	s.taken[name] = struct{}{}
+	return name
+}
+
+// primitive returns the TypeScript type of the given primitive type. Bytes
+// are encoded as base64 strings in JSON.
+func primitive(p expr.Primitive) string {
+	switch p.Kind() {
+	case expr.BooleanKind:
+		return "boolean"
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind,
+		expr.UInt32Kind, expr.UInt64Kind, expr.Float32Kind, expr.Float64Kind:
+		return "number"
+	case expr.StringKind, expr.BytesKind:
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+// literals returns the union of the TypeScript literal types of the given
+// enum values.
+func literals(vals []interface{}) string {
+	lits := make([]string, len(vals))
+	for i, v := range vals {
+		b, err := json.Marshal(v)
+		if err != nil {
+			panic(err) // bug
+		}
+		lits[i] = string(b)
+	}
+	return strings.Join(lits, " | ")
+}
+
+// property returns the TypeScript property name for the given attribute name,
+// quoted if it is not a valid identifier.
+func property(name string) string {
+	if identRegex.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// access returns the TypeScript expression accessing the property name of
+// the object held by v.
+func access(v, name string) string {
+	if identRegex.MatchString(name) {
+		return v + "." + name
+	}
+	return fmt.Sprintf("%s[%s]", v, strconv.Quote(name))
+}
+
+// comment returns the given description on a single line suitable for a
+// JSDoc comment.
+func comment(desc string) string {
+	desc = strings.Join(strings.Fields(desc), " ")
+	return strings.ReplaceAll(desc, "*/", "* /")
+}
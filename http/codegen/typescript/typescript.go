@@ -0,0 +1,514 @@
+/*
+Package typescript implements a plugin that generates TypeScript declarations
+for the types used by the HTTP endpoints together with a typed client built on
+top of the fetch API.
+
+Enable the plugin by importing the package in the design:
+
+	import (
+	    _ "goa.design/goa/v3/http/codegen/typescript"
+	    . "goa.design/goa/v3/dsl"
+	)
+
+The "gen" command then generates one TypeScript module per HTTP service under
+gen/http/typescript. The modules declare an interface for each payload, result
+and error type of the service methods and a client class with one method per
+endpoint. Attributes that are not required map to optional properties, enums
+map to unions of literal types and nullable attributes accept null. The client
+methods throw an APIError whose name is the name of the error defined in the
+design when the server responds with an error status code.
+
+Streaming endpoints, endpoints that skip the request or response body
+encoding, multipart requests, map query parameters and redirects are not
+supported: the corresponding methods are not generated. Cookies are left to
+the browser.
+*/
+package typescript
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// fileData contains the data needed to render a TypeScript module.
+	fileData struct {
+		// ServiceName is the name of the service.
+		ServiceName string
+		// ClientName is the name of the client class.
+		ClientName string
+		// Description is the service description.
+		Description string
+		// Types lists the type declarations.
+		Types []*typeDecl
+		// Methods lists the client methods.
+		Methods []*methodData
+		// Unsupported lists the names of the methods that are not
+		// generated.
+		Unsupported []string
+	}
+
+	// methodData contains the data needed to render a client method.
+	methodData struct {
+		// Name is the name of the client method.
+		Name string
+		// Description is the method description.
+		Description string
+		// PayloadType is the TypeScript type of the payload if any.
+		PayloadType string
+		// ResultType is the TypeScript type of the result.
+		ResultType string
+		// ErrorType is the TypeScript type of the errors thrown by the
+		// method.
+		ErrorType string
+		// Verb is the HTTP method.
+		Verb string
+		// Path is the content of the template literal used to build the
+		// request path.
+		Path string
+		// Request lists the statements that initialize the "query" and
+		// "headers" variables and optionally the "body" variable.
+		Request []string
+		// HasBody is true if the request has a body.
+		HasBody bool
+		// Errors is the object literal mapping the error status codes
+		// to the error names.
+		Errors string
+		// Response lists the statements that build and return the result.
+		Response []string
+	}
+)
+
+func init() {
+	codegen.RegisterPluginLast("typescript", "gen", nil, Generate)
+}
+
+// Generate appends the TypeScript client modules to the generated files.
+func Generate(_ string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*expr.RootExpr); ok {
+			files = append(files, Files(r)...)
+		}
+	}
+	return files, nil
+}
+
+// Files returns the TypeScript client modules, one per HTTP service.
+func Files(root *expr.RootExpr) []*codegen.File {
+	if root.API == nil || root.API.HTTP == nil {
+		return nil
+	}
+	fw := make([]*codegen.File, len(root.API.HTTP.Services))
+	for i, svc := range root.API.HTTP.Services {
+		fw[i] = clientFile(svc)
+	}
+	return fw
+}
+
+// clientFile returns the TypeScript module for the given service.
+func clientFile(svc *expr.HTTPServiceExpr) *codegen.File {
+	var (
+		name  = svc.Name()
+		path  = filepath.Join(codegen.Gendir, "http", "typescript", codegen.SnakeCase(codegen.Goify(name, false))+".ts")
+		scope = newTypeScope()
+		data  = &fileData{
+			ServiceName: name,
+			ClientName:  codegen.Goify(name, true) + "Client",
+			Description: svc.ServiceExpr.Description,
+		}
+	)
+	for _, e := range svc.HTTPEndpoints {
+		if !supported(e) {
+			data.Unsupported = append(data.Unsupported, e.Name())
+			continue
+		}
+		data.Methods = append(data.Methods, buildMethodData(e, scope))
+	}
+	data.Types = scope.Decls()
+	var (
+		title = fmt.Sprintf("%s TypeScript client", name)
+		funcs = map[string]interface{}{
+			"join":      strings.Join,
+			"tsComment": comment,
+			"indent":    func(s string) string { return strings.ReplaceAll(s, "\n", "\n    ") },
+		}
+	)
+	return &codegen.File{
+		Path: path,
+		SectionTemplates: []*codegen.SectionTemplate{
+			{
+				Name:   "typescript-header",
+				Source: headerT,
+				Data:   map[string]interface{}{"Title": title, "ToolVersion": goa.Version()},
+			},
+			{Name: "typescript-types", Source: typesT, Data: data, FuncMap: funcs},
+			{Name: "typescript-client", Source: clientT, Data: data, FuncMap: funcs},
+		},
+	}
+}
+
+// supported returns true if the TypeScript client can call the endpoint.
+func supported(e *expr.HTTPEndpointExpr) bool {
+	return !e.MethodExpr.IsStreaming() &&
+		!e.SkipRequestBodyEncodeDecode &&
+		!e.SkipResponseBodyEncodeDecode &&
+		!e.MultipartRequest &&
+		e.MapQueryParams == nil &&
+		e.Redirect == nil &&
+		len(e.Routes) > 0
+}
+
+// buildMethodData computes the data needed to render the client method that
+// calls the given endpoint.
+func buildMethodData(e *expr.HTTPEndpointExpr, scope *typeScope) *methodData {
+	var (
+		m       = e.MethodExpr
+		payload = m.Payload
+		isObj   = expr.IsObject(payload.Type)
+	)
+	// value returns the expression holding the value of the given payload
+	// attribute.
+	value := func(name string) string {
+		if !isObj {
+			return "payload"
+		}
+		return access("payload", name)
+	}
+	// required returns true if the given payload attribute is always set.
+	required := func(name string) bool {
+		return !isObj || payload.IsRequired(name)
+	}
+	// walk iterates over the attributes of ma if any.
+	walk := func(ma *expr.MappedAttributeExpr, fn func(name, elem string, att *expr.AttributeExpr)) {
+		if ma == nil {
+			return
+		}
+		_ = codegen.WalkMappedAttr(ma, func(name, elem string, _ bool, att *expr.AttributeExpr) error {
+			fn(name, elem, att)
+			return nil
+		})
+	}
+
+	md := &methodData{
+		Name:        codegen.Goify(m.Name, false),
+		Description: m.Description,
+		ResultType:  "void",
+		Verb:        e.Routes[0].Method,
+	}
+	if payload.Type != expr.Empty {
+		md.PayloadType = scope.Named(payload, codegen.Goify(m.Name, true)+"Payload")
+	}
+	if m.Result.Type != expr.Empty {
+		md.ResultType = scope.Named(m.Result, codegen.Goify(m.Name, true)+"Result")
+	}
+
+	// Path
+	{
+		pparams := e.PathParams()
+		path := strings.ReplaceAll(e.Routes[0].FullPaths()[0], "`", "\\`")
+		md.Path = expr.HTTPWildcardRegex.ReplaceAllStringFunc(path, func(w string) string {
+			elem := expr.HTTPWildcardRegex.FindStringSubmatch(w)[1]
+			name := pparams.KeyName(elem)
+			v := value(name)
+			if att := pparams.Find(name); att != nil && expr.IsArray(att.Type) {
+				return fmt.Sprintf("/${%s.map((v) => encodeURIComponent(String(v))).join(\",\")}", v)
+			}
+			return fmt.Sprintf("/${encodeURIComponent(String(%s))}", v)
+		})
+	}
+
+	// Query string
+	md.Request = append(md.Request, "const query = new URLSearchParams();")
+	walk(e.QueryParams(), func(name, elem string, att *expr.AttributeExpr) {
+		v := value(name)
+		var stmt string
+		if expr.IsArray(att.Type) {
+			stmt = fmt.Sprintf("for (const v of %s) {\n  query.append(%q, String(v));\n}", v, elem)
+		} else {
+			stmt = fmt.Sprintf("query.append(%q, String(%s));", elem, v)
+		}
+		md.Request = append(md.Request, optional(v, stmt, required(name)))
+	})
+
+	// Headers
+	md.Request = append(md.Request, "const headers: Record<string, string> = { ...this.options.headers };")
+	if user := expr.TaggedAttribute(payload, "security:username"); user != "" {
+		pass := expr.TaggedAttribute(payload, "security:password")
+		stmt := fmt.Sprintf("headers[\"Authorization\"] = `Basic ${btoa(`${%s}:${%s ?? \"\"}`)}`;", value(user), value(pass))
+		md.Request = append(md.Request, optional(value(user), stmt, required(user)))
+	}
+	walk(e.Headers, func(name, elem string, att *expr.AttributeExpr) {
+		v := value(name)
+		val := fmt.Sprintf("String(%s)", v)
+		if expr.IsArray(att.Type) {
+			val = fmt.Sprintf("%s.join(\",\")", v)
+		}
+		stmt := fmt.Sprintf("headers[%q] = %s;", elem, val)
+		if isToken(payload, name) {
+			stmt = fmt.Sprintf("const token = %s;\nheaders[%q] = token.includes(\" \") ? token : `Bearer ${token}`;", val, elem)
+		}
+		md.Request = append(md.Request, optional(v, stmt, required(name)))
+	})
+
+	// Body
+	if body := e.Body; body != nil && body.Type != expr.Empty {
+		var b string
+		switch {
+		case body.Meta["origin:attribute"] != nil:
+			b = value(body.Meta["origin:attribute"][0])
+		case !isObj:
+			b = "payload"
+		default:
+			var fields []string
+			for _, nat := range *expr.AsObject(body.Type) {
+				fields = append(fields, fmt.Sprintf("%s: %s", property(nat.Name), value(nat.Name)))
+			}
+			b = "{ " + strings.Join(fields, ", ") + " }"
+		}
+		md.HasBody = true
+		md.Request = append(md.Request,
+			"headers[\"Content-Type\"] = \"application/json\";",
+			fmt.Sprintf("const body = JSON.stringify(%s);", b))
+	}
+
+	// Errors
+	{
+		var (
+			codes []int
+			names = make(map[int][]string)
+			types []string
+		)
+		for _, he := range e.HTTPErrors {
+			code := he.Response.StatusCode
+			if _, ok := names[code]; !ok {
+				codes = append(codes, code)
+			}
+			names[code] = append(names[code], fmt.Sprintf("%q", he.Name))
+			types = append(types, fmt.Sprintf("APIError<%q, %s>", he.Name, scope.Ref(he.AttributeExpr)))
+		}
+		sort.Ints(codes)
+		entries := make([]string, len(codes))
+		for i, code := range codes {
+			entries[i] = fmt.Sprintf("%d: [%s]", code, strings.Join(names[code], ", "))
+		}
+		md.Errors = "{ " + strings.Join(entries, ", ") + " }"
+		if len(entries) == 0 {
+			md.Errors = "{}"
+		}
+		md.ErrorType = "APIError"
+		if len(types) > 0 {
+			md.ErrorType = strings.Join(types, " | ")
+		}
+	}
+
+	// Response
+	md.Response = responseStatements(e, md.ResultType)
+
+	return md
+}
+
+// responseStatements returns the statements that build the result of the
+// endpoint from the fetch response held by the "res" variable.
+func responseStatements(e *expr.HTTPEndpointExpr, resultType string) []string {
+	result := e.MethodExpr.Result
+	if result.Type == expr.Empty {
+		return []string{"return;"}
+	}
+	var (
+		resp    = e.Responses[0]
+		hasBody = resp.Body != nil && resp.Body.Type != expr.Empty
+		headers = resp.Headers
+	)
+	if !expr.IsObject(result.Type) {
+		if hasBody {
+			return []string{fmt.Sprintf("return (await res.json()) as %s;", resultType)}
+		}
+		var stmts []string
+		if headers != nil {
+			_ = codegen.WalkMappedAttr(headers, func(_, elem string, _ bool, att *expr.AttributeExpr) error {
+				stmts = append(stmts,
+					fmt.Sprintf("const value = res.headers.get(%q);", elem),
+					fmt.Sprintf("return (value === null ? undefined : %s) as unknown as %s;", parseHeader("value", att), resultType))
+				return errStop
+			})
+		}
+		return stmts
+	}
+	var stmts []string
+	switch {
+	case hasBody && resp.Body.Meta["origin:attribute"] != nil:
+		stmts = append(stmts,
+			"const result: Record<string, unknown> = {};",
+			fmt.Sprintf("result[%q] = await res.json();", resp.Body.Meta["origin:attribute"][0]))
+	case hasBody:
+		stmts = append(stmts, "const result = (await res.json()) as Record<string, unknown>;")
+	default:
+		stmts = append(stmts, "const result: Record<string, unknown> = {};")
+	}
+	if headers != nil {
+		_ = codegen.WalkMappedAttr(headers, func(name, elem string, _ bool, att *expr.AttributeExpr) error {
+			v := codegen.Goify(name, false) + "Header"
+			stmts = append(stmts,
+				fmt.Sprintf("const %s = res.headers.get(%q);", v, elem),
+				fmt.Sprintf("if (%s !== null) {\n  result[%q] = %s;\n}", v, name, parseHeader(v, att)))
+			return nil
+		})
+	}
+	return append(stmts, fmt.Sprintf("return result as unknown as %s;", resultType))
+}
+
+// errStop stops the iteration over the mapped attributes.
+var errStop = errors.New("stop")
+
+// parseHeader returns the expression that converts the value of the header
+// held by v to the type of att.
+func parseHeader(v string, att *expr.AttributeExpr) string {
+	if arr := expr.AsArray(att.Type); arr != nil {
+		return fmt.Sprintf("%s.split(\",\").map((v) => %s)", v, parseHeader("v.trim()", arr.ElemType))
+	}
+	p, ok := att.Type.(expr.Primitive)
+	if !ok {
+		return v
+	}
+	switch primitive(p) {
+	case "number":
+		return fmt.Sprintf("Number(%s)", v)
+	case "boolean":
+		return fmt.Sprintf("%s === \"true\"", v)
+	default:
+		return v
+	}
+}
+
+// optional wraps stmt in a condition that checks that v is defined unless req
+// is true.
+func optional(v, stmt string, req bool) string {
+	if req {
+		return stmt
+	}
+	return fmt.Sprintf("if (%s !== undefined) {\n  %s\n}", v, strings.ReplaceAll(stmt, "\n", "\n  "))
+}
+
+// isToken returns true if the given payload attribute holds a JWT or OAuth2
+// token sent using the bearer scheme.
+func isToken(payload *expr.AttributeExpr, name string) bool {
+	return expr.TaggedAttribute(payload, "security:token") == name ||
+		expr.TaggedAttribute(payload, "security:accesstoken") == name
+}
+
+// input: map[string]interface{}{"Title": string, "ToolVersion": string}
+const headerT = `// Code generated by goa {{ .ToolVersion }}, DO NOT EDIT.
+//
+// {{ .Title }}
+//
+// Command:
+{{ comment commandLine }}
+
+/** ClientOptions configures the client. */
+export interface ClientOptions {
+  /** fetch sends the requests, defaults to the global fetch function. */
+  fetch?: typeof fetch;
+  /** headers are added to all the requests. */
+  headers?: Record<string, string>;
+}
+
+/**
+ * APIError is thrown when the server responds with an error status code. name
+ * is the name of the error defined in the design.
+ */
+export class APIError<N extends string = string, T = unknown> extends Error {
+  constructor(
+    readonly errorName: N,
+    readonly status: number,
+    readonly body: T,
+  ) {
+    super(` + "`${errorName} (${status})`" + `);
+  }
+}
+`
+
+// input: fileData
+const typesT = `{{ range .Types }}
+{{ if .Description }}/** {{ tsComment .Description }} */
+{{ end }}
+{{- if .Interface }}export interface {{ .Name }} {{ .Def }}{{ else }}export type {{ .Name }} = {{ .Def }};{{ end }}
+{{ end }}`
+
+// input: fileData
+const clientT = `
+/**
+ * {{ .ClientName }} calls the {{ printf "%q" .ServiceName }} service endpoints.
+{{- if .Description }}
+ *
+ * {{ tsComment .Description }}
+{{- end }}
+{{- if .Unsupported }}
+ *
+ * The following methods are not supported: {{ join .Unsupported ", " }}.
+{{- end }}
+ */
+export class {{ .ClientName }} {
+  constructor(
+    private readonly baseURL: string,
+    private readonly options: ClientOptions = {},
+  ) {}
+{{- range .Methods }}
+
+  /**
+{{- if .Description }}
+   * {{ tsComment .Description }}
+   *
+{{- end }}
+   * @throws { {{- .ErrorType -}} }
+   */
+  async {{ .Name }}({{ if .PayloadType }}payload: {{ .PayloadType }}, {{ end }}init?: RequestInit): Promise<{{ .ResultType }}> {
+{{- range .Request }}
+    {{ indent . }}
+{{- end }}
+    const qs = query.toString();
+    const res = await (this.options.fetch ?? fetch)(` + "`${this.baseURL}{{ .Path }}${qs ? `?${qs}` : \"\"}`" + `, {
+      ...init,
+      method: {{ printf "%q" .Verb }},
+      headers,
+{{- if .HasBody }}
+      body,
+{{- end }}
+    });
+    if (!res.ok) {
+      throw await decodeError(res, {{ .Errors }});
+    }
+{{- range .Response }}
+    {{ indent . }}
+{{- end }}
+  }
+{{- end }}
+}
+
+// decodeError builds the APIError corresponding to the given error response.
+// errors maps the status codes to the names of the errors that use them.
+async function decodeError(res: Response, errors: Record<number, string[]>): Promise<APIError> {
+  let body: unknown;
+  try {
+    body = await res.json();
+  } catch {
+    body = undefined;
+  }
+  const names = errors[res.status] ?? [];
+  let name = names[0] ?? "unknown";
+  if (typeof body === "object" && body !== null && "name" in body) {
+    const n = (body as { name: unknown }).name;
+    if (typeof n === "string" && names.includes(n)) {
+      name = n;
+    }
+  }
+  return new APIError(name, res.status, body);
+}
+`
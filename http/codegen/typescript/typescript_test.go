@@ -0,0 +1,67 @@
+package typescript
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/typescript/testdata"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+func TestFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+	}{
+		{"simple", testdata.SimpleDSL},
+		{"body", testdata.BodyDSL},
+		{"unsupported", testdata.UnsupportedDSL},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := Files(root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			var buf bytes.Buffer
+			// Skip the header section as it contains the goa version.
+			for _, s := range fs[0].SectionTemplates[1:] {
+				if err := s.Write(&buf); err != nil {
+					t.Fatalf("failed to render section %q: %s", s.Name, err)
+				}
+			}
+			golden := filepath.Join("testdata", "golden", c.Name+".ts.golden")
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			want = bytes.ReplaceAll(want, []byte{'\r', '\n'}, []byte{'\n'})
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("result does not match the golden file, got vs. expected:\n%s\n", codegen.Diff(t, buf.String(), string(want)))
+			}
+		})
+	}
+}
+
+func TestFilesPath(t *testing.T) {
+	root := httpcodegen.RunHTTPDSL(t, testdata.BodyDSL)
+	fs := Files(root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if want := filepath.Join("gen", "http", "typescript", "accounts.ts"); fs[0].Path != want {
+		t.Errorf("got path %q, expected %q", fs[0].Path, want)
+	}
+}
@@ -56,11 +56,30 @@ type (
 		// MustClose indicates whether to generate the Close() function
 		// for the stream.
 		MustClose bool
+		// CloseAndRecv indicates whether to generate the CloseAndRecv()
+		// function for the stream.
+		CloseAndRecv bool
 		// PkgName is the service package name.
 		PkgName string
 		// Kind is the kind of the stream (payload, result or
 		// bidirectional).
 		Kind expr.StreamKind
+		// Config contains the settings defined with the WebSocket DSL if
+		// any.
+		Config *WebSocketConfigData
+	}
+
+	// WebSocketConfigData contains the WebSocket settings defined in the
+	// design.
+	WebSocketConfigData struct {
+		// Binary is true if the messages are sent as binary frames.
+		Binary bool
+		// PingInterval is the Go expression for the interval between the
+		// ping messages sent by the server, empty if pings are disabled.
+		PingInterval string
+		// PongTimeout is the Go expression for the duration after which
+		// reads fail if no pong message was received, "0" if disabled.
+		PongTimeout string
 	}
 )
 
@@ -77,11 +96,24 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 		cliSendDesc     string
 		cliRecvDesc     string
 		cliPayload      *TypeData
+		config          *WebSocketConfigData
 
 		md     = ed.Method
 		svc    = sd.Service
 		svcctx = serviceContext(sd.Service.PkgName, sd.Service.Scope)
 	)
+	if ws := e.WebSocket; ws != nil {
+		config = &WebSocketConfigData{
+			Binary:      ws.Framing == expr.WebSocketFramingBinary,
+			PongTimeout: "0",
+		}
+		if ws.PingInterval > 0 {
+			config.PingInterval = codegen.DurationToGo(ws.PingInterval)
+		}
+		if ws.PongTimeout > 0 {
+			config.PongTimeout = codegen.DurationToGo(ws.PongTimeout)
+		}
+	}
 	{
 		svrSendTypeName = ed.Result.Name
 		svrSendTypeRef = ed.Result.Ref
@@ -199,6 +231,7 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 		RecvTypeRef:       svrRecvTypeRef,
 		RecvTypeIsPointer: expr.IsArray(e.MethodExpr.StreamingPayload.Type) || expr.IsMap(e.MethodExpr.StreamingPayload.Type),
 		MustClose:         md.ServerStream.MustClose,
+		Config:            config,
 	}
 	ed.ClientWebSocket = &WebSocketData{
 		VarName:      md.ClientStream.VarName,
@@ -218,6 +251,8 @@ func initWebSocketData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceDa
 		RecvTypeName: svrSendTypeName,
 		RecvTypeRef:  svrSendTypeRef,
 		MustClose:    md.ClientStream.MustClose,
+		CloseAndRecv: md.ClientStream.CloseAndRecv,
+		Config:       config,
 	}
 }
 
@@ -327,6 +362,7 @@ func serverWSSections(data *ServiceData) []*codegen.SectionTemplate {
 					FuncMap: map[string]interface{}{
 						"upgradeParams":    upgradeParams,
 						"viewedServerBody": viewedServerBody,
+						"writeJSON":        writeJSON,
 					},
 				})
 			}
@@ -410,6 +446,7 @@ func clientWSSections(data *ServiceData) []*codegen.SectionTemplate {
 					FuncMap: map[string]interface{}{
 						"upgradeParams":    upgradeParams,
 						"viewedServerBody": viewedServerBody,
+						"writeJSON":        writeJSON,
 					},
 				})
 			}
@@ -421,6 +458,13 @@ func clientWSSections(data *ServiceData) []*codegen.SectionTemplate {
 					FuncMap: map[string]interface{}{"upgradeParams": upgradeParams},
 				})
 			}
+			if e.ClientWebSocket.CloseAndRecv {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-websocket-close-and-recv",
+					Source: webSocketCloseAndRecvT,
+					Data:   e.ClientWebSocket,
+				})
+			}
 			if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
 				sections = append(sections, &codegen.SectionTemplate{
 					Name:   "client-websocket-set-view",
@@ -433,6 +477,15 @@ func clientWSSections(data *ServiceData) []*codegen.SectionTemplate {
 	return sections
 }
 
+// writeJSON returns the Go code that writes the JSON encoding of the value
+// held by the variable v to the stream websocket connection.
+func writeJSON(ws *WebSocketData, v string) string {
+	if ws.Config != nil && ws.Config.Binary {
+		return fmt.Sprintf("goahttp.WriteBinaryJSON(s.conn, %s)", v)
+	}
+	return fmt.Sprintf("s.conn.WriteJSON(%s)", v)
+}
+
 // hasWebSocket returns true if at least one of the endpoints in the service
 // defines a streaming payload or result.
 func hasWebSocket(sd *ServiceData) bool {
@@ -468,6 +521,10 @@ type {{ .VarName }} struct {
 	w http.ResponseWriter
 	{{ comment "r is the HTTP request." }}
 	r *http.Request
+	{{- if and .Config .Config.PingInterval }}
+	{{ comment "stop stops sending the keepalive ping messages." }}
+	stop func()
+	{{- end }}
 {{- end }}
 	{{ comment "conn is the underlying websocket connection." }}
 	conn *websocket.Conn
@@ -519,6 +576,11 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 		var err error
 		{{- template "websocket_upgrade" (upgradeParams .Endpoint .SendName) }}
 	{{- else }} {{/* SendAndClose */}}
+		{{- if and .Config .Config.PingInterval }}
+		if s.stop != nil {
+			defer s.stop()
+		}
+		{{- end }}
 		defer s.conn.Close()
 	{{- end }}
 	{{- if .Endpoint.Method.ViewedResult }}
@@ -550,19 +612,19 @@ func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
 			{{- else }}
 				body := {{ (index .Response.ServerBody 0).Init.Name }}({{ range (index .Response.ServerBody 0).Init.ServerArgs }}{{ .Ref }}, {{ end }})
 			{{- end }}
-			return s.conn.WriteJSON(body)
+			return {{ writeJSON . "body" }}
 		{{- else }}
-			return s.conn.WriteJSON(res)
+			return {{ writeJSON . "res" }}
 		{{- end }}
 	{{- else }}
-		return s.conn.WriteJSON(res)
+		return {{ writeJSON . "res" }}
 	{{- end }}
 {{- else }}
 	{{- if .Payload.Init }}
 		body := {{ .Payload.Init.Name }}(v)
-		return s.conn.WriteJSON(body)
+		return {{ writeJSON . "body" }}
 	{{- else }}
-		return s.conn.WriteJSON(v)
+		return {{ writeJSON . "v" }}
 	{{- end }}
 {{- end }}
 }
@@ -592,6 +654,11 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 	if err = s.conn.ReadJSON(&body); err != nil {
 	{{- else }}
 	if err = s.conn.ReadJSON(&msg); err != nil {
+	{{- end }}
+	{{- if .Config }}
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			return rv, io.EOF
+		}
 	{{- end }}
 		return rv, err
 	}
@@ -625,7 +692,7 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 		}
 	{{- end }}
 	err = s.conn.ReadJSON(&body)
-	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure{{ if .Config }}, websocket.CloseGoingAway{{ end }}) {
 		{{- if not .MustClose }}
 			s.conn.Close()
 		{{- end }}
@@ -690,6 +757,15 @@ func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
 			conn = s.configurer(conn, s.cancel)
 		}
 		s.conn = conn
+		{{- if .Config }}
+			{{- if .Config.PingInterval }}
+		s.stop = goahttp.KeepAlive(conn, {{ .Config.PingInterval }}, {{ .Config.PongTimeout }}, s.cancel)
+			{{- end }}
+			{{- if .ReadControl }}
+		{{ comment "Process the control messages so that closing the connection cancels the request context." }}
+		go goahttp.ReadControl(conn, s.cancel)
+			{{- end }}
+		{{- end }}
 	})
 	if err != nil {
 		return {{ if eq .Function "Recv" }}rv, {{ end }}err
@@ -707,6 +783,11 @@ func (s *{{ .VarName }}) Close() error {
 	if s.conn == nil {
 		return nil
 	}
+	{{- if and .Config .Config.PingInterval }}
+	if s.stop != nil {
+		s.stop()
+	}
+	{{- end }}
 	if err = s.conn.WriteControl(
 		websocket.CloseMessage,
 		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "server closing connection"),
@@ -724,6 +805,20 @@ func (s *{{ .VarName }}) Close() error {
 }
 ` + upgradeT
 
+	// webSocketCloseAndRecvT renders the function implementing the
+	// CloseAndRecv method in client bidirectional stream interface.
+	// input: WebSocketData
+	webSocketCloseAndRecvT = `{{ printf "CloseAndRecv stops sending messages to the %q endpoint websocket connection and reads the next instance of %q from the connection." .Endpoint.Method.Name .RecvTypeName | comment }}
+func (s *{{ .VarName }}) CloseAndRecv() ({{ .RecvTypeRef }}, error) {
+	{{ comment "Send a nil payload to the server implying end of message" }}
+	if err := s.conn.WriteJSON(nil); err != nil {
+		var rv {{ .RecvTypeRef }}
+		return rv, err
+	}
+	return s.{{ .RecvName }}()
+}
+`
+
 	// webSocketSetViewT renders the function implementing the SetView method in
 	// server stream interface.
 	// input: WebSocketData
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// gzipResponseWriter is a http.ResponseWriter which compresses the
+	// response body once it reaches the minimum size.
+	gzipResponseWriter struct {
+		http.ResponseWriter
+		minSize int
+		status  int
+		buf     bytes.Buffer
+		gz      *gzip.Writer
+		// started is true once the headers have been written.
+		started bool
+	}
+)
+
+// GzipMinSize is the default minimum size in bytes of the response bodies
+// compressed by the Gzip middleware.
+const GzipMinSize = 1024
+
+// gzipWriters is the pool of gzip writers used to compress the responses.
+var gzipWriters = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+
+// Gzip returns a middleware which decompresses the request bodies whose
+// Content-Encoding header is "gzip" and compresses the response bodies with
+// gzip when the request Accept-Encoding header accepts it. Response bodies
+// smaller than minSize bytes, responses that already set the Content-Encoding
+// header and WebSocket upgrade requests are left untouched. Requests with an
+// invalid gzip body are rejected with a 400 Bad Request response.
+func Gzip(minSize int) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+				body, err := gzip.NewReader(r.Body)
+				if err != nil {
+					http.Error(w, "invalid gzip request body", http.StatusBadRequest)
+					return
+				}
+				defer body.Close()
+				r.Body = body
+				r.Header.Del("Content-Encoding")
+				r.Header.Del("Content-Length")
+				r.ContentLength = -1
+			}
+			if r.Header.Get("Upgrade") != "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			gw := &gzipResponseWriter{ResponseWriter: w, minSize: minSize}
+			defer gw.close()
+			h.ServeHTTP(gw, r)
+		})
+	}
+}
+
+// acceptsGzip returns true if the given Accept-Encoding header value accepts
+// the gzip encoding.
+func acceptsGzip(header string) bool {
+	for _, enc := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(enc), ";")
+		name = strings.TrimSpace(name)
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		q := 1.0
+		if p := strings.TrimSpace(params); strings.HasPrefix(p, "q=") {
+			if f, err := strconv.ParseFloat(p[2:], 64); err == nil {
+				q = f
+			}
+		}
+		if q > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteHeader records the status code, the headers are written when the
+// response body reaches the minimum size or when the response completes.
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write buffers the response body until it reaches the minimum size, it then
+// writes the headers and compresses the body.
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	if w.started {
+		return w.ResponseWriter.Write(b)
+	}
+	w.buf.Write(b)
+	if w.buf.Len() >= w.minSize {
+		if err := w.start(w.ResponseWriter.Header().Get("Content-Encoding") == ""); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush writes the headers and the buffered body, compressing the body only
+// if it already reached the minimum size, and flushes the response.
+func (w *gzipResponseWriter) Flush() {
+	if !w.started {
+		w.start(false)
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.
+func (w *gzipResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not support hijacking")
+}
+
+// start writes the headers and the buffered body using gzip if compress is
+// true.
+func (w *gzipResponseWriter) start(compress bool) error {
+	w.started = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if compress {
+		w.ResponseWriter.Header().Set("Content-Encoding", "gzip")
+		w.ResponseWriter.Header().Del("Content-Length")
+		w.gz = gzipWriters.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.gz != nil {
+		_, err = w.gz.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+// close writes the response if the body did not reach the minimum size and
+// completes the compressed body otherwise.
+func (w *gzipResponseWriter) close() {
+	if !w.started {
+		if w.status == 0 {
+			return // hijacked or nothing written
+		}
+		w.start(false)
+		return
+	}
+	if w.gz != nil {
+		w.gz.Close()
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGzip(t *testing.T) {
+	large := strings.Repeat("goa", 100)
+	cases := []struct {
+		Name           string
+		AcceptEncoding string
+		Body           string
+		Encoding       string
+		Compressed     bool
+	}{
+		{"not accepted", "", large, "", false},
+		{"refused", "gzip;q=0", large, "", false},
+		{"small", "gzip", "goa", "", false},
+		{"already encoded", "gzip", large, "br", false},
+		{"large", "gzip, deflate", large, "", true},
+		{"wildcard", "*", large, "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := Gzip(100)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if c.Encoding != "" {
+					w.Header().Set("Content-Encoding", c.Encoding)
+				}
+				w.WriteHeader(http.StatusCreated)
+				io.WriteString(w, c.Body)
+			}))
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.AcceptEncoding != "" {
+				r.Header.Set("Accept-Encoding", c.AcceptEncoding)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("got status %d, expected %d", w.Code, http.StatusCreated)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("got Vary header %q, expected %q", got, "Accept-Encoding")
+			}
+			body := w.Body.Bytes()
+			if c.Compressed {
+				if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+					t.Fatalf("got Content-Encoding header %q, expected %q", got, "gzip")
+				}
+				gr, err := gzip.NewReader(bytes.NewReader(body))
+				if err != nil {
+					t.Fatalf("invalid gzip body: %s", err)
+				}
+				body, _ = io.ReadAll(gr)
+			} else if got := w.Header().Get("Content-Encoding"); got != c.Encoding {
+				t.Errorf("got Content-Encoding header %q, expected %q", got, c.Encoding)
+			}
+			if string(body) != c.Body {
+				t.Errorf("got body %q, expected %q", string(body), c.Body)
+			}
+		})
+	}
+}
+
+func TestGzipRequest(t *testing.T) {
+	var compressed bytes.Buffer
+	gw := gzip.NewWriter(&compressed)
+	gw.Write([]byte(`{"name":"goa"}`))
+	gw.Close()
+	cases := []struct {
+		Name     string
+		Body     []byte
+		Encoding string
+		Status   int
+		Expected string
+	}{
+		{"plain", []byte(`{"name":"goa"}`), "", http.StatusOK, `{"name":"goa"}`},
+		{"gzip", compressed.Bytes(), "gzip", http.StatusOK, `{"name":"goa"}`},
+		{"invalid", []byte("not gzip"), "gzip", http.StatusBadRequest, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var got string
+			h := Gzip(GzipMinSize)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if enc := r.Header.Get("Content-Encoding"); enc != "" {
+					t.Errorf("got Content-Encoding header %q, expected none", enc)
+				}
+				b, _ := io.ReadAll(r.Body)
+				got = string(b)
+			}))
+			r := httptest.NewRequest("POST", "/", bytes.NewReader(c.Body))
+			if c.Encoding != "" {
+				r.Header.Set("Content-Encoding", c.Encoding)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if got != c.Expected {
+				t.Errorf("got body %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrPreconditionFailed is the error returned by CheckIfMatch when the
+// If-Match request header does not match the current entity tag. The generated
+// HTTP servers of endpoints that handle conditional requests respond with a 412
+// Precondition Failed status code when the endpoint returns this error.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ETag returns the entity tag computed from the JSON encoding of v. The
+// computation is deterministic: the same value always produces the same tag.
+// The tag is weak ("W/" prefix) if weak is true.
+func ETag(v interface{}, weak bool) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		// Values that cannot be encoded are not expected in the generated
+		// code, fall back to their string representation.
+		b = []byte(err.Error())
+	}
+	sum := sha256.Sum256(b)
+	tag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	if weak {
+		return "W/" + tag
+	}
+	return tag
+}
+
+// MatchETag returns true if the list of entity tags held by the value of a
+// If-Match or If-None-Match header matches etag. The "*" value matches any
+// entity tag. strong indicates whether the strong comparison function
+// defined in RFC 7232 is used: weak entity tags never match using the strong
+// comparison.
+func MatchETag(header, etag string, strong bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	if strong && strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if strings.HasPrefix(t, "W/") {
+			if strong {
+				continue
+			}
+			t = t[2:]
+		}
+		if t == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckConditions evaluates the If-Match and If-None-Match headers of GET and
+// HEAD requests against the entity tag of the response. It returns the status
+// code of the response that must be written instead of the endpoint result or
+// 0 if the conditions are met. The conditions of other requests are not
+// evaluated as the endpoint already modified the resource when the entity tag
+// of the response is known, services use CheckIfMatch instead.
+func CheckConditions(r *http.Request, etag string) int {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return 0
+	}
+	if im := r.Header.Get("If-Match"); im != "" {
+		if !MatchETag(im, etag, true) {
+			return http.StatusPreconditionFailed
+		}
+	}
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if MatchETag(inm, etag, false) {
+			return http.StatusNotModified
+		}
+	}
+	return 0
+}
+
+// CheckIfMatch compares the value of the If-Match request header with the
+// entity tag computed from v using ETag. It returns ErrPreconditionFailed if
+// the request defines the header and the tags do not match, nil otherwise.
+// Services call CheckIfMatch with the current value of the attribute used to
+// compute the entity tags before modifying a resource so that concurrent
+// updates are detected. It relies on the request header being stored in the
+// context by the generated HTTP server.
+func CheckIfMatch(ctx context.Context, v interface{}) error {
+	im, _ := ctx.Value(IfMatchKey).(string)
+	if im == "" {
+		return nil
+	}
+	if !MatchETag(im, ETag(v, false), true) {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
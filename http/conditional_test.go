@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestETag(t *testing.T) {
+	strong := ETag(map[string]interface{}{"b": 2, "a": "x"}, false)
+	if again := ETag(map[string]interface{}{"a": "x", "b": 2}, false); again != strong {
+		t.Errorf("got %s, expected deterministic tag %s", again, strong)
+	}
+	if other := ETag("v2", false); other == strong {
+		t.Errorf("got identical tags for different values")
+	}
+	if weak := ETag(map[string]interface{}{"a": "x", "b": 2}, true); weak != "W/"+strong {
+		t.Errorf("got weak tag %s, expected %s", weak, "W/"+strong)
+	}
+}
+
+func TestMatchETag(t *testing.T) {
+	cases := map[string]struct {
+		header   string
+		etag     string
+		strong   bool
+		expected bool
+	}{
+		"any":                {"*", `"a"`, true, true},
+		"equal":              {`"a"`, `"a"`, true, true},
+		"list":               {`"b", "a"`, `"a"`, true, true},
+		"different":          {`"b"`, `"a"`, true, false},
+		"weak header":        {`W/"a"`, `"a"`, false, true},
+		"weak header strong": {`W/"a"`, `"a"`, true, false},
+		"weak tag":           {`"a"`, `W/"a"`, false, true},
+		"weak tag strong":    {`"a"`, `W/"a"`, true, false},
+	}
+	for k, tc := range cases {
+		if actual := MatchETag(tc.header, tc.etag, tc.strong); actual != tc.expected {
+			t.Errorf("%s: got %v, expected %v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestCheckConditions(t *testing.T) {
+	const etag = `"a"`
+	cases := map[string]struct {
+		method   string
+		header   string
+		value    string
+		expected int
+	}{
+		"no condition":          {"GET", "", "", 0},
+		"if-none-match get":     {"GET", "If-None-Match", `"a"`, http.StatusNotModified},
+		"if-none-match weak":    {"HEAD", "If-None-Match", `W/"a"`, http.StatusNotModified},
+		"if-none-match changed": {"GET", "If-None-Match", `"b"`, 0},
+		"if-none-match put":     {"PUT", "If-None-Match", "*", 0},
+		"if-match get":          {"GET", "If-Match", `"a"`, 0},
+		"if-match get changed":  {"GET", "If-Match", `"b"`, http.StatusPreconditionFailed},
+		"if-match put":          {"PUT", "If-Match", `"b"`, 0},
+	}
+	for k, tc := range cases {
+		r := httptest.NewRequest(tc.method, "/", nil)
+		if tc.header != "" {
+			r.Header.Set(tc.header, tc.value)
+		}
+		if actual := CheckConditions(r, etag); actual != tc.expected {
+			t.Errorf("%s: got %d, expected %d", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	current := ETag("v1", false)
+	cases := map[string]struct {
+		header   string
+		expected error
+	}{
+		"no header": {"", nil},
+		"any":       {"*", nil},
+		"match":     {current, nil},
+		"mismatch":  {ETag("v0", false), ErrPreconditionFailed},
+		"weak":      {"W/" + current, ErrPreconditionFailed},
+	}
+	for k, tc := range cases {
+		ctx := context.WithValue(context.Background(), IfMatchKey, tc.header)
+		if actual := CheckIfMatch(ctx, "v1"); actual != tc.expected {
+			t.Errorf("%s: got %v, expected %v", k, actual, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package http
+
+import "strings"
+
+// MatchOrigin returns true if the given request origin matches the origin
+// specification of a CORS policy. The specification may be "*" to match any
+// origin or may contain a single "*" wildcard in place of a sub-domain, for
+// example "https://*.example.com".
+func MatchOrigin(origin, spec string) bool {
+	if spec == "*" || origin == spec {
+		return true
+	}
+	idx := strings.IndexByte(spec, '*')
+	if idx < 0 {
+		return false
+	}
+	prefix, suffix := spec[:idx], spec[idx+1:]
+	if len(origin) <= len(prefix)+len(suffix) {
+		return false
+	}
+	if !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+	// The wildcard must not match across the scheme or port separators.
+	return !strings.ContainsAny(origin[len(prefix):len(origin)-len(suffix)], "/:")
+}
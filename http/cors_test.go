@@ -0,0 +1,29 @@
+package http
+
+import "testing"
+
+func TestMatchOrigin(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Origin   string
+		Spec     string
+		Expected bool
+	}{
+		{"wildcard", "https://app.example.com", "*", true},
+		{"exact", "https://app.example.com", "https://app.example.com", true},
+		{"different", "https://app.example.com", "https://api.example.com", false},
+		{"sub-domain", "https://app.example.com", "https://*.example.com", true},
+		{"nested sub-domain", "https://a.b.example.com", "https://*.example.com", true},
+		{"apex", "https://example.com", "https://*.example.com", false},
+		{"scheme", "http://app.example.com", "https://*.example.com", false},
+		{"port", "https://app.example.com:8080", "https://*.example.com", false},
+		{"suffix", "https://app.example.com.evil.com", "https://*.example.com", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := MatchOrigin(c.Origin, c.Spec); actual != c.Expected {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -23,6 +24,16 @@ const (
 	// response Content-Type header when explicitly set in the DSL. The value
 	// may be used by encoders to set the header appropriately.
 	ContentTypeKey
+
+	// IfMatchKey is the context key used to store the value of the HTTP
+	// request If-Match header for endpoints that handle conditional
+	// requests. The value is used by CheckIfMatch.
+	IfMatchKey
+
+	// IdempotentKey is the context key used to store whether a client
+	// request is idempotent when the design declares it explicitly. The
+	// value is used by IsIdempotent.
+	IdempotentKey
 )
 
 type (
@@ -54,6 +65,8 @@ type (
 //   - application/json using package encoding/json
 //   - application/xml using package encoding/xml
 //   - application/gob using package encoding/gob
+//   - application/x-www-form-urlencoded for structs, see the "form" struct
+//     field tags
 //   - text/html and text/plain for strings
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
@@ -77,6 +90,8 @@ func RequestDecoder(r *http.Request) Decoder {
 		return gob.NewDecoder(r.Body)
 	case "application/xml":
 		return xml.NewDecoder(r.Body)
+	case "application/x-www-form-urlencoded":
+		return newFormDecoder(r.Body)
 	case "text/html", "text/plain":
 		return newTextDecoder(r.Body, contentType)
 	default:
@@ -166,15 +181,95 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 	return enc
 }
 
+// NegotiateContentType returns the media type that best matches the value of
+// the request Accept header stored in the context under the AcceptTypeKey. The
+// candidate media types are listed in order of preference in offered. The
+// first offered media type is returned if the request does not define an
+// Accept header. NegotiateContentType returns false if none of the offered
+// media types is acceptable.
+func NegotiateContentType(ctx context.Context, offered ...string) (string, bool) {
+	if len(offered) == 0 {
+		return "", false
+	}
+	var accept string
+	if a := ctx.Value(AcceptTypeKey); a != nil {
+		accept = a.(string)
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offered[0], true
+	}
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, o := range offered {
+		if q := acceptQuality(accept, o); q > bestQ {
+			best, bestQ = o, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// acceptQuality returns the quality factor assigned to the media type mt by
+// the most specific media range of the Accept header value accept. mt is
+// parsed like the media ranges so that its case and parameters are ignored.
+func acceptQuality(accept, mt string) float64 {
+	if pmt, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = pmt
+	} else {
+		mt = strings.ToLower(strings.TrimSpace(mt))
+	}
+	typ, sub, _ := strings.Cut(mt, "/")
+	var (
+		q           float64
+		specificity = -1
+	)
+	for _, r := range strings.Split(accept, ",") {
+		rmt, params, err := mime.ParseMediaType(strings.TrimSpace(r))
+		if err != nil {
+			continue
+		}
+		rtyp, rsub, _ := strings.Cut(rmt, "/")
+		var s int
+		switch {
+		case rtyp == typ && rsub == sub:
+			s = 2
+		case rtyp == typ && rsub == "*":
+			s = 1
+		case rtyp == "*" && rsub == "*":
+			s = 0
+		default:
+			continue
+		}
+		if s <= specificity {
+			continue
+		}
+		specificity = s
+		q = 1
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+	}
+	return q
+}
+
 // RequestEncoder returns a HTTP request encoder.
-// The encoder uses package encoding/json.
+// The encoder encodes application/x-www-form-urlencoded bodies as forms when
+// the request "Content-Type" header is set accordingly and uses package
+// encoding/json otherwise.
 func RequestEncoder(r *http.Request) Encoder {
 	const k = "Content-Type"
-	if h := r.Header.Get(k); h == "" {
+	h := r.Header.Get(k)
+	if h == "" {
 		r.Header.Set(k, "application/json")
 	}
 	var buf bytes.Buffer
 	r.Body = io.NopCloser(&buf)
+	if mediaType, _, err := mime.ParseMediaType(h); err == nil && mediaType == "application/x-www-form-urlencoded" {
+		return newFormEncoder(&buf)
+	}
 	return json.NewEncoder(&buf)
 }
 
@@ -215,13 +310,18 @@ func ResponseDecoder(resp *http.Response) Decoder {
 // provided encoder. If the error is not a goa ServiceError struct then it is
 // encoded as a permanent internal server error. This behavior as well as the
 // shape of the response can be overridden by providing a non-nil formatter.
+// The response content type is the value returned by the ContentType method of
+// the formatted error if any (see ProblemDetails).
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
-		enc := encoder(ctx, w)
 		if formatter == nil {
 			formatter = NewErrorResponse
 		}
 		resp := formatter(ctx, err)
+		if ct, ok := resp.(interface{ ContentType() string }); ok {
+			ctx = context.WithValue(ctx, ContentTypeKey, ct.ContentType())
+		}
+		enc := encoder(ctx, w)
 		w.WriteHeader(resp.StatusCode())
 		return enc.Encode(resp)
 	}
@@ -104,6 +104,44 @@ func TestResponseEncoder(t *testing.T) {
 	}
 }
 
+func TestNegotiateContentType(t *testing.T) {
+	offered := []string{"application/json", "application/xml"}
+	cases := []struct {
+		name       string
+		acceptType string
+		offered    []string
+		wantCT     string
+		wantOK     bool
+	}{
+		{"no accept", "", nil, "application/json", true},
+		{"json", "application/json", nil, "application/json", true},
+		{"xml", "application/xml", nil, "application/xml", true},
+		{"xml with params", "application/xml; charset=utf-8", nil, "application/xml", true},
+		{"any", "*/*", nil, "application/json", true},
+		{"sub-type wildcard", "application/*", nil, "application/json", true},
+		{"quality", "application/json;q=0.5, application/xml", nil, "application/xml", true},
+		{"specific over wildcard", "application/*;q=0.9, application/json;q=0.1", nil, "application/xml", true},
+		{"refused", "application/xml;q=0, */*", nil, "application/json", true},
+		{"unsupported", "text/html", nil, "", false},
+		{"all refused", "application/*;q=0", nil, "", false},
+		{"offered upper case", "application/xml", []string{"application/json", "application/XML"}, "application/XML", true},
+		{"offered with params", "application/json", []string{"application/json; charset=utf-8"}, "application/json; charset=utf-8", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.acceptType)
+			o := offered
+			if c.offered != nil {
+				o = c.offered
+			}
+			ct, ok := NegotiateContentType(ctx, o...)
+			if ct != c.wantCT || ok != c.wantOK {
+				t.Errorf("got (%q, %v), want (%q, %v)", ct, ok, c.wantCT, c.wantOK)
+			}
+		})
+	}
+}
+
 func TestResponseDecoder(t *testing.T) {
 	cases := []struct {
 		contentType string
@@ -0,0 +1,227 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// formEncoder encodes structs as application/x-www-form-urlencoded
+	// bodies.
+	formEncoder struct {
+		w io.Writer
+	}
+
+	// formDecoder decodes application/x-www-form-urlencoded bodies into
+	// structs.
+	formDecoder struct {
+		r io.Reader
+	}
+)
+
+// newFormEncoder returns an encoder that writes the fields of the encoded
+// structs as form values. The names of the values are given by the "form"
+// struct field tags. Slices of primitive values are encoded using repeated
+// keys. Nil pointers and slices are omitted.
+func newFormEncoder(w io.Writer) Encoder {
+	return &formEncoder{w}
+}
+
+// newFormDecoder returns a decoder that reads form values into the fields of
+// a struct. The names of the values are given by the "form" struct field tags.
+// Slice fields accept repeated keys.
+func newFormDecoder(r io.Reader) Decoder {
+	return &formDecoder{r}
+}
+
+// Encode encodes v which must be a struct or a pointer to a struct.
+func (e *formEncoder) Encode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("can't encode %T as a form", v)
+	}
+	values := make(url.Values)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, ok := formFieldName(rt.Field(i))
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+			for j := 0; j < fv.Len(); j++ {
+				s, err := formatFormValue(fv.Index(j))
+				if err != nil {
+					return fmt.Errorf("can't encode form field %q: %w", name, err)
+				}
+				values.Add(name, s)
+			}
+			continue
+		}
+		s, err := formatFormValue(fv)
+		if err != nil {
+			return fmt.Errorf("can't encode form field %q: %w", name, err)
+		}
+		values.Set(name, s)
+	}
+	_, err := io.WriteString(e.w, values.Encode())
+	return err
+}
+
+// Decode decodes the form into v which must be a pointer to a struct.
+func (d *formDecoder) Decode(v interface{}) error {
+	b, err := io.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	values, err := url.ParseQuery(string(b))
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("can't decode a form into %T", v)
+	}
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("can't decode a form into %T", v)
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, ok := formFieldName(rt.Field(i))
+		if !ok {
+			continue
+		}
+		vals, ok := values[name]
+		if !ok || len(vals) == 0 {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Ptr {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+			sl := reflect.MakeSlice(fv.Type(), len(vals), len(vals))
+			for j, s := range vals {
+				if err := parseFormValue(sl.Index(j), s); err != nil {
+					return fmt.Errorf("invalid value for form field %q: %w", name, err)
+				}
+			}
+			fv.Set(sl)
+			continue
+		}
+		if err := parseFormValue(fv, vals[0]); err != nil {
+			return fmt.Errorf("invalid value for form field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// formFieldName returns the name of the form value corresponding to the given
+// struct field and false if the field is not encoded.
+func formFieldName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" {
+		return "", false // unexported
+	}
+	tag := f.Tag.Get("form")
+	if tag == "-" {
+		return "", false
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return f.Name, true
+}
+
+// formatFormValue returns the string representation of a primitive value.
+func formatFormValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// parseFormValue sets v to the value represented by s.
+func parseFormValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		v.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type formBody struct {
+	Name     *string  `form:"name,omitempty" json:"name,omitempty"`
+	Age      *int     `form:"age,omitempty" json:"age,omitempty"`
+	Score    float64  `form:"score" json:"score"`
+	Admin    *bool    `form:"admin,omitempty" json:"admin,omitempty"`
+	Tags     []string `form:"tags,omitempty" json:"tags,omitempty"`
+	Ignored  string   `form:"-" json:"ignored"`
+	internal string
+}
+
+func TestFormDecoder(t *testing.T) {
+	var (
+		name  = "goa"
+		age   = 42
+		admin = true
+	)
+	cases := []struct {
+		Name     string
+		Body     string
+		Expected *formBody
+		Error    string
+	}{
+		{"empty", "", &formBody{}, ""},
+		{"fields", "name=goa&age=42&score=1.5&admin=true", &formBody{Name: &name, Age: &age, Score: 1.5, Admin: &admin}, ""},
+		{"repeated-keys", "tags=a&tags=b", &formBody{Tags: []string{"a", "b"}}, ""},
+		{"ignored", "Ignored=x&internal=y", &formBody{}, ""},
+		{"invalid-int", "age=old", nil, `invalid value for form field "age"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			var body formBody
+			err := RequestDecoder(r).Decode(&body)
+			if c.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), c.Error) {
+					t.Fatalf("got error %v, expected to contain %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(&body, c.Expected) {
+				t.Errorf("got %+v, expected %+v", body, *c.Expected)
+			}
+		})
+	}
+}
+
+func TestFormEncoder(t *testing.T) {
+	var (
+		name  = "goa"
+		admin = false
+	)
+	cases := []struct {
+		Name     string
+		Body     interface{}
+		Expected string
+	}{
+		{"empty", &formBody{}, "score=0"},
+		{"fields", &formBody{Name: &name, Score: 1.5, Admin: &admin}, "admin=false&name=goa&score=1.5"},
+		{"repeated-keys", &formBody{Tags: []string{"a", "b"}}, "score=0&tags=a&tags=b"},
+		{"pointer-to-pointer", func() interface{} { b := &formBody{Name: &name}; return &b }(), "name=goa&score=0"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", nil)
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if err := RequestEncoder(r).Encode(c.Body); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var buf bytes.Buffer
+			buf.ReadFrom(r.Body)
+			if got := buf.String(); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// IdempotencyStore is the interface implemented by the stores used by
+	// the generated HTTP servers to record the responses to idempotent
+	// requests.
+	IdempotencyStore interface {
+		// Begin reserves key for the duration of a request. hash
+		// identifies the request sent with the key. Begin returns the
+		// response recorded for key if a prior request using the same key
+		// and hash completed, ErrIdempotencyKeyReused if the prior request
+		// has a different hash, ErrIdempotencyKeyInUse if a request using
+		// the key is still being processed and nil otherwise.
+		Begin(ctx context.Context, key, hash string, ttl time.Duration) (*IdempotentResponse, error)
+		// Complete records the response for key and releases it.
+		Complete(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error
+		// Release releases key without recording a response so that the
+		// request may be retried.
+		Release(ctx context.Context, key string) error
+	}
+
+	// IdempotentResponse is a recorded HTTP response.
+	IdempotentResponse struct {
+		// StatusCode is the response status code.
+		StatusCode int
+		// Header contains the response headers.
+		Header http.Header
+		// Body is the response body.
+		Body []byte
+	}
+
+	// idempotencyRecorder is a http.ResponseWriter which records the
+	// response while writing it.
+	idempotencyRecorder struct {
+		http.ResponseWriter
+		status int
+		header http.Header
+		body   bytes.Buffer
+	}
+
+	// memoryIdempotencyStore is an in-memory implementation of
+	// IdempotencyStore.
+	memoryIdempotencyStore struct {
+		mu      sync.Mutex
+		entries map[string]*memoryIdempotencyEntry
+		// swept is the last time the expired entries were deleted.
+		swept time.Time
+	}
+
+	// memoryIdempotencyEntry is a single entry of memoryIdempotencyStore.
+	memoryIdempotencyEntry struct {
+		hash    string
+		resp    *IdempotentResponse
+		expires time.Time
+	}
+)
+
+// IdempotencyMaxBodySize is the maximum size in bytes of the bodies of the
+// requests that carry an idempotency key. The body is read in memory to detect
+// keys reused with a different request.
+const IdempotencyMaxBodySize = 10 << 20
+
+// memoryIdempotencySweepInterval is the minimum duration between two deletions
+// of the expired entries of memoryIdempotencyStore.
+const memoryIdempotencySweepInterval = time.Minute
+
+// ErrIdempotencyKeyInUse is the error returned by IdempotencyStore.Begin when
+// a request using the same idempotency key is still being processed.
+var ErrIdempotencyKeyInUse = errors.New("idempotency key in use")
+
+// ErrIdempotencyKeyReused is the error returned by IdempotencyStore.Begin when
+// the idempotency key was used by a prior request with a different method, URI
+// or body.
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+
+// Idempotent returns a middleware which replays the response recorded for the
+// value of the given request header. scope identifies the endpoint and is
+// prepended to the key so that the same key may be used with different
+// endpoints. Requests that do not set the header are handled normally.
+// Requests using a key that is still being processed are rejected with a 409
+// Conflict response and requests reusing a key with a different method, URI or
+// body are rejected with a 422 Unprocessable Entity response. Requests whose
+// body exceeds IdempotencyMaxBodySize are rejected with a 413 Request Entity
+// Too Large response. Only the 2xx and 4xx responses are recorded, the key is
+// released after other responses so that the request may be retried.
+func Idempotent(store IdempotencyStore, scope, header string, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(header)
+			if key == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			hash, err := requestHash(w, r)
+			if err != nil {
+				var mbe *http.MaxBytesError
+				if errors.As(err, &mbe) {
+					http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			ctx := r.Context()
+			key = scope + ":" + key
+			resp, err := store.Begin(ctx, key, hash, ttl)
+			if err != nil {
+				switch {
+				case errors.Is(err, ErrIdempotencyKeyInUse):
+					http.Error(w, "a request with the same "+header+" header is being processed", http.StatusConflict)
+				case errors.Is(err, ErrIdempotencyKeyReused):
+					http.Error(w, "the "+header+" header was used with a different request", http.StatusUnprocessableEntity)
+				default:
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+				return
+			}
+			if resp != nil {
+				for k, v := range resp.Header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(resp.StatusCode)
+				w.Write(resp.Body)
+				return
+			}
+			completed := false
+			defer func() {
+				if !completed {
+					store.Release(ctx, key)
+				}
+			}()
+			rec := &idempotencyRecorder{ResponseWriter: w}
+			h.ServeHTTP(rec, r)
+			resp = rec.response()
+			if class := resp.StatusCode / 100; class != 2 && class != 4 {
+				return
+			}
+			if err := store.Complete(ctx, key, resp, ttl); err == nil {
+				completed = true
+			}
+		})
+	}
+}
+
+// requestHash returns the hex encoded SHA-256 hash of the method, URI and body
+// of r. It reads at most IdempotencyMaxBodySize bytes of the body and replaces
+// it so that it can be read again.
+func requestHash(w http.ResponseWriter, r *http.Request) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, IdempotencyMaxBodySize))
+		r.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// IsIdempotent returns true if sending req multiple times has the same effect
+// as sending it once. Retry middlewares may use IsIdempotent to decide whether
+// a failed request may be sent again. IsIdempotent uses the value stored in the
+// request context under IdempotentKey by the generated client when the design
+// declares the idempotency of the method explicitly. It otherwise infers the
+// idempotency from the request HTTP method.
+func IsIdempotent(req *http.Request) bool {
+	if v, ok := req.Context().Value(IdempotentKey).(bool); ok {
+		return v
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// NewMemoryIdempotencyStore returns an IdempotencyStore which keeps the
+// recorded responses in memory. It is intended for tests and single instance
+// deployments.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{entries: make(map[string]*memoryIdempotencyEntry)}
+}
+
+// Begin reserves key unless a response was recorded or a request using the
+// key is still being processed. It deletes the expired entries at most once per
+// minute.
+func (s *memoryIdempotencyStore) Begin(_ context.Context, key, hash string, ttl time.Duration) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.Sub(s.swept) >= memoryIdempotencySweepInterval {
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.swept = now
+	}
+	if e, ok := s.entries[key]; ok && now.Before(e.expires) {
+		switch {
+		case e.hash != hash:
+			return nil, ErrIdempotencyKeyReused
+		case e.resp == nil:
+			return nil, ErrIdempotencyKeyInUse
+		}
+		return e.resp, nil
+	}
+	s.entries[key] = &memoryIdempotencyEntry{hash: hash, expires: now.Add(ttl)}
+	return nil, nil
+}
+
+// Complete records resp for key.
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		e = &memoryIdempotencyEntry{}
+		s.entries[key] = e
+	}
+	e.resp = resp
+	e.expires = time.Now().Add(ttl)
+	return nil
+}
+
+// Release deletes key.
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// WriteHeader records the status code and headers before writing them.
+func (w *idempotencyRecorder) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the body before writing it.
+func (w *idempotencyRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes the wrapped writer if it implements http.Flusher.
+func (w *idempotencyRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// response returns the recorded response.
+func (w *idempotencyRecorder) response() *IdempotentResponse {
+	if w.status == 0 {
+		w.status = http.StatusOK
+		w.header = w.ResponseWriter.Header().Clone()
+	}
+	return &IdempotentResponse{
+		StatusCode: w.status,
+		Header:     w.header,
+		Body:       w.body.Bytes(),
+	}
+}
@@ -0,0 +1,245 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdempotent(t *testing.T) {
+	var calls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Location", "/payments/1")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	})
+	h := Idempotent(NewMemoryIdempotencyStore(), "svc.method", "Idempotency-Key", time.Hour)(handler)
+
+	serve := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/payments", nil)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	first := serve("key")
+	replayed := serve("key")
+	if calls != 1 {
+		t.Errorf("got %d calls, expected 1", calls)
+	}
+	for _, w := range []*httptest.ResponseRecorder{first, replayed} {
+		if w.Code != http.StatusCreated {
+			t.Errorf("got status %d, expected %d", w.Code, http.StatusCreated)
+		}
+		if l := w.Header().Get("Location"); l != "/payments/1" {
+			t.Errorf("got location %q, expected %q", l, "/payments/1")
+		}
+		if b := w.Body.String(); b != "created" {
+			t.Errorf("got body %q, expected %q", b, "created")
+		}
+	}
+
+	serve("other")
+	serve("")
+	serve("")
+	if calls != 4 {
+		t.Errorf("got %d calls, expected 4", calls)
+	}
+}
+
+func TestIdempotentConflict(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	var inner *httptest.ResponseRecorder
+	var h http.Handler
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simulate a concurrent request made while this one is processed.
+		inner = httptest.NewRecorder()
+		h.ServeHTTP(inner, r)
+		w.WriteHeader(http.StatusOK)
+	})
+	h = Idempotent(store, "svc.method", "Idempotency-Key", time.Hour)(handler)
+
+	req := httptest.NewRequest("POST", "/payments", nil)
+	req.Header.Set("Idempotency-Key", "key")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if inner.Code != http.StatusConflict {
+		t.Errorf("got status %d for concurrent request, expected %d", inner.Code, http.StatusConflict)
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestIdempotentReleaseOnPanic(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	h := Idempotent(store, "svc.method", "Idempotency-Key", time.Hour)(handler)
+
+	func() {
+		defer func() { recover() }()
+		req := httptest.NewRequest("POST", "/payments", nil)
+		req.Header.Set("Idempotency-Key", "key")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	resp, err := store.Begin(context.Background(), "svc.method:key", "", time.Hour)
+	if err != nil {
+		t.Fatalf("got error %v, expected key to be released", err)
+	}
+	if resp != nil {
+		t.Errorf("got recorded response, expected none")
+	}
+}
+
+func TestIdempotentServerError(t *testing.T) {
+	var calls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	h := Idempotent(NewMemoryIdempotencyStore(), "svc.method", "Idempotency-Key", time.Hour)(handler)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/payments", nil)
+		req.Header.Set("Idempotency-Key", "key")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, expected 2", calls)
+	}
+}
+
+func TestIdempotentReusedKey(t *testing.T) {
+	var bodies []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusCreated)
+	})
+	h := Idempotent(NewMemoryIdempotencyStore(), "svc.method", "Idempotency-Key", time.Hour)(handler)
+
+	serve := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/payments", strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", "key")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := serve(`{"amount":1}`); w.Code != http.StatusCreated {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusCreated)
+	}
+	if w := serve(`{"amount":1}`); w.Code != http.StatusCreated {
+		t.Errorf("got status %d for replayed request, expected %d", w.Code, http.StatusCreated)
+	}
+	if w := serve(`{"amount":2}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("got status %d for reused key, expected %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if len(bodies) != 1 || bodies[0] != `{"amount":1}` {
+		t.Errorf("got bodies %q, expected the first body only", bodies)
+	}
+}
+
+func TestIdempotentBodyTooLarge(t *testing.T) {
+	var calls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	h := Idempotent(NewMemoryIdempotencyStore(), "svc.method", "Idempotency-Key", time.Hour)(handler)
+
+	body := strings.Repeat("a", IdempotencyMaxBodySize+1)
+	req := httptest.NewRequest("POST", "/payments", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", "key")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	if calls != 0 {
+		t.Errorf("got %d calls, expected 0", calls)
+	}
+}
+
+func TestIdempotentFlush(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("got writer that does not implement http.Flusher")
+		}
+		w.Write([]byte("partial"))
+		f.Flush()
+	})
+	h := Idempotent(NewMemoryIdempotencyStore(), "svc.method", "Idempotency-Key", time.Hour)(handler)
+
+	req := httptest.NewRequest("POST", "/payments", nil)
+	req.Header.Set("Idempotency-Key", "key")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if !w.Flushed {
+		t.Error("got unflushed response, expected the wrapped writer to be flushed")
+	}
+}
+
+func TestMemoryIdempotencyStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryIdempotencyStore().(*memoryIdempotencyStore)
+	if _, err := store.Begin(ctx, "expired", "", -time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Begin(ctx, "live", "", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.entries) != 2 {
+		t.Fatalf("got %d entries, expected 2 before the next sweep", len(store.entries))
+	}
+	store.swept = time.Time{}
+	if _, err := store.Begin(ctx, "other", "", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.entries["expired"]; ok {
+		t.Error("got expired entry, expected it to be deleted")
+	}
+	if len(store.entries) != 2 {
+		t.Errorf("got %d entries, expected 2", len(store.entries))
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Method   string
+		Value    interface{}
+		Expected bool
+	}{
+		{"get", "GET", nil, true},
+		{"put", "PUT", nil, true},
+		{"delete", "DELETE", nil, true},
+		{"post", "POST", nil, false},
+		{"patch", "PATCH", nil, false},
+		{"explicit-post", "POST", true, true},
+		{"explicit-get", "GET", false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest(c.Method, "/", nil)
+			if c.Value != nil {
+				req = req.WithContext(context.WithValue(req.Context(), IdempotentKey, c.Value))
+			}
+			if got := IsIdempotent(req); got != c.Expected {
+				t.Errorf("got %v, expected %v", got, c.Expected)
+			}
+		})
+	}
+}
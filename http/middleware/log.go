@@ -1,7 +1,11 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"time"
@@ -9,6 +13,26 @@ import (
 	"goa.design/goa/v3/middleware"
 )
 
+type (
+	// logDoer is a client Doer that logs the requests it makes and the
+	// corresponding responses.
+	logDoer struct {
+		Doer
+		logger middleware.Logger
+		svc    string
+		method string
+		body   bool
+		redact map[string]struct{}
+	}
+
+	// LogDoerOption is a function that configures the client Doer returned by
+	// WrapLogDoer.
+	LogDoerOption func(*logDoer)
+)
+
+// redacted is the value logged in place of the sensitive fields.
+const redacted = "[REDACTED]"
+
 // Log returns a middleware that logs incoming HTTP requests and outgoing
 // responses. The middleware uses the request ID set by the RequestID middleware
 // or creates a short unique request ID if missing for each incoming request and
@@ -64,6 +88,110 @@ func log(l middleware.Logger, r *http.Request, w http.ResponseWriter, next http.
 		"time", time.Since(started).String())
 }
 
+// WrapLogDoer wraps a goa client Doer and logs the requests it makes to the
+// given service method. The logs include the request HTTP method and URL, the
+// response status code and the duration of the request. The request ID stored
+// in the request context by the RequestID middleware is logged if any.
+func WrapLogDoer(doer Doer, l middleware.Logger, svc, method string, opts ...LogDoerOption) Doer {
+	d := &logDoer{Doer: doer, logger: l, svc: svc, method: method}
+	for _, o := range opts {
+		o(d)
+	}
+	return d
+}
+
+// LogBodyOption enables the logging of the request and response bodies. The
+// values of the JSON object fields with the given names are replaced with
+// "[REDACTED]" at any depth. Bodies that are not JSON are not logged when
+// fields must be redacted. The bodies are read in full and replaced so that
+// they can be read again by the client and the caller.
+func LogBodyOption(redact ...string) LogDoerOption {
+	return func(d *logDoer) {
+		d.body = true
+		d.redact = make(map[string]struct{}, len(redact))
+		for _, f := range redact {
+			d.redact[f] = struct{}{}
+		}
+	}
+}
+
+// Do makes the request and logs it together with the response.
+func (d *logDoer) Do(r *http.Request) (*http.Response, error) {
+	keyvals := []interface{}{"svc", d.svc, "method", d.method}
+	if id, ok := r.Context().Value(middleware.RequestIDKey).(string); ok && id != "" {
+		keyvals = append([]interface{}{"id", id}, keyvals...)
+	}
+	keyvals = append(keyvals, "req", r.Method+" "+r.URL.String())
+	if d.body && r.Body != nil && r.Body != http.NoBody {
+		b, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(b)), nil
+		}
+		keyvals = append(keyvals, "req-body", d.redactBody(b))
+	}
+	started := time.Now()
+	resp, err := d.Doer.Do(r)
+	keyvals = append(keyvals, "time", time.Since(started).String())
+	if err != nil {
+		d.logger.Log(append(keyvals, "err", err)...)
+		return resp, err
+	}
+	keyvals = append(keyvals, "status", resp.StatusCode)
+	if d.body && resp.Body != nil {
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			d.logger.Log(append(keyvals, "err", err)...)
+			return resp, err
+		}
+		keyvals = append(keyvals, "resp-body", d.redactBody(b))
+	}
+	d.logger.Log(keyvals...)
+	return resp, nil
+}
+
+// redactBody returns the string logged for the given body.
+func (d *logDoer) redactBody(b []byte) string {
+	if len(d.redact) == 0 {
+		return string(b)
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Sprintf("[%d bytes]", len(b))
+	}
+	r, err := json.Marshal(redactValue(v, d.redact))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes]", len(b))
+	}
+	return string(r)
+}
+
+// redactValue replaces the values of the object fields with the given names
+// with "[REDACTED]" recursively.
+func redactValue(v interface{}, fields map[string]struct{}) interface{} {
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		for k, val := range actual {
+			if _, ok := fields[k]; ok {
+				actual[k] = redacted
+				continue
+			}
+			actual[k] = redactValue(val, fields)
+		}
+	case []interface{}:
+		for i, val := range actual {
+			actual[i] = redactValue(val, fields)
+		}
+	}
+	return v
+}
+
 // from makes a best effort to compute the request client IP.
 func from(req *http.Request) string {
 	if f := req.Header.Get("X-Forwarded-For"); f != "" {
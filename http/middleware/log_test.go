@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+	"goa.design/goa/v3/middleware"
+)
+
+func TestWrapLogDoer(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Options  []httpm.LogDoerOption
+		Body     string
+		Response string
+		Expected string
+	}{
+		{"no body", nil, `{"name":"goa"}`, `{"id":1}`,
+			"id=request-id svc=svc method=method req=POST /users time=* status=200"},
+		{"body", []httpm.LogDoerOption{httpm.LogBodyOption()}, `{"name":"goa"}`, `{"id":1}`,
+			`id=request-id svc=svc method=method req=POST /users req-body={"name":"goa"} time=* status=200 resp-body={"id":1}`},
+		{"redacted", []httpm.LogDoerOption{httpm.LogBodyOption("ssn")}, `{"name":"goa","ssn":"123"}`, `{"users":[{"ssn":"456"}]}`,
+			`id=request-id svc=svc method=method req=POST /users req-body={"name":"goa","ssn":"[REDACTED]"} time=* status=200 resp-body={"users":[{"ssn":"[REDACTED]"}]}`},
+		{"redacted not JSON", []httpm.LogDoerOption{httpm.LogBodyOption("ssn")}, "name=goa", "ok",
+			"id=request-id svc=svc method=method req=POST /users req-body=[8 bytes] time=* status=200 resp-body=[2 bytes]"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := middleware.NewLogger(log.New(&buf, "", 0))
+			ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "request-id")
+			req, _ := http.NewRequestWithContext(ctx, "POST", "/users", strings.NewReader(c.Body))
+			doer := &logTestDoer{response: c.Response}
+
+			resp, err := httpm.WrapLogDoer(doer, logger, "svc", "method", c.Options...).Do(req)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if doer.body != c.Body {
+				t.Errorf("got request body %q, expected %q", doer.body, c.Body)
+			}
+			if b, _ := io.ReadAll(resp.Body); string(b) != c.Response {
+				t.Errorf("got response body %q, expected %q", string(b), c.Response)
+			}
+			got := strings.TrimSpace(buf.String())
+			prefix, suffix, _ := strings.Cut(c.Expected, "*")
+			if !strings.HasPrefix(got, prefix) || !strings.HasSuffix(got, suffix) {
+				t.Errorf("got log %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
+
+// logTestDoer records the body of the request it receives and responds with
+// the given body.
+type logTestDoer struct {
+	response string
+	body     string
+}
+
+// Do implements the client Doer interface.
+func (d *logTestDoer) Do(r *http.Request) (*http.Response, error) {
+	b, _ := io.ReadAll(r.Body)
+	d.body = string(b)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(d.response))}, nil
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// MethodOverrideHeader is the name of the header used by clients to override
+// the method of POST requests.
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
+// MethodOverride returns a middleware that replaces the method of POST requests
+// with the value of the X-HTTP-Method-Override header so that clients behind
+// proxies that do not support methods such as PATCH may still call the
+// corresponding endpoints. The header value must be one of the given methods,
+// it is ignored otherwise. methods defaults to PUT, PATCH and DELETE, GET and
+// other safe methods cannot be used as overrides.
+//
+// The middleware must be applied to the handler wrapping the muxer so that
+// the request method is overridden before the request is routed, for example:
+//
+//	var handler http.Handler = mux
+//	handler = middleware.MethodOverride("PATCH")(handler)
+func MethodOverride(methods ...string) func(http.Handler) http.Handler {
+	if len(methods) == 0 {
+		methods = []string{http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	allowed := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		switch m = strings.ToUpper(m); m {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			allowed[m] = struct{}{}
+		}
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				if m := strings.ToUpper(r.Header.Get(MethodOverrideHeader)); m != "" {
+					if _, ok := allowed[m]; ok {
+						r.Method = m
+						r.Header.Del(MethodOverrideHeader)
+					}
+				}
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
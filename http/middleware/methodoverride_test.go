@@ -0,0 +1,43 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestMethodOverride(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Methods  []string
+		Method   string
+		Override string
+		Expected string
+	}{
+		{"patch", nil, "POST", "PATCH", "PATCH"},
+		{"lower case", nil, "POST", "delete", "DELETE"},
+		{"no header", nil, "POST", "", "POST"},
+		{"not post", nil, "PUT", "PATCH", "PUT"},
+		{"safe method", nil, "POST", "GET", "POST"},
+		{"not allowed", []string{"PATCH"}, "POST", "PUT", "POST"},
+		{"unsafe allowed", []string{"GET"}, "POST", "GET", "POST"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var method string
+			h := httpm.MethodOverride(c.Methods...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+			}))
+			req := httptest.NewRequest(c.Method, "/", nil)
+			if c.Override != "" {
+				req.Header.Set(httpm.MethodOverrideHeader, c.Override)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if method != c.Expected {
+				t.Errorf("got method %q, expected %q", method, c.Expected)
+			}
+		})
+	}
+}
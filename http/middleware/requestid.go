@@ -7,6 +7,13 @@ import (
 	"goa.design/goa/v3/middleware"
 )
 
+// requestIDDoer is a client Doer that sets the request ID header for each
+// request it makes.
+type requestIDDoer struct {
+	Doer
+	header string
+}
+
 // RequestID returns a middleware, which initializes the context with a unique
 // value under the RequestIDKey key. Optionally uses the incoming "X-Request-Id"
 // header, if present, with or without a length limit to use as request ID. the
@@ -41,6 +48,11 @@ func RequestID(options ...middleware.RequestIDOption) func(http.Handler) http.Ha
 	}
 }
 
+// RequestIDFuncOption sets the function used to generate new request IDs.
+func RequestIDFuncOption(f middleware.IDFunc) middleware.RequestIDOption {
+	return middleware.RequestIDFuncOption(f)
+}
+
 // UseXRequestIDHeaderOption enables/disables using "X-Request-Id" header.
 func UseXRequestIDHeaderOption(f bool) middleware.RequestIDOption {
 	return middleware.UseRequestIDOption(f)
@@ -56,3 +68,21 @@ func RequestIDHeaderOption(name string) middleware.RequestIDOption {
 func XRequestHeaderLimitOption(limit int) middleware.RequestIDOption {
 	return middleware.RequestIDLimitOption(limit)
 }
+
+// WrapRequestIDDoer wraps a goa client Doer and sets the header with the given
+// name to the request ID stored in the request context if any so that the
+// downstream service may use the same request ID. The header is left untouched
+// if the request already sets it.
+func WrapRequestIDDoer(doer Doer, header string) Doer {
+	return &requestIDDoer{Doer: doer, header: header}
+}
+
+// Do sets the request ID header before making the request.
+func (d *requestIDDoer) Do(r *http.Request) (*http.Response, error) {
+	if id, ok := r.Context().Value(middleware.RequestIDKey).(string); ok && id != "" {
+		if r.Header.Get(d.header) == "" {
+			r.Header.Set(d.header, id)
+		}
+	}
+	return d.Doer.Do(r)
+}
@@ -1,9 +1,12 @@
 package middleware_test
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
+
 	httpm "goa.design/goa/v3/http/middleware"
 	"goa.design/goa/v3/middleware"
 )
@@ -89,6 +92,19 @@ func TestRequestID(t *testing.T) {
 				}
 			},
 		},
+		{"generate uuid",
+			[]middleware.RequestIDOption{
+				httpm.RequestIDHeaderOption("Custom-Id"),
+				httpm.RequestIDFuncOption(middleware.UUID),
+			},
+			makeRequest("ignore this header"),
+			func(_ http.ResponseWriter, r *http.Request) {
+				id := getRequestID(r)
+				if _, err := uuid.Parse(id); err != nil {
+					t.Errorf("%s: unexpected request ID: %s is not a UUID", r.Header.Get("Test-Case"), id)
+				}
+			},
+		},
 	} {
 		httpm.RequestID(tc.options...)(
 			&requestIDTestHandler{tc.name, tc.handler}).ServeHTTP(ignored, tc.request)
@@ -100,3 +116,44 @@ func (h *requestIDTestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	r.Header.Set("Test-Case", h.testCaseName)
 	h.handler(w, r)
 }
+
+func TestWrapRequestIDDoer(t *testing.T) {
+	cases := []struct {
+		Name     string
+		ID       string
+		Header   string
+		Expected string
+	}{
+		{"no request ID", "", "", ""},
+		{"request ID", "request-id", "", "request-id"},
+		{"header already set", "request-id", "other-id", "other-id"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.ID != "" {
+				ctx = context.WithValue(ctx, middleware.RequestIDKey, c.ID)
+			}
+			req, _ := http.NewRequestWithContext(ctx, "GET", "/", nil)
+			if c.Header != "" {
+				req.Header.Set("X-Request-Id", c.Header)
+			}
+			doer := &requestIDTestDoer{}
+			httpm.WrapRequestIDDoer(doer, "X-Request-Id").Do(req)
+			if got := doer.header.Get("X-Request-Id"); got != c.Expected {
+				t.Errorf("got header %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
+
+// requestIDTestDoer records the headers of the request it receives.
+type requestIDTestDoer struct {
+	header http.Header
+}
+
+// Do implements the client Doer interface.
+func (d *requestIDTestDoer) Do(r *http.Request) (*http.Response, error) {
+	d.header = r.Header
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// DefaultMultipartMaxDepth is the maximum number of nested multipart bodies
+// read by the decoders generated with the "multipart:decode" meta when the
+// design does not set one with "multipart:max-depth".
+const DefaultMultipartMaxDepth = 5
+
+// DefaultMultipartMaxPartSize is the maximum size in bytes of the content of a
+// part read by the decoders generated with the "multipart:decode" meta when
+// the design does not set one with "multipart:max-part-size".
+const DefaultMultipartMaxPartSize = 10 << 20
+
+// DefaultMultipartMaxSize is the maximum size in bytes of all the parts,
+// headers included, read by the decoders generated with the "multipart:decode"
+// meta when the design does not set one with "multipart:max-size".
+const DefaultMultipartMaxSize = 32 << 20
+
+// MultipartPart is a part of a multipart request body read with ReadMultipart.
+type MultipartPart struct {
+	// Name is the name of the part given by its Content-Disposition
+	// header.
+	Name string
+	// FileName is the file name of the part given by its
+	// Content-Disposition header if any.
+	FileName string
+	// ContentType is the media type of the part without its parameters.
+	// It defaults to "text/plain" as defined in RFC 7578.
+	ContentType string
+	// Header is the MIME header of the part.
+	Header textproto.MIMEHeader
+	// Data is the content of the part, nil if the part is a nested
+	// multipart body.
+	Data []byte
+	// Parts lists the parts of the nested multipart body if the part
+	// content type is "multipart/*", nil otherwise.
+	Parts []*MultipartPart
+}
+
+// ReadMultipart reads the parts of the multipart body read by mr, including
+// the parts of the nested multipart bodies (e.g. "multipart/mixed" parts).
+// maxDepth is the maximum number of nested multipart bodies, maxPartSize the
+// maximum size in bytes of the content of a part and maxSize the maximum size
+// in bytes of all the parts including their headers. ReadMultipart returns a
+// decode_payload error if the body is nested deeper or exceeds the sizes.
+func ReadMultipart(mr *multipart.Reader, maxDepth int, maxPartSize, maxSize int64) ([]*MultipartPart, error) {
+	r := &multipartReader{maxDepth: maxDepth, maxPartSize: maxPartSize, remaining: maxSize, maxSize: maxSize}
+	return r.read(mr, 0)
+}
+
+// multipartReader reads multipart bodies enforcing the limits given to
+// ReadMultipart.
+type multipartReader struct {
+	maxDepth    int
+	maxPartSize int64
+	maxSize     int64
+	// remaining is the number of bytes that may still be read.
+	remaining int64
+}
+
+// ValidateMultipartContentType returns an invalid_format error if the content
+// type of the part is not one of the given media types. The media types may
+// use a wildcard subtype, e.g. "image/*". name is the name of the payload
+// field read from the part used in the error.
+func ValidateMultipartContentType(name string, p *MultipartPart, types ...string) error {
+	for _, t := range types {
+		t = strings.ToLower(t)
+		if t == p.ContentType {
+			return nil
+		}
+		if strings.HasSuffix(t, "/*") && strings.HasPrefix(p.ContentType, strings.TrimSuffix(t, "*")) {
+			return nil
+		}
+	}
+	return goa.InvalidFormatError(name, p.ContentType, goa.Format("content-type"),
+		fmt.Errorf("must be one of %s", strings.Join(types, ", ")))
+}
+
+// read reads the parts of the multipart body read by mr nested depth levels
+// deep.
+func (r *multipartReader) read(mr *multipart.Reader, depth int) ([]*MultipartPart, error) {
+	var parts []*MultipartPart
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		part := &MultipartPart{
+			FileName:    p.FileName(),
+			ContentType: "text/plain",
+			Header:      p.Header,
+		}
+		if _, params, err := mime.ParseMediaType(p.Header.Get("Content-Disposition")); err == nil {
+			part.Name = params["name"]
+		}
+		for k, vs := range p.Header {
+			for _, v := range vs {
+				r.remaining -= int64(len(k) + len(v))
+			}
+		}
+		if r.remaining < 0 {
+			return nil, r.maxSizeError()
+		}
+		var boundary string
+		if ct := p.Header.Get("Content-Type"); ct != "" {
+			mt, params, err := mime.ParseMediaType(ct)
+			if err != nil {
+				return nil, goa.DecodePayloadError(fmt.Sprintf("invalid content type of part %q: %s", part.Name, err))
+			}
+			part.ContentType = mt
+			if strings.HasPrefix(mt, "multipart/") {
+				boundary = params["boundary"]
+				if boundary == "" {
+					return nil, goa.DecodePayloadError(fmt.Sprintf("missing boundary in content type of part %q", part.Name))
+				}
+			}
+		}
+		if boundary != "" {
+			if depth >= r.maxDepth {
+				return nil, goa.DecodePayloadError(fmt.Sprintf("part %q exceeds the maximum multipart nesting depth of %d", part.Name, r.maxDepth))
+			}
+			nested, err := r.read(multipart.NewReader(p, boundary), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if nested == nil {
+				nested = []*MultipartPart{}
+			}
+			part.Parts = nested
+		} else {
+			limit := r.maxPartSize
+			if r.remaining < limit {
+				limit = r.remaining
+			}
+			data, err := io.ReadAll(io.LimitReader(p, limit+1))
+			if err != nil {
+				return nil, goa.DecodePayloadError(err.Error())
+			}
+			if int64(len(data)) > limit {
+				if limit == r.maxPartSize {
+					return nil, goa.DecodePayloadError(fmt.Sprintf("part %q exceeds the maximum part size of %d bytes", part.Name, r.maxPartSize))
+				}
+				return nil, r.maxSizeError()
+			}
+			r.remaining -= int64(len(data))
+			part.Data = data
+		}
+		parts = append(parts, part)
+	}
+}
+
+// maxSizeError returns the error reported when the parts exceed the maximum
+// size.
+func (r *multipartReader) maxSizeError() error {
+	return goa.DecodePayloadError(fmt.Sprintf("multipart body exceeds the maximum size of %d bytes", r.maxSize))
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func TestReadMultipart(t *testing.T) {
+	body, boundary := nestedMultipart(t, 2)
+	parts, err := ReadMultipart(multipart.NewReader(body, boundary), 2, DefaultMultipartMaxPartSize, DefaultMultipartMaxSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, expected 2", len(parts))
+	}
+	if parts[0].Name != "title" || string(parts[0].Data) != "goa" || parts[0].ContentType != "text/plain" {
+		t.Errorf("got part %q with content type %q and data %q, expected title text/plain goa", parts[0].Name, parts[0].ContentType, parts[0].Data)
+	}
+	nested := parts[1]
+	for depth := 1; depth <= 2; depth++ {
+		if nested.Name != "nested" || nested.ContentType != "multipart/mixed" {
+			t.Fatalf("depth %d: got part %q with content type %q, expected nested multipart/mixed", depth, nested.Name, nested.ContentType)
+		}
+		if len(nested.Parts) == 0 {
+			t.Fatalf("depth %d: got no nested parts", depth)
+		}
+		if depth < 2 {
+			nested = nested.Parts[len(nested.Parts)-1]
+		}
+	}
+	if leaf := nested.Parts[0]; leaf.FileName != "logo.png" || leaf.ContentType != "image/png" {
+		t.Errorf("got leaf file %q with content type %q, expected logo.png image/png", leaf.FileName, leaf.ContentType)
+	}
+}
+
+func TestReadMultipartMaxDepth(t *testing.T) {
+	body, boundary := nestedMultipart(t, 3)
+	_, err := ReadMultipart(multipart.NewReader(body, boundary), 2, DefaultMultipartMaxPartSize, DefaultMultipartMaxSize)
+	if err == nil {
+		t.Fatal("got no error, expected max depth error")
+	}
+	if !strings.Contains(err.Error(), "maximum multipart nesting depth of 2") {
+		t.Errorf("got error %q, expected max depth error", err)
+	}
+}
+
+func TestReadMultipartMaxSize(t *testing.T) {
+	cases := []struct {
+		Name        string
+		MaxPartSize int64
+		MaxSize     int64
+		Error       string
+	}{
+		{"part size", 2, DefaultMultipartMaxSize, `part "title" exceeds the maximum part size of 2 bytes`},
+		{"total size", DefaultMultipartMaxPartSize, 100, "multipart body exceeds the maximum size of 100 bytes"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			body, boundary := nestedMultipart(t, 1)
+			_, err := ReadMultipart(multipart.NewReader(body, boundary), 1, c.MaxPartSize, c.MaxSize)
+			if err == nil {
+				t.Fatal("got no error, expected max size error")
+			}
+			if !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %q, expected %q", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestValidateMultipartContentType(t *testing.T) {
+	cases := []struct {
+		Name        string
+		ContentType string
+		Types       []string
+		Valid       bool
+	}{
+		{"exact", "image/png", []string{"image/jpeg", "image/png"}, true},
+		{"wildcard", "image/png", []string{"image/*"}, true},
+		{"invalid", "text/plain", []string{"image/*"}, false},
+		{"prefix", "imagex/png", []string{"image/*"}, false},
+		{"upper case", "image/png", []string{"Image/PNG"}, true},
+		{"upper case wildcard", "image/png", []string{"IMAGE/*"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := ValidateMultipartContentType("logo", &MultipartPart{ContentType: c.ContentType}, c.Types...)
+			if c.Valid && err != nil {
+				t.Errorf("got error %q, expected none", err)
+			}
+			if !c.Valid && err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
+
+// nestedMultipart returns a multipart body with a "title" part and a "nested"
+// multipart/mixed part nested depth levels deep, and its boundary.
+func nestedMultipart(t *testing.T, depth int) (*bytes.Buffer, string) {
+	t.Helper()
+	var build func(mw *multipart.Writer, level int)
+	build = func(mw *multipart.Writer, level int) {
+		if level == depth {
+			h := make(textproto.MIMEHeader)
+			h.Set("Content-Disposition", `form-data; name="logo"; filename="logo.png"`)
+			h.Set("Content-Type", "image/png")
+			w, err := mw.CreatePart(h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write([]byte("png"))
+			return
+		}
+		if level == 0 {
+			if err := mw.WriteField("title", "goa"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var nested bytes.Buffer
+		nmw := multipart.NewWriter(&nested)
+		build(nmw, level+1)
+		if err := nmw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="nested"`)
+		h.Set("Content-Type", "multipart/mixed; boundary="+nmw.Boundary())
+		w, err := mw.CreatePart(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(nested.Bytes())
+	}
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	build(mw, 0)
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &body, mw.Boundary()
+}
@@ -72,15 +72,32 @@ type (
 func NewMuxer() MiddlewareMuxer {
 	r := httptreemux.NewContextMux()
 	r.EscapeAddedRoutes = true
-	r.NotFoundHandler = func(w http.ResponseWriter, req *http.Request) {
-		ctx := context.WithValue(req.Context(), AcceptTypeKey, req.Header.Get("Accept"))
-		enc := ResponseEncoder(ctx, w)
-		w.WriteHeader(http.StatusNotFound)
-		enc.Encode(NewErrorResponse(ctx, fmt.Errorf("404 page not found")))
-	}
+	r.NotFoundHandler = notFound
 	return &mux{r}
 }
 
+// MatchPathPatterns wraps handler so that it responds with 404 Not Found
+// unless the path variables captured by mux match the given regular
+// expressions indexed by variable name. The regular expressions must match
+// the entire variable values. The generated code uses MatchPathPatterns to
+// mount the handlers of routes whose wildcards define regular expressions.
+func MatchPathPatterns(mux Muxer, patterns map[string]string, handler http.HandlerFunc) http.HandlerFunc {
+	res := make(map[string]*regexp.Regexp, len(patterns))
+	for name, pattern := range patterns {
+		res[name] = regexp.MustCompile("^(?:" + pattern + ")$")
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		for name, re := range res {
+			if !re.MatchString(vars[name]) {
+				notFound(w, r)
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
 // Handle maps the wildcard format used by goa to the one used by httptreemux.
 func (m *mux) Handle(method, pattern string, handler http.HandlerFunc) {
 	m.ContextMux.Handle(method, treemuxify(pattern), handler)
@@ -97,6 +114,15 @@ func (m *mux) Use(f func(http.Handler) http.Handler) {
 	m.ContextMux.UseHandler(f)
 }
 
+// notFound writes a 404 Not Found response whose body is encoded using the
+// request Accept header.
+func notFound(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+	enc := ResponseEncoder(ctx, w)
+	w.WriteHeader(http.StatusNotFound)
+	enc.Encode(NewErrorResponse(ctx, fmt.Errorf("404 page not found")))
+}
+
 var wildSeg = regexp.MustCompile(`/{([a-zA-Z0-9_]+)}`)
 var wildPath = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
 
@@ -68,3 +68,29 @@ func TestMiddlewares(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchPathPatterns(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Path   string
+		Status int
+	}{
+		{"match", "/users/42", http.StatusOK},
+		{"no match", "/users/abc", http.StatusNotFound},
+		{"partial match", "/users/42abc", http.StatusNotFound},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMuxer()
+			m.Handle("GET", "/users/{id}", MatchPathPatterns(m, map[string]string{"id": "[0-9]+"}, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(m.Vars(r)["id"]))
+			}))
+			r, _ := http.NewRequest("GET", c.Path, nil)
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package http
+
+import "net/http"
+
+// AllowMethods returns a HTTP handler that sets the Allow response header to
+// methods before calling h. The generated servers use it to answer the OPTIONS
+// requests made to the endpoint paths with the list of methods that the paths
+// accept, for example "GET, POST, OPTIONS".
+func AllowMethods(methods string, h http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", methods)
+		h.ServeHTTP(w, r)
+	}
+}
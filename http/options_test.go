@@ -0,0 +1,26 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAllowMethods(t *testing.T) {
+	var called bool
+	h := AllowMethods("GET, POST, OPTIONS", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("OPTIONS", "/items", nil))
+	if !called {
+		t.Error("handler not called")
+	}
+	if allow := w.Header().Get("Allow"); allow != "GET, POST, OPTIONS" {
+		t.Errorf("got Allow header %q, expected %q", allow, "GET, POST, OPTIONS")
+	}
+	if w.Code != http.StatusNoContent {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusNoContent)
+	}
+}
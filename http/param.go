@@ -0,0 +1,116 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+const (
+	// PathStyleSimple is the default path parameter style, e.g. "5" or
+	// "3,4,5".
+	PathStyleSimple = "simple"
+	// PathStyleMatrix is the matrix path parameter style defined in RFC
+	// 6570, e.g. ";id=5", ";id=3,4,5" or ";id=3;id=4;id=5" when exploded.
+	PathStyleMatrix = "matrix"
+	// PathStyleLabel is the label path parameter style defined in RFC 6570,
+	// e.g. ".5", ".3,4,5" or ".3.4.5" when exploded.
+	PathStyleLabel = "label"
+)
+
+// DecodePathParam returns the value of the path parameter with the given name
+// serialized in raw using the given style converted to the simple style, that
+// is the value itself or the comma separated array elements. explode
+// indicates whether array elements are serialized separately. The generated
+// code then decodes the simple style value as it does for the path parameters
+// that do not define a style.
+func DecodePathParam(raw, name, style string, explode bool) (string, error) {
+	switch style {
+	case PathStyleMatrix:
+		if !strings.HasPrefix(raw, ";") {
+			return "", goa.InvalidFieldTypeError(name, raw, "matrix path parameter")
+		}
+		parts := strings.Split(raw[1:], ";")
+		if !explode && len(parts) > 1 {
+			return "", goa.InvalidFieldTypeError(name, raw, "matrix path parameter")
+		}
+		vals := make([]string, len(parts))
+		for i, p := range parts {
+			key, val, _ := strings.Cut(p, "=")
+			if key != name {
+				return "", goa.InvalidFieldTypeError(name, raw, "matrix path parameter")
+			}
+			vals[i] = val
+		}
+		return strings.Join(vals, ","), nil
+	case PathStyleLabel:
+		if !strings.HasPrefix(raw, ".") {
+			return "", goa.InvalidFieldTypeError(name, raw, "label path parameter")
+		}
+		if explode {
+			return strings.ReplaceAll(raw[1:], ".", ","), nil
+		}
+		return raw[1:], nil
+	}
+	return raw, nil
+}
+
+// EncodePathParam serializes the values of the path parameter with the given
+// name using the given style. values contains a single element for primitive
+// parameters and the array elements for array parameters. explode indicates
+// whether array elements are serialized separately.
+func EncodePathParam(name, style string, explode bool, values ...string) string {
+	switch style {
+	case PathStyleMatrix:
+		if explode && len(values) > 0 {
+			return ";" + name + "=" + strings.Join(values, ";"+name+"=")
+		}
+		return ";" + name + "=" + strings.Join(values, ",")
+	case PathStyleLabel:
+		if explode {
+			return "." + strings.Join(values, ".")
+		}
+		return "." + strings.Join(values, ",")
+	}
+	return strings.Join(values, ",")
+}
+
+// HasDeepObjectParam returns true if query contains at least one property of
+// the query parameter with the given name that uses the "deepObject" style,
+// e.g. "filter[name]=x" for the "filter" parameter.
+func HasDeepObjectParam(query url.Values, name string) bool {
+	prefix := name + "["
+	for key := range query {
+		if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, "]") {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeDelimitedQueryParam returns the elements of an array query string
+// parameter whose elements are separated with sep, e.g. "ids=3|4|5". values
+// contains the values of the parameter in the query string, the elements of
+// all the values are returned in order. It returns nil if values is nil so
+// that the generated code can detect missing parameters.
+func DecodeDelimitedQueryParam(values []string, sep string) []string {
+	if values == nil {
+		return nil
+	}
+	elems := []string{}
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		elems = append(elems, strings.Split(v, sep)...)
+	}
+	return elems
+}
+
+// EncodeDelimitedQueryParam serializes the elements of an array query string
+// parameter separated with sep. It is the inverse of
+// DecodeDelimitedQueryParam.
+func EncodeDelimitedQueryParam(sep string, elems ...string) string {
+	return strings.Join(elems, sep)
+}
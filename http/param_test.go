@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPathParamStyles(t *testing.T) {
+	cases := map[string]struct {
+		style   string
+		explode bool
+		values  []string
+		raw     string
+	}{
+		"simple":                {PathStyleSimple, false, []string{"3", "4"}, "3,4"},
+		"matrix primitive":      {PathStyleMatrix, false, []string{"5"}, ";id=5"},
+		"matrix array":          {PathStyleMatrix, false, []string{"3", "4"}, ";id=3,4"},
+		"matrix array exploded": {PathStyleMatrix, true, []string{"3", "4"}, ";id=3;id=4"},
+		"label primitive":       {PathStyleLabel, false, []string{"5"}, ".5"},
+		"label array":           {PathStyleLabel, false, []string{"3", "4"}, ".3,4"},
+		"label array exploded":  {PathStyleLabel, true, []string{"3", "4"}, ".3.4"},
+	}
+	for k, tc := range cases {
+		if actual := EncodePathParam("id", tc.style, tc.explode, tc.values...); actual != tc.raw {
+			t.Errorf("%s: got encoded %q, expected %q", k, actual, tc.raw)
+		}
+		actual, err := DecodePathParam(tc.raw, "id", tc.style, tc.explode)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", k, err)
+			continue
+		}
+		if expected := EncodePathParam("id", PathStyleSimple, false, tc.values...); actual != expected {
+			t.Errorf("%s: got decoded %q, expected %q", k, actual, expected)
+		}
+	}
+}
+
+func TestDecodePathParamInvalid(t *testing.T) {
+	cases := map[string]struct {
+		raw     string
+		style   string
+		explode bool
+	}{
+		"matrix no prefix":           {"5", PathStyleMatrix, false},
+		"matrix other name":          {";key=5", PathStyleMatrix, false},
+		"matrix exploded unexpected": {";id=3;id=4", PathStyleMatrix, false},
+		"label no prefix":            {"5", PathStyleLabel, false},
+	}
+	for k, tc := range cases {
+		if _, err := DecodePathParam(tc.raw, "id", tc.style, tc.explode); err == nil {
+			t.Errorf("%s: expected an error", k)
+		}
+	}
+}
+
+func TestHasDeepObjectParam(t *testing.T) {
+	cases := map[string]struct {
+		query    string
+		expected bool
+	}{
+		"empty":          {"", false},
+		"property":       {"filter[name]=x", true},
+		"other property": {"sort=name&filter[age]=5", true},
+		"other param":    {"filters[name]=x", false},
+		"plain param":    {"filter=x", false},
+	}
+	for k, tc := range cases {
+		query, err := url.ParseQuery(tc.query)
+		if err != nil {
+			t.Fatalf("%s: invalid query: %s", k, err)
+		}
+		if actual := HasDeepObjectParam(query, "filter"); actual != tc.expected {
+			t.Errorf("%s: got %v, expected %v", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestDelimitedQueryParam(t *testing.T) {
+	cases := map[string]struct {
+		query    string
+		sep      string
+		expected []string
+	}{
+		"missing":  {"", "|", nil},
+		"empty":    {"ids=", "|", []string{}},
+		"single":   {"ids=3", "|", []string{"3"}},
+		"pipe":     {"ids=3%7C4%7C5", "|", []string{"3", "4", "5"}},
+		"comma":    {"ids=3,4", ",", []string{"3", "4"}},
+		"repeated": {"ids=3,4&ids=5", ",", []string{"3", "4", "5"}},
+	}
+	for k, tc := range cases {
+		query, err := url.ParseQuery(tc.query)
+		if err != nil {
+			t.Fatalf("%s: invalid query: %s", k, err)
+		}
+		actual := DecodeDelimitedQueryParam(query["ids"], tc.sep)
+		if (actual == nil) != (tc.expected == nil) || strings.Join(actual, " ") != strings.Join(tc.expected, " ") {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+		if len(actual) > 0 {
+			if enc := EncodeDelimitedQueryParam(tc.sep, actual...); enc != strings.Join(tc.expected, tc.sep) {
+				t.Errorf("%s: got encoded value %q, expected %q", k, enc, strings.Join(tc.expected, tc.sep))
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// ProblemContentType is the content type of the responses that encode problem
+// details as defined in RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is the data structure encoded in HTTP error responses by the
+// servers generated for APIs that use the "problem+json" error format (see
+// RFC 7807). The members of the error response body other than the standard
+// problem members are encoded as extension members so that clients that
+// decode the goa error response shape keep working.
+type ProblemDetails struct {
+	// Type is a URI reference that identifies the problem type.
+	Type string
+	// Title is a short summary of the problem type.
+	Title string
+	// Status is the HTTP status code.
+	Status int
+	// Detail is an explanation specific to this occurrence of the problem.
+	Detail string
+	// Instance is a URI reference that identifies this occurrence of the
+	// problem.
+	Instance string
+	// Extensions lists the problem extension members indexed by name.
+	Extensions map[string]interface{}
+}
+
+// NewProblemDetails creates problem details from the given error. It can be
+// used as error formatter by the generated servers. The status code is
+// computed using the same heuristic as ErrorResponse, the instance is the error
+// ID and the ErrorResponse fields are encoded as extension members.
+func NewProblemDetails(ctx context.Context, err error) Statuser {
+	resp := NewErrorResponse(ctx, err)
+	p := NewProblemDetailsWithBody(err, resp.StatusCode(), resp)
+	if eresp, ok := resp.(*ErrorResponse); ok {
+		p.Instance = eresp.ID
+	}
+	return p
+}
+
+// NewProblemDetailsWithBody creates problem details for the given error
+// encoded in a response with the given status code. The members of the JSON
+// representation of body are added as extension members, body is typically the
+// response body generated for the error in the design. body is returned with
+// its status set if it already is a ProblemDetails, for example because it was
+// created by an error formatter.
+func NewProblemDetailsWithBody(err error, status int, body interface{}) *ProblemDetails {
+	if p, ok := body.(*ProblemDetails); ok {
+		p.Status = status
+		return p
+	}
+	p := &ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+	var serr *goa.ServiceError
+	if errors.As(err, &serr) {
+		p.Detail = serr.Message
+		p.Instance = serr.ID
+	}
+	if body != nil {
+		if b, merr := json.Marshal(body); merr == nil {
+			var ext map[string]interface{}
+			if json.Unmarshal(b, &ext) == nil && len(ext) > 0 {
+				p.Extensions = ext
+			}
+		}
+	}
+	return p
+}
+
+// ProblemErrorEncoder is the ErrorEncoder used by the servers generated for
+// APIs that use the "problem+json" error format. It encodes the errors as
+// problem details using NewProblemDetails if formatter is nil.
+func ProblemErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
+	if formatter == nil {
+		formatter = NewProblemDetails
+	}
+	return ErrorEncoder(encoder, formatter)
+}
+
+// StatusCode returns the HTTP status code of the problem.
+func (p *ProblemDetails) StatusCode() int {
+	return p.Status
+}
+
+// ContentType returns the content type of the HTTP responses that encode the
+// problem details.
+func (p *ProblemDetails) ContentType() string {
+	return ProblemContentType
+}
+
+// MarshalJSON encodes the standard problem members followed by the extension
+// members. The extension members cannot override the standard members.
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	m["type"] = p.Type
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	} else {
+		delete(m, "detail")
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	} else {
+		delete(m, "instance")
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type customError struct {
+	Reason string `json:"reason"`
+	Title  string `json:"title"`
+}
+
+func (e *customError) Error() string { return e.Reason }
+
+func TestNewProblemDetailsWithBody(t *testing.T) {
+	serr := &goa.ServiceError{Name: "not_found", ID: "abc", Message: "missing"}
+	cases := map[string]struct {
+		err      error
+		status   int
+		body     interface{}
+		expected map[string]interface{}
+	}{
+		"service-error": {serr, http.StatusNotFound, NewErrorResponse(context.Background(), serr), map[string]interface{}{
+			"type": "about:blank", "title": "Not Found", "status": 404.0, "detail": "missing", "instance": "abc",
+			"name": "not_found", "id": "abc", "message": "missing", "temporary": false, "timeout": false, "fault": false,
+		}},
+		"custom-error": {&customError{Reason: "conflict", Title: "ignored"}, http.StatusConflict, &customError{Reason: "conflict", Title: "ignored"}, map[string]interface{}{
+			"type": "about:blank", "title": "Conflict", "status": 409.0, "detail": "conflict", "reason": "conflict",
+		}},
+		"no-body": {errors.New("boom"), http.StatusInternalServerError, nil, map[string]interface{}{
+			"type": "about:blank", "title": "Internal Server Error", "status": 500.0, "detail": "boom",
+		}},
+	}
+	for k, tc := range cases {
+		b, err := json.Marshal(NewProblemDetailsWithBody(tc.err, tc.status, tc.body))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", k, err)
+		}
+		var actual map[string]interface{}
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Fatalf("%s: unexpected error: %s", k, err)
+		}
+		if len(actual) != len(tc.expected) {
+			t.Errorf("%s: got %v, expected %v", k, actual, tc.expected)
+			continue
+		}
+		for m, v := range tc.expected {
+			if actual[m] != v {
+				t.Errorf("%s: got %q member %v, expected %v", k, m, actual[m], v)
+			}
+		}
+	}
+}
+
+func TestProblemErrorEncoder(t *testing.T) {
+	w := httptest.NewRecorder()
+	encodeError := ProblemErrorEncoder(ResponseEncoder, nil)
+	if err := encodeError(context.Background(), w, goa.PermanentError("bad", "invalid")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusBadRequest)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("got content type %q, expected %q", ct, ProblemContentType)
+	}
+}
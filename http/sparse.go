@@ -0,0 +1,160 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// sparseRecorder is a http.ResponseWriter which buffers the response so that
+// its fields may be filtered.
+type sparseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// SparseFields returns a middleware which filters the fields of the JSON
+// response bodies using the comma separated list of field names given in the
+// "fields" query string parameter. allowed lists the names of the fields that
+// may be selected. The fields of the elements of array response bodies are
+// filtered the same way. Requests selecting unknown or nested fields (e.g.
+// "address.zip") are rejected with a 400 Bad Request response. Responses are
+// left untouched if the request does not select fields or if the response is
+// not a successful JSON response.
+func SparseFields(allowed ...string) func(http.Handler) http.Handler {
+	known := make(map[string]struct{}, len(allowed))
+	for _, f := range allowed {
+		known[f] = struct{}{}
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var selected map[string]struct{}
+			for _, v := range r.URL.Query()["fields"] {
+				for _, f := range strings.Split(v, ",") {
+					f = strings.TrimSpace(f)
+					if f == "" {
+						continue
+					}
+					if strings.Contains(f, ".") {
+						http.Error(w, "invalid field "+f+": nested field selection is not supported", http.StatusBadRequest)
+						return
+					}
+					if _, ok := known[f]; !ok {
+						http.Error(w, "unknown field "+f, http.StatusBadRequest)
+						return
+					}
+					if selected == nil {
+						selected = make(map[string]struct{})
+					}
+					selected[f] = struct{}{}
+				}
+			}
+			if selected == nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+			rec := &sparseRecorder{ResponseWriter: w}
+			h.ServeHTTP(rec, r)
+			rec.flush(selected)
+		})
+	}
+}
+
+// WriteHeader records the status code, the headers are written once the
+// response body is filtered.
+func (w *sparseRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write buffers the response body.
+func (w *sparseRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// flush writes the response with the body filtered using the selected
+// fields.
+func (w *sparseRecorder) flush(selected map[string]struct{}) {
+	if w.status == 0 {
+		return
+	}
+	body := w.body.Bytes()
+	if w.status >= 200 && w.status < 300 && strings.Contains(w.Header().Get("Content-Type"), "json") {
+		if filtered, err := filterFields(body, selected); err == nil {
+			body = filtered
+			w.Header().Del("Content-Length")
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(body)
+}
+
+// filterFields removes the fields not listed in selected from the given JSON
+// object or from the elements of the given JSON array. The order of the
+// remaining fields is preserved.
+func filterFields(body []byte, selected map[string]struct{}) ([]byte, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return body, nil
+	}
+	switch trimmed[0] {
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(trimmed, &elems); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, e := range elems {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			f, err := filterFields(e, selected)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(f)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		first := true
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			var val json.RawMessage
+			if err := dec.Decode(&val); err != nil {
+				return nil, err
+			}
+			key, _ := t.(string)
+			if _, ok := selected[key]; !ok {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			k, _ := json.Marshal(key)
+			buf.Write(k)
+			buf.WriteByte(':')
+			buf.Write(val)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	}
+	return body, nil
+}
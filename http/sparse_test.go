@@ -0,0 +1,64 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSparseFields(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Query    string
+		Body     string
+		Status   int
+		Expected string
+	}{
+		{"no selection", "", `{"id":1,"name":"goa","age":4}`, http.StatusOK, `{"id":1,"name":"goa","age":4}`},
+		{"object", "?fields=name,id", `{"id":1,"name":"goa","age":4}`, http.StatusOK, `{"id":1,"name":"goa"}`},
+		{"repeated", "?fields=id&fields=age", `{"id":1,"name":"goa","age":4}`, http.StatusOK, `{"id":1,"age":4}`},
+		{"array", "?fields=id", `[{"id":1,"name":"a"},{"id":2,"name":"b"}]`, http.StatusOK, `[{"id":1},{"id":2}]`},
+		{"nested value", "?fields=address", `{"id":1,"address":{"zip":"123"}}`, http.StatusOK, `{"address":{"zip":"123"}}`},
+		{"unknown", "?fields=id,secret", `{"id":1}`, http.StatusBadRequest, "unknown field secret\n"},
+		{"nested", "?fields=address.zip", `{"id":1}`, http.StatusBadRequest, "invalid field address.zip: nested field selection is not supported\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := SparseFields("id", "name", "age", "address")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, c.Body)
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/"+c.Query, nil))
+
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if got := w.Body.String(); got != c.Expected {
+				t.Errorf("got body %q, expected %q", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestSparseFieldsError(t *testing.T) {
+	body := `{"name":"not_found","message":"user not found"}`
+	h := SparseFields("id")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, body)
+	}))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/?fields=id", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusNotFound)
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != body {
+		t.Errorf("got body %q, expected %q", got, body)
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type (
+	// SSEEvent is a server-sent event.
+	SSEEvent struct {
+		// ID is the event ID, empty if the event does not define one.
+		ID string
+		// Event is the event name, "message" if the event does not
+		// define one.
+		Event string
+		// Data is the event data.
+		Data []byte
+	}
+
+	// SSEReader reads server-sent events from a stream.
+	SSEReader struct {
+		r      *bufio.Reader
+		events map[string]struct{}
+	}
+)
+
+// WriteSSEEvent writes a server-sent event with the given ID and name whose
+// data is the JSON encoding of v to w. The event ID is omitted if empty. The
+// writer is flushed after the event is written if it implements http.Flusher.
+func WriteSSEEvent(w io.Writer, id, event string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if id != "" {
+		buf.WriteString("id: " + sseField(id) + "\n")
+	}
+	buf.WriteString("event: " + sseField(event) + "\n")
+	for _, line := range strings.Split(string(data), "\n") {
+		buf.WriteString("data: " + line + "\n")
+	}
+	buf.WriteString("\n")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// NewSSEReader returns a reader that reads the server-sent events sent on r.
+// If events is not empty the reader skips the events whose name is not
+// listed.
+func NewSSEReader(r io.Reader, events ...string) *SSEReader {
+	sr := &SSEReader{r: bufio.NewReader(r)}
+	if len(events) > 0 {
+		sr.events = make(map[string]struct{}, len(events))
+		for _, e := range events {
+			sr.events[e] = struct{}{}
+		}
+	}
+	return sr
+}
+
+// Next returns the next event. It returns io.EOF once the stream is closed,
+// the event being read when the stream is closed is discarded.
+func (r *SSEReader) Next() (*SSEEvent, error) {
+	var (
+		ev   SSEEvent
+		data []string
+		seen bool
+	)
+	for {
+		line, err := r.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if line == "" {
+			if !seen {
+				continue
+			}
+			if ev.Event == "" {
+				ev.Event = "message"
+			}
+			if _, ok := r.events[ev.Event]; ok || r.events == nil {
+				ev.Data = []byte(strings.Join(data, "\n"))
+				return &ev, nil
+			}
+			ev, data, seen = SSEEvent{}, nil, false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// Comment
+			continue
+		}
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "id":
+			ev.ID = value
+		case "event":
+			ev.Event = value
+		case "data":
+			data = append(data, value)
+			seen = true
+		}
+	}
+}
+
+// sseField removes the line breaks from the given event field value.
+func sseField(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
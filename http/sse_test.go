@@ -0,0 +1,63 @@
+package http
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteSSEEvent(t *testing.T) {
+	cases := []struct {
+		Name     string
+		ID       string
+		Event    string
+		Value    interface{}
+		Expected string
+	}{
+		{"no-id", "", "message", map[string]string{"a": "b"}, "event: message\ndata: {\"a\":\"b\"}\n\n"},
+		{"id", "42", "tick", 1, "id: 42\nevent: tick\ndata: 1\n\n"},
+		{"line-breaks", "4\n2", "ti\r\nck", "x", "id: 42\nevent: tick\ndata: \"x\"\n\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := WriteSSEEvent(w, c.ID, c.Event, c.Value); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := w.Body.String(); got != c.Expected {
+				t.Errorf("got %q, expected %q", got, c.Expected)
+			}
+			if !w.Flushed {
+				t.Error("response was not flushed")
+			}
+		})
+	}
+}
+
+func TestSSEReader(t *testing.T) {
+	stream := ": comment\n\n" +
+		"data: {\"a\":1}\n\n" +
+		"id: 1\r\nevent: tick\r\ndata: 2\r\n\r\n" +
+		"event: ignored\ndata: 3\n\n" +
+		"id: 2\nevent: message\ndata:4\ndata: 5\n\n" +
+		"data: incomplete\n"
+	r := NewSSEReader(strings.NewReader(stream), "message", "tick")
+	expected := []SSEEvent{
+		{Event: "message", Data: []byte(`{"a":1}`)},
+		{ID: "1", Event: "tick", Data: []byte("2")},
+		{ID: "2", Event: "message", Data: []byte("4\n5")},
+	}
+	for i, e := range expected {
+		ev, err := r.Next()
+		if err != nil {
+			t.Fatalf("event %d: unexpected error: %s", i, err)
+		}
+		if ev.ID != e.ID || ev.Event != e.Event || string(ev.Data) != string(e.Data) {
+			t.Errorf("event %d: got %+v, expected %+v", i, ev, e)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("got error %v, expected EOF", err)
+	}
+}
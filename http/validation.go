@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ValidationErrorResponse is the data structure encoded in the HTTP
+	// responses of the servers generated for APIs that set the
+	// "http:error:validation" meta to "fields". It lists the validation
+	// errors of the request fields and is encoded with the 422 Unprocessable
+	// Entity status code.
+	ValidationErrorResponse []*FieldErrorResponse
+
+	// FieldErrorResponse describes the validation error of a single request
+	// field.
+	FieldErrorResponse struct {
+		// Pointer is the JSON pointer (RFC 6901) to the invalid field,
+		// e.g. "/address/zip" or "/items/0/name".
+		Pointer string `json:"pointer" xml:"pointer" form:"pointer"`
+		// Rule is the name of the validation rule that failed, e.g.
+		// "missing_field" or "invalid_pattern".
+		Rule string `json:"rule" xml:"rule" form:"rule"`
+		// Message describes the validation error.
+		Message string `json:"message" xml:"message" form:"message"`
+		// I18nKey is the localization key of the error message set in
+		// the design with the "i18n:key" meta, if any.
+		I18nKey string `json:"i18n_key,omitempty" xml:"i18n_key,omitempty" form:"i18n_key,omitempty"`
+	}
+)
+
+// NewValidationErrorResponse creates a HTTP response from the given error. It
+// returns a ValidationErrorResponse if err is made only of field validation
+// errors, for example the errors returned by the generated request decoders,
+// and the response built by NewErrorResponse otherwise.
+func NewValidationErrorResponse(ctx context.Context, err error) Statuser {
+	var serr *goa.ServiceError
+	if !errors.As(err, &serr) {
+		return NewErrorResponse(ctx, err)
+	}
+	ferrs := serr.FieldErrors()
+	if len(ferrs) == 0 || len(ferrs) != len(serr.History()) {
+		return NewErrorResponse(ctx, err)
+	}
+	resp := make(ValidationErrorResponse, len(ferrs))
+	for i, ferr := range ferrs {
+		resp[i] = &FieldErrorResponse{Pointer: ferr.Pointer, Rule: ferr.Rule, Message: ferr.Message, I18nKey: ferr.I18nKey}
+	}
+	return resp
+}
+
+// ValidationErrorEncoder returns an encoder that encodes the field validation
+// errors as a ValidationErrorResponse. The other errors are formatted with
+// formatter as done by ErrorEncoder.
+func ValidationErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(ctx context.Context, err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		resp, ok := NewValidationErrorResponse(ctx, err).(ValidationErrorResponse)
+		if !ok {
+			return encodeError(ctx, w, err)
+		}
+		enc := encoder(ctx, w)
+		w.WriteHeader(resp.StatusCode())
+		return enc.Encode(resp)
+	}
+}
+
+// StatusCode returns the 422 Unprocessable Entity HTTP status code.
+func (resp ValidationErrorResponse) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
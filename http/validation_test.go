@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestValidationErrorEncoder(t *testing.T) {
+	validationErr := goa.MergeErrors(
+		goa.MissingFieldError("id", "body"),
+		goa.NestErrors(goa.InvalidLengthError("body.zip", "123", 3, 5, true), "body.address"),
+	)
+	cases := map[string]struct {
+		err      error
+		status   int
+		expected string
+	}{
+		"validation": {validationErr, http.StatusUnprocessableEntity, `[{"pointer":"/id","rule":"missing_field","message":"\"id\" is missing from body"},{"pointer":"/address/zip","rule":"invalid_length","message":"length of body.zip must be greater or equal than 5 but got value \"123\" (len=3)"}]`},
+		"i18n":       {goa.WithI18nKey(goa.MissingFieldError("name", "body"), "user.name.required"), http.StatusUnprocessableEntity, `[{"pointer":"/name","rule":"missing_field","message":"\"name\" is missing from body","i18n_key":"user.name.required"}]`},
+		"mixed":      {goa.MergeErrors(goa.MissingFieldError("id", "body"), goa.PermanentError("bad_request", "bad")), http.StatusBadRequest, ""},
+		"other":      {errors.New("boom"), http.StatusInternalServerError, ""},
+	}
+	for k, tc := range cases {
+		w := httptest.NewRecorder()
+		encodeError := ValidationErrorEncoder(ResponseEncoder, nil)
+		if err := encodeError(context.Background(), w, tc.err); err != nil {
+			t.Fatalf("%s: unexpected error: %s", k, err)
+		}
+		if w.Code != tc.status {
+			t.Errorf("%s: got status %d, expected %d", k, w.Code, tc.status)
+		}
+		if tc.expected == "" {
+			continue
+		}
+		var actual, expected interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("%s: unexpected error: %s", k, err)
+		}
+		if err := json.Unmarshal([]byte(tc.expected), &expected); err != nil {
+			t.Fatalf("%s: unexpected error: %s", k, err)
+		}
+		a, _ := json.Marshal(actual)
+		e, _ := json.Marshal(expected)
+		if string(a) != string(e) {
+			t.Errorf("%s: got body %s, expected %s", k, a, e)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VerifyWebhookSignature returns a middleware which verifies the HMAC
+// signature of the request body read from the given request header. The
+// signature is computed with the given secret and hash function ("sha1",
+// "sha256" or "sha512") and may be hex encoded or prefixed with the name of
+// the hash function (e.g. "sha256=<hex>"). Requests whose signature is
+// missing or invalid are rejected with a 401 Unauthorized response, all the
+// requests are rejected if secret is empty. The request body is read once and
+// replaced with a reader of the same bytes so that the handler decodes the
+// buffer used to verify the signature.
+func VerifyWebhookSignature(secret []byte, header, algorithm string) func(http.Handler) http.Handler {
+	newHash := webhookSignatureHash(algorithm)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sig := r.Header.Get(header)
+			if sig == "" || len(secret) == 0 {
+				http.Error(w, "missing or invalid "+header+" header", http.StatusUnauthorized)
+				return
+			}
+			var body []byte
+			if r.Body != nil {
+				b, err := io.ReadAll(r.Body)
+				r.Body.Close()
+				if err != nil {
+					http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+					return
+				}
+				body = b
+			}
+			sig = strings.TrimPrefix(sig, algorithm+"=")
+			expected, err := hex.DecodeString(sig)
+			if err != nil {
+				http.Error(w, "missing or invalid "+header+" header", http.StatusUnauthorized)
+				return
+			}
+			mac := hmac.New(newHash, secret)
+			mac.Write(body)
+			if !hmac.Equal(mac.Sum(nil), expected) {
+				http.Error(w, "missing or invalid "+header+" header", http.StatusUnauthorized)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// webhookSignatureHash returns the hash function with the given name. It
+// panics if the name is not supported as the generated code only uses the
+// names validated by the design.
+func webhookSignatureHash(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case "sha1":
+		return sha1.New
+	case "sha256":
+		return sha256.New
+	case "sha512":
+		return sha512.New
+	}
+	panic("goa: unsupported webhook signature algorithm " + algorithm)
+}
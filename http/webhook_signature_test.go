@@ -0,0 +1,55 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	const body = `{"event":"paid"}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	sig := hex.EncodeToString(mac.Sum(nil))
+
+	cases := []struct {
+		Name      string
+		Secret    string
+		Signature string
+		Status    int
+	}{
+		{"hex", "secret", sig, http.StatusOK},
+		{"prefixed", "secret", "sha256=" + sig, http.StatusOK},
+		{"missing", "secret", "", http.StatusUnauthorized},
+		{"not-hex", "secret", "sha256=invalid", http.StatusUnauthorized},
+		{"wrong-secret", "other", sig, http.StatusUnauthorized},
+		{"no-secret", "", sig, http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var decoded string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				decoded = string(b)
+			})
+			h := VerifyWebhookSignature([]byte(c.Secret), "X-Signature", "sha256")(handler)
+			req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(body))
+			if c.Signature != "" {
+				req.Header.Set("X-Signature", c.Signature)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if c.Status == http.StatusOK && decoded != body {
+				t.Errorf("got body %q, expected %q", decoded, body)
+			}
+		})
+	}
+}
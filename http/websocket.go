@@ -2,7 +2,10 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -25,3 +28,62 @@ type (
 	// invoked in the configure function.
 	ConnConfigureFunc func(conn *websocket.Conn, cancel context.CancelFunc) *websocket.Conn
 )
+
+// KeepAlive sends a ping message on conn every interval until the returned
+// function is called. If timeout is not zero the reads on conn fail unless a
+// pong message was received during the last timeout period. Note that pong
+// messages are only processed while reading from the connection. cancel is
+// invoked if sending a ping fails, cancel may be nil.
+func KeepAlive(conn *websocket.Conn, interval, timeout time.Duration, cancel context.CancelFunc) func() {
+	if timeout > 0 {
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(timeout))
+		})
+	}
+	var (
+		done = make(chan struct{})
+		once sync.Once
+	)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(interval)); err != nil {
+					if cancel != nil {
+						cancel()
+					}
+					return
+				}
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// ReadControl reads and discards the messages received on conn so that the
+// control messages (ping, pong and close) get processed. It is meant to run in
+// its own goroutine for connections that are otherwise only written to. It
+// invokes cancel and returns once reading fails, for example when the peer
+// closes the connection.
+func ReadControl(conn *websocket.Conn, cancel context.CancelFunc) {
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			cancel()
+			return
+		}
+	}
+}
+
+// WriteBinaryJSON writes the JSON encoding of v as a binary message.
+func WriteBinaryJSON(conn *websocket.Conn, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.BinaryMessage, b)
+}
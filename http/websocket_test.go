@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newWebSocketServer starts a test server that upgrades the connections and
+// calls handle with the server side of the connection.
+func newWebSocketServer(t *testing.T, handle func(*websocket.Conn)) (*httptest.Server, *websocket.Conn) {
+	t.Helper()
+	var upgrader websocket.Upgrader
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade: %s", err)
+			return
+		}
+		handle(conn)
+	}))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		srv.Close()
+		t.Fatalf("failed to dial: %s", err)
+	}
+	return srv, conn
+}
+
+func TestKeepAlive(t *testing.T) {
+	pinged := make(chan struct{}, 1)
+	srv, conn := newWebSocketServer(t, func(conn *websocket.Conn) {
+		stop := KeepAlive(conn, 10*time.Millisecond, time.Second, nil)
+		defer stop()
+		conn.ReadMessage() // processes the pongs until the client closes
+	})
+	defer srv.Close()
+	defer conn.Close()
+	conn.SetPingHandler(func(data string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	go conn.ReadMessage() // processes the pings
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Error("got no ping, expected ping")
+	}
+}
+
+func TestKeepAlivePongTimeout(t *testing.T) {
+	errc := make(chan error, 1)
+	srv, conn := newWebSocketServer(t, func(conn *websocket.Conn) {
+		stop := KeepAlive(conn, 10*time.Millisecond, 30*time.Millisecond, nil)
+		defer stop()
+		_, _, err := conn.ReadMessage()
+		errc <- err
+	})
+	defer srv.Close()
+	defer conn.Close()
+	// The client does not read and thus never answers the pings.
+	select {
+	case err := <-errc:
+		if err == nil {
+			t.Error("got no error, expected timeout error")
+		}
+	case <-time.After(time.Second):
+		t.Error("got no timeout, expected read to time out")
+	}
+}
+
+func TestReadControl(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	srv, conn := newWebSocketServer(t, func(conn *websocket.Conn) {
+		ReadControl(conn, cancel)
+	})
+	defer srv.Close()
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second))
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Error("context not canceled, expected cancel on close")
+	}
+	conn.Close()
+}
+
+func TestWriteBinaryJSON(t *testing.T) {
+	srv, conn := newWebSocketServer(t, func(conn *websocket.Conn) {
+		if err := WriteBinaryJSON(conn, map[string]int{"a": 1}); err != nil {
+			t.Errorf("got error %s, expected none", err)
+		}
+	})
+	defer srv.Close()
+	defer conn.Close()
+	typ, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("got error %s, expected none", err)
+	}
+	if typ != websocket.BinaryMessage {
+		t.Errorf("got message type %d, expected %d", typ, websocket.BinaryMessage)
+	}
+	if string(msg) != `{"a":1}` {
+		t.Errorf("got message %q, expected %q", msg, `{"a":1}`)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"io"
+
+	"github.com/google/uuid"
 )
 
 type (
@@ -23,6 +25,9 @@ type (
 		// requestIDLimit if positive truncates the request ID at the specified
 		// length. Defaults to no limit.
 		requestIDLimit int
+		// requestIDFunc is the function used to generate new request IDs.
+		// Defaults to short random IDs.
+		requestIDFunc IDFunc
 	}
 )
 
@@ -50,7 +55,11 @@ func GenerateRequestID(ctx context.Context, o *RequestIDOptions) context.Context
 			}
 		}
 		if id == "" {
-			id = shortID()
+			if o.requestIDFunc != nil {
+				id = o.requestIDFunc()
+			} else {
+				id = shortID()
+			}
 		}
 	}
 	return context.WithValue(ctx, RequestIDKey, id)
@@ -85,6 +94,15 @@ func RequestIDLimitOption(limit int) RequestIDOption {
 	}
 }
 
+// RequestIDFuncOption sets the function used to generate new request IDs.
+// Use UUID to generate UUIDs.
+func RequestIDFuncOption(f IDFunc) RequestIDOption {
+	return func(o *RequestIDOptions) *RequestIDOptions {
+		o.requestIDFunc = f
+		return o
+	}
+}
+
 // IsUseRequestID returns the request ID option.
 func (o *RequestIDOptions) IsUseRequestID() bool {
 	return o.useRequestID
@@ -95,6 +113,12 @@ func (o *RequestIDOptions) RequestIDHeader() string {
 	return o.requestIDHeader
 }
 
+// UUID produces a random (version 4) UUID. It can be used with
+// RequestIDFuncOption to generate UUID request IDs.
+func UUID() string {
+	return uuid.NewString()
+}
+
 // shortID produces a " unique" 6 bytes long string.
 // Do not use as a reliable way to get unique IDs, instead use for things like logging.
 func shortID() string {
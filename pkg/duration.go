@@ -0,0 +1,35 @@
+package goa
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duration holds the value of an attribute defined with the DurationFormat
+// DSL. It is a time.Duration encoded using the syntax accepted by
+// time.ParseDuration such as "1h30m" instead of a number of nanoseconds.
+//
+// Duration implements encoding.TextMarshaler and encoding.TextUnmarshaler so
+// that encoding/json and encoding/xml encode and decode it as a string.
+// Decoding fails if the string is not a valid duration.
+type Duration time.Duration
+
+// String returns the duration formatted like time.Duration.String.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalText encodes d using the syntax accepted by time.ParseDuration.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes the duration string b into d.
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", string(b))
+	}
+	*d = Duration(v)
+	return nil
+}
@@ -0,0 +1,34 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDurationJSON(t *testing.T) {
+	type body struct {
+		Timeout  Duration  `json:"timeout"`
+		Interval *Duration `json:"interval,omitempty"`
+	}
+	var b body
+	if err := json.Unmarshal([]byte(`{"timeout":"1h30m","interval":"5s"}`), &b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if time.Duration(b.Timeout) != 90*time.Minute {
+		t.Errorf("got timeout %s, expected %s", b.Timeout, 90*time.Minute)
+	}
+	if b.Interval == nil || time.Duration(*b.Interval) != 5*time.Second {
+		t.Errorf("got interval %v, expected %s", b.Interval, 5*time.Second)
+	}
+	js, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(js) != `{"timeout":"1h30m0s","interval":"5s"}` {
+		t.Errorf("got %s, expected %s", js, `{"timeout":"1h30m0s","interval":"5s"}`)
+	}
+	if err := json.Unmarshal([]byte(`{"timeout":"soon"}`), &b); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
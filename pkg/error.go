@@ -3,6 +3,7 @@ package goa
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -22,6 +23,10 @@ type (
 		Field *string
 		// Message contains the specific error details.
 		Message string
+		// I18nKey is the localization key of the error message set with the
+		// "i18n:key" meta of the invalid attribute, if any. Message still
+		// contains the default English message.
+		I18nKey string
 		// Is the error a timeout?
 		Timeout bool
 		// Is the error temporary?
@@ -31,10 +36,46 @@ type (
 		// History tracks all the individual errors that were built into this error, should
 		// this error have been merged.
 		history []ServiceError
+		// path is the full path to the invalid field for validation
+		// errors, e.g. "body.address.zip".
+		path string
 		// err holds the original error if exists.
 		err error
 	}
 
+	// FieldError describes a single validation error of a field.
+	FieldError struct {
+		// Pointer is the JSON pointer (RFC 6901) to the invalid field
+		// relative to the validated value, e.g. "/address/zip" or
+		// "/items/0/name".
+		Pointer string
+		// Rule is the name of the validation rule that failed, e.g.
+		// "missing_field" or "invalid_pattern".
+		Rule string
+		// Message describes the validation error.
+		Message string
+		// I18nKey is the localization key of the error message, if any.
+		I18nKey string
+	}
+
+	// ErrorInfo describes an error defined in the design.
+	ErrorInfo struct {
+		// Name is the name of the error.
+		Name string
+		// Timeout is true if the error is due to a timeout.
+		Timeout bool
+		// Temporary is true if the error is temporary and retrying the
+		// request may be successful.
+		Temporary bool
+		// Fault is true if the error is a server-side fault.
+		Fault bool
+	}
+
+	// ErrorCatalog lists the errors defined in the design indexed by name.
+	// The generated service packages define an Errors catalog that lists
+	// the errors the service methods may return.
+	ErrorCatalog map[string]ErrorInfo
+
 	// GoaErrorNamer is an interface implemented by generated error structs that
 	// exposes the name of the error as defined in the design.
 	GoaErrorNamer interface {
@@ -42,11 +83,17 @@ type (
 	}
 )
 
+// pointerEscaper escapes the JSON pointer reference tokens.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
 const (
 	// InvalidFieldType is the error name for invalid field type errors.
 	InvalidFieldType = "invalid_field_type"
 	// MissingField is the error name for missing field errors.
 	MissingField = "missing_field"
+	// MissingParam is the error name for missing required request
+	// parameter errors.
+	MissingParam = "missing_param"
 	// InvalidEnumValue is the error name for invalid enum value errors.
 	InvalidEnumValue = "invalid_enum_value"
 	// InvalidFormat is the error name for invalid format errors.
@@ -57,6 +104,17 @@ const (
 	InvalidRange = "invalid_range"
 	// InvalidLength is the error name for invalid length errors.
 	InvalidLength = "invalid_length"
+	// InvalidCustom is the error name for failed custom validations.
+	InvalidCustom = "invalid_custom"
+	// InvalidEqualFields is the error name for fields that must be equal
+	// but are not.
+	InvalidEqualFields = "invalid_equal_fields"
+	// InvalidMutuallyExclusive is the error name for mutually exclusive
+	// fields that are set together.
+	InvalidMutuallyExclusive = "invalid_mutually_exclusive"
+	// MissingRequiredOneOf is the error name for groups of fields of which
+	// none is set but at least one is required.
+	MissingRequiredOneOf = "missing_required_one_of"
 )
 
 // NewServiceError creates an error.
@@ -127,8 +185,45 @@ func InvalidFieldTypeError(name string, val interface{}, expected string) error
 // MissingFieldError is the error produced by the generated code when a payload
 // is missing a required field.
 func MissingFieldError(name, context string) error {
-	return withField(name, PermanentError(
+	err := withField(name, PermanentError(
 		MissingField, "%q is missing from %s", name, context))
+	if context != "" {
+		err.path = context + "." + name
+	}
+	return err
+}
+
+// MissingParamError is the error produced by the generated code when a request
+// is missing a required parameter. context describes where the parameter is
+// read from, e.g. "query string". Merging missing parameter errors produces a
+// single error whose message lists all the missing parameters.
+func MissingParamError(name, context string) error {
+	err := withField(name, PermanentError(
+		MissingParam, "missing required parameter %q", name))
+	if context != "" {
+		err.path = context + "." + name
+	}
+	return err
+}
+
+// RenameMissingParamError returns a copy of err with the given name if err
+// only reports missing required parameters, err otherwise. Errors merging
+// missing parameters with other validation errors keep their name. The
+// generated request decoders use it to report missing parameters using the
+// method bad request error.
+func RenameMissingParamError(err error, name string) error {
+	var e *ServiceError
+	if !errors.As(err, &e) {
+		return err
+	}
+	for _, h := range e.History() {
+		if h.Name != MissingParam {
+			return err
+		}
+	}
+	renamed := *e
+	renamed.Name = name
+	return &renamed
 }
 
 // InvalidEnumValueError is the error produced by the generated code when the
@@ -183,6 +278,155 @@ func InvalidLengthError(name string, target interface{}, ln, value int, min bool
 		InvalidLength, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln))
 }
 
+// InvalidCustomError is the error produced by the generated code when a custom
+// validation function returns an error.
+func InvalidCustomError(name, validation string, target interface{}, customError error) error {
+	return withField(name, PermanentError(
+		InvalidCustom, "%s failed the %s validation with value %#v, %s", name, validation, target, customError.Error()))
+}
+
+// InvalidEqualFieldsError is the error produced by the generated code when
+// the values of two fields that must be equal differ or when only one of them
+// is set. The values are not included in the message as the fields typically
+// hold secrets such as passwords.
+func InvalidEqualFieldsError(name, other string) error {
+	return withField(name, PermanentError(
+		InvalidEqualFields, "%s must be equal to %s", name, other))
+}
+
+// InvalidMutuallyExclusiveError is the error produced by the generated code when
+// more than one of mutually exclusive fields are set. names lists the mutually
+// exclusive fields and set the fields that are set. The error field is the
+// first field that is set.
+func InvalidMutuallyExclusiveError(names, set []string) error {
+	return withField(set[0], PermanentError(
+		InvalidMutuallyExclusive, "at most one of %s may be set but got %s",
+		strings.Join(names, ", "), strings.Join(set, ", ")))
+}
+
+// MissingRequiredOneOfError is the error produced by the generated code when
+// none of the fields of which at least one is required is set. The error field
+// is the first field of the group.
+func MissingRequiredOneOfError(names []string) error {
+	return withField(names[0], PermanentError(
+		MissingRequiredOneOf, "one of %s must be set", strings.Join(names, ", ")))
+}
+
+// NestErrors updates the paths of the validation errors merged in err so that
+// they are relative to path. The generated code uses NestErrors to merge the
+// errors returned when validating a nested value, path is the path of the
+// nested value in the enclosing value, e.g. "body.address". The first segment
+// of the error paths (e.g. "body" in "body.zip") is replaced with path.
+func NestErrors(err error, path string) error {
+	e, ok := err.(*ServiceError)
+	if !ok {
+		return err
+	}
+	e.path = nestPath(e.path, path)
+	for i := range e.history {
+		e.history[i].path = nestPath(e.history[i].path, path)
+	}
+	return e
+}
+
+// ElemErrors updates the paths of the validation errors merged in err so that
+// they reference the array element or map value identified by key. path is the
+// path of the array or map, the generated code uses placeholders such as
+// "body.items[*]" when validating the elements and ElemErrors replaces the
+// placeholder with the actual index or key, e.g. "body.items[2]".
+func ElemErrors(err error, path string, key interface{}) error {
+	e, ok := err.(*ServiceError)
+	if !ok {
+		return err
+	}
+	k := fmt.Sprint(key)
+	e.path = elemPath(e.path, path, k)
+	for i := range e.history {
+		e.history[i].path = elemPath(e.history[i].path, path, k)
+	}
+	return e
+}
+
+// WithI18nKey sets the localization key of the validation errors merged in err
+// that do not have one already. The generated code uses WithI18nKey to attach
+// the "i18n:key" meta of an attribute to the errors returned when validating
+// the attribute so that the error messages can be translated. WithI18nKey
+// returns err unchanged if err is nil or not a ServiceError.
+func WithI18nKey(err error, key string) error {
+	e, ok := err.(*ServiceError)
+	if !ok {
+		return err
+	}
+	if e.I18nKey == "" {
+		e.I18nKey = key
+	}
+	for i := range e.history {
+		if e.history[i].I18nKey == "" {
+			e.history[i].I18nKey = key
+		}
+	}
+	return e
+}
+
+// FieldErrors returns the validation errors merged in e that relate to a field
+// with the JSON pointers of the fields. It returns nil if e does not contain
+// any field validation error.
+func (e *ServiceError) FieldErrors() []*FieldError {
+	var ferrs []*FieldError
+	for _, h := range e.History() {
+		if h.path == "" {
+			continue
+		}
+		ferrs = append(ferrs, &FieldError{Pointer: FieldPointer(h.path), Rule: h.Name, Message: h.Message, I18nKey: h.I18nKey})
+	}
+	return ferrs
+}
+
+// FieldPointer returns the JSON pointer (RFC 6901) corresponding to the given
+// validation error path. The first segment of the path names the validated
+// value (e.g. "body") and is omitted unless it is the only segment, so that
+// "body.items[2].name" produces "/items/2/name" and "id" produces "/id".
+func FieldPointer(path string) string {
+	var (
+		segments []string
+		current  strings.Builder
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, current.String())
+			current.Reset()
+		}
+	}
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				current.WriteString(path[i+1:])
+				i = len(path)
+				continue
+			}
+			segments = append(segments, path[i+1:i+end])
+			i += end
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	if len(segments) > 1 {
+		segments = segments[1:]
+	}
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(s))
+	}
+	return b.String()
+}
+
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
 // error identifier.
 func NewErrorID() string {
@@ -231,6 +475,9 @@ func MergeErrors(err, other error) error {
 	e.err = multierror.Append(e.err, o.err)
 
 	e.Message = e.Message + "; " + o.Message
+	if msg, ok := missingParamsMessage(e.history); ok {
+		e.Message = msg
+	}
 	e.Timeout = e.Timeout && o.Timeout
 	e.Temporary = e.Temporary && o.Temporary
 	e.Fault = e.Fault && o.Fault
@@ -238,6 +485,24 @@ func MergeErrors(err, other error) error {
 	return e
 }
 
+// Lookup returns the description of err if err has a name listed in the
+// catalog, false otherwise. The name of err is the name returned by
+// GoaErrorName if err or an error it wraps implements GoaErrorNamer.
+func (c ErrorCatalog) Lookup(err error) (ErrorInfo, bool) {
+	var namer GoaErrorNamer
+	if !errors.As(err, &namer) {
+		return ErrorInfo{}, false
+	}
+	info, ok := c[namer.GoaErrorName()]
+	return info, ok
+}
+
+// Retryable returns true if err is listed in the catalog as a temporary error.
+func (c ErrorCatalog) Retryable(err error) bool {
+	info, ok := c.Lookup(err)
+	return ok && info.Temporary
+}
+
 // History returns the history of error revisions, ignoring the result of any merges.
 func (e ServiceError) History() []ServiceError {
 	if len(e.history) > 0 {
@@ -260,11 +525,61 @@ func (e *ServiceError) GoaErrorName() string { return e.ErrorName() }
 
 func (e *ServiceError) Unwrap() error { return e.err }
 
+// missingParamsMessage returns the message of an error merging the errors in
+// history that lists the missing parameters in a single sentence followed by
+// the messages of the other errors. It returns false if history contains less
+// than two missing parameter errors.
+func missingParamsMessage(history []ServiceError) (string, bool) {
+	var (
+		params []string
+		others []string
+	)
+	for _, h := range history {
+		if h.Name == MissingParam && h.Field != nil {
+			params = append(params, fmt.Sprintf("%q", *h.Field))
+			continue
+		}
+		others = append(others, h.Message)
+	}
+	if len(params) < 2 {
+		return "", false
+	}
+	msgs := append([]string{"missing required parameters " + strings.Join(params, ", ")}, others...)
+	return strings.Join(msgs, "; "), true
+}
+
 func withField(field string, err *ServiceError) *ServiceError {
 	err.Field = &field
+	err.path = field
 	return err
 }
 
+// nestPath replaces the first segment of the error path p with prefix.
+func nestPath(p, prefix string) string {
+	if p == "" {
+		return p
+	}
+	idx := strings.IndexAny(p, ".[")
+	if idx < 0 {
+		return prefix
+	}
+	return prefix + p[idx:]
+}
+
+// elemPath replaces the element placeholder that follows prefix in the error
+// path p with key.
+func elemPath(p, prefix, key string) string {
+	if !strings.HasPrefix(p, prefix+"[") {
+		return p
+	}
+	rest := p[len(prefix):]
+	end := strings.IndexByte(rest, ']')
+	if end < 0 {
+		return p
+	}
+	return prefix + "[" + key + rest[end:]
+}
+
 func newError(name string, timeout, temporary, fault bool, format string, v ...interface{}) *ServiceError {
 	return &ServiceError{
 		Name:      name,
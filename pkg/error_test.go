@@ -0,0 +1,240 @@
+package goa
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFieldErrors(t *testing.T) {
+	validateItem := func(name *string) (err error) {
+		if name == nil {
+			err = MergeErrors(err, MissingFieldError("name", "body"))
+		}
+		return
+	}
+	validateAddress := func(zip string) (err error) {
+		if len(zip) < 5 {
+			err = MergeErrors(err, InvalidLengthError("body.zip", zip, len(zip), 5, true))
+		}
+		return
+	}
+	name := "item"
+	var (
+		items  = []*string{&name, nil}
+		matrix = [][]int{{1}, {3, 1}}
+		err    error
+	)
+	err = MergeErrors(err, MissingFieldError("id", "body"))
+	if err2 := validateAddress("123"); err2 != nil {
+		err = MergeErrors(err, NestErrors(err2, "body.address"))
+	}
+	for i, e := range items {
+		err = MergeErrors(err, ElemErrors(func() (err error) {
+			if err2 := validateItem(e); err2 != nil {
+				err = MergeErrors(err, NestErrors(err2, "body.items[*]"))
+			}
+			return
+		}(), "body.items", i))
+	}
+	for i, e := range matrix {
+		err = MergeErrors(err, ElemErrors(func() (err error) {
+			for j, e := range e {
+				err = MergeErrors(err, ElemErrors(func() (err error) {
+					if !(e == 1 || e == 2) {
+						err = MergeErrors(err, InvalidEnumValueError("body.matrix[*][*]", e, []interface{}{1, 2}))
+					}
+					return
+				}(), "body.matrix[*]", j))
+			}
+			return
+		}(), "body.matrix", i))
+	}
+
+	var serr *ServiceError
+	if !errors.As(err, &serr) {
+		t.Fatalf("got error %T, expected *ServiceError", err)
+	}
+	expected := []struct{ Pointer, Rule string }{
+		{"/id", MissingField},
+		{"/address/zip", InvalidLength},
+		{"/items/1/name", MissingField},
+		{"/matrix/1/0", InvalidEnumValue},
+	}
+	ferrs := serr.FieldErrors()
+	if len(ferrs) != len(expected) {
+		t.Fatalf("got %d field errors, expected %d", len(ferrs), len(expected))
+	}
+	for i, ferr := range ferrs {
+		if ferr.Pointer != expected[i].Pointer {
+			t.Errorf("field error %d: got pointer %q, expected %q", i, ferr.Pointer, expected[i].Pointer)
+		}
+		if ferr.Rule != expected[i].Rule {
+			t.Errorf("field error %d: got rule %q, expected %q", i, ferr.Rule, expected[i].Rule)
+		}
+		if ferr.Message == "" {
+			t.Errorf("field error %d: got empty message", i)
+		}
+	}
+}
+
+func TestFieldPointer(t *testing.T) {
+	cases := map[string]struct {
+		path     string
+		expected string
+	}{
+		"empty":    {"", ""},
+		"single":   {"id", "/id"},
+		"nested":   {"body.address.zip", "/address/zip"},
+		"index":    {"body.items[2].name", "/items/2/name"},
+		"key":      {"body.tags[a.b]", "/tags/a.b"},
+		"escaped":  {"body.tags[a/b~c]", "/tags/a~1b~0c"},
+		"unclosed": {"body.items[2", "/items/2"},
+	}
+	for k, tc := range cases {
+		if actual := FieldPointer(tc.path); actual != tc.expected {
+			t.Errorf("%s: got %q, expected %q", k, actual, tc.expected)
+		}
+	}
+}
+
+func TestWithI18nKey(t *testing.T) {
+	var err error
+	err = MergeErrors(err, WithI18nKey(MissingFieldError("name", "body"), "user.name.required"))
+	err = MergeErrors(err, WithI18nKey(InvalidLengthError("body.zip", "123", 3, 5, true), "user.zip.length"))
+	err = MergeErrors(err, MissingFieldError("id", "body"))
+	if WithI18nKey(nil, "key") != nil {
+		t.Error("got non-nil error for nil error")
+	}
+	plain := errors.New("plain")
+	if WithI18nKey(plain, "key") != plain {
+		t.Error("got different error for non service error")
+	}
+
+	var serr *ServiceError
+	if !errors.As(err, &serr) {
+		t.Fatalf("got error %T, expected *ServiceError", err)
+	}
+	expected := []struct{ Pointer, Key, Message string }{
+		{"/name", "user.name.required", `"name" is missing from body`},
+		{"/zip", "user.zip.length", `length of body.zip must be greater or equal than 5 but got value "123" (len=3)`},
+		{"/id", "", `"id" is missing from body`},
+	}
+	ferrs := serr.FieldErrors()
+	if len(ferrs) != len(expected) {
+		t.Fatalf("got %d field errors, expected %d", len(ferrs), len(expected))
+	}
+	for i, ferr := range ferrs {
+		if ferr.Pointer != expected[i].Pointer {
+			t.Errorf("field error %d: got pointer %q, expected %q", i, ferr.Pointer, expected[i].Pointer)
+		}
+		if ferr.I18nKey != expected[i].Key {
+			t.Errorf("field error %d: got key %q, expected %q", i, ferr.I18nKey, expected[i].Key)
+		}
+		if ferr.Message != expected[i].Message {
+			t.Errorf("field error %d: got message %q, expected %q", i, ferr.Message, expected[i].Message)
+		}
+	}
+}
+
+func TestMissingParamErrors(t *testing.T) {
+	cases := map[string]struct {
+		errs     []error
+		expected string
+	}{
+		"single":   {[]error{MissingParamError("a", "query string")}, `missing required parameter "a"`},
+		"multiple": {[]error{MissingParamError("a", "query string"), MissingParamError("b", "query string")}, `missing required parameters "a", "b"`},
+		"mixed":    {[]error{MissingParamError("a", "query string"), MissingFieldError("b", "header")}, `missing required parameter "a"; "b" is missing from header`},
+		"grouped":  {[]error{MissingParamError("a", "query string"), MissingFieldError("b", "header"), MissingParamError("c", "query string")}, `missing required parameters "a", "c"; "b" is missing from header`},
+	}
+	for k, tc := range cases {
+		var err error
+		for _, e := range tc.errs {
+			err = MergeErrors(err, e)
+		}
+		var serr *ServiceError
+		if !errors.As(err, &serr) {
+			t.Fatalf("%s: got error %T, expected *ServiceError", k, err)
+		}
+		if serr.Message != tc.expected {
+			t.Errorf("%s: got message %q, expected %q", k, serr.Message, tc.expected)
+		}
+		if serr.Name != MissingParam {
+			t.Errorf("%s: got name %q, expected %q", k, serr.Name, MissingParam)
+		}
+		if ferrs := serr.FieldErrors(); len(ferrs) != len(tc.errs) {
+			t.Errorf("%s: got %d field errors, expected %d", k, len(ferrs), len(tc.errs))
+		}
+	}
+}
+
+type namedError string
+
+func (e namedError) Error() string        { return string(e) }
+func (e namedError) GoaErrorName() string { return string(e) }
+
+func TestErrorCatalog(t *testing.T) {
+	catalog := ErrorCatalog{
+		"timeout":   {Name: "timeout", Timeout: true, Temporary: true},
+		"not_found": {Name: "not_found"},
+	}
+	cases := map[string]struct {
+		err       error
+		found     bool
+		retryable bool
+	}{
+		"service-error": {PermanentTimeoutError("timeout", "timed out"), true, true},
+		"custom-error":  {namedError("not_found"), true, false},
+		"wrapped":       {fmt.Errorf("wrapped: %w", namedError("timeout")), true, true},
+		"unknown":       {PermanentError("unknown", "unknown"), false, false},
+		"not-named":     {errors.New("boom"), false, false},
+	}
+	for k, tc := range cases {
+		info, ok := catalog.Lookup(tc.err)
+		if ok != tc.found {
+			t.Errorf("%s: got found %v, expected %v", k, ok, tc.found)
+		}
+		if ok && info != catalog[info.Name] {
+			t.Errorf("%s: got info %+v, expected %+v", k, info, catalog[info.Name])
+		}
+		if r := catalog.Retryable(tc.err); r != tc.retryable {
+			t.Errorf("%s: got retryable %v, expected %v", k, r, tc.retryable)
+		}
+	}
+}
+
+func TestRenameMissingParamError(t *testing.T) {
+	other := errors.New("other")
+	cases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"missing params": {MergeErrors(MissingParamError("a", "query string"), MissingParamError("b", "query string")), "bad_request"},
+		"missing field":  {MissingFieldError("a", "header"), MissingField},
+		"merged":         {MergeErrors(InvalidFieldTypeError("a", "x", "integer"), MissingParamError("b", "query string")), InvalidFieldType},
+		"merged last":    {MergeErrors(MissingParamError("a", "query string"), InvalidEnumValueError("b", "x", []interface{}{"y"})), MissingParam},
+		"other error":    {other, ""},
+	}
+	for k, tc := range cases {
+		err := RenameMissingParamError(tc.err, "bad_request")
+		var serr *ServiceError
+		if !errors.As(err, &serr) {
+			if err != tc.err {
+				t.Errorf("%s: got error %v, expected %v", k, err, tc.err)
+			}
+			continue
+		}
+		if serr.Name != tc.expected {
+			t.Errorf("%s: got name %q, expected %q", k, serr.Name, tc.expected)
+		}
+		if serr.Message != tc.err.Error() {
+			t.Errorf("%s: got message %q, expected %q", k, serr.Message, tc.err.Error())
+		}
+	}
+	var serr *ServiceError
+	original := MissingParamError("a", "query string")
+	_ = RenameMissingParamError(original, "bad_request")
+	if errors.As(original, &serr) && serr.Name != MissingParam {
+		t.Errorf("got original error name %q, expected %q", serr.Name, MissingParam)
+	}
+}
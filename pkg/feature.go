@@ -0,0 +1,23 @@
+package goa
+
+import "context"
+
+type (
+	// FeatureChecker is the interface implemented by the feature flag
+	// providers used by the generated endpoints of the methods gated with
+	// the FeatureGate DSL.
+	FeatureChecker interface {
+		// FeatureEnabled returns true if the feature with the given
+		// name is enabled for the request with the given context.
+		FeatureEnabled(ctx context.Context, name string) bool
+	}
+
+	// FeatureCheckerFunc is an adapter that makes it possible to use
+	// ordinary functions as feature checkers.
+	FeatureCheckerFunc func(ctx context.Context, name string) bool
+)
+
+// FeatureEnabled calls f(ctx, name).
+func (f FeatureCheckerFunc) FeatureEnabled(ctx context.Context, name string) bool {
+	return f(ctx, name)
+}
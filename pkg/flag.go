@@ -0,0 +1,214 @@
+package goa
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type (
+	// jsonFlag is the flag.Value used by JSONFlag.
+	jsonFlag struct {
+		p *string
+	}
+
+	// arrayFlag is the flag.Value used by ArrayFlag.
+	arrayFlag struct {
+		p      *string
+		quote  bool
+		values []string
+		json   bool
+	}
+
+	// secretFlag is the flag.Value used by SecretFlag.
+	secretFlag struct {
+		p        *string
+		env      string
+		required bool
+		set      bool
+	}
+)
+
+// JSONFlag defines a flag with the given name, default value and usage string
+// holding a JSON value. The value "@path" is replaced with the content of the
+// file at path and the value "@-" with the content read from the standard
+// input so that complex values do not have to be given on the command line.
+// The return value is the address of the string variable that stores the
+// value of the flag.
+func JSONFlag(fs *flag.FlagSet, name, value, usage string) *string {
+	p := new(string)
+	*p = value
+	fs.Var(&jsonFlag{p: p}, name, usage)
+	return p
+}
+
+// ArrayFlag defines a flag with the given name, default value and usage string
+// holding a JSON array of primitive values. The flag may be repeated in which
+// case the array is built from the values given to each flag, the values are
+// quoted if quote is true (i.e. the array elements are strings). The flag
+// also accepts a single JSON array or a reference to a file as described in
+// JSONFlag. The return value is the address of the string variable that
+// stores the JSON array.
+func ArrayFlag(fs *flag.FlagSet, name, value, usage string, quote bool) *string {
+	p := new(string)
+	*p = value
+	fs.Var(&arrayFlag{p: p, quote: quote}, name, usage)
+	return p
+}
+
+// SecretFlag defines a flag with the given name, default value and usage
+// string holding a secret such as a password or a token. Secrets given on the
+// command line may be visible to other users of the system and are recorded
+// in the shell history so ReadSecretFlags reads the values of the secret flags
+// that are not set from the environment variable env instead or prompts for
+// them if required is true. The return value is the address of the string
+// variable that stores the value of the flag.
+func SecretFlag(fs *flag.FlagSet, name, value, usage, env string, required bool) *string {
+	p := new(string)
+	*p = value
+	fs.Var(&secretFlag{p: p, env: env, required: required}, name, usage)
+	return p
+}
+
+// ReadSecretFlags sets the values of the flags defined with SecretFlag that
+// are not set on the command line. The value is read from the flag environment
+// variable if set. Otherwise ReadSecretFlags prompts for the values of the
+// required flags on the standard error and reads them from the standard input
+// if it is a terminal.
+func ReadSecretFlags(fs *flag.FlagSet) error {
+	var interactive bool
+	if fi, err := os.Stdin.Stat(); err == nil {
+		interactive = fi.Mode()&os.ModeCharDevice != 0
+	}
+	return readSecretFlags(fs, os.Stdin, os.Stderr, interactive)
+}
+
+// String returns the flag value.
+func (f *jsonFlag) String() string {
+	if f.p == nil {
+		return ""
+	}
+	return *f.p
+}
+
+// Set sets the flag value loading the content of the file it references if
+// any.
+func (f *jsonFlag) Set(v string) error {
+	val, err := loadFlagValue(v)
+	if err != nil {
+		return err
+	}
+	*f.p = val
+	return nil
+}
+
+// String returns the flag value.
+func (f *arrayFlag) String() string {
+	if f.p == nil {
+		return ""
+	}
+	return *f.p
+}
+
+// Set adds v to the array or sets the array if v is a JSON array or a file
+// reference.
+func (f *arrayFlag) Set(v string) error {
+	if f.json {
+		return fmt.Errorf("cannot repeat a flag whose value is a JSON array")
+	}
+	if len(f.values) == 0 && (strings.HasPrefix(v, "@") || strings.HasPrefix(v, "[") && json.Valid([]byte(v))) {
+		val, err := loadFlagValue(v)
+		if err != nil {
+			return err
+		}
+		*f.p = val
+		f.json = true
+		return nil
+	}
+	elem := v
+	if f.quote {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		elem = string(b)
+	}
+	f.values = append(f.values, elem)
+	*f.p = "[" + strings.Join(f.values, ",") + "]"
+	return nil
+}
+
+// String returns the flag value.
+func (f *secretFlag) String() string {
+	if f.p == nil {
+		return ""
+	}
+	return *f.p
+}
+
+// Set sets the flag value.
+func (f *secretFlag) Set(v string) error {
+	*f.p = v
+	f.set = true
+	return nil
+}
+
+// readSecretFlags implements ReadSecretFlags reading the prompted values from
+// in and writing the prompts to out.
+func readSecretFlags(fs *flag.FlagSet, in io.Reader, out io.Writer, interactive bool) error {
+	var (
+		r   *bufio.Reader
+		err error
+	)
+	fs.VisitAll(func(fl *flag.Flag) {
+		sf, ok := fl.Value.(*secretFlag)
+		if !ok || sf.set || err != nil {
+			return
+		}
+		if v, ok := os.LookupEnv(sf.env); ok {
+			*sf.p = v
+			return
+		}
+		if !sf.required || !interactive {
+			return
+		}
+		if r == nil {
+			r = bufio.NewReader(in)
+		}
+		fmt.Fprintf(out, "%s: ", fl.Name)
+		var line string
+		line, err = r.ReadString('\n')
+		if err != nil && !(err == io.EOF && line != "") {
+			err = fmt.Errorf("failed to read value of flag %q: %w", fl.Name, err)
+			return
+		}
+		err = nil
+		*sf.p = strings.TrimRight(line, "\r\n")
+	})
+	return err
+}
+
+// loadFlagValue returns the content of the file referenced by v if v starts
+// with "@", the content of the standard input if v is "@-" and v otherwise.
+func loadFlagValue(v string) (string, error) {
+	if !strings.HasPrefix(v, "@") {
+		return v, nil
+	}
+	var (
+		b   []byte
+		err error
+	)
+	if v == "@-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(v[1:])
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read flag value: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
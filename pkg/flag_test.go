@@ -0,0 +1,125 @@
+package goa
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONFlag(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "body.json")
+	if err := os.WriteFile(file, []byte("{\"a\":1}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		Name     string
+		Args     []string
+		Expected string
+		Error    bool
+	}{
+		{"default", nil, "{}", false},
+		{"value", []string{"-body", `{"b":2}`}, `{"b":2}`, false},
+		{"file", []string{"-body", "@" + file}, `{"a":1}`, false},
+		{"missing-file", []string{"-body", "@" + file + ".missing"}, "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+			p := JSONFlag(fs, "body", "{}", "body")
+			err := fs.Parse(c.Args)
+			if c.Error {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if *p != c.Expected {
+				t.Errorf("got %q, expected %q", *p, c.Expected)
+			}
+		})
+	}
+}
+
+func TestArrayFlag(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Quote    bool
+		Args     []string
+		Expected string
+		Error    bool
+	}{
+		{"default", true, nil, "REQUIRED", false},
+		{"repeated-strings", true, []string{"-tags", "a", "-tags", `b"c`}, `["a","b\"c"]`, false},
+		{"repeated-ints", false, []string{"-tags", "1", "-tags", "2"}, "[1,2]", false},
+		{"json-array", true, []string{"-tags", `["a","b"]`}, `["a","b"]`, false},
+		{"string-bracket", true, []string{"-tags", "[a"}, `["[a"]`, false},
+		{"repeated-json-array", false, []string{"-tags", "[1]", "-tags", "2"}, "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(new(bytes.Buffer))
+			p := ArrayFlag(fs, "tags", "REQUIRED", "tags", c.Quote)
+			err := fs.Parse(c.Args)
+			if c.Error {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if *p != c.Expected {
+				t.Errorf("got %q, expected %q", *p, c.Expected)
+			}
+		})
+	}
+}
+
+func TestReadSecretFlags(t *testing.T) {
+	t.Setenv("TEST_TOKEN", "env-token")
+	cases := []struct {
+		Name        string
+		Args        []string
+		Input       string
+		Interactive bool
+		Token       string
+		Password    string
+		Prompt      string
+	}{
+		{"flags", []string{"-token", "t", "-password", "p"}, "", true, "t", "p", ""},
+		{"env-and-prompt", nil, "secret\n", true, "env-token", "secret", "password: "},
+		{"not-interactive", nil, "secret\n", false, "env-token", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			token := SecretFlag(fs, "token", "", "token", "TEST_TOKEN", false)
+			password := SecretFlag(fs, "password", "", "password", "TEST_PASSWORD", true)
+			if err := fs.Parse(c.Args); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var out bytes.Buffer
+			if err := readSecretFlags(fs, strings.NewReader(c.Input), &out, c.Interactive); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if *token != c.Token {
+				t.Errorf("got token %q, expected %q", *token, c.Token)
+			}
+			if *password != c.Password {
+				t.Errorf("got password %q, expected %q", *password, c.Password)
+			}
+			if out.String() != c.Prompt {
+				t.Errorf("got prompt %q, expected %q", out.String(), c.Prompt)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package goa
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable holds the value of an attribute defined with the Nullable DSL. It
+// distinguishes attributes that are absent from attributes explicitly set to
+// null, making it possible to implement JSON Merge Patch (RFC 7396) semantics:
+//
+//   - Set is false: the attribute is absent, the resource field must be left
+//     unchanged.
+//   - Set is true and Null is true: the attribute is null, the resource field
+//     must be cleared.
+//   - Set is true and Null is false: the attribute holds Value.
+//
+// Nullable implements json.Marshaler and json.Unmarshaler. encoding/json
+// cannot omit struct fields so the generated HTTP body types implement
+// json.Marshaler and use OmitJSONKeys to leave absent attributes out of the
+// encoded objects.
+type Nullable[T any] struct {
+	// Value is the attribute value, only meaningful if Set is true and Null
+	// is false.
+	Value T
+	// Set is true if the attribute is present.
+	Set bool
+	// Null is true if the attribute is explicitly set to null.
+	Null bool
+}
+
+// NullableOf returns a Nullable holding v.
+func NullableOf[T any](v T) Nullable[T] {
+	return Nullable[T]{Value: v, Set: true}
+}
+
+// Null returns a Nullable explicitly set to null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+
+// HasValue returns true if n is set and not null.
+func (n Nullable[T]) HasValue() bool {
+	return n.Set && !n.Null
+}
+
+// IsZero returns true if n is absent.
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+
+// MarshalJSON encodes n as null if it is absent or null and as the JSON
+// encoding of its value otherwise.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.HasValue() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// UnmarshalJSON decodes data into n. encoding/json only calls UnmarshalJSON
+// for attributes present in the decoded object so n is always set afterwards.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	n.Value = zero
+	n.Set = true
+	n.Null = bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+	if n.Null {
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+// OmitJSONKeys removes the given top-level keys from the JSON object encoded in
+// data. It preserves the order of the remaining keys. Generated code uses it to
+// omit absent Nullable attributes when encoding HTTP bodies.
+func OmitJSONKeys(data []byte, keys ...string) ([]byte, error) {
+	if len(keys) == 0 {
+		return data, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		if omitted(key, keys) {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// omitted returns true if key is one of keys.
+func omitted(key string, keys []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
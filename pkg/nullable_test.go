@@ -0,0 +1,145 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullableUnmarshalJSON(t *testing.T) {
+	type body struct {
+		Name Nullable[string] `json:"name"`
+	}
+	cases := []struct {
+		Name      string
+		JSON      string
+		Set, Null bool
+		Value     string
+	}{
+		{"absent", `{}`, false, false, ""},
+		{"null", `{"name":null}`, true, true, ""},
+		{"value", `{"name":"foo"}`, true, false, "foo"},
+		{"empty", `{"name":""}`, true, false, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var b body
+			if err := json.Unmarshal([]byte(c.JSON), &b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if b.Name.Set != c.Set {
+				t.Errorf("got Set %v, expected %v", b.Name.Set, c.Set)
+			}
+			if b.Name.Null != c.Null {
+				t.Errorf("got Null %v, expected %v", b.Name.Null, c.Null)
+			}
+			if b.Name.Value != c.Value {
+				t.Errorf("got Value %q, expected %q", b.Name.Value, c.Value)
+			}
+		})
+	}
+}
+
+func TestNullableUnmarshalJSONInvalid(t *testing.T) {
+	var n Nullable[int]
+	if err := json.Unmarshal([]byte(`"foo"`), &n); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestNullableMarshalJSON(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Nullable Nullable[int]
+		Expected string
+	}{
+		{"absent", Nullable[int]{}, `null`},
+		{"null", Null[int](), `null`},
+		{"value", NullableOf(42), `42`},
+		{"zero", NullableOf(0), `0`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := json.Marshal(c.Nullable)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(b) != c.Expected {
+				t.Errorf("got %s, expected %s", b, c.Expected)
+			}
+		})
+	}
+}
+
+// nullableBody mimics the HTTP body types generated for nullable attributes.
+type nullableBody struct {
+	ID   string           `json:"id"`
+	Name Nullable[string] `json:"name,omitempty"`
+}
+
+func (body nullableBody) MarshalJSON() ([]byte, error) {
+	type plain nullableBody
+	data, err := json.Marshal(plain(body))
+	if err != nil {
+		return nil, err
+	}
+	var unset []string
+	if !body.Name.Set {
+		unset = append(unset, "name")
+	}
+	return OmitJSONKeys(data, unset...)
+}
+
+func TestNullableRoundTrip(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Nullable Nullable[string]
+		Expected string
+	}{
+		{"absent", Nullable[string]{}, `{"id":"1"}`},
+		{"null", Null[string](), `{"id":"1","name":null}`},
+		{"value", NullableOf("foo"), `{"id":"1","name":"foo"}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := json.Marshal(nullableBody{ID: "1", Name: c.Nullable})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(b) != c.Expected {
+				t.Errorf("got %s, expected %s", b, c.Expected)
+			}
+			var decoded nullableBody
+			if err := json.Unmarshal(b, &decoded); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decoded.Name != c.Nullable {
+				t.Errorf("got %+v, expected %+v", decoded.Name, c.Nullable)
+			}
+		})
+	}
+}
+
+func TestOmitJSONKeys(t *testing.T) {
+	cases := []struct {
+		Name     string
+		JSON     string
+		Keys     []string
+		Expected string
+	}{
+		{"no-key", `{"b":1,"a":2}`, nil, `{"b":1,"a":2}`},
+		{"keeps-order", `{"c":1,"b":{"x":[1,2]},"a":null}`, []string{"a"}, `{"c":1,"b":{"x":[1,2]}}`},
+		{"all-keys", `{"a":1}`, []string{"a"}, `{}`},
+		{"nested-key", `{"b":{"a":1}}`, []string{"a"}, `{"b":{"a":1}}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			b, err := OmitJSONKeys([]byte(c.JSON), c.Keys...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(b) != c.Expected {
+				t.Errorf("got %s, expected %s", b, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package goa
+
+import (
+	"strconv"
+	"time"
+)
+
+// SLO describes the service level objectives of a method as defined in the
+// design with the SLO DSL. The generated service packages list the objectives
+// of their methods in the MethodSLOs variable.
+type SLO struct {
+	// LatencyP99 is the target 99th percentile latency of the method
+	// requests, zero if not set.
+	LatencyP99 time.Duration
+	// SuccessRate is the target percentage of successful requests, zero if
+	// not set.
+	SuccessRate float64
+}
+
+// Labels returns the objectives formatted as metric labels: "slo_latency_p99"
+// holds the latency objective formatted with time.Duration.String and
+// "slo_success_rate" the success rate objective. The objectives that are not
+// set are omitted.
+func (s SLO) Labels() map[string]string {
+	labels := make(map[string]string, 2)
+	if s.LatencyP99 > 0 {
+		labels["slo_latency_p99"] = s.LatencyP99.String()
+	}
+	if s.SuccessRate > 0 {
+		labels["slo_success_rate"] = strconv.FormatFloat(s.SuccessRate, 'f', -1, 64)
+	}
+	return labels
+}
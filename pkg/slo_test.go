@@ -0,0 +1,26 @@
+package goa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSLOLabels(t *testing.T) {
+	cases := []struct {
+		Name     string
+		SLO      SLO
+		Expected map[string]string
+	}{
+		{"all", SLO{LatencyP99: 200 * time.Millisecond, SuccessRate: 99.9}, map[string]string{"slo_latency_p99": "200ms", "slo_success_rate": "99.9"}},
+		{"latency", SLO{LatencyP99: 2 * time.Second}, map[string]string{"slo_latency_p99": "2s"}},
+		{"success rate", SLO{SuccessRate: 99}, map[string]string{"slo_success_rate": "99"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := c.SLO.Labels(); !reflect.DeepEqual(got, c.Expected) {
+				t.Errorf("got labels %v, expected %v", got, c.Expected)
+			}
+		})
+	}
+}
@@ -58,6 +58,10 @@ const (
 
 	// FormatRFC1123 describes RFC1123 date time values.
 	FormatRFC1123 = "rfc1123"
+
+	// FormatDuration describes duration values using the syntax accepted by
+	// the Go time.ParseDuration function.
+	FormatDuration = "duration"
 )
 
 var (
@@ -82,6 +86,7 @@ var (
 //     - "cidr": RFC4632 and RFC4291 CIDR notation IP address value
 //     - "regexp": Regular expression syntax accepted by RE2
 //     - "rfc1123": RFC1123 date time value
+//     - "duration": Go duration value such as "1h30m"
 func ValidateFormat(name string, val string, f Format) error {
 	var err error
 	switch f {
@@ -127,6 +132,8 @@ func ValidateFormat(name string, val string, f Format) error {
 		}
 	case FormatRFC1123:
 		_, err = time.Parse(time.RFC1123, val)
+	case FormatDuration:
+		_, err = time.ParseDuration(val)
 	default:
 		return fmt.Errorf("unknown format %#v", f)
 	}
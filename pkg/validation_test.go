@@ -45,6 +45,8 @@ func TestValidateFormat(t *testing.T) {
 		invalidJSON     = "{"
 		validRFC1123    = "Mon, 04 Jun 2017 23:52:05 MST"
 		invalidRFC1123  = "Mon 04 Jun 2017 23:52:05 MST"
+		validDuration   = "1h30m"
+		invalidDuration = "1 hour"
 	)
 	cases := map[string]struct {
 		name     string
@@ -92,6 +94,8 @@ func TestValidateFormat(t *testing.T) {
 		"invalid json":       {"invalidJSON", invalidJSON, FormatJSON, InvalidFormatError("invalidJSON", invalidJSON, FormatJSON, fmt.Errorf("invalid JSON"))},
 		"valid rfc1123":      {"validRFC1123", validRFC1123, FormatRFC1123, nil},
 		"invalid rfc1123":    {"invalidRFC1123", invalidRFC1123, FormatRFC1123, InvalidFormatError("invalidRFC1123", invalidRFC1123, FormatRFC1123, &time.ParseError{Layout: time.RFC1123, Value: invalidRFC1123, LayoutElem: ", ", ValueElem: invalidRFC1123[3:]})},
+		"valid duration":     {"validDuration", validDuration, FormatDuration, nil},
+		"invalid duration":   {"invalidDuration", invalidDuration, FormatDuration, InvalidFormatError("invalidDuration", invalidDuration, FormatDuration, fmt.Errorf("time: unknown unit \" hour\" in duration \"1 hour\""))},
 	}
 
 	for k, tc := range cases {
@@ -91,6 +91,14 @@ type (
 	// AuthJWTFunc is the function type that implements the JWT
 	// scheme of using a JWT token.
 	AuthJWTFunc func(ctx context.Context, token string, s *JWTScheme) (context.Context, error)
+
+	// MissingScopesError is the error returned when the scopes granted to a
+	// request do not contain all of the required scopes. Transports use it
+	// to tell apart authorization failures from authentication failures.
+	MissingScopesError struct {
+		// Scopes lists the missing scopes.
+		Scopes []string
+	}
 )
 
 // Validate returns a non-nil error if scopes does not contain all of
@@ -134,5 +142,53 @@ func validateScopes(expected, actual []string) error {
 	if len(missing) == 0 {
 		return nil
 	}
-	return fmt.Errorf("missing scopes: %s", strings.Join(missing, ", "))
+	return &MissingScopesError{Scopes: missing}
+}
+
+// Error returns the error message listing the missing scopes.
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("missing scopes: %s", strings.Join(e.Scopes, ", "))
+}
+
+// contextKey is the private type used to key the context values set by the
+// package.
+type contextKey int
+
+// scopesKey is the context key used to store the scopes granted to the
+// request.
+const scopesKey contextKey = iota + 1
+
+// WithScopes returns a copy of ctx holding the scopes granted to the request.
+// Authorization functions call WithScopes so that the generated code may
+// check the scopes required by the method. scopes may be a space delimited
+// string such as the value of the "scope" claim of a JWT, a slice of strings
+// or a slice of values holding strings such as the value of a "scp" claim
+// decoded from JSON. Other values do not grant any scope.
+func WithScopes(ctx context.Context, scopes interface{}) context.Context {
+	var granted []string
+	switch s := scopes.(type) {
+	case string:
+		granted = strings.Fields(s)
+	case []string:
+		granted = s
+	case []interface{}:
+		for _, v := range s {
+			if str, ok := v.(string); ok {
+				granted = append(granted, str)
+			}
+		}
+	}
+	return context.WithValue(ctx, scopesKey, granted)
+}
+
+// ContextScopes returns the scopes stored in ctx with WithScopes.
+func ContextScopes(ctx context.Context) []string {
+	scopes, _ := ctx.Value(scopesKey).([]string)
+	return scopes
+}
+
+// CheckScopes returns a non-nil error listing the missing scopes if the scopes
+// stored in ctx with WithScopes do not contain all of the required scopes.
+func CheckScopes(ctx context.Context, required []string) error {
+	return validateScopes(required, ContextScopes(ctx))
 }